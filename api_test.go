@@ -0,0 +1,44 @@
+package pave
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombinedAPI(t *testing.T) {
+	type Request struct {
+		Name string   `query:"name,omitempty" default:"" validate:"required"`
+		Page Opt[int] `query:"page,omitempty"`
+		Lat  float64  `query:"lat,omitempty" default:"0" validate:"lat"`
+		Tags []string `query:"tag,omitempty" default:""`
+	}
+
+	registry, err := NewParserRegistry(ParserRegistryOpts{Parsers: []Parser{NewHTTPRequestParser()}})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("GET", "http://example.com/?name=jane&page=2&tag=a&tag=b", nil)
+	require.NoError(t, err)
+	var dest Request
+	require.NoError(t, registry.Parse(req, &dest, true))
+	assert.Equal(t, "jane", dest.Name)
+	assert.Equal(t, Some(2), dest.Page)
+	assert.Equal(t, []string{"a", "b"}, dest.Tags)
+
+	// The validator of the validate package is installed
+	req, err = http.NewRequest("GET", "http://example.com/?lat=95", nil)
+	require.NoError(t, err)
+	err = registry.Parse(req, &Request{}, true)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.ErrorIs(t, err, ErrValueRequired)
+	assert.ErrorIs(t, err, ErrInvalidLatitude)
+
+	// Aliases are the types of the packages
+	var opt parser.Opt[int] = Some(1)
+	assert.Equal(t, Some(1), opt)
+	assert.ErrorIs(t, ValidateStruct(&Request{Lat: 91}), parser.ErrInvalidLatitude)
+}
@@ -41,8 +41,17 @@
 //
 // All parsers must implement the [Parser](https://pkg.go.dev/pave#SourceParser) interface,
 // which defines the methods required for parsing data from a source into a Validatable type.
+//
+// The implementation is split into the parser package (parsers, execution
+// chains, bindings and registries) and the validate package (the rules of
+// the validate tag). This package combines both: its declarations are
+// generated aliases of theirs, so that programs importing pave need not
+// know where a declaration lives, while programs that only parse can
+// import the parser package without the validation rules.
 package pave
 
+//go:generate go run ./internal/genapi
+
 /**
 PLANNING:
 - Add support for default values modifiers in tags, e.g., `default:"value"`. (DONEish, need to add support for automatic default value type conversion)
@@ -56,7 +65,7 @@ PLANNING:
 - Tag aliasing.
 - Global Tag String White/Blacklist registry
     - Allow per tag parse includes/excludes but also global excludes that can be set. (WIP)
-- Split Package into PAVE-Parser and PAVE-Validator
+- Split Package into PAVE-Parser and PAVE-Validator (DONE)
 - Implement Validation
 - Add support for custom parsers that can be registered with the Registry. (DONE)
 - Add support for custom validators that can be registered with the Registry.
@@ -0,0 +1,371 @@
+// Command loadtest is an example HTTP server wiring pave into net/http,
+// with pprof enabled, that doubles as a reproducible performance
+// baseline.
+//
+// Serve on :8080, with profiles under /debug/pprof/:
+//
+//	go run ./examples/loadtest
+//
+// Drive load against an in-process server for 5 minutes, checking that
+// the caches of the parser stay bounded:
+//
+//	go run ./examples/loadtest -soak 5m -workers 32
+//
+// The soak mode prints the throughput and cache sizes every -interval and
+// exits with status 1 if a cache outgrows its bound or a request fails
+// unexpectedly. Profiles can be taken while it runs, see -addr.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pave "github.com/SimonDaKappa/go-pave"
+	"github.com/SimonDaKappa/go-pave/paverespond"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Request Types
+///////////////////////////////////////////////////////////////////////////////
+
+type Address struct {
+	Street  string `json:"shipping.street" validate:"required"`
+	City    string `json:"shipping.city" validate:"required"`
+	Country string `json:"shipping.country" validate:"country"`
+}
+
+type CreateOrderRequest struct {
+	RequestID string     `header:"X-Request-Id,omitempty" default:"none"`
+	DryRun    bool       `query:"dry_run,omitempty" default:"false"`
+	Customer  string     `json:"customer" validate:"required"`
+	Quantity  int        `json:"quantity"`
+	Total     pave.Money `json:"total,currency=USD"`
+	Shipping  *Address
+}
+
+type ListOrdersRequest struct {
+	Customer string `query:"customer"`
+	Page     int    `query:"page,omitempty" default:"1"`
+	Limit    int    `query:"limit,omitempty" default:"20"`
+	Sort     string `query:"sort,omitempty" default:"created"`
+}
+
+type GetOrderRequest struct {
+	OrderID string `query:"id"`
+	Session string `cookie:"session,omitempty" header:"X-Session,omitempty" default:"anonymous"`
+}
+
+// structTypes is the number of struct types above, nested ones included,
+// which bounds the number of parse chains the parser caches.
+const structTypes = 4
+
+///////////////////////////////////////////////////////////////////////////////
+// Server
+///////////////////////////////////////////////////////////////////////////////
+
+type server struct {
+	parser   *pave.HTTPRequestParser
+	registry *pave.ParserRegistry
+}
+
+func newServer() (*server, error) {
+	parser := pave.NewHTTPRequestParserWithOpts(pave.HTTPRequestParserOpts{
+		MaxBodyBytes: 1 << 20,
+	})
+
+	registry, err := pave.NewParserRegistry(pave.ParserRegistryOpts{
+		Parsers:         []pave.Parser{parser},
+		ExcludeDefaults: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &server{parser: parser, registry: registry}, nil
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /orders", handle[CreateOrderRequest](s))
+	mux.HandleFunc("GET /orders", handle[ListOrdersRequest](s))
+	mux.HandleFunc("GET /order", handle[GetOrderRequest](s))
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return mux
+}
+
+// handle parses and validates a T from the request and echoes it back.
+func handle[T any](s *server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// The binding cache keeps the values of a request until its entry
+		// is deleted, and requests are not parsed again once handled
+		defer s.parser.BCache.Delete(r)
+
+		var req T
+		if err := s.registry.Parse(r, &req, true); err != nil {
+			paverespond.WriteProblem(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", pave.ContentTypeApplicationJSON)
+		json.NewEncoder(w).Encode(req)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Soak Mode
+///////////////////////////////////////////////////////////////////////////////
+
+type soakOpts struct {
+	duration   time.Duration
+	interval   time.Duration
+	workers    int
+	maxSources int
+}
+
+type soakStats struct {
+	requests   atomic.Int64
+	rejected   atomic.Int64 // Intentionally invalid requests, rejected as expected
+	failures   atomic.Int64
+	lastFailed atomic.Value // string
+}
+
+// soak serves on addr and drives load against the server until the soak
+// duration has passed, returning an error if the caches of the parser
+// outgrew their bounds or requests failed.
+func soak(s *server, addr string, opts soakOpts) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{Handler: s.handler()}
+	go srv.Serve(listener)
+	defer srv.Shutdown(context.Background())
+
+	baseURL := "http://" + listener.Addr().String()
+	log.Printf("soaking %s for %v with %d workers, pprof at %s/debug/pprof/", baseURL, opts.duration, opts.workers, baseURL)
+
+	client := &http.Client{
+		Transport: &http.Transport{MaxIdleConnsPerHost: opts.workers},
+		Timeout:   10 * time.Second,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.duration)
+	defer cancel()
+
+	var (
+		stats soakStats
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < opts.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; ctx.Err() == nil; n++ {
+				doRequest(ctx, client, baseURL, n, &stats)
+			}
+		}()
+	}
+
+	var violation error
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	last, lastRequests := start, int64(0)
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case now := <-ticker.C:
+			requests := stats.requests.Load()
+			rps := float64(requests-lastRequests) / now.Sub(last).Seconds()
+			last, lastRequests = now, requests
+
+			if violation = checkBounds(s, opts, rps); violation != nil {
+				cancel()
+				break loop
+			}
+		}
+	}
+
+	wg.Wait()
+
+	if violation == nil {
+		// Check once more without load, after all sources are gone
+		client.CloseIdleConnections()
+		srv.Shutdown(context.Background())
+		violation = checkBounds(s, soakOpts{maxSources: 0}, 0)
+	}
+
+	elapsed := time.Since(start)
+	requests := stats.requests.Load()
+	log.Printf(
+		"%d requests in %v (%.0f req/s), %d rejected as expected, %d failed",
+		requests, elapsed.Round(time.Millisecond), float64(requests)/elapsed.Seconds(),
+		stats.rejected.Load(), stats.failures.Load(),
+	)
+
+	if violation != nil {
+		return violation
+	}
+	if failures := stats.failures.Load(); failures > 0 {
+		return fmt.Errorf("%d requests failed, last: %v", failures, stats.lastFailed.Load())
+	}
+	return nil
+}
+
+// checkBounds logs the cache sizes of the parser and returns an error if
+// one exceeds its bound.
+func checkBounds(s *server, opts soakOpts, rps float64) error {
+	sources := s.parser.BCache.Len()
+	chains := s.parser.PCMgr.Len()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	log.Printf(
+		"%8.0f req/s  chains %d  cached sources %4d  heap %6.1f MiB  goroutines %d",
+		rps, chains, sources, float64(mem.HeapInuse)/(1<<20), runtime.NumGoroutine(),
+	)
+
+	switch {
+	case chains > structTypes:
+		return fmt.Errorf("parse chain cache holds %d chains, expected at most %d", chains, structTypes)
+	case sources > opts.maxSources:
+		return fmt.Errorf("binding cache holds %d sources, expected at most %d", sources, opts.maxSources)
+	}
+	return nil
+}
+
+// doRequest sends the n-th request of a worker. Every tenth request is
+// invalid and must be rejected.
+func doRequest(ctx context.Context, client *http.Client, baseURL string, n int, stats *soakStats) {
+	invalid := n%10 == 9
+
+	var (
+		req *http.Request
+		err error
+	)
+	switch n % 3 {
+	case 0:
+		body := `{"customer":"c-42","quantity":2,"total":"12.34",` +
+			`"shipping":{"street":"1 Main St","city":"Springfield","country":"US"}}`
+		if invalid {
+			body = strings.Replace(body, `"US"`, `"XX"`, 1)
+		}
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/orders?dry_run=true", strings.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", pave.ContentTypeApplicationJSON)
+			req.Header.Set("X-Request-Id", fmt.Sprint(n))
+		}
+	case 1:
+		page := fmt.Sprint(n % 50)
+		if invalid {
+			page = "first"
+		}
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/orders?customer=c-42&page="+page, nil)
+	default:
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/order?id=o-"+fmt.Sprint(n), nil)
+		if err == nil {
+			req.AddCookie(&http.Cookie{Name: "session", Value: "s-" + fmt.Sprint(n)})
+		}
+		invalid = false
+	}
+	if err != nil {
+		stats.fail(err)
+		return
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() == nil {
+			stats.fail(err)
+		}
+		return
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	stats.requests.Add(1)
+
+	switch ok := resp.StatusCode == http.StatusOK; {
+	case invalid && !ok:
+		stats.rejected.Add(1)
+	case invalid == ok:
+		stats.fail(fmt.Errorf("%s %s: unexpected status %s", req.Method, req.URL.RequestURI(), resp.Status))
+	}
+}
+
+func (stats *soakStats) fail(err error) {
+	stats.failures.Add(1)
+	stats.lastFailed.Store(err.Error())
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Main
+///////////////////////////////////////////////////////////////////////////////
+
+func main() {
+	var (
+		addr       = flag.String("addr", "", "address to listen on (default :8080, or a random local port with -soak)")
+		duration   = flag.Duration("soak", 0, "drive load against the server for this long, then exit")
+		interval   = flag.Duration("interval", 5*time.Second, "how often the soak mode checks the caches")
+		workers    = flag.Int("workers", 2*runtime.GOMAXPROCS(0), "number of concurrent clients in soak mode")
+		maxSources = flag.Int("max-sources", 0, "bound on cached sources in soak mode (default 4 per worker)")
+	)
+	flag.Parse()
+
+	s, err := newServer()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *duration <= 0 {
+		if *addr == "" {
+			*addr = ":8080"
+		}
+		log.Printf("listening on %s, pprof at /debug/pprof/", *addr)
+		log.Fatal(http.ListenAndServe(*addr, s.handler()))
+	}
+
+	if *addr == "" {
+		*addr = "127.0.0.1:0"
+	}
+	if *maxSources <= 0 {
+		*maxSources = 4 * *workers
+	}
+
+	err = soak(s, *addr, soakOpts{
+		duration:   *duration,
+		interval:   *interval,
+		workers:    *workers,
+		maxSources: *maxSources,
+	})
+	if err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+}
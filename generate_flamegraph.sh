@@ -13,7 +13,7 @@ echo "Creating output directory..."
 mkdir -p $OUTPUT_DIR
 
 echo "Running benchmarks with profiling..."
-go test -bench=$BENCHMARK_PATTERN -cpuprofile=$OUTPUT_DIR/cpu.prof -memprofile=$OUTPUT_DIR/mem.prof -benchtime=10s
+go test ./parser -bench=$BENCHMARK_PATTERN -cpuprofile=$OUTPUT_DIR/cpu.prof -memprofile=$OUTPUT_DIR/mem.prof -benchtime=10s
 
 echo "Generating flamegraph data..."
 go tool pprof -raw -output=$OUTPUT_DIR/cpu_raw.txt $OUTPUT_DIR/cpu.prof
@@ -0,0 +1,496 @@
+// Command genapi generates the combined API of the pave package: aliases
+// of the exported types, constants and variables of the parser and
+// validate packages, and functions calling their exported functions.
+//
+// Run it from the root of the module, or through go generate:
+//
+//	go run ./internal/genapi
+//
+// Declarations of files with build constraints are generated into files
+// with the same constraints, so that the combined API follows the build
+// tags of its packages.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Module is the import path of the module holding the packages.
+const Module = "github.com/SimonDaKappa/go-pave"
+
+// Packages are the directories of the packages the combined API exposes,
+// relative to the root of the module.
+var Packages = []string{"parser", "validate"}
+
+// Prologues are the statements that the generated functions calling the
+// functions of a package run first, keyed by package. Those of the parser
+// package install the validator of the validate package on first use
+// (see installTagValidator), rather than the validate package installing
+// it when imported.
+var Prologues = map[string]string{"parser": "installTagValidator()"}
+
+// Generated matches the names of the files genapi generates.
+var Generated = regexp.MustCompile(`^(parser|validate)_api(_\w+)?\.go$`)
+
+const header = "// Code generated by go run ./internal/genapi; DO NOT EDIT.\n"
+
+func main() {
+	root := flag.String("root", ".", "root directory of the module")
+	flag.Parse()
+
+	files, err := Generate(*root)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stale, err := generatedFiles(*root)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range stale {
+		if _, ok := files[name]; !ok {
+			if err := os.Remove(filepath.Join(*root, name)); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(*root, name), src, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generatedFiles returns the names of the generated files in root.
+func generatedFiles(root string) ([]string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if Generated.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// Generate returns the sources of the combined API of the packages of the
+// module in root, keyed by file name.
+func Generate(root string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	seen := make(map[string]string)
+
+	for _, dir := range Packages {
+		pkg, err := loadPackage(filepath.Join(root, dir), dir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range pkg.names {
+			if other, ok := seen[name]; ok {
+				return nil, fmt.Errorf("%s is exported by both %s and %s", name, other, dir)
+			}
+			seen[name] = dir
+		}
+
+		for _, group := range pkg.groups {
+			src, err := group.source(pkg)
+			if err != nil {
+				return nil, err
+			}
+			files[group.fileName(dir)] = src
+		}
+	}
+
+	return files, nil
+}
+
+// pkg holds the exported declarations of a package.
+type pkg struct {
+	name     string          // Name of the package, and of its directory
+	exported map[string]bool // Exported top-level names
+	names    []string        // Exported top-level names, sorted
+	groups   []*group        // Declarations by build constraint, sorted
+}
+
+// group holds the exported declarations of the files of a package with
+// the same build constraint.
+type group struct {
+	constraint string
+	imports    map[string]string // Import paths by name, of all files
+	used       map[string]bool   // Names of imports the declarations use
+	types      []*ast.TypeSpec
+	consts     []*ast.Ident
+	vars       []*ast.Ident
+	funcs      []*ast.FuncDecl
+}
+
+func loadPackage(dir string, name string) (*pkg, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	p := &pkg{name: name, exported: make(map[string]bool)}
+	byConstraint := make(map[string]*group)
+
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if file.Name.Name != name {
+			return nil, fmt.Errorf("%s: package %s, want %s", path, file.Name.Name, name)
+		}
+
+		expr, err := fileConstraint(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		g, ok := byConstraint[expr]
+		if !ok {
+			g = &group{constraint: expr, imports: make(map[string]string), used: make(map[string]bool)}
+			byConstraint[expr] = g
+			p.groups = append(p.groups, g)
+		}
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			importName := filepath.Base(path)
+			if spec.Name != nil {
+				importName = spec.Name.Name
+			} else if strings.HasPrefix(importName, "v") && strings.Count(path, "/") > 1 {
+				// Major version suffixes, e.g. .../semver/v3
+				importName = filepath.Base(filepath.Dir(path))
+			}
+			g.imports[importName] = path
+		}
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil && decl.Name.IsExported() {
+					g.funcs = append(g.funcs, decl)
+					p.exported[decl.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if spec.Name.IsExported() {
+							g.types = append(g.types, spec)
+							p.exported[spec.Name.Name] = true
+						}
+					case *ast.ValueSpec:
+						for _, ident := range spec.Names {
+							if !ident.IsExported() {
+								continue
+							}
+							if decl.Tok == token.CONST {
+								g.consts = append(g.consts, ident)
+							} else {
+								g.vars = append(g.vars, ident)
+							}
+							p.exported[ident.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for name := range p.exported {
+		p.names = append(p.names, name)
+	}
+	sort.Strings(p.names)
+
+	p.groups = slices.DeleteFunc(p.groups, func(g *group) bool {
+		return len(g.types)+len(g.consts)+len(g.vars)+len(g.funcs) == 0
+	})
+	sort.Slice(p.groups, func(i, j int) bool {
+		return p.groups[i].constraint < p.groups[j].constraint
+	})
+
+	return p, nil
+}
+
+// fileConstraint returns the build constraint of file, or "" if it has
+// none.
+func fileConstraint(file *ast.File) (string, error) {
+	for _, comments := range file.Comments {
+		if comments.Pos() > file.Package {
+			break
+		}
+		for _, comment := range comments.List {
+			if constraint.IsGoBuild(comment.Text) {
+				expr, err := constraint.Parse(comment.Text)
+				if err != nil {
+					return "", err
+				}
+				return expr.String(), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// fileName returns the name of the file generated for g, e.g.
+// parser_api_pave_aws.go for the parser declarations built with pave_aws.
+// Negations are spelled out, so that !pave_minimal does not share the file
+// of pave_minimal.
+func (g *group) fileName(dir string) string {
+	if g.constraint == "" {
+		return dir + "_api.go"
+	}
+	suffix := strings.ReplaceAll(g.constraint, "!", "not_")
+	suffix = regexp.MustCompile(`\W+`).ReplaceAllString(suffix, "_")
+	return dir + "_api_" + strings.Trim(suffix, "_") + ".go"
+}
+
+func (g *group) source(p *pkg) ([]byte, error) {
+	var body bytes.Buffer
+
+	for _, spec := range g.types {
+		name := spec.Name.Name
+		fmt.Fprintf(&body, "// %s is %s.%s.\n", name, p.name, name)
+		if spec.TypeParams == nil {
+			fmt.Fprintf(&body, "type %s = %s.%s\n\n", name, p.name, name)
+			continue
+		}
+		params, args := g.typeParams(spec.TypeParams)
+		fmt.Fprintf(&body, "type %s[%s] = %s.%s[%s]\n\n", name, params, p.name, name, args)
+	}
+
+	if len(g.consts) > 0 {
+		body.WriteString("const (\n")
+		for _, ident := range g.consts {
+			fmt.Fprintf(&body, "\t%s = %s.%s\n", ident.Name, p.name, ident.Name)
+		}
+		body.WriteString(")\n\n")
+	}
+
+	if len(g.vars) > 0 {
+		body.WriteString("var (\n")
+		for _, ident := range g.vars {
+			fmt.Fprintf(&body, "\t%s = %s.%s\n", ident.Name, p.name, ident.Name)
+		}
+		body.WriteString(")\n\n")
+	}
+
+	for _, decl := range g.funcs {
+		g.function(&body, p, decl)
+	}
+
+	var src bytes.Buffer
+	src.WriteString(header)
+	if g.constraint != "" {
+		fmt.Fprintf(&src, "\n//go:build %s\n", g.constraint)
+	}
+	src.WriteString("\npackage pave\n\nimport (\n")
+	var std, other []string
+	other = append(other, strconv.Quote(Module+"/"+p.name))
+	for name := range g.used {
+		path, ok := g.imports[name]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown import %s", p.name, name)
+		}
+		spec := strconv.Quote(path)
+		if filepath.Base(path) != name {
+			spec = name + " " + spec
+		}
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			other = append(other, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	for _, specs := range [][]string{std, other} {
+		for _, spec := range specs {
+			fmt.Fprintf(&src, "\t%s\n", spec)
+		}
+		src.WriteString("\n")
+	}
+	src.WriteString(")\n\n")
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("%s: formatting %s", p.name, g.fileName(p.name)), err)
+	}
+	return formatted, nil
+}
+
+// function writes a function calling decl.
+func (g *group) function(body *bytes.Buffer, p *pkg, decl *ast.FuncDecl) {
+	name := decl.Name.Name
+	typ := decl.Type
+
+	var typeParams, typeArgs string
+	if typ.TypeParams != nil {
+		params, args := g.typeParams(typ.TypeParams)
+		typeParams, typeArgs = "["+params+"]", "["+args+"]"
+	}
+
+	var params, args []string
+	index := 0
+	for _, field := range typ.Params.List {
+		fieldType := g.expr(field.Type)
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{nil}
+		}
+		for _, ident := range names {
+			arg := fmt.Sprintf("p%d", index)
+			if ident != nil && ident.Name != "_" && !slices.Contains(Packages, ident.Name) {
+				arg = ident.Name
+			}
+			index++
+
+			params = append(params, arg+" "+fieldType)
+			if _, ok := field.Type.(*ast.Ellipsis); ok {
+				arg += "..."
+			}
+			args = append(args, arg)
+		}
+	}
+
+	var results []string
+	if typ.Results != nil {
+		for _, field := range typ.Results.List {
+			fieldType := g.expr(field.Type)
+			for range max(len(field.Names), 1) {
+				results = append(results, fieldType)
+			}
+		}
+	}
+
+	fmt.Fprintf(body, "// %s calls %s.%s.\n", name, p.name, name)
+	fmt.Fprintf(body, "func %s%s(%s)", name, typeParams, strings.Join(params, ", "))
+	switch len(results) {
+	case 0:
+	case 1:
+		fmt.Fprintf(body, " %s", results[0])
+	default:
+		fmt.Fprintf(body, " (%s)", strings.Join(results, ", "))
+	}
+
+	call := fmt.Sprintf("%s.%s%s(%s)", p.name, name, typeArgs, strings.Join(args, ", "))
+	if len(results) > 0 {
+		call = "return " + call
+	}
+	if prologue, ok := Prologues[p.name]; ok {
+		call = prologue + "\n\t" + call
+	}
+	fmt.Fprintf(body, " {\n\t%s\n}\n\n", call)
+}
+
+// typeParams returns the type parameters of list, with their constraints,
+// and their names as type arguments.
+func (g *group) typeParams(list *ast.FieldList) (params string, args string) {
+	var paramList, argList []string
+	for _, field := range list.List {
+		var names []string
+		for _, ident := range field.Names {
+			names = append(names, ident.Name)
+		}
+		paramList = append(paramList, strings.Join(names, ", ")+" "+g.expr(field.Type))
+		argList = append(argList, names...)
+	}
+	return strings.Join(paramList, ", "), strings.Join(argList, ", ")
+}
+
+// expr prints the type expression expr, recording the imports it uses.
+// The names of the packages of the module refer to their aliases in the
+// combined API instead.
+func (g *group) expr(expr ast.Expr) string {
+	var qualify func(ast.Expr) ast.Expr
+	qualify = func(expr ast.Expr) ast.Expr {
+		switch e := expr.(type) {
+		case *ast.SelectorExpr:
+			if x, ok := e.X.(*ast.Ident); ok {
+				if strings.HasPrefix(g.imports[x.Name], Module+"/") {
+					return e.Sel
+				}
+				g.used[x.Name] = true
+			}
+			return e
+		case *ast.StarExpr:
+			return &ast.StarExpr{X: qualify(e.X)}
+		case *ast.ArrayType:
+			return &ast.ArrayType{Len: e.Len, Elt: qualify(e.Elt)}
+		case *ast.MapType:
+			return &ast.MapType{Key: qualify(e.Key), Value: qualify(e.Value)}
+		case *ast.ChanType:
+			return &ast.ChanType{Dir: e.Dir, Value: qualify(e.Value)}
+		case *ast.Ellipsis:
+			return &ast.Ellipsis{Elt: qualify(e.Elt)}
+		case *ast.ParenExpr:
+			return &ast.ParenExpr{X: qualify(e.X)}
+		case *ast.UnaryExpr:
+			return &ast.UnaryExpr{Op: e.Op, X: qualify(e.X)}
+		case *ast.BinaryExpr:
+			return &ast.BinaryExpr{X: qualify(e.X), Op: e.Op, Y: qualify(e.Y)}
+		case *ast.IndexExpr:
+			return &ast.IndexExpr{X: qualify(e.X), Index: qualify(e.Index)}
+		case *ast.IndexListExpr:
+			indices := make([]ast.Expr, len(e.Indices))
+			for i, index := range e.Indices {
+				indices[i] = qualify(index)
+			}
+			return &ast.IndexListExpr{X: qualify(e.X), Indices: indices}
+		case *ast.FuncType:
+			return &ast.FuncType{Params: qualifyFields(e.Params, qualify), Results: qualifyFields(e.Results, qualify)}
+		case *ast.StructType:
+			return &ast.StructType{Fields: qualifyFields(e.Fields, qualify)}
+		case *ast.InterfaceType:
+			return &ast.InterfaceType{Methods: qualifyFields(e.Methods, qualify)}
+		}
+		return expr
+	}
+
+	var out bytes.Buffer
+	if err := printer.Fprint(&out, token.NewFileSet(), qualify(expr)); err != nil {
+		panic(err)
+	}
+	return out.String()
+}
+
+// qualifyFields returns a copy of list with the types of its fields
+// qualified.
+func qualifyFields(list *ast.FieldList, qualify func(ast.Expr) ast.Expr) *ast.FieldList {
+	if list == nil {
+		return nil
+	}
+
+	fields := make([]*ast.Field, len(list.List))
+	for i, field := range list.List {
+		fields[i] = &ast.Field{Names: field.Names, Type: qualify(field.Type)}
+	}
+	return &ast.FieldList{List: fields}
+}
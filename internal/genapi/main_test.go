@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+
+	files, err := Generate(root)
+	require.NoError(t, err)
+	require.Contains(t, files, "parser_api.go")
+	require.Contains(t, files, "validate_api.go")
+
+	names, err := generatedFiles(root)
+	require.NoError(t, err)
+	assert.Len(t, names, len(files), "run go generate to remove stale files")
+
+	for name, src := range files {
+		current, err := os.ReadFile(filepath.Join(root, name))
+		require.NoError(t, err, "run go generate to create %s", name)
+		assert.Equal(t, string(src), string(current), "run go generate to update %s", name)
+	}
+}
+
+func TestGroup_FileName(t *testing.T) {
+	for constraint, want := range map[string]string{
+		"":              "parser_api.go",
+		"pave_aws":      "parser_api_pave_aws.go",
+		"js && wasm":    "parser_api_js_wasm.go",
+		"!pave_minimal": "parser_api_not_pave_minimal.go",
+	} {
+		name := (&group{constraint: constraint}).fileName("parser")
+		assert.Equal(t, want, name)
+		assert.True(t, Generated.MatchString(name), name)
+	}
+}
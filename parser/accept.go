@@ -0,0 +1,218 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidAccept = errors.New("invalid accept header")
+	ErrNotAcceptable = errors.New("none of the offered values is acceptable")
+)
+
+// AcceptValue is a single element of an Accept, Accept-Language or
+// Accept-Encoding header, e.g. "text/html;level=1;q=0.8".
+type AcceptValue struct {
+	Value  string            // Media range, language range or coding, lower case
+	Q      float64           // Quality, from 0 (not acceptable) to 1
+	Params map[string]string // Parameters other than q, e.g. level=1. Nil if none.
+}
+
+// AcceptList is a parsed Accept, Accept-Language or Accept-Encoding header,
+// most preferred first.
+type AcceptList []AcceptValue
+
+// ParseAccept parses the value of an Accept, Accept-Language or
+// Accept-Encoding header. Elements are ordered by quality, more specific
+// ranges first for equal qualities (e.g. "text/html" before "text/*"),
+// and otherwise in header order.
+func ParseAccept(value string) (AcceptList, error) {
+	var list AcceptList
+
+	for _, element := range strings.Split(value, CommaDelimeter) {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+
+		params := strings.Split(element, ";")
+		accept := AcceptValue{
+			Value: strings.ToLower(strings.TrimSpace(params[0])),
+			Q:     1,
+		}
+		if accept.Value == "" {
+			return nil, fmt.Errorf("%w: empty element in %q", ErrInvalidAccept, value)
+		}
+
+		for _, param := range params[1:] {
+			name, paramValue, _ := strings.Cut(strings.TrimSpace(param), "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+			paramValue = strings.Trim(strings.TrimSpace(paramValue), `"`)
+
+			if name != "q" {
+				if accept.Params == nil {
+					accept.Params = make(map[string]string)
+				}
+				accept.Params[name] = paramValue
+				continue
+			}
+
+			q, err := strconv.ParseFloat(paramValue, 64)
+			if err != nil || q < 0 || q > 1 {
+				return nil, fmt.Errorf("%w: invalid quality %q in %q", ErrInvalidAccept, paramValue, element)
+			}
+			accept.Q = q
+		}
+
+		list = append(list, accept)
+	}
+
+	slices.SortStableFunc(list, func(a, b AcceptValue) int {
+		if a.Q != b.Q {
+			if a.Q > b.Q {
+				return -1
+			}
+			return 1
+		}
+		return acceptSpecificity(b) - acceptSpecificity(a)
+	})
+
+	return list, nil
+}
+
+// Values returns the acceptable values of the list (those with a non-zero
+// quality), most preferred first.
+func (list AcceptList) Values() []string {
+	values := make([]string, 0, len(list))
+	for _, accept := range list {
+		if accept.Q > 0 {
+			values = append(values, accept.Value)
+		}
+	}
+	return values
+}
+
+// String formats the list as a header value, most preferred first.
+func (list AcceptList) String() string {
+	elements := make([]string, len(list))
+	for i, accept := range list {
+		var sb strings.Builder
+		sb.WriteString(accept.Value)
+
+		params := make([]string, 0, len(accept.Params))
+		for name := range accept.Params {
+			params = append(params, name)
+		}
+		slices.Sort(params)
+		for _, name := range params {
+			sb.WriteString(";" + name + "=" + accept.Params[name])
+		}
+
+		if accept.Q < 1 {
+			sb.WriteString(";q=" + strconv.FormatFloat(accept.Q, 'f', -1, 64))
+		}
+		elements[i] = sb.String()
+	}
+	return strings.Join(elements, CommaDelimeter)
+}
+
+// MarshalText formats the list as a header value.
+func (list AcceptList) MarshalText() ([]byte, error) {
+	return []byte(list.String()), nil
+}
+
+// Quality returns the quality of offer, taken from the most specific
+// element of the list matching it. It is 0 if no element matches.
+func (list AcceptList) Quality(offer string) float64 {
+	offer = strings.ToLower(offer)
+
+	var (
+		quality     float64
+		specificity = -1
+	)
+	for _, accept := range list {
+		if s := acceptSpecificity(accept); s > specificity && acceptMatches(accept.Value, offer) {
+			quality, specificity = accept.Q, s
+		}
+	}
+	return quality
+}
+
+// Negotiate returns the offer with the highest quality, preferring
+// earlier offers for equal qualities. It reports false if no offer is
+// acceptable. An empty list accepts the first offer.
+func (list AcceptList) Negotiate(offers ...string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	if len(list) == 0 {
+		return offers[0], true
+	}
+
+	var (
+		best    string
+		quality float64
+	)
+	for _, offer := range offers {
+		if q := list.Quality(offer); q > quality {
+			best, quality = offer, q
+		}
+	}
+	return best, quality > 0
+}
+
+// acceptMatches reports whether the range of an Accept element matches
+// offer. Media ranges match by type and subtype with * wildcards, other
+// ranges (languages, codings) match exactly, by prefix up to a "-" (RFC
+// 4647 basic filtering, e.g. "en" matches "en-us"), or by *.
+func acceptMatches(accepted string, offer string) bool {
+	if accepted == "*" || accepted == "*/*" || accepted == offer {
+		return true
+	}
+
+	if acceptedType, acceptedSub, ok := strings.Cut(accepted, "/"); ok {
+		offerType, _, _ := strings.Cut(offer, "/")
+		return acceptedSub == "*" && acceptedType == offerType
+	}
+
+	return strings.HasPrefix(offer, accepted+"-")
+}
+
+// acceptSpecificity ranks how specific an Accept element is: wildcards
+// are the least specific and parameters make a range more specific.
+func acceptSpecificity(accept AcceptValue) int {
+	specificity := len(accept.Params)
+	switch {
+	case accept.Value == "*" || accept.Value == "*/*":
+	case strings.HasSuffix(accept.Value, "/*"):
+		specificity += 100
+	default:
+		specificity += 200 + strings.Count(accept.Value, "-")
+	}
+	return specificity
+}
+
+// parseOffersModifier is the ModifierValueParser of the offers modifier,
+// which lists the offered values separated by "|", e.g.
+// `accept:"Accept,offers=application/json|text/csv"`.
+func parseOffersModifier(value string) (any, error) {
+	var offers []string
+	for _, offer := range strings.Split(value, "|") {
+		if offer = strings.TrimSpace(offer); offer != "" {
+			offers = append(offers, offer)
+		}
+	}
+
+	if len(offers) == 0 {
+		return nil, fmt.Errorf("%w: no offers in %q", ErrInvalidAccept, value)
+	}
+	return offers, nil
+}
+
+// convertAcceptList parses an AcceptList field.
+func convertAcceptList(value string) (any, error) {
+	return ParseAccept(value)
+}
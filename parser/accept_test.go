@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccept(t *testing.T) {
+	list, err := ParseAccept("text/*;q=0.3, text/html;q=0.7, text/html;level=1, */*;q=0.5, Application/JSON")
+	require.NoError(t, err)
+	assert.Equal(t, AcceptList{
+		{Value: "text/html", Q: 1, Params: map[string]string{"level": "1"}},
+		{Value: "application/json", Q: 1},
+		{Value: "text/html", Q: 0.7},
+		{Value: "*/*", Q: 0.5},
+		{Value: "text/*", Q: 0.3},
+	}, list)
+	assert.Equal(t, "text/html;level=1,application/json,text/html;q=0.7,*/*;q=0.5,text/*;q=0.3", list.String())
+
+	list, err = ParseAccept("*, en-US, en;q=0.9, fr;q=0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en-us", "*", "en"}, list.Values(), "specific ranges come first and q=0 is excluded")
+
+	list, err = ParseAccept("")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	for _, value := range []string{"text/html;q=2", "gzip;q=-1", "gzip;q=high", "gzip, ;q=0.5"} {
+		_, err := ParseAccept(value)
+		assert.ErrorIs(t, err, ErrInvalidAccept, value)
+	}
+}
+
+func TestAcceptList_Negotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		offers []string
+		want   string
+		ok     bool
+	}{
+		{"application/json, text/html;q=0.5", []string{"text/html", "application/json"}, "application/json", true},
+		{"text/*", []string{"application/json", "text/csv"}, "text/csv", true},
+		{"*/*, text/csv;q=0", []string{"text/csv", "application/json"}, "application/json", true},
+		{"image/png", []string{"text/html"}, "", false},
+		{"de, en;q=0.8", []string{"en-GB", "fr"}, "en-GB", true},
+		{"en-US", []string{"en"}, "", false},
+		{"gzip;q=0.5, br", []string{"gzip", "br"}, "br", true},
+		{"*;q=0.1, identity;q=0", []string{"identity", "zstd"}, "zstd", true},
+		{"", []string{"text/html", "application/json"}, "text/html", true},
+	}
+
+	for _, tt := range tests {
+		list, err := ParseAccept(tt.header)
+		require.NoError(t, err)
+
+		got, ok := list.Negotiate(tt.offers...)
+		assert.Equal(t, tt.ok, ok, tt.header)
+		assert.Equal(t, tt.want, got, tt.header)
+	}
+}
+
+func TestHTTPRequestParser_Accept(t *testing.T) {
+	type Negotiation struct {
+		Types     AcceptList `accept:"Accept,omitempty" default:"*/*"`
+		Languages AcceptList `accept:"Accept-Language,omitempty" default:"*"`
+		Format    string     `accept:"Accept,offers=application/json|text/csv,omitempty" default:"application/json"`
+		Encoding  string     `accept:"Accept-Encoding,offers=br|gzip|identity,omitempty" default:"identity"`
+	}
+
+	parser := NewHTTPRequestParser()
+
+	req := newTestRequest(t, "GET", "http://example.com/report", nil)
+	req.Header.Add("Accept", "text/html, text/csv;q=0.9")
+	req.Header.Add("Accept", "application/json;q=0.5")
+	req.Header.Set("Accept-Language", "fr-CH, fr;q=0.9, en;q=0.8")
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	var negotiation Negotiation
+	require.NoError(t, parser.Parse(req, &negotiation))
+	assert.Equal(t, []string{"text/html", "text/csv", "application/json"}, negotiation.Types.Values())
+	assert.Equal(t, []string{"fr-ch", "fr", "en"}, negotiation.Languages.Values())
+	assert.Equal(t, "text/csv", negotiation.Format)
+	assert.Equal(t, "gzip", negotiation.Encoding)
+
+	t.Run("Absent", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/report", nil)
+
+		var negotiation Negotiation
+		require.NoError(t, parser.Parse(req, &negotiation))
+		assert.Equal(t, AcceptList{{Value: "*/*", Q: 1}}, negotiation.Types)
+		assert.Equal(t, "application/json", negotiation.Format)
+		assert.Equal(t, "identity", negotiation.Encoding)
+	})
+
+	t.Run("NotAcceptable", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/report", nil)
+		req.Header.Set("Accept", "image/png")
+
+		var strict struct {
+			Format string `accept:"Accept,offers=application/json|text/csv"`
+		}
+		err := parser.Parse(req, &strict)
+		assert.ErrorIs(t, err, ErrNotAcceptable)
+
+		// Like other binding errors, it falls back to the default of
+		// omitempty fields
+		var negotiation Negotiation
+		require.NoError(t, parser.Parse(req, &negotiation))
+		assert.Equal(t, "application/json", negotiation.Format)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/report", nil)
+		req.Header.Set("Accept", "text/html;q=high")
+
+		err := parser.Parse(req, &Negotiation{})
+		assert.ErrorIs(t, err, ErrInvalidAccept)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		type Client struct {
+			Types AcceptList `accept:"Accept"`
+		}
+
+		want := Client{Types: AcceptList{{Value: "application/json", Q: 1}, {Value: "text/*", Q: 0.5}}}
+		req, err := NewHTTPRequest("GET", "http://example.com/report", want)
+		require.NoError(t, err)
+		assert.Equal(t, "application/json,text/*;q=0.5", req.Header.Get("Accept"))
+
+		var got Client
+		require.NoError(t, parser.Parse(req, &got))
+		assert.Equal(t, want, got)
+	})
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"reflect"
+)
+
+// DefaultMaxUncachedBindings is the number of distinct bindings up to
+// which destination types are parsed without the BindingCache, see
+// BindingCacheOpts.
+const DefaultMaxUncachedBindings = 1
+
+// BindingCacheOpts tunes, per destination type, whether a BaseMBParser
+// with UseCache parses through its BindingCache.
+//
+// The BindingCache shares the values read from a source (e.g. the parsed
+// body of a request) between all the fields and parses of that source,
+// at the cost of an entry tracked until the source is garbage collected.
+// Types with few bindings read little more than one value and gain
+// nothing from it, so they are parsed with an entry of their own that is
+// dropped with the parse, unless their source already has an entry in
+// the BindingCache, which they reuse.
+type BindingCacheOpts struct {
+	// MaxUncachedBindings is the number of distinct bindings (see
+	// ParseChain.Bindings) up to which destination types are parsed
+	// without the BindingCache. Defaults to DefaultMaxUncachedBindings,
+	// negative values always use the BindingCache.
+	MaxUncachedBindings int
+	// Types overrides the decision for the given destination types: true
+	// always uses the BindingCache, false only reuses entries their
+	// sources already have.
+	Types map[reflect.Type]bool
+}
+
+// usesBindingCache reports whether parses into typ, whose chain is chain,
+// go through the BindingCache. The decision is made once per type.
+func (base *BaseMBParser[S, C]) usesBindingCache(typ reflect.Type, chain *ParseChain[S]) bool {
+	if cached, ok := base.cacheOpts.Types[typ]; ok {
+		return cached
+	}
+
+	if decision, ok := base.cacheDecisions.Load(typ); ok {
+		return decision.(bool)
+	}
+
+	limit := base.cacheOpts.MaxUncachedBindings
+	if limit == 0 {
+		limit = DefaultMaxUncachedBindings
+	}
+	cached := limit < 0 || len(chain.Bindings()) > limit
+
+	base.cacheDecisions.Store(typ, cached)
+	return cached
+}
+
+// uncachedHandler returns the handler that the parse of source into typ
+// gets the values of its bindings with: one reading through an entry of
+// the parse's own, if the type does not use the BindingCache (see
+// BindingCacheOpts) and source has no entry in it yet, or else nil for
+// the Handler of the chain. The returned function drops the entry and
+// must be called once the parse is done.
+//
+// The entry is never shared with other parses, so that it can be
+// recycled once dropped, see newParseEntry.
+func (base *BaseMBParser[S, C]) uncachedHandler(
+	source *S, typ reflect.Type, chain *ParseChain[S],
+) (BindingHandlerFunc[S], func()) {
+
+	if !base.useBCache || base.BCache == nil || base.usesBindingCache(typ, chain) {
+		return nil, func() {}
+	}
+
+	// Sources parsed before share their entry
+	if _, reused := base.BCache.Get(source); reused {
+		return nil, func() {}
+	}
+
+	return base.newParseEntry()
+}
+
+// entryHandler gets the value of binding from source through entry, the
+// entry of a parse that skips the BindingCache.
+func (base *BaseMBParser[S, C]) entryHandler(source *S, entry *CacheEntry[C], binding Binding) BindingResult {
+	return base.BMgr.BindingHandlerCached(source, entry, binding)
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type adaptiveSingle struct {
+	Name string `json:"name"`
+}
+
+type adaptiveMany struct {
+	Name  string `json:"name"`
+	Token string `header:"X-Token"`
+}
+
+func newAdaptiveRequest(t testing.TB, body string) *http.Request {
+	req := newTestRequest(t, "POST", "http://example.com/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Token", "abc")
+	return req
+}
+
+func TestBaseMBParser_AdaptiveCache(t *testing.T) {
+	t.Run("FewBindings", func(t *testing.T) {
+		parser := NewHTTPRequestParser()
+		req := newAdaptiveRequest(t, `{"name": "jane"}`)
+
+		var single adaptiveSingle
+		require.NoError(t, parser.Parse(req, &single))
+		assert.Equal(t, "jane", single.Name)
+		assert.Equal(t, 0, parser.BCache.Len(), "single bindings skip the cache")
+
+		var many adaptiveMany
+		require.NoError(t, parser.Parse(req, &many))
+		assert.Equal(t, adaptiveMany{Name: "jane", Token: "abc"}, many)
+		assert.Equal(t, 1, parser.BCache.Len())
+		runtime.KeepAlive(req)
+	})
+
+	t.Run("ReusedSource", func(t *testing.T) {
+		parser := NewHTTPRequestParser()
+		req := newAdaptiveRequest(t, `{"name": "jane"}`)
+
+		var many adaptiveMany
+		require.NoError(t, parser.Parse(req, &many))
+
+		// The body cached for the first parse is read by the second
+		req.Body = io.NopCloser(strings.NewReader(`{"name": "joe"}`))
+		var single adaptiveSingle
+		require.NoError(t, parser.Parse(req, &single))
+		assert.Equal(t, "jane", single.Name)
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			opts   BindingCacheOpts
+			cached bool
+		}{
+			{"Types", BindingCacheOpts{Types: map[reflect.Type]bool{reflect.TypeFor[adaptiveSingle](): true}}, true},
+			{"Always", BindingCacheOpts{MaxUncachedBindings: -1}, true},
+			{"MaxBindings", BindingCacheOpts{MaxUncachedBindings: 2}, false},
+			{"TypesUncached", BindingCacheOpts{Types: map[reflect.Type]bool{reflect.TypeFor[adaptiveMany](): false}}, false},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{BindingCache: test.opts})
+				req := newAdaptiveRequest(t, `{"name": "jane"}`)
+
+				var single adaptiveSingle
+				require.NoError(t, parser.Parse(req, &single))
+				var many adaptiveMany
+				require.NoError(t, parser.Parse(req, &many))
+
+				assert.Equal(t, test.cached, parser.BCache.Len() == 1)
+				runtime.KeepAlive(req)
+			})
+		}
+	})
+}
+
+func BenchmarkHTTPRequestParser_AdaptiveCache(b *testing.B) {
+	for name, limit := range map[string]int{"Adaptive": 0, "Cached": -1} {
+		b.Run(name, func(b *testing.B) {
+			parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{
+				BindingCache: BindingCacheOpts{MaxUncachedBindings: limit},
+			})
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var dest adaptiveSingle
+				if err := parser.Parse(newAdaptiveRequest(b, `{"name": "jane"}`), &dest); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+//go:build pave_arena
+
+package parser
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Experimental bump allocation of the per-parse entries of the parses that
+// skip the BindingCache (see BindingCacheOpts). Build with the pave_arena
+// tag to enable it, for services parsing many small structs, where the
+// entry and its handler are otherwise allocated by every parse.
+
+// arenaSlabSize is the number of entries of an arenaSlab.
+const arenaSlabSize = 64
+
+// parseArena hands out the entries of parses from slabs of arenaSlabSize
+// entries, bumping through a slab until it is full. A slab whose entries
+// are all dropped is reused from its start, a full one with live entries
+// is left to the garbage collector once they are dropped.
+type parseArena[S, C any] struct {
+	slabs sync.Pool // *arenaSlab[S, C], held by one parse at a time while it takes an entry
+}
+
+// arenaSlab is a block of entries handed out in order.
+type arenaSlab[S, C any] struct {
+	slots [arenaSlabSize]arenaSlot[S, C]
+	next  int          // Index of the next slot handed out
+	live  atomic.Int32 // Number of slots handed out and not yet dropped
+}
+
+// arenaSlot is an entry of an arenaSlab, with its handler and the function
+// dropping it, bound once when the slab is made.
+type arenaSlot[S, C any] struct {
+	entry   CacheEntry[C]
+	handler BindingHandlerFunc[S]
+	release func()
+}
+
+// newParseEntry returns the handler of a parse reading through an entry of
+// its own taken from the arena of base, and the function dropping it.
+func (base *BaseMBParser[S, C]) newParseEntry() (BindingHandlerFunc[S], func()) {
+	slot := base.arena.alloc(base)
+	slot.entry.data = base.BMgr.NewCached()
+	return slot.handler, slot.release
+}
+
+// alloc takes the next free slot of a slab of the arena.
+func (arena *parseArena[S, C]) alloc(base *BaseMBParser[S, C]) *arenaSlot[S, C] {
+	slab, _ := arena.slabs.Get().(*arenaSlab[S, C])
+	if slab == nil {
+		slab = newArenaSlab(base)
+	}
+
+	slot := slab.take()
+	if slot == nil {
+		slab = newArenaSlab(base)
+		slot = slab.take()
+	}
+
+	arena.slabs.Put(slab)
+	return slot
+}
+
+// take hands out the next slot of slab, starting over once all its slots
+// are dropped. It returns nil if slab is full.
+func (slab *arenaSlab[S, C]) take() *arenaSlot[S, C] {
+	if slab.live.Load() == 0 {
+		slab.next = 0
+	}
+	if slab.next == arenaSlabSize {
+		return nil
+	}
+
+	slot := &slab.slots[slab.next]
+	slab.next++
+	slab.live.Add(1)
+	return slot
+}
+
+// newArenaSlab makes a slab whose slots read through base.
+func newArenaSlab[S, C any](base *BaseMBParser[S, C]) *arenaSlab[S, C] {
+	slab := &arenaSlab[S, C]{}
+
+	for i := range slab.slots {
+		slot := &slab.slots[i]
+		slot.handler = func(source *S, binding Binding) BindingResult {
+			return base.entryHandler(source, &slot.entry, binding)
+		}
+		slot.release = func() {
+			var zero C
+			slot.entry.data = zero
+			slab.live.Add(-1)
+		}
+	}
+	return slab
+}
@@ -0,0 +1,18 @@
+//go:build !pave_arena
+
+package parser
+
+// parseArena holds nothing without the pave_arena build tag.
+type parseArena[S, C any] struct{}
+
+// newParseEntry returns the handler of a parse reading through a new entry
+// of its own, and the function dropping it. Without the pave_arena build
+// tag the entry is left to the garbage collector.
+func (base *BaseMBParser[S, C]) newParseEntry() (BindingHandlerFunc[S], func()) {
+	entry := &CacheEntry[C]{data: base.BMgr.NewCached()}
+
+	handler := func(source *S, binding Binding) BindingResult {
+		return base.entryHandler(source, entry, binding)
+	}
+	return handler, func() {}
+}
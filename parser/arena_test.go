@@ -0,0 +1,55 @@
+//go:build pave_arena
+
+package parser
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArenaSlab_Take(t *testing.T) {
+	slab := newArenaSlab(NewHTTPRequestParser().BaseMBParser)
+
+	first := slab.take()
+	second := slab.take()
+	assert.NotSame(t, first, second, "live slots are not handed out again")
+
+	first.release()
+	second.release()
+	assert.Same(t, first, slab.take(), "drained slabs are reused from their start")
+
+	for i := 1; i < arenaSlabSize; i++ {
+		require.NotNil(t, slab.take())
+	}
+	assert.Nil(t, slab.take(), "full slabs hand out nothing")
+}
+
+func TestHTTPRequestParser_Arena(t *testing.T) {
+	parser := NewHTTPRequestParser()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4*arenaSlabSize; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			name := fmt.Sprint("user", i)
+			var dest adaptiveSingle
+			if assert.NoError(t, parser.Parse(newAdaptiveRequest(t, `{"name": "`+name+`"}`), &dest)) {
+				assert.Equal(t, name, dest.Name)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var dest adaptiveSingle
+	report, err := parser.ParseWithReport(newAdaptiveRequest(t, `{"name": "jane"}`), &dest)
+	require.NoError(t, err)
+	assert.Equal(t, "jane", dest.Name)
+	assert.Len(t, report.Steps, 1)
+	assert.Equal(t, 0, parser.BCache.Len())
+}
@@ -0,0 +1,344 @@
+//go:build pave_aws
+
+package parser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Support for the event envelopes of github.com/aws/aws-lambda-go, so that
+// Lambda handlers parse and validate API Gateway requests, SQS messages
+// and SNS notifications into tagged structs instead of unmarshalling them
+// by hand.
+//
+//	func handler(ctx context.Context, event events.SQSEvent) error {
+//		parser := pave.NewSQSMessageParser()
+//		for i := range event.Records {
+//			var order OrderPlaced
+//			if err := parser.Parse(&event.Records[i], &order); err != nil {
+//				return err
+//			}
+//			...
+//		}
+//	}
+
+var (
+	ErrInvalidLambdaEvent = errors.New("invalid lambda event")
+)
+
+var (
+	// Default SQSMessageParser Options
+	_sqsParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts: PCManagerOpts{
+			tagOpts: lambdaMessageTagOpts(SQSTagBinding),
+		},
+	}
+
+	// Default SNSEntityParser Options
+	_snsParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts: PCManagerOpts{
+			tagOpts: lambdaMessageTagOpts(SNSTagBinding),
+		},
+	}
+)
+
+// lambdaMessageTagOpts returns the tag options of the parsers of queue
+// and topic messages, whose metadata is bound by the binding metadata.
+func lambdaMessageTagOpts(metadata string) ParseTagOpts {
+	return ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				JsonTagBinding,
+				AttributeTagBinding,
+				metadata,
+			},
+			CustomBindingModifiers: []string{
+				LiteralBindingModifier,
+			},
+			ValueModifiers: map[string]ModifierValueParser{
+				CurrencyBindingModifier: parseCurrencyModifier,
+			},
+		},
+		AllowedTagOptionals: []string{},
+	}
+}
+
+// APIGatewayProxyParser parses the events.APIGatewayProxyRequest of a
+// REST API (or Lambda function URL) proxy integration. The event is
+// converted to an *http.Request, see APIGatewayProxyHTTPRequest, so that
+// structs tagged for the HTTPRequestParser parse the same behind API
+// Gateway, with all of its bindings and modifiers except the trailer and
+// range bindings.
+//
+// The path binding additionally gets the path parameters of the resource,
+// e.g. `path:"id"` for the resource /users/{id}.
+type APIGatewayProxyParser struct {
+	*BaseMBParser[events.APIGatewayProxyRequest, HTTPAdapterOnce]
+	bMgr *HTTPAdapterBindingManager[events.APIGatewayProxyRequest]
+}
+
+func NewAPIGatewayProxyParser() *APIGatewayProxyParser {
+	bMgr := NewHTTPAdapterBindingManager(
+		APIGatewayProxyHTTPRequest,
+		func(event *events.APIGatewayProxyRequest) map[string]string { return event.PathParameters },
+	)
+
+	return &APIGatewayProxyParser{
+		BaseMBParser: NewBaseMBParser(bMgr, _httpAdapterParserOpts),
+		bMgr:         bMgr,
+	}
+}
+
+func (ap *APIGatewayProxyParser) Name() string {
+	return APIGatewayProxyParserName
+}
+
+// RegisterVerifier makes verifier available to the signed modifier of the
+// parser as name. See HTTPRequestParser.RegisterVerifier.
+func (ap *APIGatewayProxyParser) RegisterVerifier(name string, verifier SignatureVerifier) {
+	ap.bMgr.RegisterVerifier(name, verifier)
+}
+
+// UnregisterVerifier removes the verifier registered as name, if any.
+func (ap *APIGatewayProxyParser) UnregisterVerifier(name string) {
+	ap.bMgr.UnregisterVerifier(name)
+}
+
+// APIGatewayProxyHTTPRequest converts a proxy integration event to the
+// *http.Request it stands for. Multi-value headers and query parameters
+// take precedence over their single-value counterparts, and base64
+// encoded bodies are decoded.
+func APIGatewayProxyHTTPRequest(event *events.APIGatewayProxyRequest) (*http.Request, error) {
+	body := []byte(event.Body)
+	if event.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(event.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: body: %w", ErrInvalidLambdaEvent, err)
+		}
+		body = decoded
+	}
+
+	query := url.Values(event.MultiValueQueryStringParameters)
+	if len(query) == 0 {
+		query = make(url.Values, len(event.QueryStringParameters))
+		for name, value := range event.QueryStringParameters {
+			query.Set(name, value)
+		}
+	}
+
+	header := make(http.Header, len(event.Headers))
+	if len(event.MultiValueHeaders) > 0 {
+		for name, values := range event.MultiValueHeaders {
+			for _, value := range values {
+				header.Add(name, value)
+			}
+		}
+	} else {
+		for name, value := range event.Headers {
+			header.Set(name, value)
+		}
+	}
+
+	target := &url.URL{Path: event.Path, RawQuery: query.Encode()}
+	req, err := http.NewRequest(event.HTTPMethod, target.String(), io.NopCloser(bytes.NewReader(body)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLambdaEvent, err)
+	}
+	req.Header = header
+	req.Host = header.Get("Host")
+	req.ContentLength = int64(len(body))
+	return req, nil
+}
+
+// SQSMessageParser parses the events.SQSMessage records of an SQS event:
+//   - json:'<key,[modifiers]>'`: Parses a JSON key from the message body
+//   - attribute:'<name,[modifiers]>'`: Parses a message attribute. Binary
+//     attributes are base64 encoded.
+//   - sqs:'<name,[modifiers]>'`: Parses the messageId, receiptHandle,
+//     md5OfBody, eventSource, eventSourceARN or awsRegion of the message,
+//     or one of its system attributes, e.g. ApproximateReceiveCount or
+//     MessageGroupId.
+type SQSMessageParser struct {
+	*BaseMBParser[events.SQSMessage, JSONBodyOnce]
+}
+
+func NewSQSMessageParser() *SQSMessageParser {
+	return &SQSMessageParser{
+		BaseMBParser: NewBaseMBParser(&SQSBindingManager{}, _sqsParserOpts),
+	}
+}
+
+func (sp *SQSMessageParser) Name() string {
+	return SQSMessageParserName
+}
+
+// SQSBindingManager gets the values of bindings from an
+// events.SQSMessage. See SQSMessageParser.
+type SQSBindingManager struct{}
+
+func (mgr *SQSBindingManager) BindingHandlerCached(
+	source *events.SQSMessage,
+	entry *CacheEntry[JSONBodyOnce],
+	binding Binding,
+) BindingResult {
+
+	if entry == nil {
+		return BindingResultError(ErrBindingCacheNilEntry)
+	}
+
+	switch binding.Name {
+	case JsonTagBinding:
+		var result BindingResult
+		entry.WriteData(func(data *JSONBodyOnce) {
+			result = data.jsonValue(source.Body, binding)
+		})
+		return result
+	case AttributeTagBinding:
+		attribute, ok := source.MessageAttributes[binding.Identifier]
+		switch {
+		case !ok:
+			return BindingResultNotFound()
+		case attribute.StringValue != nil:
+			return BindingResultValue(*attribute.StringValue)
+		case attribute.BinaryValue != nil:
+			return BindingResultValue(base64.StdEncoding.EncodeToString(attribute.BinaryValue))
+		default:
+			return BindingResultNotFound()
+		}
+	case SQSTagBinding:
+		return nonEmptyMapValue(sqsMetadata(source), binding.Identifier)
+	default:
+		return BindingResultError(fmt.Errorf("%w: unknown binding: %s", ErrUnallowedBindingName, binding.Name))
+	}
+}
+
+func (mgr *SQSBindingManager) BindingHandler(source *events.SQSMessage, binding Binding) BindingResult {
+	return mgr.BindingHandlerCached(source, &CacheEntry[JSONBodyOnce]{}, binding)
+}
+
+func (mgr *SQSBindingManager) NewCached() JSONBodyOnce {
+	return JSONBodyOnce{}
+}
+
+// sqsMetadata returns the metadata of message bound by the sqs binding.
+func sqsMetadata(message *events.SQSMessage) map[string]string {
+	metadata := make(map[string]string, len(message.Attributes)+6)
+	for name, value := range message.Attributes {
+		metadata[name] = value
+	}
+	metadata["messageId"] = message.MessageId
+	metadata["receiptHandle"] = message.ReceiptHandle
+	metadata["md5OfBody"] = message.Md5OfBody
+	metadata["eventSource"] = message.EventSource
+	metadata["eventSourceARN"] = message.EventSourceARN
+	metadata["awsRegion"] = message.AWSRegion
+	return metadata
+}
+
+// SNSEntityParser parses the events.SNSEntity of the records of an SNS
+// event:
+//   - json:'<key,[modifiers]>'`: Parses a JSON key from the message
+//   - attribute:'<name,[modifiers]>'`: Parses the value of a message
+//     attribute
+//   - sns:'<name,[modifiers]>'`: Parses the MessageId, Type, TopicArn,
+//     Subject, Timestamp, SignatureVersion, Signature, SigningCertUrl or
+//     UnsubscribeUrl of the notification.
+type SNSEntityParser struct {
+	*BaseMBParser[events.SNSEntity, JSONBodyOnce]
+}
+
+func NewSNSEntityParser() *SNSEntityParser {
+	return &SNSEntityParser{
+		BaseMBParser: NewBaseMBParser(&SNSBindingManager{}, _snsParserOpts),
+	}
+}
+
+func (sp *SNSEntityParser) Name() string {
+	return SNSEntityParserName
+}
+
+// SNSBindingManager gets the values of bindings from an events.SNSEntity.
+// See SNSEntityParser.
+type SNSBindingManager struct{}
+
+func (mgr *SNSBindingManager) BindingHandlerCached(
+	source *events.SNSEntity,
+	entry *CacheEntry[JSONBodyOnce],
+	binding Binding,
+) BindingResult {
+
+	if entry == nil {
+		return BindingResultError(ErrBindingCacheNilEntry)
+	}
+
+	switch binding.Name {
+	case JsonTagBinding:
+		var result BindingResult
+		entry.WriteData(func(data *JSONBodyOnce) {
+			result = data.jsonValue(source.Message, binding)
+		})
+		return result
+	case AttributeTagBinding:
+		return snsAttributeValue(source.MessageAttributes, binding.Identifier)
+	case SNSTagBinding:
+		return nonEmptyMapValue(snsMetadata(source), binding.Identifier)
+	default:
+		return BindingResultError(fmt.Errorf("%w: unknown binding: %s", ErrUnallowedBindingName, binding.Name))
+	}
+}
+
+func (mgr *SNSBindingManager) BindingHandler(source *events.SNSEntity, binding Binding) BindingResult {
+	return mgr.BindingHandlerCached(source, &CacheEntry[JSONBodyOnce]{}, binding)
+}
+
+func (mgr *SNSBindingManager) NewCached() JSONBodyOnce {
+	return JSONBodyOnce{}
+}
+
+// snsAttributeValue returns the value of the attribute name, which SNS
+// delivers as {"Type": "String", "Value": "..."}.
+func snsAttributeValue(attributes map[string]any, name string) BindingResult {
+	attribute, ok := attributes[name]
+	if !ok || attribute == nil {
+		return BindingResultNotFound()
+	}
+
+	fields, ok := attribute.(map[string]any)
+	if !ok {
+		return BindingResultError(fmt.Errorf("%w: attribute %s is a %T, not an object", ErrInvalidLambdaEvent, name, attribute))
+	}
+	value, ok := fields["Value"]
+	if !ok || value == nil {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(fmt.Sprint(value))
+}
+
+// snsMetadata returns the metadata of entity bound by the sns binding.
+func snsMetadata(entity *events.SNSEntity) map[string]string {
+	metadata := map[string]string{
+		"MessageId":        entity.MessageID,
+		"Type":             entity.Type,
+		"TopicArn":         entity.TopicArn,
+		"Subject":          entity.Subject,
+		"SignatureVersion": entity.SignatureVersion,
+		"Signature":        entity.Signature,
+		"SigningCertUrl":   entity.SigningCertURL,
+		"UnsubscribeUrl":   entity.UnsubscribeURL,
+	}
+	if !entity.Timestamp.IsZero() {
+		metadata["Timestamp"] = entity.Timestamp.Format(time.RFC3339Nano)
+	}
+	return metadata
+}
@@ -0,0 +1,168 @@
+//go:build pave_aws
+
+package parser
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIGatewayProxyParser(t *testing.T) {
+	type UpdateUser struct {
+		ID      int    `path:"id"`
+		Name    string `json:"name"`
+		Age     int    `json:"age"`
+		Trace   string `header:"X-Trace-Id"`
+		Fields  string `query:"fields"`
+		Dry     bool   `query:"dry,omitempty" default:"false"`
+		Session string `cookie:"session"`
+	}
+
+	event := &events.APIGatewayProxyRequest{
+		Resource:       "/users/{id}",
+		Path:           "/users/7",
+		HTTPMethod:     "PATCH",
+		PathParameters: map[string]string{"id": "7"},
+		MultiValueHeaders: map[string][]string{
+			"x-trace-id": {"abc"},
+			"Cookie":     {"session=s1"},
+		},
+		MultiValueQueryStringParameters: map[string][]string{"fields": {"name,age"}},
+		Body:                            `{"name":"Ada","age":36}`,
+	}
+
+	parser := NewAPIGatewayProxyParser()
+
+	var update UpdateUser
+	require.NoError(t, parser.Parse(event, &update))
+	assert.Equal(t, UpdateUser{
+		ID:      7,
+		Name:    "Ada",
+		Age:     36,
+		Trace:   "abc",
+		Fields:  "name,age",
+		Session: "s1",
+	}, update)
+
+	t.Run("SingleValue", func(t *testing.T) {
+		event := &events.APIGatewayProxyRequest{
+			HTTPMethod:            "GET",
+			Path:                  "/users/7",
+			Headers:               map[string]string{"X-Trace-Id": "def"},
+			QueryStringParameters: map[string]string{"dry": "true"},
+			PathParameters:        map[string]string{"id": "7"},
+			Body:                  base64.StdEncoding.EncodeToString([]byte(`{"name":"Bob"}`)),
+			IsBase64Encoded:       true,
+		}
+
+		var update struct {
+			ID    int    `path:"id"`
+			Name  string `json:"name"`
+			Trace string `header:"X-Trace-Id"`
+			Dry   bool   `query:"dry"`
+		}
+		require.NoError(t, parser.Parse(event, &update))
+		assert.Equal(t, 7, update.ID)
+		assert.Equal(t, "Bob", update.Name)
+		assert.Equal(t, "def", update.Trace)
+		assert.True(t, update.Dry)
+	})
+
+	t.Run("InvalidBody", func(t *testing.T) {
+		event := &events.APIGatewayProxyRequest{HTTPMethod: "POST", Body: "%%%", IsBase64Encoded: true}
+
+		var update struct {
+			Name string `json:"name"`
+		}
+		assert.ErrorIs(t, parser.Parse(event, &update), ErrInvalidLambdaEvent)
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		secret := []byte("secret")
+		body := `{"action":"opened"}`
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(body))
+
+		parser := NewAPIGatewayProxyParser()
+		parser.RegisterVerifier("github", HMACVerifier{Header: "X-Hub-Signature-256", Prefix: "sha256=", Secret: secret})
+
+		var hook struct {
+			Action string `json:"action,signed=github"`
+		}
+		newEvent := func(body string) *events.APIGatewayProxyRequest {
+			return &events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Headers:    map[string]string{"X-Hub-Signature-256": "sha256=" + hex.EncodeToString(mac.Sum(nil))},
+				Body:       body,
+			}
+		}
+		require.NoError(t, parser.Parse(newEvent(body), &hook))
+		assert.Equal(t, "opened", hook.Action)
+
+		err := parser.Parse(newEvent(`{"action":"closed"}`), &hook)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func TestSQSMessageParser(t *testing.T) {
+	type OrderPlaced struct {
+		OrderID  string `json:"order.id"`
+		Total    int    `json:"order.total"`
+		Tenant   string `attribute:"tenant"`
+		Receipts int    `sqs:"ApproximateReceiveCount"`
+		ID       string `sqs:"messageId"`
+		Group    string `sqs:"MessageGroupId,omitempty" default:"none"`
+	}
+
+	tenant := "acme"
+	message := &events.SQSMessage{
+		MessageId:         "m-1",
+		Body:              `{"order":{"id":"o-1","total":42}}`,
+		Attributes:        map[string]string{"ApproximateReceiveCount": "3"},
+		MessageAttributes: map[string]events.SQSMessageAttribute{"tenant": {StringValue: &tenant, DataType: "String"}},
+	}
+
+	var order OrderPlaced
+	require.NoError(t, NewSQSMessageParser().Parse(message, &order))
+	assert.Equal(t, OrderPlaced{OrderID: "o-1", Total: 42, Tenant: "acme", Receipts: 3, ID: "m-1", Group: "none"}, order)
+
+	err := NewSQSMessageParser().Parse(&events.SQSMessage{Body: `{}`}, &OrderPlaced{})
+	assert.Error(t, err)
+}
+
+func TestSNSEntityParser(t *testing.T) {
+	type UserDeleted struct {
+		UserID  string    `json:"user_id"`
+		Region  string    `attribute:"region"`
+		Topic   string    `sns:"TopicArn"`
+		Subject string    `sns:"Subject,omitempty" default:""`
+		At      time.Time `sns:"Timestamp"`
+	}
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	entity := &events.SNSEntity{
+		MessageID: "n-1",
+		TopicArn:  "arn:aws:sns:eu-west-1:123:users",
+		Timestamp: at,
+		Message:   `{"user_id":"u-1"}`,
+		MessageAttributes: map[string]any{
+			"region": map[string]any{"Type": "String", "Value": "eu"},
+		},
+	}
+
+	var deleted UserDeleted
+	require.NoError(t, NewSNSEntityParser().Parse(entity, &deleted))
+	assert.Equal(t, UserDeleted{UserID: "u-1", Region: "eu", Topic: "arn:aws:sns:eu-west-1:123:users", At: at}, deleted)
+
+	entity.MessageAttributes["region"] = "eu"
+	err := NewSNSEntityParser().Parse(entity, &UserDeleted{})
+	assert.ErrorIs(t, err, ErrInvalidLambdaEvent)
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Support for the HTTP trigger requests of Azure Functions custom
+// handlers, so that Go functions running on Azure parse the same tagged
+// structs as an HTTPRequestParser or APIGatewayProxyParser would.
+
+var (
+	ErrInvalidAzureRequest = errors.New("invalid azure http request")
+)
+
+// AzureHTTPRequest is the HTTP trigger request of an Azure Functions
+// custom handler invocation, found in its Data under the name of the
+// trigger binding (usually "req"):
+//
+//	var invocation struct {
+//		Data struct {
+//			Req pave.AzureHTTPRequest `json:"req"`
+//		}
+//	}
+type AzureHTTPRequest struct {
+	URL     string              `json:"Url"`
+	Method  string              `json:"Method"`
+	Query   map[string]string   `json:"Query"`
+	Headers map[string][]string `json:"Headers"`
+	Params  map[string]string   `json:"Params"`
+	Body    json.RawMessage     `json:"Body"` // A JSON string of the body, or the body itself
+}
+
+// HTTPRequest converts the request to the *http.Request it stands for.
+func (ar *AzureHTTPRequest) HTTPRequest() (*http.Request, error) {
+	body := []byte(ar.Body)
+	if len(body) > 0 && body[0] == '"' {
+		var text string
+		if err := json.Unmarshal(body, &text); err != nil {
+			return nil, fmt.Errorf("%w: body: %w", ErrInvalidAzureRequest, err)
+		}
+		body = []byte(text)
+	} else if string(body) == "null" {
+		body = nil
+	}
+
+	target, err := url.Parse(ar.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAzureRequest, err)
+	}
+	if len(ar.Query) > 0 {
+		query := target.Query()
+		for name, value := range ar.Query {
+			query.Set(name, value)
+		}
+		target.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequest(ar.Method, target.String(), io.NopCloser(bytes.NewReader(body)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAzureRequest, err)
+	}
+	for name, values := range ar.Headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	req.ContentLength = int64(len(body))
+	return req, nil
+}
+
+// AzureHTTPRequestParser parses the AzureHTTPRequest of an HTTP trigger.
+// It supports the bindings and modifiers of the HTTPRequestParser except
+// the trailer and range bindings, plus the path binding for the route
+// parameters of the function, e.g. `path:"id"` for the route users/{id}.
+type AzureHTTPRequestParser struct {
+	*BaseMBParser[AzureHTTPRequest, HTTPAdapterOnce]
+	bMgr *HTTPAdapterBindingManager[AzureHTTPRequest]
+}
+
+func NewAzureHTTPRequestParser() *AzureHTTPRequestParser {
+	bMgr := NewHTTPAdapterBindingManager(
+		(*AzureHTTPRequest).HTTPRequest,
+		func(req *AzureHTTPRequest) map[string]string { return req.Params },
+	)
+
+	return &AzureHTTPRequestParser{
+		BaseMBParser: NewBaseMBParser(bMgr, _httpAdapterParserOpts),
+		bMgr:         bMgr,
+	}
+}
+
+func (ap *AzureHTTPRequestParser) Name() string {
+	return AzureHTTPRequestParserName
+}
+
+// RegisterVerifier makes verifier available to the signed modifier of the
+// parser as name. See HTTPRequestParser.RegisterVerifier.
+func (ap *AzureHTTPRequestParser) RegisterVerifier(name string, verifier SignatureVerifier) {
+	ap.bMgr.RegisterVerifier(name, verifier)
+}
+
+// UnregisterVerifier removes the verifier registered as name, if any.
+func (ap *AzureHTTPRequestParser) UnregisterVerifier(name string) {
+	ap.bMgr.UnregisterVerifier(name)
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAzureHTTPRequestParser(t *testing.T) {
+	type UpdateUser struct {
+		ID     int    `path:"id"`
+		Name   string `json:"name"`
+		Age    int    `json:"age"`
+		Trace  string `header:"X-Trace-Id"`
+		Fields string `query:"fields"`
+		Dry    bool   `query:"dry,omitempty" default:"false"`
+	}
+
+	invocation := []byte(`{
+		"Data": {
+			"req": {
+				"Url": "http://localhost:7071/api/users/7?dry=true",
+				"Method": "PATCH",
+				"Query": {"fields": "name,age"},
+				"Headers": {"X-Trace-Id": ["abc"], "Content-Type": ["application/json"]},
+				"Params": {"id": "7"},
+				"Body": "{\"name\":\"Ada\",\"age\":36}"
+			}
+		},
+		"Metadata": {}
+	}`)
+
+	var payload struct {
+		Data struct {
+			Req AzureHTTPRequest `json:"req"`
+		}
+	}
+	require.NoError(t, json.Unmarshal(invocation, &payload))
+
+	parser := NewAzureHTTPRequestParser()
+
+	var update UpdateUser
+	require.NoError(t, parser.Parse(&payload.Data.Req, &update))
+	assert.Equal(t, UpdateUser{ID: 7, Name: "Ada", Age: 36, Trace: "abc", Fields: "name,age", Dry: true}, update)
+
+	t.Run("ObjectBody", func(t *testing.T) {
+		req := &AzureHTTPRequest{Method: "POST", URL: "http://localhost/api/users", Body: json.RawMessage(`{"name":"Bob"}`)}
+
+		var update struct {
+			Name string `json:"name"`
+		}
+		require.NoError(t, parser.Parse(req, &update))
+		assert.Equal(t, "Bob", update.Name)
+	})
+
+	t.Run("InvalidURL", func(t *testing.T) {
+		req := &AzureHTTPRequest{Method: "GET", URL: "://"}
+
+		var update struct {
+			Trace string `header:"X-Trace-Id"`
+		}
+		assert.ErrorIs(t, parser.Parse(req, &update), ErrInvalidAzureRequest)
+	})
+}
@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidBinaryBinding = errors.New("invalid binary binding")
+)
+
+// Byte orders of the endian modifier
+const (
+	BigEndian    string = "be"
+	LittleEndian string = "le"
+)
+
+var (
+	// Default BinaryParser Binding Options
+	_binaryTagOpts = ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				BinaryTagBinding,
+			},
+			CustomBindingModifiers: []string{
+				SignedBindingModifier,
+			},
+			ValueModifiers: map[string]ModifierValueParser{
+				LenBindingModifier:    parseBinaryLen,
+				EndianBindingModifier: parseEndian,
+			},
+		},
+		AllowedTagOptionals: []string{},
+	}
+
+	// Default BinaryParser Options
+	_binaryParserOpts = BaseMBParserOpts{
+		UseCache: false,
+		PCMOpts: PCManagerOpts{
+			tagOpts: _binaryTagOpts,
+		},
+	}
+)
+
+// BinaryParser parses fixed layout binary frames, e.g. the headers of a
+// network protocol, so that protocol decoders get the chain caching,
+// defaults and validation of the other parsers:
+//   - bin:'<offset=n,[modifiers]>'`: Parses the integer of len bytes
+//     (len=1, 2, 4 or 8, default 1) starting at byte n of the frame, in
+//     endian=be (default) or endian=le byte order. It is unsigned unless
+//     the signed modifier marks it as two's complement. The offset= prefix
+//     of the identifier is optional.
+//
+// For example:
+//
+//	type Header struct {
+//		Version uint8  `bin:"offset=0"`
+//		Flags   uint8  `bin:"offset=1"`
+//		Length  uint16 `bin:"offset=2,len=2"`
+//		Delta   int32  `bin:"offset=4,len=4,endian=le,signed"`
+//	}
+//
+// Integers past the end of the frame are not found, so that optional
+// trailing fields can be omitted.
+type BinaryParser struct {
+	*BaseMBParser[[]byte, struct{}]
+}
+
+func NewBinaryParser() *BinaryParser {
+	return &BinaryParser{
+		BaseMBParser: NewBaseMBParser(&BinaryBindingManager{}, _binaryParserOpts),
+	}
+}
+
+func (bp *BinaryParser) Name() string {
+	return BinaryParserName
+}
+
+// BinaryBindingManager gets the values of bin bindings from a binary
+// frame. See BinaryParser.
+type BinaryBindingManager struct{}
+
+func (mgr *BinaryBindingManager) BindingHandler(source *[]byte, binding Binding) BindingResult {
+	offset, err := strconv.Atoi(strings.TrimPrefix(binding.Identifier, OffsetBindingModifier+ModifierKeyValueDelimiter))
+	if err != nil || offset < 0 {
+		return BindingResultError(fmt.Errorf("%w: invalid offset %q", ErrInvalidBinaryBinding, binding.Identifier))
+	}
+
+	length := 1
+	if value, ok := ModifierValue[int](binding, LenBindingModifier); ok {
+		length = value
+	}
+
+	frame := *source
+	if offset+length > len(frame) {
+		return BindingResultNotFound()
+	}
+	bytes := frame[offset : offset+length]
+
+	var order binary.ByteOrder = binary.BigEndian
+	if endian, _ := ModifierValue[string](binding, EndianBindingModifier); endian == LittleEndian {
+		order = binary.LittleEndian
+	}
+
+	var value uint64
+	switch length {
+	case 1:
+		value = uint64(bytes[0])
+	case 2:
+		value = uint64(order.Uint16(bytes))
+	case 4:
+		value = uint64(order.Uint32(bytes))
+	case 8:
+		value = order.Uint64(bytes)
+	}
+
+	if !binding.Modifiers.Custom[SignedBindingModifier] {
+		return BindingResultValue(strconv.FormatUint(value, 10))
+	}
+
+	// Sign-extend the len byte integer
+	shift := 64 - 8*length
+	return BindingResultValue(strconv.FormatInt(int64(value<<shift)>>shift, 10))
+}
+
+func (mgr *BinaryBindingManager) BindingHandlerCached(
+	source *[]byte, _ *CacheEntry[struct{}], binding Binding,
+) BindingResult {
+	return mgr.BindingHandler(source, binding)
+}
+
+func (mgr *BinaryBindingManager) NewCached() struct{} {
+	return struct{}{}
+}
+
+// parseBinaryLen parses the len modifier, the size of a bound integer.
+func parseBinaryLen(value string) (any, error) {
+	length, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	switch length {
+	case 1, 2, 4, 8:
+		return length, nil
+	default:
+		return nil, fmt.Errorf("%w: len must be 1, 2, 4 or 8, not %d", ErrInvalidBinaryBinding, length)
+	}
+}
+
+// parseEndian parses the endian modifier, the byte order of a bound
+// integer.
+func parseEndian(value string) (any, error) {
+	switch value {
+	case BigEndian, LittleEndian:
+		return value, nil
+	default:
+		return nil, fmt.Errorf("%w: endian must be %s or %s, not %q", ErrInvalidBinaryBinding, BigEndian, LittleEndian, value)
+	}
+}
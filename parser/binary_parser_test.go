@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryParser(t *testing.T) {
+	type Header struct {
+		Version  uint8  `bin:"offset=0"`
+		Flags    uint8  `bin:"1"`
+		Length   uint16 `bin:"offset=2,len=2"`
+		Delta    int32  `bin:"offset=4,len=4,endian=le,signed"`
+		Sequence uint64 `bin:"offset=8,len=8"`
+		Checksum uint32 `bin:"offset=16,len=4,omitempty" default:"0"`
+	}
+
+	frame := []byte{
+		0x02, 0x81, // Version, Flags
+		0x01, 0x02, // Length
+		0xfe, 0xff, 0xff, 0xff, // Delta
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, // Sequence
+	}
+
+	parser := NewBinaryParser()
+
+	var header Header
+	require.NoError(t, parser.Parse(&frame, &header))
+	assert.Equal(t, Header{Version: 2, Flags: 0x81, Length: 0x0102, Delta: -2, Sequence: 256}, header)
+
+	t.Run("Signed", func(t *testing.T) {
+		var values struct {
+			Byte  int8  `bin:"offset=1,signed"`
+			Short int16 `bin:"offset=4,len=2,signed"`
+			Long  int64 `bin:"offset=8,len=8,signed"`
+		}
+		require.NoError(t, parser.Parse(&frame, &values))
+		assert.Equal(t, int8(-127), values.Byte)
+		assert.Equal(t, int16(-257), values.Short)
+		assert.Equal(t, int64(256), values.Long)
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		var values struct {
+			Flags int8 `bin:"offset=1"`
+		}
+		assert.Error(t, parser.Parse(&frame, &values), "unsigned 0x81 does not fit an int8")
+	})
+
+	t.Run("ShortFrame", func(t *testing.T) {
+		short := frame[:3]
+		err := parser.Parse(&short, &Header{})
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+	})
+
+	t.Run("InvalidBindings", func(t *testing.T) {
+		for _, dest := range []any{
+			&struct {
+				V uint8 `bin:"offset=x"`
+			}{},
+			&struct {
+				V uint8 `bin:"-1"`
+			}{},
+			&struct {
+				V uint32 `bin:"0,len=3"`
+			}{},
+			&struct {
+				V uint32 `bin:"0,len=4,endian=middle"`
+			}{},
+		} {
+			assert.ErrorIs(t, parser.Parse(&frame, dest), ErrInvalidBinaryBinding)
+		}
+	})
+}
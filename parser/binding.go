@@ -0,0 +1,209 @@
+package parser
+
+import "reflect"
+
+// Binding represents a complete view of a single possible value
+// binding for a field. Multiple Binding's are usually defined per field.
+type Binding struct {
+	Name       string           // The name of the binding method with the source type
+	Identifier string           // The identifier of this specific field on the binding method
+	Modifiers  BindingModifiers // Additional modifiers for the binding.
+	Direct     bool             // If true, the binding may be read straight from the source. See PCManagerOpts.DirectBindings.
+	Multi      bool             // If true, the field is a slice that may take every value of the binding as a []string.
+}
+
+// BindingModifiers represents all modifiers for a binding.
+//
+// The built-in modifiers control failure and fallback
+// behavior for a single binding. Custom modifiers can be used
+// however the BindingManager wishes to handle them.
+type BindingModifiers struct {
+	Required  bool            // If true, this is the final source to try. Error on not found.
+	OmitEmpty bool            // If true, skip this source if not found
+	OmitNil   bool            // If true, skip this source if the value is nil
+	OmitError bool            // If true, skip this source if an error occurs
+	AllowDup  bool            // If true, other fields of the struct may bind the same value
+	Flatten   bool            // If true, the nested struct does not scope the bindings of its fields
+	Scope     bool            // If true, the nested struct scopes the bindings of its fields, see ScopeBindingModifier
+	Custom    map[string]bool // Custom modifiers for parser-specific behavior
+	Values    map[string]any  // Parsed key=value custom modifiers, keyed by modifier name
+}
+
+// Value returns the parsed value of the key=value modifier name.
+func (m BindingModifiers) Value(name string) (any, bool) {
+	value, ok := m.Values[name]
+	return value, ok
+}
+
+// ModifierValue returns the parsed value of the key=value modifier name
+// of a binding as a T. It reports false if the modifier is not set or was
+// declared with a parser for a different type.
+func ModifierValue[T any](binding Binding, name string) (T, bool) {
+	value, ok := binding.Modifiers.Values[name].(T)
+	return value, ok
+}
+
+// ModifierValueParser parses the value of a key=value custom modifier,
+// e.g. the "2006-01-02" of `query:"since,layout=2006-01-02"`. It is
+// called once per binding at chain build time.
+type ModifierValueParser func(value string) (any, error)
+
+// TypedModifier returns a ModifierValueParser that converts the modifier
+// value to a T with the same rules used for field values.
+func TypedModifier[T any]() ModifierValueParser {
+	return func(value string) (any, error) {
+		var v T
+		if err := setFieldValue(reflect.ValueOf(&v).Elem(), value); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+type BindingOpts struct {
+	AllowedBindingNames    []string
+	CustomBindingModifiers []string
+	// ValueModifiers declares the allowed key=value custom modifiers and
+	// how their values are parsed, keyed by modifier name. Values cannot
+	// contain commas, as commas separate modifiers.
+	ValueModifiers map[string]ModifierValueParser
+}
+
+// BindingResult represents the result of a binding operation.
+//
+// It contains the value extracted from the source, a boolean indicating
+// whether the binding was successful, and an error if any occurred during
+// the binding operation.
+//
+// A binding may also produce multiple named sub-values at once (e.g. the
+// username and password of a basic auth header). These are carried in
+// Values and distributed by the executor to every field that selects one
+// of them with a `part:"<name>"` tag.
+type BindingResult struct {
+	Value  any
+	Values map[string]any
+	Found  bool
+	Error  error
+	Fatal  bool // If true, Error fails the field even if it is optional or has a default
+}
+
+// BindingResultNotFound creates a BindingResult indicating that
+// the binding was not found in the source.
+func BindingResultNotFound() BindingResult {
+	return BindingResult{
+		Value: nil,
+		Found: false,
+		Error: nil,
+	}
+}
+
+// BindingResultNil creates a BindingResult indicating that the binding
+// was found but the value is nil.
+func BindingResultError(err error) BindingResult {
+	return BindingResult{
+		Value: nil,
+		Found: false,
+		Error: err,
+	}
+}
+
+// BindingResultFatal creates a BindingResult for an error that no omit
+// modifier nor default value may recover from, such as a failed
+// signature verification.
+func BindingResultFatal(err error) BindingResult {
+	return BindingResult{
+		Value: nil,
+		Found: false,
+		Error: err,
+		Fatal: true,
+	}
+}
+
+// BindingResultValue creates a BindingResult indicating that the binding
+// was successful and the value was found in the source.
+func BindingResultValue(value any) BindingResult {
+	return BindingResult{
+		Value: value,
+		Found: true,
+		Error: nil,
+	}
+}
+
+// BindingResultValues creates a BindingResult indicating that the binding
+// was successful and produced multiple named sub-values.
+func BindingResultValues(values map[string]any) BindingResult {
+	return BindingResult{
+		Value:  nil,
+		Values: values,
+		Found:  true,
+		Error:  nil,
+	}
+}
+
+// Part returns the sub-value of the result selected by part. If part is
+// empty, the result's Value is returned. The second return value reports
+// whether the selected value exists.
+func (r BindingResult) Part(part string) (any, bool) {
+	if part == "" {
+		return r.Value, r.Found
+	}
+
+	value, ok := r.Values[part]
+	return value, r.Found && ok
+}
+
+// BindingHandlerFunc is a function type that defines how to handle a binding
+// operation for a specific source type. It takes a pointer to the source type
+// and a Binding, and returns the value extracted from the source, a boolean
+// indicating if the binding was successful, and an error if any occurred during
+// the binding operation.
+type BindingHandlerFunc[S any] func(
+	source *S,
+	binding Binding,
+) BindingResult
+
+// BindingHandlerCachedFunc is a function type that defines how to handle a binding
+// operation for a specific source type with caching. It takes a pointer to the
+// source type, a CacheEntry for the cached value, and a Binding, and returns
+// the value extracted from the source, a boolean indicating if the binding was
+// successful, and an error if any occurred during the binding operation.
+type BindingHandlerCachedFunc[S any, C any] func(
+	source *S,
+	entry *CacheEntry[C],
+	binding Binding,
+) BindingResult
+
+// BindingManager is responsible for managing the binding process for a specific
+// source type. It provides methods to create a new cache entry instance, as well
+// as to handle binding operations for both cached and non-cached scenarios.
+//
+// All implementations of this interface should be thread-safe, as well as
+// stateless.
+type BindingManager[Source any, Cached any] interface {
+	NewCached() Cached
+	BindingHandler(source *Source, binding Binding) BindingResult
+	BindingHandlerCached(source *Source, entry *CacheEntry[Cached], binding Binding) BindingResult
+}
+
+// CustomTagField is the view of a field that a CustomTagHandler receives
+// while its parse step is built.
+type CustomTagField struct {
+	Field    reflect.StructField // The struct field the step is built for
+	Tags     []CustomTag         // Custom tags of the field, in AllowedTagOptionals order
+	Bindings []Binding           // Bindings of the field. May be modified or replaced.
+	Metadata map[string]any      // Stored on the step as ParseStep.Metadata
+}
+
+// CustomTagHandler receives the custom tags (see
+// ParseTagOpts.AllowedTagOptionals) of every field that has any, at
+// chain build time. It may alter the field's bindings, e.g. to prefix the
+// identifiers of a field tagged `region:"eu"`, or attach metadata to the
+// field's step.
+//
+// A BindingManager that implements CustomTagHandler is used automatically
+// by NewBaseMBParser unless PCManagerOpts.CustomTagHandler is set.
+//
+// Returning an error fails building the chain.
+type CustomTagHandler interface {
+	HandleCustomTags(field *CustomTagField) error
+}
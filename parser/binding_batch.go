@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrBindingBatchResults = errors.New("binding batch handler returned the wrong number of results")
+)
+
+// BindingBatchHandler is implemented by BindingManagers whose backend can
+// get many values in a single round trip, such as Redis MGET or SSM
+// GetParameters. HandleBindingBatch receives the bindings of every field
+// of a destination (see ParseChain.Bindings) before any field is set, and
+// returns one result per binding, in the same order.
+//
+// The results are used for the fields of the parse in place of calls to
+// BindingHandler. Fallback bindings are part of the batch, even though
+// they are only used if the bindings before them find no value.
+//
+// A BindingManager that implements BindingBatchHandler is used
+// automatically by NewBaseMBParser.
+type BindingBatchHandler[S any] interface {
+	HandleBindingBatch(source *S, bindings []Binding) []BindingResult
+}
+
+// bindingKey identifies the value a binding gets from a source, whatever
+// its modifiers.
+type bindingKey struct {
+	name       string
+	identifier string
+}
+
+// Bindings returns the bindings of every step of the chain and its
+// sub-chains, in execution order. Bindings getting the same value (same
+// name and identifier) are only listed once.
+func (chain *ParseChain[S]) Bindings() []Binding {
+	var (
+		bindings []Binding
+		seen     = make(map[bindingKey]bool)
+	)
+
+	var collect func(chain *ParseChain[S])
+	collect = func(chain *ParseChain[S]) {
+		for current := chain.Head; current != nil; current = current.Next {
+			for _, binding := range current.Bindings {
+				key := bindingKey{binding.Name, binding.Identifier}
+				if !seen[key] {
+					seen[key] = true
+					bindings = append(bindings, binding)
+				}
+			}
+			if current.SubChain != nil {
+				collect(current.SubChain)
+			}
+		}
+	}
+	collect(chain)
+
+	return bindings
+}
+
+// prefetch gets the values of all bindings of chain from source through
+// the BindingBatchHandler of the parser, if it has one, and returns the
+// handler of the parse: one answering the bindings of the batch with its
+// results, and the others with handler (the Handler of chain if nil).
+// Without a BindingBatchHandler, handler is returned as is.
+//
+// The results belong to the parse, so that parses of the same source into
+// other destinations, even concurrent ones, get their own batch.
+func (base *BaseMBParser[S, C]) prefetch(
+	source *S, chain *ParseChain[S], handler BindingHandlerFunc[S],
+) (BindingHandlerFunc[S], error) {
+
+	batcher, ok := base.BMgr.(BindingBatchHandler[S])
+	if !ok {
+		return handler, nil
+	}
+
+	bindings := chain.Bindings()
+	if len(bindings) == 0 {
+		return handler, nil
+	}
+
+	results := batcher.HandleBindingBatch(source, bindings)
+	if len(results) != len(bindings) {
+		return nil, fmt.Errorf(
+			"%w: %d results for %d bindings",
+			ErrBindingBatchResults, len(results), len(bindings),
+		)
+	}
+
+	batch := make(map[bindingKey]BindingResult, len(bindings))
+	for i, binding := range bindings {
+		batch[bindingKey{binding.Name, binding.Identifier}] = results[i]
+	}
+
+	if handler == nil {
+		handler = chain.Handler
+	}
+	return func(source *S, binding Binding) BindingResult {
+		if result, ok := batch[bindingKey{binding.Name, binding.Identifier}]; ok {
+			return result
+		}
+		return handler(source, binding)
+	}, nil
+}
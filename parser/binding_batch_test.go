@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchBindingManager reads values from a string map like a multi-get
+// backend, counting single and batched round trips.
+type batchBindingManager struct {
+	regionBindingManager
+	singles  atomic.Int32
+	batches  atomic.Int32
+	dropLast bool
+}
+
+func (m *batchBindingManager) BindingHandler(source *map[string]string, binding Binding) BindingResult {
+	m.singles.Add(1)
+	return m.regionBindingManager.BindingHandler(source, binding)
+}
+
+func (m *batchBindingManager) BindingHandlerCached(
+	source *map[string]string, _ *CacheEntry[struct{}], binding Binding,
+) BindingResult {
+	return m.BindingHandler(source, binding)
+}
+
+func (m *batchBindingManager) HandleBindingBatch(source *map[string]string, bindings []Binding) []BindingResult {
+	m.batches.Add(1)
+
+	results := make([]BindingResult, 0, len(bindings))
+	for _, binding := range bindings {
+		results = append(results, m.regionBindingManager.BindingHandler(source, binding))
+	}
+	if m.dropLast {
+		results = results[:len(results)-1]
+	}
+	return results
+}
+
+func newBatchParser(m *batchBindingManager) *BaseMBParser[map[string]string, struct{}] {
+	return NewBaseMBParser[map[string]string, struct{}](m, BaseMBParserOpts{
+		PCMOpts: NewPCManagerOpts(ParseTagOpts{
+			BindingOpts: BindingOpts{AllowedBindingNames: []string{MapValueTagBinding}},
+		}),
+	})
+}
+
+type batchAddress struct {
+	City string `mapvalue:"city"`
+}
+
+type batchConfig struct {
+	Host    string `mapvalue:"host"`
+	Port    int    `mapvalue:"port,omitempty" default:"8080"`
+	Backup  string `mapvalue:"host,allowdup"`
+	Address batchAddress
+}
+
+// batchGate is converted by a TypeConverter that the tests hold.
+type batchGate string
+
+type batchGated struct {
+	Host batchGate `mapvalue:"host"`
+	City string    `mapvalue:"city"`
+}
+
+func TestParseChain_Bindings(t *testing.T) {
+	chain, err := newBatchParser(&batchBindingManager{}).PCMgr.GetParseChain(reflect.TypeFor[batchConfig]())
+	require.NoError(t, err)
+
+	var identifiers []string
+	for _, binding := range chain.Bindings() {
+		identifiers = append(identifiers, binding.Identifier)
+	}
+	assert.Equal(t, []string{"host", "port", "city"}, identifiers)
+}
+
+func TestBaseMBParser_BindingBatchHandler(t *testing.T) {
+	source := map[string]string{"host": "example.com", "city": "Berlin"}
+
+	t.Run("SingleRoundTrip", func(t *testing.T) {
+		m := &batchBindingManager{}
+		parser := newBatchParser(m)
+
+		var config batchConfig
+		require.NoError(t, parser.Parse(&source, &config))
+
+		assert.Equal(t, batchConfig{
+			Host:    "example.com",
+			Port:    8080,
+			Backup:  "example.com",
+			Address: batchAddress{City: "Berlin"},
+		}, config)
+		assert.EqualValues(t, 1, m.batches.Load())
+		assert.Zero(t, m.singles.Load())
+	})
+
+	t.Run("ConcurrentDestinations", func(t *testing.T) {
+		m := &batchBindingManager{}
+		parser := newBatchParser(m)
+
+		// The first parse is held between its fields while the same
+		// source is parsed into another type
+		started, proceed := make(chan struct{}), make(chan struct{})
+		RegisterTypeConverter(func(value string) (batchGate, error) {
+			close(started)
+			<-proceed
+			return batchGate(value), nil
+		})
+		t.Cleanup(UnregisterTypeConverter[batchGate])
+
+		var (
+			gated batchGated
+			err   error
+			done  = make(chan struct{})
+		)
+		go func() {
+			defer close(done)
+			err = parser.Parse(&source, &gated)
+		}()
+
+		<-started
+		var config batchConfig
+		require.NoError(t, parser.Parse(&source, &config))
+		close(proceed)
+		<-done
+
+		require.NoError(t, err)
+		assert.Equal(t, batchGated{Host: "example.com", City: "Berlin"}, gated)
+		assert.EqualValues(t, 2, m.batches.Load())
+		assert.Zero(t, m.singles.Load(), "every binding is answered by the batch of its parse")
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		m := &batchBindingManager{}
+		parser := newBatchParser(m)
+
+		var config batchConfig
+		report, err := parser.ParseWithReport(&source, &config)
+		require.NoError(t, err)
+
+		assert.Equal(t, "example.com", config.Host)
+		assert.Positive(t, report.BatchDuration)
+		assert.Contains(t, report.String(), "binding batch")
+		assert.Zero(t, m.singles.Load())
+	})
+
+	t.Run("WrongResultCount", func(t *testing.T) {
+		m := &batchBindingManager{dropLast: true}
+
+		var config batchConfig
+		err := newBatchParser(m).Parse(&source, &config)
+		assert.ErrorIs(t, err, ErrBindingBatchResults)
+		assert.Empty(t, config.Host)
+	})
+}
@@ -1,4 +1,4 @@
-package pave
+package parser
 
 import (
 	"errors"
@@ -68,6 +68,16 @@ func (bc *BindingCache[S, C]) Delete(source *S) {
 	bc.cache.Delete(source)
 }
 
+// Len returns the number of cached sources
+func (bc *BindingCache[S, C]) Len() int {
+	n := 0
+	bc.cache.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
 // Clear removes all cache entries
 func (bc *BindingCache[S, C]) Clear() {
 	// Create a new map instead of iterating
@@ -1,4 +1,4 @@
-package pave
+package parser
 
 import (
 	"testing"
@@ -99,9 +99,11 @@ func TestBindingCache(t *testing.T) {
 		assert.True(t, exists2)
 		assert.NotNil(t, entry1)
 		assert.NotNil(t, entry2)
+		assert.Equal(t, 2, cache.Len())
 
 		// Clear all
 		cache.Clear()
+		assert.Zero(t, cache.Len())
 
 		// Should not exist now
 		entry1, exists1 = cache.Get(sourcePtr1)
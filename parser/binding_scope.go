@@ -0,0 +1,100 @@
+package parser
+
+import "slices"
+
+// ScopeFunc joins the identifier of a scoping struct field (prefix) with
+// the identifier of a binding on one of its nested fields.
+//
+// Example: with ConcatScope registered for the query binding,
+//
+//	type Request struct {
+//		Paging struct {
+//			Page int `query:"page"`
+//			Size int `query:"size"`
+//		} `query:"paging_"`
+//	}
+//
+// binds Page to the query parameter "paging_page" and Size to "paging_size".
+// Bindings without a registered ScopeFunc only scope the fields of
+// nested structs whose binding carries the scope modifier, joining the
+// identifiers with DotScope, e.g. `json:"city"` within a field tagged
+// `json:"address,scope"` binds to "address.city".
+type ScopeFunc func(prefix string, identifier string) string
+
+// ConcatScope joins prefix and identifier without a separator.
+func ConcatScope(prefix string, identifier string) string {
+	return prefix + identifier
+}
+
+// DotScope joins prefix and identifier with a dot, for bindings whose
+// identifiers are dotted paths.
+func DotScope(prefix string, identifier string) string {
+	return prefix + "." + identifier
+}
+
+// bindingScopes holds the active identifier prefix per binding name
+// while building a scoped sub-chain.
+type bindingScopes map[string]string
+
+// lookupScopeFunc returns the ScopeFunc of the binding name, DotScope if
+// none is registered.
+func lookupScopeFunc(scopeFuncs map[string]ScopeFunc, name string) (ScopeFunc, bool) {
+	if scopeFunc, ok := scopeFuncs[name]; ok {
+		return scopeFunc, true
+	}
+	return DotScope, false
+}
+
+// child returns the scopes of a nested struct whose field carries
+// bindingTags. Bindings with a registered ScopeFunc or the scope modifier
+// extend (or start) the scope for their binding name, unless they carry
+// the flatten modifier; all other scopes are inherited as-is.
+func (scopes bindingScopes) child(
+	bindingTags []BindingTag,
+	scopeFuncs map[string]ScopeFunc,
+) bindingScopes {
+
+	var child bindingScopes
+
+	for _, tag := range bindingTags {
+		scopeFunc, registered := lookupScopeFunc(scopeFuncs, tag.Name)
+		if !registered && !slices.Contains(tag.Modifiers, ScopeBindingModifier) ||
+			slices.Contains(tag.Modifiers, FlattenBindingModifier) {
+			continue
+		}
+
+		if child == nil {
+			child = make(bindingScopes, len(scopes)+1)
+			for name, prefix := range scopes {
+				child[name] = prefix
+			}
+		}
+
+		if prefix, scoped := scopes[tag.Name]; scoped {
+			child[tag.Name] = scopeFunc(prefix, tag.Identifier)
+		} else {
+			child[tag.Name] = tag.Identifier
+		}
+	}
+
+	if child == nil {
+		return scopes
+	}
+	return child
+}
+
+// apply rewrites the identifiers of bindings that are within a scope.
+func (scopes bindingScopes) apply(bindings []Binding, scopeFuncs map[string]ScopeFunc) {
+	if len(scopes) == 0 {
+		return
+	}
+
+	for i := range bindings {
+		prefix, scoped := scopes[bindings[i].Name]
+		if !scoped {
+			continue
+		}
+		scopeFunc, _ := lookupScopeFunc(scopeFuncs, bindings[i].Name)
+		bindings[i].Identifier = scopeFunc(prefix, bindings[i].Identifier)
+	}
+}
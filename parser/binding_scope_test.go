@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindingScopes(t *testing.T) {
+	scopeFuncs := map[string]ScopeFunc{QueryTagBinding: ConcatScope}
+
+	t.Run("ChildStartsScope", func(t *testing.T) {
+		var scopes bindingScopes
+		child := scopes.child([]BindingTag{{Name: QueryTagBinding, Identifier: "paging_"}}, scopeFuncs)
+		assert.Equal(t, bindingScopes{QueryTagBinding: "paging_"}, child)
+	})
+
+	t.Run("ChildExtendsScope", func(t *testing.T) {
+		scopes := bindingScopes{QueryTagBinding: "outer_"}
+		child := scopes.child([]BindingTag{{Name: QueryTagBinding, Identifier: "inner_"}}, scopeFuncs)
+		assert.Equal(t, "outer_inner_", child[QueryTagBinding])
+		assert.Equal(t, "outer_", scopes[QueryTagBinding], "parent scopes must not be modified")
+	})
+
+	t.Run("UnscopedBindingIgnored", func(t *testing.T) {
+		var scopes bindingScopes
+		child := scopes.child([]BindingTag{{Name: HeaderTagBinding, Identifier: "X-"}}, scopeFuncs)
+		assert.Empty(t, child)
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		scopes := bindingScopes{QueryTagBinding: "paging_"}
+		bindings := []Binding{
+			{Name: QueryTagBinding, Identifier: "page"},
+			{Name: HeaderTagBinding, Identifier: "X-Page"},
+		}
+
+		scopes.apply(bindings, scopeFuncs)
+		assert.Equal(t, "paging_page", bindings[0].Identifier)
+		assert.Equal(t, "X-Page", bindings[1].Identifier)
+	})
+}
+
+func TestHTTPRequestParser_ScopedQueryStruct(t *testing.T) {
+	type Paging struct {
+		Page int `query:"page,omitempty" default:"1"`
+		Size int `query:"size" header:"X-Page-Size,omitempty"`
+	}
+
+	type Sorting struct {
+		Field string `query:"field"`
+	}
+
+	type ListRequest struct {
+		Paging   Paging  `query:"paging_"`
+		Sorting  Sorting `query:"sort_"`
+		Unscoped Paging
+	}
+
+	req := newTestRequest(t, "GET",
+		"http://example.com/?paging_page=3&paging_size=50&sort_field=name&page=9&size=10", nil)
+
+	var result ListRequest
+	err := NewHTTPRequestParser().Parse(req, &result)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Paging.Page)
+	assert.Equal(t, 50, result.Paging.Size)
+	assert.Equal(t, "name", result.Sorting.Field)
+	assert.Equal(t, 9, result.Unscoped.Page)
+	assert.Equal(t, 10, result.Unscoped.Size)
+}
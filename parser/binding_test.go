@@ -1,4 +1,4 @@
-package pave
+package parser
 
 import (
 	"errors"
@@ -34,4 +34,31 @@ func TestBindingResult(t *testing.T) {
 		assert.True(t, result.Found)
 		assert.Nil(t, result.Error)
 	})
+
+	t.Run("BindingResultValues", func(t *testing.T) {
+		result := BindingResultValues(map[string]any{"start": 0, "end": 99})
+
+		assert.Nil(t, result.Value)
+		assert.True(t, result.Found)
+		assert.Nil(t, result.Error)
+		assert.Len(t, result.Values, 2)
+	})
+
+	t.Run("Part", func(t *testing.T) {
+		result := BindingResultValues(map[string]any{"start": 0})
+
+		value, ok := result.Part("start")
+		assert.True(t, ok)
+		assert.Equal(t, 0, value)
+
+		_, ok = result.Part("end")
+		assert.False(t, ok)
+
+		value, ok = BindingResultValue("whole").Part("")
+		assert.True(t, ok)
+		assert.Equal(t, "whole", value)
+
+		_, ok = BindingResultNotFound().Part("start")
+		assert.False(t, ok)
+	})
 }
@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// The bit modifier unpacks a single bit of an integer binding into a bool
+// field, e.g. the flags of a feature-flag mask or a hardware status word.
+// Several fields bind the same integer with different bits:
+//
+//	type Status struct {
+//		Ready bool `query:"status,bit=0"`
+//		Error bool `query:"status,bit=3"`
+//	}
+//
+// Bits are numbered from the least significant bit, 0. Integers may be
+// written in any base strconv.ParseUint understands, e.g. 0x0f or 0b1001.
+
+// parseBitModifier parses the value of the bit modifier, the position of
+// a bit in a 64 bit integer.
+func parseBitModifier(value string) (any, error) {
+	bit, err := strconv.ParseUint(value, 10, 8)
+	if err != nil || bit > 63 {
+		return nil, fmt.Errorf("bit must be between 0 and 63, not %q", value)
+	}
+	return uint(bit), nil
+}
+
+// transformBit rewrites an integer value to whether its bit param is set.
+func transformBit(value string, param any, _ Binding) (string, error) {
+	mask, err := strconv.ParseUint(value, 0, 64)
+	if err != nil {
+		// Masks of signed integers, e.g. the int32 status words of some
+		// devices, keep their two's complement bits.
+		signed, signedErr := strconv.ParseInt(value, 0, 64)
+		if signedErr != nil {
+			return "", fmt.Errorf("%q is not an integer", value)
+		}
+		mask = uint64(signed)
+	}
+
+	return strconv.FormatBool(mask&(1<<param.(uint)) != 0), nil
+}
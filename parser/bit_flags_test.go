@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitModifier(t *testing.T) {
+	type Features struct {
+		Beta    bool `query:"flags,bit=0"`
+		Dark    bool `query:"flags,bit=1"`
+		Export  bool `query:"flags,bit=3"`
+		Preview bool `query:"flags,bit=63,omitempty" default:"false"`
+	}
+
+	parser := NewHTTPRequestParser()
+
+	for query, want := range map[string]Features{
+		"flags=9":                   {Beta: true, Export: true},
+		"flags=0x02":                {Dark: true},
+		"flags=0b1011":              {Beta: true, Dark: true, Export: true},
+		"flags=-1":                  {Beta: true, Dark: true, Export: true, Preview: true},
+		"flags=9223372036854775808": {Preview: true},
+	} {
+		var features Features
+		require.NoError(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/settings?"+query, nil), &features), query)
+		assert.Equal(t, want, features, query)
+	}
+
+	err := parser.Parse(newTestRequest(t, "GET", "http://example.com/settings?", nil), &Features{})
+	assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+
+	err = parser.Parse(newTestRequest(t, "GET", "http://example.com/settings?flags=on", nil), &Features{})
+	assert.ErrorIs(t, err, ErrInvalidModifierValue)
+
+	t.Run("Binary", func(t *testing.T) {
+		var status struct {
+			Ready bool `bin:"offset=0,bit=7"`
+			Fault bool `bin:"offset=1,len=2,bit=8"`
+		}
+		frame := []byte{0x80, 0x01, 0x00}
+		require.NoError(t, NewBinaryParser().Parse(&frame, &status))
+		assert.True(t, status.Ready)
+		assert.True(t, status.Fault)
+	})
+
+	t.Run("InvalidBit", func(t *testing.T) {
+		for _, dest := range []any{
+			&struct {
+				V bool `query:"flags,bit=64"`
+			}{},
+			&struct {
+				V bool `query:"flags,bit=x"`
+			}{},
+		} {
+			err := parser.Parse(newTestRequest(t, "GET", "http://example.com/settings?flags=1", nil), dest)
+			assert.ErrorIs(t, err, ErrInvalidModifierValue)
+		}
+	})
+}
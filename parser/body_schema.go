@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+var (
+	ErrBodySchema = errors.New("body does not match schema")
+)
+
+// BodySchema is a compiled JSON Schema that the bodies of requests are
+// validated against before any field is extracted from them, for earlier
+// and more structural errors (wrong types, missing objects) than those of
+// the fields. The Schema of github.com/santhosh-tekuri/jsonschema
+// implements it, for instance. See HTTPRequestParser.RegisterBodySchema.
+type BodySchema interface {
+	// Validate validates the decoded JSON document of a body: nil, a
+	// bool, json.Number, string, []any or map[string]any.
+	Validate(document any) error
+}
+
+// BodySchemaFunc adapts a function to the BodySchema interface.
+type BodySchemaFunc func(document any) error
+
+func (f BodySchemaFunc) Validate(document any) error {
+	return f(document)
+}
+
+// RegisterBodySchema makes the parser validate the JSON bodies of the
+// requests it parses into values of typ against schema first. Bodies that
+// are invalid JSON, or do not match the schema, fail the parse with
+// ErrBodySchema wrapping the error of the schema, so that its details
+// remain available through errors.As. Empty bodies are validated as an
+// empty object, as json bindings see them.
+//
+// Registering a schema for an already registered type replaces it.
+func (hp *HTTPRequestParser) RegisterBodySchema(typ reflect.Type, schema BodySchema) {
+	mgr := hp.bindingManager()
+	mgr.schemasMu.Lock()
+	defer mgr.schemasMu.Unlock()
+
+	if mgr.schemas == nil {
+		mgr.schemas = make(map[reflect.Type]BodySchema)
+	}
+	mgr.schemas[typ] = schema
+}
+
+// UnregisterBodySchema removes the schema registered for typ, if any.
+func (hp *HTTPRequestParser) UnregisterBodySchema(typ reflect.Type) {
+	mgr := hp.bindingManager()
+	mgr.schemasMu.Lock()
+	defer mgr.schemasMu.Unlock()
+
+	delete(mgr.schemas, typ)
+}
+
+// Parse validates the body of source against the schema registered for
+// the type of dest, if any, then parses source into dest. See
+// BaseMBParser.Parse.
+func (hp *HTTPRequestParser) Parse(source any, dest any) error {
+	if err := hp.validateBody(source, dest); err != nil {
+		return err
+	}
+	return hp.BaseMBParser.Parse(source, dest)
+}
+
+// ParseMasked is Parse, populating only the fields of dest that paths
+// select. See MaskedParser.
+func (hp *HTTPRequestParser) ParseMasked(source any, dest any, paths []string) error {
+	if err := hp.validateBody(source, dest); err != nil {
+		return err
+	}
+	return hp.BaseMBParser.ParseMasked(source, dest, paths)
+}
+
+// ParseWithReport is Parse, also timing the parse of every field of
+// dest. See BaseMBParser.ParseWithReport.
+func (hp *HTTPRequestParser) ParseWithReport(source any, dest any) (*ParseReport, error) {
+	if err := hp.validateBody(source, dest); err != nil {
+		return &ParseReport{Type: reflect.TypeOf(dest).Elem()}, err
+	}
+	return hp.BaseMBParser.ParseWithReport(source, dest)
+}
+
+// validateBody validates the body of source against the schema registered
+// for the type of dest, if any. Invalid arguments are left to the parse
+// to report.
+func (hp *HTTPRequestParser) validateBody(source any, dest any) error {
+	req, ok := source.(*http.Request)
+	if !ok || checkDest(dest) != nil {
+		return nil
+	}
+
+	mgr := hp.bindingManager()
+	mgr.schemasMu.RLock()
+	schema, ok := mgr.schemas[reflect.TypeOf(dest).Elem()]
+	mgr.schemasMu.RUnlock()
+	if !ok || schema == nil {
+		return nil
+	}
+
+	// Read the body through the binding cache, as json bindings will
+	var (
+		body []byte
+		err  error
+	)
+	entry := hp.BCache.GetOrCreate(req, mgr.NewCached)
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		body, err = mgr.readBodyOnce(req, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	document, err := decodeBodyDocument(body)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBodySchema, err)
+	}
+	if err := schema.Validate(document); err != nil {
+		return fmt.Errorf("%w: %w", ErrBodySchema, err)
+	}
+	return nil
+}
+
+// decodeBodyDocument decodes a JSON body for a BodySchema, keeping the
+// precision of numbers.
+func decodeBodyDocument(body []byte) (any, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return map[string]any{}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("invalid JSON: data after top-level value")
+	}
+	return document, nil
+}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaError is the error of testSchema, standing for the detailed
+// errors of JSON Schema libraries.
+type schemaError struct {
+	Location string
+}
+
+func (e *schemaError) Error() string {
+	return fmt.Sprintf("at %s: expected object", e.Location)
+}
+
+// testSchema requires an object with a "user" object.
+var testSchema = BodySchemaFunc(func(document any) error {
+	root, ok := document.(map[string]any)
+	if !ok {
+		return &schemaError{Location: "/"}
+	}
+	if _, ok := root["user"].(map[string]any); !ok {
+		return &schemaError{Location: "/user"}
+	}
+	return nil
+})
+
+func TestHTTPRequestParser_BodySchema(t *testing.T) {
+	type CreateUser struct {
+		Name string `json:"user.name,omitempty" default:""`
+		Age  int    `json:"user.age,omitempty" default:"0"`
+	}
+
+	newRequest := func(t *testing.T, body string) *http.Request {
+		req := newTestRequest(t, "POST", "http://example.com/users", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	parser := NewHTTPRequestParser()
+	parser.RegisterBodySchema(reflect.TypeFor[CreateUser](), testSchema)
+
+	t.Run("Valid", func(t *testing.T) {
+		var user CreateUser
+		require.NoError(t, parser.Parse(newRequest(t, `{"user": {"name": "jane", "age": 41}}`), &user))
+		assert.Equal(t, CreateUser{Name: "jane", Age: 41}, user, "the body is still read by the fields")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var user CreateUser
+		err := parser.Parse(newRequest(t, `{"user": "jane"}`), &user)
+		assert.ErrorIs(t, err, ErrBodySchema)
+
+		var details *schemaError
+		require.True(t, errors.As(err, &details))
+		assert.Equal(t, "/user", details.Location)
+		assert.Empty(t, user.Name, "no field is extracted from invalid bodies")
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		var user CreateUser
+		report, err := parser.ParseWithReport(newRequest(t, `[]`), &user)
+		assert.ErrorIs(t, err, ErrBodySchema)
+		require.NotNil(t, report)
+		assert.Equal(t, reflect.TypeFor[CreateUser](), report.Type)
+	})
+
+	t.Run("InvalidJSON", func(t *testing.T) {
+		var user CreateUser
+		err := parser.Parse(newRequest(t, `{"user": {}} trailing`), &user)
+		assert.ErrorIs(t, err, ErrBodySchema)
+	})
+
+	t.Run("EmptyBody", func(t *testing.T) {
+		var user CreateUser
+		err := parser.Parse(newRequest(t, ""), &user)
+		assert.ErrorIs(t, err, ErrBodySchema, "empty bodies are empty objects")
+	})
+
+	t.Run("Numbers", func(t *testing.T) {
+		var number any
+		numbers := NewHTTPRequestParser()
+		numbers.RegisterBodySchema(reflect.TypeFor[CreateUser](), BodySchemaFunc(func(document any) error {
+			number = document.(map[string]any)["user"].(map[string]any)["age"]
+			return nil
+		}))
+
+		var user CreateUser
+		require.NoError(t, numbers.Parse(newRequest(t, `{"user": {"age": 41}}`), &user))
+		assert.Equal(t, json.Number("41"), number)
+	})
+
+	t.Run("OtherTypes", func(t *testing.T) {
+		type Other struct {
+			Name string `json:"name"`
+		}
+
+		var other Other
+		require.NoError(t, parser.Parse(newRequest(t, `{"name": "jane"}`), &other))
+
+		parser.UnregisterBodySchema(reflect.TypeFor[CreateUser]())
+		var user CreateUser
+		require.NoError(t, parser.Parse(newRequest(t, `{"user": "jane"}`), &user))
+	})
+}
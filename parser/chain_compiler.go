@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrFailedToCompileChain = errors.New("failed to compile parse chain for type")
+)
+
+// ChainCompiler compiles the parse chains of a PCManager into alternative
+// executors, e.g. generated code, bytecode-style programs or a vectorized
+// executor, reusing the tag decoding and caching of the PCManager.
+//
+// Compile receives each chain once it is built, before it is cached: its
+// tags are decoded, its bindings scoped and its sub-chains built. The
+// chain is shared and must not be modified. The executor returned is
+// cached with the chain as its Executor, and runs in place of the steps
+// of the chain. Returning a nil executor leaves the chain interpreted.
+//
+// Chains are still interpreted step by step when a ParseReport times
+// their steps, and once masked or derived (see ParseChain.Derive) until
+// the PCManager compiles them again.
+type ChainCompiler[S any] interface {
+	Compile(chain *ParseChain[S]) (ChainExecutor[S], error)
+}
+
+// ChainCompilerFunc adapts an ordinary function to a ChainCompiler.
+type ChainCompilerFunc[S any] func(chain *ParseChain[S]) (ChainExecutor[S], error)
+
+// Compile calls compile(chain).
+func (compile ChainCompilerFunc[S]) Compile(chain *ParseChain[S]) (ChainExecutor[S], error) {
+	return compile(chain)
+}
+
+// ChainExecutor populates dest, a pointer to the destination struct of
+// the chain it was compiled from, with the values of its bindings in
+// source. Values are read with handler, which may differ between calls
+// (e.g. when replaying a SourceSnapshot), so executors must not keep the
+// Handler of the chain.
+//
+// Executors are called concurrently and must behave as the steps of the
+// chain do, reporting errors of fields as FieldErrors. The chain applies
+// the defaults of fields without bindings and verifies checksums once
+// its executor returns.
+type ChainExecutor[S any] interface {
+	Execute(source *S, dest any, handler BindingHandlerFunc[S]) error
+}
+
+// ChainExecutorFunc adapts an ordinary function to a ChainExecutor.
+type ChainExecutorFunc[S any] func(source *S, dest any, handler BindingHandlerFunc[S]) error
+
+// Execute calls execute(source, dest, handler).
+func (execute ChainExecutorFunc[S]) Execute(source *S, dest any, handler BindingHandlerFunc[S]) error {
+	return execute(source, dest, handler)
+}
+
+// Interpret runs the steps of the chain and its sub-chains one by one,
+// getting the values of bindings with handler, even if they are compiled.
+// ChainExecutors fall back to it for the chains they do not compile.
+func (chain *ParseChain[S]) Interpret(source *S, dest any, handler BindingHandlerFunc[S]) error {
+	if handler == nil {
+		handler = chain.Handler
+	}
+	return chain.execute(source, dest, execution[S]{handler: handler, steps: true}, "")
+}
+
+// compile sets the Executor of chain with the Compiler of the manager, if
+// any and the chain has none yet.
+func (cman *PCManager[S]) compile(chain *ParseChain[S]) error {
+	if cman.Compiler == nil || chain.Executor != nil {
+		return nil
+	}
+
+	executor, err := cman.Compiler.Compile(chain)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrFailedToCompileChain, chain.StructType, err)
+	}
+	chain.Executor = executor
+	return nil
+}
+
+// SetChainCompiler sets the Compiler of the manager. Cached chains are
+// dropped, as they were compiled by the previous one.
+func (cman *PCManager[S]) SetChainCompiler(compiler ChainCompiler[S]) {
+	cman.CMutex.Lock()
+	defer cman.CMutex.Unlock()
+
+	cman.Compiler = compiler
+	cman.Chains = make(map[reflect.Type]*ParseChain[S])
+	cman.lruEntries = nil
+	cman.cacheBytes = 0
+}
+
+// SetChainCompiler sets the ChainCompiler of the parse chains of the
+// parser, versioned ones included. See ChainCompiler.
+func (base *BaseMBParser[S, C]) SetChainCompiler(compiler ChainCompiler[S]) {
+	base.PCMgr.SetChainCompiler(compiler)
+	for _, pcm := range base.versioned {
+		pcm.SetChainCompiler(compiler)
+	}
+}
@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type compiledAddress struct {
+	City string `query:"city,omitempty" default:"Oslo"`
+}
+
+type compiledRequest struct {
+	Name    string          `query:"name"`
+	Address compiledAddress `query:"address_"`
+}
+
+// countingCompiler compiles chains into executors that interpret them,
+// counting the executions.
+func countingCompiler(executions *atomic.Int32) ChainCompiler[http.Request] {
+	return ChainCompilerFunc[http.Request](func(chain *ParseChain[http.Request]) (ChainExecutor[http.Request], error) {
+		return ChainExecutorFunc[http.Request](func(source *http.Request, dest any, handler BindingHandlerFunc[http.Request]) error {
+			executions.Add(1)
+			return chain.Interpret(source, dest, handler)
+		}), nil
+	})
+}
+
+func newCompiledRequest(t testing.TB) *http.Request {
+	req := newTestRequest(t, "GET", "http://example.com/?name=jane&address_city=Bergen", nil)
+	return req
+}
+
+func TestPCManager_ChainCompiler(t *testing.T) {
+	t.Run("Compiled", func(t *testing.T) {
+		var executions atomic.Int32
+		parser := NewHTTPRequestParser()
+		parser.SetChainCompiler(countingCompiler(&executions))
+
+		var dest compiledRequest
+		require.NoError(t, parser.Parse(newCompiledRequest(t), &dest))
+		assert.Equal(t, compiledRequest{Name: "jane", Address: compiledAddress{City: "Bergen"}}, dest)
+		assert.Equal(t, int32(1), executions.Load(), "sub-chains are interpreted by the executor")
+
+		report, err := parser.ParseWithReport(newCompiledRequest(t), &compiledRequest{})
+		require.NoError(t, err)
+		assert.Len(t, report.Steps, 2)
+		assert.Equal(t, int32(1), executions.Load(), "timed steps are interpreted")
+
+		require.NoError(t, parser.ParseMasked(newCompiledRequest(t), &compiledRequest{}, []string{"Name"}))
+		assert.Equal(t, int32(1), executions.Load(), "masked chains are interpreted")
+	})
+
+	t.Run("Generated", func(t *testing.T) {
+		compiler := ChainCompilerFunc[http.Request](func(chain *ParseChain[http.Request]) (ChainExecutor[http.Request], error) {
+			step, ok := chain.Step("City")
+			if !ok {
+				return nil, nil
+			}
+			binding := step.Bindings[0]
+
+			return ChainExecutorFunc[http.Request](func(source *http.Request, dest any, handler BindingHandlerFunc[http.Request]) error {
+				result := handler(source, binding)
+				if value, ok := result.Value.(string); ok {
+					dest.(*compiledAddress).City = "compiled " + value
+				}
+				return nil
+			}), nil
+		})
+
+		parser := NewHTTPRequestParser()
+		parser.SetChainCompiler(compiler)
+
+		var dest compiledRequest
+		require.NoError(t, parser.Parse(newCompiledRequest(t), &dest))
+		assert.Equal(t, "jane", dest.Name, "chains compiled into nil executors are interpreted")
+		assert.Equal(t, "Bergen", dest.Address.City, "scoped sub-chains are not cached, nor compiled")
+
+		req := newTestRequest(t, "GET", "http://example.com/?city=Bergen", nil)
+		var address compiledAddress
+		require.NoError(t, parser.Parse(req, &address))
+		assert.Equal(t, "compiled Bergen", address.City)
+
+		report, err := parser.ParseWithReport(req, &compiledAddress{})
+		require.NoError(t, err)
+		var replayed compiledAddress
+		require.NoError(t, parser.Replay(report.Snapshot(), &replayed))
+		assert.Equal(t, "compiled Bergen", replayed.City, "executors read with the handler of the replay")
+	})
+
+	t.Run("DefaultsAndChecksums", func(t *testing.T) {
+		type Upload struct {
+			File    string `query:"file,checksum=sha256:Digest"`
+			Digest  string `query:"digest"`
+			Retries int    `default:"3"`
+		}
+
+		parser := NewHTTPRequestParser()
+		parser.SetChainCompiler(ChainCompilerFunc[http.Request](func(*ParseChain[http.Request]) (ChainExecutor[http.Request], error) {
+			return ChainExecutorFunc[http.Request](func(source *http.Request, dest any, _ BindingHandlerFunc[http.Request]) error {
+				upload := dest.(*Upload)
+				upload.File = source.URL.Query().Get("file")
+				upload.Digest = source.URL.Query().Get("digest")
+				return nil
+			}), nil
+		}))
+
+		sum := sha256.Sum256([]byte("data"))
+		req := newTestRequest(t, "GET", "http://example.com/?file=data&digest="+hex.EncodeToString(sum[:]), nil)
+
+		var upload Upload
+		require.NoError(t, parser.Parse(req, &upload))
+		assert.Equal(t, 3, upload.Retries, "the chain applies its defaults after the executor")
+
+		req = newTestRequest(t, "GET", "http://example.com/?file=data&digest=00", nil)
+		err := parser.Parse(req, &Upload{})
+		assert.ErrorIs(t, err, ErrChecksumMismatch, "the chain verifies its checksums after the executor")
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		errCompile := errors.New("unsupported")
+		parser := NewHTTPRequestParser()
+		parser.SetChainCompiler(ChainCompilerFunc[http.Request](func(*ParseChain[http.Request]) (ChainExecutor[http.Request], error) {
+			return nil, errCompile
+		}))
+
+		err := parser.Parse(newCompiledRequest(t), &compiledRequest{})
+		assert.ErrorIs(t, err, ErrFailedToCompileChain)
+		assert.ErrorIs(t, err, errCompile)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		var executions atomic.Int32
+		parser := NewHTTPRequestParser()
+		parser.SetChainCompiler(countingCompiler(&executions))
+
+		typ := reflect.TypeFor[compiledAddress]()
+		chain, err := parser.PCMgr.UpdateParseChain(typ, func(chain *ParseChain[http.Request]) error {
+			assert.Nil(t, chain.Executor, "derived chains are compiled again")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, chain.Executor)
+	})
+}
@@ -0,0 +1,27 @@
+//go:build !pave_minimal
+
+package parser
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// charsetReader returns a reader decoding input of charset to UTF-8. It
+// knows the charsets of golang.org/x/text, by their WHATWG names and
+// aliases.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "us-ascii":
+		return input, nil
+	}
+
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: charset %s: %w", ErrInvalidMailMessage, charset, err)
+	}
+	return encoding.NewDecoder().Reader(input), nil
+}
@@ -0,0 +1,48 @@
+//go:build pave_minimal
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// charsetReader returns a reader decoding input of charset to UTF-8. In
+// pave_minimal builds, it only knows UTF-8, US-ASCII and ISO-8859-1
+// (Latin-1).
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "", "utf-8", "us-ascii":
+		return input, nil
+	case "iso-8859-1", "latin1":
+		return &latin1Reader{input: input}, nil
+	}
+	return nil, fmt.Errorf("%w: charset %s: %w", ErrInvalidMailMessage, charset, errors.ErrUnsupported)
+}
+
+// latin1Reader decodes ISO-8859-1 input, whose bytes are the code points
+// U+0000 to U+00FF, to UTF-8.
+type latin1Reader struct {
+	input   io.Reader
+	pending []byte // Encoded runes that did not fit the last read
+}
+
+func (r *latin1Reader) Read(p []byte) (int, error) {
+	if len(r.pending) == 0 {
+		buf := make([]byte, max(len(p)/2, 1))
+		n, err := r.input.Read(buf)
+		for _, b := range buf[:n] {
+			r.pending = utf8.AppendRune(r.pending, rune(b))
+		}
+		if len(r.pending) == 0 {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
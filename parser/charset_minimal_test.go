@@ -0,0 +1,47 @@
+//go:build pave_minimal
+
+package parser
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharsetReader_Minimal(t *testing.T) {
+	for _, charset := range []string{"", "UTF-8", "us-ascii"} {
+		reader, err := charsetReader(charset, strings.NewReader("café"))
+		require.NoError(t, err, charset)
+		decoded, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, "café", string(decoded))
+	}
+
+	reader, err := charsetReader("ISO-8859-1", strings.NewReader("caf\xe9 \xbf\xfe?"))
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "café ¿þ?", string(decoded))
+
+	// Reads shorter than an encoded rune still make progress
+	reader, _ = charsetReader("latin1", strings.NewReader("\xe9\xe9"))
+	var out []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := reader.Read(buf)
+		out = append(out, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "éé", string(out))
+
+	_, err = charsetReader("shift_jis", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrInvalidMailMessage)
+	assert.True(t, errors.Is(err, errors.ErrUnsupported))
+}
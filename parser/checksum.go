@@ -0,0 +1,192 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnsupportedChecksum  = errors.New("unsupported checksum algorithm")
+	ErrUnknownChecksumField = errors.New("checksum field not found")
+	ErrChecksumMismatch     = errors.New("checksum mismatch")
+)
+
+// constants for the algorithms of the checksum modifier
+const (
+	CRC32Checksum  string = "crc32"
+	SHA256Checksum string = "sha256"
+)
+
+// The checksum modifier verifies the value of a field against the digest
+// held by a sibling field before the parse is accepted, e.g. for uploads
+// and financial-file ingestion:
+//
+//	type Upload struct {
+//		File   []byte `json:"file,checksum=sha256:Digest"`
+//		Digest string `header:"X-Content-SHA256,omitempty" trailer:"X-Content-SHA256"`
+//	}
+//
+// The digest field may be bound to any binding, including trailers, as
+// checksums are verified once every field of the struct was parsed.
+// Digests are hex or base64 encoded strings, raw byte arrays or slices
+// or, for crc32, unsigned integers. Zero values are not verified, but
+// their digest is then required.
+
+// FieldChecksum is a checksum modifier of a field, resolved against the
+// fields of its struct.
+type FieldChecksum struct {
+	FieldIndex int    // Index of the verified field in the struct
+	FieldName  string // Name of the verified field for error reporting
+	SumIndex   int    // Index of the field holding the digest
+	SumName    string // Name of the field holding the digest
+	Algorithm  string // CRC32Checksum or SHA256Checksum
+}
+
+// checksumSpec is the parsed value of the checksum modifier.
+type checksumSpec struct {
+	algorithm string
+	field     string
+}
+
+// parseChecksumModifier parses the value of the checksum modifier,
+// <algorithm>:<field>.
+func parseChecksumModifier(value string) (any, error) {
+	algorithm, field, ok := strings.Cut(value, ":")
+	if !ok || field == "" {
+		return nil, fmt.Errorf("expected <algorithm>:<field>, got %q", value)
+	}
+	if algorithm != CRC32Checksum && algorithm != SHA256Checksum {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedChecksum, algorithm)
+	}
+	return checksumSpec{algorithm: algorithm, field: field}, nil
+}
+
+// fieldChecksums resolves the checksum modifiers of the steps of a chain
+// for typ against its fields.
+func fieldChecksums[S any](typ reflect.Type, head *ParseStep[S]) ([]FieldChecksum, []TagIssue) {
+	var (
+		checksums []FieldChecksum
+		issues    []TagIssue
+	)
+
+	for step := head; step != nil; step = step.Next {
+		for _, binding := range step.Bindings {
+			spec, ok := ModifierValue[checksumSpec](binding, ChecksumBindingModifier)
+			if !ok {
+				continue
+			}
+
+			sum, found := typ.FieldByName(spec.field)
+			if !found || len(sum.Index) > 1 || !sum.IsExported() || sum.Name == step.FieldName {
+				issues = append(issues, TagIssue{
+					Field: step.FieldName,
+					Err:   fmt.Errorf("%w: %s", ErrUnknownChecksumField, spec.field),
+				})
+				break
+			}
+
+			checksums = append(checksums, FieldChecksum{
+				FieldIndex: step.FieldIndex,
+				FieldName:  step.FieldName,
+				SumIndex:   sum.Index[0],
+				SumName:    sum.Name,
+				Algorithm:  spec.algorithm,
+			})
+			break
+		}
+	}
+
+	return checksums, issues
+}
+
+// verifyChecksums verifies the fields of chain.Checksums against their
+// digests.
+func (chain *ParseChain[S]) verifyChecksums(dest any) error {
+	if len(chain.Checksums) == 0 {
+		return nil
+	}
+
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() == reflect.Ptr {
+		destValue = destValue.Elem()
+	}
+
+	for _, checksum := range chain.Checksums {
+		if err := checksum.verify(destValue); err != nil {
+			return &FieldError{Field: checksum.FieldName, Err: err}
+		}
+	}
+	return nil
+}
+
+// verify verifies the field of checksum in the struct value against its
+// digest.
+func (checksum FieldChecksum) verify(value reflect.Value) error {
+	field := value.Field(checksum.FieldIndex)
+	if field.IsZero() {
+		return nil
+	}
+
+	sumField := value.Field(checksum.SumIndex)
+	if sumField.IsZero() {
+		return fmt.Errorf("%w: %s is missing", ErrChecksumMismatch, checksum.SumName)
+	}
+
+	data, err := formatFieldValue(field)
+	if err != nil {
+		return err
+	}
+
+	var sum []byte
+	switch checksum.Algorithm {
+	case CRC32Checksum:
+		sum = binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE([]byte(data)))
+	default:
+		digest := sha256.Sum256([]byte(data))
+		sum = digest[:]
+	}
+
+	if !digestMatches(sum, sumField) {
+		return fmt.Errorf("%w: %s of %s does not match %s", ErrChecksumMismatch, checksum.Algorithm, checksum.FieldName, checksum.SumName)
+	}
+	return nil
+}
+
+// digestMatches reports whether the digest held by field is sum.
+func digestMatches(sum []byte, field reflect.Value) bool {
+	for field.Kind() == reflect.Ptr {
+		field = field.Elem()
+	}
+
+	switch field.Kind() {
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return len(sum) == 4 && field.Uint() == uint64(binary.BigEndian.Uint32(sum))
+	case reflect.Slice, reflect.Array:
+		if field.Type().Elem().Kind() != reflect.Uint8 {
+			return false
+		}
+		digest := make([]byte, field.Len())
+		reflect.Copy(reflect.ValueOf(digest), field)
+		return string(digest) == string(sum)
+	case reflect.String:
+		digest := strings.TrimSpace(field.String())
+		if strings.EqualFold(digest, hex.EncodeToString(sum)) {
+			return true
+		}
+		if len(sum) == 4 && digest == strconv.FormatUint(uint64(binary.BigEndian.Uint32(sum)), 10) {
+			return true
+		}
+		return digest == base64.StdEncoding.EncodeToString(sum) ||
+			digest == base64.RawStdEncoding.EncodeToString(sum) ||
+			digest == base64.RawURLEncoding.EncodeToString(sum)
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash/crc32"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksumModifier(t *testing.T) {
+	type Upload struct {
+		File   string `json:"file,checksum=sha256:Digest"`
+		Digest string `header:"X-Content-SHA256,omitempty" trailer:"X-Content-SHA256,omitempty" default:""`
+	}
+
+	const file = "date,amount\n2026-01-02,100.00\n"
+	sum := sha256.Sum256([]byte(file))
+
+	newRequest := func(t *testing.T, digest string) *http.Request {
+		body := `{"file": ` + strconv.Quote(file) + `}`
+		req := newTestRequest(t, "POST", "http://example.com/upload", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if digest != "" {
+			req.Header.Set("X-Content-SHA256", digest)
+		}
+		return req
+	}
+
+	parser := NewHTTPRequestParser()
+
+	t.Run("Hex", func(t *testing.T) {
+		var upload Upload
+		require.NoError(t, parser.Parse(newRequest(t, hex.EncodeToString(sum[:])), &upload))
+		assert.Equal(t, file, upload.File)
+	})
+
+	t.Run("Base64", func(t *testing.T) {
+		var upload Upload
+		require.NoError(t, parser.Parse(newRequest(t, base64.StdEncoding.EncodeToString(sum[:])), &upload))
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		var upload Upload
+		err := parser.Parse(newRequest(t, strings.Repeat("0", 64)), &upload)
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+		assert.Equal(t, "File", FieldPath(err))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		var upload Upload
+		err := parser.Parse(newRequest(t, ""), &upload)
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+	})
+
+	t.Run("Trailer", func(t *testing.T) {
+		body := `{"file": ` + strconv.Quote(file) + `}`
+		raw := "POST /upload HTTP/1.1\r\n" +
+			"Host: example.com\r\n" +
+			"Content-Type: application/json\r\n" +
+			"Transfer-Encoding: chunked\r\n" +
+			"Trailer: X-Content-SHA256\r\n" +
+			"\r\n" +
+			strconv.FormatInt(int64(len(body)), 16) + "\r\n" + body + "\r\n" +
+			"0\r\n" +
+			"X-Content-SHA256: " + hex.EncodeToString(sum[:]) + "\r\n" +
+			"\r\n"
+
+		req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(raw)))
+		require.NoError(t, err)
+
+		var upload Upload
+		require.NoError(t, parser.Parse(req, &upload))
+		assert.Equal(t, file, upload.File)
+	})
+}
+
+func TestChecksumModifier_CRC32(t *testing.T) {
+	type Record struct {
+		Payload []byte `query:"payload,checksum=crc32:CRC"`
+		CRC     uint32 `query:"crc"`
+	}
+
+	const payload = "hello"
+	crc := crc32.ChecksumIEEE([]byte(payload))
+
+	var record Record
+	req := newTestRequest(t, "GET", "http://example.com/?payload=hello&crc="+strconv.FormatUint(uint64(crc), 10), nil)
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &record))
+	assert.Equal(t, []byte(payload), record.Payload)
+
+	req = newTestRequest(t, "GET", "http://example.com/?payload=hellO&crc="+strconv.FormatUint(uint64(crc), 10), nil)
+	assert.ErrorIs(t, NewHTTPRequestParser().Parse(req, &record), ErrChecksumMismatch)
+}
+
+func TestChecksumModifier_Invalid(t *testing.T) {
+	type UnknownAlgorithm struct {
+		File string `query:"file,checksum=md5:Sum"`
+		Sum  string `query:"sum"`
+	}
+	type UnknownField struct {
+		File string `query:"file,checksum=sha256:Digest"`
+		Sum  string `query:"sum"`
+	}
+
+	req := newTestRequest(t, "GET", "http://example.com/?file=x&sum=y", nil)
+	parser := NewHTTPRequestParser()
+
+	assert.ErrorIs(t, parser.Parse(req, &UnknownAlgorithm{}), ErrUnsupportedChecksum)
+	assert.ErrorIs(t, parser.Parse(req, &UnknownField{}), ErrUnknownChecksumField)
+}
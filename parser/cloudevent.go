@@ -0,0 +1,253 @@
+package parser
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Support for CloudEvents in structured JSON mode, the envelope of the
+// events GCP delivers to Cloud Run functions through Eventarc (Pub/Sub
+// messages, Cloud Storage notifications, audit logs...), and that Azure
+// Event Grid delivers too.
+
+var (
+	ErrInvalidCloudEvent = errors.New("invalid cloud event")
+)
+
+var (
+	// Default CloudEventParser Binding Options
+	_cloudEventTagOpts = ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				JsonTagBinding,
+				CloudEventTagBinding,
+			},
+			CustomBindingModifiers: []string{
+				LiteralBindingModifier,
+			},
+			ValueModifiers: map[string]ModifierValueParser{
+				CurrencyBindingModifier: parseCurrencyModifier,
+			},
+		},
+		AllowedTagOptionals: []string{},
+	}
+
+	// Default CloudEventParser Options
+	_cloudEventParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts: PCManagerOpts{
+			tagOpts: _cloudEventTagOpts,
+		},
+	}
+)
+
+// CloudEvent is a CloudEvent (https://cloudevents.io) in structured JSON
+// mode. Extension attributes are kept in Extensions, formatted as text.
+type CloudEvent struct {
+	SpecVersion     string
+	ID              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	DataSchema      string
+	Data            json.RawMessage // Data of the event, if it is JSON or a JSON string
+	DataBase64      []byte          // Binary data of the event, from data_base64
+	Extensions      map[string]string
+}
+
+// cloudEventAttributes are the context attributes of a CloudEvent that
+// are not extensions.
+var cloudEventAttributes = []string{
+	"specversion", "id", "source", "type", "subject", "time",
+	"datacontenttype", "dataschema", "data", "data_base64",
+}
+
+// UnmarshalJSON decodes a CloudEvent in structured JSON mode.
+func (ce *CloudEvent) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCloudEvent, err)
+	}
+
+	text := func(name string) (string, error) {
+		var value string
+		if raw, ok := members[name]; ok && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return "", fmt.Errorf("%w: %s: %w", ErrInvalidCloudEvent, name, err)
+			}
+		}
+		return value, nil
+	}
+
+	event := CloudEvent{Data: members["data"]}
+	for name, field := range map[string]*string{
+		"specversion":     &event.SpecVersion,
+		"id":              &event.ID,
+		"source":          &event.Source,
+		"type":            &event.Type,
+		"subject":         &event.Subject,
+		"datacontenttype": &event.DataContentType,
+		"dataschema":      &event.DataSchema,
+	} {
+		value, err := text(name)
+		if err != nil {
+			return err
+		}
+		*field = value
+	}
+
+	if value, err := text("time"); err != nil {
+		return err
+	} else if value != "" {
+		if event.Time, err = time.Parse(time.RFC3339Nano, value); err != nil {
+			return fmt.Errorf("%w: time: %w", ErrInvalidCloudEvent, err)
+		}
+	}
+
+	if value, err := text("data_base64"); err != nil {
+		return err
+	} else if value != "" {
+		if event.DataBase64, err = base64.StdEncoding.DecodeString(value); err != nil {
+			return fmt.Errorf("%w: data_base64: %w", ErrInvalidCloudEvent, err)
+		}
+	}
+
+	for name, raw := range members {
+		if slices.Contains(cloudEventAttributes, name) || string(raw) == "null" {
+			continue
+		}
+		if event.Extensions == nil {
+			event.Extensions = make(map[string]string)
+		}
+
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			value = string(raw) // Numbers and booleans
+		}
+		event.Extensions[name] = value
+	}
+
+	if event.SpecVersion == "" || event.ID == "" || event.Source == "" || event.Type == "" {
+		return fmt.Errorf("%w: missing specversion, id, source or type", ErrInvalidCloudEvent)
+	}
+
+	*ce = event
+	return nil
+}
+
+// Attribute returns the value of the context attribute or extension name,
+// formatted as text.
+func (ce *CloudEvent) Attribute(name string) (string, bool) {
+	var value string
+	switch strings.ToLower(name) {
+	case "specversion":
+		value = ce.SpecVersion
+	case "id":
+		value = ce.ID
+	case "source":
+		value = ce.Source
+	case "type":
+		value = ce.Type
+	case "subject":
+		value = ce.Subject
+	case "time":
+		if !ce.Time.IsZero() {
+			value = ce.Time.Format(time.RFC3339Nano)
+		}
+	case "datacontenttype":
+		value = ce.DataContentType
+	case "dataschema":
+		value = ce.DataSchema
+	default:
+		value = ce.Extensions[strings.ToLower(name)]
+	}
+	return value, value != ""
+}
+
+// body returns the JSON the json binding reads: the data of the event, or
+// its binary data, or the text of its data if it is a JSON string.
+func (ce *CloudEvent) body() string {
+	if ce.DataBase64 != nil {
+		return string(ce.DataBase64)
+	}
+
+	var text string
+	if err := json.Unmarshal(ce.Data, &text); err == nil {
+		return text
+	}
+	return string(ce.Data)
+}
+
+// CloudEventParser parses a CloudEvent:
+//   - json:'<key,[modifiers]>'`: Parses a JSON key from the data of the
+//     event, which may also be carried as a JSON string or as data_base64
+//   - cloudevent:'<attribute,[modifiers]>'`: Parses a context attribute
+//     (id, source, type, subject, time...) or extension of the event
+//
+// For example, GCP Cloud Storage notifications parse into:
+//
+//	type ObjectFinalized struct {
+//		Bucket string    `json:"bucket"`
+//		Name   string    `json:"name"`
+//		At     time.Time `cloudevent:"time"`
+//	}
+type CloudEventParser struct {
+	*BaseMBParser[CloudEvent, JSONBodyOnce]
+}
+
+func NewCloudEventParser() *CloudEventParser {
+	return &CloudEventParser{
+		BaseMBParser: NewBaseMBParser(&CloudEventBindingManager{}, _cloudEventParserOpts),
+	}
+}
+
+func (cp *CloudEventParser) Name() string {
+	return CloudEventParserName
+}
+
+// CloudEventBindingManager gets the values of bindings from a CloudEvent.
+// See CloudEventParser.
+type CloudEventBindingManager struct{}
+
+func (mgr *CloudEventBindingManager) BindingHandlerCached(
+	source *CloudEvent,
+	entry *CacheEntry[JSONBodyOnce],
+	binding Binding,
+) BindingResult {
+
+	if entry == nil {
+		return BindingResultError(ErrBindingCacheNilEntry)
+	}
+
+	switch binding.Name {
+	case JsonTagBinding:
+		var result BindingResult
+		entry.WriteData(func(data *JSONBodyOnce) {
+			result = data.jsonValue(source.body(), binding)
+		})
+		return result
+	case CloudEventTagBinding:
+		value, ok := source.Attribute(binding.Identifier)
+		if !ok {
+			return BindingResultNotFound()
+		}
+		return BindingResultValue(value)
+	default:
+		return BindingResultError(fmt.Errorf("%w: unknown binding: %s", ErrUnallowedBindingName, binding.Name))
+	}
+}
+
+func (mgr *CloudEventBindingManager) BindingHandler(source *CloudEvent, binding Binding) BindingResult {
+	return mgr.BindingHandlerCached(source, &CacheEntry[JSONBodyOnce]{}, binding)
+}
+
+func (mgr *CloudEventBindingManager) NewCached() JSONBodyOnce {
+	return JSONBodyOnce{}
+}
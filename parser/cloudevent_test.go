@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudEvent_UnmarshalJSON(t *testing.T) {
+	var event CloudEvent
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"specversion": "1.0",
+		"id": "1",
+		"source": "//storage.googleapis.com/projects/_/buckets/photos",
+		"type": "google.cloud.storage.object.v1.finalized",
+		"time": "2024-05-01T12:00:00.5Z",
+		"datacontenttype": "application/json",
+		"bucket": "photos",
+		"attempt": 2,
+		"data": {"name": "cat.png"}
+	}`), &event))
+
+	assert.Equal(t, "1.0", event.SpecVersion)
+	assert.Equal(t, "google.cloud.storage.object.v1.finalized", event.Type)
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 500_000_000, time.UTC), event.Time)
+	assert.Equal(t, map[string]string{"bucket": "photos", "attempt": "2"}, event.Extensions)
+	assert.JSONEq(t, `{"name": "cat.png"}`, string(event.Data))
+
+	value, ok := event.Attribute("Bucket")
+	assert.True(t, ok)
+	assert.Equal(t, "photos", value)
+	_, ok = event.Attribute("subject")
+	assert.False(t, ok)
+
+	for _, data := range []string{
+		`{"id": "1", "source": "s", "type": "t"}`,
+		`{"specversion": "1.0", "id": "1", "source": "s", "type": "t", "time": "yesterday"}`,
+		`{"specversion": "1.0", "id": "1", "source": "s", "type": "t", "data_base64": "%%"}`,
+		`[]`,
+	} {
+		err := json.Unmarshal([]byte(data), &CloudEvent{})
+		assert.ErrorIs(t, err, ErrInvalidCloudEvent, data)
+	}
+}
+
+func TestCloudEventParser(t *testing.T) {
+	type ObjectFinalized struct {
+		Bucket string    `json:"bucket"`
+		Name   string    `json:"name"`
+		Size   int64     `json:"size"`
+		At     time.Time `cloudevent:"time"`
+		Type   string    `cloudevent:"type"`
+		Retry  int       `cloudevent:"attempt,omitempty" default:"0"`
+	}
+
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	event := &CloudEvent{
+		SpecVersion: "1.0",
+		ID:          "1",
+		Source:      "//storage.googleapis.com/projects/_/buckets/photos",
+		Type:        "google.cloud.storage.object.v1.finalized",
+		Time:        at,
+		Data:        json.RawMessage(`{"bucket":"photos","name":"cat.png","size":"1024"}`),
+	}
+
+	parser := NewCloudEventParser()
+
+	var object ObjectFinalized
+	require.NoError(t, parser.Parse(event, &object))
+	assert.Equal(t, ObjectFinalized{
+		Bucket: "photos",
+		Name:   "cat.png",
+		Size:   1024,
+		At:     at,
+		Type:   "google.cloud.storage.object.v1.finalized",
+	}, object)
+
+	t.Run("EncodedData", func(t *testing.T) {
+		var message struct {
+			ID string `json:"id"`
+		}
+
+		base64Event := &CloudEvent{DataBase64: []byte(`{"id":"b64"}`)}
+		require.NoError(t, parser.Parse(base64Event, &message))
+		assert.Equal(t, "b64", message.ID)
+
+		stringEvent := &CloudEvent{Data: json.RawMessage(`"{\"id\":\"text\"}"`)}
+		require.NoError(t, parser.Parse(stringEvent, &message))
+		assert.Equal(t, "text", message.ID)
+	})
+}
@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidCountryCode = errors.New("invalid ISO 3166-1 country code")
+	ErrInvalidLanguageTag = errors.New("invalid BCP 47 language tag")
+	ErrInvalidTimezone    = errors.New("invalid IANA time zone")
+)
+
+// Country code formats accepted by the country rule
+const (
+	CountryCodeAlpha2 string = "alpha2" // Two letters, e.g. "DE" (default)
+	CountryCodeAlpha3 string = "alpha3" // Three letters, e.g. "DEU"
+	CountryCodeAny    string = "any"    // Either of the above
+)
+
+// LoadTimezone loads the IANA time zone name, e.g. "Europe/Berlin" or
+// "UTC". "Local" and the empty name are rejected as they do not name a
+// zone. Zones are looked up like time.LoadLocation does, import
+// time/tzdata where the system has no time zone database.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimezone, name)
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrInvalidTimezone, name, err)
+	}
+	return location, nil
+}
+
+// convertTimezone is the TypeConverter for *time.Location fields.
+func convertTimezone(value string) (any, error) {
+	return LoadTimezone(value)
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	location, err := LoadTimezone("UTC")
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, location)
+
+	for _, name := range []string{"", "Local", "Mars/Olympus_Mons", "../etc/passwd"} {
+		_, err := LoadTimezone(name)
+		assert.ErrorIs(t, err, ErrInvalidTimezone, name)
+	}
+}
+
+func TestHTTPRequestParser_Codes(t *testing.T) {
+	type request struct {
+		Location *time.Location `query:"tz"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/?tz=UTC", nil)
+	require.NoError(t, err)
+
+	var dest request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &dest))
+	assert.Same(t, time.UTC, dest.Location)
+
+	// Invalidating must not reset the shared location
+	require.NoError(t, InvalidateWithOpts(&dest, InvalidateOpts{KeepAllocations: true}))
+	assert.Nil(t, dest.Location)
+	assert.Equal(t, "UTC", time.UTC.String())
+
+	req, err = http.NewRequest(http.MethodGet, "/?tz=Nowhere/City", nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, NewHTTPRequestParser().Parse(req, &request{}), ErrInvalidTimezone)
+
+	query, err := EncodeQuery(request{Location: time.UTC})
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", query.Get("tz"))
+}
@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidColor = errors.New("invalid color")
+)
+
+// Color is an 8-bit RGBA color. It implements image/color.Color.
+//
+// Color fields are populated from CSS style values:
+//   - "#RGB", "#RGBA", "#RRGGBB" or "#RRGGBBAA" (the "#" is optional)
+//   - "rgb(r, g, b)" or "rgba(r, g, b, a)", with channels from 0 to 255 or
+//     percentages and alpha from 0 to 1 or a percentage
+//
+// Colors without alpha are opaque.
+type Color struct {
+	R, G, B, A uint8
+}
+
+// ParseColor parses a color in one of the formats described on Color.
+func ParseColor(value string) (Color, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+
+	var (
+		color Color
+		err   error
+	)
+	switch {
+	case strings.HasPrefix(s, "rgba(") && strings.HasSuffix(s, ")"):
+		color, err = parseRGBFunc(s[len("rgba("):len(s)-1], true)
+	case strings.HasPrefix(s, "rgb(") && strings.HasSuffix(s, ")"):
+		color, err = parseRGBFunc(s[len("rgb("):len(s)-1], false)
+	default:
+		color, err = parseHexColor(strings.TrimPrefix(s, "#"))
+	}
+
+	if err != nil {
+		return Color{}, fmt.Errorf("%w: %q: %w", ErrInvalidColor, value, err)
+	}
+	return color, nil
+}
+
+// parseHexColor parses the digits of a hex color.
+func parseHexColor(digits string) (Color, error) {
+	// Short forms repeat each digit: "f80" is "ff8800"
+	if len(digits) == 3 || len(digits) == 4 {
+		var long strings.Builder
+		for i := 0; i < len(digits); i++ {
+			long.WriteByte(digits[i])
+			long.WriteByte(digits[i])
+		}
+		digits = long.String()
+	}
+
+	if len(digits) != 6 && len(digits) != 8 {
+		return Color{}, errors.New("expected 3, 4, 6 or 8 hex digits")
+	}
+
+	channels := [4]uint8{3: 255}
+	for i := 0; i < len(digits)/2; i++ {
+		channel, err := strconv.ParseUint(digits[2*i:2*i+2], 16, 8)
+		if err != nil {
+			return Color{}, err
+		}
+		channels[i] = uint8(channel)
+	}
+
+	return Color{R: channels[0], G: channels[1], B: channels[2], A: channels[3]}, nil
+}
+
+// parseRGBFunc parses the arguments of rgb() or rgba().
+func parseRGBFunc(args string, alpha bool) (Color, error) {
+	parts := strings.Split(args, CommaDelimeter)
+
+	want := 3
+	if alpha {
+		want = 4
+	}
+	if len(parts) != want {
+		return Color{}, fmt.Errorf("expected %d arguments, got %d", want, len(parts))
+	}
+
+	channels := [4]uint8{3: 255}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+
+		limit := 255.0
+		if i == 3 {
+			limit = 1
+		}
+
+		var (
+			v   float64
+			err error
+		)
+		if percent, ok := strings.CutSuffix(part, "%"); ok {
+			v, err = strconv.ParseFloat(percent, 64)
+			v = v / 100 * limit
+		} else {
+			v, err = strconv.ParseFloat(part, 64)
+		}
+		if err != nil {
+			return Color{}, err
+		}
+		if !(v >= 0 && v <= limit) {
+			return Color{}, fmt.Errorf("%s is out of range", part)
+		}
+
+		channels[i] = uint8(v/limit*255 + 0.5)
+	}
+
+	return Color{R: channels[0], G: channels[1], B: channels[2], A: channels[3]}, nil
+}
+
+// RGBA implements image/color.Color, returning alpha-premultiplied
+// 16-bit channels.
+func (c Color) RGBA() (r, g, b, a uint32) {
+	a = uint32(c.A) * 0x101
+	r = uint32(c.R) * 0x101 * a / 0xffff
+	g = uint32(c.G) * 0x101 * a / 0xffff
+	b = uint32(c.B) * 0x101 * a / 0xffff
+	return r, g, b, a
+}
+
+// String formats c as "#rrggbb", or "#rrggbbaa" if it is not opaque.
+func (c Color) String() string {
+	if c.A == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// MarshalText implements encoding.TextMarshaler using Color.String.
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseColor.
+func (c *Color) UnmarshalText(text []byte) error {
+	color, err := ParseColor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = color
+	return nil
+}
+
+// convertColor is the TypeConverter for Color fields.
+func convertColor(value string) (any, error) {
+	return ParseColor(value)
+}
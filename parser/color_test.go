@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"image/color"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Color
+	}{
+		{"#ff8800", Color{255, 136, 0, 255}},
+		{"FF8800", Color{255, 136, 0, 255}},
+		{"#f80", Color{255, 136, 0, 255}},
+		{"#f808", Color{255, 136, 0, 136}},
+		{"#ff880080", Color{255, 136, 0, 128}},
+		{"rgb(255, 136, 0)", Color{255, 136, 0, 255}},
+		{"RGB(100%,0%,50%)", Color{255, 0, 128, 255}},
+		{"rgba(0, 0, 0, 0.5)", Color{0, 0, 0, 128}},
+		{"rgba(0, 0, 0, 25%)", Color{0, 0, 0, 64}},
+	}
+	for _, tt := range tests {
+		got, err := ParseColor(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	for _, value := range []string{"", "#ff888", "#ff88001", "#gg8800", "rgb(256,0,0)", "rgb(0,0)", "rgba(0,0,0)", "rgba(0,0,0,2)", "rgb(0,0,0", "red"} {
+		_, err := ParseColor(value)
+		assert.ErrorIs(t, err, ErrInvalidColor, value)
+	}
+}
+
+func TestColor_String(t *testing.T) {
+	assert.Equal(t, "#ff8800", Color{255, 136, 0, 255}.String())
+	assert.Equal(t, "#ff880080", Color{255, 136, 0, 128}.String())
+}
+
+func TestColor_RGBA(t *testing.T) {
+	var c color.Color = Color{255, 0, 0, 255}
+	assert.Equal(t, color.RGBAModel.Convert(color.NRGBA{255, 0, 0, 255}), color.RGBAModel.Convert(c))
+
+	c = Color{255, 0, 0, 128}
+	assert.Equal(t, color.RGBAModel.Convert(color.NRGBA{255, 0, 0, 128}), color.RGBAModel.Convert(c))
+}
+
+func TestHTTPRequestParser_ColorAndHex(t *testing.T) {
+	type request struct {
+		Theme  Color    `query:"theme"`
+		Digest [32]byte `header:"X-Content-SHA256"`
+		Token  *[8]byte `query:"token"`
+	}
+
+	query := url.Values{"theme": {"rgb(16, 32, 48)"}, "token": {"0x0102030405060708"}}
+	req, err := http.NewRequest(http.MethodGet, "/?"+query.Encode(), nil)
+	require.NoError(t, err)
+	req.Header.Set("X-Content-SHA256", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+
+	var dest request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &dest))
+	assert.Equal(t, Color{16, 32, 48, 255}, dest.Theme)
+	assert.Equal(t, byte(0xe3), dest.Digest[0])
+	assert.Equal(t, byte(0x55), dest.Digest[31])
+	require.NotNil(t, dest.Token)
+	assert.Equal(t, [8]byte{1, 2, 3, 4, 5, 6, 7, 8}, *dest.Token)
+
+	header, err := EncodeHeader(dest)
+	require.NoError(t, err)
+	assert.Equal(t, req.Header.Get("X-Content-SHA256"), header.Get("X-Content-SHA256"))
+
+	req.Header.Set("X-Content-SHA256", "e3b0c442")
+	assert.ErrorIs(t, NewHTTPRequestParser().Parse(req, &request{}), ErrArrayLength)
+}
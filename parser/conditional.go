@@ -0,0 +1,241 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidETag         = errors.New("invalid entity tag")
+	ErrInvalidPrecondition = errors.New("invalid precondition header")
+)
+
+// Headers supported by the conditional binding
+const (
+	IfMatchHeader           string = "If-Match"
+	IfNoneMatchHeader       string = "If-None-Match"
+	IfModifiedSinceHeader   string = "If-Modified-Since"
+	IfUnmodifiedSinceHeader string = "If-Unmodified-Since"
+)
+
+// ETag is an entity tag (RFC 9110), e.g. `"v1"` or `W/"v1"`.
+type ETag struct {
+	Tag  string // Opaque tag, without quotes
+	Weak bool   // Whether the tag is weak (W/ prefix)
+}
+
+// String formats the entity tag as in an ETag header.
+func (etag ETag) String() string {
+	if etag.Weak {
+		return `W/"` + etag.Tag + `"`
+	}
+	return `"` + etag.Tag + `"`
+}
+
+// MarshalText formats the entity tag as in an ETag header.
+func (etag ETag) MarshalText() ([]byte, error) {
+	return []byte(etag.String()), nil
+}
+
+// ParseETag parses a single entity tag, e.g. `W/"v1"`.
+func ParseETag(value string) (ETag, error) {
+	value = strings.TrimSpace(value)
+
+	var etag ETag
+	if rest, ok := strings.CutPrefix(value, "W/"); ok {
+		etag.Weak, value = true, rest
+	}
+
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return ETag{}, fmt.Errorf("%w: %q is not quoted", ErrInvalidETag, value)
+	}
+
+	etag.Tag = value[1 : len(value)-1]
+	if strings.ContainsAny(etag.Tag, "\" \t") {
+		return ETag{}, fmt.Errorf("%w: %q", ErrInvalidETag, value)
+	}
+	return etag, nil
+}
+
+// ETagList is the value of an If-Match or If-None-Match header: either *
+// or a list of entity tags. The zero ETagList stands for an absent header.
+type ETagList struct {
+	Any  bool   // Whether the header is *, matching any current representation
+	Tags []ETag // Entity tags, in header order
+}
+
+// IsZero reports whether the list stands for an absent header.
+func (list ETagList) IsZero() bool {
+	return !list.Any && len(list.Tags) == 0
+}
+
+// MatchStrong reports whether etag matches the list by strong comparison,
+// as If-Match requires: weak tags never match.
+func (list ETagList) MatchStrong(etag ETag) bool {
+	if list.Any {
+		return true
+	}
+	if etag.Weak {
+		return false
+	}
+	for _, tag := range list.Tags {
+		if !tag.Weak && tag.Tag == etag.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchWeak reports whether etag matches the list by weak comparison, as
+// If-None-Match requires: tags match whether they are weak or not.
+func (list ETagList) MatchWeak(etag ETag) bool {
+	if list.Any {
+		return true
+	}
+	for _, tag := range list.Tags {
+		if tag.Tag == etag.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the list as an If-Match or If-None-Match header value.
+func (list ETagList) String() string {
+	if list.Any {
+		return "*"
+	}
+	tags := make([]string, len(list.Tags))
+	for i, tag := range list.Tags {
+		tags[i] = tag.String()
+	}
+	return strings.Join(tags, CommaDelimeter)
+}
+
+// MarshalText formats the list as an If-Match or If-None-Match header
+// value.
+func (list ETagList) MarshalText() ([]byte, error) {
+	return []byte(list.String()), nil
+}
+
+// ParseETagList parses the value of an If-Match or If-None-Match header.
+// An empty value is the zero ETagList.
+func ParseETagList(value string) (ETagList, error) {
+	if strings.TrimSpace(value) == "*" {
+		return ETagList{Any: true}, nil
+	}
+
+	var list ETagList
+	for _, element := range strings.Split(value, CommaDelimeter) {
+		if strings.TrimSpace(element) == "" {
+			continue
+		}
+
+		etag, err := ParseETag(element)
+		if err != nil {
+			return ETagList{}, err
+		}
+		list.Tags = append(list.Tags, etag)
+	}
+	return list, nil
+}
+
+// Preconditions holds the conditional headers of a request (RFC 9110,
+// section 13). Embed it in a request struct to evaluate them with
+// Evaluate.
+type Preconditions struct {
+	IfMatch           ETagList  `conditional:"If-Match"`
+	IfNoneMatch       ETagList  `conditional:"If-None-Match"`
+	IfModifiedSince   time.Time `conditional:"If-Modified-Since"`
+	IfUnmodifiedSince time.Time `conditional:"If-Unmodified-Since"`
+}
+
+// Evaluate evaluates the preconditions against the current representation
+// of the target resource, in the order of RFC 9110, section 13.2.2. etag
+// and modified describe the representation; a zero etag means it has none,
+// and a zero modified that its modification time is unknown.
+//
+// It returns http.StatusOK if the request should be performed,
+// http.StatusNotModified if a GET or HEAD request can be answered with
+// 304, or http.StatusPreconditionFailed.
+func (p Preconditions) Evaluate(method string, etag ETag, modified time.Time) int {
+	safe := method == http.MethodGet || method == http.MethodHead
+	modified = modified.Truncate(time.Second)
+	hasETag := etag != ETag{}
+
+	switch {
+	case !p.IfMatch.IsZero():
+		if !hasETag || !p.IfMatch.MatchStrong(etag) {
+			return http.StatusPreconditionFailed
+		}
+	case !p.IfUnmodifiedSince.IsZero() && !modified.IsZero():
+		if modified.After(p.IfUnmodifiedSince) {
+			return http.StatusPreconditionFailed
+		}
+	}
+
+	switch {
+	case !p.IfNoneMatch.IsZero():
+		if (hasETag || p.IfNoneMatch.Any) && p.IfNoneMatch.MatchWeak(etag) {
+			if safe {
+				return http.StatusNotModified
+			}
+			return http.StatusPreconditionFailed
+		}
+	case safe && !p.IfModifiedSince.IsZero() && !modified.IsZero():
+		if !modified.After(p.IfModifiedSince) {
+			return http.StatusNotModified
+		}
+	}
+
+	return http.StatusOK
+}
+
+// absentPrecondition is the value of an absent conditional header, which
+// sets its field to the zero value of its type: an empty ETagList or the
+// zero time. See conditionalValue.
+type absentPrecondition struct{}
+
+// conditionalValue parses the value of the conditional header named
+// header. Absent headers, which mean that there is no precondition, are
+// found as absentPrecondition. As RFC 9110 requires, invalid dates are
+// ignored like absent headers.
+func conditionalValue(header string, values []string) BindingResult {
+	switch http.CanonicalHeaderKey(header) {
+	case IfMatchHeader, IfNoneMatchHeader:
+		value := strings.Join(values, CommaDelimeter)
+		if strings.TrimSpace(value) == "" {
+			return BindingResultValue(absentPrecondition{})
+		}
+		if _, err := ParseETagList(value); err != nil {
+			return BindingResultError(fmt.Errorf("header %s: %w", header, err))
+		}
+		return BindingResultValue(value)
+
+	case IfModifiedSinceHeader, IfUnmodifiedSinceHeader:
+		if len(values) == 0 {
+			return BindingResultValue(absentPrecondition{})
+		}
+		date, err := http.ParseTime(values[0])
+		if err != nil {
+			return BindingResultValue(absentPrecondition{})
+		}
+		return BindingResultValue(date.UTC().Format(time.RFC3339))
+
+	default:
+		return BindingResultError(fmt.Errorf("%w: %s", ErrInvalidPrecondition, header))
+	}
+}
+
+// convertETag parses an ETag field.
+func convertETag(value string) (any, error) {
+	return ParseETag(value)
+}
+
+// convertETagList parses an ETagList field.
+func convertETagList(value string) (any, error) {
+	return ParseETagList(value)
+}
@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseETagList(t *testing.T) {
+	list, err := ParseETagList(`"v1", W/"v2",""`)
+	require.NoError(t, err)
+	assert.Equal(t, ETagList{Tags: []ETag{{Tag: "v1"}, {Tag: "v2", Weak: true}, {Tag: ""}}}, list)
+	assert.Equal(t, `"v1",W/"v2",""`, list.String())
+
+	list, err = ParseETagList(" * ")
+	require.NoError(t, err)
+	assert.Equal(t, ETagList{Any: true}, list)
+
+	list, err = ParseETagList("")
+	require.NoError(t, err)
+	assert.True(t, list.IsZero())
+
+	for _, value := range []string{"v1", `"v1`, `w/"v1"`, `"v 1"`, `"a"b"`, `"v1", *`} {
+		_, err := ParseETagList(value)
+		assert.ErrorIs(t, err, ErrInvalidETag, value)
+	}
+}
+
+func TestETagList_Match(t *testing.T) {
+	list := ETagList{Tags: []ETag{{Tag: "v1"}, {Tag: "v2", Weak: true}}}
+
+	assert.True(t, list.MatchStrong(ETag{Tag: "v1"}))
+	assert.False(t, list.MatchStrong(ETag{Tag: "v1", Weak: true}))
+	assert.False(t, list.MatchStrong(ETag{Tag: "v2"}))
+	assert.True(t, list.MatchWeak(ETag{Tag: "v2"}))
+	assert.True(t, list.MatchWeak(ETag{Tag: "v1", Weak: true}))
+	assert.False(t, list.MatchWeak(ETag{Tag: "v3"}))
+	assert.True(t, ETagList{Any: true}.MatchStrong(ETag{Tag: "v3", Weak: true}))
+}
+
+func TestPreconditions_Evaluate(t *testing.T) {
+	var (
+		etag     = ETag{Tag: "v2"}
+		modified = time.Date(2024, 5, 1, 12, 0, 0, 500, time.UTC)
+		before   = modified.Add(-time.Hour)
+	)
+
+	tests := []struct {
+		name   string
+		p      Preconditions
+		method string
+		want   int
+	}{
+		{"None", Preconditions{}, http.MethodGet, http.StatusOK},
+		{"IfMatch", Preconditions{IfMatch: ETagList{Tags: []ETag{{Tag: "v2"}}}}, http.MethodPut, http.StatusOK},
+		{"IfMatchStale", Preconditions{IfMatch: ETagList{Tags: []ETag{{Tag: "v1"}}}}, http.MethodPut, http.StatusPreconditionFailed},
+		{"IfMatchWeak", Preconditions{IfMatch: ETagList{Tags: []ETag{{Tag: "v2", Weak: true}}}}, http.MethodPut, http.StatusPreconditionFailed},
+		{"IfUnmodifiedSince", Preconditions{IfUnmodifiedSince: modified.Truncate(time.Second)}, http.MethodDelete, http.StatusOK},
+		{"IfUnmodifiedSinceStale", Preconditions{IfUnmodifiedSince: before}, http.MethodDelete, http.StatusPreconditionFailed},
+		{"IfMatchOverridesDate", Preconditions{IfMatch: ETagList{Any: true}, IfUnmodifiedSince: before}, http.MethodPut, http.StatusOK},
+		{"IfNoneMatch", Preconditions{IfNoneMatch: ETagList{Tags: []ETag{{Tag: "v2", Weak: true}}}}, http.MethodGet, http.StatusNotModified},
+		{"IfNoneMatchUnsafe", Preconditions{IfNoneMatch: ETagList{Any: true}}, http.MethodPost, http.StatusPreconditionFailed},
+		{"IfNoneMatchChanged", Preconditions{IfNoneMatch: ETagList{Tags: []ETag{{Tag: "v1"}}}, IfModifiedSince: modified}, http.MethodGet, http.StatusOK},
+		{"IfModifiedSince", Preconditions{IfModifiedSince: modified.Truncate(time.Second)}, http.MethodHead, http.StatusNotModified},
+		{"IfModifiedSinceChanged", Preconditions{IfModifiedSince: before}, http.MethodGet, http.StatusOK},
+		{"IfModifiedSinceUnsafe", Preconditions{IfModifiedSince: modified}, http.MethodPut, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.p.Evaluate(tt.method, etag, modified))
+		})
+	}
+
+	assert.Equal(t, http.StatusPreconditionFailed, Preconditions{IfMatch: ETagList{Any: true}}.Evaluate(http.MethodPut, ETag{}, time.Time{}),
+		"If-Match: * fails for a resource without a representation")
+	assert.Equal(t, http.StatusOK, Preconditions{IfModifiedSince: modified}.Evaluate(http.MethodGet, etag, time.Time{}),
+		"dates are ignored without a modification time")
+}
+
+func TestHTTPRequestParser_Conditional(t *testing.T) {
+	type GetDocument struct {
+		Preconditions
+		ID string `query:"id"`
+	}
+
+	parser := NewHTTPRequestParser()
+
+	req := newTestRequest(t, "GET", "http://example.com/doc?id=7", nil)
+	req.Header.Add("If-None-Match", `"v1"`)
+	req.Header.Add("If-None-Match", `W/"v2"`)
+	req.Header.Set("If-Modified-Since", "Wed, 01 May 2024 12:00:00 GMT")
+	req.Header.Set("If-Unmodified-Since", "yesterday")
+
+	var doc GetDocument
+	require.NoError(t, parser.Parse(req, &doc))
+	assert.Equal(t, GetDocument{
+		Preconditions: Preconditions{
+			IfNoneMatch:     ETagList{Tags: []ETag{{Tag: "v1"}, {Tag: "v2", Weak: true}}},
+			IfModifiedSince: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+		ID: "7",
+	}, doc, "absent headers and invalid dates are zero")
+	assert.Equal(t, http.StatusNotModified, doc.Evaluate(req.Method, ETag{Tag: "v2"}, time.Time{}))
+
+	t.Run("InvalidETag", func(t *testing.T) {
+		req := newTestRequest(t, "PUT", "http://example.com/doc?id=7", nil)
+		req.Header.Set("If-Match", "v1")
+
+		err := parser.Parse(req, &GetDocument{})
+		assert.ErrorIs(t, err, ErrInvalidETag)
+	})
+
+	t.Run("EmptyTime", func(t *testing.T) {
+		var since struct {
+			Since time.Time `query:"since"`
+		}
+		req := newTestRequest(t, "GET", "http://example.com/doc?since=", nil)
+
+		err := parser.Parse(req, &since)
+		assert.ErrorIs(t, err, ErrEmptyValue, "only absent conditional headers are zero")
+	})
+
+	t.Run("UnsupportedHeader", func(t *testing.T) {
+		var ranged struct {
+			IfRange ETagList `conditional:"If-Range"`
+		}
+		req := newTestRequest(t, "GET", "http://example.com/doc", nil)
+
+		err := parser.Parse(req, &ranged)
+		assert.ErrorIs(t, err, ErrInvalidPrecondition)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		want := GetDocument{
+			Preconditions: Preconditions{
+				IfMatch:           ETagList{Any: true},
+				IfUnmodifiedSince: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			},
+			ID: "7",
+		}
+		req, err := NewHTTPRequest("GET", "http://example.com/doc", want)
+		require.NoError(t, err)
+		assert.Equal(t, "*", req.Header.Get("If-Match"))
+		assert.Equal(t, "Wed, 01 May 2024 12:00:00 GMT", req.Header.Get("If-Unmodified-Since"))
+		assert.Empty(t, req.Header.Values("If-None-Match"))
+		assert.Empty(t, req.Header.Values("If-Modified-Since"))
+
+		var got GetDocument
+		require.NoError(t, parser.Parse(req, &got))
+		assert.Equal(t, want, got)
+	})
+}
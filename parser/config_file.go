@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"sync"
+)
+
+// ConfigFileOnce caches the key/value pairs decoded from the content of a
+// config file or environment (INIData, DotEnvData or EnvBlock), so that
+// the content is only decoded once per source, whatever the number of
+// bound fields.
+type ConfigFileOnce struct {
+	values map[string]string // Decoded key/value pairs
+	err    error             // Error encountered while decoding
+	once   sync.Once         // Ensures the content is decoded only once
+}
+
+// configFileBindingManager gets the values of bindings from config file
+// content of type S, looking up binding identifiers in the key/value
+// pairs returned by decode. If set, foldKey is applied to the identifiers
+// before the lookup, for sources with case-insensitive keys.
+type configFileBindingManager[S any] struct {
+	decode  func(content S) (map[string]string, error)
+	foldKey func(key string) string
+}
+
+func (mgr *configFileBindingManager[S]) BindingHandlerCached(
+	source *S,
+	entry *CacheEntry[ConfigFileOnce],
+	binding Binding,
+) BindingResult {
+
+	if entry == nil {
+		return BindingResultError(ErrBindingCacheNilEntry)
+	}
+
+	var (
+		values map[string]string
+		err    error
+	)
+
+	entry.WriteData(func(data *ConfigFileOnce) {
+		data.once.Do(func() {
+			data.values, data.err = mgr.decode(*source)
+		})
+		values, err = data.values, data.err
+	})
+
+	return mgr.value(values, err, binding)
+}
+
+func (mgr *configFileBindingManager[S]) BindingHandler(source *S, binding Binding) BindingResult {
+	values, err := mgr.decode(*source)
+	return mgr.value(values, err, binding)
+}
+
+func (mgr *configFileBindingManager[S]) NewCached() ConfigFileOnce {
+	return ConfigFileOnce{}
+}
+
+func (mgr *configFileBindingManager[S]) value(values map[string]string, err error, binding Binding) BindingResult {
+	if err != nil {
+		return BindingResultError(err)
+	}
+
+	key := binding.Identifier
+	if mgr.foldKey != nil {
+		key = mgr.foldKey(key)
+	}
+
+	value, exists := values[key]
+	if !exists {
+		return BindingResultNotFound()
+	}
+
+	return BindingResultValue(value)
+}
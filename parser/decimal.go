@@ -0,0 +1,36 @@
+//go:build pave_decimal
+
+package parser
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/shopspring/decimal"
+)
+
+// Support for github.com/shopspring/decimal destination fields.
+
+// installDecimal registers the decimal integration. See installIntegrations.
+func installDecimal() {
+	_builtinTypeConverters[reflect.TypeFor[decimal.Decimal]()] = ignoreModifiers(convertDecimal)
+	_builtinTypeConverters[reflect.TypeFor[decimal.NullDecimal]()] = ignoreModifiers(convertNullDecimal)
+}
+
+// convertDecimal parses a decimal number exactly, e.g. "12.34" or "1e-3".
+func convertDecimal(value string) (any, error) {
+	result, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot convert %q to decimal.Decimal: %w", ErrInvalidBigNumber, value, err)
+	}
+	return result, nil
+}
+
+// convertNullDecimal parses a decimal number into a valid NullDecimal.
+func convertNullDecimal(value string) (any, error) {
+	result, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot convert %q to decimal.NullDecimal: %w", ErrInvalidBigNumber, value, err)
+	}
+	return decimal.NullDecimal{Decimal: result, Valid: true}, nil
+}
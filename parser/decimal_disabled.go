@@ -0,0 +1,6 @@
+//go:build !pave_decimal
+
+package parser
+
+// installDecimal is a no-op without the pave_decimal build tag.
+func installDecimal() {}
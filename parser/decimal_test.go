@@ -0,0 +1,36 @@
+//go:build pave_decimal
+
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPRequestParser_Decimal(t *testing.T) {
+	type Payment struct {
+		Amount   decimal.Decimal     `json:"amount"`
+		Fee      *decimal.Decimal    `json:"fee"`
+		Discount decimal.NullDecimal `query:"discount"`
+	}
+
+	req := newTestRequest(t, "POST", "http://example.com/?discount=0.10",
+		bytes.NewBufferString(`{"amount": 1234567890.123456789012, "fee": "0.30"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var result Payment
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &result))
+
+	assert.Equal(t, "1234567890.123456789012", result.Amount.String())
+	require.NotNil(t, result.Fee)
+	assert.Equal(t, "0.3", result.Fee.String())
+	assert.True(t, result.Discount.Valid)
+	assert.Equal(t, "0.1", result.Discount.Decimal.String())
+
+	_, err := convertDecimal("12,34")
+	assert.ErrorIs(t, err, ErrInvalidBigNumber)
+}
@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// constants for subtag prefixes in parse subtag
+const (
+	ParseTagPrefix                          string = "parse"
+	DefaultValueSubTagPrefix                string = "default"
+	DefaultValueSubTagPrefixWithKVDelimiter string = "default:"
+	PartSubTagPrefix                        string = "part"
+	bDefaultSubTagScopeDelimiter            byte   = byte('\'')
+	sDefaultSubTagScopeDelimiter            string = "'"
+	DefaultKeyValueTagDelimiter             string = ":"
+	CommaDelimeter                          string = ","
+	ModifierKeyValueDelimiter               string = "="
+)
+
+// constants for struct tags that are not bindings
+const (
+	PreserveTagName string = "preserve"
+	ValidateTagName string = "validate"
+	UseTagName      string = "use"
+	PIITagName      string = "pii"
+)
+
+// constants for builtin source bindings in parse subtag
+const (
+	JsonTagBinding        string = "json"
+	CookieTagBinding      string = "cookie"
+	HeaderTagBinding      string = "header"
+	QueryTagBinding       string = "query"
+	MapValueTagBinding    string = "mapvalue"
+	BasicAuthTagBinding   string = "basicauth"
+	TrailerTagBinding     string = "trailer"
+	MultipartTagBinding   string = "multipart"
+	RangeTagBinding       string = "range"
+	AcceptTagBinding      string = "accept"
+	LocaleTagBinding      string = "locale"
+	GeoIPTagBinding       string = "geoip"
+	FlagTagBinding        string = "flag"
+	RateLimitTagBinding   string = "ratelimit"
+	TenantTagBinding      string = "tenant"
+	ConditionalTagBinding string = "conditional"
+	EnvTagBinding         string = "env"
+	FormTagBinding        string = "form" // requires a js/wasm build
+	DataTagBinding        string = "data"
+	INITagBinding         string = "ini"
+	PathTagBinding        string = "path"
+	BinaryTagBinding      string = "bin"
+	ColumnTagBinding      string = "column"
+	MailHeaderTagBinding  string = "mailheader"
+	MailBodyTagBinding    string = "mailbody"
+	CloudEventTagBinding  string = "cloudevent"
+	URLSegmentTagBinding  string = "urlseg"
+	KeyTagBinding         string = "key"
+	AttributeTagBinding   string = "attribute" // requires the pave_aws build tag
+	SQSTagBinding         string = "sqs"       // requires the pave_aws build tag
+	SNSTagBinding         string = "sns"       // requires the pave_aws build tag
+	MetadataTagBinding    string = "metadata"  // requires the pave_gocloud build tag
+	MessageTagBinding     string = "message"   // requires the pave_gocloud build tag
+)
+
+// constants for builtin source binding modifiers
+const (
+	OmitEmptyBindingModifier  string = "omitempty"
+	OmitNilBindingModifier    string = "omitnil"
+	OmitErrorBindingModifier  string = "omiterror"
+	AllowDupBindingModifier   string = "allowdup"
+	FlattenBindingModifier    string = "flatten"
+	ScopeBindingModifier      string = "scope"
+	LiteralBindingModifier    string = "literal"
+	CurrencyBindingModifier   string = "currency"
+	MaxBindingModifier        string = "max"
+	OffersBindingModifier     string = "offers"
+	SupportedBindingModifier  string = "supported"
+	KeyBindingModifier        string = "key"
+	AllowBindingModifier      string = "allow"
+	BitBindingModifier        string = "bit"
+	SignedBindingModifier     string = "signed"
+	LenBindingModifier        string = "len"
+	EndianBindingModifier     string = "endian"
+	OffsetBindingModifier     string = "offset"
+	DeprecatedBindingModifier string = "deprecated"
+	SunsetBindingModifier     string = "sunset"
+	EncryptedBindingModifier  string = "encrypted"
+	ChecksumBindingModifier   string = "checksum"
+	ExplodeBindingModifier    string = "explode"
+	DelimBindingModifier      string = "delim"
+	LayoutBindingModifier     string = "layout"
+	EnumBindingModifier       string = "enum"
+	E164BindingModifier       string = "e164" // requires the pave_phone build tag
+)
+
+// Parser Name constants for built in parsers.
+const (
+	HTTPRequestParserName      string = "http-request-parser"
+	JSONByteSliceParserName    string = "json-[]byte-parser"
+	JSONStringParserName       string = "json-string-parser"
+	StringMapParserName        string = "stringmap-parser"
+	StringAnyMapParserName     string = "map-parser"
+	TemplateDataParserName     string = "template-data-parser"
+	INIParserName              string = "ini-parser"
+	DotEnvParserName           string = "dotenv-parser"
+	EnvBlockParserName         string = "env-block-parser"
+	JSValueParserName          string = "js-value-parser" // requires a js/wasm build
+	MailMessageParserName      string = "mail-message-parser"
+	TableRowParserName         string = "table-row-parser"
+	BinaryParserName           string = "binary-parser"
+	AzureHTTPRequestParserName string = "azure-http-request-parser"
+	CloudEventParserName       string = "cloudevent-parser"
+	URLTemplateParserName      string = "url-template-parser"
+	ObjectKeyParserName        string = "object-key-parser"
+	APIGatewayProxyParserName  string = "apigateway-proxy-parser" // requires the pave_aws build tag
+	SQSMessageParserName       string = "sqs-message-parser"      // requires the pave_aws build tag
+	SNSEntityParserName        string = "sns-entity-parser"       // requires the pave_aws build tag
+	PubSubMessageParserName    string = "pubsub-message-parser"   // requires the pave_gocloud build tag
+)
+
+// Mime Type constants for content types and encodings.
+const (
+	ContentEncodingUTF8        string = "UTF-8"
+	ContentTypeApplicationJSON string = "application/json"
+	ContentTypeDelimiter       string = ";"
+)
+
+// reflect.TypeOf constants for type checks
+var (
+	HTTPRequestType   = reflect.TypeFor[http.Request]()
+	JSONByteSliceType = reflect.TypeFor[[]byte]()
+	StringType        = reflect.TypeFor[string]()
+	StringMapType     = reflect.TypeFor[map[string]string]()
+	StringMapAnyType  = reflect.TypeFor[map[string]any]()
+	DurationType      = reflect.TypeFor[time.Duration]()
+)
+
+// reflect.TypeOf constants for special struct types that should not be
+// parsed recursively
+var (
+	TimeType       = reflect.TypeFor[time.Time]()
+	FileHeaderType = reflect.TypeFor[multipart.FileHeader]()
+)
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// The deprecated modifier marks a legacy binding that clients should stop
+// using, with a hint at its replacement. The sunset modifier dates its
+// removal, as YYYY-MM-DD:
+//
+//	type Request struct {
+//		UserID string `query:"user_id" header:"X-User,omitempty,deprecated=use user_id,sunset=2026-06-30"`
+//	}
+//
+// Deprecated bindings keep working. When one supplies the value of a
+// field, ParseWithReport lists it in the Deprecations of its report, and
+// SetDeprecationHeaders announces it to the client.
+
+// Deprecation is a deprecated binding that supplied the value of a field
+// during a parse.
+type Deprecation struct {
+	Field   string    // Dotted path of the field
+	Binding Binding   // The deprecated binding
+	Message string    // Value of the deprecated modifier, if any
+	Sunset  time.Time // Value of the sunset modifier, if any
+}
+
+// parseDeprecatedModifier parses the value of the deprecated modifier,
+// a message for the clients of the binding.
+func parseDeprecatedModifier(value string) (any, error) {
+	if value == "" {
+		return nil, errors.New("deprecation message cannot be empty")
+	}
+	return value, nil
+}
+
+// parseSunsetModifier parses the value of the sunset modifier, the date
+// a deprecated binding is removed on.
+func parseSunsetModifier(value string) (any, error) {
+	sunset, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, fmt.Errorf("sunset must be a YYYY-MM-DD date, not %q", value)
+	}
+	return sunset, nil
+}
+
+// deprecation returns the Deprecation of binding, if it is deprecated.
+func deprecation(field string, binding Binding) (Deprecation, bool) {
+	message, deprecated := ModifierValue[string](binding, DeprecatedBindingModifier)
+	sunset, sunsetting := ModifierValue[time.Time](binding, SunsetBindingModifier)
+	if !deprecated && !sunsetting {
+		return Deprecation{}, false
+	}
+	return Deprecation{Field: field, Binding: binding, Message: message, Sunset: sunset}, true
+}
+
+// Deprecations returns the deprecated bindings that supplied the value of
+// a field, in execution order.
+func (report *ParseReport) Deprecations() []Deprecation {
+	var deprecations []Deprecation
+	for _, step := range report.Steps {
+		if step.Err != nil || step.Default {
+			continue
+		}
+		if dep, ok := deprecation(step.Field, step.Binding); ok {
+			deprecations = append(deprecations, dep)
+		}
+	}
+	return deprecations
+}
+
+// SetDeprecationHeaders sets the Deprecation header of a response if a
+// deprecated binding supplied a value during the parse of report, and the
+// Sunset header (RFC 8594) to the earliest sunset of those bindings.
+func SetDeprecationHeaders(header http.Header, report *ParseReport) {
+	deprecations := report.Deprecations()
+	if len(deprecations) == 0 {
+		return
+	}
+
+	header.Set("Deprecation", "true")
+
+	var sunset time.Time
+	for _, dep := range deprecations {
+		if !dep.Sunset.IsZero() && (sunset.IsZero() || dep.Sunset.Before(sunset)) {
+			sunset = dep.Sunset
+		}
+	}
+	if !sunset.IsZero() {
+		header.Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecatedBindings(t *testing.T) {
+	type Request struct {
+		UserID string `query:"user_id" header:"X-User,omitempty,deprecated=use user_id,sunset=2026-06-30"`
+		Org    string `query:"org,omitempty" header:"X-Org,omitempty,sunset=2026-03-31" default:"acme"`
+	}
+
+	parser := NewHTTPRequestParser()
+
+	t.Run("Current", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/?user_id=u1&org=globex", nil)
+
+		var request Request
+		report, err := parser.ParseWithReport(req, &request)
+		require.NoError(t, err)
+		assert.Empty(t, report.Deprecations())
+
+		header := http.Header{}
+		SetDeprecationHeaders(header, report)
+		assert.Empty(t, header)
+	})
+
+	t.Run("Deprecated", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/", nil)
+		req.Header.Set("X-User", "u1")
+		req.Header.Set("X-Org", "globex")
+
+		var request Request
+		report, err := parser.ParseWithReport(req, &request)
+		require.NoError(t, err)
+		assert.Equal(t, "u1", request.UserID, "deprecated bindings keep working")
+
+		deprecations := report.Deprecations()
+		require.Len(t, deprecations, 2)
+		assert.Equal(t, "UserID", deprecations[0].Field)
+		assert.Equal(t, "X-User", deprecations[0].Binding.Identifier)
+		assert.Equal(t, "use user_id", deprecations[0].Message)
+		assert.Equal(t, time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC), deprecations[0].Sunset)
+		assert.Empty(t, deprecations[1].Message)
+		assert.Contains(t, report.String(), `warning: UserID was bound from deprecated header:"X-User", use user_id (sunset 2026-06-30)`)
+
+		header := http.Header{}
+		SetDeprecationHeaders(header, report)
+		assert.Equal(t, "true", header.Get("Deprecation"))
+		assert.Equal(t, "Tue, 31 Mar 2026 00:00:00 GMT", header.Get("Sunset"), "the earliest sunset is announced")
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/?user_id=u1", nil)
+
+		var request Request
+		report, err := parser.ParseWithReport(req, &request)
+		require.NoError(t, err)
+		assert.Equal(t, "acme", request.Org)
+		assert.Empty(t, report.Deprecations(), "defaults are not deprecated")
+	})
+
+	t.Run("InvalidSunset", func(t *testing.T) {
+		var request struct {
+			UserID string `query:"uid,sunset=June"`
+		}
+		req := newTestRequest(t, "GET", "http://example.com/?uid=u1", nil)
+		assert.ErrorIs(t, parser.Parse(req, &request), ErrInvalidModifierValue)
+	})
+}
@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrDiffTypeMismatch = errors.New("cannot diff values of different types")
+)
+
+// FieldChange describes a bound field whose value differs between two
+// values of the same struct type.
+type FieldChange struct {
+	Field    string    // Dotted path of the struct field (e.g. "Address.Street")
+	Binding  Binding   // Provenance: the binding the field is parsed from
+	Bindings []Binding // All bindings of the field, in priority order
+	Old      any       // Value in the first argument
+	New      any       // Value in the second argument
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf(
+		"%s (%s:%q): %v -> %v",
+		c.Field, c.Binding.Name, c.Binding.Identifier, c.Old, c.New,
+	)
+}
+
+// Diff reports the bound fields that differ between a and b, in struct
+// order, using the binding tags understood by the HTTPRequestParser.
+// Fields without a binding are ignored.
+//
+// This is useful to build audit trails for PATCH style operations:
+// parse the stored and the incoming representation into the same type
+// and record the changes.
+func Diff[T any](a, b T) ([]FieldChange, error) {
+	return _httpEncoder.Diff(a, b)
+}
+
+// Diff reports the bound fields that differ between a and b, which must
+// be of the same struct type (or pointers to it).
+func (enc *Encoder) Diff(a, b any) ([]FieldChange, error) {
+	typeA, typeB := reflect.TypeOf(a), reflect.TypeOf(b)
+	if typeA != typeB {
+		return nil, fmt.Errorf("%w %s and %s", ErrDiffTypeMismatch, typeA, typeB)
+	}
+
+	fieldsA, err := enc.boundFields(a, false)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldsB, err := enc.boundFields(b, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FieldChange
+	for i := range fieldsA {
+		oldValue := fieldsA[i].Value.Interface()
+		newValue := fieldsB[i].Value.Interface()
+
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		changes = append(changes, FieldChange{
+			Field:    fieldsA[i].Field,
+			Binding:  fieldsA[i].Binding,
+			Bindings: fieldsA[i].Bindings,
+			Old:      oldValue,
+			New:      newValue,
+		})
+	}
+
+	return changes, nil
+}
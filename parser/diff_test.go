@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	type Address struct {
+		Street string `json:"address.street"`
+		City   string `json:"address.city"`
+	}
+
+	type User struct {
+		Name     string `json:"name"`
+		Email    string `header:"X-Email,omitempty" json:"email"`
+		Address  Address
+		Internal string
+	}
+
+	old := User{Name: "jane", Email: "j@a.com", Address: Address{Street: "Main", City: "Oslo"}, Internal: "x"}
+	updated := User{Name: "jane", Email: "", Address: Address{Street: "Side", City: "Oslo"}, Internal: "y"}
+
+	changes, err := Diff(old, updated)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	assert.Equal(t, "Email", changes[0].Field)
+	assert.Equal(t, JsonTagBinding, changes[0].Binding.Name)
+	assert.Len(t, changes[0].Bindings, 2)
+	assert.Equal(t, "j@a.com", changes[0].Old)
+	assert.Equal(t, "", changes[0].New)
+
+	assert.Equal(t, "Address.Street", changes[1].Field)
+	assert.Equal(t, "address.street", changes[1].Binding.Identifier)
+	assert.Equal(t, `Address.Street (json:"address.street"): Main -> Side`, changes[1].String())
+
+	t.Run("Pointers", func(t *testing.T) {
+		changes, err := Diff(&old, &old)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("NotStruct", func(t *testing.T) {
+		_, err := Diff(1, 2)
+		assert.ErrorIs(t, err, ErrEncodeNotStruct)
+	})
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultDirectBindingLimit is the number of header, query or cookie
+// bindings a destination type may have for the HTTPRequestParser to read
+// them straight from the request, see HTTPRequestParserOpts.
+const DefaultDirectBindingLimit = 2
+
+// markDirectBindings sets Direct on the bindings of the steps of chain
+// whose name limits maps to at least the number of distinct bindings of
+// that name in the chain and its sub-chains. See
+// PCManagerOpts.DirectBindings.
+//
+// Sub-chains were marked when they were built, and may be shared with
+// other chains, so only the steps of chain itself are marked.
+func markDirectBindings[S any](chain *ParseChain[S], limits map[string]int) {
+	if len(limits) == 0 {
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, binding := range chain.Bindings() {
+		counts[binding.Name]++
+	}
+
+	for current := chain.Head; current != nil; current = current.Next {
+		for i, binding := range current.Bindings {
+			limit, ok := limits[binding.Name]
+			current.Bindings[i].Direct = ok && counts[binding.Name] <= limit
+		}
+	}
+}
+
+// directBindingLimits returns the PCManagerOpts.DirectBindings of the
+// HTTPRequestParser for limit, an HTTPRequestParserOpts.DirectBindingLimit.
+func directBindingLimits(limit int) map[string]int {
+	switch {
+	case limit == 0:
+		limit = DefaultDirectBindingLimit
+	case limit < 0:
+		return nil
+	}
+
+	return map[string]int{
+		HeaderTagBinding: limit,
+		QueryTagBinding:  limit,
+		CookieTagBinding: limit,
+	}
+}
+
+// directValue gets the value of a Direct header, query or cookie binding
+// straight from source, the way the cached handlers find it, without
+// materializing the headers, query parameters or cookies of the request.
+// It reports false for the bindings it cannot read directly.
+func (mgr *HTTPBindingManager) directValue(source *http.Request, binding Binding) (BindingResult, bool) {
+	switch binding.Name {
+	case HeaderTagBinding:
+		if explodes(binding) {
+			return mgr.HeaderValues(source, binding.Identifier), true
+		}
+		value := source.Header.Get(binding.Identifier)
+		if value == "" {
+			return BindingResultNotFound(), true
+		}
+		return BindingResultValue(value), true
+
+	case QueryTagBinding:
+		if mgr.opts.QueryDecoding == QueryDecodingBracket || explodes(binding) {
+			return BindingResult{}, false
+		}
+		value, found := rawQueryValue(source.URL.RawQuery, binding.Identifier)
+		if !found {
+			return BindingResultNotFound(), true
+		}
+		return BindingResultValue(value), true
+
+	case CookieTagBinding:
+		// The cached handler keeps the last cookie of a name
+		cookies := source.CookiesNamed(binding.Identifier)
+		if len(cookies) == 0 {
+			return BindingResultNotFound(), true
+		}
+		return BindingResultValue(cookies[len(cookies)-1].Value), true
+	}
+
+	return BindingResult{}, false
+}
+
+// rawQueryValue returns the first value of the parameter key of the raw
+// query of a URL, skipping the parameters url.ParseQuery rejects.
+func rawQueryValue(rawQuery string, key string) (string, bool) {
+	for rawQuery != "" {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		if pair == "" || strings.Contains(pair, ";") {
+			continue
+		}
+
+		rawKey, rawValue, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(rawKey)
+		if err != nil || name != key {
+			continue
+		}
+
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			continue
+		}
+		return value, true
+	}
+	return "", false
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type directBindingsRequest struct {
+	Token   string `header:"X-Token,omitempty" default:""`
+	Session string `cookie:"session,omitempty" default:""`
+	Page    int    `query:"page,omitempty" default:"1"`
+	Search  string `query:"q,omitempty" default:""`
+}
+
+type cachedBindingsRequest struct {
+	Page  int    `query:"page,omitempty" default:"1"`
+	Sort  string `query:"sort,omitempty" default:""`
+	Order string `query:"order,omitempty" default:""`
+}
+
+func newDirectBindingsRequest(t testing.TB, rawQuery string) *http.Request {
+	req := newTestRequest(t, "GET", "http://example.com/?"+rawQuery, nil)
+	req.Header.Set("X-Token", "abc")
+	req.Header.Add("X-Token", "def")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "first"})
+	req.AddCookie(&http.Cookie{Name: "session", Value: "last"})
+	return req
+}
+
+func TestMarkDirectBindings(t *testing.T) {
+	parser := NewHTTPRequestParser()
+
+	chain, err := parser.PCMgr.GetParseChain(reflect.TypeFor[directBindingsRequest]())
+	require.NoError(t, err)
+	for _, binding := range chain.Bindings() {
+		assert.True(t, binding.Direct, binding.Name)
+	}
+
+	chain, err = parser.PCMgr.GetParseChain(reflect.TypeFor[cachedBindingsRequest]())
+	require.NoError(t, err)
+	step, _ := chain.Step("Sort")
+	assert.False(t, step.Bindings[0].Direct, "types with more query bindings than the limit use the cache")
+
+	disabled := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{DirectBindingLimit: -1})
+	chain, err = disabled.PCMgr.GetParseChain(reflect.TypeFor[directBindingsRequest]())
+	require.NoError(t, err)
+	for _, binding := range chain.Bindings() {
+		assert.False(t, binding.Direct, binding.Name)
+	}
+}
+
+func TestHTTPRequestParser_DirectBindings(t *testing.T) {
+	direct := NewHTTPRequestParser()
+	cached := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{DirectBindingLimit: -1})
+
+	queries := []string{
+		"page=2&q=go+pave",
+		"page=3&page=4&q=",
+		"p%61ge=5&q=%E2%82%AC",
+		"page=6;q=x&q=y",
+		"q=%zz&q=z&page",
+		"",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			var fromDirect, fromCache directBindingsRequest
+			directErr := direct.Parse(newDirectBindingsRequest(t, query), &fromDirect)
+			cachedErr := cached.Parse(newDirectBindingsRequest(t, query), &fromCache)
+
+			assert.Equal(t, cachedErr, directErr)
+			assert.Equal(t, fromCache, fromDirect)
+			assert.Equal(t, "abc", fromDirect.Token)
+			assert.Equal(t, "last", fromDirect.Session)
+		})
+	}
+}
+
+func TestRawQueryValue(t *testing.T) {
+	rawQueries := []string{"a=1&b=2&a=3", "a", "a=&a=1", "a=%", "a%=1&a=2", "x;a=1&a=2", "&&a=+1+", "b=1"}
+
+	for _, rawQuery := range rawQueries {
+		query, _ := url.ParseQuery(rawQuery)
+
+		value, found := rawQueryValue(rawQuery, "a")
+		assert.Equal(t, query.Has("a"), found, rawQuery)
+		assert.Equal(t, query.Get("a"), value, rawQuery)
+	}
+}
+
+func BenchmarkHTTPRequestParser_DirectBindings(b *testing.B) {
+	for name, limit := range map[string]int{"Direct": 0, "Cached": -1} {
+		b.Run(name, func(b *testing.B) {
+			parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{DirectBindingLimit: limit})
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var dest directBindingsRequest
+				if err := parser.Parse(newDirectBindingsRequest(b, "page=2&q=go&sort=name&order=asc"), &dest); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
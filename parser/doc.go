@@ -0,0 +1,19 @@
+// Package parser holds the parsing half of pave: the parsers of the
+// built-in sources, the execution chains built from struct tags, the
+// binding managers and the registries of parsers, converters and value
+// modifiers.
+//
+// It does not depend on the validation rules of the `validate` tag, which
+// the validate package provides. Until it installs its validator, parses
+// asking for validation fail with ErrNoTagValidator for structs with
+// validate tags, see SetTagValidator. The pave package combines both packages.
+//
+// Integrations with third-party modules are enabled by build tags, and the
+// package depends on a module only when its tag is set:
+//
+//	pave_decimal  github.com/shopspring/decimal destination fields
+//	pave_phone    phone numbers, backed by github.com/nyaruka/phonenumbers
+//	pave_semver   github.com/Masterminds/semver/v3 destination fields
+//	pave_aws      the event envelopes of github.com/aws/aws-lambda-go
+//	pave_gocloud  the messages of gocloud.dev/pubsub
+package parser
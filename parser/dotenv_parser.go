@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	ErrInvalidDotEnv = errors.New("invalid dotenv content")
+)
+
+var (
+	// Default DotEnvParser Options
+	_dotEnvParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts: PCManagerOpts{
+			tagOpts: ParseTagOpts{
+				BindingOpts: BindingOpts{
+					AllowedBindingNames: []string{EnvTagBinding},
+				},
+				AllowedTagOptionals: []string{},
+			},
+			ScopeFuncs: map[string]ScopeFunc{
+				EnvTagBinding: ConcatScope,
+			},
+		},
+	}
+)
+
+// DotEnvData is the content of a .env file, e.g. as read by os.ReadFile.
+type DotEnvData []byte
+
+// DotEnvParser parses DotEnvData.
+//
+// The following Field Bindings are supported:
+//   - env:'<name,[modifiers]>'`: Parses the value of the variable name.
+//
+// Every line is either empty, a # comment or NAME=VALUE, optionally
+// prefixed with "export ". Values are trimmed and may be quoted:
+//   - Unquoted values end at a " #" comment.
+//   - 'Single quoted' values are taken as they are.
+//   - "Double quoted" values may span lines and contain the escapes \n,
+//     \r, \t, \", \\ and \$.
+//
+// References to variables set earlier in the content, $NAME or ${NAME},
+// are expanded in unquoted and double quoted values, except after a \$
+// escape. The environment of
+// the process is not consulted.
+//
+// A nested struct field tagged with `env:"<prefix>"` scopes the env
+// bindings of its fields, e.g. `env:"HOST"` inside a struct tagged
+// `env:"DB_"` binds to "DB_HOST".
+//
+// The content of a source is decoded once, on its first parse. Parse new
+// DotEnvData after changing the content.
+type DotEnvParser struct {
+	*BaseMBParser[DotEnvData, ConfigFileOnce]
+}
+
+func NewDotEnvParser() *DotEnvParser {
+	return &DotEnvParser{
+		BaseMBParser: NewBaseMBParser(
+			&configFileBindingManager[DotEnvData]{decode: decodeDotEnv},
+			_dotEnvParserOpts,
+		),
+	}
+}
+
+func (dp *DotEnvParser) Name() string {
+	return DotEnvParserName
+}
+
+// decodeDotEnv decodes dotenv content into the values of its variables.
+func decodeDotEnv(content DotEnvData) (map[string]string, error) {
+	var (
+		values = make(map[string]string)
+		lines  = strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	)
+
+	expand := func(value string) string {
+		return os.Expand(value, func(name string) string { return values[name] })
+	}
+
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(lines[i])
+
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("%w: line %d: expected NAME=VALUE, got %q", ErrInvalidDotEnv, lineNo, line)
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, "'"):
+			end := strings.IndexByte(value[1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: line %d: unterminated single quote", ErrInvalidDotEnv, lineNo)
+			}
+			values[name] = value[1 : end+1]
+
+		case strings.HasPrefix(value, `"`):
+			// Double quoted values continue on the next lines until the
+			// closing quote
+			quoted := value[1:]
+			for {
+				unquoted, closed := unescapeDotEnvValue(quoted, expand)
+				if closed {
+					values[name] = unquoted
+					break
+				}
+				if i++; i >= len(lines) {
+					return nil, fmt.Errorf("%w: line %d: unterminated double quote", ErrInvalidDotEnv, lineNo)
+				}
+				quoted += "\n" + lines[i]
+			}
+
+		default:
+			if comment := strings.Index(value, " #"); comment >= 0 {
+				value = strings.TrimSpace(value[:comment])
+			}
+			values[name] = expand(value)
+		}
+	}
+
+	return values, nil
+}
+
+// unescapeDotEnvValue returns the content of a double quoted value up to
+// its closing quote, expanded by expand, and whether there was one. The
+// \$ escape is not expanded.
+func unescapeDotEnvValue(quoted string, expand func(string) string) (string, bool) {
+	var out, sb strings.Builder
+
+	for i := 0; i < len(quoted); i++ {
+		switch c := quoted[i]; {
+		case c == '"':
+			out.WriteString(expand(sb.String()))
+			return out.String(), true
+		case c == '\\' && i+1 < len(quoted):
+			i++
+			switch quoted[i] {
+			case '$':
+				out.WriteString(expand(sb.String()))
+				out.WriteByte('$')
+				sb.Reset()
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '"', '\\':
+				sb.WriteByte(quoted[i])
+			default:
+				sb.WriteByte('\\')
+				sb.WriteByte(quoted[i])
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return "", false
+}
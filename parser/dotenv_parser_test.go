@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDotEnvParser(t *testing.T) {
+	type Database struct {
+		Host string `env:"HOST"`
+		URL  string `env:"URL"`
+	}
+	type Config struct {
+		Name     string   `env:"APP_NAME"`
+		Port     int      `env:"PORT"`
+		Greeting string   `env:"GREETING"`
+		Literal  string   `env:"LITERAL"`
+		Key      string   `env:"KEY"`
+		Missing  string   `env:"MISSING,omitempty" default:"fallback"`
+		Database Database `env:"DB_"`
+	}
+
+	content := DotEnvData(`# application
+APP_NAME=billing
+export PORT=8080 # http port
+GREETING="hello\tworld"
+LITERAL='no $expansion\n here'
+KEY="-----BEGIN KEY-----
+abc
+-----END KEY-----"
+
+DB_HOST=db.internal
+DB_URL="postgres://${DB_HOST}:5432/$APP_NAME"
+`)
+
+	parser := NewDotEnvParser()
+
+	var config Config
+	require.NoError(t, parser.Parse(&content, &config))
+	assert.Equal(t, Config{
+		Name:     "billing",
+		Port:     8080,
+		Greeting: "hello\tworld",
+		Literal:  `no $expansion\n here`,
+		Key:      "-----BEGIN KEY-----\nabc\n-----END KEY-----",
+		Missing:  "fallback",
+		Database: Database{
+			Host: "db.internal",
+			URL:  "postgres://db.internal:5432/billing",
+		},
+	}, config)
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, content := range []DotEnvData{
+			DotEnvData("APP_NAME"),
+			DotEnvData("APP NAME=billing"),
+			DotEnvData("APP_NAME='billing"),
+			DotEnvData("KEY=\"abc\ndef"),
+		} {
+			var config Config
+			err := parser.Parse(&content, &config)
+			assert.ErrorIs(t, err, ErrInvalidDotEnv, string(content))
+		}
+	})
+}
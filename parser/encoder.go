@@ -0,0 +1,547 @@
+package parser
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEncodeNotStruct        = errors.New("value to encode must be a struct or a non-nil pointer to a struct")
+	ErrUnsupportedEncodeType  = errors.New("unsupported field type for encoding")
+	ErrJSONEncodePathConflict = errors.New("conflicting json binding paths")
+)
+
+// EncodedField is a single bound field of a struct, as seen by an Encoder.
+type EncodedField struct {
+	Binding  Binding       // The binding the field is written to (identifier already scoped)
+	Bindings []Binding     // All bindings of the field, in priority order
+	Part     string        // The part tag of the field, if any
+	Field    string        // Dotted path of the struct field
+	Value    reflect.Value // The field value
+}
+
+// Encoder is the reverse of a MultiBindingParser: it walks a struct with
+// the same binding tags and reports, for each bound field, the binding
+// the field would have been parsed from.
+//
+// Each field is written to the first of its bindings that the Encoder
+// knows about (in the order of AllowedBindingNames), so that a value
+// encoded by a client is picked up by the highest priority binding on
+// the server. Fields with omitempty/omitnil/omiterror on that binding are
+// skipped when they hold their zero value.
+//
+// Concrete representations are produced by the Encode* functions, e.g.
+// EncodeQuery, EncodeHeader, EncodeJSON, EncodeEnv and NewHTTPRequest.
+type Encoder struct {
+	tagOpts    ParseTagOpts
+	scopeFuncs map[string]ScopeFunc
+}
+
+// NewEncoder creates an Encoder for the given tag options. scopeFuncs must
+// match the ScopeFuncs of the parser the output is meant for.
+func NewEncoder(tagOpts ParseTagOpts, scopeFuncs map[string]ScopeFunc) *Encoder {
+	return &Encoder{
+		tagOpts:    tagOpts,
+		scopeFuncs: scopeFuncs,
+	}
+}
+
+// Fields returns all bound fields of v that should be encoded, in struct
+// order.
+func (enc *Encoder) Fields(v any) ([]EncodedField, error) {
+	return enc.boundFields(v, true)
+}
+
+// boundFields returns the bound fields of v. If skipOmittable is set,
+// zero valued fields whose binding may be omitted are left out.
+func (enc *Encoder) boundFields(v any, skipOmittable bool) ([]EncodedField, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, ErrEncodeNotStruct
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w, got %T", ErrEncodeNotStruct, v)
+	}
+
+	var fields []EncodedField
+	if err := enc.walk(value, "", nil, skipOmittable, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func (enc *Encoder) walk(
+	value reflect.Value,
+	path string,
+	scopes bindingScopes,
+	skipOmittable bool,
+	fields *[]EncodedField,
+) error {
+
+	typ := value.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		parseTag, err := DecodeParseTagV2(field, enc.tagOpts)
+		if err != nil {
+			return fmt.Errorf("%w %s: %w", ErrFailedToParseTag, fieldPath, err)
+		}
+
+		fieldValue := value.Field(i)
+
+		if parseTag.recursiveTag.Enabled {
+			// Nil pointers to structs have nothing to encode
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					continue
+				}
+				fieldValue = fieldValue.Elem()
+			}
+
+			childScopes := scopes.child(parseTag.bindingTags, enc.scopeFuncs)
+			if err := enc.walk(fieldValue, fieldPath, childScopes, skipOmittable, fields); err != nil {
+				return err
+			}
+			continue
+		}
+
+		bindings, err := makeBindings(parseTag, enc.tagOpts)
+		if err != nil {
+			return err
+		}
+		if len(bindings) == 0 {
+			continue
+		}
+
+		scopes.apply(bindings, enc.scopeFuncs)
+
+		binding := bindings[0]
+		if skipOmittable && !binding.Modifiers.Required && isZeroOrUnset(fieldValue) {
+			continue
+		}
+
+		*fields = append(*fields, EncodedField{
+			Binding:  binding,
+			Bindings: bindings,
+			Part:     parseTag.partTag.Name,
+			Field:    fieldPath,
+			Value:    fieldValue,
+		})
+	}
+
+	return nil
+}
+
+// format formats the value of the field the way it is parsed back,
+// applying the encode transforms of its binding, e.g. encryption.
+func (field EncodedField) format() (string, error) {
+	value, err := formatFieldValueWithModifiers(field.Value, field.Binding.Modifiers)
+	if err != nil {
+		return "", err
+	}
+	return encodeValue(value, field.Binding)
+}
+
+// formatFieldValue formats a field value the way setFieldValue parses it.
+func formatFieldValue(value reflect.Value) (string, error) {
+	return formatFieldValueWithModifiers(value, BindingModifiers{})
+}
+
+// formatFieldValueWithModifiers is formatFieldValue for the value of a
+// binding with the given modifiers, e.g. times in the layout of the
+// binding.
+func formatFieldValueWithModifiers(value reflect.Value, modifiers BindingModifiers) (string, error) {
+	if value.CanInterface() {
+		switch typed := value.Interface().(type) {
+		case *time.Location:
+			// Shared values converted through pointers are formatted by name
+			if typed != nil {
+				return typed.String(), nil
+			}
+		case time.Duration:
+			return typed.String(), nil
+		case time.Time:
+			if layout, ok := timeLayout(modifiers); ok {
+				return typed.Format(layout), nil
+			}
+		}
+	}
+
+	if value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", nil
+		}
+		return formatFieldValueWithModifiers(value.Elem(), modifiers)
+	}
+
+	// Unset Opt values are absent
+	if IsOptType(value.Type()) {
+		if !value.Field(1).Bool() {
+			return "", nil
+		}
+		return formatFieldValueWithModifiers(OptValue(value), modifiers)
+	}
+
+	// Unions format the value they hold
+	if IsUnionType(value.Type()) {
+		held, ok := UnionValue(value)
+		if !ok {
+			return "", nil
+		}
+		return formatFieldValueWithModifiers(held, modifiers)
+	}
+
+	// Enums are formatted by name
+	if name, ok, err := formatEnumValue(value, modifiers); ok {
+		return name, err
+	}
+
+	if value.CanInterface() {
+		if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+			text, err := marshaler.MarshalText()
+			return string(text), err
+		}
+	}
+	if value.CanAddr() {
+		if marshaler, ok := value.Addr().Interface().(encoding.TextMarshaler); ok {
+			text, err := marshaler.MarshalText()
+			return string(text), err
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.FormatComplex(value.Complex(), 'f', -1, value.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return string(value.Bytes()), nil
+		}
+	case reflect.Struct:
+		// Structs populated in one shot, see setStructValue
+		document, err := json.Marshal(value.Interface())
+		return string(document), err
+	case reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(bytes), value)
+			return hex.EncodeToString(bytes), nil
+		}
+
+		elems := make([]string, value.Len())
+		for i := range elems {
+			elem, err := formatFieldValueWithModifiers(value.Index(i), modifiers)
+			if err != nil {
+				return "", err
+			}
+			elems[i] = elem
+		}
+		return strings.Join(elems, CommaDelimeter), nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedEncodeType, value.Type())
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Representations
+///////////////////////////////////////////////////////////////////////////////
+
+var (
+	_httpEncoder = NewEncoder(_httpTagOpts, _httpPCMOpts.ScopeFuncs)
+
+	_envEncoder = NewEncoder(ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{EnvTagBinding},
+		},
+	}, nil)
+)
+
+// EncodeQuery encodes the query bindings of v as url.Values.
+func EncodeQuery(v any) (url.Values, error) {
+	return encodeValues(v, QueryTagBinding, func(values url.Values, key, value string) {
+		values.Add(key, value)
+	})
+}
+
+// EncodeHeader encodes the header bindings of v as an http.Header.
+func EncodeHeader(v any) (http.Header, error) {
+	header, err := encodeValues(v, HeaderTagBinding, func(values url.Values, key, value string) {
+		http.Header(values).Add(key, value)
+	})
+	return http.Header(header), err
+}
+
+func encodeValues(v any, bindingName string, add func(url.Values, string, string)) (url.Values, error) {
+	fields, err := _httpEncoder.Fields(v)
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	for _, field := range fields {
+		if field.Binding.Name != bindingName {
+			continue
+		}
+
+		value, err := field.format()
+		if err != nil {
+			return nil, fmt.Errorf("error encoding field %s: %w", field.Field, err)
+		}
+		add(values, field.Binding.Identifier, value)
+	}
+
+	return values, nil
+}
+
+// EncodeJSON encodes the json bindings of v as a JSON object. Dotted
+// identifiers (e.g. `json:"user.name"`) produce nested objects, unless
+// the dots are escaped or the binding is literal.
+func EncodeJSON(v any) ([]byte, error) {
+	fields, err := _httpEncoder.Fields(v)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := encodeJSONDocument(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+func encodeJSONDocument(fields []EncodedField) (map[string]any, error) {
+	doc := make(map[string]any)
+
+	for _, field := range fields {
+		if field.Binding.Name != JsonTagBinding {
+			continue
+		}
+
+		path := jsonBindingKeys(field.Binding)
+		node := doc
+		for _, key := range path[:len(path)-1] {
+			child, exists := node[key]
+			if !exists {
+				next := make(map[string]any)
+				node[key] = next
+				node = next
+				continue
+			}
+
+			next, ok := child.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrJSONEncodePathConflict, field.Binding.Identifier)
+			}
+			node = next
+		}
+
+		leaf := path[len(path)-1]
+		if _, exists := node[leaf]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrJSONEncodePathConflict, field.Binding.Identifier)
+		}
+		if hasEncodeTransform(field.Binding.Modifiers) {
+			value, err := field.format()
+			if err != nil {
+				return nil, fmt.Errorf("error encoding field %s: %w", field.Field, err)
+			}
+			node[leaf] = value
+			continue
+		}
+		node[leaf] = field.Value.Interface()
+	}
+
+	return doc, nil
+}
+
+// EncodeEnv encodes the env bindings of v as dotenv content (KEY=VALUE
+// lines, sorted by key) that DotEnvParser parses back into v. Values
+// containing whitespace, quotes, '#', backslashes or '$' are quoted, see
+// quoteDotEnvValue.
+func EncodeEnv(v any) ([]byte, error) {
+	fields, err := _envEncoder.Fields(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sorting whole lines would order DB_PORT2 before DB_PORT
+	slices.SortStableFunc(fields, func(a, b EncodedField) int {
+		return strings.Compare(a.Binding.Identifier, b.Binding.Identifier)
+	})
+
+	var buf bytes.Buffer
+	for _, field := range fields {
+		value, err := field.format()
+		if err != nil {
+			return nil, fmt.Errorf("error encoding field %s: %w", field.Field, err)
+		}
+
+		if value != strings.TrimSpace(value) || strings.ContainsAny(value, " \t\r\n\"'#\\$") {
+			value = quoteDotEnvValue(value)
+		}
+		buf.WriteString(field.Binding.Identifier + "=" + value)
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes(), nil
+}
+
+// _dotEnvEscaper escapes double quoted dotenv values, see
+// unescapeDotEnvValue.
+var _dotEnvEscaper = strings.NewReplacer(
+	"\\", `\\`,
+	"\"", `\"`,
+	"$", `\$`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// quoteDotEnvValue quotes value for DotEnvParser. Values are double
+// quoted with the escapes that DotEnvParser knows, except values with
+// references to variables ('$'), which are single quoted so that they are
+// not expanded, unless they contain single quotes or line breaks.
+func quoteDotEnvValue(value string) string {
+	if strings.Contains(value, "$") && !strings.ContainsAny(value, "'\r\n") {
+		return "'" + value + "'"
+	}
+	return `"` + _dotEnvEscaper.Replace(value) + `"`
+}
+
+// NewHTTPRequest builds a request that HTTPRequestParser parses back into
+// a value equal to v: query, header and cookie bindings are set on the
+// request, json bindings form the body and basicauth parts set the
+// Authorization header. Range fields set their header. Trailer bindings
+// are sent as trailers, after a chunked body. Path bindings set the path
+// values of the request, as http.ServeMux does.
+func NewHTTPRequest(method string, target string, v any) (*http.Request, error) {
+	fields, err := _httpEncoder.Fields(v)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := req.URL.Query()
+	basicAuth := make(map[string]map[string]string)
+
+	for _, field := range fields {
+		if field.Binding.Name == JsonTagBinding {
+			continue
+		}
+
+		value, err := field.format()
+		if err != nil {
+			return nil, fmt.Errorf("error encoding field %s: %w", field.Field, err)
+		}
+
+		switch field.Binding.Name {
+		case QueryTagBinding:
+			query.Add(field.Binding.Identifier, value)
+		case PathTagBinding:
+			req.SetPathValue(field.Binding.Identifier, value)
+		case HeaderTagBinding:
+			req.Header.Add(field.Binding.Identifier, value)
+		case CookieTagBinding:
+			req.AddCookie(&http.Cookie{Name: field.Binding.Identifier, Value: value})
+		case BasicAuthTagBinding:
+			if basicAuth[field.Binding.Identifier] == nil {
+				basicAuth[field.Binding.Identifier] = make(map[string]string)
+			}
+			basicAuth[field.Binding.Identifier][field.Part] = value
+		case AcceptTagBinding, LocaleTagBinding:
+			req.Header.Add(field.Binding.Identifier, value)
+		case ConditionalTagBinding:
+			// Absent headers are zero values
+			if field.Value.IsZero() {
+				continue
+			}
+			if date, ok := field.Value.Interface().(time.Time); ok {
+				value = date.UTC().Format(http.TimeFormat)
+			}
+			req.Header.Add(field.Binding.Identifier, value)
+		case RangeTagBinding:
+			// Parts of the first range cannot form a header of their own
+			if field.Part == "" {
+				req.Header.Add(field.Binding.Identifier, value)
+			}
+		case TrailerTagBinding:
+			if req.Trailer == nil {
+				req.Trailer = make(http.Header)
+			}
+			req.Trailer.Add(field.Binding.Identifier, value)
+		}
+	}
+
+	req.URL.RawQuery = query.Encode()
+
+	for header, parts := range basicAuth {
+		credentials := parts[BasicAuthUsernamePart] + ":" + parts[BasicAuthPasswordPart]
+		req.Header.Set(header, "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+
+	doc, err := encodeJSONDocument(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doc) > 0 {
+		body, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+		req.Header.Set("Content-Type", ContentTypeApplicationJSON)
+	}
+
+	// Trailers require a chunked body, of unknown length
+	if len(req.Trailer) > 0 {
+		if req.Body == nil {
+			req.Body = http.NoBody
+		}
+		req.ContentLength = -1
+	}
+
+	return req, nil
+}
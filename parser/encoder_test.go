@@ -0,0 +1,203 @@
+package parser
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type encodeTestPaging struct {
+	Page int `query:"page"`
+	Size int `query:"size,omitempty" default:"20"`
+}
+
+type encodeTestRequest struct {
+	UserName  string           `json:"user.name"`
+	UserAge   int              `json:"user.age"`
+	Active    bool             `json:"active"`
+	RequestID uuid.UUID        `header:"X-Request-ID"`
+	Session   string           `cookie:"session"`
+	Since     time.Time        `query:"since"`
+	Paging    encodeTestPaging `query:"p_"`
+	Username  string           `basicauth:"Authorization" part:"username"`
+	Password  string           `basicauth:"Authorization" part:"password"`
+	Trace     string           `header:"X-Trace,omitempty"`
+}
+
+func TestEncoder_Fields(t *testing.T) {
+	fields, err := _httpEncoder.Fields(&encodeTestRequest{
+		Paging: encodeTestPaging{Page: 2},
+	})
+	require.NoError(t, err)
+
+	byField := make(map[string]EncodedField)
+	for _, field := range fields {
+		byField[field.Field] = field
+	}
+
+	assert.Equal(t, "p_page", byField["Paging.Page"].Binding.Identifier)
+	assert.NotContains(t, byField, "Paging.Size", "zero omitempty fields are skipped")
+	assert.NotContains(t, byField, "Trace", "zero omitempty fields are skipped")
+	assert.Equal(t, "username", byField["Username"].Part)
+
+	_, err = _httpEncoder.Fields("not a struct")
+	assert.ErrorIs(t, err, ErrEncodeNotStruct)
+}
+
+func TestEncodeQueryAndHeader(t *testing.T) {
+	v := encodeTestRequest{
+		RequestID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		Since:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Paging:    encodeTestPaging{Page: 2, Size: 50},
+	}
+
+	query, err := EncodeQuery(v)
+	require.NoError(t, err)
+	assert.Equal(t, "2", query.Get("p_page"))
+	assert.Equal(t, "50", query.Get("p_size"))
+	assert.Equal(t, "2024-01-02T03:04:05Z", query.Get("since"))
+
+	header, err := EncodeHeader(&v)
+	require.NoError(t, err)
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", header.Get("X-Request-ID"))
+}
+
+func TestEncodeJSON(t *testing.T) {
+	body, err := EncodeJSON(encodeTestRequest{UserName: "jane", UserAge: 30, Active: true})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user": {"name": "jane", "age": 30}, "active": true}`, string(body))
+
+	t.Run("PathConflict", func(t *testing.T) {
+		type Conflict struct {
+			User     string `json:"user"`
+			UserName string `json:"user.name"`
+		}
+
+		_, err := EncodeJSON(Conflict{User: "a", UserName: "b"})
+		assert.ErrorIs(t, err, ErrJSONEncodePathConflict)
+	})
+}
+
+func TestEncodeEnv(t *testing.T) {
+	type Config struct {
+		Host    string `env:"DB_HOST"`
+		Port    int    `env:"DB_PORT"`
+		Comment string `env:"COMMENT,omitempty"`
+		Motd    string `env:"MOTD"`
+	}
+
+	out, err := EncodeEnv(Config{Host: "localhost", Port: 5432, Motd: "hello world"})
+	require.NoError(t, err)
+	assert.Equal(t, "DB_HOST=localhost\nDB_PORT=5432\nMOTD=\"hello world\"\n", string(out))
+
+	t.Run("SortedByIdentifier", func(t *testing.T) {
+		type Replicas struct {
+			Second string `env:"DB_HOST2"`
+			First  string `env:"DB_HOST"`
+			Dashed string `env:"DB-HOST"`
+		}
+
+		out, err := EncodeEnv(Replicas{Second: "b", First: "a", Dashed: "c"})
+		require.NoError(t, err)
+		assert.Equal(t, "DB-HOST=c\nDB_HOST=a\nDB_HOST2=b\n", string(out))
+	})
+}
+
+func TestEncodeEnv_RoundTrip(t *testing.T) {
+	type Config struct {
+		Password string `env:"PASS"`
+		Multi    string `env:"MULTI"`
+		Quote    string `env:"QUOTE"`
+		Control  string `env:"CONTROL"`
+		Unicode  string `env:"UNICODE"`
+		Spaces   string `env:"SPACES"`
+		Slash    string `env:"SLASH"`
+		Comment  string `env:"COMMENT"`
+	}
+
+	want := Config{
+		Password: "pa$word",
+		Multi:    "it's\n$HOME\r\t\"x\"",
+		Quote:    `say "hi" to ${USER}`,
+		Control:  "a\x01b\u00ff",
+		Unicode:  "żółw",
+		Spaces:   "  padded\t",
+		Slash:    `C:\dir\$x`,
+		Comment:  "a #b",
+	}
+
+	out, err := EncodeEnv(want)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "PASS='pa$word'\n")
+
+	var got Config
+	content := DotEnvData(out)
+	require.NoError(t, NewDotEnvParser().Parse(&content, &got))
+	assert.Equal(t, want, got)
+}
+
+func TestNewHTTPRequest_RoundTrip(t *testing.T) {
+	want := encodeTestRequest{
+		UserName:  "jane",
+		UserAge:   30,
+		Active:    true,
+		RequestID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		Session:   "abc123",
+		Since:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Paging:    encodeTestPaging{Page: 2, Size: 50},
+		Username:  "alice",
+		Password:  "s3cret",
+		Trace:     "t-1",
+	}
+
+	req, err := NewHTTPRequest("POST", "http://example.com/users", want)
+	require.NoError(t, err)
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+	assert.Equal(t, ContentTypeApplicationJSON, req.Header.Get("Content-Type"))
+
+	req, err = NewHTTPRequest("POST", "http://example.com/users", want)
+	require.NoError(t, err)
+
+	var got encodeTestRequest
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &got))
+	assert.Equal(t, want, got)
+}
+
+func TestNewHTTPRequest_Trailers(t *testing.T) {
+	type upload struct {
+		Name     string `json:"name"`
+		Checksum string `trailer:"X-Checksum"`
+	}
+
+	want := upload{Name: "report.csv", Checksum: "sha256=abc"}
+
+	received := make(chan upload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var got upload
+		if err := NewHTTPRequestParser().Parse(r, &got); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received <- got
+	}))
+	defer server.Close()
+
+	req, err := NewHTTPRequest("POST", server.URL, want)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), req.ContentLength)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Equal(t, want, <-received)
+}
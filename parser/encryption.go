@@ -0,0 +1,169 @@
+package parser
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrNoKeyProvider     = errors.New("no key provider registered")
+	ErrUnsupportedCipher = errors.New("unsupported cipher")
+	ErrDecryptionFailed  = errors.New("failed to decrypt value")
+)
+
+// constants for the ciphers of the encrypted modifier
+const (
+	AESGCMCipher string = "aes-gcm"
+)
+
+// The encrypted modifier decrypts the values of a binding, e.g. encrypted
+// cookies or config secrets, before they are converted to the field type:
+//
+//	type Session struct {
+//		UserID string `cookie:"session,encrypted=aes-gcm"`
+//	}
+//
+// Values are the base64url encoded (unpadded) nonce and ciphertext of the
+// value, sealed with a key of the registered KeyProvider. The binding name
+// and identifier (e.g. "cookie:session") are authenticated along with the
+// value, so that a value sealed for one binding is not accepted by
+// another. Encoders seal the values of such fields again, so that
+// EncodeQuery, NewHTTPRequest, etc. produce what the parser decrypts.
+
+// KeyProvider provides the keys of encrypted bindings, e.g. from a secret
+// manager or KMS. See RegisterKeyProvider.
+type KeyProvider interface {
+	// Keys returns the keys values are decrypted with, in the order they
+	// are tried, so that keys can be rotated. The first key encrypts.
+	// AES-GCM keys are 16, 24 or 32 bytes long.
+	Keys() ([][]byte, error)
+}
+
+// KeyProviderFunc adapts a function to the KeyProvider interface.
+type KeyProviderFunc func() ([][]byte, error)
+
+func (f KeyProviderFunc) Keys() ([][]byte, error) {
+	return f()
+}
+
+var (
+	_keyProvider      KeyProvider
+	_keyProviderMutex sync.RWMutex
+)
+
+// RegisterKeyProvider makes provider provide the keys of encrypted
+// bindings, replacing the provider registered before, if any.
+func RegisterKeyProvider(provider KeyProvider) {
+	_keyProviderMutex.Lock()
+	defer _keyProviderMutex.Unlock()
+
+	_keyProvider = provider
+}
+
+// UnregisterKeyProvider removes the registered key provider, if any.
+func UnregisterKeyProvider() {
+	RegisterKeyProvider(nil)
+}
+
+// encryptionKeys returns the keys of the registered KeyProvider.
+func encryptionKeys() ([][]byte, error) {
+	_keyProviderMutex.RLock()
+	provider := _keyProvider
+	_keyProviderMutex.RUnlock()
+
+	if provider == nil {
+		return nil, ErrNoKeyProvider
+	}
+
+	keys, err := provider.Keys()
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no keys", ErrNoKeyProvider)
+	}
+	return keys, nil
+}
+
+// parseEncryptedModifier parses the value of the encrypted modifier, the
+// cipher values are sealed with.
+func parseEncryptedModifier(value string) (any, error) {
+	if value != AESGCMCipher {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedCipher, value)
+	}
+	return value, nil
+}
+
+// newAEAD returns the AES-GCM cipher of key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// associatedData returns the additional data values of binding are
+// sealed with, tying them to the binding.
+func associatedData(binding Binding) []byte {
+	return []byte(binding.Name + ":" + binding.Identifier)
+}
+
+// transformDecrypt decrypts value with the keys of the registered
+// KeyProvider, trying each in turn.
+func transformDecrypt(value string, _ any, binding Binding) (string, error) {
+	keys, err := encryptionKeys()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid encoding", ErrDecryptionFailed)
+	}
+
+	for _, key := range keys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+		}
+		if len(sealed) < aead.NonceSize() {
+			return "", fmt.Errorf("%w: value too short", ErrDecryptionFailed)
+		}
+
+		nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+		if plaintext, err := aead.Open(nil, nonce, ciphertext, associatedData(binding)); err == nil {
+			return string(plaintext), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: no key opens it", ErrDecryptionFailed)
+}
+
+// transformEncrypt seals value with the first key of the registered
+// KeyProvider, reverting transformDecrypt.
+func transformEncrypt(value string, _ any, binding Binding) (string, error) {
+	keys, err := encryptionKeys()
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(keys[0])
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(value)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(value), associatedData(binding))
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedBindings(t *testing.T) {
+	type Session struct {
+		UserID string `cookie:"session,encrypted=aes-gcm"`
+		Limit  int    `query:"limit,encrypted=aes-gcm"`
+		Page   int    `query:"page"`
+	}
+
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 16)
+
+	RegisterKeyProvider(KeyProviderFunc(func() ([][]byte, error) {
+		return [][]byte{oldKey}, nil
+	}))
+	defer UnregisterKeyProvider()
+
+	parser := NewHTTPRequestParser()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		req, err := NewHTTPRequest("GET", "http://example.com/", &Session{UserID: "u1", Limit: 10, Page: 2})
+		require.NoError(t, err)
+
+		cookie, err := req.Cookie("session")
+		require.NoError(t, err)
+		assert.NotEqual(t, "u1", cookie.Value, "encrypted bindings are sealed")
+		assert.NotEqual(t, "10", req.URL.Query().Get("limit"))
+		assert.Equal(t, "2", req.URL.Query().Get("page"))
+
+		var session Session
+		require.NoError(t, parser.Parse(req, &session))
+		assert.Equal(t, Session{UserID: "u1", Limit: 10, Page: 2}, session)
+	})
+
+	t.Run("BoundToBinding", func(t *testing.T) {
+		type Tokens struct {
+			User  string `query:"user,encrypted=aes-gcm"`
+			Admin string `query:"admin,encrypted=aes-gcm,omitempty" default:""`
+		}
+
+		req, err := NewHTTPRequest("GET", "http://example.com/", &Tokens{User: "u1"})
+		require.NoError(t, err)
+
+		query := req.URL.Query()
+		query.Set("admin", query.Get("user"))
+		req.URL.RawQuery = query.Encode()
+
+		var tokens Tokens
+		err = parser.Parse(req, &tokens)
+		assert.ErrorIs(t, err, ErrDecryptionFailed, "values sealed for one binding do not open for another")
+	})
+
+	t.Run("KeyRotation", func(t *testing.T) {
+		req, err := NewHTTPRequest("GET", "http://example.com/", &Session{UserID: "u1", Limit: 10})
+		require.NoError(t, err)
+
+		RegisterKeyProvider(KeyProviderFunc(func() ([][]byte, error) {
+			return [][]byte{newKey, oldKey}, nil
+		}))
+
+		var session Session
+		require.NoError(t, parser.Parse(req, &session), "values sealed with older keys still open")
+		assert.Equal(t, "u1", session.UserID)
+
+		RegisterKeyProvider(KeyProviderFunc(func() ([][]byte, error) {
+			return [][]byte{newKey}, nil
+		}))
+
+		err = parser.Parse(req, &session)
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/?limit=bm90LXNlYWxlZC1hdC1hbGw", nil)
+		req.AddCookie(&http.Cookie{Name: "session", Value: "!!"})
+
+		var session Session
+		err := parser.Parse(req, &session)
+		assert.ErrorIs(t, err, ErrDecryptionFailed)
+	})
+
+	t.Run("NoKeyProvider", func(t *testing.T) {
+		UnregisterKeyProvider()
+
+		req := newTestRequest(t, "GET", "http://example.com/?limit=x", nil)
+		req.AddCookie(&http.Cookie{Name: "session", Value: "x"})
+
+		var session Session
+		assert.ErrorIs(t, parser.Parse(req, &session), ErrNoKeyProvider)
+
+		_, err := EncodeQuery(&Session{Limit: 1})
+		assert.ErrorIs(t, err, ErrNoKeyProvider)
+	})
+
+	t.Run("KeyProviderError", func(t *testing.T) {
+		errVault := errors.New("vault sealed")
+		RegisterKeyProvider(KeyProviderFunc(func() ([][]byte, error) {
+			return nil, errVault
+		}))
+
+		_, err := EncodeQuery(&Session{Limit: 1})
+		assert.ErrorIs(t, err, errVault)
+	})
+}
+
+func TestEncryptedBindings_UnsupportedCipher(t *testing.T) {
+	type Session struct {
+		UserID string `cookie:"session,encrypted=rot13"`
+	}
+
+	req := newTestRequest(t, "GET", "http://example.com/", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "x"})
+
+	var session Session
+	err := NewHTTPRequestParser().Parse(req, &session)
+	assert.ErrorIs(t, err, ErrUnsupportedCipher)
+}
+
+func TestEncryptedBindings_JSON(t *testing.T) {
+	type Secret struct {
+		Token string `json:"token,encrypted=aes-gcm"`
+		Name  string `json:"name"`
+	}
+
+	RegisterKeyProvider(KeyProviderFunc(func() ([][]byte, error) {
+		return [][]byte{bytes.Repeat([]byte{3}, 24)}, nil
+	}))
+	defer UnregisterKeyProvider()
+
+	body, err := EncodeJSON(&Secret{Token: "t0ps3cret", Name: "ci"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "t0ps3cret")
+
+	req := newTestRequest(t, "POST", "http://example.com/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	var secret Secret
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &secret))
+	assert.Equal(t, Secret{Token: "t0ps3cret", Name: "ci"}, secret)
+}
@@ -0,0 +1,207 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidEnumValue = errors.New("invalid enum value")
+)
+
+// enumType holds the named constants of an enum type, see RegisterEnum.
+type enumType struct {
+	names   []string       // Names of the constants, sorted
+	values  map[string]any // Constants by name
+	byValue map[any]string // Names by constant, the first in sort order, for encoders
+}
+
+// enums holds the registered enum types.
+var (
+	_enums      = make(map[reflect.Type]*enumType)
+	_enumsMutex sync.RWMutex
+)
+
+// RegisterEnum registers the named constants of the enum type T, so that
+// fields of type T (or *T) take the name of a constant and hold the
+// constant, and encoders format them by name:
+//
+//	type Color int
+//
+//	const (
+//		Red Color = iota + 1
+//		Green
+//		Blue
+//	)
+//
+//	pave.RegisterEnum(map[string]Color{"red": Red, "green": Green, "blue": Blue})
+//
+// Other values fail with ErrInvalidEnumValue, listing the names. Constants
+// with more than one name are formatted with the first in sort order. The
+// enum modifier further restricts the names a binding accepts.
+//
+// Registering an enum replaces the TypeConverter of T, if any.
+func RegisterEnum[T comparable](constants map[string]T) {
+	enum := &enumType{
+		names:   slices.Sorted(maps.Keys(constants)),
+		values:  make(map[string]any, len(constants)),
+		byValue: make(map[any]string, len(constants)),
+	}
+	for _, name := range enum.names {
+		enum.values[name] = constants[name]
+		if _, ok := enum.byValue[constants[name]]; !ok {
+			enum.byValue[constants[name]] = name
+		}
+	}
+
+	typ := reflect.TypeFor[T]()
+
+	_enumsMutex.Lock()
+	_enums[typ] = enum
+	_enumsMutex.Unlock()
+
+	RegisterTypeConverter(func(value string) (T, error) {
+		constant, ok := enum.values[value]
+		if !ok {
+			return *new(T), enumValueError(value, enum.names)
+		}
+		return constant.(T), nil
+	})
+}
+
+// UnregisterEnum removes the enum registered for type T, if any, and its
+// TypeConverter.
+func UnregisterEnum[T comparable]() {
+	_enumsMutex.Lock()
+	delete(_enums, reflect.TypeFor[T]())
+	_enumsMutex.Unlock()
+
+	UnregisterTypeConverter[T]()
+}
+
+// getEnum returns the enum registered for typ, if any.
+func getEnum(typ reflect.Type) (*enumType, bool) {
+	_enumsMutex.RLock()
+	defer _enumsMutex.RUnlock()
+
+	enum, ok := _enums[typ]
+	return enum, ok
+}
+
+// parseEnumModifier parses the names of the enum modifier, separated by
+// "|", e.g. `enum=red|green|blue`.
+func parseEnumModifier(value string) (any, error) {
+	names := strings.Split(value, "|")
+	for i, name := range names {
+		if names[i] = strings.TrimSpace(name); names[i] == "" {
+			return nil, fmt.Errorf("enum names must not be empty in %q", value)
+		}
+		if slices.Contains(names[:i], names[i]) {
+			return nil, fmt.Errorf("duplicate enum name %q", names[i])
+		}
+	}
+	return names, nil
+}
+
+// enumNames returns the names of the enum modifier of modifiers, if any.
+func enumNames(modifiers BindingModifiers) ([]string, bool) {
+	names, ok := modifiers.Values[EnumBindingModifier].([]string)
+	return names, ok
+}
+
+// enumValueError is the error of a value that is none of names.
+func enumValueError(value string, names []string) error {
+	return fmt.Errorf("%w %q, allowed values are %s", ErrInvalidEnumValue, value, strings.Join(names, ", "))
+}
+
+// checksEnum reports whether the enum modifier of a binding checks
+// values of typ as a whole. Pointers, and slices and arrays converted
+// element by element, leave the check to their elements.
+func checksEnum(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return false
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return true
+		}
+		_, hasConverter := getTypeConverter(typ)
+		return hasConverter || implementsUnmarshaler(typ)
+	}
+	return true
+}
+
+// isIndexEnum reports whether fields of typ hold the index of their name
+// in the enum modifier, rather than a value converted from the name:
+// integers without a TypeConverter or unmarshaler of their own, such as
+// constants declared with iota.
+func isIndexEnum(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+	default:
+		return false
+	}
+	_, hasConverter := getTypeConverter(typ)
+	return !hasConverter && !implementsUnmarshaler(typ)
+}
+
+// setEnumValue checks that value is one of the names of the enum
+// modifier, and sets index enums (see isIndexEnum) to its index. It
+// reports whether it set field, which is otherwise converted from value as
+// usual.
+func setEnumValue(field reflect.Value, value string, names []string) (bool, error) {
+	index := slices.Index(names, value)
+	if index < 0 {
+		return false, enumValueError(value, names)
+	}
+	if !isIndexEnum(field.Type()) {
+		return false, nil
+	}
+
+	if field.CanInt() {
+		if field.OverflowInt(int64(index)) {
+			return false, fmt.Errorf("%w: enum index %d for %s", ErrValueOverflow, index, field.Type())
+		}
+		field.SetInt(int64(index))
+	} else {
+		if field.OverflowUint(uint64(index)) {
+			return false, fmt.Errorf("%w: enum index %d for %s", ErrValueOverflow, index, field.Type())
+		}
+		field.SetUint(uint64(index))
+	}
+	return true, nil
+}
+
+// formatEnumValue formats the values of registered enums and index enums
+// by name. It reports whether value is an enum.
+func formatEnumValue(value reflect.Value, modifiers BindingModifiers) (string, bool, error) {
+	if enum, ok := getEnum(value.Type()); ok && value.CanInterface() {
+		name, ok := enum.byValue[value.Interface()]
+		if !ok {
+			return "", true, enumValueError(fmt.Sprint(value.Interface()), enum.names)
+		}
+		return name, true, nil
+	}
+
+	names, ok := enumNames(modifiers)
+	if !ok || !isIndexEnum(value.Type()) {
+		return "", false, nil
+	}
+
+	index := -1
+	if value.CanInt() && value.Int() >= 0 && value.Int() < int64(len(names)) {
+		index = int(value.Int())
+	} else if value.CanUint() && value.Uint() < uint64(len(names)) {
+		index = int(value.Uint())
+	}
+	if index < 0 {
+		return "", true, enumValueError(fmt.Sprint(value.Interface()), names)
+	}
+	return names[index], true, nil
+}
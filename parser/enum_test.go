@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type enumShade string
+
+type enumLevel uint16
+
+type enumColor int
+
+const (
+	enumRed enumColor = iota + 1
+	enumGreen
+	enumBlue
+)
+
+type enumRequest struct {
+	Shade   enumShade    `query:"shade,enum=light|dark"`
+	Level   enumLevel    `query:"level,omitempty,enum=debug|info|warn" default:"info"`
+	Color   enumColor    `query:"color,omitempty" default:"red"`
+	Primary *enumColor   `query:"primary,omitempty,enum=red|blue" default:""`
+	Tags    []string     `query:"tag,omitempty,enum=a|b|c" default:""`
+	Opt     Opt[string]  `query:"opt,omitempty,enum=x|y"`
+	Levels  [2]enumLevel `query:"levels,omitempty,enum=debug|info|warn" default:"debug,warn"`
+}
+
+func registerEnumColor(t *testing.T) {
+	RegisterEnum(map[string]enumColor{"red": enumRed, "green": enumGreen, "blue": enumBlue, "crimson": enumRed})
+	t.Cleanup(UnregisterEnum[enumColor])
+}
+
+func TestEnum_HTTP(t *testing.T) {
+	registerEnumColor(t)
+	parser := NewHTTPRequestParser()
+
+	t.Run("Parsed", func(t *testing.T) {
+		var dest enumRequest
+		require.NoError(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/?shade=dark&level=warn&color=green&primary=blue&tag=c&tag=a&opt=y&levels=debug,warn", nil), &dest))
+
+		assert.Equal(t, enumShade("dark"), dest.Shade)
+		assert.Equal(t, enumLevel(2), dest.Level)
+		assert.Equal(t, enumGreen, dest.Color)
+		require.NotNil(t, dest.Primary)
+		assert.Equal(t, enumBlue, *dest.Primary)
+		assert.Equal(t, []string{"c", "a"}, dest.Tags)
+		assert.Equal(t, Opt[string]{Value: "y", Set: true}, dest.Opt)
+		assert.Equal(t, [2]enumLevel{0, 2}, dest.Levels)
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		var dest enumRequest
+		require.NoError(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/?shade=light&color=crimson", nil), &dest))
+		assert.Equal(t, enumLevel(1), dest.Level)
+		assert.Equal(t, enumRed, dest.Color)
+		assert.Nil(t, dest.Primary)
+		assert.Equal(t, [2]enumLevel{0, 2}, dest.Levels)
+	})
+
+	for name, query := range map[string]string{
+		"Modifier":   "shade=grey",
+		"Registered": "shade=light&color=purple",
+		"Restricted": "shade=light&primary=green",
+		"Element":    "shade=light&tag=a&tag=d",
+		"Index":      "shade=light&level=1",
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := parser.Parse(newTestRequest(t, "GET", "http://example.com/?"+query, nil), &enumRequest{})
+			assert.ErrorIs(t, err, ErrInvalidEnumValue)
+		})
+	}
+
+	t.Run("Message", func(t *testing.T) {
+		err := parser.Parse(newTestRequest(t, "GET", "http://example.com/?shade=grey", nil), &enumRequest{})
+		assert.ErrorContains(t, err, `invalid enum value "grey", allowed values are light, dark`)
+		assert.Equal(t, "Shade", FieldPath(err))
+
+		err = parser.Parse(newTestRequest(t, "GET", "http://example.com/?shade=light&color=purple", nil), &enumRequest{})
+		assert.ErrorContains(t, err, `invalid enum value "purple", allowed values are blue, crimson, green, red`)
+	})
+
+	t.Run("InvalidModifier", func(t *testing.T) {
+		type duplicate struct {
+			Shade string `query:"shade,enum=light|light"`
+		}
+		type empty struct {
+			Shade string `query:"shade,enum=light||dark"`
+		}
+		assert.Error(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/?shade=light", nil), &duplicate{}))
+		assert.Error(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/?shade=light", nil), &empty{}))
+	})
+}
+
+func TestParseEnumModifier(t *testing.T) {
+	names, err := parseEnumModifier("red| green |blue")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"red", "green", "blue"}, names)
+
+	for _, value := range []string{"", "red|", "red|red"} {
+		_, err := parseEnumModifier(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestFormatFieldValue_Enum(t *testing.T) {
+	registerEnumColor(t)
+
+	primary := enumBlue
+	value := enumRequest{
+		Shade:   "dark",
+		Level:   2,
+		Color:   enumRed,
+		Primary: &primary,
+		Levels:  [2]enumLevel{1, 0},
+	}
+
+	query, err := EncodeQuery(value)
+	require.NoError(t, err)
+	assert.Equal(t, "dark", query.Get("shade"))
+	assert.Equal(t, "warn", query.Get("level"))
+	assert.Equal(t, "crimson", query.Get("color"), "first name in sort order")
+	assert.Equal(t, "blue", query.Get("primary"))
+	assert.Equal(t, "info,debug", query.Get("levels"))
+
+	req := newTestRequest(t, "GET", "http://example.com/?"+query.Encode(), nil)
+	var parsed enumRequest
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &parsed))
+	assert.True(t, reflect.DeepEqual(value, parsed))
+
+	_, err = EncodeQuery(enumRequest{Level: 3})
+	assert.ErrorIs(t, err, ErrInvalidEnumValue)
+	_, err = EncodeQuery(enumRequest{Color: 7})
+	assert.ErrorIs(t, err, ErrInvalidEnumValue)
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+var (
+	ErrInvalidEnvBlock = errors.New("invalid environment block")
+)
+
+// EnvBlock is an environment block of "KEY=VALUE" entries, in the form of
+// exec.Cmd.Env and os.Environ.
+type EnvBlock []string
+
+// EnvBlockParserOpts configures the behavior of an EnvBlockParser.
+type EnvBlockParserOpts struct {
+	// CaseInsensitive matches variable names regardless of case, like
+	// Windows does. NewEnvBlockParser sets it on Windows.
+	CaseInsensitive bool
+}
+
+// EnvBlockParser parses an EnvBlock, such as the environment a process
+// launcher is about to give a child process, rather than the environment
+// of the current process.
+//
+// The following Field Bindings are supported:
+//   - env:'<name,[modifiers]>'`: Parses the value of the variable name.
+//
+// Like exec.Cmd, a variable set more than once keeps its last value.
+// Windows entries of the hidden per-drive working directories (e.g.
+// "=C:=C:\work") are ignored. Other entries without "=", or with an
+// empty name, fail to parse with ErrInvalidEnvBlock.
+//
+// A nested struct field tagged with `env:"<prefix>"` scopes the env
+// bindings of its fields, e.g. `env:"HOST"` inside a struct tagged
+// `env:"DB_"` binds to "DB_HOST".
+//
+// The entries of a source are decoded once, on its first parse. Parse a
+// new EnvBlock after changing the entries.
+type EnvBlockParser struct {
+	*BaseMBParser[EnvBlock, ConfigFileOnce]
+}
+
+// NewEnvBlockParser creates an EnvBlockParser matching variable names
+// like the current platform does.
+func NewEnvBlockParser() *EnvBlockParser {
+	return NewEnvBlockParserWithOpts(EnvBlockParserOpts{
+		CaseInsensitive: runtime.GOOS == "windows",
+	})
+}
+
+// NewEnvBlockParserWithOpts creates an EnvBlockParser configured with the
+// given options.
+func NewEnvBlockParserWithOpts(opts EnvBlockParserOpts) *EnvBlockParser {
+	mgr := &configFileBindingManager[EnvBlock]{decode: decodeEnvBlock}
+	if opts.CaseInsensitive {
+		mgr.decode = func(block EnvBlock) (map[string]string, error) {
+			return decodeEnvBlockFolded(block, strings.ToUpper)
+		}
+		mgr.foldKey = strings.ToUpper
+	}
+
+	// Env blocks share the env bindings of dotenv content
+	return &EnvBlockParser{
+		BaseMBParser: NewBaseMBParser(mgr, _dotEnvParserOpts),
+	}
+}
+
+func (ep *EnvBlockParser) Name() string {
+	return EnvBlockParserName
+}
+
+// decodeEnvBlock decodes the entries of an environment block into the
+// values of its variables.
+func decodeEnvBlock(block EnvBlock) (map[string]string, error) {
+	return decodeEnvBlockFolded(block, nil)
+}
+
+// decodeEnvBlockFolded is decodeEnvBlock, keying the values by the names
+// of their variables folded with foldKey, if set.
+func decodeEnvBlockFolded(block EnvBlock, foldKey func(string) string) (map[string]string, error) {
+	values := make(map[string]string, len(block))
+
+	for i, entry := range block {
+		name, value, ok := strings.Cut(entry, "=")
+		if ok && name == "" && strings.Contains(value, "=") {
+			// Hidden per-drive working directory of Windows, e.g. "=C:=C:\work"
+			continue
+		}
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: entry %d: expected KEY=VALUE, got %q", ErrInvalidEnvBlock, i, entry)
+		}
+
+		if foldKey != nil {
+			name = foldKey(name)
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
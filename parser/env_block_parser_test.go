@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvBlockParser(t *testing.T) {
+	type Database struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT,omitempty" default:"5432"`
+	}
+	type ChildEnv struct {
+		Path     string   `env:"PATH"`
+		Mode     string   `env:"MODE"`
+		Args     string   `env:"ARGS"`
+		Database Database `env:"DB_"`
+	}
+
+	block := EnvBlock{
+		"=C:=C:\\work",
+		"PATH=/usr/bin",
+		"MODE=debug",
+		"ARGS=--flag=value",
+		"DB_HOST=db.internal",
+		"MODE=release",
+	}
+
+	t.Run("CaseSensitive", func(t *testing.T) {
+		parser := NewEnvBlockParserWithOpts(EnvBlockParserOpts{})
+
+		var env ChildEnv
+		require.NoError(t, parser.Parse(&block, &env))
+		assert.Equal(t, ChildEnv{
+			Path:     "/usr/bin",
+			Mode:     "release",
+			Args:     "--flag=value",
+			Database: Database{Host: "db.internal", Port: 5432},
+		}, env)
+
+		lower := EnvBlock{"path=/usr/bin", "MODE=debug", "ARGS=", "DB_HOST=db"}
+		err := parser.Parse(&lower, &env)
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+	})
+
+	t.Run("CaseInsensitive", func(t *testing.T) {
+		parser := NewEnvBlockParserWithOpts(EnvBlockParserOpts{CaseInsensitive: true})
+
+		windows := EnvBlock{"Path=C:\\Windows", "mode=debug", "ARGS=", "Db_Host=db", "MODE=release"}
+
+		var env ChildEnv
+		require.NoError(t, parser.Parse(&windows, &env))
+		assert.Equal(t, "C:\\Windows", env.Path)
+		assert.Equal(t, "release", env.Mode)
+		assert.Equal(t, "db", env.Database.Host)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		parser := NewEnvBlockParser()
+
+		for _, block := range []EnvBlock{{"PATH"}, {"=value"}} {
+			var env ChildEnv
+			err := parser.Parse(&block, &env)
+			assert.ErrorIs(t, err, ErrInvalidEnvBlock, block)
+		}
+	})
+}
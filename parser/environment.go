@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+)
+
+// EnvironmentParser is a Parser whose struct tags have environment
+// variants: with an environment set, a tag qualified with its name takes
+// precedence over the unqualified tag, e.g. with "prod"
+//
+//	Debug bool `default:"false" default.dev:"true"`
+//
+// defaults to false, and to true with "dev". Any tag may be qualified,
+// including bindings and the tags of profiles (see RegisterProfile).
+//
+// Parsers built on BaseMBParser are EnvironmentParsers. See
+// ParserRegistryOpts.Environment.
+type EnvironmentParser interface {
+	Parser
+	// SetEnvironment selects the environment variants of tags used by
+	// the parser. An empty env uses unqualified tags only.
+	SetEnvironment(env string)
+}
+
+// SetEnvironment selects the environment variants of tags used to build
+// parse chains, see EnvironmentParser. Cached chains are dropped, as they
+// were built for the previous environment.
+func (cman *PCManager[S]) SetEnvironment(env string) {
+	cman.CMutex.Lock()
+	defer cman.CMutex.Unlock()
+
+	cman.Opts.tagOpts.Environment = env
+	cman.Chains = make(map[reflect.Type]*ParseChain[S])
+	cman.lruEntries = nil
+	cman.cacheBytes = 0
+}
+
+// SetEnvironment selects the environment variants of tags, see
+// EnvironmentParser.
+func (base *BaseMBParser[S, C]) SetEnvironment(env string) {
+	base.PCMgr.SetEnvironment(env)
+	for _, pcm := range base.versioned {
+		pcm.SetEnvironment(env)
+	}
+}
+
+// qualifiedTag returns tag with its variants qualified with qualifier
+// (an environment or version) prepended as unqualified tags, so that
+// lookups find them first.
+func qualifiedTag(tag reflect.StructTag, qualifier string) reflect.StructTag {
+	if qualifier == "" {
+		return tag
+	}
+
+	var variants strings.Builder
+	suffix := "." + qualifier
+	for raw := string(tag); raw != ""; {
+		key, value, rest, ok := nextTagPair(raw)
+		if !ok {
+			break
+		}
+		raw = rest
+
+		if name, qualified := strings.CutSuffix(key, suffix); qualified && name != "" {
+			variants.WriteString(name)
+			variants.WriteString(":")
+			variants.WriteString(value)
+			variants.WriteString(" ")
+		}
+	}
+
+	if variants.Len() == 0 {
+		return tag
+	}
+	return reflect.StructTag(variants.String() + string(tag))
+}
+
+// nextTagPair splits the first key:"value" pair off a struct tag, like
+// reflect.StructTag.Lookup does. value keeps its quotes.
+func nextTagPair(tag string) (key, value, rest string, ok bool) {
+	tag = strings.TrimLeft(tag, " ")
+	if tag == "" {
+		return "", "", "", false
+	}
+
+	// Keys are non-empty and end at a colon, see reflect.StructTag
+	i := 0
+	for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+		i++
+	}
+	if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+		return "", "", "", false
+	}
+	key, tag = tag[:i], tag[i+1:]
+
+	// Values are quoted strings, possibly with escaped quotes
+	i = 1
+	for i < len(tag) && tag[i] != '"' {
+		if tag[i] == '\\' {
+			i++
+		}
+		i++
+	}
+	if i >= len(tag) {
+		return "", "", "", false
+	}
+
+	return key, tag[:i+1], tag[i+1:], true
+}
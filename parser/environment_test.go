@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualifiedTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  reflect.StructTag
+		env  string
+		want reflect.StructTag
+	}{
+		{"no_environment", `default:"1" default.dev:"2"`, "", `default:"1" default.dev:"2"`},
+		{"variant", `default:"1" default.dev:"2"`, "dev", `default:"2" default:"1" default.dev:"2"`},
+		{"other_environment", `default:"1" default.dev:"2"`, "prod", `default:"1" default.dev:"2"`},
+		{"escaped_quotes", `default.dev:"a\"b" query:"q"`, "dev", `default:"a\"b" default.dev:"a\"b" query:"q"`},
+		{"malformed", `default.dev:2`, "dev", `default.dev:2`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, qualifiedTag(tt.tag, tt.env))
+		})
+	}
+}
+
+func TestParserRegistry_Environment(t *testing.T) {
+	type Config struct {
+		Debug    bool   `query:"debug,omitempty" default:"false" default.dev:"true"`
+		Endpoint string `query:"endpoint,omitempty" query.dev:"dev_endpoint,omitempty" default:"api.example.com"`
+		Retries  int    `default:"5" default.dev:"0"`
+	}
+
+	req := newTestRequest(t, "GET", "http://example.com/?endpoint=prod.example.com&dev_endpoint=localhost", nil)
+
+	dev, err := NewParserRegistry(ParserRegistryOpts{Environment: "dev", Parsers: []Parser{NewHTTPRequestParser()}})
+	require.NoError(t, err)
+	assert.Equal(t, "dev", dev.Environment())
+
+	var config Config
+	require.NoError(t, dev.Parse(req, &config, false))
+	assert.Equal(t, Config{Debug: true, Endpoint: "localhost", Retries: 0}, config)
+
+	prod, err := NewParserRegistry(ParserRegistryOpts{Environment: "prod", Parsers: []Parser{NewHTTPRequestParser()}})
+	require.NoError(t, err)
+
+	config = Config{}
+	require.NoError(t, prod.Parse(req, &config, false))
+	assert.Equal(t, Config{Debug: false, Endpoint: "prod.example.com", Retries: 5}, config)
+
+	t.Run("SharedDefaultParsers", func(t *testing.T) {
+		var config Config
+		require.NoError(t, Parse(req, &config, false))
+		assert.Equal(t, "prod.example.com", config.Endpoint, "registries with an environment do not share parsers")
+	})
+
+	t.Run("Invalidate", func(t *testing.T) {
+		dev, err := NewParserRegistry(ParserRegistryOpts{Environment: "dev", Parsers: []Parser{NewHTTPRequestParser()}})
+		require.NoError(t, err)
+
+		config := Config{Endpoint: "x", Retries: 3}
+		require.NoError(t, dev.InvalidateWithOpts(&config, InvalidateOpts{UseDefaults: true}))
+		assert.Equal(t, Config{Debug: true, Endpoint: "api.example.com", Retries: 0}, config)
+	})
+
+	t.Run("Register", func(t *testing.T) {
+		dev, err := NewParserRegistry(ParserRegistryOpts{ExcludeDefaults: true, Environment: "dev"})
+		require.NoError(t, err)
+
+		parser := NewHTTPRequestParser()
+		require.NoError(t, dev.Register(parser))
+
+		var config Config
+		require.NoError(t, parser.Parse(req, &config))
+		assert.True(t, config.Debug, "registered parsers are switched to the environment")
+	})
+}
+
+func TestHTTPRequestParser_Environment(t *testing.T) {
+	var config struct {
+		Region string `query:"region,omitempty" default:"eu" default.staging:"us"`
+	}
+
+	req := newTestRequest(t, "GET", "http://example.com/", nil)
+
+	parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{Environment: "staging"})
+	require.NoError(t, parser.Parse(req, &config))
+	assert.Equal(t, "us", config.Region)
+
+	parser.SetEnvironment("")
+	require.NoError(t, parser.Parse(req, &config))
+	assert.Equal(t, "eu", config.Region, "cached chains are rebuilt for the new environment")
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// RedactedErrorValue replaces redacted values in error messages.
+const RedactedErrorValue = "[REDACTED]"
+
+// ValueError is returned for a field whose bound value failed to
+// transform or convert. Its message is that of Err, which often echoes
+// Value: registries truncate or redact the values echoed in the messages
+// of their errors, see ErrorValueOpts, while errors.As still reaches
+// Value.
+type ValueError struct {
+	Value string // Value bound to the field
+	PII   string // Personal data category of the field, if any
+	Err   error  // Why the value failed
+}
+
+func (e *ValueError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorValueOpts configures how the values bound to fields are echoed in
+// the messages of errors, so that huge bodies or secrets do not end up in
+// logs. The zero value echoes values as they are.
+type ErrorValueOpts struct {
+	// MaxLength truncates values longer than MaxLength bytes to their
+	// first MaxLength bytes, followed by the number of bytes cut, e.g.
+	// "abc…(+1021 bytes)". Zero does not truncate.
+	MaxLength int
+
+	// RedactPII replaces the values of fields holding personal data
+	// (tagged `pii`) with RedactedErrorValue.
+	RedactPII bool
+
+	// Redact replaces every value with RedactedErrorValue.
+	Redact bool
+}
+
+// Sanitize returns err with the values of the ValueErrors it wraps
+// truncated or redacted in its message. The errors err wraps remain
+// available to errors.Is and errors.As, unchanged.
+func (opts ErrorValueOpts) Sanitize(err error) error {
+	if err == nil || opts == (ErrorValueOpts{}) {
+		return err
+	}
+
+	var pairs []string
+	for _, valueErr := range collectValueErrors(err, nil) {
+		formatted := opts.format(valueErr)
+		if valueErr.Value == "" || formatted == valueErr.Value {
+			continue
+		}
+		// Replace the form the error echoes, so that short values do not
+		// replace parts of the words around quoted ones
+		if quoted := strconv.Quote(valueErr.Value); strings.Contains(valueErr.Err.Error(), quoted) {
+			pairs = append(pairs, quoted, strconv.Quote(formatted))
+		} else {
+			pairs = append(pairs, valueErr.Value, formatted)
+		}
+	}
+	if len(pairs) == 0 {
+		return err
+	}
+
+	return &sanitizedError{err: err, replacer: strings.NewReplacer(sortErrorValuePairs(pairs)...)}
+}
+
+// format returns the form of the value of valueErr that error messages
+// echo.
+func (opts ErrorValueOpts) format(valueErr *ValueError) string {
+	if opts.Redact || (opts.RedactPII && valueErr.PII != "") {
+		return RedactedErrorValue
+	}
+
+	value := valueErr.Value
+	if opts.MaxLength <= 0 || len(value) <= opts.MaxLength {
+		return value
+	}
+
+	end := opts.MaxLength
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+	return fmt.Sprintf("%s…(+%d bytes)", value[:end], len(value)-end)
+}
+
+// sortErrorValuePairs sorts the old, new pairs of a strings.Replacer by
+// decreasing length of old, so that values containing others are replaced
+// whole, and the result does not depend on the order of the errors.
+func sortErrorValuePairs(pairs []string) []string {
+	type pair struct{ old, new string }
+
+	sorted := make([]pair, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		sorted = append(sorted, pair{pairs[i], pairs[i+1]})
+	}
+	slices.SortStableFunc(sorted, func(a, b pair) int {
+		return cmp.Or(cmp.Compare(len(b.old), len(a.old)), strings.Compare(a.old, b.old))
+	})
+
+	pairs = pairs[:0]
+	for _, p := range sorted {
+		pairs = append(pairs, p.old, p.new)
+	}
+	return pairs
+}
+
+// collectValueErrors appends the ValueErrors in the tree of err to
+// valueErrs.
+func collectValueErrors(err error, valueErrs []*ValueError) []*ValueError {
+	if valueErr, ok := err.(*ValueError); ok {
+		valueErrs = append(valueErrs, valueErr)
+	}
+
+	switch unwrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		if inner := unwrapper.Unwrap(); inner != nil {
+			valueErrs = collectValueErrors(inner, valueErrs)
+		}
+	case interface{ Unwrap() []error }:
+		for _, inner := range unwrapper.Unwrap() {
+			valueErrs = collectValueErrors(inner, valueErrs)
+		}
+	}
+	return valueErrs
+}
+
+// sanitizedError is an error whose message has its values truncated or
+// redacted, see ErrorValueOpts.Sanitize.
+type sanitizedError struct {
+	err      error
+	replacer *strings.Replacer
+}
+
+func (e *sanitizedError) Error() string {
+	return e.replacer.Replace(e.err.Error())
+}
+
+func (e *sanitizedError) Unwrap() error {
+	return e.err
+}
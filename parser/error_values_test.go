@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errorValuesQuery struct {
+	Limit int    `query:"limit"`
+	Email Color  `query:"email" pii:"email"`
+	Plan  string `query:"plan,omitempty" default:"free"`
+}
+
+func parseErrorValues(t *testing.T, opts ErrorValueOpts, query url.Values) error {
+	t.Helper()
+
+	registry, err := NewParserRegistry(ParserRegistryOpts{ErrorValues: opts, Parsers: []Parser{NewHTTPRequestParser()}})
+	require.NoError(t, err)
+
+	req := newTestRequest(t, "GET", "http://example.com/?"+query.Encode(), nil)
+	var dest errorValuesQuery
+	return registry.Parse(req, &dest, false)
+}
+
+func TestErrorValueOpts(t *testing.T) {
+	huge := strings.Repeat("9x", 600)
+
+	t.Run("Unchanged", func(t *testing.T) {
+		err := parseErrorValues(t, ErrorValueOpts{}, url.Values{"limit": {huge}, "email": {"#fff"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), huge)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		err := parseErrorValues(t, ErrorValueOpts{MaxLength: 8}, url.Values{"limit": {huge}, "email": {"#fff"}})
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), huge)
+		assert.Contains(t, err.Error(), `"9x9x9x9x…(+1192 bytes)"`)
+
+		var valueErr *ValueError
+		require.ErrorAs(t, err, &valueErr)
+		assert.Equal(t, huge, valueErr.Value, "errors keep the value")
+	})
+
+	t.Run("TruncatedRunes", func(t *testing.T) {
+		err := parseErrorValues(t, ErrorValueOpts{MaxLength: 3}, url.Values{"limit": {"1€€€"}, "email": {"#fff"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"1…(+9 bytes)"`)
+	})
+
+	t.Run("RedactPII", func(t *testing.T) {
+		err := parseErrorValues(t, ErrorValueOpts{RedactPII: true}, url.Values{"limit": {"1"}, "email": {"jane@example.com"}})
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "jane@example.com")
+		assert.Contains(t, err.Error(), RedactedErrorValue)
+		assert.ErrorIs(t, err, ErrInvalidColor)
+	})
+
+	t.Run("Redact", func(t *testing.T) {
+		err := parseErrorValues(t, ErrorValueOpts{Redact: true}, url.Values{"limit": {"r"}, "email": {"#fff"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `parsing "[REDACTED]"`)
+		assert.Contains(t, err.Error(), "failed to parse with", "short values only replace the form echoed")
+	})
+}
+
+func TestErrorValueOpts_Sanitize(t *testing.T) {
+	opts := ErrorValueOpts{Redact: true}
+	assert.Nil(t, opts.Sanitize(nil))
+
+	plain := errors.New("no values")
+	assert.Same(t, plain, opts.Sanitize(plain))
+
+	err := errors.Join(
+		&ValueError{Value: "secret", Err: errors.New(`bad value "secret"`)},
+		&ValueError{Value: "secret-token", Err: errors.New("bad value secret-token")},
+	)
+	assert.Equal(t, "bad value \"[REDACTED]\"\nbad value [REDACTED]", opts.Sanitize(err).Error())
+}
@@ -0,0 +1,214 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrInjectedFault   = errors.New("injected fault")
+	ErrInvalidFaultOpt = errors.New("invalid fault injection option")
+)
+
+// FaultOpts configures a FaultInjectingBindingManager. The zero value
+// injects no faults.
+type FaultOpts struct {
+	// ErrorRate is the probability that a binding fails with an error
+	// wrapping ErrInjectedFault, which omiterror recovers from. The errors
+	// are transient, see IsTransient.
+	ErrorRate float64
+	// NilRate is the probability that a binding is found with a nil
+	// value, which omitnil recovers from.
+	NilRate float64
+	// MissingRate is the probability that a binding is not found, which
+	// omitempty recovers from.
+	MissingRate float64
+	// Latency delays every binding. Bindings of sources with a Context
+	// method, such as *http.Request, fail with the error of their context
+	// if it is done first.
+	Latency time.Duration
+	// Jitter adds up to Jitter more, at random, to the Latency of every
+	// binding.
+	Jitter time.Duration
+	// Bindings are the names of the bindings faults are injected into,
+	// e.g. "header". Empty injects faults into all bindings.
+	Bindings []string
+	// Seed seeds the faults drawn, which are the same for the same seed
+	// as long as bindings are handled in the same order. Zero draws them
+	// from a random seed.
+	Seed uint64
+}
+
+// FaultCounts counts the faults a FaultInjectingBindingManager injected.
+type FaultCounts struct {
+	Errors  int64 // Bindings failed with ErrInjectedFault
+	Nils    int64 // Bindings found with a nil value
+	Missing int64 // Bindings not found
+	Delayed int64 // Bindings delayed by Latency or Jitter
+}
+
+// FaultInjectingBindingManager decorates a BindingManager, injecting
+// errors, nil values, missing values and latency into the results of its
+// bindings at random. It tests how an application behaves when parts of
+// its sources fail, e.g. that the omiterror and omitempty fallbacks and
+// the defaults of its structs hold up:
+//
+//	parser := pave.NewHTTPRequestParser()
+//	parser.BMgr, err = pave.NewFaultInjectingBindingManager(parser.BMgr, pave.FaultOpts{ErrorRate: 0.1})
+//
+// The CustomTagHandler of the decorated manager, if any, is used. Its
+// BindingBatchHandler is not, so that faults are injected into every
+// binding.
+type FaultInjectingBindingManager[S, C any] struct {
+	inner BindingManager[S, C]
+	opts  FaultOpts
+
+	mu     sync.Mutex // Guards random
+	random *rand.Rand
+
+	errors  atomic.Int64
+	nils    atomic.Int64
+	missing atomic.Int64
+	delayed atomic.Int64
+}
+
+// NewFaultInjectingBindingManager returns a FaultInjectingBindingManager
+// that decorates inner. The rates of opts must be between 0 and 1, and
+// add up to at most 1.
+func NewFaultInjectingBindingManager[S, C any](
+	inner BindingManager[S, C], opts FaultOpts,
+) (*FaultInjectingBindingManager[S, C], error) {
+
+	rates := []float64{opts.ErrorRate, opts.NilRate, opts.MissingRate}
+	for _, rate := range rates {
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("%w: rate %v is not between 0 and 1", ErrInvalidFaultOpt, rate)
+		}
+	}
+	if sum := opts.ErrorRate + opts.NilRate + opts.MissingRate; sum > 1 {
+		return nil, fmt.Errorf("%w: rates add up to %v, more than 1", ErrInvalidFaultOpt, sum)
+	}
+	if opts.Latency < 0 || opts.Jitter < 0 {
+		return nil, fmt.Errorf("%w: negative latency", ErrInvalidFaultOpt)
+	}
+
+	seed := opts.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+
+	return &FaultInjectingBindingManager[S, C]{
+		inner:  inner,
+		opts:   opts,
+		random: rand.New(rand.NewPCG(seed, seed)),
+	}, nil
+}
+
+func (fm *FaultInjectingBindingManager[S, C]) NewCached() C {
+	return fm.inner.NewCached()
+}
+
+func (fm *FaultInjectingBindingManager[S, C]) BindingHandler(source *S, binding Binding) BindingResult {
+	if result, injected := fm.inject(source, binding); injected {
+		return result
+	}
+	return fm.inner.BindingHandler(source, binding)
+}
+
+func (fm *FaultInjectingBindingManager[S, C]) BindingHandlerCached(
+	source *S, entry *CacheEntry[C], binding Binding,
+) BindingResult {
+
+	if result, injected := fm.inject(source, binding); injected {
+		return result
+	}
+	return fm.inner.BindingHandlerCached(source, entry, binding)
+}
+
+// HandleCustomTags implements CustomTagHandler with the handler of the
+// decorated manager, if it has one.
+func (fm *FaultInjectingBindingManager[S, C]) HandleCustomTags(field *CustomTagField) error {
+	if handler, ok := fm.inner.(CustomTagHandler); ok {
+		return handler.HandleCustomTags(field)
+	}
+	return nil
+}
+
+// Unwrap returns the decorated BindingManager.
+func (fm *FaultInjectingBindingManager[S, C]) Unwrap() BindingManager[S, C] {
+	return fm.inner
+}
+
+// Counts returns the faults injected so far.
+func (fm *FaultInjectingBindingManager[S, C]) Counts() FaultCounts {
+	return FaultCounts{
+		Errors:  fm.errors.Load(),
+		Nils:    fm.nils.Load(),
+		Missing: fm.missing.Load(),
+		Delayed: fm.delayed.Load(),
+	}
+}
+
+// inject delays binding and draws its fault, returning the result of the
+// fault and true, or false if the decorated manager handles binding.
+func (fm *FaultInjectingBindingManager[S, C]) inject(source *S, binding Binding) (BindingResult, bool) {
+	if len(fm.opts.Bindings) > 0 && !slices.Contains(fm.opts.Bindings, binding.Name) {
+		return BindingResult{}, false
+	}
+
+	fm.mu.Lock()
+	draw := fm.random.Float64()
+	delay := fm.opts.Latency
+	if fm.opts.Jitter > 0 {
+		delay += time.Duration(fm.random.Int64N(int64(fm.opts.Jitter) + 1))
+	}
+	fm.mu.Unlock()
+
+	if delay > 0 {
+		fm.delayed.Add(1)
+		if err := sleepFor(source, delay); err != nil {
+			return BindingResultError(err), true
+		}
+	}
+
+	switch {
+	case draw < fm.opts.ErrorRate:
+		fm.errors.Add(1)
+		return BindingResultError(MarkTransient(fmt.Errorf(
+			"%w: %s:%s", ErrInjectedFault, binding.Name, binding.Identifier,
+		))), true
+	case draw < fm.opts.ErrorRate+fm.opts.NilRate:
+		fm.nils.Add(1)
+		return BindingResult{Found: true}, true
+	case draw < fm.opts.ErrorRate+fm.opts.NilRate+fm.opts.MissingRate:
+		fm.missing.Add(1)
+		return BindingResultNotFound(), true
+	}
+	return BindingResult{}, false
+}
+
+// sleepFor sleeps for delay, or until the context of source is done if it
+// has one, returning the error of the context.
+func sleepFor[S any](source *S, delay time.Duration) error {
+	contexter, ok := any(source).(interface{ Context() context.Context })
+	if !ok {
+		time.Sleep(delay)
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-contexter.Context().Done():
+		return contexter.Context().Err()
+	}
+}
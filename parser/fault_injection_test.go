@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type faultRequest struct {
+	Name  string `query:"name,omiterror,omitempty" header:"X-Name,omiterror,omitempty" default:"anonymous"`
+	Page  int    `header:"X-Page,omitnil,omitempty" default:"1"`
+	Token string `query:"token"`
+}
+
+func newFaultParser(t *testing.T, opts FaultOpts) (*HTTPRequestParser, *FaultInjectingBindingManager[http.Request, HTTPRequestOnce]) {
+	t.Helper()
+
+	parser := NewHTTPRequestParser()
+	faults, err := NewFaultInjectingBindingManager(parser.BMgr, opts)
+	require.NoError(t, err)
+	parser.BMgr = faults
+	return parser, faults
+}
+
+func newFaultRequest(t testing.TB) *http.Request {
+	req := newTestRequest(t, "GET", "http://example.com/?name=jane&token=secret", nil)
+	req.Header.Set("X-Name", "janet")
+	req.Header.Set("X-Page", "3")
+	return req
+}
+
+func TestFaultInjectingBindingManager(t *testing.T) {
+	t.Run("NoFaults", func(t *testing.T) {
+		parser, faults := newFaultParser(t, FaultOpts{})
+
+		var req faultRequest
+		require.NoError(t, parser.Parse(newFaultRequest(t), &req))
+		assert.Equal(t, faultRequest{Name: "janet", Page: 3, Token: "secret"}, req)
+		assert.Equal(t, FaultCounts{}, faults.Counts())
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		parser, faults := newFaultParser(t, FaultOpts{ErrorRate: 1, Bindings: []string{"header"}})
+
+		var req faultRequest
+		require.NoError(t, parser.Parse(newFaultRequest(t), &req))
+		assert.Equal(t, "jane", req.Name, "falls back to the query")
+		assert.Equal(t, 1, req.Page)
+		assert.Equal(t, int64(2), faults.Counts().Errors)
+	})
+
+	t.Run("RequiredError", func(t *testing.T) {
+		parser, _ := newFaultParser(t, FaultOpts{ErrorRate: 1})
+
+		var req faultRequest
+		err := parser.Parse(newFaultRequest(t), &req)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInjectedFault)
+		assert.True(t, IsTransient(err))
+		assert.Equal(t, "Token", FieldPath(err))
+	})
+
+	t.Run("Nils", func(t *testing.T) {
+		parser, faults := newFaultParser(t, FaultOpts{NilRate: 1, Bindings: []string{"header"}})
+
+		var req faultRequest
+		require.NoError(t, parser.Parse(newFaultRequest(t), &req))
+		assert.Equal(t, "jane", req.Name)
+		assert.Equal(t, 1, req.Page)
+		assert.Equal(t, int64(2), faults.Counts().Nils)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		parser, faults := newFaultParser(t, FaultOpts{MissingRate: 1, Bindings: []string{"query"}})
+
+		var req faultRequest
+		err := parser.Parse(newFaultRequest(t), &req)
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+		assert.Equal(t, int64(1), faults.Counts().Missing, "the header supplies the name")
+	})
+
+	t.Run("Seed", func(t *testing.T) {
+		parse := func() []string {
+			parser, _ := newFaultParser(t, FaultOpts{ErrorRate: 0.5, Seed: 7, Bindings: []string{"header"}})
+			var names []string
+			for range 20 {
+				var req faultRequest
+				require.NoError(t, parser.Parse(newFaultRequest(t), &req))
+				names = append(names, req.Name)
+			}
+			return names
+		}
+
+		names := parse()
+		assert.Equal(t, names, parse())
+		assert.Contains(t, names, "jane")
+		assert.Contains(t, names, "janet")
+	})
+
+	t.Run("Latency", func(t *testing.T) {
+		parser, faults := newFaultParser(t, FaultOpts{Latency: 5 * time.Millisecond, Jitter: time.Millisecond})
+
+		start := time.Now()
+		var req faultRequest
+		require.NoError(t, parser.Parse(newFaultRequest(t), &req))
+		assert.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+		assert.Equal(t, int64(3), faults.Counts().Delayed)
+	})
+
+	t.Run("LatencyCanceled", func(t *testing.T) {
+		parser, _ := newFaultParser(t, FaultOpts{Latency: time.Hour})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var req faultRequest
+		err := parser.Parse(newFaultRequest(t).WithContext(ctx), &req)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("Verifiers", func(t *testing.T) {
+		parser, _ := newFaultParser(t, FaultOpts{})
+
+		assert.NotPanics(t, func() { parser.UnregisterVerifier("hmac") })
+	})
+}
+
+func TestNewFaultInjectingBindingManager_InvalidOpts(t *testing.T) {
+	tests := map[string]FaultOpts{
+		"negative_rate": {ErrorRate: -0.1},
+		"rate_above_1":  {NilRate: 1.5},
+		"rates_sum":     {ErrorRate: 0.6, MissingRate: 0.6},
+		"negative":      {Latency: -time.Second},
+	}
+
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewFaultInjectingBindingManager(NewHTTPBindingManager(), opts)
+			assert.ErrorIs(t, err, ErrInvalidFaultOpt)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+var (
+	ErrNoFlagProvider       = errors.New("no flag provider registered")
+	ErrFlagEvaluationFailed = errors.New("flag evaluation failed")
+)
+
+// FlagProvider evaluates feature flags for a request, e.g. an adapter of a
+// LaunchDarkly or OpenFeature client deriving the evaluation context (user,
+// tenant) from the request. See HTTPRequestParser.RegisterFlagProvider.
+type FlagProvider interface {
+	// EvaluateFlag returns the value of the flag key for req: a bool,
+	// string, number or encoding.TextMarshaler. A nil value means the
+	// flag is unknown.
+	EvaluateFlag(req *http.Request, key string) (any, error)
+}
+
+// FlagProviderFunc adapts a function to the FlagProvider interface.
+type FlagProviderFunc func(req *http.Request, key string) (any, error)
+
+func (f FlagProviderFunc) EvaluateFlag(req *http.Request, key string) (any, error) {
+	return f(req, key)
+}
+
+// flagEvaluation is the cached result of evaluating a flag for a request.
+type flagEvaluation struct {
+	value any
+	err   error
+}
+
+// RegisterFlagProvider makes provider evaluate the flag bindings of the
+// parser, replacing the provider registered before, if any.
+func (hp *HTTPRequestParser) RegisterFlagProvider(provider FlagProvider) {
+	mgr := hp.bindingManager()
+	mgr.flagProviderMu.Lock()
+	defer mgr.flagProviderMu.Unlock()
+
+	mgr.flagProvider = provider
+}
+
+// UnregisterFlagProvider removes the registered flag provider, if any.
+func (hp *HTTPRequestParser) UnregisterFlagProvider() {
+	hp.RegisterFlagProvider(nil)
+}
+
+// FlagValue returns the value of the feature flag named by binding, as
+// evaluated by the registered FlagProvider. Each flag is evaluated once
+// per request, so that fields bound to the same flag agree.
+func (mgr *HTTPBindingManager) FlagValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], binding Binding,
+) BindingResult {
+
+	mgr.flagProviderMu.RLock()
+	provider := mgr.flagProvider
+	mgr.flagProviderMu.RUnlock()
+	if provider == nil {
+		return BindingResultError(ErrNoFlagProvider)
+	}
+
+	var evaluation flagEvaluation
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		cached, ok := data.flags[binding.Identifier]
+		if !ok {
+			cached.value, cached.err = provider.EvaluateFlag(source, binding.Identifier)
+			if data.flags == nil {
+				data.flags = make(map[string]flagEvaluation)
+			}
+			data.flags[binding.Identifier] = cached
+		}
+		evaluation = cached
+	})
+
+	if evaluation.err != nil {
+		return BindingResultError(fmt.Errorf("%w: %s: %w", ErrFlagEvaluationFailed, binding.Identifier, evaluation.err))
+	}
+	if evaluation.value == nil {
+		return BindingResultNotFound()
+	}
+
+	value, err := formatFieldValue(reflect.ValueOf(evaluation.value))
+	if err != nil {
+		return BindingResultError(fmt.Errorf("%w: %s: %w", ErrFlagEvaluationFailed, binding.Identifier, err))
+	}
+	return BindingResultValue(value)
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagBinding(t *testing.T) {
+	type Checkout struct {
+		NewCheckout bool   `flag:"new-checkout"`
+		Theme       string `flag:"theme,omitempty" default:"light"`
+		MaxItems    int    `flag:"max-items,omiterror" default:"10"`
+	}
+
+	var evaluations int
+	flags := FlagProviderFunc(func(req *http.Request, key string) (any, error) {
+		evaluations++
+		beta := req.Header.Get("X-User") == "beta"
+		switch key {
+		case "new-checkout":
+			return beta, nil
+		case "max-items":
+			if beta {
+				return 50, nil
+			}
+			return nil, errors.New("provider unavailable")
+		}
+		return nil, nil
+	})
+
+	parser := NewHTTPRequestParser()
+	parser.RegisterFlagProvider(flags)
+
+	newRequest := func(t *testing.T, user string) *http.Request {
+		req := newTestRequest(t, "GET", "http://example.com/checkout", nil)
+		req.Header.Set("X-User", user)
+		return req
+	}
+
+	var checkout Checkout
+	require.NoError(t, parser.Parse(newRequest(t, "beta"), &checkout))
+	assert.Equal(t, Checkout{NewCheckout: true, Theme: "light", MaxItems: 50}, checkout)
+	assert.Equal(t, 3, evaluations)
+
+	checkout = Checkout{}
+	require.NoError(t, parser.Parse(newRequest(t, "alice"), &checkout), "omiterror falls back when the provider fails")
+	assert.Equal(t, Checkout{Theme: "light", MaxItems: 10}, checkout)
+
+	t.Run("EvaluatedOncePerRequest", func(t *testing.T) {
+		evaluations = 0
+		var twice struct {
+			Enabled bool   `flag:"new-checkout"`
+			Raw     string `flag:"new-checkout,allowdup"`
+		}
+		require.NoError(t, parser.Parse(newRequest(t, "beta"), &twice))
+		assert.True(t, twice.Enabled)
+		assert.Equal(t, "true", twice.Raw)
+		assert.Equal(t, 1, evaluations)
+	})
+
+	t.Run("EvaluationError", func(t *testing.T) {
+		err := parser.Parse(newRequest(t, "alice"), &struct {
+			MaxItems int `flag:"max-items"`
+		}{})
+		assert.ErrorIs(t, err, ErrFlagEvaluationFailed)
+	})
+
+	t.Run("NoProvider", func(t *testing.T) {
+		parser := NewHTTPRequestParser()
+		err := parser.Parse(newRequest(t, "beta"), &Checkout{})
+		assert.ErrorIs(t, err, ErrNoFlagProvider)
+
+		parser.RegisterFlagProvider(flags)
+		parser.UnregisterFlagProvider()
+		err = parser.Parse(newRequest(t, "beta"), &Checkout{})
+		assert.ErrorIs(t, err, ErrNoFlagProvider)
+	})
+}
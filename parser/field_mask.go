@@ -0,0 +1,205 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrUnknownMaskField = errors.New("unknown field in field mask")
+)
+
+// FieldMask is a sparse fieldset, e.g. the fields=name,email,address.city
+// query parameter of a partial response. Its fields are the JSON names of
+// the fields of T, dotted for nested structs, and are checked against T
+// when the mask is parsed:
+//
+//	type GetUser struct {
+//		ID     string                   `query:"id"`
+//		Fields pave.FieldMask[UserView] `query:"fields,omitempty" default:""`
+//	}
+//
+// An empty mask selects every field.
+type FieldMask[T any] []string
+
+// ParseFieldMask parses a comma separated list of the JSON field names of
+// T. Unknown names fail with ErrUnknownMaskField.
+func ParseFieldMask[T any](value string) (FieldMask[T], error) {
+	var mask FieldMask[T]
+	for _, name := range strings.Split(value, CommaDelimeter) {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+
+		if !hasJSONFieldPath(reflect.TypeFor[T](), strings.Split(name, ".")) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownMaskField, name)
+		}
+		if !slices.Contains(mask, name) {
+			mask = append(mask, name)
+		}
+	}
+	return mask, nil
+}
+
+// UnmarshalText parses the mask with ParseFieldMask.
+func (mask *FieldMask[T]) UnmarshalText(text []byte) error {
+	parsed, err := ParseFieldMask[T](string(text))
+	if err != nil {
+		return err
+	}
+	*mask = parsed
+	return nil
+}
+
+// MarshalText formats the mask as a comma separated list.
+func (mask FieldMask[T]) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(mask, CommaDelimeter)), nil
+}
+
+// Has reports whether the mask selects the field at the dotted JSON path
+// name: either itself, one of its parents, or one of its children are
+// listed. Every field is selected by an empty mask.
+func (mask FieldMask[T]) Has(name string) bool {
+	if len(mask) == 0 {
+		return true
+	}
+	for _, field := range mask {
+		if field == name || strings.HasPrefix(name, field+".") || strings.HasPrefix(field, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns the JSON encoding of v restricted to the fields of the
+// mask. Nested objects, and the objects of arrays, are restricted to the
+// children of their field that the mask lists, if any.
+func (mask FieldMask[T]) Apply(v T) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil || len(mask) == 0 {
+		return raw, err
+	}
+
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	paths := make([][]string, len(mask))
+	for i, field := range mask {
+		paths[i] = strings.Split(field, ".")
+	}
+	return json.Marshal(pruneJSONValue(doc, paths))
+}
+
+// pruneJSONValue keeps the members of the objects in doc that paths
+// select. A path that ends at a member keeps it whole.
+func pruneJSONValue(doc any, paths [][]string) any {
+	switch value := doc.(type) {
+	case map[string]any:
+		children := make(map[string][][]string)
+		whole := make(map[string]bool)
+		for _, path := range paths {
+			if len(path) == 1 {
+				whole[path[0]] = true
+			} else {
+				children[path[0]] = append(children[path[0]], path[1:])
+			}
+		}
+
+		pruned := make(map[string]any)
+		for name, member := range value {
+			switch {
+			case whole[name]:
+				pruned[name] = member
+			case children[name] != nil:
+				pruned[name] = pruneJSONValue(member, children[name])
+			}
+		}
+		return pruned
+
+	case []any:
+		pruned := make([]any, len(value))
+		for i, element := range value {
+			pruned[i] = pruneJSONValue(element, paths)
+		}
+		return pruned
+
+	default:
+		return doc
+	}
+}
+
+// _jsonFieldTypes caches the JSON field names of struct types, see
+// jsonFieldTypes.
+var _jsonFieldTypes sync.Map // reflect.Type -> map[string]reflect.Type
+
+// hasJSONFieldPath reports whether path is a path of JSON field names
+// through typ, following pointers, slices, arrays and maps of structs.
+func hasJSONFieldPath(typ reflect.Type, path []string) bool {
+	for _, name := range path {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return false
+		}
+
+		fieldType, ok := jsonFieldTypes(typ)[name]
+		if !ok {
+			return false
+		}
+		typ = fieldType
+	}
+	return true
+}
+
+// jsonFieldTypes returns the types of the fields of the struct type typ
+// keyed by the names encoding/json gives them, including the fields of
+// embedded structs.
+func jsonFieldTypes(typ reflect.Type) map[string]reflect.Type {
+	if cached, ok := _jsonFieldTypes.Load(typ); ok {
+		return cached.(map[string]reflect.Type)
+	}
+
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), CommaDelimeter)
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range jsonFieldTypes(embedded) {
+					if _, ok := fields[embeddedName]; !ok {
+						fields[embeddedName] = embeddedType
+					}
+				}
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+
+	_jsonFieldTypes.Store(typ, fields)
+	return fields
+}
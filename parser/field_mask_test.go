@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type maskAddress struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type maskAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type maskUser struct {
+	maskAudit
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Email    string        `json:"email,omitempty"`
+	Password string        `json:"-"`
+	Address  *maskAddress  `json:"address"`
+	Previous []maskAddress `json:"previous"`
+	Nickname string
+	internal string
+}
+
+func TestParseFieldMask(t *testing.T) {
+	mask, err := ParseFieldMask[maskUser](" name, email,address.city,previous.country, created_by,Nickname,name")
+	require.NoError(t, err)
+	assert.Equal(t, FieldMask[maskUser]{"name", "email", "address.city", "previous.country", "created_by", "Nickname"}, mask)
+
+	mask, err = ParseFieldMask[maskUser]("")
+	require.NoError(t, err)
+	assert.Empty(t, mask)
+
+	for _, value := range []string{"Password", "password", "internal", "Name", "address.zip", "name.first", "address."} {
+		_, err := ParseFieldMask[maskUser](value)
+		assert.ErrorIs(t, err, ErrUnknownMaskField, value)
+	}
+}
+
+func TestFieldMask_Has(t *testing.T) {
+	mask := FieldMask[maskUser]{"name", "address.city"}
+
+	assert.True(t, mask.Has("name"))
+	assert.True(t, mask.Has("address"), "parents of listed fields are selected")
+	assert.True(t, mask.Has("address.city"))
+	assert.False(t, mask.Has("address.country"))
+	assert.False(t, mask.Has("email"))
+	assert.True(t, FieldMask[maskUser]{"address"}.Has("address.country"), "children of listed fields are selected")
+	assert.True(t, FieldMask[maskUser]{}.Has("email"))
+}
+
+func TestFieldMask_Apply(t *testing.T) {
+	user := maskUser{
+		maskAudit: maskAudit{CreatedBy: "admin"},
+		ID:        "7",
+		Name:      "Ada",
+		Email:     "ada@example.com",
+		Address:   &maskAddress{City: "London", Country: "UK"},
+		Previous:  []maskAddress{{City: "Paris", Country: "FR"}, {City: "Rome", Country: "IT"}},
+	}
+
+	partial, err := FieldMask[maskUser]{"name", "address.city", "previous.country", "created_by"}.Apply(user)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "Ada",
+		"created_by": "admin",
+		"address": {"city": "London"},
+		"previous": [{"country": "FR"}, {"country": "IT"}]
+	}`, string(partial))
+
+	full, err := FieldMask[maskUser]{}.Apply(user)
+	require.NoError(t, err)
+	assert.Contains(t, string(full), `"email":"ada@example.com"`)
+
+	partial, err = FieldMask[maskUser]{"address.city"}.Apply(maskUser{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"address": null}`, string(partial))
+}
+
+func TestHTTPRequestParser_FieldMask(t *testing.T) {
+	type GetUser struct {
+		ID     string              `query:"id"`
+		Fields FieldMask[maskUser] `query:"fields,omitempty" default:""`
+	}
+
+	parser := NewHTTPRequestParser()
+
+	req := newTestRequest(t, "GET", "http://example.com/user?id=7&fields=name,address.city", nil)
+	var get GetUser
+	require.NoError(t, parser.Parse(req, &get))
+	assert.Equal(t, GetUser{ID: "7", Fields: FieldMask[maskUser]{"name", "address.city"}}, get)
+
+	req = newTestRequest(t, "GET", "http://example.com/user?id=7", nil)
+	get = GetUser{}
+	require.NoError(t, parser.Parse(req, &get))
+	assert.Empty(t, get.Fields)
+
+	req = newTestRequest(t, "GET", "http://example.com/user?id=7&fields=name,password", nil)
+	err := parser.Parse(req, &GetUser{})
+	assert.ErrorIs(t, err, ErrUnknownMaskField)
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		want := GetUser{ID: "7", Fields: FieldMask[maskUser]{"id", "previous.city"}}
+		req, err := NewHTTPRequest("GET", "http://example.com/user", want)
+		require.NoError(t, err)
+
+		var got GetUser
+		require.NoError(t, parser.Parse(req, &got))
+		assert.Equal(t, want, got)
+	})
+}
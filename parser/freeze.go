@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Frozen is an immutable view of a parsed (and usually validated) value
+// of type T. It holds a deep copy of the value and hands out deep copies
+// of it, so that code mutating what it got cannot bypass validation for
+// anyone else, e.g. when request structs are passed around as value
+// objects.
+//
+// Slices, maps, pointers and interfaces are copied recursively, aliasing
+// within the value is preserved. Unexported fields, functions, channels
+// and values of types converted through pointers (e.g. *time.Location)
+// are shared, as they cannot be copied safely. The zero Frozen holds the
+// zero value of T.
+type Frozen[T any] struct {
+	value T
+}
+
+// Freeze returns an immutable view of a deep copy of value.
+func Freeze[T any](value T) Frozen[T] {
+	return Frozen[T]{value: deepCopy(value)}
+}
+
+// ParseFrozen parses source into a new T with the default parser
+// registry (see Parse), validates it and freezes the result. Freeze the
+// result of parsing with another registry or parser instead.
+func ParseFrozen[T any](source any) (Frozen[T], error) {
+	var value T
+	if err := Parse(source, &value, true); err != nil {
+		return Frozen[T]{}, err
+	}
+	return Frozen[T]{value: value}, nil
+}
+
+// Get returns a deep copy of the frozen value.
+func (f Frozen[T]) Get() T {
+	return deepCopy(f.value)
+}
+
+// MarshalJSON encodes the frozen value.
+func (f Frozen[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.value)
+}
+
+// deepCopy returns a deep copy of value, see Frozen.
+func deepCopy[T any](value T) T {
+	var copied T
+	src := reflect.ValueOf(&value).Elem()
+	dst := reflect.ValueOf(&copied).Elem()
+	copyValue(dst, src, make(map[copiedPointer]reflect.Value))
+	return copied
+}
+
+// copiedPointer identifies a pointer copied by copyValue. Pointers to a
+// struct and to its first field share their address, but not their type.
+type copiedPointer struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// copyValue deep copies src into dst, which must be settable. copies are
+// the copies of the pointers copied so far.
+func copyValue(dst, src reflect.Value, copies map[copiedPointer]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		if _, shared := getTypeConverter(src.Type()); shared {
+			dst.Set(src)
+			return
+		}
+		ptr := copiedPointer{addr: src.Pointer(), typ: src.Type()}
+		if copied, ok := copies[ptr]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.New(src.Type().Elem())
+		copies[ptr] = copied
+		copyValue(copied.Elem(), src.Elem(), copies)
+		dst.Set(copied)
+
+	case reflect.Struct:
+		// Copies unexported fields, exported fields are then deep copied
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if src.Type().Field(i).IsExported() {
+				copyValue(dst.Field(i), src.Field(i), copies)
+			}
+		}
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		copied := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			copyValue(copied.Index(i), src.Index(i), copies)
+		}
+		dst.Set(copied)
+
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i), copies)
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		copied := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(src.Type().Key()).Elem()
+			copyValue(key, iter.Key(), copies)
+			elem := reflect.New(src.Type().Elem()).Elem()
+			copyValue(elem, iter.Value(), copies)
+			copied.SetMapIndex(key, elem)
+		}
+		dst.Set(copied)
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		copyValue(elem, src.Elem(), copies)
+		dst.Set(elem)
+
+	default:
+		dst.Set(src)
+	}
+}
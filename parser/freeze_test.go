@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeze(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type Order struct {
+		ID       string
+		Items    []string
+		Labels   map[string][]int
+		Shipping *Address
+		Billing  *Address
+		Extra    any
+		Placed   time.Time
+		Zone     *time.Location
+		note     *string
+	}
+
+	note := "fragile"
+	shipping := &Address{City: "Oslo"}
+	order := Order{
+		ID:       "o-1",
+		Items:    []string{"book"},
+		Labels:   map[string][]int{"priority": {1}},
+		Shipping: shipping,
+		Billing:  shipping,
+		Extra:    []string{"gift"},
+		Placed:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Zone:     time.UTC,
+		note:     &note,
+	}
+
+	frozen := Freeze(order)
+
+	order.Items[0] = "pen"
+	order.Labels["priority"][0] = 9
+	order.Shipping.City = "Bergen"
+	order.Extra.([]string)[0] = "wrap"
+
+	got := frozen.Get()
+	assert.Equal(t, []string{"book"}, got.Items, "frozen values are copied")
+	assert.Equal(t, []int{1}, got.Labels["priority"])
+	assert.Equal(t, "Oslo", got.Shipping.City)
+	assert.Same(t, got.Shipping, got.Billing, "aliasing is preserved")
+	assert.Equal(t, []string{"gift"}, got.Extra)
+	assert.Equal(t, order.Placed, got.Placed)
+	assert.Same(t, time.UTC, got.Zone, "pointer converted types are shared")
+	assert.Same(t, &note, got.note, "unexported fields are shared")
+
+	got.Items[0] = "pen"
+	got.Shipping.City = "Bergen"
+	again := frozen.Get()
+	assert.Equal(t, []string{"book"}, again.Items, "values handed out are copies")
+	assert.Equal(t, "Oslo", again.Shipping.City)
+
+	encoded, err := json.Marshal(Freeze(Address{City: "Oslo"}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"City":"Oslo"}`, string(encoded))
+
+	t.Run("ParseFrozen", func(t *testing.T) {
+		type Request struct {
+			Tenant string `query:"tenant"`
+		}
+
+		req := newTestRequest(t, "GET", "http://example.com/?tenant=acme", nil)
+		frozen, err := ParseFrozen[Request](req)
+		require.NoError(t, err)
+		assert.Equal(t, Request{Tenant: "acme"}, frozen.Get())
+	})
+}
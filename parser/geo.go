@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidLatitude    = errors.New("latitude must be between -90 and 90")
+	ErrInvalidLongitude   = errors.New("longitude must be between -180 and 180")
+	ErrInvalidPoint       = errors.New("invalid point")
+	ErrInvalidBoundingBox = errors.New("invalid bounding box")
+	ErrOutsideBoundingBox = errors.New("point is outside of the bounding box")
+	ErrInvalidCoordinate  = errors.New("invalid coordinate")
+)
+
+// Point is a WGS 84 coordinate in decimal degrees. Point fields are
+// populated from "lat,lon" values, e.g. "52.5200,13.4050".
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// ParsePoint parses a "lat,lon" value into a Point, checking that both
+// coordinates are in range.
+func ParsePoint(value string) (Point, error) {
+	coords, err := parseCoordinates(value, 2)
+	if err != nil {
+		return Point{}, fmt.Errorf("%w: %q: %w", ErrInvalidPoint, value, err)
+	}
+
+	point := Point{Lat: coords[0], Lon: coords[1]}
+	if err := point.check(); err != nil {
+		return Point{}, fmt.Errorf("%w: %q: %w", ErrInvalidPoint, value, err)
+	}
+	return point, nil
+}
+
+// String formats p as "lat,lon".
+func (p Point) String() string {
+	return formatCoordinates(p.Lat, p.Lon)
+}
+
+// MarshalText implements encoding.TextMarshaler using Point.String.
+func (p Point) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParsePoint.
+func (p *Point) UnmarshalText(text []byte) error {
+	point, err := ParsePoint(string(text))
+	if err != nil {
+		return err
+	}
+	*p = point
+	return nil
+}
+
+// check returns an error if a coordinate of p is out of range.
+func (p Point) check() error {
+	if err := CheckLatitude(p.Lat); err != nil {
+		return err
+	}
+	return CheckLongitude(p.Lon)
+}
+
+// BoundingBox is the area between two corners in decimal degrees.
+// BoundingBox fields are populated from "minLat,minLon,maxLat,maxLon"
+// values. A box with MinLon greater than MaxLon crosses the antimeridian.
+type BoundingBox struct {
+	MinLat, MinLon float64 // South-west corner
+	MaxLat, MaxLon float64 // North-east corner
+}
+
+// ParseBoundingBox parses a "minLat,minLon,maxLat,maxLon" value.
+func ParseBoundingBox(value string) (BoundingBox, error) {
+	coords, err := parseCoordinates(value, 4)
+	if err != nil {
+		return BoundingBox{}, fmt.Errorf("%w: %q: %w", ErrInvalidBoundingBox, value, err)
+	}
+
+	box := BoundingBox{MinLat: coords[0], MinLon: coords[1], MaxLat: coords[2], MaxLon: coords[3]}
+	for _, corner := range []Point{{box.MinLat, box.MinLon}, {box.MaxLat, box.MaxLon}} {
+		if err := corner.check(); err != nil {
+			return BoundingBox{}, fmt.Errorf("%w: %q: %w", ErrInvalidBoundingBox, value, err)
+		}
+	}
+	if box.MinLat > box.MaxLat {
+		return BoundingBox{}, fmt.Errorf("%w: %q: minimum latitude is above the maximum", ErrInvalidBoundingBox, value)
+	}
+	return box, nil
+}
+
+// Contains reports whether p lies within b, edges included.
+func (b BoundingBox) Contains(p Point) bool {
+	if p.Lat < b.MinLat || p.Lat > b.MaxLat {
+		return false
+	}
+	if b.MinLon > b.MaxLon {
+		return p.Lon >= b.MinLon || p.Lon <= b.MaxLon
+	}
+	return p.Lon >= b.MinLon && p.Lon <= b.MaxLon
+}
+
+// String formats b as "minLat,minLon,maxLat,maxLon".
+func (b BoundingBox) String() string {
+	return formatCoordinates(b.MinLat, b.MinLon, b.MaxLat, b.MaxLon)
+}
+
+// MarshalText implements encoding.TextMarshaler using BoundingBox.String.
+func (b BoundingBox) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseBoundingBox.
+func (b *BoundingBox) UnmarshalText(text []byte) error {
+	box, err := ParseBoundingBox(string(text))
+	if err != nil {
+		return err
+	}
+	*b = box
+	return nil
+}
+
+// convertPoint is the TypeConverter for Point fields.
+func convertPoint(value string) (any, error) {
+	return ParsePoint(value)
+}
+
+// convertBoundingBox is the TypeConverter for BoundingBox fields.
+func convertBoundingBox(value string) (any, error) {
+	return ParseBoundingBox(value)
+}
+
+// parseCoordinates parses exactly n comma separated numbers.
+func parseCoordinates(value string, n int) ([]float64, error) {
+	parts := strings.Split(value, CommaDelimeter)
+	if len(parts) != n {
+		return nil, fmt.Errorf("expected %d comma separated numbers", n)
+	}
+
+	coords := make([]float64, n)
+	for i, part := range parts {
+		coord, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, err
+		}
+		coords[i] = coord
+	}
+	return coords, nil
+}
+
+// formatCoordinates formats coords as comma separated numbers.
+func formatCoordinates(coords ...float64) string {
+	parts := make([]string, len(coords))
+	for i, coord := range coords {
+		parts[i] = strconv.FormatFloat(coord, 'f', -1, 64)
+	}
+	return strings.Join(parts, CommaDelimeter)
+}
+
+// CheckLatitude fails with ErrInvalidLatitude for latitudes outside
+// [-90, 90].
+func CheckLatitude(lat float64) error {
+	if !(lat >= -90 && lat <= 90) {
+		return fmt.Errorf("%w, got %g", ErrInvalidLatitude, lat)
+	}
+	return nil
+}
+
+// CheckLongitude fails with ErrInvalidLongitude for longitudes outside
+// [-180, 180].
+func CheckLongitude(lon float64) error {
+	if !(lon >= -180 && lon <= 180) {
+		return fmt.Errorf("%w, got %g", ErrInvalidLongitude, lon)
+	}
+	return nil
+}
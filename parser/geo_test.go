@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePoint(t *testing.T) {
+	point, err := ParsePoint("52.52, 13.405")
+	require.NoError(t, err)
+	assert.Equal(t, Point{Lat: 52.52, Lon: 13.405}, point)
+	assert.Equal(t, "52.52,13.405", point.String())
+
+	for _, value := range []string{"", "52.52", "52.52,13.405,1", "north,east", "91,0", "0,-181", "NaN,0"} {
+		_, err := ParsePoint(value)
+		assert.ErrorIs(t, err, ErrInvalidPoint, value)
+	}
+
+	_, err = ParsePoint("91,0")
+	assert.ErrorIs(t, err, ErrInvalidLatitude)
+}
+
+func TestParseBoundingBox(t *testing.T) {
+	box, err := ParseBoundingBox("47.2,5.8,55.1,15.1")
+	require.NoError(t, err)
+	assert.Equal(t, BoundingBox{MinLat: 47.2, MinLon: 5.8, MaxLat: 55.1, MaxLon: 15.1}, box)
+	assert.True(t, box.Contains(Point{52.52, 13.405}))
+	assert.True(t, box.Contains(Point{47.2, 5.8}), "edges are included")
+	assert.False(t, box.Contains(Point{48.85, 2.35}))
+
+	_, err = ParseBoundingBox("55.1,5.8,47.2,15.1")
+	assert.ErrorIs(t, err, ErrInvalidBoundingBox)
+	_, err = ParseBoundingBox("47.2,5.8,55.1")
+	assert.ErrorIs(t, err, ErrInvalidBoundingBox)
+
+	t.Run("antimeridian", func(t *testing.T) {
+		fiji, err := ParseBoundingBox("-21,177,-12,-178")
+		require.NoError(t, err)
+		assert.True(t, fiji.Contains(Point{-17.7, 178.1}))
+		assert.True(t, fiji.Contains(Point{-17.7, -179.5}))
+		assert.False(t, fiji.Contains(Point{-17.7, 0}))
+	})
+}
+
+func TestHTTPRequestParser_Point(t *testing.T) {
+	type request struct {
+		Near Point        `query:"near"`
+		Area *BoundingBox `query:"bbox,omitempty"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/?near=52.52,13.405&bbox=47.2,5.8,55.1,15.1", nil)
+	require.NoError(t, err)
+
+	var dest request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &dest))
+	assert.Equal(t, Point{52.52, 13.405}, dest.Near)
+	require.NotNil(t, dest.Area)
+	assert.True(t, dest.Area.Contains(dest.Near))
+
+	req, err = http.NewRequest(http.MethodGet, "/?near=152.52,13.405", nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, NewHTTPRequestParser().Parse(req, &request{}), ErrInvalidLatitude)
+}
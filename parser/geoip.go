@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNoIPEnricher    = errors.New("no ip enricher configured")
+	ErrInvalidClientIP = errors.New("invalid client ip")
+)
+
+// Identifiers of geoip bindings
+const (
+	GeoIPCountry      string = "country" // ISO 3166-1 alpha-2 country code
+	GeoIPASN          string = "asn"     // Autonomous system number
+	GeoIPOrganization string = "org"     // Organization of the autonomous system
+)
+
+// IPInfo is what an IPEnricher knows about an IP address. Unknown
+// attributes are zero.
+type IPInfo struct {
+	Country      string // ISO 3166-1 alpha-2 country code, e.g. "DE"
+	ASN          uint32 // Autonomous system number, e.g. 3320
+	Organization string // Organization of the autonomous system
+}
+
+// IPEnricher looks up IP addresses in a geo-IP database, e.g. a MaxMind
+// GeoLite2 reader. Set it with HTTPRequestParserOpts.IPEnricher to enable
+// geoip bindings.
+type IPEnricher interface {
+	Enrich(ip netip.Addr) (IPInfo, error)
+}
+
+// IPEnricherFunc adapts a function to the IPEnricher interface.
+type IPEnricherFunc func(ip netip.Addr) (IPInfo, error)
+
+func (f IPEnricherFunc) Enrich(ip netip.Addr) (IPInfo, error) {
+	return f(ip)
+}
+
+// ClientIP returns the IP address of the client of a request. It is the
+// address of the peer (http.Request.RemoteAddr), unless the peer is one of
+// the trusted proxies: then X-Forwarded-For is walked from the right, the
+// address appended by the closest proxy, and the first address that is
+// not a trusted proxy is the client.
+func ClientIP(req *http.Request, trustedProxies []netip.Prefix) (netip.Addr, error) {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	client, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("%w: remote address %q", ErrInvalidClientIP, req.RemoteAddr)
+	}
+	client = client.Unmap()
+
+	var forwarded []string
+	for _, value := range req.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(value, CommaDelimeter)...)
+	}
+
+	for i := len(forwarded) - 1; i >= 0 && isTrustedProxy(client, trustedProxies); i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(forwarded[i]))
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("%w: X-Forwarded-For %q", ErrInvalidClientIP, forwarded[i])
+		}
+		client = addr.Unmap()
+	}
+	return client, nil
+}
+
+// isTrustedProxy reports whether addr is in one of the trusted prefixes.
+func isTrustedProxy(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// GeoIPValue returns the attribute of the client IP named by the binding
+// identifier, as found by the IPEnricher of the parser. The client IP is
+// enriched once per request.
+func (mgr *HTTPBindingManager) GeoIPValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], binding Binding,
+) BindingResult {
+
+	if mgr.opts.IPEnricher == nil {
+		return BindingResultError(ErrNoIPEnricher)
+	}
+
+	var (
+		info IPInfo
+		err  error
+	)
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		data.ipInfoOnce.Do(func() {
+			var ip netip.Addr
+			if ip, data.ipInfoError = ClientIP(source, mgr.opts.TrustedProxies); data.ipInfoError != nil {
+				return
+			}
+			if data.ipInfo, data.ipInfoError = mgr.opts.IPEnricher.Enrich(ip); data.ipInfoError != nil {
+				data.ipInfoError = fmt.Errorf("enriching %s: %w", ip, data.ipInfoError)
+			}
+		})
+		info, err = data.ipInfo, data.ipInfoError
+	})
+	if err != nil {
+		return BindingResultError(err)
+	}
+
+	var value string
+	switch binding.Identifier {
+	case GeoIPCountry:
+		value = info.Country
+	case GeoIPASN:
+		if info.ASN != 0 {
+			value = strconv.FormatUint(uint64(info.ASN), 10)
+		}
+	case GeoIPOrganization:
+		value = info.Organization
+	default:
+		return BindingResultError(fmt.Errorf("%w: unknown geoip attribute %q", ErrUnallowedBindingName, binding.Identifier))
+	}
+
+	if value == "" {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(value)
+}
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoIPBinding(t *testing.T) {
+	type Visitor struct {
+		Country string `geoip:"country"`
+		ASN     uint32 `geoip:"asn"`
+		Org     string `geoip:"org,omitempty" default:"unknown"`
+	}
+
+	var lookups int
+	database := map[netip.Addr]IPInfo{
+		netip.MustParseAddr("203.0.113.7"): {Country: "DE", ASN: 3320, Organization: "Deutsche Telekom AG"},
+		netip.MustParseAddr("2001:db8::1"): {Country: "FR", ASN: 3215},
+	}
+	enricher := IPEnricherFunc(func(ip netip.Addr) (IPInfo, error) {
+		lookups++
+		info, ok := database[ip]
+		if !ok {
+			return IPInfo{}, errors.New("database unavailable")
+		}
+		return info, nil
+	})
+
+	parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{
+		IPEnricher:     enricher,
+		TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+	})
+
+	newRequest := func(t *testing.T, remoteAddr string, forwardedFor ...string) *http.Request {
+		req := newTestRequest(t, "GET", "http://example.com/", nil)
+		req.RemoteAddr = remoteAddr
+		for _, value := range forwardedFor {
+			req.Header.Add("X-Forwarded-For", value)
+		}
+		return req
+	}
+
+	var visitor Visitor
+	require.NoError(t, parser.Parse(newRequest(t, "203.0.113.7:52100"), &visitor))
+	assert.Equal(t, Visitor{Country: "DE", ASN: 3320, Org: "Deutsche Telekom AG"}, visitor)
+	assert.Equal(t, 1, lookups, "the client IP is enriched once per request")
+
+	t.Run("TrustedProxies", func(t *testing.T) {
+		var visitor Visitor
+		req := newRequest(t, "10.0.0.2:443", "198.51.100.1, 203.0.113.7", "10.0.0.1")
+		require.NoError(t, parser.Parse(req, &visitor))
+		assert.Equal(t, "DE", visitor.Country)
+
+		visitor = Visitor{}
+		req = newRequest(t, "[2001:db8::1]:443", "203.0.113.7")
+		require.NoError(t, parser.Parse(req, &visitor), "untrusted peers cannot forward")
+		assert.Equal(t, Visitor{Country: "FR", ASN: 3215, Org: "unknown"}, visitor)
+	})
+
+	t.Run("ClientIP", func(t *testing.T) {
+		ip, err := ClientIP(newRequest(t, "10.0.0.2:443", "10.1.1.1"), []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+		require.NoError(t, err)
+		assert.Equal(t, netip.MustParseAddr("10.1.1.1"), ip, "a chain of trusted proxies yields its leftmost address")
+
+		_, err = ClientIP(newRequest(t, "10.0.0.2:443", "not-an-ip"), []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")})
+		assert.ErrorIs(t, err, ErrInvalidClientIP)
+
+		_, err = ClientIP(newRequest(t, "pipe"), nil)
+		assert.ErrorIs(t, err, ErrInvalidClientIP)
+	})
+
+	t.Run("EnricherError", func(t *testing.T) {
+		err := parser.Parse(newRequest(t, "192.0.2.1:80"), &Visitor{})
+		assert.ErrorContains(t, err, "database unavailable")
+
+		var region struct {
+			Country string `geoip:"country,omiterror" default:"ZZ"`
+		}
+		require.NoError(t, parser.Parse(newRequest(t, "192.0.2.1:80"), &region))
+		assert.Equal(t, "ZZ", region.Country)
+	})
+
+	t.Run("NoEnricher", func(t *testing.T) {
+		err := NewHTTPRequestParser().Parse(newRequest(t, "203.0.113.7:52100"), &Visitor{})
+		assert.ErrorIs(t, err, ErrNoIPEnricher)
+	})
+
+	t.Run("UnknownAttribute", func(t *testing.T) {
+		err := parser.Parse(newRequest(t, "203.0.113.7:52100"), &struct {
+			City string `geoip:"city"`
+		}{})
+		assert.ErrorIs(t, err, ErrUnallowedBindingName)
+	})
+}
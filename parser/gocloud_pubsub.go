@@ -0,0 +1,138 @@
+//go:build pave_gocloud
+
+package parser
+
+import (
+	"fmt"
+
+	"gocloud.dev/pubsub"
+)
+
+// Support for the messages of gocloud.dev/pubsub, a vendor-neutral entry
+// point for parsing the messages of GCP Pub/Sub, AWS SNS/SQS, Azure
+// Service Bus, Kafka, NATS or RabbitMQ subscriptions.
+
+var (
+	// Default PubSubMessageParser Binding Options
+	_pubsubTagOpts = ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				JsonTagBinding,
+				MetadataTagBinding,
+				MessageTagBinding,
+			},
+			CustomBindingModifiers: []string{
+				LiteralBindingModifier,
+			},
+			ValueModifiers: map[string]ModifierValueParser{
+				CurrencyBindingModifier: parseCurrencyModifier,
+			},
+		},
+		AllowedTagOptionals: []string{},
+	}
+
+	// Default PubSubMessageParser Options
+	_pubsubParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts: PCManagerOpts{
+			tagOpts: _pubsubTagOpts,
+		},
+	}
+)
+
+// Fields of a pubsub.Message bound by the message binding
+const (
+	PubSubMessageBodyField string = "body"
+	PubSubMessageIDField   string = "id"
+)
+
+// PubSubMessageParser parses a *pubsub.Message received from a
+// gocloud.dev subscription:
+//   - json:'<key,[modifiers]>'`: Parses a JSON key from the message body
+//   - metadata:'<key,[modifiers]>'`: Parses a metadata value by key
+//   - message:'<field,[modifiers]>'`: Parses the "body" of the message
+//     as is, e.g. for text or binary payloads, or its loggable "id"
+//
+// For example:
+//
+//	msg, err := subscription.Receive(ctx)
+//	...
+//	var event OrderPlaced
+//	if err := parser.Parse(msg, &event); err != nil {
+//		msg.Nack()
+//		...
+//	}
+type PubSubMessageParser struct {
+	*BaseMBParser[pubsub.Message, JSONBodyOnce]
+}
+
+func NewPubSubMessageParser() *PubSubMessageParser {
+	return &PubSubMessageParser{
+		BaseMBParser: NewBaseMBParser(&PubSubBindingManager{}, _pubsubParserOpts),
+	}
+}
+
+func (pp *PubSubMessageParser) Name() string {
+	return PubSubMessageParserName
+}
+
+// PubSubBindingManager gets the values of bindings from a
+// *pubsub.Message. See PubSubMessageParser.
+type PubSubBindingManager struct{}
+
+func (mgr *PubSubBindingManager) BindingHandlerCached(
+	source *pubsub.Message,
+	entry *CacheEntry[JSONBodyOnce],
+	binding Binding,
+) BindingResult {
+
+	if entry == nil {
+		return BindingResultError(ErrBindingCacheNilEntry)
+	}
+
+	switch binding.Name {
+	case JsonTagBinding:
+		var result BindingResult
+		entry.WriteData(func(data *JSONBodyOnce) {
+			result = data.jsonValue(string(source.Body), binding)
+		})
+		return result
+	case MetadataTagBinding:
+		value, ok := source.Metadata[binding.Identifier]
+		if !ok {
+			return BindingResultNotFound()
+		}
+		return BindingResultValue(value)
+	case MessageTagBinding:
+		return pubsubMessageValue(source, binding.Identifier)
+	default:
+		return BindingResultError(fmt.Errorf("%w: unknown binding: %s", ErrUnallowedBindingName, binding.Name))
+	}
+}
+
+func (mgr *PubSubBindingManager) BindingHandler(source *pubsub.Message, binding Binding) BindingResult {
+	return mgr.BindingHandlerCached(source, &CacheEntry[JSONBodyOnce]{}, binding)
+}
+
+func (mgr *PubSubBindingManager) NewCached() JSONBodyOnce {
+	return JSONBodyOnce{}
+}
+
+// pubsubMessageValue returns the field of message bound by the message
+// binding.
+func pubsubMessageValue(message *pubsub.Message, field string) BindingResult {
+	switch field {
+	case PubSubMessageBodyField:
+		if len(message.Body) == 0 {
+			return BindingResultNotFound()
+		}
+		return BindingResultValue(string(message.Body))
+	case PubSubMessageIDField:
+		if message.LoggableID == "" {
+			return BindingResultNotFound()
+		}
+		return BindingResultValue(message.LoggableID)
+	default:
+		return BindingResultError(fmt.Errorf("%w: unknown message field: %s", ErrUnallowedBindingName, field))
+	}
+}
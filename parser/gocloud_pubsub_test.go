@@ -0,0 +1,82 @@
+//go:build pave_gocloud
+
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/pubsub"
+	_ "gocloud.dev/pubsub/mempubsub"
+)
+
+func TestPubSubMessageParser(t *testing.T) {
+	type OrderPlaced struct {
+		OrderID string `json:"order.id"`
+		Total   int    `json:"order.total"`
+		Tenant  string `metadata:"tenant"`
+		Region  string `metadata:"region,omitempty" default:"eu"`
+		Raw     string `message:"body"`
+	}
+
+	parser := NewPubSubMessageParser()
+
+	message := &pubsub.Message{
+		Body:     []byte(`{"order":{"id":"o-1","total":42}}`),
+		Metadata: map[string]string{"tenant": "acme"},
+	}
+
+	var order OrderPlaced
+	require.NoError(t, parser.Parse(message, &order))
+	assert.Equal(t, OrderPlaced{
+		OrderID: "o-1",
+		Total:   42,
+		Tenant:  "acme",
+		Region:  "eu",
+		Raw:     `{"order":{"id":"o-1","total":42}}`,
+	}, order)
+
+	err := parser.Parse(&pubsub.Message{Body: []byte(`{}`)}, &OrderPlaced{})
+	assert.Error(t, err)
+
+	t.Run("UnknownField", func(t *testing.T) {
+		var order struct {
+			Key string `message:"key"`
+		}
+		err := parser.Parse(message, &order)
+		assert.ErrorIs(t, err, ErrUnallowedBindingName)
+	})
+
+	t.Run("Subscription", func(t *testing.T) {
+		ctx := context.Background()
+
+		topic, err := pubsub.OpenTopic(ctx, "mem://orders")
+		require.NoError(t, err)
+		defer topic.Shutdown(ctx)
+
+		subscription, err := pubsub.OpenSubscription(ctx, "mem://orders")
+		require.NoError(t, err)
+		defer subscription.Shutdown(ctx)
+
+		require.NoError(t, topic.Send(ctx, &pubsub.Message{
+			Body:     []byte(`{"order":{"id":"o-2","total":7}}`),
+			Metadata: map[string]string{"tenant": "globex"},
+		}))
+
+		received, err := subscription.Receive(ctx)
+		require.NoError(t, err)
+		defer received.Ack()
+
+		var order struct {
+			OrderID string `json:"order.id"`
+			Tenant  string `metadata:"tenant"`
+			ID      string `message:"id"`
+		}
+		require.NoError(t, parser.Parse(received, &order))
+		assert.Equal(t, "o-2", order.OrderID)
+		assert.Equal(t, "globex", order.Tenant)
+		assert.NotEmpty(t, order.ID)
+	})
+}
@@ -0,0 +1,731 @@
+package parser
+
+import (
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrArrayLength          = errors.New("value does not match the array length")
+	ErrUnsupportedFieldType = errors.New("unsupported field type")
+	ErrValueOverflow        = errors.New("value overflows the field type")
+	ErrEmptyValue           = errors.New("empty value for a field type without an empty value")
+	ErrInvalidSubDocument   = errors.New("invalid sub-document for struct field")
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Helpers
+///////////////////////////////////////////////////////////////////////////////
+
+// Set field value with type conversion
+//
+// Currently supports:
+//   - string to string
+//   - string to int (with overflow checking)
+//   - string to bool
+//   - string to float64 (with overflow checking)
+//   - string to uuid.UUID
+//   - string to []byte (raw byte slice)
+//   - string to array of uuid.UUID
+//   - hex string to byte array (e.g. [32]byte)
+//   - comma delimited string or JSON array to slice or array (e.g. []int,
+//     [3]int), delimited by the delim modifier if any
+//   - string to struct with uuid.UUID field
+//   - string to struct with time.Time field
+//   - TextUnmarshaler, BinaryUnmarshaler and json.Unmarshaler support for
+//     custom types
+//   - Interface{} support for any type
+//   - Interfaces with methods through a registered InterfaceFactory
+//   - Pointers to any supported type (allocated as needed)
+//   - math/big Int, Float and Rat, and types with a registered TypeConverter
+func setFieldValue(field reflect.Value, value string) error {
+	return setFieldValueWithModifiers(field, value, BindingModifiers{})
+}
+
+// setFieldValueWithModifiers is setFieldValue for a value produced by a
+// binding. The modifiers of the binding are handed to TypeConverters.
+func setFieldValueWithModifiers(field reflect.Value, value string, modifiers BindingModifiers) error {
+	// Opt fields are populated through their Value, and only marked Set
+	// by bindings, see markOptSet
+	if IsOptType(field.Type()) {
+		field.Field(1).SetBool(false)
+		return setFieldValueWithModifiers(OptValue(field), value, modifiers)
+	}
+
+	// Union fields try their alternatives in order, empty values included
+	if IsUnionType(field.Type()) {
+		return setUnionValue(field, value, modifiers)
+	}
+
+	// Handle nil/empty values
+	if value == "" {
+		return handleEmptyValue(field)
+	}
+
+	// Enum modifiers restrict the names a value may have, see setEnumValue
+	if names, ok := enumNames(modifiers); ok && checksEnum(field.Type()) {
+		if set, err := setEnumValue(field, value, names); set || err != nil {
+			return err
+		}
+	}
+
+	// Check for a registered or built-in TypeConverter. Pointer types are
+	// checked too, for converters of shared values like *time.Location.
+	if converter, ok := getTypeConverter(field.Type()); ok {
+		return setConvertedValue(field, converter, value, modifiers)
+	}
+
+	// Allocate pointer fields and populate the value they point to
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return setFieldValueWithModifiers(field.Elem(), value, modifiers)
+	}
+
+	// Times are parsed in the layout of their binding, if it declares one
+	if layout, ok := timeLayout(modifiers); ok && field.Type() == TimeType {
+		return setTimeLayoutValue(field, value, layout)
+	}
+
+	// Check for TextUnmarshaler, BinaryUnmarshaler and json.Unmarshaler
+	if ok, err := setUnmarshalerValue(field, value); ok {
+		return err
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return setStringValue(field, value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return setIntValue(field, value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return setUintValue(field, value)
+	case reflect.Float32, reflect.Float64:
+		return setFloatValue(field, value)
+	case reflect.Complex64, reflect.Complex128:
+		return setComplexValue(field, value)
+	case reflect.Bool:
+		return setBoolValue(field, value)
+	case reflect.Slice:
+		return setSliceValue(field, value, modifiers)
+	case reflect.Array:
+		return setArrayValue(field, value, modifiers)
+	case reflect.Struct:
+		return setStructValue(field, value)
+	case reflect.Interface:
+		return setInterfaceValue(field, value)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedFieldType, field.Type())
+	}
+}
+
+// handleEmptyValue handles empty string values for different field types,
+// setting those with an empty value to their zero value.
+func handleEmptyValue(field reflect.Value) error {
+	if !hasEmptyValue(field.Type()) {
+		return fmt.Errorf("%w: %s", ErrEmptyValue, field.Type())
+	}
+	field.SetZero()
+	return nil
+}
+
+// hasEmptyValue reports whether an empty string populates fields of type
+// typ with their zero value: strings, slices, maps, pointers and
+// interfaces.
+func hasEmptyValue(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+// setUnmarshalerValue populates field through the first interface that
+// field or its pointer implements of encoding.TextUnmarshaler,
+// encoding.BinaryUnmarshaler and json.Unmarshaler, in that order, and
+// reports whether it implements one.
+func setUnmarshalerValue(field reflect.Value, value string) (bool, error) {
+	if !field.CanInterface() {
+		return false, nil
+	}
+
+	candidates := []any{field.Interface()}
+	if field.CanAddr() {
+		candidates = append(candidates, field.Addr().Interface())
+	}
+
+	for _, candidate := range candidates {
+		if unmarshaler, ok := candidate.(encoding.TextUnmarshaler); ok {
+			return true, unmarshaler.UnmarshalText([]byte(value))
+		}
+	}
+	for _, candidate := range candidates {
+		if unmarshaler, ok := candidate.(encoding.BinaryUnmarshaler); ok {
+			return true, unmarshaler.UnmarshalBinary([]byte(value))
+		}
+	}
+	for _, candidate := range candidates {
+		if unmarshaler, ok := candidate.(json.Unmarshaler); ok {
+			return true, unmarshalJSONValue(field, unmarshaler, value)
+		}
+	}
+	return false, nil
+}
+
+// unmarshalJSONValue populates field through unmarshaler, its
+// json.Unmarshaler. Bindings hand values over as text, so values that are
+// not valid JSON are unmarshaled as JSON strings, and so are JSON numbers,
+// booleans and null that unmarshaler rejects: an ID type that only
+// unmarshals strings still takes "42" from a query parameter.
+func unmarshalJSONValue(field reflect.Value, unmarshaler json.Unmarshaler, value string) error {
+	data := []byte(value)
+	if json.Valid(data) {
+		err := unmarshaler.UnmarshalJSON(data)
+		if err == nil || strings.ContainsAny(strings.TrimSpace(value)[:1], `{["`) {
+			return err
+		}
+		if field.CanSet() {
+			field.SetZero()
+		}
+	}
+
+	quoted, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return unmarshaler.UnmarshalJSON(quoted)
+}
+
+// implementsUnmarshaler reports whether typ or its pointer implements an
+// interface that setUnmarshalerValue populates values through.
+func implementsUnmarshaler(typ reflect.Type) bool {
+	for _, iface := range []reflect.Type{
+		reflect.TypeFor[encoding.TextUnmarshaler](),
+		reflect.TypeFor[encoding.BinaryUnmarshaler](),
+		reflect.TypeFor[json.Unmarshaler](),
+	} {
+		if typ.Implements(iface) || reflect.PointerTo(typ).Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// setStringValue sets string field values
+func setStringValue(field reflect.Value, value string) error {
+	field.SetString(value)
+	return nil
+}
+
+// setIntValue sets integer field values with overflow checking. Integral
+// decimals such as "10.0" or "1e2", which JSON encoders write for some
+// integers, are accepted as well.
+func setIntValue(field reflect.Value, value string) error {
+	intValue, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		floatValue, ok := parseIntegralFloat(value)
+		if !ok || floatValue < -(1<<63) || floatValue >= 1<<63 {
+			return fmt.Errorf("error converting value to int: %w", err)
+		}
+		intValue = int64(floatValue)
+	}
+
+	if field.OverflowInt(intValue) {
+		return fmt.Errorf("%w: %d overflows %s", ErrValueOverflow, intValue, field.Type())
+	}
+
+	field.SetInt(intValue)
+	return nil
+}
+
+// setUintValue sets unsigned integer field values with overflow checking,
+// accepting integral decimals like setIntValue.
+func setUintValue(field reflect.Value, value string) error {
+	uintValue, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		floatValue, ok := parseIntegralFloat(value)
+		if !ok || floatValue < 0 || floatValue >= 1<<64 {
+			return fmt.Errorf("error converting value to uint: %w", err)
+		}
+		uintValue = uint64(floatValue)
+	}
+
+	if field.OverflowUint(uintValue) {
+		return fmt.Errorf("%w: %d overflows %s", ErrValueOverflow, uintValue, field.Type())
+	}
+
+	field.SetUint(uintValue)
+	return nil
+}
+
+// parseIntegralFloat parses value as a decimal with a fraction or an
+// exponent, and reports whether it holds an integral number. Integers that
+// fit in 64 bits are parsed as integers beforehand, so that only integral
+// decimals lose the precision of float64.
+func parseIntegralFloat(value string) (float64, bool) {
+	if !strings.ContainsAny(value, ".eE") || strings.ContainsAny(value, "xX") {
+		return 0, false
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil || floatValue != math.Trunc(floatValue) {
+		return 0, false
+	}
+	return floatValue, true
+}
+
+// setFloatValue sets float field values with overflow checking
+func setFloatValue(field reflect.Value, value string) error {
+	floatValue, err := strconv.ParseFloat(value, field.Type().Bits())
+	if err != nil {
+		return fmt.Errorf("error converting value to float: %w", err)
+	}
+
+	if field.OverflowFloat(floatValue) {
+		return fmt.Errorf("%w: %f overflows %s", ErrValueOverflow, floatValue, field.Type())
+	}
+
+	field.SetFloat(floatValue)
+	return nil
+}
+
+// setComplexValue sets complex field values
+func setComplexValue(field reflect.Value, value string) error {
+	complexValue, err := strconv.ParseComplex(value, field.Type().Bits())
+	if err != nil {
+		return fmt.Errorf("error converting value to complex: %w", err)
+	}
+
+	if field.OverflowComplex(complexValue) {
+		return fmt.Errorf("%w: %v overflows %s", ErrValueOverflow, complexValue, field.Type())
+	}
+
+	field.SetComplex(complexValue)
+	return nil
+}
+
+// setBoolValue sets boolean field values with better validation
+//
+// Many common boolean representations are supported:
+//   - "true", "1", "yes", "on" (case insensitive)
+//   - "false", "0", "no", "off" (case insensitive)
+//   - Standard boolean parsing using strconv.ParseBool
+func setBoolValue(field reflect.Value, value string) error {
+	// Handle common boolean representations
+	switch value {
+	case "true", "1", "yes", "on", "True", "TRUE", "YES", "ON":
+		field.SetBool(true)
+		return nil
+	case "false", "0", "no", "off", "False", "FALSE", "NO", "OFF":
+		field.SetBool(false)
+		return nil
+	default:
+		// Fall back to standard parsing
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("error converting value to bool: %w", err)
+		}
+		field.SetBool(boolValue)
+		return nil
+	}
+}
+
+// setSliceValue sets slice field values. []byte slices take the bytes of
+// value, other slices are populated element by element from a JSON array
+// ("[1,2,3]") or a delimited string ("1,2,3"), empty elements and JSON
+// nulls leaving the zero value. Elements are delimited by commas, or by
+// the delim modifier (see DelimBindingModifier).
+func setSliceValue(field reflect.Value, value string, modifiers BindingModifiers) error {
+	if field.Type().Elem().Kind() == reflect.Uint8 {
+		field.SetBytes([]byte(value))
+		return nil
+	}
+
+	elems, err := splitDelimitedValue(value, modifiers)
+	if err != nil {
+		return fmt.Errorf("error converting value to %s: %w", field.Type(), err)
+	}
+	return setSliceElems(field, elems, modifiers)
+}
+
+// setSliceElems sets a slice field to a new slice of the given elements,
+// empty elements leaving the zero value.
+func setSliceElems(field reflect.Value, elems []string, modifiers BindingModifiers) error {
+	slice := reflect.MakeSlice(field.Type(), len(elems), len(elems))
+
+	for i, elem := range elems {
+		if elem == "" {
+			continue
+		}
+		if err := setFieldValueWithModifiers(slice.Index(i), elem, modifiers); err != nil {
+			return fmt.Errorf("error converting element %d of %s: %w", i, field.Type(), err)
+		}
+	}
+
+	field.Set(slice)
+	return nil
+}
+
+// setArrayValue sets array field values. Arrays are populated element
+// by element from a JSON array ("[1,2,3]") or a delimited string
+// ("1,2,3") with exactly as many elements as the array has, empty elements
+// and JSON nulls leaving the zero value. Elements are delimited as for
+// slices, see setSliceValue. Byte arrays (hashes, tokens) are instead
+// decoded from hex strings of exactly twice their length, with an optional
+// "0x" prefix. uuid.UUID fields never get here: they are converted by
+// convertUUID, or by their UnmarshalText in pave_minimal builds.
+func setArrayValue(field reflect.Value, value string, modifiers BindingModifiers) error {
+	if field.Type().Elem().Kind() == reflect.Uint8 && !isJSONArray(value) {
+		return setHexArrayValue(field, value)
+	}
+
+	elems, err := splitDelimitedValue(value, modifiers)
+	if err != nil {
+		return fmt.Errorf("error converting value to %s: %w", field.Type(), err)
+	}
+	if len(elems) != field.Len() {
+		return fmt.Errorf("%w: %s needs %d elements, got %d", ErrArrayLength, field.Type(), field.Len(), len(elems))
+	}
+
+	for i, elem := range elems {
+		if elem == "" {
+			field.Index(i).SetZero()
+			continue
+		}
+		if err := setFieldValueWithModifiers(field.Index(i), elem, modifiers); err != nil {
+			return fmt.Errorf("error converting element %d of %s: %w", i, field.Type(), err)
+		}
+	}
+	return nil
+}
+
+// isJSONArray reports whether value looks like a JSON array.
+func isJSONArray(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]")
+}
+
+// splitDelimitedValue splits value into the string values of its elements
+// on the delimiter of the delim modifier, trimming their spaces, or with
+// splitArrayValue without the modifier.
+func splitDelimitedValue(value string, modifiers BindingModifiers) ([]string, error) {
+	delim, ok := modifiers.Values[DelimBindingModifier].(string)
+	if !ok {
+		return splitArrayValue(value)
+	}
+
+	elems := strings.Split(value, delim)
+	for i := range elems {
+		elems[i] = strings.TrimSpace(elems[i])
+	}
+	return elems, nil
+}
+
+// parseDelimModifier parses the delimiter of the delim modifier, which may
+// be quoted, e.g. `delim=";"` or `delim=|`.
+func parseDelimModifier(value string) (any, error) {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	if value == "" {
+		return nil, fmt.Errorf("delim must not be empty")
+	}
+	return value, nil
+}
+
+// splitArrayValue splits a JSON array or comma delimited string into the
+// string values of its elements. JSON strings are unquoted, other JSON
+// values (numbers, objects, ...) are kept as raw JSON.
+func splitArrayValue(value string) ([]string, error) {
+	if !isJSONArray(value) {
+		elems := strings.Split(value, CommaDelimeter)
+		for i := range elems {
+			elems[i] = strings.TrimSpace(elems[i])
+		}
+		return elems, nil
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		return nil, err
+	}
+
+	elems := make([]string, len(raw))
+	for i, elem := range raw {
+		if len(elem) > 0 && elem[0] == '"' {
+			if err := json.Unmarshal(elem, &elems[i]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if string(elem) != "null" {
+			elems[i] = string(elem)
+		}
+	}
+	return elems, nil
+}
+
+// setHexArrayValue decodes a hex string into a byte array field.
+func setHexArrayValue(field reflect.Value, value string) error {
+	digits := value
+	if len(digits) > 2 && (digits[:2] == "0x" || digits[:2] == "0X") {
+		digits = digits[2:]
+	}
+
+	if len(digits) != 2*field.Len() {
+		return fmt.Errorf("%w: %s needs %d hex digits, got %d", ErrArrayLength, field.Type(), 2*field.Len(), len(digits))
+	}
+
+	decoded, err := hex.DecodeString(digits)
+	if err != nil {
+		return fmt.Errorf("error converting value to %s: %w", field.Type(), err)
+	}
+
+	reflect.Copy(field, reflect.ValueOf(decoded))
+	return nil
+}
+
+// setStructValue sets struct field values for special types
+func setStructValue(field reflect.Value, value string) error {
+	fieldType := field.Type()
+
+	// Handle time.Time type
+	if fieldType == TimeType {
+		timeValue, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			// Try common time formats
+			formats := []string{
+				time.RFC3339,
+				time.RFC3339Nano,
+				"2006-01-02T15:04:05",
+				"2006-01-02 15:04:05",
+				"2006-01-02",
+				"15:04:05",
+			}
+
+			for _, format := range formats {
+				if timeValue, err = time.Parse(format, value); err == nil {
+					break
+				}
+			}
+
+			if err != nil {
+				return fmt.Errorf("error converting value to time.Time: %w", err)
+			}
+		}
+		field.Set(reflect.ValueOf(timeValue))
+		return nil
+	}
+
+	// Other structs are fields tagged `recursive:"false"`, populated in
+	// one shot from their JSON sub-document
+	if !field.CanAddr() {
+		return fmt.Errorf("%w: %s", ErrUnsupportedFieldType, fieldType)
+	}
+	if err := json.Unmarshal([]byte(value), field.Addr().Interface()); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrInvalidSubDocument, fieldType, err)
+	}
+	return nil
+}
+
+// setInterfaceValue sets interface{} field values
+//
+// Interfaces with methods are populated by the InterfaceFactory registered
+// for the interface type. See [RegisterInterfaceFactory].
+func setInterfaceValue(field reflect.Value, value string) error {
+	if field.NumMethod() != 0 {
+		return setFactoryInterfaceValue(field, value)
+	}
+
+	// For empty interface, store as string
+	field.Set(reflect.ValueOf(value))
+	return nil
+}
+
+// zeroStructFields recursively sets all fields of a struct to
+// their zero values, skipping preserved fields.
+func zeroStructFields(value reflect.Value) {
+	// Without UseDefaults no value is parsed, so resetting cannot fail.
+	_ = resetStructFields(value, InvalidateOpts{})
+}
+
+// resetStructFields recursively resets all settable fields of a struct
+// according to opts. Fields tagged `preserve:"true"` are left untouched.
+func resetStructFields(value reflect.Value, opts InvalidateOpts) error {
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	typ := value.Type()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		structField := typ.Field(i)
+		if !field.CanSet() || isPreservedField(structField) {
+			continue
+		}
+
+		if err := resetFieldValue(field, opts); err != nil {
+			return fmt.Errorf("error resetting field %s: %w", structField.Name, err)
+		}
+
+		if !opts.UseDefaults {
+			continue
+		}
+		tag := qualifiedTag(structField.Tag, opts.environment)
+		if defaultValue, ok := tag.Lookup(DefaultValueSubTagPrefix); ok {
+			if err := setDefaultFieldValue(field, strings.TrimSpace(defaultValue)); err != nil {
+				return fmt.Errorf("error applying default to field %s: %w", structField.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resetFieldValue resets a single field to its zero value, recursing into
+// nested structs. With opts.KeepAllocations, pointed-to structs, slices
+// and maps are emptied in place rather than dropped.
+func resetFieldValue(field reflect.Value, opts InvalidateOpts) error {
+	switch field.Kind() {
+	case reflect.Struct:
+		if !IsSpecialStructType(field.Type()) {
+			return resetStructFields(field, opts)
+		}
+	case reflect.Ptr:
+		elemType := field.Type().Elem()
+		if opts.KeepAllocations && !field.IsNil() &&
+			elemType.Kind() == reflect.Struct && !IsSpecialStructType(elemType) {
+			return resetStructFields(field.Elem(), opts)
+		}
+	case reflect.Slice:
+		if opts.KeepAllocations && !field.IsNil() {
+			// Zero the elements so the backing array does not keep stale
+			// references alive.
+			for i := 0; i < field.Len(); i++ {
+				field.Index(i).SetZero()
+			}
+			field.SetLen(0)
+			return nil
+		}
+	case reflect.Map:
+		if opts.KeepAllocations && !field.IsNil() {
+			field.Clear()
+			return nil
+		}
+	}
+
+	field.SetZero()
+	return nil
+}
+
+// setDefaultFieldValue sets a default tag value, allocating pointer fields
+// as needed.
+func setDefaultFieldValue(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return setFieldValue(field.Elem(), value)
+	}
+	return setFieldValue(field, value)
+}
+
+// isPreservedField reports whether a field is tagged `preserve:"true"`.
+func isPreservedField(field reflect.StructField) bool {
+	preserve, ok := field.Tag.Lookup(PreserveTagName)
+	return ok && strings.TrimSpace(preserve) == "true"
+}
+
+// IsSpecialStructType checks if a struct type should be treated as a primitive
+// rather than being recursively parsed. Special types include time.Time, uuid.UUID,
+// every type with a TypeConverter (e.g. big.Int) and every type that unmarshals
+// itself (e.g. url.URL), see setUnmarshalerValue.
+func IsSpecialStructType(t reflect.Type) bool {
+	// List of struct types that should be treated as primitives
+	specialTypes := []reflect.Type{TimeType, UUIDType, FileHeaderType}
+
+	for _, specialType := range specialTypes {
+		if t == specialType {
+			return true
+		}
+	}
+
+	// Opt and union fields are populated through their alternatives
+	if IsOptType(t) || IsUnionType(t) {
+		return true
+	}
+
+	if _, hasConverter := getTypeConverter(t); hasConverter {
+		return true
+	}
+	if implementsUnmarshaler(t) {
+		return true
+	}
+	// Values of types converted through pointers (e.g. *time.Location)
+	// are shared and must not be recursed into.
+	_, hasConverter := getTypeConverter(reflect.PointerTo(t))
+	return hasConverter
+}
+
+func ParseTypeErasedPointer[S any](
+	source any,
+	dest any,
+	parse func(source *S, dest any) error,
+) error {
+	typedSource, ok := source.(*S)
+	if !ok {
+		return sourceTypeError(new(S), source)
+	}
+
+	if err := checkDest(dest); err != nil {
+		return err
+	}
+
+	return parse(typedSource, dest)
+}
+
+func ParseTypeErasedSlice[S any](
+	source any,
+	dest any,
+	parse func(source []S, dest any) error,
+) error {
+	typedSource, ok := source.([]S)
+	if !ok {
+		return sourceTypeError([]S(nil), source)
+	}
+
+	if err := checkDest(dest); err != nil {
+		return err
+	}
+
+	return parse(typedSource, dest)
+}
+
+// checkDest returns ErrNilDest or ErrDestNotStructPtr unless dest is a
+// non-nil pointer to a struct.
+func checkDest(dest any) error {
+	if dest == nil {
+		return ErrNilDest
+	}
+
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w, got %T", ErrDestNotStructPtr, dest)
+	}
+
+	return nil
+}
+
+// sourceTypeError returns ErrSourceTypeMismatch for a source that is not
+// of the type of expected.
+func sourceTypeError(expected any, source any) error {
+	return fmt.Errorf("%w: expected %T, got %T", ErrSourceTypeMismatch, expected, source)
+}
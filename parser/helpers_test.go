@@ -1,13 +1,19 @@
-package pave
+package parser
 
 import (
 	"encoding"
+	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Custom type that implements TextUnmarshaler
@@ -36,6 +42,50 @@ func (c *CustomPointerType) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// Custom type that only implements BinaryUnmarshaler
+type CustomBinaryType [4]byte
+
+func (c *CustomBinaryType) UnmarshalBinary(data []byte) error {
+	if len(data) != len(c) {
+		return errors.New("custom binary error")
+	}
+	copy(c[:], data)
+	return nil
+}
+
+// Custom enum that only implements json.Unmarshaler, from JSON strings
+type CustomJSONEnum int
+
+func (c *CustomJSONEnum) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	switch name {
+	case "red":
+		*c = 1
+	case "42":
+		*c = 42
+	default:
+		return errors.New("custom enum error")
+	}
+	return nil
+}
+
+// Custom type that only implements json.Unmarshaler, from JSON objects
+type CustomJSONObject struct {
+	Value string
+}
+
+func (c *CustomJSONObject) UnmarshalJSON(data []byte) error {
+	var object struct{ V string }
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	c.Value = "json:" + object.V
+	return nil
+}
+
 // Helper function to create a reflect.Value from any type
 func valueFromInterface(v interface{}) reflect.Value {
 	return reflect.ValueOf(v).Elem()
@@ -114,6 +164,16 @@ func TestSetFieldValue(t *testing.T) {
 		{"custom_text_unmarshaler", ptr(CustomTextType{}), "test", CustomTextType{Value: "custom:test"}, false},
 		{"custom_text_error", ptr(CustomTextType{}), "error", CustomTextType{}, true},
 		{"custom_pointer_unmarshaler", ptr(CustomPointerType{}), "test", CustomPointerType{Value: "pointer:test"}, false},
+
+		// BinaryUnmarshaler and json.Unmarshaler tests
+		{"custom_binary_unmarshaler", ptr(CustomBinaryType{}), "abcd", CustomBinaryType{'a', 'b', 'c', 'd'}, false},
+		{"custom_binary_error", ptr(CustomBinaryType{}), "abc", CustomBinaryType{}, true},
+		{"custom_json_enum", ptr(CustomJSONEnum(0)), "red", CustomJSONEnum(1), false},
+		{"custom_json_enum_quoted", ptr(CustomJSONEnum(0)), `"red"`, CustomJSONEnum(1), false},
+		{"custom_json_enum_number", ptr(CustomJSONEnum(0)), "42", CustomJSONEnum(42), false},
+		{"custom_json_enum_error", ptr(CustomJSONEnum(0)), "blue", CustomJSONEnum(0), true},
+		{"custom_json_object", ptr(CustomJSONObject{}), `{"V": "x"}`, CustomJSONObject{Value: "json:x"}, false},
+		{"custom_json_object_error", ptr(CustomJSONObject{}), `{"V": 1}`, CustomJSONObject{}, true},
 	}
 
 	for _, tt := range tests {
@@ -151,6 +211,8 @@ func TestHandleEmptyValue(t *testing.T) {
 		{"interface_empty", ptr(interface{}("test")), interface{}(nil), false},
 		{"int_empty", ptr(int(42)), int(0), true}, // Should error
 		{"bool_empty", ptr(true), false, true},    // Should error
+		{"time_empty", ptr(time.Now()), time.Time{}, true},
+		{"struct_empty", ptr(Range{Unit: "bytes"}), Range{}, true},
 	}
 
 	for _, tt := range tests {
@@ -200,6 +262,12 @@ func TestSetIntValue(t *testing.T) {
 		{"int8_overflow", ptr(int8(0)), "128", int8(0), true},
 		{"int_invalid", ptr(int(0)), "abc", int(0), true},
 		{"int_negative", ptr(int(0)), "-42", int(-42), false},
+		{"int_integral_decimal", ptr(int(0)), "10.0", int(10), false},
+		{"int_exponent", ptr(int(0)), "-1e2", int(-100), false},
+		{"int_fraction", ptr(int(0)), "10.5", int(0), true},
+		{"int8_exponent_overflow", ptr(int8(0)), "1e3", int8(0), true},
+		{"int64_exponent_overflow", ptr(int64(0)), "1e19", int64(0), true},
+		{"int_hex_float", ptr(int(0)), "0x1p4", int(0), true},
 	}
 
 	for _, tt := range tests {
@@ -235,6 +303,9 @@ func TestSetUintValue(t *testing.T) {
 		{"uint8_overflow", ptr(uint8(0)), "256", uint8(0), true},
 		{"uint_invalid", ptr(uint(0)), "abc", uint(0), true},
 		{"uint_negative", ptr(uint(0)), "-1", uint(0), true},
+		{"uint_integral_decimal", ptr(uint(0)), "10.0", uint(10), false},
+		{"uint_exponent", ptr(uint(0)), "1E2", uint(100), false},
+		{"uint_negative_exponent", ptr(uint(0)), "-1e2", uint(0), true},
 	}
 
 	for _, tt := range tests {
@@ -390,14 +461,21 @@ func TestSetSliceValue(t *testing.T) {
 		wantErr bool
 	}{
 		{"byte_slice", ptr([]byte{}), "hello", []byte("hello"), false},
-		{"string_slice", ptr([]string{}), "hello", []string{}, true}, // Should error
-		{"int_slice", ptr([]int{}), "hello", []int{}, true},          // Should error
+		{"string_slice", ptr([]string{}), "hello", []string{"hello"}, false},
+		{"string_slice_delimited", ptr([]string{}), "a, b,,c", []string{"a", "b", "", "c"}, false},
+		{"int_slice", ptr([]int{}), "1,2,3", []int{1, 2, 3}, false},
+		{"int_slice_json", ptr([]int{}), "[1, null, 3]", []int{1, 0, 3}, false},
+		{"float_slice", ptr([]float64{}), "1.5,-2", []float64{1.5, -2}, false},
+		{"bool_slice", ptr([]bool{}), "true,off", []bool{true, false}, false},
+		{"uuid_slice", ptr([]uuid.UUID{}), "123e4567-e89b-12d3-a456-426614174000", []uuid.UUID{uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")}, false},
+		{"time_slice", ptr([]time.Time{}), "2024-01-02T03:04:05Z", []time.Time{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, false},
+		{"int_slice_invalid", ptr([]int{}), "1,hello", []int{}, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			field := valueFromInterface(tt.field)
-			err := setSliceValue(field, tt.value)
+			err := setSliceValue(field, tt.value, BindingModifiers{})
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("setSliceValue() error = %v, wantErr %v", err, tt.wantErr)
@@ -422,15 +500,27 @@ func TestSetArrayValue(t *testing.T) {
 		want    interface{}
 		wantErr bool
 	}{
-		{"uuid_valid", ptr(uuid.UUID{}), "550e8400-e29b-41d4-a716-446655440000", uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), false},
-		{"uuid_invalid", ptr(uuid.UUID{}), "invalid-uuid", uuid.UUID{}, true},
 		{"int_array", ptr([3]int{}), "123", [3]int{}, true}, // Should error
+		{"int_array_delimited", ptr([3]int{}), "1, 2,3", [3]int{1, 2, 3}, false},
+		{"int_array_json", ptr([3]int{}), "[1, 2, 3]", [3]int{1, 2, 3}, false},
+		{"string_array_json", ptr([2]string{}), `["a,b", "c"]`, [2]string{"a,b", "c"}, false},
+		{"float_array_json_null", ptr([2]float64{}), "[1.5, null]", [2]float64{1.5, 0}, false},
+		{"byte_array_json", ptr([2]byte{}), "[1, 255]", [2]byte{1, 255}, false},
+		{"bool_array", ptr([2]bool{}), "true,false", [2]bool{true, false}, false},
+		{"int_array_too_long", ptr([2]int{}), "1,2,3", [2]int{}, true},
+		{"int_array_element", ptr([2]int{}), "1,x", [2]int{}, true},
+		{"int_array_bad_json", ptr([2]int{}), "[1,", [2]int{}, true},
+		{"hex_bytes", ptr([4]byte{}), "DEADbeef", [4]byte{0xde, 0xad, 0xbe, 0xef}, false},
+		{"hex_bytes_prefix", ptr([2]byte{}), "0x0a0B", [2]byte{0x0a, 0x0b}, false},
+		{"hex_bytes_short", ptr([4]byte{}), "deadbe", [4]byte{}, true},
+		{"hex_bytes_long", ptr([2]byte{}), "deadbeef", [2]byte{}, true},
+		{"hex_bytes_invalid", ptr([2]byte{}), "zzzz", [2]byte{}, true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			field := valueFromInterface(tt.field)
-			err := setArrayValue(field, tt.value)
+			err := setArrayValue(field, tt.value, BindingModifiers{})
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("setArrayValue() error = %v, wantErr %v", err, tt.wantErr)
@@ -455,10 +545,6 @@ func TestSetStructValue(t *testing.T) {
 		want    interface{}
 		wantErr bool
 	}{
-		// UUID tests
-		{"uuid_valid", ptr(uuid.UUID{}), "550e8400-e29b-41d4-a716-446655440000", uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), false},
-		{"uuid_invalid", ptr(uuid.UUID{}), "invalid-uuid", uuid.UUID{}, true},
-
 		// Time tests
 		{"time_rfc3339", ptr(time.Time{}), "2023-01-01T00:00:00Z", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), false},
 		{"time_rfc3339_nano", ptr(time.Time{}), "2023-01-01T00:00:00.123456789Z", time.Date(2023, 1, 1, 0, 0, 0, 123456789, time.UTC), false},
@@ -609,7 +695,89 @@ func TestZeroStructFields_UnexportedFields(t *testing.T) {
 	}
 }
 
-// Test for isSpecialStructType function
+func TestZeroStructFields_Preserve(t *testing.T) {
+	type TestStruct struct {
+		Name   string
+		Buffer []byte `preserve:"true"`
+	}
+
+	original := TestStruct{Name: "hello", Buffer: []byte("keep")}
+	zeroStructFields(reflect.ValueOf(&original).Elem())
+
+	assert.Empty(t, original.Name)
+	assert.Equal(t, []byte("keep"), original.Buffer)
+}
+
+func TestResetStructFields(t *testing.T) {
+	type Nested struct {
+		Size  int `default:"20"`
+		Label string
+	}
+
+	type TestStruct struct {
+		Page    int    `default:"1"`
+		Limit   *int   `default:"10"`
+		Name    string `default:"anonymous"`
+		Nested  *Nested
+		Items   []Nested
+		Tags    map[string]string
+		Tracker string `default:"x" preserve:"true"`
+	}
+
+	newValue := func() (*TestStruct, *Nested) {
+		nested := &Nested{Size: 5, Label: "nested"}
+		return &TestStruct{
+			Page:    3,
+			Limit:   ptr(50),
+			Name:    "jane",
+			Nested:  nested,
+			Items:   make([]Nested, 2, 8),
+			Tags:    map[string]string{"a": "b"},
+			Tracker: "kept",
+		}, nested
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		v, _ := newValue()
+		require.NoError(t, resetStructFields(reflect.ValueOf(v).Elem(), InvalidateOpts{UseDefaults: true}))
+
+		assert.Equal(t, 1, v.Page)
+		require.NotNil(t, v.Limit)
+		assert.Equal(t, 10, *v.Limit)
+		assert.Equal(t, "anonymous", v.Name)
+		assert.Nil(t, v.Nested)
+		assert.Nil(t, v.Items)
+		assert.Nil(t, v.Tags)
+		assert.Equal(t, "kept", v.Tracker)
+	})
+
+	t.Run("KeepAllocations", func(t *testing.T) {
+		v, nested := newValue()
+		require.NoError(t, resetStructFields(reflect.ValueOf(v).Elem(), InvalidateOpts{
+			UseDefaults:     true,
+			KeepAllocations: true,
+		}))
+
+		assert.Same(t, nested, v.Nested)
+		assert.Equal(t, Nested{Size: 20}, *v.Nested)
+		assert.NotNil(t, v.Items)
+		assert.Len(t, v.Items, 0)
+		assert.Equal(t, 8, cap(v.Items))
+		assert.NotNil(t, v.Tags)
+		assert.Empty(t, v.Tags)
+	})
+
+	t.Run("InvalidDefault", func(t *testing.T) {
+		type Bad struct {
+			Count int `default:"many"`
+		}
+
+		err := resetStructFields(reflect.ValueOf(&Bad{}).Elem(), InvalidateOpts{UseDefaults: true})
+		assert.Error(t, err)
+	})
+}
+
+// Test for IsSpecialStructType function
 func TestIsSpecialStructType(t *testing.T) {
 	tests := []struct {
 		name string
@@ -617,7 +785,6 @@ func TestIsSpecialStructType(t *testing.T) {
 		want bool
 	}{
 		{"time.Time", reflect.TypeOf(time.Time{}), true},
-		{"uuid.UUID", reflect.TypeOf(uuid.UUID{}), true},
 		{"regular_struct", reflect.TypeOf(struct{ Name string }{}), false},
 		{"string", reflect.TypeOf(""), false},
 		{"int", reflect.TypeOf(int(0)), false},
@@ -625,8 +792,8 @@ func TestIsSpecialStructType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := isSpecialStructType(tt.t); got != tt.want {
-				t.Errorf("isSpecialStructType() = %v, want %v", got, tt.want)
+			if got := IsSpecialStructType(tt.t); got != tt.want {
+				t.Errorf("IsSpecialStructType() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -839,3 +1006,96 @@ func TestParseTypeErasedSlice(t *testing.T) {
 func ptr[T any](v T) *T {
 	return &v
 }
+
+func TestTypedErrors(t *testing.T) {
+	type Dest struct {
+		Value int `json:"value"`
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"unsupported_field", setFieldValue(valueFromInterface(ptr(make(chan int))), "x"), ErrUnsupportedFieldType},
+		{"unsupported_slice", setSliceValue(valueFromInterface(ptr([]chan int{})), "x", BindingModifiers{}), ErrUnsupportedFieldType},
+		{"invalid_struct_document", setStructValue(valueFromInterface(ptr(struct{ Name string }{})), "x"), ErrInvalidSubDocument},
+		{"empty_value", handleEmptyValue(valueFromInterface(ptr(42))), ErrEmptyValue},
+		{"int_overflow", setIntValue(valueFromInterface(ptr(int8(0))), "128"), ErrValueOverflow},
+		{"uint_overflow", setUintValue(valueFromInterface(ptr(uint8(0))), "256"), ErrValueOverflow},
+		{"erased_nil_dest", ParseTypeErasedSlice([]byte("{}"), nil, func([]byte, any) error { return nil }), ErrNilDest},
+		{"erased_dest", ParseTypeErasedSlice([]byte("{}"), Dest{}, func([]byte, any) error { return nil }), ErrDestNotStructPtr},
+		{"erased_nil_ptr_dest", ParseTypeErasedSlice([]byte("{}"), (*Dest)(nil), func([]byte, any) error { return nil }), ErrDestNotStructPtr},
+		{"erased_source", ParseTypeErasedPointer(42, &Dest{}, func(*string, any) error { return nil }), ErrSourceTypeMismatch},
+		{"parser_source", NewHTTPRequestParser().Parse("request", &Dest{}), ErrSourceTypeMismatch},
+		{"parser_dest", NewHTTPRequestParser().Parse(createTestRequest(t), Dest{}), ErrDestNotStructPtr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.err, tt.want)
+		})
+	}
+}
+
+func TestSetFieldValue_DelimModifier(t *testing.T) {
+	type Request struct {
+		IDs    []int         `query:"ids,delim=;,omitempty" default:""`
+		Tags   []string      `query:"tags,delim=\"|\",omitempty" default:"x|y"`
+		Point  [2]float64    `query:"point,delim=' ',omitempty" default:"0 0"`
+		Groups []string      `query:"group,delim=;,explode=true,omitempty" default:""`
+		Days   *[]time.Month `query:"days,delim=/,omitempty" default:""`
+	}
+
+	req := newTestRequest(t, "GET", "http://example.com/?ids=1%3B2%3B%3B4&point=1.5+-2&group=a%3Bb&group=c&days=1/12", nil)
+
+	var dest Request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &dest))
+	assert.Equal(t, []int{1, 2, 0, 4}, dest.IDs)
+	assert.Equal(t, []string{"x", "y"}, dest.Tags, "defaults are split too")
+	assert.Equal(t, [2]float64{1.5, -2}, dest.Point)
+	assert.Equal(t, []string{"a;b", "c"}, dest.Groups, "exploded values are not split")
+	assert.Equal(t, &[]time.Month{1, 12}, dest.Days)
+
+	type Invalid struct {
+		IDs []int `query:"ids,delim=\"\""`
+	}
+	err := NewHTTPRequestParser().Parse(req, &Invalid{})
+	assert.ErrorContains(t, err, "delim must not be empty")
+}
+
+func TestHTTPRequestParser_Unmarshalers(t *testing.T) {
+	type request struct {
+		Color  CustomJSONEnum    `query:"color"`
+		Colors []CustomJSONEnum  `query:"colors"`
+		Key    *CustomBinaryType `header:"X-Key"`
+		Filter CustomJSONObject  `json:"filter" recursive:"false"`
+		Level  CustomJSONEnum    `json:"level"`
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "/?color=red&colors=red&colors=42", strings.NewReader(`{"filter": {"V": "open"}, "level": 42}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Key", "abcd")
+
+	var dest request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &dest))
+	assert.Equal(t, CustomJSONEnum(1), dest.Color)
+	assert.Equal(t, []CustomJSONEnum{1, 42}, dest.Colors)
+	assert.Equal(t, &CustomBinaryType{'a', 'b', 'c', 'd'}, dest.Key)
+	assert.Equal(t, CustomJSONObject{Value: "json:open"}, dest.Filter)
+	assert.Equal(t, CustomJSONEnum(42), dest.Level)
+
+	req, err = http.NewRequest(http.MethodGet, "/?color=blue", nil)
+	require.NoError(t, err)
+	assert.ErrorContains(t, NewHTTPRequestParser().Parse(req, &request{}), "custom enum error")
+}
+
+// newTestRequest returns a new request, failing the test if it cannot be
+// created.
+func newTestRequest(t testing.TB, method, target string, body io.Reader) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(method, target, body)
+	require.NoError(t, err)
+	return req
+}
@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"net/http"
+	"sync"
+)
+
+// HTTPAdapterOnce caches the *http.Request a source is converted to by an
+// HTTPAdapterBindingManager.
+type HTTPAdapterOnce struct {
+	requestOnce sync.Once
+	request     *http.Request
+	requestErr  error
+	httpEntry   *CacheEntry[HTTPRequestOnce] // Cache of the HTTPBindingManager
+}
+
+// HTTPAdapterBindingManager gets the values of bindings from a source
+// standing for an HTTP request, such as the HTTP trigger event of a
+// serverless platform. The source is converted to an *http.Request once,
+// whose bindings an HTTPBindingManager then gets, so that structs tagged
+// for the HTTPRequestParser parse the same on every platform.
+//
+// The path binding gets the path parameters of the source instead.
+type HTTPAdapterBindingManager[S any] struct {
+	http       *HTTPBindingManager
+	convert    func(source *S) (*http.Request, error)
+	pathParams func(source *S) map[string]string
+}
+
+// NewHTTPAdapterBindingManager creates an HTTPAdapterBindingManager
+// converting sources with convert, and getting their path parameters
+// with pathParams.
+func NewHTTPAdapterBindingManager[S any](
+	convert func(source *S) (*http.Request, error),
+	pathParams func(source *S) map[string]string,
+) *HTTPAdapterBindingManager[S] {
+
+	return &HTTPAdapterBindingManager[S]{
+		http:       NewHTTPBindingManager(),
+		convert:    convert,
+		pathParams: pathParams,
+	}
+}
+
+func (mgr *HTTPAdapterBindingManager[S]) BindingHandlerCached(
+	source *S,
+	entry *CacheEntry[HTTPAdapterOnce],
+	binding Binding,
+) BindingResult {
+
+	if entry == nil {
+		return BindingResultError(ErrBindingCacheNilEntry)
+	}
+
+	if binding.Name == PathTagBinding {
+		return nonEmptyMapValue(mgr.pathParams(source), binding.Identifier)
+	}
+
+	var (
+		request   *http.Request
+		err       error
+		httpEntry *CacheEntry[HTTPRequestOnce]
+	)
+	entry.WriteData(func(data *HTTPAdapterOnce) {
+		data.requestOnce.Do(func() {
+			data.request, data.requestErr = mgr.convert(source)
+		})
+		request, err, httpEntry = data.request, data.requestErr, data.httpEntry
+	})
+	if err != nil {
+		return BindingResultError(err)
+	}
+
+	return mgr.http.BindingHandlerCached(request, httpEntry, binding)
+}
+
+func (mgr *HTTPAdapterBindingManager[S]) BindingHandler(source *S, binding Binding) BindingResult {
+	entry := &CacheEntry[HTTPAdapterOnce]{data: mgr.NewCached()}
+	return mgr.BindingHandlerCached(source, entry, binding)
+}
+
+func (mgr *HTTPAdapterBindingManager[S]) NewCached() HTTPAdapterOnce {
+	return HTTPAdapterOnce{
+		httpEntry: &CacheEntry[HTTPRequestOnce]{data: NewHTTPRequestOnce()},
+	}
+}
+
+// RegisterVerifier makes verifier available to the signed modifier as
+// name. See HTTPRequestParser.RegisterVerifier.
+func (mgr *HTTPAdapterBindingManager[S]) RegisterVerifier(name string, verifier SignatureVerifier) {
+	mgr.http.registerVerifier(name, verifier)
+}
+
+// UnregisterVerifier removes the verifier registered as name, if any.
+func (mgr *HTTPAdapterBindingManager[S]) UnregisterVerifier(name string) {
+	mgr.http.unregisterVerifier(name)
+}
+
+var (
+	// Binding Options of parsers adapting HTTP requests, see
+	// HTTPAdapterBindingManager.
+	_httpAdapterTagOpts = ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				JsonTagBinding,
+				CookieTagBinding,
+				HeaderTagBinding,
+				QueryTagBinding,
+				PathTagBinding,
+				BasicAuthTagBinding,
+				AcceptTagBinding,
+				LocaleTagBinding,
+				ConditionalTagBinding,
+			},
+			CustomBindingModifiers: _httpTagOpts.CustomBindingModifiers,
+			ValueModifiers:         _httpTagOpts.ValueModifiers,
+		},
+		AllowedTagOptionals: []string{},
+	}
+
+	// Options of parsers adapting HTTP requests
+	_httpAdapterParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts: PCManagerOpts{
+			tagOpts:    _httpAdapterTagOpts,
+			ScopeFuncs: _httpPCMOpts.ScopeFuncs,
+		},
+	}
+)
+
+// JSONBodyOnce caches the parsed JSON body of a message, such as a queue
+// message or an event.
+type JSONBodyOnce struct {
+	jsonOnce sync.Once
+	jsonBody jsonDoc
+}
+
+// jsonValue returns the value at the path of binding in body, parsed once.
+func (data *JSONBodyOnce) jsonValue(body string, binding Binding) BindingResult {
+	data.jsonOnce.Do(func() {
+		if body == "" {
+			body = "{}"
+		}
+		data.jsonBody = parseJSONDoc([]byte(body))
+	})
+	return data.jsonBody.lookup(jsonBindingPath(binding))
+}
+
+// nonEmptyMapValue returns the value of name in values. Empty values are
+// not found, since events and messages leave absent fields empty.
+func nonEmptyMapValue(values map[string]string, name string) BindingResult {
+	value, ok := values[name]
+	if !ok || value == "" {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(value)
+}
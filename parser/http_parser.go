@@ -0,0 +1,814 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/netip"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidBasicAuth     = errors.New("invalid basic auth credentials")
+	ErrBodyTooLarge         = errors.New("request body too large")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// Part names produced by the basicauth binding
+const (
+	BasicAuthUsernamePart string = "username"
+	BasicAuthPasswordPart string = "password"
+)
+
+var (
+	// Default HTTPRequestParser Binding Options
+	_httpTagOpts = ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				JsonTagBinding,
+				MultipartTagBinding,
+				CookieTagBinding,
+				HeaderTagBinding,
+				QueryTagBinding,
+				PathTagBinding,
+				BasicAuthTagBinding,
+				TrailerTagBinding,
+				RangeTagBinding,
+				AcceptTagBinding,
+				LocaleTagBinding,
+				ConditionalTagBinding,
+				GeoIPTagBinding,
+				FlagTagBinding,
+				RateLimitTagBinding,
+				TenantTagBinding,
+			},
+			CustomBindingModifiers: []string{
+				LiteralBindingModifier,
+			},
+			ValueModifiers: map[string]ModifierValueParser{
+				CurrencyBindingModifier:  parseCurrencyModifier,
+				MaxBindingModifier:       TypedModifier[int64](),
+				OffersBindingModifier:    parseOffersModifier,
+				SupportedBindingModifier: parseSupportedModifier,
+				KeyBindingModifier:       parseRateLimitKeyModifier,
+				SignedBindingModifier:    TypedModifier[string](),
+				ExplodeBindingModifier:   TypedModifier[bool](),
+			},
+		},
+		AllowedTagOptionals: []string{},
+	}
+
+	// Default HTTPRequestParser ParseChainManager Options
+	_httpPCMOpts = PCManagerOpts{
+		tagOpts: _httpTagOpts,
+		ScopeFuncs: map[string]ScopeFunc{
+			QueryTagBinding: ConcatScope,
+		},
+	}
+
+	// Default HTTPRequestParser Options
+	_httpParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts:  _httpPCMOpts,
+	}
+)
+
+// HTTPRequestParser provides a parser for HTTP requests with the
+// the following features:
+//   - Parses JSON body, cookies, headers, and query parameters
+//   - Caches repetively parsed data by http package to avoid extra
+//     computation and allocs.
+//   - Supports both cached and non-cached parsing
+//   - Implements the MultiBindingParser interface for flexible
+//     field bindings
+//
+// The following Field Bindings are supported:
+//   - json:'<key,[modifiers]>'`: Parses a JSON key from the request body
+//   - multipart:'<key,[modifiers]>'`: Parses a value or uploaded file of a
+//     multipart/form-data body into a string, *multipart.FileHeader or
+//     []*multipart.FileHeader field, or the content of the first file
+//     into another field, e.g. []byte. See MultipartValue.
+//   - cookie:'<key,[modifiers]>'`: Parses a cookie value by key
+//   - header:'<key,[modifiers]>'`: Parses a header value by key
+//   - query:'<key,[modifiers]>'`: Parses a query parameter value by key.
+//     Slice fields (e.g. []string, []int) of header and query bindings
+//     take every value of repeated parameters or headers, or with
+//     `explode=false` the delimited elements of the first one.
+//   - path:'<name,[modifiers]>'`: Parses a path parameter of the route by
+//     name, as found by the PathValueExtractor of the
+//     HTTPRequestParserOpts (http.ServeMux by default).
+//   - basicauth:'<header,[modifiers]>'`: Parses the basic auth credentials
+//     of a header (usually Authorization) into the "username" and
+//     "password" parts. Select one with `part:"username"`.
+//   - trailer:'<key,[modifiers]>'`: Parses a trailer value by key. Trailers
+//     follow the body, which is read first if nothing has read it yet.
+//     Handlers streaming large uploads consume the body before parsing.
+//   - range:'<header,[modifiers]>'`: Parses a Range header (usually
+//     Range) into a Range field, or the "unit", "start", "end" and "suffix"
+//     parts of its first range. With `max=<size>`, ranges beyond size
+//     fail with ErrRangeNotSatisfiable. Other bindings only take `max` for
+//     Range fields, and fail with ErrMaxNotRange otherwise.
+//   - accept:'<header,[modifiers]>'`: Parses an Accept, Accept-Language or
+//     Accept-Encoding header into an AcceptList field. With
+//     `offers=<value>|<value>...`, it populates the offer the client
+//     prefers instead, or fails with ErrNotAcceptable.
+//   - locale:'<header,[modifiers]>'`: Resolves the locale of an
+//     Accept-Language header into a language.Tag field. With
+//     `supported=<tag>|<tag>...`, it populates the supported locale that
+//     best matches the header instead, or fails with ErrNotAcceptable.
+//   - conditional:'<header,[modifiers]>'`: Parses the conditional header
+//     If-Match or If-None-Match into an ETagList field, or
+//     If-Modified-Since or If-Unmodified-Since into a time.Time field.
+//     Absent headers populate the zero value, and invalid dates are
+//     ignored. See Preconditions.
+//   - geoip:'<attribute,[modifiers]>'`: Parses the "country", "asn" or
+//     "org" attribute of the client IP, as found by the IPEnricher of the
+//     HTTPRequestParserOpts. See ClientIP.
+//   - flag:'<key,[modifiers]>'`: Parses the value of the feature flag key,
+//     as evaluated by the registered FlagProvider. With omiterror, fields
+//     fall back to their default when the provider is down. See
+//     RegisterFlagProvider.
+//   - ratelimit:'<limiter,[modifiers]>'`: Counts the request against the
+//     RateLimiter registered as limiter and parses the resulting window
+//     into a RateLimit field, or its "limit", "remaining" and "reset"
+//     parts. Limits are keyed by the client IP, or by the binding of
+//     `key=header:<name>`, `key=query:<name>` or `key=cookie:<name>`. See
+//     RegisterRateLimiter.
+//   - tenant:'<resolver,[modifiers]>'`: Parses the tenant of the request,
+//     as resolved by the TenantResolver registered as resolver, e.g. into
+//     a TenantID field. See RegisterTenantResolver.
+//
+// With the signed=<verifier> modifier, a binding only gets its value once
+// the SignatureVerifier registered as verifier accepted the signature of
+// the body. See RegisterVerifier.
+//
+// A nested struct field tagged with `query:"<prefix>"` scopes the query
+// bindings of its fields, e.g. `query:"page"` inside a struct tagged
+// `query:"paging_"` binds to the "paging_page" query parameter. Likewise,
+// a nested struct field tagged with `json:"<path>,scope"` resolves the
+// json bindings of its fields relative to that subtree, e.g. `json:"city"`
+// inside a struct tagged `json:"address,scope"` binds to "address.city",
+// the way encoding/json nests objects. Without the scope modifier, json
+// identifiers are paths from the root of the body.
+//
+// Like all other MultiBindingParsers, this parser caches the
+// parsing strategy (ParseChain) for each destination type, so
+// that only the first parse takes the time to build the chain,
+// and subsequent parses simply execute the pre-built chain.
+//
+// This parser expects the standard parse tag format. See: [tags.go](./tags.go)
+//
+// This parser does not support any custom modifiers, but it does
+// support all standard modifiers (required, omitempty, omitnil, omiterror).
+type HTTPRequestParser struct {
+	*BaseMBParser[http.Request, HTTPRequestOnce]
+}
+
+// HTTPRequestParserOpts configures the behavior of an HTTPRequestParser.
+// The zero value matches the defaults used by NewHTTPRequestParser.
+type HTTPRequestParserOpts struct {
+	// QueryDecoding selects how query parameter names are interpreted.
+	// Defaults to QueryDecodingFlat.
+	QueryDecoding QueryDecodingMode
+	// MaxBodyBytes limits the size of the request body read for json
+	// bindings. Larger bodies fail with ErrBodyTooLarge. Zero means no limit.
+	MaxBodyBytes int64
+	// PathValues gets the path parameters of path bindings from the router
+	// of the request. Defaults to ServeMuxPathValues.
+	PathValues PathValueExtractor
+	// MultipartMaxMemory is the number of bytes of multipart bodies kept
+	// in memory, larger files are stored in temporary files. Defaults to
+	// DefaultMultipartMaxMemory.
+	MultipartMaxMemory int64
+	// RequireJSONContentType rejects non-empty bodies that do not declare
+	// a JSON Content-Type with ErrUnsupportedMediaType.
+	RequireJSONContentType bool
+	// MaxChainCacheBytes caps the estimated memory of the cached parse
+	// chains, evicting the least recently used ones. Zero means no limit.
+	// See PCManagerOpts.MaxCacheBytes.
+	MaxChainCacheBytes int64
+	// GroupByBinding executes the fields of each binding kind together,
+	// e.g. all header fields, then all json fields. See
+	// PCManagerOpts.GroupByBinding.
+	GroupByBinding bool
+	// CheckJSONTags reports fields whose json tag and bindings drifted
+	// apart in dual-use structs. See PCManagerOpts.CheckJSONTags.
+	CheckJSONTags bool
+	// UnexportedFields selects how bound unexported fields are populated,
+	// through setter methods or unsafe. They are skipped by default. See
+	// UnexportedFieldMode.
+	UnexportedFields UnexportedFieldMode
+	// Environment selects the environment variants of tags, e.g.
+	// `default.prod:"..."`. See EnvironmentParser.
+	Environment string
+	// Versions enables versioned bindings, e.g. `json.v2:"userId"`, with
+	// the version of each request read from a header or field. See
+	// VersionOpts.
+	Versions VersionOpts
+	// IPEnricher resolves the client IP of geoip bindings, which fail
+	// with ErrNoIPEnricher without one.
+	IPEnricher IPEnricher
+	// TrustedProxies are the proxies whose X-Forwarded-For header is
+	// trusted to find the client IP. See ClientIP.
+	TrustedProxies []netip.Prefix
+	// DirectBindingLimit is the number of header, query or cookie
+	// bindings a destination type may have for them to be read straight
+	// from the request, rather than from a cache of all its headers,
+	// query parameters or cookies. Defaults to DefaultDirectBindingLimit,
+	// negative values always use the cache. See
+	// PCManagerOpts.DirectBindings.
+	DirectBindingLimit int
+	// BindingCache selects the destination types parsed without the
+	// cache of the values read from each request. By default, types with
+	// a single binding skip it. See BindingCacheOpts.
+	BindingCache BindingCacheOpts
+}
+
+func NewHTTPRequestParser() *HTTPRequestParser {
+	return NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{})
+}
+
+// NewHTTPRequestParserWithOpts creates an HTTPRequestParser configured
+// with the given options.
+func NewHTTPRequestParserWithOpts(opts HTTPRequestParserOpts) *HTTPRequestParser {
+	parserOpts := _httpParserOpts
+	parserOpts.PCMOpts.MaxCacheBytes = opts.MaxChainCacheBytes
+	parserOpts.PCMOpts.GroupByBinding = opts.GroupByBinding
+	parserOpts.PCMOpts.CheckJSONTags = opts.CheckJSONTags
+	parserOpts.PCMOpts.UnexportedFields = opts.UnexportedFields
+	parserOpts.PCMOpts.DirectBindings = directBindingLimits(opts.DirectBindingLimit)
+	parserOpts.PCMOpts.tagOpts.Environment = opts.Environment
+	parserOpts.Versions = opts.Versions
+	parserOpts.BindingCache = opts.BindingCache
+
+	base := NewBaseMBParser(
+		NewHTTPBindingManagerWithOpts(opts),
+		parserOpts,
+	)
+
+	return &HTTPRequestParser{
+		BaseMBParser: base,
+	}
+}
+
+func (hp *HTTPRequestParser) Name() string {
+	return HTTPRequestParserName
+}
+
+type HTTPBindingManager struct {
+	opts        HTTPRequestParserOpts
+	verifiers   map[string]SignatureVerifier // Verifiers of the signed modifier, by name
+	verifiersMu sync.RWMutex
+
+	flagProvider   FlagProvider // Provider of flag bindings, see RegisterFlagProvider
+	flagProviderMu sync.RWMutex
+
+	rateLimiters   map[string]RateLimiter // Limiters of ratelimit bindings, by name
+	rateLimitersMu sync.RWMutex
+
+	tenantResolvers   map[string]TenantResolver // Resolvers of tenant bindings, by name
+	tenantResolversMu sync.RWMutex
+
+	schemas   map[reflect.Type]BodySchema // Schemas of request bodies, by destination type
+	schemasMu sync.RWMutex
+}
+
+func NewHTTPBindingManager() *HTTPBindingManager {
+	return NewHTTPBindingManagerWithOpts(HTTPRequestParserOpts{})
+}
+
+func NewHTTPBindingManagerWithOpts(opts HTTPRequestParserOpts) *HTTPBindingManager {
+	return &HTTPBindingManager{opts: opts}
+}
+
+func (mgr *HTTPBindingManager) BindingHandlerCached(
+	source *http.Request,
+	entry *CacheEntry[HTTPRequestOnce],
+	binding Binding,
+) BindingResult {
+
+	if entry == nil {
+		return BindingResultError(ErrBindingCacheNilEntry)
+	}
+
+	if verifier, ok := ModifierValue[string](binding, SignedBindingModifier); ok {
+		if err := mgr.verifySignature(source, entry, verifier); err != nil {
+			return BindingResultFatal(err)
+		}
+	}
+
+	if binding.Direct {
+		if result, ok := mgr.directValue(source, binding); ok {
+			return result
+		}
+	}
+
+	switch binding.Name {
+	case JsonTagBinding:
+		return mgr.JSONValue(source, entry, jsonBindingPath(binding))
+	case MultipartTagBinding:
+		return mgr.MultipartValue(source, entry, binding.Identifier)
+	case CookieTagBinding:
+		return mgr.CookieValue(source, entry, binding.Identifier)
+	case HeaderTagBinding:
+		if explodes(binding) {
+			return mgr.HeaderValues(source, binding.Identifier)
+		}
+		return mgr.HeaderValue(source, entry, binding.Identifier)
+	case QueryTagBinding:
+		if explodes(binding) {
+			return mgr.QueryValues(source, entry, binding.Identifier)
+		}
+		return mgr.QueryValue(source, entry, binding.Identifier)
+	case PathTagBinding:
+		return mgr.PathValue(source, binding.Identifier)
+	case BasicAuthTagBinding:
+		return mgr.BasicAuthValue(source, entry, binding.Identifier)
+	case TrailerTagBinding:
+		return mgr.TrailerValue(source, entry, binding.Identifier)
+	case RangeTagBinding:
+		return mgr.RangeValue(source, entry, binding)
+	case AcceptTagBinding:
+		return mgr.AcceptValue(source, binding)
+	case LocaleTagBinding:
+		return mgr.LocaleValue(source, binding)
+	case ConditionalTagBinding:
+		return conditionalValue(binding.Identifier, source.Header.Values(binding.Identifier))
+	case GeoIPTagBinding:
+		return mgr.GeoIPValue(source, entry, binding)
+	case FlagTagBinding:
+		return mgr.FlagValue(source, entry, binding)
+	case RateLimitTagBinding:
+		return mgr.RateLimitValue(source, entry, binding)
+	case TenantTagBinding:
+		return mgr.TenantValue(source, entry, binding)
+	default:
+		return BindingResultError(fmt.Errorf("%w: unknown binding: %s", ErrUnallowedBindingName, binding.Name))
+	}
+}
+
+func (mgr *HTTPBindingManager) BindingHandler(
+	source *http.Request,
+	binding Binding,
+) BindingResult {
+
+	// This should be fine. We onyl allow instances of HTTBindingManager to be
+	// created by the HTTPRequestParser, which always uses the cache.
+	return BindingResultError(fmt.Errorf("%w: uncached handler not implemented for HTTPBindingManager", errors.ErrUnsupported))
+}
+
+func (mgr *HTTPBindingManager) NewCached() HTTPRequestOnce {
+	return NewHTTPRequestOnce()
+}
+
+func (mgr *HTTPBindingManager) JSONValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], key string,
+) BindingResult {
+
+	var jsonBody jsonDoc
+	var err error
+
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		data.jsonOnce.Do(func() {
+			body, readErr := mgr.readBodyOnce(source, data)
+			if readErr != nil {
+				data.jsonError = readErr
+				return
+			}
+
+			if len(body) == 0 {
+				data.jsonBody = parseJSONDoc([]byte("{}"))
+				return
+			}
+
+			if mgr.opts.RequireJSONContentType {
+				if err := checkJSONContentType(source.Header.Get("Content-Type")); err != nil {
+					data.jsonError = err
+					return
+				}
+			}
+			data.jsonBody = parseJSONDoc(body)
+		})
+		jsonBody = data.jsonBody
+		err = data.jsonError
+	})
+
+	if err != nil {
+		return BindingResultError(err)
+	}
+
+	return jsonBody.lookup(key)
+}
+
+// jsonBindingPath returns the path of a json binding, see jsonDoc. Dots in the
+// identifier separate nested keys unless they are escaped with a
+// backslash (`json:"user\\.name"`) or the binding has the literal
+// modifier (`json:"user.name,literal"`), which matches the identifier as
+// a single key.
+func jsonBindingPath(binding Binding) string {
+	if binding.Modifiers.Custom[LiteralBindingModifier] {
+		return escapeJSONPath(binding.Identifier)
+	}
+	return binding.Identifier
+}
+
+// jsonBindingKeys splits a json binding into the keys of its path,
+// honoring escapes and the literal modifier like jsonBindingPath.
+func jsonBindingKeys(binding Binding) []string {
+	if binding.Modifiers.Custom[LiteralBindingModifier] {
+		return []string{binding.Identifier}
+	}
+	return splitJSONPath(binding.Identifier)
+}
+
+// splitJSONPath splits path into its keys, at the dots that are not
+// escaped with a backslash.
+func splitJSONPath(path string) []string {
+	var (
+		keys []string
+		key  strings.Builder
+	)
+
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path):
+			i++
+			key.WriteByte(path[i])
+		case path[i] == '.':
+			keys = append(keys, key.String())
+			key.Reset()
+		default:
+			key.WriteByte(path[i])
+		}
+	}
+
+	return append(keys, key.String())
+}
+
+// readBodyOnce reads the request body into data on first use. See
+// readBody.
+func (mgr *HTTPBindingManager) readBodyOnce(source *http.Request, data *HTTPRequestOnce) ([]byte, error) {
+	data.bodyOnce.Do(func() {
+		data.body, data.bodyError = mgr.readBody(source)
+	})
+	return data.body, data.bodyError
+}
+
+// readBody reads the request body, enforcing the configured size limit,
+// and restores it so that others can read it again.
+func (mgr *HTTPBindingManager) readBody(source *http.Request) ([]byte, error) {
+	if source.Body == nil || source.ContentLength == 0 {
+		return nil, nil
+	}
+
+	limit := mgr.opts.MaxBodyBytes
+	if limit > 0 && source.ContentLength > limit {
+		return nil, fmt.Errorf(
+			"%w: content length %d exceeds limit of %d bytes",
+			ErrBodyTooLarge, source.ContentLength, limit,
+		)
+	}
+
+	var reader io.Reader = source.Body
+	if limit > 0 {
+		reader = io.LimitReader(source.Body, limit+1)
+	}
+
+	// Read body and restore it to so others can read it
+	body, err := io.ReadAll(reader)
+	source.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	if limit > 0 && int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: body exceeds limit of %d bytes", ErrBodyTooLarge, limit)
+	}
+
+	source.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// checkJSONContentType returns ErrUnsupportedMediaType unless contentType
+// is application/json or a +json structured syntax suffix type.
+func checkJSONContentType(contentType string) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrUnsupportedMediaType, contentType)
+	}
+
+	if mediaType != ContentTypeApplicationJSON && !strings.HasSuffix(mediaType, "+json") {
+		return fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType)
+	}
+
+	return nil
+}
+
+func (mgr *HTTPBindingManager) CookieValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], key string,
+) BindingResult {
+
+	var cookies map[string]*http.Cookie
+
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		data.cookiesOnce.Do(func() {
+			data.cookies = make(map[string]*http.Cookie)
+			for _, cookie := range source.Cookies() {
+				data.cookies[cookie.Name] = cookie
+			}
+		})
+		cookies = data.cookies
+	})
+
+	cookie, exists := cookies[key]
+	if !exists {
+		return BindingResultNotFound()
+	}
+
+	return BindingResultValue(cookie.Value)
+}
+
+func (mgr *HTTPBindingManager) HeaderValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], key string,
+) BindingResult {
+
+	var headers map[string]string
+
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		data.headersOnce.Do(func() {
+			data.headers = make(map[string]string)
+			for key, values := range source.Header {
+				if len(values) > 0 {
+					data.headers[key] = values[0]
+				}
+			}
+		})
+		headers = data.headers
+	})
+
+	// Canonicalize the key to lower case
+	value, exists := headers[http.CanonicalHeaderKey(key)]
+	if !exists || value == "" {
+		return BindingResultNotFound()
+	}
+
+	return BindingResultValue(value)
+}
+
+func (mgr *HTTPBindingManager) QueryValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], key string,
+) BindingResult {
+
+	if mgr.opts.QueryDecoding == QueryDecodingBracket {
+		return mgr.bracketQueryValue(source, entry, key)
+	}
+
+	var queryParams map[string][]string
+
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		data.queryOnce.Do(func() {
+			data.queryParams = source.URL.Query()
+		})
+		queryParams = data.queryParams
+	})
+
+	values, exists := queryParams[key]
+	if !exists || len(values) == 0 {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(values[0])
+}
+
+// HeaderValues returns every value of the header key, for the slice
+// fields of Multi bindings.
+func (mgr *HTTPBindingManager) HeaderValues(source *http.Request, key string) BindingResult {
+	values := source.Header.Values(key)
+	if len(values) == 0 {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(values)
+}
+
+// QueryValues returns every value of the query parameter key, in the
+// order of the query, for the slice fields of Multi bindings. With
+// QueryDecodingBracket, the elements of the array decoded for key (e.g.
+// from "tags[]=a&tags[]=b") are returned instead.
+func (mgr *HTTPBindingManager) QueryValues(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], key string,
+) BindingResult {
+
+	if mgr.opts.QueryDecoding == QueryDecodingBracket {
+		result := mgr.bracketQueryValue(source, entry, key)
+		if value, ok := result.Value.(string); ok && isJSONArray(value) {
+			values, err := splitArrayValue(value)
+			if err != nil {
+				return BindingResultError(err)
+			}
+			return BindingResultValue(values)
+		}
+		return result
+	}
+
+	var queryParams map[string][]string
+
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		data.queryOnce.Do(func() {
+			data.queryParams = source.URL.Query()
+		})
+		queryParams = data.queryParams
+	})
+
+	values, exists := queryParams[key]
+	if !exists || len(values) == 0 {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(values)
+}
+
+// bracketQueryValue resolves key against the nested document decoded from
+// bracketed query parameter names. See QueryDecodingBracket.
+func (mgr *HTTPBindingManager) bracketQueryValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], key string,
+) BindingResult {
+
+	var queryDoc jsonDoc
+	var err error
+
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		data.queryDocOnce.Do(func() {
+			data.queryDoc, data.queryDocError = decodeBracketQuery(source.URL.Query())
+		})
+		queryDoc = data.queryDoc
+		err = data.queryDocError
+	})
+
+	if err != nil {
+		return BindingResultError(err)
+	}
+
+	return queryDoc.lookup(bracketIdentifierToPath(key))
+}
+
+// TrailerValue returns the value of the trailer named key. Trailers are
+// only known once the body has been read to its end, so the body is read
+// (and kept for the other bindings) unless that already happened, e.g. by
+// a handler that streamed it to disk before parsing.
+func (mgr *HTTPBindingManager) TrailerValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], key string,
+) BindingResult {
+
+	var err error
+
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		_, err = mgr.readBodyOnce(source, data)
+	})
+
+	if err != nil {
+		return BindingResultError(err)
+	}
+
+	value := source.Trailer.Get(key)
+	if value == "" {
+		return BindingResultNotFound()
+	}
+
+	return BindingResultValue(value)
+}
+
+// RangeValue parses the Range header named by binding. The result holds
+// the header value, for Range fields, and the parts of its first range.
+func (mgr *HTTPBindingManager) RangeValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], binding Binding,
+) BindingResult {
+
+	result := mgr.HeaderValue(source, entry, binding.Identifier)
+	if !result.Found || result.Error != nil {
+		return result
+	}
+
+	limit, _ := ModifierValue[int64](binding, MaxBindingModifier)
+	parsed, err := ParseRange(result.Value.(string), limit)
+	if err != nil {
+		return BindingResultError(fmt.Errorf("header %s: %w", binding.Identifier, err))
+	}
+
+	return BindingResult{
+		Value:  result.Value,
+		Values: rangeParts(parsed),
+		Found:  true,
+	}
+}
+
+// AcceptValue returns the content negotiation header named by binding,
+// joining repeated headers. If the binding has the offers modifier, the
+// result is the offer the header prefers.
+func (mgr *HTTPBindingManager) AcceptValue(source *http.Request, binding Binding) BindingResult {
+	values := source.Header.Values(binding.Identifier)
+	if len(values) == 0 {
+		return BindingResultNotFound()
+	}
+	header := strings.Join(values, CommaDelimeter)
+
+	offers, ok := ModifierValue[[]string](binding, OffersBindingModifier)
+	if !ok {
+		return BindingResultValue(header)
+	}
+
+	list, err := ParseAccept(header)
+	if err != nil {
+		return BindingResultError(fmt.Errorf("header %s: %w", binding.Identifier, err))
+	}
+
+	offer, ok := list.Negotiate(offers...)
+	if !ok {
+		return BindingResultError(fmt.Errorf("%w: header %s: %q", ErrNotAcceptable, binding.Identifier, header))
+	}
+	return BindingResultValue(offer)
+}
+
+// BasicAuthValue decodes the basic auth credentials carried in the header
+// named key. The result holds the "username" and "password" parts.
+func (mgr *HTTPBindingManager) BasicAuthValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], key string,
+) BindingResult {
+
+	result := mgr.HeaderValue(source, entry, key)
+	if !result.Found || result.Error != nil {
+		return result
+	}
+
+	username, password, ok := parseBasicAuth(result.Value.(string))
+	if !ok {
+		return BindingResultError(fmt.Errorf("%w in header %s", ErrInvalidBasicAuth, key))
+	}
+
+	return BindingResultValues(map[string]any{
+		BasicAuthUsernamePart: username,
+		BasicAuthPasswordPart: password,
+	})
+}
+
+// parseBasicAuth parses an HTTP Basic Authentication header value.
+func parseBasicAuth(value string) (username, password string, ok bool) {
+	const prefix = "Basic "
+	if len(value) < len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(value[len(prefix):])
+	if err != nil {
+		return "", "", false
+	}
+
+	username, password, ok = strings.Cut(string(decoded), ":")
+	return username, password, ok
+}
+
+// HTTPRequestOnce holds parsed HTTP request data to avoid re-parsing
+// on subsequent accesses. It uses sync.Once to ensure that
+// parsing is only done once per request instance. This is the
+// `Cached` type used by the MBPTemplate for HTTPRequestParser.
+type HTTPRequestOnce struct {
+	body          []byte                      // Body of the request, once read
+	jsonBody      jsonDoc                     // Parsed JSON body from the request
+	queryParams   map[string][]string         // Parsed query parameters from the request
+	headers       map[string]string           // Parsed headers from the request
+	cookies       map[string]*http.Cookie     // Parsed cookies from the request
+	queryDoc      jsonDoc                     // Nested query document (QueryDecodingBracket only)
+	signatures    map[string]error            // Results of the signature verifiers run, by name
+	ipInfo        IPInfo                      // Enriched client IP (geoip bindings)
+	flags         map[string]flagEvaluation   // Evaluated feature flags, by key
+	rateLimits    map[string]rateLimitTake    // Rate limits taken, by limiter and key
+	tenants       map[string]tenantResolution // Resolved tenants, by resolver
+	multipartForm *multipart.Form             // Parsed multipart/form-data body
+
+	bodyOnce      sync.Once // Ensures the body is read only once
+	jsonOnce      sync.Once // Ensures the JSON body is parsed only once
+	queryOnce     sync.Once // Ensures query parameters are parsed only once
+	headersOnce   sync.Once // Ensures headers are parsed only once
+	cookiesOnce   sync.Once // Ensures cookies are parsed only once
+	queryDocOnce  sync.Once // Ensures the nested query document is decoded only once
+	ipInfoOnce    sync.Once // Ensures the client IP is enriched only once
+	multipartOnce sync.Once // Ensures the multipart body is parsed only once
+
+	bodyError      error // Error encountered while reading the request body
+	jsonError      error // Error encountered while reading or checking the JSON body
+	queryDocError  error // Error encountered while decoding bracketed query keys
+	ipInfoError    error // Error encountered while enriching the client IP
+	multipartError error // Error encountered while parsing the multipart body
+}
+
+func NewHTTPRequestOnce() HTTPRequestOnce {
+	return HTTPRequestOnce{
+		queryParams: make(map[string][]string),
+		headers:     make(map[string]string),
+		cookies:     make(map[string]*http.Cookie),
+	}
+}
@@ -1,9 +1,11 @@
-package pave
+package parser
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -12,6 +14,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Test struct for various HTTP parsing scenarios
@@ -40,7 +43,7 @@ type BenchStruct struct {
 }
 
 // createTestRequest creates an HTTP request with all types of data for testing
-func createTestRequest() *http.Request {
+func createTestRequest(t testing.TB) *http.Request {
 	// JSON body
 	jsonBody := `{
 		"id": "user123",
@@ -50,7 +53,7 @@ func createTestRequest() *http.Request {
 		"optional": "present"
 	}`
 
-	req, _ := http.NewRequest("POST", "http://example.com/api?page=1&limit=20",
+	req := newTestRequest(t, "POST", "http://example.com/api?page=1&limit=20",
 		bytes.NewBufferString(jsonBody))
 
 	// Add headers
@@ -65,7 +68,7 @@ func createTestRequest() *http.Request {
 	return req
 }
 
-func createBenchRequest() *http.Request {
+func createBenchRequest(t testing.TB) *http.Request {
 	// JSON body for benchmarking
 	jsonBody := `{
 		"id": "bench123",
@@ -77,7 +80,7 @@ func createBenchRequest() *http.Request {
 	query.Set("page", "100")
 	query.Set("size", "50")
 
-	req, _ := http.NewRequest(
+	req := newTestRequest(t,
 		"POST",
 		"http://example.com/api?"+query.Encode(),
 		bytes.NewBufferString(jsonBody),
@@ -90,7 +93,7 @@ func createBenchRequest() *http.Request {
 	return req
 }
 
-func createBenchRequestRandomized() *http.Request {
+func createBenchRequestRandomized(t testing.TB) *http.Request {
 
 	benchId := fmt.Sprintf("bench%d", time.Now().UnixNano())
 	benchName := fmt.Sprintf("Benchmark User %d", time.Now().UnixNano()%1000)
@@ -111,7 +114,7 @@ func createBenchRequestRandomized() *http.Request {
 	query.Set("page", fmt.Sprintf("%d", benchPage))
 	query.Set("size", fmt.Sprintf("%d", benchSize))
 
-	req, _ := http.NewRequest(
+	req := newTestRequest(t,
 		"POST",
 		"http://example.com/api?"+query.Encode(),
 		bytes.NewBufferString(jsonBody),
@@ -126,7 +129,7 @@ func createBenchRequestRandomized() *http.Request {
 
 func TestHTTPRequestParser_JSONParsing(t *testing.T) {
 	parser := NewHTTPRequestParser()
-	req := createTestRequest()
+	req := createTestRequest(t)
 
 	var result TestStruct
 	err := parser.Parse(req, &result)
@@ -139,9 +142,37 @@ func TestHTTPRequestParser_JSONParsing(t *testing.T) {
 	assert.Equal(t, "present", result.OptionalVal)
 }
 
+func TestHTTPRequestParser_JSONIntegralNumbers(t *testing.T) {
+	parser := NewHTTPRequestParser()
+
+	type Numbers struct {
+		Age   int    `json:"age"`
+		Count uint8  `json:"count"`
+		ID    uint64 `json:"id"`
+	}
+
+	newRequest := func(t *testing.T, body string) *http.Request {
+		req := newTestRequest(t, "POST", "http://example.com/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	var result Numbers
+	err := parser.Parse(newRequest(t, `{"age": 30.0, "count": 1e2, "id": 12345678901234567890}`), &result)
+	require.NoError(t, err)
+	assert.Equal(t, 30, result.Age)
+	assert.Equal(t, uint8(100), result.Count)
+	assert.Equal(t, uint64(12345678901234567890), result.ID, "integers keep their precision")
+
+	err = parser.Parse(newRequest(t, `{"age": 30.5, "count": 1e2, "id": 1}`), &Numbers{})
+	assert.Error(t, err)
+	err = parser.Parse(newRequest(t, `{"age": 30, "count": 1e3, "id": 1}`), &Numbers{})
+	assert.ErrorIs(t, err, ErrValueOverflow)
+}
+
 func TestHTTPRequestParser_HeaderParsing(t *testing.T) {
 	parser := NewHTTPRequestParser()
-	req := createTestRequest()
+	req := createTestRequest(t)
 
 	var result TestStruct
 	err := parser.Parse(req, &result)
@@ -153,7 +184,7 @@ func TestHTTPRequestParser_HeaderParsing(t *testing.T) {
 
 func TestHTTPRequestParser_CookieParsing(t *testing.T) {
 	parser := NewHTTPRequestParser()
-	req := createTestRequest()
+	req := createTestRequest(t)
 
 	var result TestStruct
 	err := parser.Parse(req, &result)
@@ -164,7 +195,7 @@ func TestHTTPRequestParser_CookieParsing(t *testing.T) {
 
 func TestHTTPRequestParser_QueryParsing(t *testing.T) {
 	parser := NewHTTPRequestParser()
-	req := createTestRequest()
+	req := createTestRequest(t)
 
 	var result TestStruct
 	err := parser.Parse(req, &result)
@@ -182,7 +213,7 @@ func TestHTTPRequestParser_EmptyJSONBody(t *testing.T) {
 	}
 
 	// Test with empty body
-	req, _ := http.NewRequest("POST", "http://example.com/", nil)
+	req := newTestRequest(t, "POST", "http://example.com/", nil)
 	var result EmptyStruct
 	err := parser.Parse(req, &result)
 
@@ -198,7 +229,7 @@ func TestHTTPRequestParser_InvalidJSON(t *testing.T) {
 	}
 
 	// Test with invalid JSON
-	req, _ := http.NewRequest("POST", "http://example.com/",
+	req := newTestRequest(t, "POST", "http://example.com/",
 		bytes.NewBufferString("{invalid json"))
 
 	var result JSONStruct
@@ -216,7 +247,7 @@ func TestHTTPRequestParser_MissingRequiredField(t *testing.T) {
 	}
 
 	// Test with missing required field
-	req, _ := http.NewRequest("POST", "http://example.com/",
+	req := newTestRequest(t, "POST", "http://example.com/",
 		bytes.NewBufferString(`{"other": "value"}`))
 
 	var result RequiredStruct
@@ -235,7 +266,7 @@ func TestHTTPRequestParser_OmitEmptyModifier(t *testing.T) {
 	}
 
 	// Test with empty values
-	req, _ := http.NewRequest("POST", "http://example.com/",
+	req := newTestRequest(t, "POST", "http://example.com/",
 		bytes.NewBufferString(`{"name": "", "email": ""}`))
 
 	var result OmitEmptyStruct
@@ -255,7 +286,7 @@ func TestHTTPRequestParser_OmitNilModifier(t *testing.T) {
 	}
 
 	// Test with null value in JSON
-	req, _ := http.NewRequest("POST", "http://example.com/",
+	req := newTestRequest(t, "POST", "http://example.com/",
 		bytes.NewBufferString(`{"optional": null}`))
 
 	var result OmitNilStruct
@@ -274,7 +305,7 @@ func TestHTTPRequestParser_MultipleHeaders(t *testing.T) {
 		Custom      string `header:"X-Custom-Header"`
 	}
 
-	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+	req := newTestRequest(t, "GET", "http://example.com/", nil)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Custom-Header", "custom-value")
@@ -297,7 +328,7 @@ func TestHTTPRequestParser_MultipleCookies(t *testing.T) {
 		TrackingID  string `cookie:"tracking"`
 	}
 
-	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+	req := newTestRequest(t, "GET", "http://example.com/", nil)
 	req.AddCookie(&http.Cookie{Name: "session", Value: "sess123"})
 	req.AddCookie(&http.Cookie{Name: "prefs", Value: "dark-theme"})
 	req.AddCookie(&http.Cookie{Name: "tracking", Value: "track456"})
@@ -328,7 +359,7 @@ func TestHTTPRequestParser_ComplexQueryParams(t *testing.T) {
 	queryStr.Set("tags", "golang,testing")
 	queryStr.Set("filter", "active")
 
-	req, _ := http.NewRequest("GET", "http://example.com/?"+queryStr.Encode(), nil)
+	req := newTestRequest(t, "GET", "http://example.com/?"+queryStr.Encode(), nil)
 
 	var result QueryStruct
 	err := parser.Parse(req, &result)
@@ -361,7 +392,7 @@ func TestHTTPRequestParser_NestedJSONStructure(t *testing.T) {
 		}
 	}`
 
-	req, _ := http.NewRequest("POST", "http://example.com/",
+	req := newTestRequest(t, "POST", "http://example.com/",
 		bytes.NewBufferString(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -375,6 +406,100 @@ func TestHTTPRequestParser_NestedJSONStructure(t *testing.T) {
 	assert.Equal(t, 12345, result.CompanyID)
 }
 
+func TestHTTPRequestParser_JSONKeysWithDots(t *testing.T) {
+	type DottedKeys struct {
+		Escaped  string `json:"user\\.name"`
+		Literal  string `json:"user.email,literal"`
+		Nested   string `json:"user.name"`
+		Wildcard string `json:"a*b,literal"`
+	}
+
+	jsonBody := `{
+		"user.name": "flat name",
+		"user.email": "flat@example.com",
+		"user": {"name": "nested name"},
+		"a*b": "star",
+		"axb": "not star"
+	}`
+
+	req := newTestRequest(t, "POST", "http://example.com/", bytes.NewBufferString(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	var result DottedKeys
+	err := NewHTTPRequestParser().Parse(req, &result)
+
+	require.NoError(t, err)
+	assert.Equal(t, "flat name", result.Escaped)
+	assert.Equal(t, "flat@example.com", result.Literal)
+	assert.Equal(t, "nested name", result.Nested)
+	assert.Equal(t, "star", result.Wildcard)
+
+	t.Run("EncodeRoundTrip", func(t *testing.T) {
+		body, err := EncodeJSON(result)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"user.name": "flat name",
+			"user.email": "flat@example.com",
+			"user": {"name": "nested name"},
+			"a*b": "star"
+		}`, string(body))
+	})
+}
+
+func TestHTTPRequestParser_FixedArrays(t *testing.T) {
+	type Arrays struct {
+		RGB    [3]uint8   `json:"rgb"`
+		Coords [2]float64 `json:"coords"`
+		Tags   [2]string  `json:"tags"`
+		Range  [2]int     `query:"range"`
+	}
+
+	jsonBody := `{"rgb": [255, 128, 0], "coords": [52.52, 13.405], "tags": ["a", "b"]}`
+
+	req := newTestRequest(t, "POST", "http://example.com/?range=10,20", bytes.NewBufferString(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	var result Arrays
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &result))
+	assert.Equal(t, [3]uint8{255, 128, 0}, result.RGB)
+	assert.Equal(t, [2]float64{52.52, 13.405}, result.Coords)
+	assert.Equal(t, [2]string{"a", "b"}, result.Tags)
+	assert.Equal(t, [2]int{10, 20}, result.Range)
+
+	query, err := EncodeQuery(result)
+	require.NoError(t, err)
+	assert.Equal(t, "10,20", query.Get("range"))
+
+	req = newTestRequest(t, "POST", "http://example.com/?range=10,20,30", bytes.NewBufferString(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	err = NewHTTPRequestParser().Parse(req, &Arrays{})
+	assert.ErrorIs(t, err, ErrArrayLength)
+	assert.Equal(t, "Range", FieldPath(err))
+}
+
+func TestJSONBindingKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		binding Binding
+		want    []string
+	}{
+		{"plain", Binding{Identifier: "name"}, []string{"name"}},
+		{"nested", Binding{Identifier: "user.name"}, []string{"user", "name"}},
+		{"escaped", Binding{Identifier: `user\.name`}, []string{"user.name"}},
+		{"escaped_nested", Binding{Identifier: `a\.b.c`}, []string{"a.b", "c"}},
+		{"literal", Binding{
+			Identifier: "user.name",
+			Modifiers:  BindingModifiers{Custom: map[string]bool{LiteralBindingModifier: true}},
+		}, []string{"user.name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, jsonBindingKeys(tt.binding))
+		})
+	}
+}
+
 // Test tag grammar edge cases
 func TestHTTPRequestParser_InvalidTagBinding(t *testing.T) {
 	parser := NewHTTPRequestParser()
@@ -383,7 +508,7 @@ func TestHTTPRequestParser_InvalidTagBinding(t *testing.T) {
 		Value string `invalidbinding:"test"`
 	}
 
-	req := createTestRequest()
+	req := createTestRequest(t)
 	var result InvalidStruct
 	err := parser.Parse(req, &result)
 
@@ -402,7 +527,7 @@ func TestHTTPRequestParser_UnexpectedModifiers(t *testing.T) {
 		Email string `json:"email,anothercustom,omitempty"`
 	}
 
-	req := createTestRequest()
+	req := createTestRequest(t)
 	var result ModifierStruct
 	err := parser.Parse(req, &result)
 
@@ -421,7 +546,7 @@ func TestHTTPRequestParser_EmptyIdentifiers(t *testing.T) {
 		JSON   string `json:""`
 	}
 
-	req := createTestRequest()
+	req := createTestRequest(t)
 	var result EmptyIdentifierStruct
 	err := parser.Parse(req, &result)
 
@@ -432,7 +557,7 @@ func TestHTTPRequestParser_EmptyIdentifiers(t *testing.T) {
 
 func TestHTTPRequestParser_CacheConsistency(t *testing.T) {
 	parser := NewHTTPRequestParser()
-	req := createTestRequest()
+	req := createTestRequest(t)
 
 	// Parse multiple times with same request
 	var result1, result2, result3 TestStruct
@@ -453,7 +578,7 @@ func TestHTTPRequestParser_CacheConsistency(t *testing.T) {
 // Benchmark tests
 func BenchmarkHTTPRequestParser_CachedParsing(b *testing.B) {
 	parser := NewHTTPRequestParser()
-	req := createBenchRequest()
+	req := createBenchRequest(b)
 
 	// First parse to warm up cache
 	var warmup BenchStruct
@@ -477,7 +602,7 @@ func TestHTTPRequestParser_CachedParsingSpeed(t *testing.T) {
 	iter_count := 100000
 	for i := 0; i < iter_count; i++ {
 		var result BenchStruct
-		req := createBenchRequest()
+		req := createBenchRequest(t)
 		start := time.Now()
 		err := parser.Parse(req, &result)
 		if err != nil {
@@ -507,7 +632,7 @@ func BenchmarkHTTPRequestParser_JSONParsing(b *testing.B) {
 		"age": 25
 	}`
 
-	req, _ := http.NewRequest("POST", "http://example.com/",
+	req := newTestRequest(b, "POST", "http://example.com/",
 		bytes.NewBufferString(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -532,7 +657,7 @@ func BenchmarkHTTPRequestParser_HeaderParsing(b *testing.B) {
 		Host      string `header:"Host"`
 	}
 
-	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+	req := newTestRequest(b, "GET", "http://example.com/", nil)
 	req.Header.Set("Authorization", "Bearer token123")
 	req.Header.Set("User-Agent", "TestAgent/1.0")
 	req.Header.Set("Accept", "application/json")
@@ -557,7 +682,7 @@ func BenchmarkHTTPRequestParser_CookieParsing(b *testing.B) {
 		Theme     string `cookie:"theme"`
 	}
 
-	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+	req := newTestRequest(b, "GET", "http://example.com/", nil)
 	req.AddCookie(&http.Cookie{Name: "session_id", Value: "sess123"})
 	req.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
 
@@ -585,7 +710,7 @@ func BenchmarkHTTPRequestParser_QueryParsing(b *testing.B) {
 	queryStr.Set("limit", "20")
 	queryStr.Set("sort", "asc")
 
-	req, _ := http.NewRequest("GET", "http://example.com/?"+queryStr.Encode(), nil)
+	req := newTestRequest(b, "GET", "http://example.com/?"+queryStr.Encode(), nil)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -600,7 +725,7 @@ func BenchmarkHTTPRequestParser_QueryParsing(b *testing.B) {
 
 func BenchmarkHTTPRequestParser_ComplexParsing(b *testing.B) {
 	parser := NewHTTPRequestParser()
-	req := createTestRequest()
+	req := createTestRequest(b)
 	var result TestStruct
 
 	b.ResetTimer()
@@ -634,7 +759,7 @@ func TestHTTPRequestParser_LargeJSONBody(t *testing.T) {
 	largeData := strings.Repeat("x", 10000)
 	jsonBody := fmt.Sprintf(`{"data": "%s"}`, largeData)
 
-	req, _ := http.NewRequest("POST", "http://example.com/",
+	req := newTestRequest(t, "POST", "http://example.com/",
 		bytes.NewBufferString(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -661,7 +786,7 @@ func TestHTTPRequestParser_LargeJSONBody(t *testing.T) {
 // 	queryStr := url.Values{}
 // 	queryStr.Set("special", specialValue)
 
-// 	req, _ := http.NewRequest("POST", "http://example.com/?"+queryStr.Encode(),
+// 	req := newTestRequest(t, "POST", "http://example.com/?"+queryStr.Encode(),
 // 		bytes.NewBufferString(jsonBody))
 // 	req.Header.Set("X-Special", specialValue)
 // 	req.AddCookie(&http.Cookie{Name: "special", Value: specialValue})
@@ -685,7 +810,7 @@ func TestHTTPRequestParser_BenchmarkParseChainCache(t *testing.T) {
 	// Test time with cache disabled (just create a new parser each time)
 	for i := 0; i < iterCount; i++ {
 		parser := NewHTTPRequestParser()
-		req := createBenchRequest()
+		req := createBenchRequest(t)
 
 		t1 := time.Now()
 		var result BenchStruct
@@ -696,7 +821,7 @@ func TestHTTPRequestParser_BenchmarkParseChainCache(t *testing.T) {
 
 	// Test time with cache enabled
 	parser := NewHTTPRequestParser()
-	req := createBenchRequest()
+	req := createBenchRequest(t)
 	for i := 0; i < iterCount; i++ {
 		var result BenchStruct
 		t1 := time.Now()
@@ -828,18 +953,19 @@ func BenchmarkHTTPRequestParser_NewParseChain(b *testing.B) {
 // Test for uncovered HTTP parser methods
 func TestHTTPBindingManager_BindingHandler_NotImplemented(t *testing.T) {
 	mgr := NewHTTPBindingManager()
-	req := createTestRequest()
+	req := createTestRequest(t)
 	binding := Binding{Name: JsonTagBinding, Identifier: "name"}
 
 	result := mgr.BindingHandler(req, binding)
 	assert.False(t, result.Found)
 	assert.Error(t, result.Error)
 	assert.Contains(t, result.Error.Error(), "uncached handler not implemented")
+	assert.ErrorIs(t, result.Error, errors.ErrUnsupported)
 }
 
 func TestHTTPBindingManager_BindingHandlerCached_NilEntry(t *testing.T) {
 	mgr := NewHTTPBindingManager()
-	req := createTestRequest()
+	req := createTestRequest(t)
 	binding := Binding{Name: JsonTagBinding, Identifier: "name"}
 
 	result := mgr.BindingHandlerCached(req, nil, binding)
@@ -850,7 +976,7 @@ func TestHTTPBindingManager_BindingHandlerCached_NilEntry(t *testing.T) {
 
 func TestHTTPBindingManager_BindingHandlerCached_UnknownBinding(t *testing.T) {
 	mgr := NewHTTPBindingManager()
-	req := createTestRequest()
+	req := createTestRequest(t)
 	binding := Binding{Name: "unknown", Identifier: "name"}
 
 	cache := NewBindingCache[http.Request, HTTPRequestOnce]()
@@ -862,11 +988,12 @@ func TestHTTPBindingManager_BindingHandlerCached_UnknownBinding(t *testing.T) {
 	assert.False(t, result.Found)
 	assert.Error(t, result.Error)
 	assert.Contains(t, result.Error.Error(), "unknown binding")
+	assert.ErrorIs(t, result.Error, ErrUnallowedBindingName)
 }
 
 func TestHTTPBindingManager_JSONValue_EmptyBody(t *testing.T) {
 	mgr := NewHTTPBindingManager()
-	req, _ := http.NewRequest("POST", "/test", bytes.NewReader([]byte{}))
+	req := newTestRequest(t, "POST", "/test", bytes.NewReader([]byte{}))
 	req.Header.Set("Content-Type", "application/json")
 
 	cache := NewBindingCache[http.Request, HTTPRequestOnce]()
@@ -881,7 +1008,7 @@ func TestHTTPBindingManager_JSONValue_EmptyBody(t *testing.T) {
 
 func TestHTTPBindingManager_JSONValue_NilBody(t *testing.T) {
 	mgr := NewHTTPBindingManager()
-	req, _ := http.NewRequest("POST", "/test", nil)
+	req := newTestRequest(t, "POST", "/test", nil)
 	req.Header.Set("Content-Type", "application/json")
 
 	cache := NewBindingCache[http.Request, HTTPRequestOnce]()
@@ -896,7 +1023,7 @@ func TestHTTPBindingManager_JSONValue_NilBody(t *testing.T) {
 
 func TestHTTPBindingManager_CookieValue_NotFound(t *testing.T) {
 	mgr := NewHTTPBindingManager()
-	req, _ := http.NewRequest("GET", "/test", nil)
+	req := newTestRequest(t, "GET", "/test", nil)
 
 	cache := NewBindingCache[http.Request, HTTPRequestOnce]()
 	entry := cache.GetOrCreate(req, func() HTTPRequestOnce {
@@ -910,7 +1037,7 @@ func TestHTTPBindingManager_CookieValue_NotFound(t *testing.T) {
 
 func TestHTTPBindingManager_HeaderValue_NotFound(t *testing.T) {
 	mgr := NewHTTPBindingManager()
-	req, _ := http.NewRequest("GET", "/test", nil)
+	req := newTestRequest(t, "GET", "/test", nil)
 
 	cache := NewBindingCache[http.Request, HTTPRequestOnce]()
 	entry := cache.GetOrCreate(req, func() HTTPRequestOnce {
@@ -924,7 +1051,7 @@ func TestHTTPBindingManager_HeaderValue_NotFound(t *testing.T) {
 
 func TestHTTPBindingManager_QueryValue_NotFound(t *testing.T) {
 	mgr := NewHTTPBindingManager()
-	req, _ := http.NewRequest("GET", "/test", nil)
+	req := newTestRequest(t, "GET", "/test", nil)
 
 	cache := NewBindingCache[http.Request, HTTPRequestOnce]()
 	entry := cache.GetOrCreate(req, func() HTTPRequestOnce {
@@ -935,3 +1062,442 @@ func TestHTTPBindingManager_QueryValue_NotFound(t *testing.T) {
 	assert.False(t, result.Found)
 	assert.Nil(t, result.Error)
 }
+
+func TestHTTPRequestParser_BasicAuthParts(t *testing.T) {
+	parser := NewHTTPRequestParser()
+
+	type AuthStruct struct {
+		Username string `basicauth:"Authorization" part:"username"`
+		Password string `basicauth:"Authorization" part:"password"`
+		Realm    string `basicauth:"Authorization,omitempty" part:"realm" default:"api"`
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/", nil)
+		req.SetBasicAuth("alice", "s3cr:et")
+
+		var result AuthStruct
+		err := parser.Parse(req, &result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", result.Username)
+		assert.Equal(t, "s3cr:et", result.Password)
+		assert.Equal(t, "api", result.Realm)
+	})
+
+	t.Run("NotBasicScheme", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/", nil)
+		req.Header.Set("Authorization", "Bearer token123")
+
+		var result AuthStruct
+		err := parser.Parse(req, &result)
+
+		assert.ErrorIs(t, err, ErrInvalidBasicAuth)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/", nil)
+
+		var result AuthStruct
+		err := parser.Parse(req, &result)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "required field Authorization not found")
+	})
+}
+
+func TestHTTPRequestParser_BodyLimits(t *testing.T) {
+	type Body struct {
+		Name string `json:"name"`
+	}
+
+	t.Run("MaxBodyBytes_ContentLength", func(t *testing.T) {
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{MaxBodyBytes: 8})
+		req := newTestRequest(t, "POST", "http://example.com/", bytes.NewBufferString(`{"name": "far too long"}`))
+
+		var result Body
+		err := parser.Parse(req, &result)
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+
+	t.Run("MaxBodyBytes_UnknownLength", func(t *testing.T) {
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{MaxBodyBytes: 8})
+		req := newTestRequest(t, "POST", "http://example.com/", strings.NewReader(`{"name": "far too long"}`))
+		req.ContentLength = -1
+
+		var result Body
+		err := parser.Parse(req, &result)
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+
+	t.Run("MaxBodyBytes_WithinLimit", func(t *testing.T) {
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{MaxBodyBytes: 64})
+		req := newTestRequest(t, "POST", "http://example.com/", bytes.NewBufferString(`{"name": "ok"}`))
+
+		var result Body
+		err := parser.Parse(req, &result)
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", result.Name)
+	})
+
+	t.Run("RequireJSONContentType", func(t *testing.T) {
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{RequireJSONContentType: true})
+
+		for contentType, wantErr := range map[string]bool{
+			"application/json":                  false,
+			"application/json; charset=UTF-8":   false,
+			"application/problem+json":          false,
+			"text/plain":                        true,
+			"application/x-www-form-urlencoded": true,
+			"":                                  true,
+		} {
+			req := newTestRequest(t, "POST", "http://example.com/", bytes.NewBufferString(`{"name": "ok"}`))
+			req.Header.Set("Content-Type", contentType)
+
+			var result Body
+			err := parser.Parse(req, &result)
+			if wantErr {
+				assert.ErrorIs(t, err, ErrUnsupportedMediaType, contentType)
+			} else {
+				assert.NoError(t, err, contentType)
+			}
+		}
+	})
+
+	t.Run("RequiredFieldError", func(t *testing.T) {
+		req := newTestRequest(t, "POST", "http://example.com/", bytes.NewBufferString(`{}`))
+
+		var result Body
+		err := NewHTTPRequestParser().Parse(req, &result)
+
+		var rfe *RequiredFieldError
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+		if assert.ErrorAs(t, err, &rfe) {
+			assert.Equal(t, "name", rfe.Identifier)
+			assert.Equal(t, JsonTagBinding, rfe.Binding)
+		}
+	})
+}
+
+func TestHTTPRequestParser_Trailers(t *testing.T) {
+	const raw = "POST /upload HTTP/1.1\r\n" +
+		"Host: example.com\r\n" +
+		"Content-Type: application/json\r\n" +
+		"Transfer-Encoding: chunked\r\n" +
+		"Trailer: X-Checksum\r\n" +
+		"\r\n" +
+		"10\r\n{\"name\": \"jane\"}\r\n" +
+		"0\r\n" +
+		"X-Checksum: sha256=abc\r\n" +
+		"\r\n"
+
+	newRequest := func(t *testing.T) *http.Request {
+		req, err := http.ReadRequest(bufio.NewReader(strings.NewReader(raw)))
+		require.NoError(t, err)
+		return req
+	}
+
+	t.Run("ReadsBody", func(t *testing.T) {
+		type Upload struct {
+			Checksum string `trailer:"X-Checksum"`
+			Name     string `json:"name"`
+		}
+
+		req := newRequest(t)
+
+		var upload Upload
+		require.NoError(t, NewHTTPRequestParser().Parse(req, &upload))
+		assert.Equal(t, Upload{Checksum: "sha256=abc", Name: "jane"}, upload)
+
+		// The body is kept for the handler
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name": "jane"}`, string(body))
+	})
+
+	t.Run("StreamedBody", func(t *testing.T) {
+		type Upload struct {
+			Checksum string `trailer:"x-checksum"`
+			Missing  string `trailer:"X-Signature,omitempty" default:"none"`
+		}
+
+		req := newRequest(t)
+
+		var streamed bytes.Buffer
+		_, err := io.Copy(&streamed, req.Body)
+		require.NoError(t, err)
+
+		var upload Upload
+		require.NoError(t, NewHTTPRequestParser().Parse(req, &upload))
+		assert.Equal(t, Upload{Checksum: "sha256=abc", Missing: "none"}, upload)
+		assert.Equal(t, `{"name": "jane"}`, streamed.String())
+	})
+}
+
+func TestHTTPRequestParser_NestedPointers(t *testing.T) {
+	type Leaf struct {
+		Value string `query:"value"`
+		Flag  bool   `query:"flag,omitempty" default:"false"`
+	}
+	type Middle struct {
+		Leaf  *Leaf `query:"leaf_"`
+		Count int   `query:"count"`
+	}
+	type Node struct {
+		Name   string `query:"name"`
+		Parent *Node  // points back to Node: never allocated
+	}
+	type Root struct {
+		Middle  *Middle
+		Value   Middle `query:"value_"`
+		Node    *Node
+		Skipped *Leaf `recursive:"false"`
+		Untyped *struct{ NoTags string }
+	}
+
+	req := newTestRequest(t, "GET",
+		"http://example.com/?leaf_value=deep&count=3&value_leaf_value=other&value_count=4&name=n", nil)
+
+	var result Root
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &result))
+
+	require.NotNil(t, result.Middle)
+	require.NotNil(t, result.Middle.Leaf)
+	assert.Equal(t, "deep", result.Middle.Leaf.Value)
+	assert.Equal(t, 3, result.Middle.Count)
+
+	require.NotNil(t, result.Value.Leaf)
+	assert.Equal(t, "other", result.Value.Leaf.Value)
+	assert.Equal(t, 4, result.Value.Count)
+
+	require.NotNil(t, result.Node)
+	assert.Equal(t, "n", result.Node.Name)
+	assert.Nil(t, result.Node.Parent)
+	assert.Nil(t, result.Skipped)
+	assert.Nil(t, result.Untyped)
+
+	t.Run("ExistingAllocationsReused", func(t *testing.T) {
+		leaf := &Leaf{Value: "old"}
+		existing := Root{Middle: &Middle{Leaf: leaf}}
+		require.NoError(t, NewHTTPRequestParser().Parse(req, &existing))
+		assert.Same(t, leaf, existing.Middle.Leaf)
+		assert.Equal(t, "deep", leaf.Value)
+	})
+
+	t.Run("FieldPath", func(t *testing.T) {
+		req := newTestRequest(t, "GET",
+			"http://example.com/?leaf_value=deep&count=x&value_leaf_value=other&value_count=4&name=n", nil)
+		err := NewHTTPRequestParser().Parse(req, &Root{})
+		assert.Equal(t, "Middle.Count", FieldPath(err))
+	})
+
+	t.Run("EncodeRoundTrip", func(t *testing.T) {
+		query, err := EncodeQuery(result)
+		require.NoError(t, err)
+		assert.Equal(t, "deep", query.Get("leaf_value"))
+		assert.Equal(t, "other", query.Get("value_leaf_value"))
+	})
+}
+
+func TestHTTPRequestParser_OneShotStructs(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+	type Filter struct {
+		Status string   `json:"status"`
+		Tags   []string `json:"tags"`
+	}
+	type Request struct {
+		Address Address  `json:"address" recursive:"false"`
+		Billing *Address `json:"billing,omitempty" recursive:"false" default:""`
+		Filter  Filter   `query:"filter" recursive:"false"`
+	}
+
+	newRequest := func(t *testing.T, body string) *http.Request {
+		query := url.Values{"filter": {`{"status":"open","tags":["a","b"]}`}}
+		req := newTestRequest(t, "POST", "http://example.com/?"+query.Encode(), strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	var request Request
+	require.NoError(t, NewHTTPRequestParser().Parse(newRequest(t, `{"address":{"city":"Oslo","zip":"0150"}}`), &request))
+	assert.Equal(t, Address{City: "Oslo", Zip: "0150"}, request.Address, "sub-documents are unmarshaled with their json tags")
+	assert.Nil(t, request.Billing)
+	assert.Equal(t, Filter{Status: "open", Tags: []string{"a", "b"}}, request.Filter)
+
+	err := NewHTTPRequestParser().Parse(newRequest(t, `{"address":"Oslo"}`), &Request{})
+	assert.ErrorIs(t, err, ErrInvalidSubDocument)
+
+	t.Run("Encode", func(t *testing.T) {
+		query, err := EncodeQuery(&struct {
+			Filter Filter `query:"filter" recursive:"false"`
+		}{Filter: request.Filter})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"status":"open","tags":["a","b"]}`, query.Get("filter"))
+	})
+
+	t.Run("GoValues", func(t *testing.T) {
+		pcm := NewPCManager(func(source *map[string]any, binding Binding) BindingResult {
+			return BindingResultValue((*source)[binding.Identifier])
+		}, PCManagerOpts{tagOpts: ParseTagOpts{
+			BindingOpts: BindingOpts{AllowedBindingNames: []string{"key"}},
+		}})
+
+		type Config struct {
+			Address Address `key:"address" recursive:"false"`
+		}
+		chain, err := pcm.GetParseChain(reflect.TypeFor[Config]())
+		require.NoError(t, err)
+
+		source := map[string]any{"address": map[string]any{"city": "Oslo", "zip": "0150"}}
+		var config Config
+		require.NoError(t, chain.Execute(&source, &config))
+		assert.Equal(t, Address{City: "Oslo", Zip: "0150"}, config.Address)
+	})
+}
+
+func TestHTTPRequestParser_ScopedJSON(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+		Geo  struct {
+			Lat float64 `json:"lat"`
+		} `json:"geo,scope"`
+	}
+	type Request struct {
+		Name     string   `json:"name"`
+		Shipping Address  `json:"shipping,scope" recursive:"true"`
+		Billing  *Address `json:"billing,scope"`
+		Contact  struct {
+			Email string `json:"contact.email"`
+		}
+	}
+
+	body := `{
+		"name": "Ann",
+		"shipping": {"city": "Oslo", "geo": {"lat": 59.9}},
+		"billing": {"city": "Bergen", "geo": {"lat": 60.4}},
+		"contact": {"email": "ann@example.com"}
+	}`
+	req := newTestRequest(t, "POST", "http://example.com/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	var request Request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &request))
+	assert.Equal(t, "Ann", request.Name)
+	assert.Equal(t, "Oslo", request.Shipping.City, "identifiers are relative to the subtree of the struct")
+	assert.Equal(t, 59.9, request.Shipping.Geo.Lat)
+	require.NotNil(t, request.Billing)
+	assert.Equal(t, "Bergen", request.Billing.City)
+	assert.Equal(t, 60.4, request.Billing.Geo.Lat)
+	assert.Equal(t, "ann@example.com", request.Contact.Email, "untagged structs do not scope")
+
+	t.Run("EncodeRoundTrip", func(t *testing.T) {
+		encoded, err := EncodeJSON(request)
+		require.NoError(t, err)
+		assert.JSONEq(t, body, string(encoded))
+	})
+
+	t.Run("Unscoped", func(t *testing.T) {
+		type Address struct {
+			City string `json:"address.city"`
+		}
+		type Request struct {
+			Address Address `json:"address" recursive:"true"`
+		}
+
+		req := newTestRequest(t, "POST", "http://example.com/", strings.NewReader(`{"address": {"city": "Oslo"}}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		var request Request
+		require.NoError(t, NewHTTPRequestParser().Parse(req, &request))
+		assert.Equal(t, "Oslo", request.Address.City, "identifiers are paths from the root without the scope modifier")
+	})
+
+	t.Run("NotStruct", func(t *testing.T) {
+		req := newTestRequest(t, "POST", "http://example.com/", strings.NewReader(`{"name": "Ann"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		err := NewHTTPRequestParser().Parse(req, &struct {
+			Name string `json:"name,scope"`
+		}{})
+		assert.ErrorIs(t, err, ErrScopeNotStruct)
+	})
+}
+
+func TestHTTPRequestParser_Flatten(t *testing.T) {
+	type Audit struct {
+		RequestID string `header:"X-Request-ID"`
+		Actor     string `query:"actor"`
+	}
+	type Paging struct {
+		Page int `query:"page"`
+		Size int `query:"size"`
+	}
+	type Request struct {
+		Name   string `query:"name"`
+		Audit  Audit  `query:"audit_,flatten"`
+		Paging Paging `query:"paging_"`
+		Inner  struct {
+			Paging Paging `query:"inner_,flatten"`
+		} `query:"sub_"`
+	}
+
+	req := newTestRequest(t, "GET",
+		"http://example.com/?name=Ann&actor=bob&paging_page=2&paging_size=10&sub_page=3&sub_size=5", nil)
+	req.Header.Set("X-Request-ID", "r-1")
+
+	var request Request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &request))
+	assert.Equal(t, "Ann", request.Name)
+	assert.Equal(t, "bob", request.Audit.Actor, "flattened fields bind in the parent scope")
+	assert.Equal(t, "r-1", request.Audit.RequestID)
+	assert.Equal(t, 2, request.Paging.Page)
+	assert.Equal(t, 10, request.Paging.Size)
+	assert.Equal(t, 3, request.Inner.Paging.Page, "flattening keeps the scopes of ancestors")
+
+	fields, err := NewEncoder(_httpTagOpts, _httpPCMOpts.ScopeFuncs).Fields(request)
+	require.NoError(t, err)
+	identifiers := make([]string, 0, len(fields))
+	for _, field := range fields {
+		identifiers = append(identifiers, field.Binding.Identifier)
+	}
+	assert.Contains(t, identifiers, "actor")
+	assert.Contains(t, identifiers, "sub_page")
+
+	err = NewHTTPRequestParser().Parse(req, &struct {
+		Name string `query:"name,flatten"`
+	}{})
+	assert.ErrorIs(t, err, ErrFlattenNotStruct)
+}
+
+// unmarshalerID is a struct that only implements encoding.BinaryUnmarshaler
+type unmarshalerID struct {
+	Prefix string
+	Number string
+}
+
+func (id *unmarshalerID) UnmarshalBinary(data []byte) error {
+	prefix, number, ok := strings.Cut(string(data), "-")
+	if !ok {
+		return errors.New("invalid id")
+	}
+	id.Prefix, id.Number = prefix, number
+	return nil
+}
+
+func TestHTTPRequestParser_UnmarshalerStructs(t *testing.T) {
+	type Request struct {
+		ID       unmarshalerID `query:"id"`
+		Callback *url.URL      `query:"callback"`
+	}
+
+	req := newTestRequest(t, "GET", "http://example.com/?id=b-42&callback=https%3A%2F%2Fexample.org%2Fdone", nil)
+
+	var request Request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &request))
+	assert.Equal(t, unmarshalerID{Prefix: "b", Number: "42"}, request.ID, "structs that unmarshal themselves are not recursed into")
+	require.NotNil(t, request.Callback)
+	assert.Equal(t, "https://example.org/done", request.Callback.String())
+}
@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrInvalidBracketQueryKey = errors.New("invalid bracketed query key")
+	ErrBracketQueryConflict   = errors.New("conflicting bracketed query keys")
+)
+
+// QueryDecodingMode controls how the HTTPRequestParser interprets the
+// query string of a request before bindings are resolved.
+type QueryDecodingMode int
+
+const (
+	// QueryDecodingFlat resolves query bindings against the raw query
+	// parameter names. Repeated parameters yield their first value.
+	QueryDecodingFlat QueryDecodingMode = iota
+	// QueryDecodingBracket decodes PHP/Rails-style bracketed keys into a
+	// nested document before binding, so that:
+	//   - filter[status]=open        binds to query:"filter.status"
+	//   - ids[]=1&ids[]=2            binds to query:"ids" as ["1","2"]
+	//   - items[0][name]=a           binds to query:"items.0.name"
+	//
+	// Identifiers may also be written in bracket form (query:"filter[status]").
+	// Objects and arrays are handed to converters as raw JSON.
+	QueryDecodingBracket
+)
+
+// decodeBracketQuery builds a nested JSON document from bracketed query
+// parameter names.
+func decodeBracketQuery(values url.Values) (jsonDoc, error) {
+	root := make(map[string]any)
+
+	for key, vals := range values {
+		path, err := splitBracketKey(key)
+		if err != nil {
+			return jsonDoc{}, err
+		}
+
+		if err := insertBracketValue(root, path, vals, key); err != nil {
+			return jsonDoc{}, err
+		}
+	}
+
+	doc, err := json.Marshal(root)
+	if err != nil {
+		return jsonDoc{}, fmt.Errorf("error encoding decoded query: %w", err)
+	}
+
+	return parseJSONDoc(doc), nil
+}
+
+// splitBracketKey splits "a[b][c]" into ["a", "b", "c"] and "a[]" into
+// ["a", ""]. An empty segment denotes an array append.
+func splitBracketKey(key string) ([]string, error) {
+	open := strings.IndexByte(key, '[')
+	if open == -1 {
+		return []string{key}, nil
+	}
+	if open == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidBracketQueryKey, key)
+	}
+
+	path := []string{key[:open]}
+	rest := key[open:]
+
+	for len(rest) > 0 {
+		if rest[0] != '[' {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidBracketQueryKey, key)
+		}
+
+		end := strings.IndexByte(rest, ']')
+		if end == -1 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidBracketQueryKey, key)
+		}
+
+		path = append(path, rest[1:end])
+		rest = rest[end+1:]
+	}
+
+	// Only the final segment may be an array append
+	for _, segment := range path[:len(path)-1] {
+		if segment == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidBracketQueryKey, key)
+		}
+	}
+
+	return path, nil
+}
+
+// insertBracketValue places vals at path inside node, creating
+// intermediate objects as needed. A trailing empty segment appends all
+// vals to the array stored under the preceding segment.
+func insertBracketValue(node map[string]any, path []string, vals []string, key string) error {
+	parents, leaf := path[:len(path)-1], path[len(path)-1]
+
+	isAppend := leaf == ""
+	if isAppend {
+		parents, leaf = path[:len(path)-2], path[len(path)-2]
+	}
+
+	for _, segment := range parents {
+		child, exists := node[segment]
+		if !exists {
+			next := make(map[string]any)
+			node[segment] = next
+			node = next
+			continue
+		}
+
+		next, ok := child.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrBracketQueryConflict, key)
+		}
+		node = next
+	}
+
+	existing, exists := node[leaf]
+
+	if isAppend {
+		if !exists {
+			node[leaf] = append([]string{}, vals...)
+			return nil
+		}
+
+		arr, ok := existing.([]string)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrBracketQueryConflict, key)
+		}
+		node[leaf] = append(arr, vals...)
+		return nil
+	}
+
+	if exists {
+		return fmt.Errorf("%w: %s", ErrBracketQueryConflict, key)
+	}
+	if len(vals) > 0 {
+		node[leaf] = vals[0]
+	}
+
+	return nil
+}
+
+// bracketIdentifierToPath converts an identifier written in bracket form
+// ("filter[status]", "ids[]") to the dotted path used for lookups.
+func bracketIdentifierToPath(identifier string) string {
+	if strings.IndexByte(identifier, '[') == -1 {
+		return identifier
+	}
+
+	replacer := strings.NewReplacer("[]", "", "][", ".", "[", ".", "]", "")
+	return replacer.Replace(identifier)
+}
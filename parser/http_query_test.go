@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBracketKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    []string
+		wantErr bool
+	}{
+		{"plain", "page", []string{"page"}, false},
+		{"nested", "filter[status]", []string{"filter", "status"}, false},
+		{"deep", "a[b][c]", []string{"a", "b", "c"}, false},
+		{"append", "ids[]", []string{"ids", ""}, false},
+		{"nested_append", "filter[tags][]", []string{"filter", "tags", ""}, false},
+		{"leading_bracket", "[a]", nil, true},
+		{"unterminated", "a[b", nil, true},
+		{"trailing_garbage", "a[b]c", nil, true},
+		{"append_not_last", "a[][b]", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitBracketKey(tt.key)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidBracketQueryKey)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeBracketQuery(t *testing.T) {
+	t.Run("NestedAndArrays", func(t *testing.T) {
+		values, _ := url.ParseQuery("filter[status]=open&filter[owner][id]=7&ids[]=1&ids[]=2&page=3")
+
+		doc, err := decodeBracketQuery(values)
+		require.NoError(t, err)
+		assert.Equal(t, "open", doc.lookup("filter.status").Value)
+		assert.Equal(t, "7", doc.lookup("filter.owner.id").Value)
+		assert.Equal(t, `["1","2"]`, doc.lookup("ids").Value)
+		assert.Equal(t, "3", doc.lookup("page").Value)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		values, _ := url.ParseQuery("filter=open&filter[status]=closed")
+
+		_, err := decodeBracketQuery(values)
+		assert.ErrorIs(t, err, ErrBracketQueryConflict)
+	})
+}
+
+func TestBracketIdentifierToPath(t *testing.T) {
+	assert.Equal(t, "page", bracketIdentifierToPath("page"))
+	assert.Equal(t, "filter.status", bracketIdentifierToPath("filter[status]"))
+	assert.Equal(t, "items.0.name", bracketIdentifierToPath("items[0][name]"))
+	assert.Equal(t, "ids", bracketIdentifierToPath("ids[]"))
+}
+
+func TestHTTPRequestParser_BracketQuery(t *testing.T) {
+	parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{
+		QueryDecoding: QueryDecodingBracket,
+	})
+
+	type Filter struct {
+		Status string `query:"filter.status"`
+		Owner  int    `query:"filter[owner][id]"`
+	}
+
+	type ListRequest struct {
+		Filter Filter
+		IDs    string `query:"ids"`
+		Page   int    `query:"page,omitempty" default:"1"`
+	}
+
+	req := newTestRequest(t, "GET",
+		"http://example.com/items?filter[status]=open&filter[owner][id]=7&ids[]=1&ids[]=2", nil)
+
+	var result ListRequest
+	err := parser.Parse(req, &result)
+
+	require.NoError(t, err)
+	assert.Equal(t, "open", result.Filter.Status)
+	assert.Equal(t, 7, result.Filter.Owner)
+	assert.Equal(t, `["1","2"]`, result.IDs)
+	assert.Equal(t, 1, result.Page)
+
+	t.Run("FlatModeIgnoresBrackets", func(t *testing.T) {
+		type FlatRequest struct {
+			Status string `query:"filter[status]"`
+		}
+
+		var flat FlatRequest
+		err := NewHTTPRequestParser().Parse(req, &flat)
+
+		require.NoError(t, err)
+		assert.Equal(t, "open", flat.Status)
+	})
+}
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidINI = errors.New("invalid INI content")
+)
+
+var (
+	// Default INIParser Options
+	_iniParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts: PCManagerOpts{
+			tagOpts: ParseTagOpts{
+				BindingOpts: BindingOpts{
+					AllowedBindingNames: []string{INITagBinding},
+				},
+				AllowedTagOptionals: []string{},
+			},
+			ScopeFuncs: map[string]ScopeFunc{
+				INITagBinding: DotScope,
+			},
+		},
+	}
+)
+
+// INIData is the content of an INI file, e.g. as read by os.ReadFile.
+type INIData []byte
+
+// INIParser parses INIData.
+//
+// The following Field Bindings are supported:
+//   - ini:'<section.key,[modifiers]>'`: Parses the value of key in
+//     section, e.g. `ini:"database.host"` for the host key of the
+//     [database] section. Keys before the first section are bound
+//     without a section (`ini:"name"`).
+//
+// Lines starting with ; or # are comments, and values may be enclosed in
+// single or double quotes, which are removed. Section and key names are
+// case-sensitive. A key set twice in a section keeps its last value.
+//
+// A nested struct field tagged with `ini:"<section>"` scopes the ini
+// bindings of its fields, e.g. `ini:"host"` inside a struct tagged
+// `ini:"database"` binds to "database.host".
+//
+// The content of a source is decoded once, on its first parse. Parse new
+// INIData after changing the content.
+type INIParser struct {
+	*BaseMBParser[INIData, ConfigFileOnce]
+}
+
+func NewINIParser() *INIParser {
+	return &INIParser{
+		BaseMBParser: NewBaseMBParser(
+			&configFileBindingManager[INIData]{decode: decodeINI},
+			_iniParserOpts,
+		),
+	}
+}
+
+func (ip *INIParser) Name() string {
+	return INIParserName
+}
+
+// decodeINI decodes INI content into the values of its keys, keyed by
+// "section.key".
+func decodeINI(content INIData) (map[string]string, error) {
+	var (
+		values  = make(map[string]string)
+		section string
+		lineNo  int
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			name, ok := strings.CutSuffix(line, "]")
+			name = strings.TrimSpace(name[1:])
+			if !ok || name == "" {
+				return nil, fmt.Errorf("%w: line %d: invalid section %q", ErrInvalidINI, lineNo, line)
+			}
+			section = name
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w: line %d: expected key = value, got %q", ErrInvalidINI, lineNo, line)
+		}
+
+		if section != "" {
+			key = section + "." + key
+		}
+		values[key] = unquoteConfigValue(strings.TrimSpace(value))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidINI, err)
+	}
+
+	return values, nil
+}
+
+// unquoteConfigValue removes matching single or double quotes around
+// value.
+func unquoteConfigValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestINIParser(t *testing.T) {
+	type Database struct {
+		Host string `ini:"host"`
+		Port int    `ini:"port,omitempty" default:"5432"`
+	}
+	type Config struct {
+		Name     string   `ini:"name"`
+		Debug    bool     `ini:"server.debug"`
+		Greeting string   `ini:"server.greeting"`
+		Database Database `ini:"database"`
+		Replica  Database `ini:"database.replica"`
+	}
+
+	content := INIData(`
+; global settings
+name = billing
+
+[server]
+debug = true
+greeting = "hello, world"
+
+# database settings
+[database]
+host = db.internal
+port = 6432
+
+[database.replica]
+host = 'replica.internal'
+`)
+
+	parser := NewINIParser()
+
+	var config Config
+	require.NoError(t, parser.Parse(&content, &config))
+	assert.Equal(t, Config{
+		Name:     "billing",
+		Debug:    true,
+		Greeting: "hello, world",
+		Database: Database{Host: "db.internal", Port: 6432},
+		Replica:  Database{Host: "replica.internal", Port: 5432},
+	}, config)
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, content := range []INIData{
+			INIData("[server\nhost = x"),
+			INIData("[]"),
+			INIData("[server]\nhost"),
+		} {
+			var config Config
+			err := parser.Parse(&content, &config)
+			assert.ErrorIs(t, err, ErrInvalidINI, string(content))
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package parser
+
+import "sync"
+
+// Integrations with third-party modules, such as phone numbers (pave_phone),
+// semantic versions (pave_semver), decimals (pave_decimal), and UUIDs and
+// language tags (left out by pave_minimal), register their converters and
+// value modifiers on first use of a registry rather than in init().
+// Programs, Go plugins and wasm builds that import pave without using it
+// pay no startup cost for them. The validate package registers their
+// validation rules the same way.
+var _integrationsOnce sync.Once
+
+// installIntegrations registers the integrations enabled by build tags,
+// once. Every Register, Unregister and lookup function of the converter
+// and value modifier registries calls it first, so that integrations never
+// override what the application registered.
+func installIntegrations() {
+	_integrationsOnce.Do(func() {
+		installUUID()
+		installLanguage()
+		installPhone()
+		installSemver()
+		installDecimal()
+	})
+}
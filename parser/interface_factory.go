@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	ErrNoInterfaceFactory      = errors.New("no factory registered for interface type")
+	ErrInterfaceFactoryResult  = errors.New("interface factory returned a value that does not implement the interface")
+	ErrUnknownDiscriminator    = errors.New("unknown discriminator value")
+	ErrMissingDiscriminator    = errors.New("discriminator not found in value")
+	ErrFactoryTypeNotInterface = errors.New("factory type parameter must be an interface type")
+)
+
+// InterfaceFactory builds a concrete value for an interface-typed field
+// from the raw value produced by a binding.
+//
+// For JSON bindings the value is the raw JSON of the bound sub-document,
+// for every other binding it is the string value of the binding.
+type InterfaceFactory func(value string) (any, error)
+
+// interfaceFactories holds the registered factories keyed by the
+// reflect.Type of the interface they produce values for.
+var (
+	_interfaceFactories      = make(map[reflect.Type]InterfaceFactory)
+	_interfaceFactoriesMutex sync.RWMutex
+)
+
+// RegisterInterfaceFactory registers a factory that is used whenever a
+// destination field of interface type I (with methods) is populated.
+//
+// Without a registered factory, fields whose type is a non-empty interface
+// cannot be populated and parsing them fails with ErrNoInterfaceFactory.
+// Registering a factory for an already registered type replaces it.
+func RegisterInterfaceFactory[I any](factory func(value string) (I, error)) error {
+	typ := reflect.TypeOf((*I)(nil)).Elem()
+	if typ.Kind() != reflect.Interface {
+		return fmt.Errorf("%w: %s", ErrFactoryTypeNotInterface, typ)
+	}
+
+	_interfaceFactoriesMutex.Lock()
+	defer _interfaceFactoriesMutex.Unlock()
+
+	_interfaceFactories[typ] = func(value string) (any, error) {
+		return factory(value)
+	}
+	return nil
+}
+
+// UnregisterInterfaceFactory removes the factory registered for interface
+// type I, if any.
+func UnregisterInterfaceFactory[I any]() {
+	typ := reflect.TypeOf((*I)(nil)).Elem()
+
+	_interfaceFactoriesMutex.Lock()
+	defer _interfaceFactoriesMutex.Unlock()
+
+	delete(_interfaceFactories, typ)
+}
+
+// getInterfaceFactory returns the factory registered for typ, if any.
+func getInterfaceFactory(typ reflect.Type) (InterfaceFactory, bool) {
+	_interfaceFactoriesMutex.RLock()
+	defer _interfaceFactoriesMutex.RUnlock()
+
+	factory, ok := _interfaceFactories[typ]
+	return factory, ok
+}
+
+// DiscriminatorFactory returns a factory for interface type I that
+// selects the concrete type from a discriminator key of a JSON object.
+//
+// The value bound to the field must be a JSON object. The discriminator
+// is read from key (a path, like the identifiers of json bindings), the
+// matching variant constructor is called, and the whole object is
+// unmarshaled into the new variant. Variants should return pointers so
+// that the unmarshaled data is kept.
+//
+// Example:
+//
+//	pave.RegisterInterfaceFactory(pave.DiscriminatorFactory("type",
+//		map[string]func() PaymentMethod{
+//			"card": func() PaymentMethod { return &CardPayment{} },
+//			"bank": func() PaymentMethod { return &BankPayment{} },
+//		},
+//	))
+func DiscriminatorFactory[I any](
+	key string,
+	variants map[string]func() I,
+) func(value string) (I, error) {
+
+	return func(value string) (I, error) {
+		var zero I
+
+		result := parseJSONDoc([]byte(value)).lookup(key)
+		if !result.Found {
+			return zero, fmt.Errorf("%w: %s", ErrMissingDiscriminator, key)
+		}
+
+		var discriminator string
+		if result.Value != nil {
+			discriminator = fmt.Sprint(result.Value)
+		}
+
+		newVariant, ok := variants[discriminator]
+		if !ok {
+			return zero, fmt.Errorf(
+				"%w for %s: %s",
+				ErrUnknownDiscriminator, key, discriminator,
+			)
+		}
+
+		variant := newVariant()
+		if err := json.Unmarshal([]byte(value), variant); err != nil {
+			return zero, fmt.Errorf(
+				"error unmarshaling %s variant: %w",
+				discriminator, err,
+			)
+		}
+
+		return variant, nil
+	}
+}
+
+// setFactoryInterfaceValue populates an interface field with methods using
+// the factory registered for the field's interface type.
+func setFactoryInterfaceValue(field reflect.Value, value string) error {
+	factory, ok := getInterfaceFactory(field.Type())
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoInterfaceFactory, field.Type())
+	}
+
+	result, err := factory(value)
+	if err != nil {
+		return fmt.Errorf("error building value for interface %s: %w", field.Type(), err)
+	}
+
+	if result == nil {
+		field.SetZero()
+		return nil
+	}
+
+	resultValue := reflect.ValueOf(result)
+	if !resultValue.Type().Implements(field.Type()) {
+		return fmt.Errorf(
+			"%w: %s does not implement %s",
+			ErrInterfaceFactoryResult, resultValue.Type(), field.Type(),
+		)
+	}
+
+	field.Set(resultValue)
+	return nil
+}
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPaymentMethod interface {
+	Kind() string
+}
+
+type testCardPayment struct {
+	Type   string `json:"type"`
+	Number string `json:"number"`
+}
+
+func (c *testCardPayment) Kind() string { return "card" }
+
+type testBankPayment struct {
+	Type string `json:"type"`
+	IBAN string `json:"iban"`
+}
+
+func (b *testBankPayment) Kind() string { return "bank" }
+
+type testShape interface {
+	Area() float64
+}
+
+func TestInterfaceFactory(t *testing.T) {
+	err := RegisterInterfaceFactory(DiscriminatorFactory("type",
+		map[string]func() testPaymentMethod{
+			"card": func() testPaymentMethod { return &testCardPayment{} },
+			"bank": func() testPaymentMethod { return &testBankPayment{} },
+		},
+	))
+	require.NoError(t, err)
+	defer UnregisterInterfaceFactory[testPaymentMethod]()
+
+	type Order struct {
+		ID      string            `json:"id"`
+		Payment testPaymentMethod `json:"payment"`
+	}
+
+	t.Run("DiscriminatorSelectsVariant", func(t *testing.T) {
+		body := `{"id": "o1", "payment": {"type": "bank", "iban": "DE89"}}`
+		req := newTestRequest(t, "POST", "http://example.com/", bytes.NewBufferString(body))
+
+		var order Order
+		err := NewHTTPRequestParser().Parse(req, &order)
+		require.NoError(t, err)
+
+		bank, ok := order.Payment.(*testBankPayment)
+		require.True(t, ok, "expected *testBankPayment, got %T", order.Payment)
+		assert.Equal(t, "DE89", bank.IBAN)
+		assert.Equal(t, "o1", order.ID)
+	})
+
+	t.Run("UnknownDiscriminator", func(t *testing.T) {
+		body := `{"id": "o1", "payment": {"type": "cash"}}`
+		req := newTestRequest(t, "POST", "http://example.com/", bytes.NewBufferString(body))
+
+		var order Order
+		err := NewHTTPRequestParser().Parse(req, &order)
+		assert.True(t, errors.Is(err, ErrUnknownDiscriminator), "got %v", err)
+	})
+
+	t.Run("MissingDiscriminator", func(t *testing.T) {
+		field := valueFromInterface(ptr(testPaymentMethod(nil)))
+		err := setInterfaceValue(field, `{"number": "4242"}`)
+		assert.True(t, errors.Is(err, ErrMissingDiscriminator), "got %v", err)
+	})
+
+	t.Run("NoFactoryRegistered", func(t *testing.T) {
+		field := valueFromInterface(ptr(testShape(nil)))
+		err := setInterfaceValue(field, "circle")
+		assert.True(t, errors.Is(err, ErrNoInterfaceFactory), "got %v", err)
+	})
+
+	t.Run("NonInterfaceTypeParameter", func(t *testing.T) {
+		err := RegisterInterfaceFactory(func(value string) (string, error) {
+			return value, nil
+		})
+		assert.True(t, errors.Is(err, ErrFactoryTypeNotInterface), "got %v", err)
+	})
+
+	t.Run("PlainStringFactory", func(t *testing.T) {
+		err := RegisterInterfaceFactory(func(value string) (testPaymentMethod, error) {
+			return &testCardPayment{Type: "card", Number: value}, nil
+		})
+		require.NoError(t, err)
+
+		field := valueFromInterface(ptr(testPaymentMethod(nil)))
+		require.NoError(t, setInterfaceValue(field, "4242"))
+		assert.Equal(t, "4242", field.Interface().(*testCardPayment).Number)
+	})
+}
@@ -0,0 +1,159 @@
+//go:build js && wasm
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"syscall/js"
+)
+
+// Support for syscall/js values, so that Go-WASM frontends can parse form
+// data, URL search params and plain objects into the same tagged structs
+// as the backend. It is only compiled for js/wasm builds.
+
+var (
+	ErrUnsupportedJSValue = errors.New("unsupported js value")
+)
+
+var (
+	// Default JSValueParser Binding Options
+	_jsTagOpts = ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				FormTagBinding,
+				QueryTagBinding,
+				HeaderTagBinding,
+				JsonTagBinding,
+			},
+			CustomBindingModifiers: []string{
+				LiteralBindingModifier,
+			},
+			ValueModifiers: map[string]ModifierValueParser{
+				CurrencyBindingModifier: parseCurrencyModifier,
+			},
+		},
+		AllowedTagOptionals: []string{},
+	}
+
+	// Default JSValueParser Options
+	_jsParserOpts = BaseMBParserOpts{
+		UseCache: false,
+		PCMOpts: PCManagerOpts{
+			tagOpts: _jsTagOpts,
+			ScopeFuncs: map[string]ScopeFunc{
+				QueryTagBinding: ConcatScope,
+			},
+		},
+	}
+)
+
+// JSValueParser parses a js.Value of a browser (or Node.js) environment.
+// The source is one of:
+//   - An object with a get method, such as FormData, URLSearchParams,
+//     Headers or Map: bindings get the value of their identifier, e.g.
+//     `form:"email"` gets formData.get("email").
+//   - Any other object: bindings get the property at their dotted path,
+//     e.g. `json:"user.email"` gets obj.user.email. Like for the json
+//     binding of the HTTPRequestParser, dots are escaped with a backslash
+//     or the literal modifier.
+//
+// The form, query, header and json bindings all read the source this way,
+// so that structs tagged for the HTTPRequestParser parse as they are from
+// what the frontend is about to send. Strings, numbers and booleans are
+// converted like strings of the same text, nested objects and arrays as
+// their JSON. null and undefined are not found.
+//
+// It is only available in js/wasm builds.
+type JSValueParser struct {
+	*BaseMBParser[js.Value, struct{}]
+}
+
+func NewJSValueParser() *JSValueParser {
+	return &JSValueParser{
+		BaseMBParser: NewBaseMBParser(&JSBindingManager{}, _jsParserOpts),
+	}
+}
+
+func (jp *JSValueParser) Name() string {
+	return JSValueParserName
+}
+
+// JSBindingManager gets the values of bindings from a js.Value. See
+// JSValueParser.
+type JSBindingManager struct{}
+
+func (mgr *JSBindingManager) BindingHandler(source *js.Value, binding Binding) BindingResult {
+	value, err := jsLookup(*source, binding)
+	if err != nil {
+		return BindingResultError(err)
+	}
+	if value.IsNull() || value.IsUndefined() {
+		return BindingResultNotFound()
+	}
+
+	text, err := jsString(value)
+	if err != nil {
+		return BindingResultError(fmt.Errorf("binding %s:%q: %w", binding.Name, binding.Identifier, err))
+	}
+	return BindingResultValue(text)
+}
+
+func (mgr *JSBindingManager) BindingHandlerCached(
+	source *js.Value, _ *CacheEntry[struct{}], binding Binding,
+) BindingResult {
+	return mgr.BindingHandler(source, binding)
+}
+
+func (mgr *JSBindingManager) NewCached() struct{} {
+	return struct{}{}
+}
+
+// jsLookup returns the value of binding in source, or undefined if it has
+// none.
+func jsLookup(source js.Value, binding Binding) (value js.Value, err error) {
+	if source.Type() != js.TypeObject {
+		return js.Undefined(), fmt.Errorf("%w: source is a %s, not an object", ErrUnsupportedJSValue, source.Type())
+	}
+
+	// Accessing a property may throw, e.g. for revoked proxies
+	defer func() {
+		if r := recover(); r != nil {
+			value, err = js.Undefined(), fmt.Errorf("%w: %v", ErrUnsupportedJSValue, r)
+		}
+	}()
+
+	if source.Get("get").Type() == js.TypeFunction {
+		return source.Call("get", binding.Identifier), nil
+	}
+
+	value = source
+	for _, key := range jsonBindingKeys(binding) {
+		if value.Type() != js.TypeObject {
+			return js.Undefined(), nil
+		}
+		value = value.Get(key)
+	}
+	return value, nil
+}
+
+// jsString returns the text of a bound js value.
+func jsString(value js.Value) (string, error) {
+	switch value.Type() {
+	case js.TypeString:
+		return value.String(), nil
+	case js.TypeNumber:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64), nil
+	case js.TypeBoolean:
+		return strconv.FormatBool(value.Bool()), nil
+	case js.TypeObject:
+		json := js.Global().Get("JSON").Call("stringify", value)
+		if json.Type() != js.TypeString {
+			return "", fmt.Errorf("%w: object cannot be converted to JSON", ErrUnsupportedJSValue)
+		}
+		return json.String(), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedJSValue, value.Type())
+	}
+}
@@ -0,0 +1,80 @@
+//go:build js && wasm
+
+package parser
+
+import (
+	"syscall/js"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSValueParser(t *testing.T) {
+	type Address struct {
+		City string `json:"address.city"`
+	}
+	type Signup struct {
+		Email   string `form:"email" json:"email"`
+		Age     int    `form:"age,omitempty" json:"age" default:"18"`
+		Page    int    `query:"page,omitempty" default:"1"`
+		Tags    string `json:"tags,omitempty"`
+		Address Address
+	}
+
+	parser := NewJSValueParser()
+
+	t.Run("FormData", func(t *testing.T) {
+		form := js.Global().Get("FormData").New()
+		form.Call("append", "email", "jane@example.com")
+		form.Call("append", "age", "42")
+
+		type Form struct {
+			Email string `form:"email"`
+			Age   int    `form:"age"`
+		}
+
+		var f Form
+		require.NoError(t, parser.Parse(&form, &f))
+		assert.Equal(t, Form{Email: "jane@example.com", Age: 42}, f)
+	})
+
+	t.Run("URLSearchParams", func(t *testing.T) {
+		type Query struct {
+			Page  int    `query:"page"`
+			Sort  string `query:"sort,omitempty" default:"asc"`
+			Debug bool   `query:"debug"`
+		}
+
+		params := js.Global().Get("URLSearchParams").New("page=3&debug=true")
+
+		var query Query
+		require.NoError(t, parser.Parse(&params, &query))
+		assert.Equal(t, Query{Page: 3, Sort: "asc", Debug: true}, query)
+	})
+
+	t.Run("Object", func(t *testing.T) {
+		obj := js.ValueOf(map[string]any{
+			"email":   "jane@example.com",
+			"age":     42,
+			"tags":    []any{"a", "b"},
+			"address": map[string]any{"city": "Berlin"},
+		})
+
+		var signup Signup
+		require.NoError(t, parser.Parse(&obj, &signup))
+		assert.Equal(t, "jane@example.com", signup.Email)
+		assert.Equal(t, 42, signup.Age)
+		assert.Equal(t, 1, signup.Page)
+		assert.Equal(t, `["a","b"]`, signup.Tags)
+		assert.Equal(t, "Berlin", signup.Address.City)
+	})
+
+	t.Run("UnsupportedSource", func(t *testing.T) {
+		source := js.ValueOf("not an object")
+
+		var signup Signup
+		err := parser.Parse(&source, &signup)
+		assert.ErrorIs(t, err, ErrUnsupportedJSValue)
+	})
+}
@@ -0,0 +1,52 @@
+//go:build !pave_minimal
+
+package parser
+
+import "github.com/tidwall/gjson"
+
+// jsonDoc is a parsed JSON document, in which json bindings look up their
+// values by path. Paths are github.com/tidwall/gjson paths: keys separated
+// by dots, array indices as keys ("items.0.name"), and the queries and
+// modifiers of gjson. Build with the pave_minimal tag to look up paths
+// with encoding/json instead, see json_doc_minimal.go.
+type jsonDoc struct {
+	result gjson.Result
+}
+
+// parseJSONDoc parses data, which is not validated: lookups in invalid
+// documents find what gjson finds before the error.
+func parseJSONDoc(data []byte) jsonDoc {
+	return jsonDoc{result: gjson.ParseBytes(data)}
+}
+
+// lookup returns the binding result of the value at path in doc.
+func (doc jsonDoc) lookup(path string) BindingResult {
+	return jsonResultValue(doc.result.Get(path))
+}
+
+// escapeJSONPath escapes key so that, as a path, it matches a single key.
+func escapeJSONPath(key string) string {
+	return gjson.Escape(key)
+}
+
+// jsonResultValue returns the binding result of a gjson lookup.
+func jsonResultValue(result gjson.Result) BindingResult {
+	if !result.Exists() {
+		return BindingResultNotFound()
+	}
+
+	// Objects and arrays are handed over as raw JSON so that they can be
+	// decoded by converters (e.g. InterfaceFactory) instead of being
+	// formatted as Go maps/slices.
+	if result.IsObject() || result.IsArray() {
+		return BindingResultValue(result.Raw)
+	}
+
+	// Numbers are handed over as written so that integers beyond 2^53 and
+	// long decimals keep their precision (e.g. for big.Int fields).
+	if result.Type == gjson.Number {
+		return BindingResultValue(result.Raw)
+	}
+
+	return BindingResultValue(result.Value())
+}
@@ -0,0 +1,97 @@
+//go:build pave_minimal
+
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// jsonDoc is a parsed JSON document, in which json bindings look up their
+// values by path. In pave_minimal builds, paths are keys separated by
+// dots, with array indices as keys ("items.0.name") and dots in keys
+// escaped with a backslash. The queries and modifiers of gjson paths
+// (e.g. "items.#.name") find nothing.
+type jsonDoc struct {
+	raw []byte
+}
+
+// parseJSONDoc parses data lazily: every lookup decodes the objects and
+// arrays along its path. Lookups through invalid JSON find nothing.
+func parseJSONDoc(data []byte) jsonDoc {
+	return jsonDoc{raw: data}
+}
+
+// lookup returns the binding result of the value at path in doc.
+func (doc jsonDoc) lookup(path string) BindingResult {
+	raw := doc.raw
+	for _, key := range splitJSONPath(path) {
+		child, ok := jsonChild(raw, key)
+		if !ok {
+			return BindingResultNotFound()
+		}
+		raw = child
+	}
+	return rawJSONValue(raw)
+}
+
+// escapeJSONPath escapes key so that, as a path, it matches a single key.
+func escapeJSONPath(key string) string {
+	return strings.NewReplacer(`\`, `\\`, ".", `\.`).Replace(key)
+}
+
+// jsonChild returns the member key of the JSON object raw, or the element
+// at index key of the JSON array raw.
+func jsonChild(raw []byte, key string) (json.RawMessage, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, false
+	}
+
+	switch raw[0] {
+	case '{':
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return nil, false
+		}
+		child, ok := object[key]
+		return child, ok
+	case '[':
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 {
+			return nil, false
+		}
+		var array []json.RawMessage
+		if err := json.Unmarshal(raw, &array); err != nil || index >= len(array) {
+			return nil, false
+		}
+		return array[index], true
+	}
+	return nil, false
+}
+
+// rawJSONValue returns the binding result of the JSON value raw, like
+// jsonResultValue in builds without pave_minimal: objects, arrays and
+// numbers as written, and other values decoded.
+func rawJSONValue(raw []byte) BindingResult {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || !json.Valid(raw) {
+		return BindingResultNotFound()
+	}
+
+	switch raw[0] {
+	case '"':
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return BindingResultNotFound()
+		}
+		return BindingResultValue(value)
+	case 't', 'f':
+		return BindingResultValue(raw[0] == 't')
+	case 'n':
+		return BindingResultValue(nil)
+	}
+	return BindingResultValue(string(raw))
+}
@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestJSONDoc checks the lookups that pave_minimal builds share with
+// gjson paths, run with and without the tag.
+func TestJSONDoc(t *testing.T) {
+	doc := parseJSONDoc([]byte(`{
+		"name": "Ada",
+		"id": 12345678901234567890,
+		"admin": true,
+		"manager": null,
+		"tags": ["a", "b"],
+		"items": [{"name": "pen", "price": 1.50}],
+		"a.b": {"c": "dotted"}
+	}`))
+
+	for path, want := range map[string]any{
+		"name":                       "Ada",
+		"id":                         "12345678901234567890",
+		"admin":                      true,
+		"manager":                    nil,
+		"tags":                       `["a", "b"]`,
+		"tags.1":                     "b",
+		"items.0.name":               "pen",
+		"items.0.price":              "1.50",
+		escapeJSONPath("a.b") + ".c": "dotted",
+		"items.0":                    `{"name": "pen", "price": 1.50}`,
+	} {
+		result := doc.lookup(path)
+		assert.True(t, result.Found, path)
+		assert.Equal(t, want, result.Value, path)
+	}
+
+	for _, path := range []string{"missing", "tags.2", "name.first", "a.b.c", "items.x"} {
+		assert.False(t, doc.lookup(path).Found, path)
+	}
+}
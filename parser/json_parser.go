@@ -0,0 +1,192 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type JSONByteSliceSourceParser struct {
+}
+
+func NewJsonByteSliceSourceParser() *JSONByteSliceSourceParser {
+	return &JSONByteSliceSourceParser{}
+}
+
+func (jbsp *JSONByteSliceSourceParser) SourceType() reflect.Type {
+	return JSONByteSliceType
+}
+
+func (jbsp *JSONByteSliceSourceParser) Name() string {
+	return JSONByteSliceParserName
+}
+
+func (jbsp *JSONByteSliceSourceParser) Parse(source any, dest any) error {
+	return ParseTypeErasedSlice(source, dest, jbsp.parse)
+}
+
+func (jbsp *JSONByteSliceSourceParser) parse(source []byte, dest any) error {
+	return unmarshalJSON(source, dest)
+}
+
+type JSONStringSourceParser struct{}
+
+func NewJSONStringSourceParser() *JSONStringSourceParser {
+	return &JSONStringSourceParser{}
+}
+func (jssp *JSONStringSourceParser) SourceType() reflect.Type {
+	return StringType
+}
+
+func (jssp *JSONStringSourceParser) Name() string {
+	return JSONStringParserName
+}
+
+func (jssp *JSONStringSourceParser) Parse(source any, dest any) error {
+	return ParseTypeErasedPointer(source, dest, jssp.parse)
+}
+
+func (jssp *JSONStringSourceParser) parse(source *string, dest any) error {
+	return unmarshalJSON([]byte(*source), dest)
+}
+
+// unmarshalJSON decodes data into dest. Like in the parse chains of other
+// parsers, nil pointer to struct fields that have json bindings are
+// allocated even when data has no value for them, see
+// allocateNestedStructs.
+func unmarshalJSON(data []byte, dest any) error {
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("error unmarshaling JSON data: %w", err)
+	}
+
+	value := reflect.ValueOf(dest)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct {
+		allocateNestedStructs(value, data)
+	}
+	return nil
+}
+
+// allocateNestedStructs allocates the nil pointer to struct fields of the
+// struct value decoded from the JSON object raw, recursively, the way
+// parse chains do before parsing into them: only pointers to structs with
+// json bindings (see hasJSONBindings), as the chain of a struct without
+// any has nothing to populate. Members that are null stay nil, as
+// encoding/json decoded them, as do fields tagged `recursive:"false"` and
+// pointers back to one of the enclosing struct types.
+func allocateNestedStructs(value reflect.Value, raw []byte, ancestors ...reflect.Type) {
+	typ := value.Type()
+	ancestors = append(ancestors[:len(ancestors):len(ancestors)], typ)
+
+	var members map[string]json.RawMessage
+	_ = json.Unmarshal(raw, &members) // Values other than objects have no members
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		structType, isPtr, isStruct := recursiveStructType(field.Type)
+		if !isStruct || (isPtr && slices.Contains(ancestors, structType)) {
+			continue
+		}
+		if recursiveTag, _ := decodeRecursiveTagV2(field); !recursiveTag.Enabled {
+			continue
+		}
+
+		// Embedded structs without a name share the object of their parent
+		name, _, _ := strings.Cut(field.Tag.Get(JsonTagBinding), CommaDelimeter)
+		if name == "-" {
+			continue
+		}
+		member := raw
+		if !field.Anonymous || name != "" {
+			if name == "" {
+				name = field.Name
+			}
+			member = jsonMember(members, name)
+		}
+		if bytes.Equal(bytes.TrimSpace(member), []byte("null")) {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if isPtr {
+			if fieldValue.IsNil() {
+				if !hasJSONBindings(structType) {
+					continue
+				}
+				fieldValue.Set(reflect.New(structType))
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		allocateNestedStructs(fieldValue, member, ancestors...)
+	}
+}
+
+// jsonMember returns the member name of members, matched like
+// encoding/json matches keys with field names: exactly, or else ignoring
+// case. It returns nil if there is none.
+func jsonMember(members map[string]json.RawMessage, name string) json.RawMessage {
+	if member, ok := members[name]; ok {
+		return member
+	}
+	for key, member := range members {
+		if strings.EqualFold(key, name) {
+			return member
+		}
+	}
+	return nil
+}
+
+// _jsonBoundTypes caches hasJSONBindings by struct type.
+var _jsonBoundTypes sync.Map // reflect.Type -> bool
+
+// hasJSONBindings reports whether a field of the struct type typ, or of
+// its nested structs, has a json binding, i.e. would be populated by the
+// parse chain of typ.
+func hasJSONBindings(typ reflect.Type) bool {
+	if bound, ok := _jsonBoundTypes.Load(typ); ok {
+		return bound.(bool)
+	}
+
+	bound := hasJSONTags(typ, make(map[reflect.Type]bool))
+	_jsonBoundTypes.Store(typ, bound)
+	return bound
+}
+
+// hasJSONTags is hasJSONBindings, with visited holding the types being
+// checked, for recursive types.
+func hasJSONTags(typ reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[typ] {
+		return false
+	}
+	visited[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup(JsonTagBinding); ok {
+			if tag != "-" {
+				return true
+			}
+			continue
+		}
+
+		structType, _, isStruct := recursiveStructType(field.Type)
+		if isStruct && hasJSONTags(structType, visited) {
+			return true
+		}
+	}
+	return false
+}
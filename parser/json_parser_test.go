@@ -1,4 +1,4 @@
-package pave
+package parser
 
 import (
 	"testing"
@@ -138,3 +138,63 @@ func TestJSONStringSourceParser(t *testing.T) {
 		assert.Contains(t, err.Error(), "error unmarshaling JSON data")
 	})
 }
+
+func TestJSONParsers_AllocateNestedPointers(t *testing.T) {
+	type Leaf struct {
+		Value string `json:"value"`
+	}
+	type Middle struct {
+		Leaf *Leaf `json:"leaf"`
+	}
+	type Root struct {
+		Present *Middle `json:"present"`
+		Absent  *Middle `json:"absent"`
+		Self    *Root   `json:"self"`
+		Opaque  *Leaf   `json:"opaque" recursive:"false"`
+	}
+
+	var fromBytes Root
+	require.NoError(t, NewJsonByteSliceSourceParser().Parse([]byte(`{"present": {"leaf": {"value": "x"}}}`), &fromBytes))
+	assert.Equal(t, "x", fromBytes.Present.Leaf.Value)
+	require.NotNil(t, fromBytes.Absent)
+	require.NotNil(t, fromBytes.Absent.Leaf)
+	assert.Nil(t, fromBytes.Self)
+	assert.Nil(t, fromBytes.Opaque)
+
+	source := `{}`
+	var fromString Root
+	require.NoError(t, NewJSONStringSourceParser().Parse(&source, &fromString))
+	require.NotNil(t, fromString.Present)
+	require.NotNil(t, fromString.Present.Leaf)
+
+	t.Run("Null", func(t *testing.T) {
+		var root Root
+		require.NoError(t, NewJsonByteSliceSourceParser().Parse([]byte(`{"present": null, "absent": {"LEAF": null}}`), &root))
+		assert.Nil(t, root.Present)
+		require.NotNil(t, root.Absent)
+		assert.Nil(t, root.Absent.Leaf, "keys match fields ignoring case")
+	})
+
+	t.Run("Unbound", func(t *testing.T) {
+		type Untagged struct {
+			Value string
+		}
+		type Embedded struct {
+			Leaf *Leaf `json:"leaf"`
+		}
+		type Parent struct {
+			Embedded
+			Untagged *Untagged `json:"untagged"`
+			Ignored  *Leaf     `json:"-"`
+		}
+
+		var parent Parent
+		require.NoError(t, NewJsonByteSliceSourceParser().Parse([]byte(`{"leaf": null}`), &parent))
+		assert.Nil(t, parent.Untagged, "the chain of a struct without json bindings populates nothing")
+		assert.Nil(t, parent.Ignored)
+		assert.Nil(t, parent.Leaf, "embedded fields are members of their parent")
+
+		require.NoError(t, NewJsonByteSliceSourceParser().Parse([]byte(`{}`), &parent))
+		assert.NotNil(t, parent.Leaf)
+	})
+}
@@ -0,0 +1,58 @@
+//go:build !pave_minimal
+
+package parser
+
+import (
+	"fmt"
+	"reflect"
+
+	"golang.org/x/text/language"
+)
+
+// Support for BCP 47 language tags and ISO 3166-1 country codes backed by
+// golang.org/x/text. Build with the pave_minimal tag to leave it out, along
+// with the locale binding's supported modifier (see Locales) and the mail
+// charsets other than UTF-8 and Latin-1.
+//
+// language.Tag fields (and pointers to them) are populated from language
+// tags, e.g. "en-US".
+
+// installLanguage registers the language integration. See
+// installIntegrations.
+func installLanguage() {
+	_builtinTypeConverters[reflect.TypeFor[language.Tag]()] = ignoreModifiers(convertLanguageTag)
+}
+
+// IsCountryCode reports whether code is an ISO 3166-1 alpha-2 or alpha-3
+// country code, in any case. Regions that are not countries (e.g. "EU",
+// "419") and reserved codes (e.g. "UK", "ZZ") are rejected.
+func IsCountryCode(code string) bool {
+	if len(code) != 2 && len(code) != 3 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		c := code[i] | 0x20 // lower case
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+
+	region, err := language.ParseRegion(code)
+	return err == nil && region.IsCountry() && region.ISO3() != "ZZZ"
+}
+
+// ParseLanguageTag parses a well-formed BCP 47 language tag with known
+// subtags, e.g. "en-US" or "zh-Hant-TW". Underscores are accepted as
+// separators ("en_US").
+func ParseLanguageTag(value string) (language.Tag, error) {
+	tag, err := language.Parse(value)
+	if err != nil {
+		return language.Tag{}, fmt.Errorf("%w: %q: %w", ErrInvalidLanguageTag, value, err)
+	}
+	return tag, nil
+}
+
+// convertLanguageTag is the TypeConverter for language.Tag fields.
+func convertLanguageTag(value string) (any, error) {
+	return ParseLanguageTag(value)
+}
@@ -0,0 +1,44 @@
+//go:build !pave_minimal
+
+package parser
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestIsCountryCode(t *testing.T) {
+	for _, code := range []string{"US", "de", "DEU", "gbr", "AQ"} {
+		assert.True(t, IsCountryCode(code), code)
+	}
+	for _, code := range []string{"", "U", "EU", "UK", "ZZ", "ZZZ", "419", "840", "QO", "USAA", "U1"} {
+		assert.False(t, IsCountryCode(code), code)
+	}
+}
+
+func TestHTTPRequestParser_Language(t *testing.T) {
+	type request struct {
+		Language language.Tag `header:"Content-Language"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Language", "en_us")
+
+	var dest request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &dest))
+	assert.Equal(t, language.AmericanEnglish, dest.Language)
+
+	req.Header.Set("Content-Language", "not a language")
+	assert.Error(t, NewHTTPRequestParser().Parse(req, &request{}))
+
+	query, err := EncodeQuery(struct {
+		Language language.Tag `query:"lang"`
+	}{Language: language.German})
+	require.NoError(t, err)
+	assert.Equal(t, "de", query.Get("lang"))
+}
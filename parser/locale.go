@@ -0,0 +1,109 @@
+//go:build !pave_minimal
+
+package parser
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// _anyLanguage is the tag language.ParseAcceptLanguage gives the "*"
+// language range.
+var _anyLanguage = language.MustParse("mul")
+
+// Locales is the value of the supported modifier of locale bindings: the
+// locales an application supports, most preferred first.
+type Locales struct {
+	Tags    []language.Tag
+	matcher language.Matcher
+}
+
+// NewLocales returns the Locales of tags. The first tag is the fallback of
+// close matches, e.g. of "en" for "en-AU" when only "en-US" is supported.
+func NewLocales(tags ...language.Tag) Locales {
+	return Locales{Tags: tags, matcher: language.NewMatcher(tags)}
+}
+
+// Match returns the supported locale that best matches the language
+// ranges of an Accept-Language header. It reports false if no supported
+// locale is an acceptable match.
+func (locales Locales) Match(header string) (language.Tag, bool, error) {
+	accepted, qualities, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return language.Tag{}, false, fmt.Errorf("%w: %q: %w", ErrInvalidAccept, header, err)
+	}
+
+	// The matched tag carries the -u-rg extension of the region asked for;
+	// the supported tag at index is the one the application knows.
+	_, index, confidence := locales.matcher.Match(accepted...)
+	if confidence != language.No {
+		return locales.Tags[index], true, nil
+	}
+
+	// "*", parsed as the "mul" tag, accepts any language, i.e. the most
+	// preferred supported one
+	for i, tag := range accepted {
+		if tag == _anyLanguage && qualities[i] > 0 {
+			return locales.Tags[0], true, nil
+		}
+	}
+	return language.Tag{}, false, nil
+}
+
+// parseSupportedModifier is the ModifierValueParser of the supported
+// modifier, which lists the locales an application supports separated by
+// "|", e.g. `locale:"Accept-Language,supported=en-US|fr|de"`.
+func parseSupportedModifier(value string) (any, error) {
+	var tags []language.Tag
+	for _, locale := range strings.Split(value, "|") {
+		if locale = strings.TrimSpace(locale); locale == "" {
+			continue
+		}
+		tag, err := ParseLanguageTag(locale)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if len(tags) == 0 {
+		return nil, fmt.Errorf("%w: no supported locales in %q", ErrInvalidLanguageTag, value)
+	}
+	return NewLocales(tags...), nil
+}
+
+// LocaleValue resolves the locale of the Accept-Language header named by
+// binding, joining repeated headers. With the supported modifier, the
+// result is the supported locale that best matches the header, otherwise
+// the locale the header prefers.
+func (mgr *HTTPBindingManager) LocaleValue(source *http.Request, binding Binding) BindingResult {
+	values := source.Header.Values(binding.Identifier)
+	if len(values) == 0 {
+		return BindingResultNotFound()
+	}
+	header := strings.Join(values, CommaDelimeter)
+
+	locales, ok := ModifierValue[Locales](binding, SupportedBindingModifier)
+	if !ok {
+		accepted, _, err := language.ParseAcceptLanguage(header)
+		if err != nil {
+			return BindingResultError(fmt.Errorf("header %s: %w: %q: %w", binding.Identifier, ErrInvalidAccept, header, err))
+		}
+		if len(accepted) == 0 {
+			return BindingResultNotFound()
+		}
+		return BindingResultValue(accepted[0].String())
+	}
+
+	tag, ok, err := locales.Match(header)
+	if err != nil {
+		return BindingResultError(fmt.Errorf("header %s: %w", binding.Identifier, err))
+	}
+	if !ok {
+		return BindingResultError(fmt.Errorf("%w: header %s: %q", ErrNotAcceptable, binding.Identifier, header))
+	}
+	return BindingResultValue(tag.String())
+}
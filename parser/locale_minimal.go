@@ -0,0 +1,40 @@
+//go:build pave_minimal
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// parseSupportedModifier is the ModifierValueParser of the supported
+// modifier. Matching locales needs golang.org/x/text, so it fails in
+// pave_minimal builds.
+func parseSupportedModifier(string) (any, error) {
+	return nil, fmt.Errorf("%w: the %s modifier in pave_minimal builds", errors.ErrUnsupported, SupportedBindingModifier)
+}
+
+// LocaleValue returns the language range that the Accept-Language header
+// named by binding prefers, joining repeated headers. In pave_minimal
+// builds, ranges are lower case rather than canonical BCP 47 tags (e.g.
+// "en-us" rather than "en-US"), and the "*" range is skipped.
+func (mgr *HTTPBindingManager) LocaleValue(source *http.Request, binding Binding) BindingResult {
+	values := source.Header.Values(binding.Identifier)
+	if len(values) == 0 {
+		return BindingResultNotFound()
+	}
+	header := strings.Join(values, CommaDelimeter)
+
+	accepted, err := ParseAccept(header)
+	if err != nil {
+		return BindingResultError(fmt.Errorf("header %s: %w", binding.Identifier, err))
+	}
+	for _, value := range accepted {
+		if value.Q > 0 && value.Value != "*" {
+			return BindingResultValue(value.Value)
+		}
+	}
+	return BindingResultNotFound()
+}
@@ -0,0 +1,45 @@
+//go:build pave_minimal
+
+package parser
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocaleBinding_Minimal(t *testing.T) {
+	parser := NewHTTPRequestParser()
+
+	newRequest := func(t *testing.T, acceptLanguage ...string) *http.Request {
+		req := newTestRequest(t, "GET", "http://example.com/", nil)
+		for _, value := range acceptLanguage {
+			req.Header.Add("Accept-Language", value)
+		}
+		return req
+	}
+
+	var page struct {
+		Preferred string `locale:"Accept-Language"`
+	}
+	for header, want := range map[string]string{
+		"fr-CH, fr;q=0.9, en;q=0.8": "fr-ch",
+		"*, de;q=0.5":               "de",
+		"en;q=0, pt-BR;q=0.1":       "pt-br",
+	} {
+		require.NoError(t, parser.Parse(newRequest(t, header), &page), header)
+		assert.Equal(t, want, page.Preferred, header)
+	}
+
+	err := parser.Parse(newRequest(t), &page)
+	assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+
+	var supported struct {
+		Locale string `locale:"Accept-Language,supported=en|fr"`
+	}
+	err = parser.Parse(newRequest(t, "fr"), &supported)
+	assert.True(t, errors.Is(err, errors.ErrUnsupported), err)
+}
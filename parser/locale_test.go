@@ -0,0 +1,79 @@
+//go:build !pave_minimal
+
+package parser
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestLocaleBinding(t *testing.T) {
+	type Page struct {
+		Locale    language.Tag `locale:"Accept-Language,supported=en-US|fr|de"`
+		Preferred language.Tag `locale:"Accept-Language"`
+	}
+
+	parser := NewHTTPRequestParser()
+
+	newRequest := func(t *testing.T, acceptLanguage ...string) *http.Request {
+		req := newTestRequest(t, "GET", "http://example.com/", nil)
+		for _, value := range acceptLanguage {
+			req.Header.Add("Accept-Language", value)
+		}
+		return req
+	}
+
+	for header, want := range map[string]language.Tag{
+		"fr-CH, fr;q=0.9, en;q=0.8": language.French,
+		"en-GB;q=0.9, de;q=0.5":     language.AmericanEnglish,
+		"de-AT":                     language.German,
+		"*":                         language.AmericanEnglish,
+	} {
+		var page Page
+		require.NoError(t, parser.Parse(newRequest(t, header), &page), header)
+		assert.Equal(t, want, page.Locale, header)
+	}
+
+	t.Run("Preferred", func(t *testing.T) {
+		var page Page
+		require.NoError(t, parser.Parse(newRequest(t, "de;q=0.5", "pt-BR"), &page))
+		assert.Equal(t, language.BrazilianPortuguese, page.Preferred)
+	})
+
+	t.Run("NotAcceptable", func(t *testing.T) {
+		err := parser.Parse(newRequest(t, "ja, ko;q=0.5"), &Page{})
+		assert.ErrorIs(t, err, ErrNotAcceptable)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		err := parser.Parse(newRequest(t), &Page{})
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+
+		var page struct {
+			Locale language.Tag `locale:"Accept-Language,supported=en-US|fr,omitempty" default:"fr"`
+		}
+		require.NoError(t, parser.Parse(newRequest(t), &page))
+		assert.Equal(t, language.French, page.Locale)
+	})
+
+	t.Run("InvalidSupported", func(t *testing.T) {
+		err := parser.Parse(newRequest(t, "en"), &struct {
+			Locale language.Tag `locale:"Accept-Language,supported=en|not a tag"`
+		}{})
+		assert.ErrorIs(t, err, ErrInvalidLanguageTag)
+	})
+
+	t.Run("Encode", func(t *testing.T) {
+		page := Page{Locale: language.French, Preferred: language.French}
+		req, err := NewHTTPRequest("GET", "http://example.com/", &page)
+		require.NoError(t, err)
+
+		var parsed Page
+		require.NoError(t, parser.Parse(req, &parsed))
+		assert.Equal(t, page, parsed)
+	})
+}
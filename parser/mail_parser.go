@@ -0,0 +1,283 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidMailMessage = errors.New("invalid mail message")
+)
+
+// Parts of a message bound by the mailbody binding
+const (
+	MailBodyTextPart string = "text"
+	MailBodyHTMLPart string = "html"
+)
+
+var (
+	// Default MailMessageParser Binding Options
+	_mailTagOpts = ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				MailHeaderTagBinding,
+				MailBodyTagBinding,
+			},
+			CustomBindingModifiers: []string{},
+			ValueModifiers:         map[string]ModifierValueParser{},
+		},
+		AllowedTagOptionals: []string{},
+	}
+
+	// Default MailMessageParser Options
+	_mailParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts: PCManagerOpts{
+			tagOpts: _mailTagOpts,
+		},
+	}
+)
+
+// _mailAddressHeaders are the headers holding address lists, which
+// net/mail decodes itself.
+var _mailAddressHeaders = []string{"From", "Sender", "Reply-To", "To", "Cc", "Bcc", "Resent-From", "Resent-To"}
+
+// _mailWordDecoder decodes RFC 2047 encoded words of any charset that
+// charsetReader knows.
+var _mailWordDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// MailMessageParser parses RFC 5322 messages read with net/mail, e.g. by
+// systems ingesting support tickets or bounces:
+//   - mailheader:'<header,[modifiers]>'`: Parses a header value by key.
+//     Encoded words (=?UTF-8?Q?...?=) are decoded, and the Date header is
+//     formatted as RFC 3339 for time.Time fields.
+//   - mailbody:'<part,[modifiers]>'`: Parses the "text" (text/plain) or
+//     "html" (text/html) body of the message, the first of its kind in
+//     multipart messages, ignoring attachments. Transfer encodings and
+//     charsets are decoded.
+//
+// Address headers populate mail.Address fields, or []*mail.Address fields
+// for address lists:
+//
+//	type Ticket struct {
+//		From    mail.Address    `mailheader:"From"`
+//		Cc      []*mail.Address `mailheader:"Cc,omitempty" default:""`
+//		Subject string          `mailheader:"Subject"`
+//		Body    string          `mailbody:"text"`
+//	}
+//
+// The body of the message is read once and restored for later readers.
+type MailMessageParser struct {
+	*BaseMBParser[mail.Message, MailMessageOnce]
+}
+
+func NewMailMessageParser() *MailMessageParser {
+	return &MailMessageParser{
+		BaseMBParser: NewBaseMBParser(&MailBindingManager{}, _mailParserOpts),
+	}
+}
+
+func (mp *MailMessageParser) Name() string {
+	return MailMessageParserName
+}
+
+// MailMessageOnce caches the body parts of a message.
+type MailMessageOnce struct {
+	bodyOnce  sync.Once
+	bodyParts map[string]string
+	bodyError error
+}
+
+// MailBindingManager gets the values of bindings from a mail.Message. See
+// MailMessageParser.
+type MailBindingManager struct{}
+
+func (mgr *MailBindingManager) BindingHandlerCached(
+	source *mail.Message,
+	entry *CacheEntry[MailMessageOnce],
+	binding Binding,
+) BindingResult {
+
+	if entry == nil {
+		return BindingResultError(ErrBindingCacheNilEntry)
+	}
+
+	switch binding.Name {
+	case MailHeaderTagBinding:
+		return mailHeaderValue(source.Header, binding.Identifier)
+	case MailBodyTagBinding:
+		var (
+			parts map[string]string
+			err   error
+		)
+		entry.WriteData(func(data *MailMessageOnce) {
+			data.bodyOnce.Do(func() {
+				data.bodyParts, data.bodyError = readMailBody(source)
+			})
+			parts, err = data.bodyParts, data.bodyError
+		})
+		if err != nil {
+			return BindingResultError(err)
+		}
+
+		if binding.Identifier != MailBodyTextPart && binding.Identifier != MailBodyHTMLPart {
+			return BindingResultError(fmt.Errorf("%w: unknown body part: %s", ErrUnallowedBindingName, binding.Identifier))
+		}
+		part, ok := parts[binding.Identifier]
+		if !ok {
+			return BindingResultNotFound()
+		}
+		return BindingResultValue(part)
+	default:
+		return BindingResultError(fmt.Errorf("%w: unknown binding: %s", ErrUnallowedBindingName, binding.Name))
+	}
+}
+
+func (mgr *MailBindingManager) BindingHandler(source *mail.Message, binding Binding) BindingResult {
+	return mgr.BindingHandlerCached(source, &CacheEntry[MailMessageOnce]{}, binding)
+}
+
+func (mgr *MailBindingManager) NewCached() MailMessageOnce {
+	return MailMessageOnce{}
+}
+
+// mailHeaderValue returns the decoded value of the header name.
+func mailHeaderValue(header mail.Header, name string) BindingResult {
+	value := header.Get(name)
+	if value == "" {
+		return BindingResultNotFound()
+	}
+
+	canonical := textproto.CanonicalMIMEHeaderKey(name)
+	if canonical == "Date" {
+		date, err := mail.ParseDate(value)
+		if err != nil {
+			return BindingResultError(fmt.Errorf("%w: Date header: %w", ErrInvalidMailMessage, err))
+		}
+		return BindingResultValue(date.Format(time.RFC3339Nano))
+	}
+
+	if slices.Contains(_mailAddressHeaders, canonical) {
+		return BindingResultValue(value)
+	}
+
+	decoded, err := _mailWordDecoder.DecodeHeader(value)
+	if err != nil {
+		return BindingResultError(fmt.Errorf("%w: %s header: %w", ErrInvalidMailMessage, name, err))
+	}
+	return BindingResultValue(decoded)
+}
+
+// readMailBody reads the text and html parts of the body of msg, and
+// restores the body so that others can read it again.
+func readMailBody(msg *mail.Message) (map[string]string, error) {
+	parts := make(map[string]string)
+	if msg.Body == nil {
+		return parts, nil
+	}
+
+	body, err := io.ReadAll(msg.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMailMessage, err)
+	}
+	msg.Body = bytes.NewReader(body)
+
+	header := textproto.MIMEHeader(msg.Header)
+	if err := collectMailParts(header, bytes.NewReader(body), parts); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// collectMailParts adds the first text/plain and text/html parts of the
+// entity with header and body to parts, descending into multiparts.
+func collectMailParts(header textproto.MIMEHeader, body io.Reader, parts map[string]string) error {
+	mediaType, params := "text/plain", map[string]string{}
+	if contentType := header.Get("Content-Type"); contentType != "" {
+		var err error
+		if mediaType, params, err = mime.ParseMediaType(contentType); err != nil {
+			return fmt.Errorf("%w: Content-Type: %w", ErrInvalidMailMessage, err)
+		}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidMailMessage, err)
+			}
+			if err := collectMailParts(part.Header, part, parts); err != nil {
+				return err
+			}
+		}
+	}
+
+	if disposition, _, _ := mime.ParseMediaType(header.Get("Content-Disposition")); disposition == "attachment" {
+		return nil
+	}
+
+	var key string
+	switch mediaType {
+	case "text/plain":
+		key = MailBodyTextPart
+	case "text/html":
+		key = MailBodyHTMLPart
+	default:
+		return nil
+	}
+	if _, ok := parts[key]; ok {
+		return nil
+	}
+
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	decoded, err := charsetReader(params["charset"], body)
+	if err != nil {
+		return err
+	}
+	text, err := io.ReadAll(decoded)
+	if err != nil {
+		return fmt.Errorf("%w: %s part: %w", ErrInvalidMailMessage, mediaType, err)
+	}
+	parts[key] = string(text)
+	return nil
+}
+
+// convertMailAddress parses a single RFC 5322 address.
+func convertMailAddress(value string) (any, error) {
+	address, err := mail.ParseAddress(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: address %q: %w", ErrInvalidMailMessage, value, err)
+	}
+	return *address, nil
+}
+
+// convertMailAddressList parses an RFC 5322 address list.
+func convertMailAddressList(value string) (any, error) {
+	addresses, err := mail.ParseAddressList(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: address list %q: %w", ErrInvalidMailMessage, value, err)
+	}
+	return addresses, nil
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"io"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readTestMail(t *testing.T, raw string) *mail.Message {
+	t.Helper()
+	msg, err := mail.ReadMessage(strings.NewReader(strings.ReplaceAll(raw, "\n", "\r\n")))
+	require.NoError(t, err)
+	return msg
+}
+
+func TestMailMessageParser(t *testing.T) {
+	type Ticket struct {
+		From    mail.Address    `mailheader:"From"`
+		To      []*mail.Address `mailheader:"To"`
+		Cc      []*mail.Address `mailheader:"Cc,omitempty" default:""`
+		Subject string          `mailheader:"Subject"`
+		Date    time.Time       `mailheader:"Date"`
+		Text    string          `mailbody:"text"`
+		HTML    string          `mailbody:"html,omitempty" default:""`
+	}
+
+	msg := readTestMail(t, `From: "Ada Lovelace" <ada@example.com>
+To: support@example.com, "Bob" <bob@example.com>
+Subject: =?UTF-8?Q?Caf=C3=A9_order?=
+Date: Wed, 01 May 2024 12:00:00 +0200
+Content-Type: text/plain; charset=utf-8
+
+My order is late.
+`)
+
+	parser := NewMailMessageParser()
+
+	var ticket Ticket
+	require.NoError(t, parser.Parse(msg, &ticket))
+	assert.Equal(t, mail.Address{Name: "Ada Lovelace", Address: "ada@example.com"}, ticket.From)
+	assert.Equal(t, []*mail.Address{{Address: "support@example.com"}, {Name: "Bob", Address: "bob@example.com"}}, ticket.To)
+	assert.Nil(t, ticket.Cc)
+	assert.Equal(t, "Café order", ticket.Subject)
+	assert.True(t, ticket.Date.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "My order is late.\r\n", ticket.Text)
+	assert.Empty(t, ticket.HTML)
+
+	body, err := io.ReadAll(msg.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "My order is late.\r\n", string(body), "the body is restored")
+
+	t.Run("Multipart", func(t *testing.T) {
+		msg := readTestMail(t, `From: bounces@example.com
+Subject: Delivery failure
+Content-Type: multipart/mixed; boundary=outer
+
+--outer
+Content-Type: multipart/alternative; boundary=inner
+
+--inner
+Content-Type: text/plain; charset=iso-8859-1
+Content-Transfer-Encoding: quoted-printable
+
+Adresse inconnue: caf=E9@example.com
+--inner
+Content-Type: text/html; charset=utf-8
+Content-Transfer-Encoding: base64
+
+PHA+VW5rbm93bjwvcD4=
+--inner--
+--outer
+Content-Type: text/plain
+Content-Disposition: attachment; filename=log.txt
+
+attached log
+--outer--
+`)
+
+		var bounce struct {
+			Text string `mailbody:"text"`
+			HTML string `mailbody:"html"`
+		}
+		require.NoError(t, parser.Parse(msg, &bounce))
+		assert.Equal(t, "Adresse inconnue: café@example.com", bounce.Text)
+		assert.Equal(t, "<p>Unknown</p>", bounce.HTML)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		msg := readTestMail(t, "From: nobody\nDate: yesterday\n\nbody\n")
+
+		var from struct {
+			From mail.Address `mailheader:"From"`
+		}
+		assert.ErrorIs(t, parser.Parse(msg, &from), ErrInvalidMailMessage)
+
+		var date struct {
+			Date time.Time `mailheader:"Date"`
+		}
+		assert.ErrorIs(t, parser.Parse(msg, &date), ErrInvalidMailMessage)
+
+		var body struct {
+			Body string `mailbody:"attachments"`
+		}
+		assert.ErrorIs(t, parser.Parse(msg, &body), ErrUnallowedBindingName)
+	})
+}
@@ -0,0 +1,211 @@
+package parser
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidDataPath = errors.New("invalid data path")
+)
+
+var (
+	// Default TemplateDataParser Binding Options
+	_dataTagOpts = ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				DataTagBinding,
+			},
+			CustomBindingModifiers: []string{
+				LiteralBindingModifier,
+			},
+		},
+		AllowedTagOptionals: []string{},
+	}
+
+	// Default TemplateDataParser Options
+	_dataParserOpts = BaseMBParserOpts{
+		UseCache: false,
+		PCMOpts: PCManagerOpts{
+			tagOpts: _dataTagOpts,
+			ScopeFuncs: map[string]ScopeFunc{
+				DataTagBinding: DotScope,
+			},
+		},
+	}
+)
+
+// TemplateDataParser parses the map[string]any data of text/template and
+// html/template pipelines, so that report and code generation tools can
+// validate their inputs before executing a template.
+//
+// The following Field Bindings are supported:
+//   - data:'<path,[modifiers]>'`: Parses the value at a dotted path, where
+//     segments are map keys or slice indexes, e.g. `data:"users.0.email"`
+//     or `data:"users[0].email"`. Dots in keys are escaped with a
+//     backslash (`data:"site\\.name"`) or the literal modifier.
+//
+// Maps with string keys, slices and arrays are traversed at any depth,
+// whatever their element types. Objects and arrays at the end of a path
+// are handed over as JSON, so that they can populate struct and interface
+// fields.
+//
+// A nested struct field tagged with `data:"<path>"` scopes the data
+// bindings of its fields, e.g. `data:"email"` inside a struct tagged
+// `data:"users.0"` binds to "users.0.email".
+type TemplateDataParser struct {
+	*BaseMBParser[map[string]any, struct{}]
+}
+
+func NewTemplateDataParser() *TemplateDataParser {
+	return &TemplateDataParser{
+		BaseMBParser: NewBaseMBParser(&TemplateDataBindingManager{}, _dataParserOpts),
+	}
+}
+
+func (tp *TemplateDataParser) Name() string {
+	return TemplateDataParserName
+}
+
+// TemplateDataBindingManager gets the values of data bindings from
+// template data. See TemplateDataParser.
+type TemplateDataBindingManager struct{}
+
+func (mgr *TemplateDataBindingManager) BindingHandler(
+	source *map[string]any,
+	binding Binding,
+) BindingResult {
+
+	path, err := dataBindingPath(binding)
+	if err != nil {
+		return BindingResultError(err)
+	}
+
+	value, found := lookupDataPath(reflect.ValueOf(*source), path)
+	if !found {
+		return BindingResultNotFound()
+	}
+
+	return dataValue(value, binding)
+}
+
+// dataValue returns the result of a bound template data value. Values
+// that marshal to text (e.g. time.Time) are handed over as that text,
+// floats without exponent so that whole numbers populate int fields.
+func dataValue(value reflect.Value, binding Binding) BindingResult {
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return BindingResultError(fmt.Errorf("data:%q: %w", binding.Identifier, err))
+		}
+		return BindingResultValue(string(text))
+	}
+
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return BindingResultValue(strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()))
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		raw, err := json.Marshal(value.Interface())
+		if err != nil {
+			return BindingResultError(fmt.Errorf("data:%q: %w", binding.Identifier, err))
+		}
+		return BindingResultValue(string(raw))
+	default:
+		return BindingResultValue(value.Interface())
+	}
+}
+
+func (mgr *TemplateDataBindingManager) BindingHandlerCached(
+	source *map[string]any, _ *CacheEntry[struct{}], binding Binding,
+) BindingResult {
+	return mgr.BindingHandler(source, binding)
+}
+
+func (mgr *TemplateDataBindingManager) NewCached() struct{} {
+	return struct{}{}
+}
+
+// dataBindingPath splits a data binding into the segments of its path.
+// Indexes in brackets ("users[0]") are segments of their own, like those
+// separated by dots ("users.0").
+func dataBindingPath(binding Binding) ([]string, error) {
+	keys := jsonBindingKeys(binding)
+	if binding.Modifiers.Custom[LiteralBindingModifier] {
+		return keys, nil
+	}
+
+	var path []string
+	for _, key := range keys {
+		name, indexes, bracketed := strings.Cut(key, "[")
+		if name != "" || !bracketed {
+			path = append(path, name)
+		}
+
+		for bracketed {
+			var index string
+			index, indexes, bracketed = strings.Cut(indexes, "]")
+			if !bracketed || index == "" {
+				return nil, fmt.Errorf("%w: unclosed or empty index in %q", ErrInvalidDataPath, binding.Identifier)
+			}
+			path = append(path, index)
+
+			if indexes == "" {
+				break
+			}
+			if indexes, bracketed = strings.CutPrefix(indexes, "["); !bracketed {
+				return nil, fmt.Errorf("%w: unexpected %q after index in %q", ErrInvalidDataPath, indexes, binding.Identifier)
+			}
+		}
+	}
+
+	return path, nil
+}
+
+// lookupDataPath returns the value at path in data, following interfaces
+// and pointers. Values that are nil are not found.
+func lookupDataPath(data reflect.Value, path []string) (reflect.Value, bool) {
+	value := data
+	for _, segment := range path {
+		value = derefDataValue(value)
+
+		switch value.Kind() {
+		case reflect.Map:
+			if value.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			value = value.MapIndex(reflect.ValueOf(segment).Convert(value.Type().Key()))
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= value.Len() {
+				return reflect.Value{}, false
+			}
+			value = value.Index(index)
+		default:
+			return reflect.Value{}, false
+		}
+
+		if !value.IsValid() {
+			return reflect.Value{}, false
+		}
+	}
+
+	value = derefDataValue(value)
+	return value, value.IsValid()
+}
+
+// derefDataValue follows interfaces and pointers to the value they hold,
+// returning the zero Value for nil.
+func derefDataValue(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
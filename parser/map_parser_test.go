@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateDataParser(t *testing.T) {
+	type Owner struct {
+		Name  string `data:"name"`
+		Email string `data:"email" validate:"required"`
+	}
+	type Report struct {
+		Title     string    `data:"title"`
+		Site      string    `data:"site\\.name"`
+		FirstUser string    `data:"users.0.email"`
+		LastUser  string    `data:"users[1].email"`
+		Total     int       `data:"stats.total"`
+		Ratio     float64   `data:"stats.ratio"`
+		Generated time.Time `data:"generated"`
+		Columns   [2]string `data:"columns"`
+		Owner     Owner     `data:"users[0]"`
+		Footer    string    `data:"footer,omitempty" default:"none"`
+	}
+
+	generated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := map[string]any{
+		"title":     "Monthly",
+		"site.name": "example.com",
+		"users": []map[string]any{
+			{"name": "Jane", "email": "jane@example.com"},
+			{"name": "John", "email": "john@example.com"},
+		},
+		"stats":     map[string]any{"total": float64(1e6), "ratio": 0.25},
+		"generated": generated,
+		"columns":   []any{"name", "email"},
+	}
+
+	parser := NewTemplateDataParser()
+
+	var report Report
+	require.NoError(t, parser.Parse(&data, &report))
+	assert.Equal(t, Report{
+		Title:     "Monthly",
+		Site:      "example.com",
+		FirstUser: "jane@example.com",
+		LastUser:  "john@example.com",
+		Total:     1000000,
+		Ratio:     0.25,
+		Generated: generated,
+		Columns:   [2]string{"name", "email"},
+		Owner:     Owner{Name: "Jane", Email: "jane@example.com"},
+		Footer:    "none",
+	}, report)
+
+	t.Run("MissingIndex", func(t *testing.T) {
+		type Missing struct {
+			Email string `data:"users.2.email"`
+		}
+
+		var missing Missing
+		err := parser.Parse(&data, &missing)
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+	})
+
+	t.Run("InvalidPath", func(t *testing.T) {
+		type Invalid struct {
+			Email string `data:"users[0.email"`
+		}
+
+		var invalid Invalid
+		err := parser.Parse(&data, &invalid)
+		assert.ErrorIs(t, err, ErrInvalidDataPath)
+	})
+}
+
+func TestDataBindingPath(t *testing.T) {
+	tests := []struct {
+		identifier string
+		literal    bool
+		want       []string
+	}{
+		{"users.0.email", false, []string{"users", "0", "email"}},
+		{"users[0].email", false, []string{"users", "0", "email"}},
+		{"matrix[1][2]", false, []string{"matrix", "1", "2"}},
+		{"[0].name", false, []string{"0", "name"}},
+		{"site\\.name", false, []string{"site.name"}},
+		{"a.b[0]", true, []string{"a.b[0]"}},
+	}
+
+	for _, tt := range tests {
+		binding := Binding{Name: DataTagBinding, Identifier: tt.identifier}
+		if tt.literal {
+			binding.Modifiers.Custom = map[string]bool{LiteralBindingModifier: true}
+		}
+
+		path, err := dataBindingPath(binding)
+		require.NoError(t, err, tt.identifier)
+		assert.Equal(t, tt.want, path, tt.identifier)
+	}
+
+	for _, identifier := range []string{"users[", "users[]", "users[0]x"} {
+		_, err := dataBindingPath(Binding{Name: DataTagBinding, Identifier: identifier})
+		assert.ErrorIs(t, err, ErrInvalidDataPath, identifier)
+	}
+}
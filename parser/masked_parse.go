@@ -0,0 +1,208 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+var (
+	ErrMaskUnsupported = errors.New("parser does not support field masks")
+)
+
+// MaskedParser is implemented by parsers that populate only the fields a
+// field mask selects, such as those built on BaseMBParser. Masks list the
+// dotted paths of fields, e.g. the paths of a google.protobuf.FieldMask
+// (fm.GetPaths()) on update requests, or the X-Fields header of a partial
+// request (see HeaderFieldMask).
+//
+// A path names a field by its JSON name or its Go name, and selects the
+// field itself, every field nested within it, and the structs it is
+// nested within. Fields the mask does not select keep their value and are
+// neither required nor defaulted. An empty mask selects every field.
+type MaskedParser interface {
+	Parser
+	// ParseMasked populates the fields of dest that paths select from
+	// source. Paths naming no field fail with ErrUnknownMaskField.
+	ParseMasked(source any, dest any, paths []string) error
+}
+
+// ParseMasked is Parse, populating only the fields that paths select.
+// See MaskedParser.
+func (base *BaseMBParser[S, C]) ParseMasked(source any, dest any, paths []string) error {
+	typedSource, ok := source.(*S)
+	if !ok {
+		return sourceTypeError(new(S), source)
+	}
+
+	if err := checkDest(dest); err != nil {
+		return err
+	}
+
+	return base.parse(typedSource, dest, paths)
+}
+
+// Masked returns a copy of the chain that only populates the fields that
+// paths select, see MaskedParser. Its sub-chains are masked with the
+// paths below their field.
+func (chain *ParseChain[S]) Masked(paths []string) (*ParseChain[S], error) {
+	if len(paths) == 0 {
+		return chain, nil
+	}
+
+	split := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		if !hasMaskFieldPath(chain.StructType, strings.Split(path, ".")) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownMaskField, path)
+		}
+		split = append(split, strings.Split(path, "."))
+	}
+	if len(split) == 0 {
+		return chain, nil
+	}
+
+	return chain.Derive(func(derived *ParseChain[S]) error {
+		derived.mask(split)
+		return nil
+	})
+}
+
+// mask unlinks the steps, defaults and checksums of the chain that paths
+// do not select.
+func (chain *ParseChain[S]) mask(paths [][]string) {
+	var prev *ParseStep[S]
+	for step := chain.Head; step != nil; step = step.Next {
+		whole, below := SelectMaskPaths(paths, chain.StructType.Field(step.FieldIndex))
+
+		if !whole && len(below) > 0 && step.SubChain != nil {
+			step.SubChain.mask(below)
+		}
+		if whole || len(below) > 0 {
+			prev = step
+			continue
+		}
+
+		if prev == nil {
+			chain.Head = step.Next
+		} else {
+			prev.Next = step.Next
+		}
+	}
+
+	kept := make(map[int]bool)
+	for step := chain.Head; step != nil; step = step.Next {
+		kept[step.FieldIndex] = true
+	}
+
+	defaults := chain.Defaults[:0]
+	for _, def := range chain.Defaults {
+		if whole, below := SelectMaskPaths(paths, chain.StructType.Field(def.FieldIndex)); whole || len(below) > 0 {
+			defaults = append(defaults, def)
+		}
+	}
+	chain.Defaults = defaults
+
+	checksums := chain.Checksums[:0]
+	for _, checksum := range chain.Checksums {
+		if kept[checksum.FieldIndex] {
+			checksums = append(checksums, checksum)
+		}
+	}
+	chain.Checksums = checksums
+}
+
+// SelectMaskPaths reports whether paths, split by SplitMaskPaths, select
+// field whole, and returns the paths below it otherwise.
+func SelectMaskPaths(paths [][]string, field reflect.StructField) (whole bool, below [][]string) {
+	for _, path := range paths {
+		if !isMaskName(field, path[0]) {
+			continue
+		}
+		if len(path) == 1 {
+			return true, nil
+		}
+		below = append(below, path[1:])
+	}
+	return false, below
+}
+
+// isMaskName reports whether name, an element of a mask path, names
+// field: its JSON name or its Go name.
+func isMaskName(field reflect.StructField, name string) bool {
+	if name == field.Name {
+		return true
+	}
+	jsonName, _, _ := strings.Cut(field.Tag.Get("json"), CommaDelimeter)
+	return jsonName != "" && jsonName != "-" && name == jsonName
+}
+
+// hasMaskFieldPath reports whether path names fields through typ, see
+// isMaskName, following pointers, slices, arrays and maps of structs.
+func hasMaskFieldPath(typ reflect.Type, path []string) bool {
+	for _, name := range path {
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice ||
+			typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return false
+		}
+
+		field, found := maskField(typ, name)
+		if !found {
+			return false
+		}
+		typ = field.Type
+	}
+	return true
+}
+
+// maskField returns the field of the struct type typ that name names.
+func maskField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); isMaskName(field, name) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// SplitMaskPaths splits the dotted paths of a field mask, see
+// MaskedParser, into their elements. Empty paths are dropped.
+func SplitMaskPaths(paths []string) [][]string {
+	var split [][]string
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path != "" {
+			split = append(split, strings.Split(path, "."))
+		}
+	}
+	return split
+}
+
+// validateMasked checks the `validate` tags of the fields of v that paths
+// select, see MaskedParser, so that the fields a masked parse left alone
+// are not validated. It collects maxErrors errors at most (all if
+// negative).
+func validateMasked(v any, paths []string, maxErrors int) error {
+	return validateTags(v, SplitMaskPaths(paths), maxErrors)
+}
+
+// HeaderFieldMask returns the paths of the field mask that the header of
+// req lists, comma separated, e.g. HeaderFieldMask(req, "X-Fields").
+// Without the header, there is no mask and every field is selected.
+func HeaderFieldMask(req *http.Request, header string) []string {
+	var paths []string
+	for _, value := range req.Header.Values(header) {
+		for _, path := range strings.Split(value, CommaDelimeter) {
+			if path = strings.TrimSpace(path); path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type maskedAddress struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type maskedUpdate struct {
+	Name    string        `json:"name"`
+	Email   string        `json:"email"`
+	Address maskedAddress `json:"address,scope"`
+	Plan    string        `query:"plan,omitempty" default:"free"`
+}
+
+func newMaskedRequest(t testing.TB, body string) *http.Request {
+	req := newTestRequest(t, "PATCH", "http://example.com/users/1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestBaseMBParser_ParseMasked(t *testing.T) {
+	current := maskedUpdate{
+		Name:    "jane",
+		Email:   "jane@example.com",
+		Address: maskedAddress{City: "Paris", Zip: "75001"},
+		Plan:    "pro",
+	}
+
+	parser := NewHTTPRequestParser()
+
+	t.Run("Fields", func(t *testing.T) {
+		update := current
+		req := newMaskedRequest(t, `{"email": "j@example.com", "address": {"city": "Lyon"}}`)
+		require.NoError(t, parser.ParseMasked(req, &update, []string{"email", "address.city"}))
+
+		expected := current
+		expected.Email = "j@example.com"
+		expected.Address.City = "Lyon"
+		assert.Equal(t, expected, update, "unmasked fields keep their value and are not required")
+	})
+
+	t.Run("GoNames", func(t *testing.T) {
+		update := current
+		req := newMaskedRequest(t, `{"address": {"city": "Lyon", "zip": "69001"}}`)
+		require.NoError(t, parser.ParseMasked(req, &update, []string{"Address"}))
+		assert.Equal(t, maskedAddress{City: "Lyon", Zip: "69001"}, update.Address)
+		assert.Equal(t, "pro", update.Plan, "defaults of unmasked fields are not applied")
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		update := current
+		err := parser.ParseMasked(newMaskedRequest(t, `{}`), &update, []string{"name"})
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+	})
+
+	t.Run("UnboundFields", func(t *testing.T) {
+		type Partial struct {
+			Name  string `json:"name"`
+			Notes string
+		}
+
+		partial := Partial{Name: "jane", Notes: "vip"}
+		require.NoError(t, parser.ParseMasked(newMaskedRequest(t, `{"name": "joe"}`), &partial, []string{"Notes"}))
+		assert.Equal(t, Partial{Name: "jane", Notes: "vip"}, partial)
+	})
+
+	t.Run("UnknownField", func(t *testing.T) {
+		update := current
+		err := parser.ParseMasked(newMaskedRequest(t, `{}`), &update, []string{"address.street"})
+		assert.ErrorIs(t, err, ErrUnknownMaskField)
+	})
+
+	t.Run("EmptyMask", func(t *testing.T) {
+		var update maskedUpdate
+		req := newMaskedRequest(t, `{"name": "joe", "email": "", "address": {"city": "", "zip": "1"}}`)
+		require.NoError(t, parser.ParseMasked(req, &update, nil))
+		assert.Equal(t, "free", update.Plan)
+	})
+}
+
+func TestParserRegistry_ParseMasked(t *testing.T) {
+	registry, err := NewParserRegistry(ParserRegistryOpts{})
+	require.NoError(t, err)
+
+	t.Run("Unsupported", func(t *testing.T) {
+		err := registry.Register(&panickingParser{})
+		require.NoError(t, err)
+
+		var update maskedUpdate
+		err = registry.WithParser("panicking").ParseMasked(newMaskedRequest(t, `{}`), &update, []string{"name"}, false)
+		assert.ErrorIs(t, err, ErrMaskUnsupported)
+	})
+}
+
+func TestHeaderFieldMask(t *testing.T) {
+	req := newTestRequest(t, "GET", "http://example.com/", nil)
+	assert.Nil(t, HeaderFieldMask(req, "X-Fields"))
+
+	req.Header.Add("X-Fields", "name, email")
+	req.Header.Add("X-Fields", "address.city,")
+	assert.Equal(t, []string{"name", "email", "address.city"}, HeaderFieldMask(req, "X-Fields"))
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxErrors is the number of errors that validation and ParseTable
+// collect at most before summarizing the rest, so that adversarial
+// payloads cannot inflate the size of error responses. Registries can
+// change it, see ParserRegistryOpts.MaxErrors.
+const DefaultMaxErrors = 20
+
+var (
+	ErrTooManyErrors = errors.New("too many errors")
+)
+
+// MoreErrors ends the errors joined by validation and ParseTable when
+// more errors occurred than were collected, see DefaultMaxErrors.
+type MoreErrors struct {
+	Count   int  // Errors that were not collected
+	Partial bool // Whether errors stopped being looked for once one was not collected, so that Count is a lower bound
+}
+
+func (e *MoreErrors) Error() string {
+	if e.Partial {
+		return "and more errors"
+	}
+	return fmt.Sprintf("and %d more errors", e.Count)
+}
+
+func (e *MoreErrors) Unwrap() error {
+	return ErrTooManyErrors
+}
+
+// ErrorLimit caps the errors collected at Max, unless Max is negative,
+// counting those it leaves out. Validation and ParseTable share it.
+type ErrorLimit struct {
+	Max     int  // Errors collected at most, all if negative
+	Partial bool // Whether errors are no longer looked for once Done, see MoreErrors
+	count   int  // Errors seen
+}
+
+// Collect counts another error and reports whether it is collected.
+func (limit *ErrorLimit) Collect() bool {
+	limit.count++
+	return limit.Max < 0 || limit.count <= limit.Max
+}
+
+// Done reports whether an error was not collected.
+func (limit *ErrorLimit) Done() bool {
+	return limit.Max >= 0 && limit.count > limit.Max
+}
+
+// More returns the MoreErrors of the errors that were not collected, or
+// nil if there are none.
+func (limit *ErrorLimit) More() error {
+	if !limit.Done() {
+		return nil
+	}
+	return &MoreErrors{Count: limit.count - limit.Max, Partial: limit.Partial}
+}
+
+// resolveMaxErrors returns the ErrorLimit maximum of an option: the
+// default for zero, and no maximum for negative values.
+func resolveMaxErrors(max int) int {
+	switch {
+	case max == 0:
+		return DefaultMaxErrors
+	case max < 0:
+		return -1
+	}
+	return max
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTable_MaxErrors(t *testing.T) {
+	type Row struct {
+		Age int `column:"Age"`
+	}
+
+	rows := [][]string{{"Age"}}
+	for i := 0; i < DefaultMaxErrors+5; i++ {
+		rows = append(rows, []string{"old"})
+	}
+	rows = append(rows, []string{"42"})
+
+	parsed, err := ParseTable[Row](rows)
+	require.Len(t, parsed, DefaultMaxErrors+6)
+	assert.Equal(t, 42, parsed[len(parsed)-1].Age)
+
+	var more *MoreErrors
+	require.ErrorAs(t, err, &more)
+	assert.Equal(t, 5, more.Count)
+}
+
+func TestErrorLimit(t *testing.T) {
+	limit := &ErrorLimit{Max: 2}
+	assert.True(t, limit.Collect())
+	assert.True(t, limit.Collect())
+	assert.False(t, limit.Done())
+	assert.NoError(t, limit.More())
+
+	assert.False(t, limit.Collect())
+	assert.False(t, limit.Collect())
+	assert.True(t, limit.Done())
+	assert.EqualError(t, limit.More(), "and 2 more errors")
+
+	limit.Partial = true
+	assert.EqualError(t, limit.More(), "and more errors")
+
+	unlimited := &ErrorLimit{Max: -1}
+	for range 100 {
+		assert.True(t, unlimited.Collect())
+	}
+	assert.False(t, unlimited.Done())
+	assert.NoError(t, unlimited.More())
+}
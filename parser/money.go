@@ -0,0 +1,219 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidMoney     = errors.New("invalid money value")
+	ErrUnknownCurrency  = errors.New("unknown ISO 4217 currency code")
+	ErrMissingCurrency  = errors.New("money value has no currency")
+	ErrCurrencyMismatch = errors.New("money currency does not match the expected currency")
+	ErrMoneyPrecision   = errors.New("money value has more decimals than its currency allows")
+)
+
+// Money is an amount of money in the minor units of an ISO 4217 currency,
+// e.g. Money{Amount: 1234, Currency: "USD"} is 12.34 USD.
+//
+// Money fields are populated from any binding with one of the formats:
+//   - "12.34 USD" or "USD 12.34": an amount in major units with its currency
+//   - "12.34": an amount in major units, currency from the `currency=` modifier
+//   - "1234": an integer amount in minor units, currency from the `currency=` modifier
+//
+// The `currency=<code>` modifier (e.g. `json:"price,currency=EUR"`) sets the
+// currency of values that carry none and rejects values in any other
+// currency. Amounts are never rounded: a value with more decimals than
+// the currency has minor units is rejected.
+type Money struct {
+	Amount   int64  // Amount in minor units of Currency (e.g. cents)
+	Currency string // ISO 4217 alphabetic currency code
+}
+
+// ParseMoney parses value in one of the formats described on Money.
+// currency, if not empty, is the currency of values that carry none and
+// the only currency accepted.
+func ParseMoney(value string, currency string) (Money, error) {
+	var amount, code string
+
+	switch fields := strings.Fields(value); len(fields) {
+	case 1:
+		amount = fields[0]
+	case 2:
+		if isCurrencyCodeFormat(fields[0]) {
+			code, amount = fields[0], fields[1]
+		} else {
+			amount, code = fields[0], fields[1]
+		}
+	default:
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, value)
+	}
+
+	code = strings.ToUpper(code)
+	currency = strings.ToUpper(currency)
+
+	switch {
+	case code == "" && currency == "":
+		return Money{}, fmt.Errorf("%w: %q", ErrMissingCurrency, value)
+	case code == "":
+		code = currency
+	case currency != "" && code != currency:
+		return Money{}, fmt.Errorf("%w: got %s, expected %s", ErrCurrencyMismatch, code, currency)
+	}
+
+	digits, ok := CurrencyMinorUnits(code)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, code)
+	}
+
+	// A bare integer is an amount in minor units
+	if !strings.Contains(value, " ") && !strings.Contains(amount, ".") {
+		minor, err := strconv.ParseInt(amount, 10, 64)
+		if err != nil {
+			return Money{}, fmt.Errorf("%w: %q: %w", ErrInvalidMoney, value, err)
+		}
+		return Money{Amount: minor, Currency: code}, nil
+	}
+
+	minor, err := parseMinorUnits(amount, digits)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q: %w", ErrInvalidMoney, value, err)
+	}
+
+	return Money{Amount: minor, Currency: code}, nil
+}
+
+// String formats m as "<amount> <currency>" in major units, e.g. "12.34 USD".
+func (m Money) String() string {
+	digits, _ := CurrencyMinorUnits(m.Currency)
+
+	amount := strconv.FormatInt(m.Amount, 10)
+	sign := ""
+	if m.Amount < 0 {
+		sign, amount = "-", amount[1:]
+	}
+
+	if digits > 0 {
+		if len(amount) <= digits {
+			amount = strings.Repeat("0", digits-len(amount)+1) + amount
+		}
+		amount = amount[:len(amount)-digits] + "." + amount[len(amount)-digits:]
+	}
+
+	if m.Currency == "" {
+		return sign + amount
+	}
+	return sign + amount + " " + m.Currency
+}
+
+// MarshalText implements encoding.TextMarshaler using Money.String.
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseMoney.
+func (m *Money) UnmarshalText(text []byte) error {
+	money, err := ParseMoney(string(text), "")
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
+
+// convertMoney is the TypeConverter for Money fields.
+func convertMoney(value string, modifiers BindingModifiers) (any, error) {
+	currency, _ := modifiers.Value(CurrencyBindingModifier)
+	code, _ := currency.(string)
+	return ParseMoney(value, code)
+}
+
+// parseCurrencyModifier is the ModifierValueParser of the currency
+// modifier. Unknown codes fail when the parse chain is built.
+func parseCurrencyModifier(value string) (any, error) {
+	code := strings.ToUpper(value)
+	if !IsCurrencyCode(code) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, value)
+	}
+	return code, nil
+}
+
+// parseMinorUnits parses a decimal amount in major units into minor units
+// of a currency with the given number of decimal digits.
+func parseMinorUnits(amount string, digits int) (int64, error) {
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if len(fraction) > digits {
+		return 0, fmt.Errorf("%w: at most %d decimals", ErrMoneyPrecision, digits)
+	}
+
+	digitsOnly := strings.TrimLeft(whole, "+-")
+	if digitsOnly == "" && fraction == "" {
+		return 0, errors.New("no digits in amount")
+	}
+	for _, r := range digitsOnly + fraction {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid digit %q", r)
+		}
+	}
+
+	return strconv.ParseInt(whole+fraction+strings.Repeat("0", digits-len(fraction)), 10, 64)
+}
+
+// isCurrencyCodeFormat reports whether s looks like a currency code
+// (three ASCII letters), without checking that the code exists.
+func isCurrencyCodeFormat(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20 // lower case
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsCurrencyCode reports whether code is an active ISO 4217 alphabetic
+// currency code. Codes are upper case.
+func IsCurrencyCode(code string) bool {
+	_, ok := _iso4217MinorUnits[code]
+	return ok
+}
+
+// CurrencyMinorUnits returns the number of decimal digits of the minor
+// unit of an ISO 4217 currency (e.g. 2 for USD, 0 for JPY, 3 for KWD).
+func CurrencyMinorUnits(code string) (int, bool) {
+	digits, ok := _iso4217MinorUnits[code]
+	return digits, ok
+}
+
+// _iso4217MinorUnits lists the active ISO 4217 currencies with their
+// minor unit digits. Funds and precious metals without a minor unit are
+// not included.
+var _iso4217MinorUnits = map[string]int{
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2, "AUD": 2,
+	"AWG": 2, "AZN": 2, "BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2, "BHD": 3, "BIF": 0,
+	"BMD": 2, "BND": 2, "BOB": 2, "BOV": 2, "BRL": 2, "BSD": 2, "BTN": 2, "BWP": 2,
+	"BYN": 2, "BZD": 2, "CAD": 2, "CDF": 2, "CHE": 2, "CHF": 2, "CHW": 2, "CLF": 4,
+	"CLP": 0, "CNY": 2, "COP": 2, "COU": 2, "CRC": 2, "CUC": 2, "CUP": 2, "CVE": 2,
+	"CZK": 2, "DJF": 0, "DKK": 2, "DOP": 2, "DZD": 2, "EGP": 2, "ERN": 2, "ETB": 2,
+	"EUR": 2, "FJD": 2, "FKP": 2, "GBP": 2, "GEL": 2, "GHS": 2, "GIP": 2, "GMD": 2,
+	"GNF": 0, "GTQ": 2, "GYD": 2, "HKD": 2, "HNL": 2, "HTG": 2, "HUF": 2, "IDR": 2,
+	"ILS": 2, "INR": 2, "IQD": 3, "IRR": 2, "ISK": 0, "JMD": 2, "JOD": 3, "JPY": 0,
+	"KES": 2, "KGS": 2, "KHR": 2, "KMF": 0, "KPW": 2, "KRW": 0, "KWD": 3, "KYD": 2,
+	"KZT": 2, "LAK": 2, "LBP": 2, "LKR": 2, "LRD": 2, "LSL": 2, "LYD": 3, "MAD": 2,
+	"MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2, "MNT": 2, "MOP": 2, "MRU": 2, "MUR": 2,
+	"MVR": 2, "MWK": 2, "MXN": 2, "MXV": 2, "MYR": 2, "MZN": 2, "NAD": 2, "NGN": 2,
+	"NIO": 2, "NOK": 2, "NPR": 2, "NZD": 2, "OMR": 3, "PAB": 2, "PEN": 2, "PGK": 2,
+	"PHP": 2, "PKR": 2, "PLN": 2, "PYG": 0, "QAR": 2, "RON": 2, "RSD": 2, "RUB": 2,
+	"RWF": 0, "SAR": 2, "SBD": 2, "SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2, "SHP": 2,
+	"SLE": 2, "SLL": 2, "SOS": 2, "SRD": 2, "SSP": 2, "STN": 2, "SVC": 2, "SYP": 2,
+	"SZL": 2, "THB": 2, "TJS": 2, "TMT": 2, "TND": 3, "TOP": 2, "TRY": 2, "TTD": 2,
+	"TWD": 2, "TZS": 2, "UAH": 2, "UGX": 0, "USD": 2, "USN": 2, "UYI": 0, "UYU": 2,
+	"UYW": 4, "UZS": 2, "VED": 2, "VES": 2, "VND": 0, "VUV": 0, "WST": 2, "XAF": 0,
+	"XCD": 2, "XCG": 2, "XOF": 0, "XPF": 0, "YER": 2, "ZAR": 2, "ZMW": 2, "ZWG": 2,
+	"ZWL": 2,
+}
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		currency string
+		want     Money
+		wantErr  error
+	}{
+		{"amount_code", "12.34 USD", "", Money{1234, "USD"}, nil},
+		{"code_amount", "eur 12.3", "", Money{1230, "EUR"}, nil},
+		{"major_integer", "12 USD", "", Money{1200, "USD"}, nil},
+		{"negative", "-0.05 USD", "", Money{-5, "USD"}, nil},
+		{"zero_decimals", "1500 JPY", "", Money{1500, "JPY"}, nil},
+		{"three_decimals", "1.234 KWD", "", Money{1234, "KWD"}, nil},
+		{"major_with_modifier", "12.34", "USD", Money{1234, "USD"}, nil},
+		{"minor_with_modifier", "1234", "USD", Money{1234, "USD"}, nil},
+		{"matching_modifier", "12.34 USD", "USD", Money{1234, "USD"}, nil},
+		{"mismatching_modifier", "12.34 EUR", "USD", Money{}, ErrCurrencyMismatch},
+		{"missing_currency", "12.34", "", Money{}, ErrMissingCurrency},
+		{"unknown_currency", "12.34 ABC", "", Money{}, ErrUnknownCurrency},
+		{"too_many_decimals", "12.345 USD", "", Money{}, ErrMoneyPrecision},
+		{"decimals_for_jpy", "12.5 JPY", "", Money{}, ErrMoneyPrecision},
+		{"garbage", "12,34 USD", "", Money{}, ErrInvalidMoney},
+		{"too_many_fields", "12 USD EUR", "", Money{}, ErrInvalidMoney},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value, tt.currency)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "12.34 USD", Money{1234, "USD"}.String())
+	assert.Equal(t, "0.05 USD", Money{5, "USD"}.String())
+	assert.Equal(t, "-0.05 USD", Money{-5, "USD"}.String())
+	assert.Equal(t, "1500 JPY", Money{1500, "JPY"}.String())
+	assert.Equal(t, "0.001 KWD", Money{1, "KWD"}.String())
+	assert.Equal(t, "0", Money{}.String())
+}
+
+func TestHTTPRequestParser_Money(t *testing.T) {
+	type Order struct {
+		Total    Money  `json:"total"`
+		Shipping Money  `json:"shipping,currency=usd"`
+		Tip      *Money `query:"tip,currency=USD"`
+	}
+
+	req := newTestRequest(t, "POST", "http://example.com/?tip=2.50",
+		bytes.NewBufferString(`{"total": "99.99 EUR", "shipping": 499}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var result Order
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &result))
+	assert.Equal(t, Money{9999, "EUR"}, result.Total)
+	assert.Equal(t, Money{499, "USD"}, result.Shipping)
+	require.NotNil(t, result.Tip)
+	assert.Equal(t, Money{250, "USD"}, *result.Tip)
+
+	t.Run("EncodeRoundTrip", func(t *testing.T) {
+		req, err := NewHTTPRequest("POST", "http://example.com/", result)
+		require.NoError(t, err)
+
+		var decoded Order
+		require.NoError(t, NewHTTPRequestParser().Parse(req, &decoded))
+		assert.Equal(t, result, decoded)
+	})
+
+	t.Run("UnknownCurrencyModifier", func(t *testing.T) {
+		type Invalid struct {
+			Price Money `query:"price,currency=XYZ"`
+		}
+
+		req := newTestRequest(t, "GET", "http://example.com/?price=1", nil)
+		err := NewHTTPRequestParser().Parse(req, &Invalid{})
+		assert.ErrorIs(t, err, ErrUnknownCurrency)
+	})
+}
@@ -1,8 +1,10 @@
-package pave
+package parser
 
 import (
 	"fmt"
 	"reflect"
+	"sync"
+	"time"
 )
 
 // BaseMBParser is a mostly implemented template for a MultiBindingParser
@@ -35,11 +37,23 @@ type BaseMBParser[S any, C any] struct {
 	BMgr      BindingManager[S, C]
 	BCache    *BindingCache[S, C]
 	useBCache bool
+
+	cacheOpts      BindingCacheOpts // Which destination types use BCache, see BindingCacheOpts
+	cacheDecisions sync.Map         // reflect.Type -> bool, whether parses into the type use BCache
+	arena          parseArena[S, C] // Entries of the parses that skip BCache, see newParseEntry
+
+	versionOpts VersionOpts
+	versioned   map[string]*PCManager[S] // PCManagers by version, see VersionOpts
 }
 
 type BaseMBParserOpts struct {
 	PCMOpts  PCManagerOpts
 	UseCache bool
+	Versions VersionOpts // Enables versioned bindings, see VersionOpts
+
+	// BindingCache selects the destination types that skip the
+	// BindingCache of a parser with UseCache. See BindingCacheOpts.
+	BindingCache BindingCacheOpts
 }
 
 // s
@@ -74,6 +88,12 @@ func NewBaseMBParser[S any, C any](
 
 	template := &BaseMBParser[S, C]{}
 
+	if opts.PCMOpts.CustomTagHandler == nil {
+		if handler, ok := bMgr.(CustomTagHandler); ok {
+			opts.PCMOpts.CustomTagHandler = handler
+		}
+	}
+
 	pcMgr := NewPCManager(
 		template.bindingHandlerAdapter,
 		opts.PCMOpts,
@@ -81,7 +101,10 @@ func NewBaseMBParser[S any, C any](
 
 	template.BMgr = bMgr
 	template.PCMgr = pcMgr
+	template.versionOpts = opts.Versions
+	template.versioned = newVersionedPCManagers(template.bindingHandlerAdapter, opts.PCMOpts, opts.Versions)
 	template.useBCache = opts.UseCache
+	template.cacheOpts = opts.BindingCache
 
 	if opts.UseCache {
 		template.BCache = NewBindingCache[S, C]()
@@ -108,31 +131,100 @@ func (base *BaseMBParser[S, C]) SourceType() reflect.Type {
 func (base *BaseMBParser[S, C]) Parse(source any, dest any) error {
 	typedSource, ok := source.(*S)
 	if !ok {
-		return fmt.Errorf("expected source type %T, got %T", *new(S), source)
+		return sourceTypeError(new(S), source)
 	}
 
-	if (reflect.TypeOf(dest).Kind() != reflect.Ptr) ||
-		(reflect.TypeOf(dest).Elem().Kind() != reflect.Struct) {
-		return fmt.Errorf("destination must be a pointer to a struct, got %T", dest)
+	if err := checkDest(dest); err != nil {
+		return err
 	}
 
-	return base.parse(typedSource, dest)
+	return base.parse(typedSource, dest, nil)
+}
+
+// ParseWithReport is Parse, also timing the parse of every field of dest.
+// The report is returned even if parsing fails, unless the arguments are
+// invalid. See ParseReport.
+func (base *BaseMBParser[S, C]) ParseWithReport(source any, dest any) (*ParseReport, error) {
+	typedSource, ok := source.(*S)
+	if !ok {
+		return nil, sourceTypeError(new(S), source)
+	}
+
+	if err := checkDest(dest); err != nil {
+		return nil, err
+	}
+
+	typ := reflect.TypeOf(dest).Elem()
+	start := time.Now()
+
+	pcm, version, err := base.chainManager(typedSource)
+	if err != nil {
+		return &ParseReport{Type: typ, Duration: time.Since(start)}, err
+	}
+
+	chain, err := pcm.GetParseChain(typ)
+	chainDuration := time.Since(start)
+	if err != nil {
+		return &ParseReport{Type: typ, Duration: chainDuration, ChainDuration: chainDuration}, err
+	}
+
+	handler, drop := base.uncachedHandler(typedSource, typ, chain)
+	defer drop()
+
+	handler, err = base.prefetch(typedSource, chain, handler)
+	batchDuration := time.Since(start) - chainDuration
+	if err != nil {
+		return &ParseReport{Type: typ, Duration: time.Since(start), ChainDuration: chainDuration, BatchDuration: batchDuration}, err
+	}
+
+	report, err := chain.executeWith(typedSource, dest, handler)
+	report.Type = typ
+	report.Version = version
+	report.ChainDuration = chainDuration
+	report.BatchDuration = batchDuration
+	report.Duration = time.Since(start)
+
+	return report, err
 }
 
 // parse is the internal method that performs the actual parsing.
 // It is separated from the Parse method to allow for type erasure
-// so that Parser interface is satisfied.
-func (base *BaseMBParser[S, C]) parse(source *S, dest any) error {
+// so that Parser interface is satisfied. Only the fields that paths
+// select are populated, see MaskedParser.
+func (base *BaseMBParser[S, C]) parse(source *S, dest any, paths []string) error {
 	typ := reflect.TypeOf(dest).Elem()
 
+	pcm, _, err := base.chainManager(source)
+	if err != nil {
+		return err
+	}
+
 	// Get the parse chain for the destination type
-	chain, err := base.PCMgr.GetParseChain(typ)
+	chain, err := pcm.GetParseChain(typ)
+	if err != nil {
+		return err
+	}
+
+	handler, drop := base.uncachedHandler(source, typ, chain)
+	defer drop()
+
+	// Masks selecting no bound field leave nothing to populate
+	masked, err := chain.Masked(paths)
+	if err != nil {
+		return err
+	}
+	if masked.empty() && !chain.empty() {
+		return nil
+	}
+	chain = masked
+
+	handler, err = base.prefetch(source, chain, handler)
 	if err != nil {
 		return err
 	}
 
 	// Execute chain
-	return chain.Execute(source, dest)
+	return chain.execute(source, dest, execution[S]{handler: handler}, "")
 }
 
 func (base *BaseMBParser[S, C]) bindingHandlerAdapter(
@@ -146,8 +238,7 @@ func (base *BaseMBParser[S, C]) bindingHandlerAdapter(
 			base.BCache = NewBindingCache[S, C]()
 		}
 
-		entry := base.BCache.GetOrCreate(source, base.BMgr.NewCached)
-		return base.BMgr.BindingHandlerCached(source, entry, binding)
+		return base.BMgr.BindingHandlerCached(source, base.BCache.GetOrCreate(source, base.BMgr.NewCached), binding)
 	} else {
 		return base.BMgr.BindingHandler(source, binding)
 	}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const regionTagName = "region"
+
+// regionBindingManager reads values from a string map and scopes the
+// bindings of fields tagged `region:"<name>"` to that region.
+type regionBindingManager struct{}
+
+func (regionBindingManager) NewCached() struct{} { return struct{}{} }
+
+func (regionBindingManager) BindingHandler(source *map[string]string, binding Binding) BindingResult {
+	value, ok := (*source)[binding.Identifier]
+	if !ok {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(value)
+}
+
+func (m regionBindingManager) BindingHandlerCached(
+	source *map[string]string, _ *CacheEntry[struct{}], binding Binding,
+) BindingResult {
+	return m.BindingHandler(source, binding)
+}
+
+func (regionBindingManager) HandleCustomTags(field *CustomTagField) error {
+	for _, tag := range field.Tags {
+		if tag.Name != regionTagName {
+			continue
+		}
+		if tag.Value == "" {
+			return errors.New("region cannot be empty")
+		}
+		for i := range field.Bindings {
+			field.Bindings[i].Identifier = tag.Value + "." + field.Bindings[i].Identifier
+		}
+		field.Metadata[regionTagName] = tag.Value
+	}
+	return nil
+}
+
+func newRegionParser() *BaseMBParser[map[string]string, struct{}] {
+	return NewBaseMBParser(regionBindingManager{}, BaseMBParserOpts{
+		PCMOpts: NewPCManagerOpts(ParseTagOpts{
+			BindingOpts:         BindingOpts{AllowedBindingNames: []string{MapValueTagBinding}},
+			AllowedTagOptionals: []string{regionTagName},
+		}),
+	})
+}
+
+func TestBaseMBParser_CustomTagHandler(t *testing.T) {
+	type Config struct {
+		Host   string `mapvalue:"host" region:"eu"`
+		Global string `mapvalue:"host"`
+	}
+
+	parser := newRegionParser()
+	source := map[string]string{"host": "global.example.com", "eu.host": "eu.example.com"}
+
+	var config Config
+	require.NoError(t, parser.Parse(&source, &config))
+	assert.Equal(t, "eu.example.com", config.Host)
+	assert.Equal(t, "global.example.com", config.Global)
+
+	chain, err := parser.PCMgr.GetParseChain(reflect.TypeFor[Config]())
+	require.NoError(t, err)
+
+	step, found := chain.Step("Host")
+	require.True(t, found)
+	assert.Equal(t, map[string]any{regionTagName: "eu"}, step.Metadata)
+
+	step, _ = chain.Step("Global")
+	assert.Nil(t, step.Metadata)
+
+	t.Run("HandlerError", func(t *testing.T) {
+		type Invalid struct {
+			Host string `mapvalue:"host" region:""`
+		}
+
+		err := parser.Parse(&source, &Invalid{})
+		assert.ErrorIs(t, err, ErrFailedToHandleCustomTags)
+	})
+}
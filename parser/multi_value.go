@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// isMultiValueType reports whether fields of typ take every value of a
+// binding found more than once, e.g. repeated query parameters: slices
+// other than []byte, possibly behind pointers or an Opt, without a
+// TypeConverter or unmarshaler of their own, see setUnmarshalerValue.
+func isMultiValueType(typ reflect.Type) bool {
+	for {
+		switch {
+		case IsOptType(typ):
+			typ = typ.Field(0).Type
+		case typ.Kind() == reflect.Ptr:
+			typ = typ.Elem()
+		default:
+			if typ.Kind() != reflect.Slice || typ.Elem().Kind() == reflect.Uint8 {
+				return false
+			}
+			if _, ok := getTypeConverter(typ); ok {
+				return false
+			}
+			return !implementsUnmarshaler(typ)
+		}
+	}
+}
+
+// explodes reports whether binding takes every value of its identifier
+// rather than splitting the first one, see ExplodeBindingModifier. Only
+// Multi bindings explode, and those with a delim modifier only with
+// `explode=true`.
+func explodes(binding Binding) bool {
+	if explode, ok := ModifierValue[bool](binding, ExplodeBindingModifier); ok {
+		return binding.Multi && explode
+	}
+	_, delimited := binding.Modifiers.Value(DelimBindingModifier)
+	return binding.Multi && !delimited
+}
+
+// setMultiValue populates field, a field for which isMultiValueType holds,
+// with one element per value, each transformed by the modifiers of
+// binding.
+func setMultiValue(field reflect.Value, values []string, binding Binding) error {
+	switch {
+	case IsOptType(field.Type()):
+		field.Field(1).SetBool(false)
+		return setMultiValue(OptValue(field), values, binding)
+	case field.Kind() == reflect.Ptr:
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return setMultiValue(field.Elem(), values, binding)
+	case field.Kind() != reflect.Slice:
+		return fmt.Errorf("%w: %s takes a single value", ErrUnsupportedFieldType, field.Type())
+	}
+
+	elems := make([]string, len(values))
+	for i, value := range values {
+		raw, err := transformValue(value, binding)
+		if err != nil {
+			return err
+		}
+		elems[i] = raw
+	}
+	return setSliceElems(field, elems, binding.Modifiers)
+}
@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type multiValueRequest struct {
+	Tags   []string   `query:"tags,omitempty" default:""`
+	IDs    []int      `query:"id,omitempty" default:""`
+	Split  []int      `query:"split,explode=false,omitempty" default:""`
+	Langs  *[]string  `header:"Accept-Language,omitempty" default:""`
+	Parts  []string   `header:"X-Parts,explode=false,omitempty" default:""`
+	Limits Opt[[]int] `query:"limit,omitempty"`
+}
+
+func newMultiValueRequest(t testing.TB, rawQuery string) *http.Request {
+	req := newTestRequest(t, "GET", "http://example.com/?"+rawQuery, nil)
+	req.Header.Add("Accept-Language", "en")
+	req.Header.Add("Accept-Language", "fr")
+	req.Header.Set("X-Parts", "a, b,c")
+	return req
+}
+
+func TestIsMultiValueType(t *testing.T) {
+	tests := []struct {
+		typ   reflect.Type
+		multi bool
+	}{
+		{reflect.TypeFor[[]string](), true},
+		{reflect.TypeFor[*[]int](), true},
+		{reflect.TypeFor[Opt[[]float64]](), true},
+		{reflect.TypeFor[[]byte](), false},
+		{reflect.TypeFor[[2]string](), false},
+		{reflect.TypeFor[string](), false},
+		{reflect.TypeFor[textSlice](), false},
+		{reflect.TypeFor[jsonSlice](), false},
+		{reflect.TypeFor[[]CustomJSONEnum](), true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.multi, isMultiValueType(test.typ), test.typ.String())
+	}
+}
+
+// textSlice is a slice type unmarshaled from a single value.
+type textSlice []string
+
+func (ips *textSlice) UnmarshalText(text []byte) error {
+	*ips = textSlice{string(text)}
+	return nil
+}
+
+// jsonSlice is a slice type unmarshaled from a single JSON value.
+type jsonSlice []string
+
+func (s *jsonSlice) UnmarshalJSON(data []byte) error {
+	*s = jsonSlice{string(data)}
+	return nil
+}
+
+func TestHTTPRequestParser_MultiValues(t *testing.T) {
+	for name, parser := range map[string]*HTTPRequestParser{
+		"Direct": NewHTTPRequestParser(),
+		"Cached": NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{DirectBindingLimit: -1}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var dest multiValueRequest
+			err := parser.Parse(newMultiValueRequest(t, "tags=a&id=1&tags=b&id=&id=3&split=4,5&split=6&limit=7"), &dest)
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"a", "b"}, dest.Tags)
+			assert.Equal(t, []int{1, 0, 3}, dest.IDs, "empty values leave the zero value")
+			assert.Equal(t, []int{4, 5}, dest.Split, "explode=false splits the first value")
+			assert.Equal(t, &[]string{"en", "fr"}, dest.Langs)
+			assert.Equal(t, []string{"a", "b", "c"}, dest.Parts)
+			assert.Equal(t, Some([]int{7}), dest.Limits)
+		})
+	}
+
+	t.Run("Missing", func(t *testing.T) {
+		var dest multiValueRequest
+		require.NoError(t, NewHTTPRequestParser().Parse(newMultiValueRequest(t, ""), &dest))
+		assert.Nil(t, dest.Tags)
+		assert.False(t, dest.Limits.Set)
+	})
+
+	t.Run("InvalidElement", func(t *testing.T) {
+		err := NewHTTPRequestParser().Parse(newMultiValueRequest(t, "id=1&id=x"), &multiValueRequest{})
+
+		var valueErr *ValueError
+		require.ErrorAs(t, err, &valueErr)
+		assert.Equal(t, "1,x", valueErr.Value)
+	})
+
+	t.Run("Bracket", func(t *testing.T) {
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{QueryDecoding: QueryDecodingBracket})
+
+		var dest multiValueRequest
+		require.NoError(t, parser.Parse(newMultiValueRequest(t, "tags[]=a&tags[]=b&id[]=2"), &dest))
+		assert.Equal(t, []string{"a", "b"}, dest.Tags)
+		assert.Equal(t, []int{2}, dest.IDs)
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		parser := NewHTTPRequestParser()
+
+		var dest multiValueRequest
+		report, err := parser.ParseWithReport(newMultiValueRequest(t, "tags=a&tags=b"), &dest)
+		require.NoError(t, err)
+
+		var replayed multiValueRequest
+		require.NoError(t, parser.Replay(report.Snapshot(), &replayed))
+		assert.Equal(t, dest, replayed)
+	})
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// DefaultMultipartMaxMemory is the number of bytes of multipart bodies
+// kept in memory unless HTTPRequestParserOpts.MultipartMaxMemory is set,
+// the default of http.Request.FormFile.
+const DefaultMultipartMaxMemory int64 = 32 << 20
+
+var (
+	_fileHeaderType  = reflect.TypeFor[*multipart.FileHeader]()
+	_fileHeadersType = reflect.TypeFor[[]*multipart.FileHeader]()
+)
+
+// MultipartValue gets the value of the multipart/form-data field key.
+// Form values are strings, and uploaded files the []*multipart.FileHeader
+// of the field, see setFileValue.
+//
+// The body is parsed once with http.Request.ParseMultipartForm, which
+// consumes it: handlers read the form from the MultipartForm of the
+// request instead, and the server removes its temporary files once the
+// handler returned.
+func (mgr *HTTPBindingManager) MultipartValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], key string,
+) BindingResult {
+
+	var (
+		form *multipart.Form
+		err  error
+	)
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		data.multipartOnce.Do(func() {
+			data.multipartForm, data.multipartError = mgr.readMultipartForm(source)
+		})
+		form, err = data.multipartForm, data.multipartError
+	})
+	if err != nil {
+		return BindingResultError(err)
+	}
+	if form == nil {
+		return BindingResultNotFound()
+	}
+
+	if values := form.Value[key]; len(values) > 0 {
+		return BindingResultValue(values[0])
+	}
+	if files := form.File[key]; len(files) > 0 {
+		return BindingResultValue(files)
+	}
+	return BindingResultNotFound()
+}
+
+// readMultipartForm parses the multipart/form-data body of source, unless
+// a handler already did. Requests without a body have no form.
+func (mgr *HTTPBindingManager) readMultipartForm(source *http.Request) (*multipart.Form, error) {
+	if source.MultipartForm != nil {
+		return source.MultipartForm, nil
+	}
+	if source.Body == nil || source.Body == http.NoBody || source.ContentLength == 0 {
+		return nil, nil
+	}
+
+	limit := mgr.opts.MaxBodyBytes
+	if limit > 0 {
+		if source.ContentLength > limit {
+			return nil, fmt.Errorf(
+				"%w: content length %d exceeds limit of %d bytes",
+				ErrBodyTooLarge, source.ContentLength, limit,
+			)
+		}
+		source.Body = http.MaxBytesReader(nil, source.Body, limit)
+	}
+
+	maxMemory := mgr.opts.MultipartMaxMemory
+	if maxMemory <= 0 {
+		maxMemory = DefaultMultipartMaxMemory
+	}
+
+	err := source.ParseMultipartForm(maxMemory)
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, http.ErrNotMultipart):
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, source.Header.Get("Content-Type"))
+	case errors.As(err, &maxBytesErr):
+		return nil, fmt.Errorf("%w: body exceeds limit of %d bytes", ErrBodyTooLarge, limit)
+	case err != nil:
+		return nil, fmt.Errorf("failed to read multipart form: %w", err)
+	}
+
+	return source.MultipartForm, nil
+}
+
+// setFileValue populates field from the files uploaded for a multipart
+// field: *multipart.FileHeader fields get the first file and
+// []*multipart.FileHeader fields all of them. Other fields, e.g. []byte
+// or string fields, are set from the content of the first file.
+func setFileValue(field reflect.Value, files []*multipart.FileHeader, binding Binding) error {
+	switch field.Type() {
+	case _fileHeaderType:
+		field.Set(reflect.ValueOf(files[0]))
+		return nil
+	case _fileHeadersType:
+		field.Set(reflect.ValueOf(files))
+		return nil
+	}
+
+	content, err := readFileHeader(files[0])
+	if err != nil {
+		return err
+	}
+
+	raw, err := transformValue(string(content), binding)
+	if err != nil {
+		return err
+	}
+	return setFieldValueWithModifiers(field, raw, binding.Modifiers)
+}
+
+// readFileHeader reads the content of an uploaded file.
+func readFileHeader(header *multipart.FileHeader) ([]byte, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file %s: %w", header.Filename, err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file %s: %w", header.Filename, err)
+	}
+	return content, nil
+}
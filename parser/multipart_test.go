@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMultipartRequest builds a multipart/form-data request with the given
+// values and files, keyed by field name.
+func newMultipartRequest(t *testing.T, values map[string]string, files map[string][]string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for key, value := range values {
+		require.NoError(t, writer.WriteField(key, value))
+	}
+	for key, contents := range files {
+		for i, content := range contents {
+			part, err := writer.CreateFormFile(key, key+string(rune('a'+i))+".txt")
+			require.NoError(t, err)
+			_, err = part.Write([]byte(content))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest("POST", "http://example.com/upload?name=query", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestHTTPRequestParser_Multipart(t *testing.T) {
+	type Upload struct {
+		Name    string                  `multipart:"name"`
+		Count   int                     `multipart:"count"`
+		Avatar  *multipart.FileHeader   `multipart:"avatar"`
+		Content []byte                  `multipart:"avatar,allowdup"`
+		Docs    []*multipart.FileHeader `multipart:"docs"`
+		Notes   string                  `multipart:"notes,omitempty" default:"none"`
+	}
+
+	newRequest := func(t *testing.T) *http.Request {
+		return newMultipartRequest(t,
+			map[string]string{"name": "jane", "count": "2"},
+			map[string][]string{"avatar": {"png bytes"}, "docs": {"one", "two"}},
+		)
+	}
+
+	t.Run("ValuesAndFiles", func(t *testing.T) {
+		var upload Upload
+		require.NoError(t, NewHTTPRequestParser().Parse(newRequest(t), &upload))
+
+		assert.Equal(t, "jane", upload.Name, "query values are not form values")
+		assert.Equal(t, 2, upload.Count)
+		require.NotNil(t, upload.Avatar)
+		assert.Equal(t, "avatara.txt", upload.Avatar.Filename)
+		assert.Equal(t, []byte("png bytes"), upload.Content)
+		require.Len(t, upload.Docs, 2)
+		assert.Equal(t, "docsb.txt", upload.Docs[1].Filename)
+		assert.Equal(t, "none", upload.Notes)
+	})
+
+	t.Run("HandlerReadsForm", func(t *testing.T) {
+		req := newRequest(t)
+
+		var upload Upload
+		require.NoError(t, NewHTTPRequestParser().Parse(req, &upload))
+
+		_, header, err := req.FormFile("avatar")
+		require.NoError(t, err)
+		assert.Equal(t, upload.Avatar, header)
+	})
+
+	t.Run("FilesOnDisk", func(t *testing.T) {
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{MultipartMaxMemory: 1})
+
+		var upload Upload
+		require.NoError(t, parser.Parse(newRequest(t), &upload))
+		assert.Equal(t, []byte("png bytes"), upload.Content)
+	})
+
+	t.Run("MaxBodyBytes", func(t *testing.T) {
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{MaxBodyBytes: 16})
+
+		var upload Upload
+		err := parser.Parse(newRequest(t), &upload)
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+
+	t.Run("NotMultipart", func(t *testing.T) {
+		req := newTestRequest(t, "POST", "http://example.com/upload", strings.NewReader(`{"name": "jane"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		var upload Upload
+		err := NewHTTPRequestParser().Parse(req, &upload)
+		assert.ErrorIs(t, err, ErrUnsupportedMediaType)
+	})
+
+	t.Run("NoBody", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/upload", nil)
+
+		var upload Upload
+		err := NewHTTPRequestParser().Parse(req, &upload)
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+	})
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrInvalidObjectKey = errors.New("invalid object key")
+)
+
+// HiveDefaultPartition is the value Hive-style writers, e.g. Spark, give
+// partitions of null values. ObjectKeyParser treats it as not found.
+const HiveDefaultPartition = "__HIVE_DEFAULT_PARTITION__"
+
+var (
+	// Default ObjectKeyParser Options
+	_objectKeyParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts: PCManagerOpts{
+			tagOpts: ParseTagOpts{
+				BindingOpts: BindingOpts{
+					AllowedBindingNames: []string{KeyTagBinding},
+					ValueModifiers: map[string]ModifierValueParser{
+						CurrencyBindingModifier: parseCurrencyModifier,
+					},
+				},
+				AllowedTagOptionals: []string{},
+			},
+		},
+	}
+)
+
+// ObjectKey is the key of an object in a bucket, e.g. of S3 or GCS, named
+// after the Hive partitioning convention such as
+// "tenant=acme/date=2024-01-02/type=events/hour=7/file.json". Keys may be
+// prefixed with the scheme and bucket of their URL, e.g. "s3://bucket/".
+type ObjectKey string
+
+// ObjectKeyParser parses the partitions of an ObjectKey.
+//
+// The following Field Bindings are supported:
+//   - key:'<name,[modifiers]>'`: Parses the value of the name=value
+//     segment of the key.
+//
+// For example:
+//
+//	type Partition struct {
+//		Tenant string `key:"tenant"`
+//		Date   string `key:"date"`
+//		Type   string `key:"type"`
+//		Hour   int    `key:"hour"`
+//	}
+//
+//	key := pave.ObjectKey(record.S3.Object.Key)
+//	err := pave.NewObjectKeyParser().Parse(&key, &partition)
+//
+// Segments without "=", such as the file name, are ignored; bind them
+// with a URLTemplateParser instead. Values are path unescaped like Hive
+// escapes them, and the HiveDefaultPartition value is not found. Keys
+// naming a partition more than once, or a partition without a name, fail
+// to parse with ErrInvalidObjectKey.
+type ObjectKeyParser struct {
+	*BaseMBParser[ObjectKey, ConfigFileOnce]
+}
+
+func NewObjectKeyParser() *ObjectKeyParser {
+	return &ObjectKeyParser{
+		BaseMBParser: NewBaseMBParser(
+			&configFileBindingManager[ObjectKey]{decode: decodeObjectKey},
+			_objectKeyParserOpts,
+		),
+	}
+}
+
+func (op *ObjectKeyParser) Name() string {
+	return ObjectKeyParserName
+}
+
+// decodeObjectKey decodes the name=value segments of an object key into
+// the values of its partitions.
+func decodeObjectKey(key ObjectKey) (map[string]string, error) {
+	partitions := make(map[string]string)
+	for _, segment := range strings.Split(string(key), "/") {
+		name, value, ok := strings.Cut(segment, "=")
+		if !ok {
+			continue
+		}
+
+		name, err := url.PathUnescape(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: segment %q: %w", ErrInvalidObjectKey, segment, err)
+		}
+		if name == "" {
+			return nil, fmt.Errorf("%w: segment %q has no partition name", ErrInvalidObjectKey, segment)
+		}
+		if _, exists := partitions[name]; exists {
+			return nil, fmt.Errorf("%w: partition %s is repeated", ErrInvalidObjectKey, name)
+		}
+
+		value, err = url.PathUnescape(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: segment %q: %w", ErrInvalidObjectKey, segment, err)
+		}
+		partitions[name] = value
+	}
+
+	// Null partitions are kept until here so that repeating them fails
+	for name, value := range partitions {
+		if value == HiveDefaultPartition {
+			delete(partitions, name)
+		}
+	}
+	return partitions, nil
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectKeyParser(t *testing.T) {
+	type Partition struct {
+		Tenant string `key:"tenant"`
+		Date   string `key:"date"`
+		Type   string `key:"type"`
+		Hour   int    `key:"hour,omitempty" default:"0"`
+	}
+
+	parser := NewObjectKeyParser()
+
+	for _, raw := range []string{
+		"tenant=acme/date=2024-01-02/type=events/file.json",
+		"s3://bucket/exports/tenant=acme/date=2024-01-02/type=events/part-0001.parquet",
+		"tenant=acme/date=2024-01-02/type=events/hour=__HIVE_DEFAULT_PARTITION__/file.json",
+	} {
+		var partition Partition
+		key := ObjectKey(raw)
+		require.NoError(t, parser.Parse(&key, &partition), raw)
+		assert.Equal(t, Partition{
+			Tenant: "acme",
+			Date:   "2024-01-02",
+			Type:   "events",
+		}, partition, raw)
+	}
+
+	t.Run("Conversion", func(t *testing.T) {
+		var partition Partition
+		key := ObjectKey("tenant=acme%2Feu/date=2024-01-02/type=events/hour=7/file.json")
+		require.NoError(t, parser.Parse(&key, &partition))
+		assert.Equal(t, "acme/eu", partition.Tenant)
+		assert.Equal(t, 7, partition.Hour)
+
+		var hourly struct {
+			Hour int `key:"hour"`
+		}
+		noon := ObjectKey("tenant=acme/date=2024-01-02/type=events/hour=noon/file.json")
+		assert.Error(t, parser.Parse(&noon, &hourly))
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		key := ObjectKey("tenant=acme/type=events/file.json")
+		err := parser.Parse(&key, &Partition{})
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, raw := range []string{
+			"tenant=acme/tenant=other/file.json",
+			"=acme/file.json",
+			"tenant=acme%zz/file.json",
+		} {
+			key := ObjectKey(raw)
+			err := parser.Parse(&key, &Partition{})
+			assert.ErrorIs(t, err, ErrInvalidObjectKey, raw)
+		}
+	})
+}
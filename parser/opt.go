@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// Opt is an optional value that tracks its presence, an alternative to
+// pointer fields:
+//
+//	type UpdateUser struct {
+//		Name pave.Opt[string] `json:"name,omitempty" default:"anonymous"`
+//		Age  pave.Opt[int]    `json:"age,omitempty"`
+//	}
+//
+// Parsers set Set only when a binding supplied the value. Defaults
+// populate Value but leave Set false, so handlers tell a value the client
+// sent from a default, and fields without a default are left unset rather
+// than failing. Validation rules other than required skip unset values,
+// as they skip nil pointers, and required passes for any set value.
+//
+// Unset values encode to JSON null, and null decodes to an unset value.
+// With the omitzero option of encoding/json, unset values are omitted.
+type Opt[T any] struct {
+	Value T    // The value, or the default of the field if not Set
+	Set   bool // Whether a binding supplied the value
+}
+
+// Some returns an Opt holding value.
+func Some[T any](value T) Opt[T] {
+	return Opt[T]{Value: value, Set: true}
+}
+
+// Get returns the value and whether it is set.
+func (o Opt[T]) Get() (T, bool) {
+	return o.Value, o.Set
+}
+
+// OrElse returns the value if it is set, and value otherwise.
+func (o Opt[T]) OrElse(value T) T {
+	if o.Set {
+		return o.Value
+	}
+	return value
+}
+
+// IsZero reports whether the value is unset, for the omitzero option of
+// encoding/json.
+func (o Opt[T]) IsZero() bool {
+	return !o.Set
+}
+
+func (o Opt[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+func (o *Opt[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Opt[T]{}
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &o.Value); err != nil {
+		return err
+	}
+	o.Set = true
+	return nil
+}
+
+// isOpt marks the instances of Opt, see IsOptType.
+func (Opt[T]) isOpt() {}
+
+// optional is implemented by every instance of Opt.
+type optional interface {
+	isOpt()
+}
+
+var _optionalType = reflect.TypeFor[optional]()
+
+// IsOptType reports whether typ is an instance of Opt.
+func IsOptType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && typ.Implements(_optionalType)
+}
+
+// OptValue returns the Value field of the Opt value opt.
+func OptValue(opt reflect.Value) reflect.Value {
+	return opt.Field(0)
+}
+
+// isZeroOrUnset reports whether value is the zero value, or an unset Opt.
+func isZeroOrUnset(value reflect.Value) bool {
+	if IsOptType(value.Type()) {
+		return !value.Field(1).Bool()
+	}
+	return value.IsZero()
+}
+
+// markOptSet sets the Set field of field, if it is an Opt (or a pointer
+// to one), after a binding supplied its value.
+func markOptSet(field reflect.Value) {
+	for field.Kind() == reflect.Ptr && !field.IsNil() {
+		field = field.Elem()
+	}
+	if IsOptType(field.Type()) {
+		field.Field(1).SetBool(true)
+	}
+}
@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type optUpdate struct {
+	Name  Opt[string] `query:"name,omitempty" default:"anonymous"`
+	Age   Opt[int]    `query:"age,omitempty"`
+	Email Opt[string] `json:"email,omitempty"`
+	Tags  Opt[[]string]
+}
+
+func TestOpt_Parse(t *testing.T) {
+	parser := NewHTTPRequestParser()
+
+	t.Run("Supplied", func(t *testing.T) {
+		req := newTestRequest(t, "PATCH", "http://example.com/?name=jane&age=30", strings.NewReader(`{"email": "jane@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		var update optUpdate
+		require.NoError(t, parser.Parse(req, &update))
+		assert.Equal(t, Some("jane"), update.Name)
+		assert.Equal(t, Some(30), update.Age)
+		assert.Equal(t, Some("jane@example.com"), update.Email)
+	})
+
+	t.Run("Defaulted", func(t *testing.T) {
+		req := newTestRequest(t, "PATCH", "http://example.com/", nil)
+
+		update := optUpdate{Age: Some(40)}
+		require.NoError(t, parser.Parse(req, &update))
+		assert.Equal(t, Opt[string]{Value: "anonymous"}, update.Name, "defaults are not set")
+		assert.Equal(t, Some(40), update.Age, "fields not found keep their value")
+		assert.False(t, update.Email.Set)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		req := newTestRequest(t, "PATCH", "http://example.com/?age=old", nil)
+
+		var update optUpdate
+		assert.Error(t, parser.Parse(req, &update))
+		assert.False(t, update.Age.Set)
+	})
+}
+
+func TestOpt_JSON(t *testing.T) {
+	type Document struct {
+		Name  Opt[string] `json:"name"`
+		Age   Opt[int]    `json:"age,omitzero"`
+		Email Opt[string] `json:"email"`
+	}
+
+	data, err := json.Marshal(Document{Name: Some("jane")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "jane", "email": null}`, string(data))
+
+	var document Document
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "jane", "age": 0, "email": null}`), &document))
+	assert.Equal(t, Document{Name: Some("jane"), Age: Some(0)}, document)
+
+	value, ok := document.Age.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 0, value)
+	assert.Equal(t, "none", document.Email.OrElse("none"))
+}
+
+func TestOpt_Encode(t *testing.T) {
+	values, err := EncodeQuery(&optUpdate{Name: Some("jane"), Age: Opt[int]{Value: 30}})
+	require.NoError(t, err)
+	assert.Equal(t, "jane", values.Get("name"))
+	assert.False(t, values.Has("age"), "unset values are omitted")
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidPage   = errors.New("invalid page")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Page sizes of Page and Cursor. DefaultPageSize is the default of their
+// size and limit query parameters, whose default tags spell it out as
+// tags cannot refer to constants (a test keeps them in sync).
+const (
+	DefaultPageSize int = 20
+	MaxPageSize     int = 100
+)
+
+// Page holds offset pagination query parameters, e.g. ?page=3&size=50.
+// Embed it in a request struct:
+//
+//	type ListUsers struct {
+//		pave.Page
+//		Role string `query:"role,omitempty" default:"member"`
+//	}
+//
+// Pages are numbered from 1 and hold DefaultPageSize items unless the
+// request says otherwise. Validate rejects page sizes above MaxPageSize;
+// as a Validatable, it runs after parsing through a ParserRegistry.
+//
+// Embed either Page or Cursor: with both, their promoted Validate methods
+// cancel each other out, so the struct is not a Validatable unless it
+// declares its own Validate method (which may call both).
+type Page struct {
+	Number int `query:"page,omitempty" default:"1"`
+	Size   int `query:"size,omitempty" default:"20"` // DefaultPageSize
+}
+
+// Validate checks that the page number is positive and that the page
+// size is between 1 and MaxPageSize.
+func (p Page) Validate() error {
+	return p.ValidateMax(MaxPageSize)
+}
+
+// ValidateMax is Validate with a maximum page size of maxSize.
+func (p Page) ValidateMax(maxSize int) error {
+	if p.Number < 1 {
+		return fmt.Errorf("%w: page %d, pages start at 1", ErrInvalidPage, p.Number)
+	}
+	if p.Size < 1 || p.Size > maxSize {
+		return fmt.Errorf("%w: size %d, expected 1 to %d", ErrInvalidPage, p.Size, maxSize)
+	}
+	return nil
+}
+
+// Offset returns the number of items before the page, (Number-1)*Size.
+func (p Page) Offset() int {
+	return max(p.Number-1, 0) * p.Size
+}
+
+// Limit returns the number of items of the page.
+func (p Page) Limit() int {
+	return p.Size
+}
+
+// Cursor holds cursor pagination query parameters, e.g.
+// ?cursor=eyJpZCI6NDJ9&limit=50. Embed it in a request struct like Page.
+//
+// The cursor is opaque to clients: servers create it with EncodeCursor
+// from the position after the last item of a page, and read it back with
+// Decode. An absent cursor requests the first page. See Page about
+// embedding both.
+type Cursor struct {
+	Token string `query:"cursor,omitempty" default:""`
+	Limit int    `query:"limit,omitempty" default:"20"` // DefaultPageSize
+}
+
+// Validate checks that the limit is between 1 and MaxPageSize.
+func (c Cursor) Validate() error {
+	return c.ValidateMax(MaxPageSize)
+}
+
+// ValidateMax is Validate with a maximum limit of maxLimit.
+func (c Cursor) ValidateMax(maxLimit int) error {
+	if c.Limit < 1 || c.Limit > maxLimit {
+		return fmt.Errorf("%w: limit %d, expected 1 to %d", ErrInvalidPage, c.Limit, maxLimit)
+	}
+	return nil
+}
+
+// IsFirst reports whether the cursor requests the first page.
+func (c Cursor) IsFirst() bool {
+	return c.Token == ""
+}
+
+// Decode decodes the position of the cursor, as encoded by EncodeCursor,
+// into position. It fails with ErrInvalidCursor for tokens that were not.
+func (c Cursor) Decode(position any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(c.Token)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if err := json.Unmarshal(raw, position); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return nil
+}
+
+// EncodeCursor encodes position, e.g. the sort key of the last item of a
+// page, into the opaque cursor token of the next page.
+func EncodeCursor(position any) (string, error) {
+	raw, err := json.Marshal(position)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
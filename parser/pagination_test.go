@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage(t *testing.T) {
+	type ListUsers struct {
+		Page
+		Role string `query:"role,omitempty" default:"member"`
+	}
+
+	parser := NewHTTPRequestParser()
+
+	var list ListUsers
+	require.NoError(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/users", nil), &list))
+	assert.Equal(t, Page{Number: 1, Size: DefaultPageSize}, list.Page)
+	assert.Equal(t, 0, list.Offset())
+	assert.Equal(t, DefaultPageSize, list.Limit())
+
+	list = ListUsers{}
+	require.NoError(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/users?page=3&size=50", nil), &list))
+	assert.Equal(t, 100, list.Offset())
+	assert.Equal(t, 50, list.Limit())
+	assert.NoError(t, list.Validate())
+
+	tests := []struct {
+		page Page
+		ok   bool
+	}{
+		{Page{Number: 1, Size: 1}, true},
+		{Page{Number: 1, Size: MaxPageSize}, true},
+		{Page{Number: 0, Size: 10}, false},
+		{Page{Number: 1, Size: 0}, false},
+		{Page{Number: 1, Size: MaxPageSize + 1}, false},
+	}
+	for _, tt := range tests {
+		err := tt.page.Validate()
+		if tt.ok {
+			assert.NoError(t, err, tt.page)
+		} else {
+			assert.ErrorIs(t, err, ErrInvalidPage, tt.page)
+		}
+	}
+	assert.ErrorIs(t, Page{Number: 1, Size: 30}.ValidateMax(25), ErrInvalidPage)
+
+	t.Run("Registry", func(t *testing.T) {
+		reg, err := NewParserRegistry(ParserRegistryOpts{Parsers: []Parser{NewHTTPRequestParser()}})
+		require.NoError(t, err)
+
+		var list ListUsers
+		err = reg.Parse(newTestRequest(t, "GET", "http://example.com/users?size=500", nil), &list, true)
+		assert.ErrorIs(t, err, ErrInvalidPage, "Validate is promoted to the embedding struct")
+
+		var validation *ValidationError
+		assert.ErrorAs(t, err, &validation)
+	})
+}
+
+func TestCursor(t *testing.T) {
+	type ListEvents struct {
+		Cursor
+	}
+
+	type position struct {
+		ID int64 `json:"id"`
+	}
+
+	parser := NewHTTPRequestParser()
+
+	req := newTestRequest(t, "GET", "http://example.com/events", nil)
+
+	var list ListEvents
+	require.NoError(t, parser.Parse(req, &list))
+	assert.Equal(t, Cursor{Limit: DefaultPageSize}, list.Cursor)
+	assert.True(t, list.IsFirst())
+
+	token, err := EncodeCursor(position{ID: 42})
+	require.NoError(t, err)
+
+	req = newTestRequest(t, "GET", "http://example.com/events?limit=5&cursor="+token, nil)
+	list = ListEvents{}
+	require.NoError(t, parser.Parse(req, &list))
+	assert.False(t, list.IsFirst())
+	assert.Equal(t, 5, list.Limit)
+	assert.NoError(t, list.Validate())
+
+	var pos position
+	require.NoError(t, list.Decode(&pos))
+	assert.Equal(t, position{ID: 42}, pos)
+
+	assert.ErrorIs(t, Cursor{Token: "not base64!"}.Decode(&pos), ErrInvalidCursor)
+	assert.ErrorIs(t, Cursor{Token: "bm90IGpzb24"}.Decode(&pos), ErrInvalidCursor)
+	assert.ErrorIs(t, Cursor{Limit: MaxPageSize + 1}.Validate(), ErrInvalidPage)
+	assert.ErrorIs(t, Cursor{Limit: 0}.ValidateMax(10), ErrInvalidPage)
+}
+
+func TestPagination_DefaultPageSize(t *testing.T) {
+	size, _ := reflect.TypeFor[Page]().FieldByName("Size")
+	limit, _ := reflect.TypeFor[Cursor]().FieldByName("Limit")
+
+	assert.Equal(t, strconv.Itoa(DefaultPageSize), size.Tag.Get("default"))
+	assert.Equal(t, strconv.Itoa(DefaultPageSize), limit.Tag.Get("default"))
+}
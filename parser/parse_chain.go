@@ -0,0 +1,1105 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"mime/multipart"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrNoStepBindings is returned when a parse step has no bindings
+	// to execute. This can happen if the field is a struct with no bindings,
+	// it will be skipped in the parse chain.
+	ErrNoStepBindings             = fmt.Errorf("no bindings found for field")
+	ErrFailedToParseTag           = fmt.Errorf("failed to parse tag for field")
+	ErrAllBindingsFailedNoDefault = fmt.Errorf("All bindings failed with no default value for field")
+	ErrFailedToBuildSubChain      = fmt.Errorf("failed to build sub-chain for field")
+	ErrNilParseChain              = fmt.Errorf("parse chain is empty for type")
+	ErrRequiredFieldNotFound      = fmt.Errorf("required field not found")
+	ErrParseStepNotFound          = fmt.Errorf("no parse step found for field")
+	ErrFailedToHandleCustomTags   = fmt.Errorf("failed to handle custom tags for field")
+	ErrNoSubChain                 = fmt.Errorf("no sub-chain available for struct field")
+	ErrFieldNotAddressable        = fmt.Errorf("cannot get address of struct field for recursive parsing")
+	ErrJSONTagMismatch            = fmt.Errorf("json tag and bindings of field do not match")
+)
+
+// RequiredFieldError is returned when a required binding found no value
+// in its source. It matches ErrRequiredFieldNotFound with errors.Is.
+type RequiredFieldError struct {
+	Identifier string // Identifier of the binding that found no value
+	Binding    string // Name of the binding
+}
+
+func (e *RequiredFieldError) Error() string {
+	return fmt.Sprintf("required field %s not found in source %s", e.Identifier, e.Binding)
+}
+
+func (e *RequiredFieldError) Is(target error) bool {
+	return target == ErrRequiredFieldNotFound
+}
+
+// Transient reports false: a missing value stays missing. See IsTransient.
+func (e *RequiredFieldError) Transient() bool {
+	return false
+}
+
+// FieldError is returned by a ParseChain when a single field could not be
+// populated, and by validation for fields that failed their rules. Errors
+// of nested struct fields are wrapped in the FieldError of their parent
+// field, use FieldPath to get the full path.
+type FieldError struct {
+	Field      string // Name of the struct field that failed
+	Err        error  // Underlying cause
+	Validation bool   // Whether the field failed validation rather than parsing
+}
+
+func (e *FieldError) Error() string {
+	if e.Validation {
+		return fmt.Sprintf("invalid field %s: %s", e.Field, e.Err)
+	}
+	return fmt.Sprintf("failed to parse field %s: %s", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldPath returns the dotted path of the innermost field that failed
+// to parse (e.g. "Address.Street"), or "" if err contains no FieldError.
+func FieldPath(err error) string {
+	var (
+		path []string
+		fe   *FieldError
+	)
+
+	for errors.As(err, &fe) {
+		path = append(path, fe.Field)
+		err = fe.Err
+	}
+
+	return strings.Join(path, ".")
+}
+
+// ParseChain represents a linked list of parse steps for a struct type
+//
+// Uses a function-based approach for binding value retrieval, eliminating
+// the need for each parser to reimplement the same linked list traversal logic.
+//
+// The BindingHandlerFunc provides dynamic dispatch to the appropriate
+// value retrieval method for each parser type.
+//
+// # It takes one generic type S
+//
+// S is the Go Type that data will be sourced from (e.g http.Request)
+//
+// # Chains are immutable once built
+//
+// Chains are cached by the PCManager and shared by every concurrent
+// Execute call, so neither the chain nor its steps may be modified after
+// they were built. Use Derive (or PCManager.UpdateParseChain) to obtain
+// a modified copy instead.
+type ParseChain[S any] struct {
+	StructType reflect.Type          // StructType is the type of the struct being parsed
+	Head       *ParseStep[S]         // Head is the first step in the chain
+	Handler    BindingHandlerFunc[S] // Function to get values from sources
+	Version    uint64                // Incremented each time a chain is derived from this one
+	Defaults   []FieldDefault        // Defaults of the fields no step populates, applied after the steps
+	Checksums  []FieldChecksum       // Checksums verified after the steps and defaults, see ChecksumBindingModifier
+	Executor   ChainExecutor[S]      // Executes the chain in place of its steps, see ChainCompiler. Nil if not compiled.
+}
+
+// FieldDefault is the `default` tag of a field without bindings. It is
+// applied after the steps of its chain ran if the field is still zero, so
+// that sub-structs absent from the source still get their defaults.
+type FieldDefault struct {
+	FieldIndex int    // Index of the field in the struct
+	FieldName  string // Name of the field for error reporting
+	Value      string // Value of the default tag
+}
+
+// ParseStep represents a single step in the execution chain
+type ParseStep[S any] struct {
+	Next          *ParseStep[S]  // Next is the next step in the current chain.
+	SubChain      *ParseChain[S] // Sub-chain for recursive struct parsing. Nil if not a struct field.
+	Bindings      []Binding      // Ordered list of bindings to try
+	FieldName     string         // Name of the field for error reporting
+	DefaultValue  string         // Default value for the field if bindings fail and not required to succeed
+	HasDefault    bool           // Whether DefaultValue is set, as `default:""` sets an empty string field
+	Part          string         // Sub-value selected from multi-value binding results. Empty selects the whole value.
+	IsStruct      bool           // if this field is a struct that needs recursive parsing
+	ShouldRecurse bool           // Indicates whether the struct-type field gets 1-step populated by binding or not
+	FieldIndex    int            // Index of the field in the struct
+	Metadata      map[string]any // Metadata attached by the CustomTagHandler. Nil if none.
+	Unexported    bool           // Whether the field is unexported, see PCManagerOpts.UnexportedFields
+	Setter        reflect.Value  // Setter method populating the unexported field. Invalid unless UnexportedFieldsSetter.
+	PII           string         // Category of personal data held by the field, see PIIManifest. Empty if none.
+}
+
+// Execute runs the entire parse chain using the provided source getter
+func (chain *ParseChain[S]) Execute(
+	source *S, dest any,
+) error {
+	return chain.execute(source, dest, execution[S]{}, "")
+}
+
+// ExecuteWithReport is Execute, also timing every step. See ParseReport.
+func (chain *ParseChain[S]) ExecuteWithReport(
+	source *S, dest any,
+) (*ParseReport, error) {
+
+	return chain.executeWith(source, dest, nil)
+}
+
+// execution is the state shared by the steps of one execution of a chain
+// and its sub-chains.
+type execution[S any] struct {
+	handler BindingHandlerFunc[S] // Overrides the Handler of the chains unless nil
+	report  *ParseReport          // Collects the timings of the steps unless nil
+	steps   bool                  // Whether compiled chains run their steps rather than their Executor
+}
+
+// handle gets the value of binding from source with the handler of exec,
+// or else with that of chain.
+func (chain *ParseChain[S]) handle(exec execution[S], source *S, binding Binding) BindingResult {
+	if exec.handler != nil {
+		return exec.handler(source, binding)
+	}
+	return chain.Handler(source, binding)
+}
+
+// executeWith is ExecuteWithReport, getting the values of the bindings
+// with handler instead of the Handler of the chains unless it is nil.
+func (chain *ParseChain[S]) executeWith(
+	source *S, dest any, handler BindingHandlerFunc[S],
+) (*ParseReport, error) {
+
+	report := &ParseReport{Type: chain.StructType}
+
+	start := time.Now()
+	err := chain.execute(source, dest, execution[S]{handler: handler, report: report}, "")
+	report.Duration = time.Since(start)
+
+	return report, err
+}
+
+// execute runs the chain as exec describes. prefix is the path of the
+// struct field being parsed by a sub-chain, for reporting.
+func (chain *ParseChain[S]) execute(
+	source *S, dest any, exec execution[S], prefix string,
+) error {
+
+	// Compiled chains run their executor, unless their steps are timed
+	if chain.Executor != nil && exec.report == nil && !exec.steps {
+		handler := exec.handler
+		if handler == nil {
+			handler = chain.Handler
+		}
+		if err := chain.Executor.Execute(source, dest, handler); err != nil {
+			return err
+		}
+		return chain.finish(dest)
+	}
+
+	if chain.empty() {
+		return fmt.Errorf(
+			"%w: %s",
+			ErrNilParseChain,
+			chain.StructType.Name(),
+		)
+	}
+
+	// Traverse the chain and execute each step
+	current := chain.Head
+	for current != nil {
+		// Execute current step
+		err := chain.doStep(source, dest, current, exec, prefix)
+		if err != nil {
+			return &FieldError{Field: current.FieldName, Err: err}
+		}
+		current = current.Next
+	}
+
+	return chain.finish(dest)
+}
+
+// finish applies the defaults of the chain and verifies its checksums
+// once its steps populated dest.
+func (chain *ParseChain[S]) finish(dest any) error {
+	if err := chain.applyDefaults(dest); err != nil {
+		return err
+	}
+	return chain.verifyChecksums(dest)
+}
+
+// empty reports whether the chain populates nothing.
+func (chain *ParseChain[S]) empty() bool {
+	return chain.Head == nil && len(chain.Defaults) == 0
+}
+
+// applyDefaults sets the fields of chain.Defaults that are still zero.
+func (chain *ParseChain[S]) applyDefaults(dest any) error {
+	if len(chain.Defaults) == 0 {
+		return nil
+	}
+
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() == reflect.Ptr {
+		destValue = destValue.Elem()
+	}
+
+	for _, def := range chain.Defaults {
+		field := destValue.Field(def.FieldIndex)
+		if !field.CanSet() || !field.IsZero() {
+			continue
+		}
+		if err := setFieldValueWithModifiers(field, def.Value, BindingModifiers{}); err != nil {
+			return &FieldError{Field: def.FieldName, Err: err}
+		}
+	}
+	return nil
+}
+
+// doStep executes a single parse step
+func (chain *ParseChain[S]) doStep(
+	sourceData *S, dest any, step *ParseStep[S], exec execution[S], prefix string,
+) error {
+
+	// Ensure we have a valid destination value
+	destValue := reflect.ValueOf(dest)
+	if destValue.Kind() == reflect.Ptr {
+		destValue = destValue.Elem()
+	}
+
+	field := destValue.Field(step.FieldIndex)
+
+	if step.Setter.IsValid() {
+		// Populate a copy of the field that is handed to the setter
+		value := reflect.New(field.Type()).Elem()
+		if err := chain.populateField(sourceData, value, step, exec, prefix); err != nil {
+			return err
+		}
+		return callFieldSetter(step.Setter, destValue.Addr(), value)
+	}
+	if step.Unexported {
+		field = exposeField(field)
+	}
+
+	if !field.CanSet() {
+		return nil
+	}
+
+	return chain.populateField(sourceData, field, step, exec, prefix)
+}
+
+// populateField populates field as step describes.
+func (chain *ParseChain[S]) populateField(
+	sourceData *S, field reflect.Value, step *ParseStep[S], exec execution[S], prefix string,
+) error {
+
+	if step.IsStruct && step.ShouldRecurse {
+		return chain.doStepRecursive(sourceData, field, step, exec, prefix+step.FieldName+".")
+	}
+
+	if exec.report == nil {
+		return chain.doStepRegular(sourceData, field, step, exec, nil)
+	}
+
+	timing := StepTiming{Field: prefix + step.FieldName}
+
+	start := time.Now()
+	err := chain.doStepRegular(sourceData, field, step, exec, &timing)
+	timing.Duration = time.Since(start)
+	timing.Err = err
+
+	exec.report.Steps = append(exec.report.Steps, timing)
+	return err
+}
+
+// doStepRegular handles parsing of regular (non-struct) fields, getting
+// the values of the bindings as exec describes. timing, if not nil,
+// records the time spent in the binding handler and the binding the
+// field was populated from.
+func (chain *ParseChain[S]) doStepRegular(
+	sourceData *S, field reflect.Value, step *ParseStep[S], exec execution[S], timing *StepTiming,
+) error {
+
+	allOmitEmpty := true
+	allOmitError := true
+	allOmitNil := true
+	var errs error
+
+	for _, binding := range step.Bindings {
+		modifiers := binding.Modifiers
+
+		allOmitEmpty = allOmitEmpty && modifiers.OmitEmpty
+		allOmitError = allOmitError && modifiers.OmitError
+		allOmitNil = allOmitNil && modifiers.OmitNil
+
+		var result BindingResult
+		if timing != nil {
+			start := time.Now()
+			result = chain.handle(exec, sourceData, binding)
+			timing.BindingDuration += time.Since(start)
+			timing.Reads = append(timing.Reads, newBindingRead(binding, step, result))
+		} else {
+			result = chain.handle(exec, sourceData, binding)
+		}
+
+		if result.Error != nil {
+			if result.Fatal {
+				return appendStepError(errs, result.Error)
+			}
+			if modifiers.OmitError {
+				continue
+			}
+
+			errs = appendStepError(errs, result.Error)
+
+			if modifiers.Required {
+				return errs
+			}
+			continue
+		}
+
+		value, found := result.Part(step.Part)
+		if found {
+			if files, ok := value.([]*multipart.FileHeader); ok {
+				if timing != nil {
+					timing.Binding = binding
+				}
+				return setFileValue(field, files, binding)
+			}
+			if _, ok := value.(absentPrecondition); ok {
+				if timing != nil {
+					timing.Binding = binding
+				}
+				field.SetZero()
+				return nil
+			}
+			if values, ok := value.([]string); ok && binding.Multi {
+				if timing != nil {
+					timing.Binding = binding
+				}
+				if err := setMultiValue(field, values, binding); err != nil {
+					return &ValueError{Value: strings.Join(values, CommaDelimeter), PII: step.PII, Err: err}
+				}
+				markOptSet(field)
+				return nil
+			}
+			if value != nil {
+				formatted := step.formatValue(value)
+				raw, err := transformValue(formatted, binding)
+				if err != nil {
+					return &ValueError{Value: formatted, PII: step.PII, Err: err}
+				}
+				if timing != nil {
+					timing.Binding = binding
+				}
+				if err := setFieldValueWithModifiers(field, raw, modifiers); err != nil {
+					return &ValueError{Value: raw, PII: step.PII, Err: err}
+				}
+				markOptSet(field)
+				return nil
+			}
+			if modifiers.OmitNil {
+				continue
+			}
+		}
+
+		if modifiers.Required {
+			return &RequiredFieldError{
+				Identifier: binding.Identifier,
+				Binding:    binding.Name,
+			}
+		}
+	}
+
+	// If all sources have failed/have no data, and default value given, thats ok
+	if allOmitEmpty || allOmitError || allOmitNil {
+		if step.DefaultValue != "" || step.HasDefault {
+			if timing != nil {
+				timing.Default = true
+			}
+			return setFieldValueWithModifiers(field, step.DefaultValue, step.defaultModifiers())
+		} else if !IsOptType(field.Type()) {
+			// Opt fields need no default, they are left unset
+			errs = appendStepError(
+				errs, fmt.Errorf("%w %s", ErrAllBindingsFailedNoDefault, step.FieldName),
+			)
+		}
+	}
+
+	return errs
+}
+
+// formatValue formats a value found for the step as the string its field
+// is set from. Structs parsed in one shot (`recursive:"false"`) are set
+// from JSON, so sub-documents found as Go values (e.g. nested maps) are
+// encoded as JSON for them.
+func (step *ParseStep[S]) formatValue(value any) string {
+	// All the values of Multi bindings, which their slices are set from
+	if values, ok := value.([]string); ok {
+		if document, err := json.Marshal(values); err == nil {
+			return string(document)
+		}
+	}
+	if step.IsStruct && !step.ShouldRecurse {
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Map, reflect.Slice, reflect.Struct:
+			if document, err := json.Marshal(value); err == nil {
+				return string(document)
+			}
+		}
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// appendStepError adds err to the errors of the bindings of a step tried
+// so far, errs, which may be nil.
+func appendStepError(errs error, err error) error {
+	if errs == nil {
+		return err
+	}
+	return fmt.Errorf("%w: %w", errs, err)
+}
+
+// defaultModifiers returns the modifiers the default value of the step is
+// converted with, which are those of its highest priority binding.
+func (step *ParseStep[S]) defaultModifiers() BindingModifiers {
+	if len(step.Bindings) == 0 {
+		return BindingModifiers{}
+	}
+	return step.Bindings[0].Modifiers
+}
+
+// recursiveStructType returns the struct type that a field of type typ
+// is recursively parsed into, if any: typ itself or the type it points
+// to, unless it is a special struct type populated like a primitive.
+func recursiveStructType(typ reflect.Type) (structType reflect.Type, isPtr bool, ok bool) {
+	if typ.Kind() == reflect.Ptr {
+		typ, isPtr = typ.Elem(), true
+	}
+	if typ.Kind() != reflect.Struct || IsSpecialStructType(typ) {
+		return nil, false, false
+	}
+	return typ, isPtr, true
+}
+
+// doStepRecursive handles recursive parsing of struct fields
+func (chain *ParseChain[S]) doStepRecursive(
+	sourceData *S,
+	field reflect.Value,
+	step *ParseStep[S],
+	exec execution[S],
+	prefix string,
+) error {
+
+	if step.SubChain == nil {
+		return fmt.Errorf("%w %s", ErrNoSubChain, step.FieldName)
+	}
+
+	// Handle pointer vs non-pointer struct fields for sub-chains
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			// Create new instance for pointer field
+			newValue := reflect.New(field.Type().Elem())
+			field.Set(newValue)
+		}
+		// Execute on pointer
+		return step.SubChain.execute(sourceData, field.Interface(), exec, prefix)
+	} else {
+		if field.Kind() == reflect.Struct && field.CanAddr() {
+			fieldAddr := field.Addr()
+			// Execute on struct
+			return step.SubChain.execute(sourceData, fieldAddr.Interface(), exec, prefix)
+		} else {
+			return fmt.Errorf("%w %s", ErrFieldNotAddressable, step.FieldName)
+		}
+	}
+}
+
+// Clone returns a deep copy of the chain, including its steps, their
+// bindings and all sub-chains. The copy shares nothing mutable with chain.
+func (chain *ParseChain[S]) Clone() *ParseChain[S] {
+	clone := *chain
+	clone.Defaults = slices.Clone(chain.Defaults)
+	clone.Checksums = slices.Clone(chain.Checksums)
+
+	var prev *ParseStep[S]
+	for current := chain.Head; current != nil; current = current.Next {
+		step := *current
+		step.Next = nil
+		step.Bindings = cloneBindings(current.Bindings)
+		step.Metadata = maps.Clone(current.Metadata)
+		if current.SubChain != nil {
+			step.SubChain = current.SubChain.Clone()
+		}
+
+		if prev == nil {
+			clone.Head = &step
+		} else {
+			prev.Next = &step
+		}
+		prev = &step
+	}
+
+	return &clone
+}
+
+// Step returns the step that populates the field at the dotted fieldPath
+// (e.g. "Address.Street"), descending into sub-chains as needed.
+func (chain *ParseChain[S]) Step(fieldPath string) (*ParseStep[S], bool) {
+	name, rest, nested := strings.Cut(fieldPath, ".")
+
+	for current := chain.Head; current != nil; current = current.Next {
+		if current.FieldName != name {
+			continue
+		}
+		if !nested {
+			return current, true
+		}
+		if current.SubChain == nil {
+			return nil, false
+		}
+		return current.SubChain.Step(rest)
+	}
+
+	return nil, false
+}
+
+// Derive returns a modified copy of the chain. modify receives a deep
+// copy that it may change freely; chain itself is left untouched, so
+// Execute calls running concurrently on it are unaffected.
+func (chain *ParseChain[S]) Derive(
+	modify func(*ParseChain[S]) error,
+) (*ParseChain[S], error) {
+
+	derived := chain.Clone()
+	derived.Executor = nil // Compiled from the steps modify may change
+	if err := modify(derived); err != nil {
+		return nil, err
+	}
+	derived.Version = chain.Version + 1
+
+	return derived, nil
+}
+
+// WithDefault returns a copy of the chain where the field at the dotted
+// fieldPath uses value as its default value.
+func (chain *ParseChain[S]) WithDefault(
+	fieldPath string, value string,
+) (*ParseChain[S], error) {
+
+	return chain.Derive(func(derived *ParseChain[S]) error {
+		step, found := derived.Step(fieldPath)
+		if !found {
+			return fmt.Errorf("%w: %s", ErrParseStepNotFound, fieldPath)
+		}
+		step.DefaultValue, step.HasDefault = value, true
+		return nil
+	})
+}
+
+// cloneBindings copies bindings along with their custom modifier maps.
+// Parsed modifier values themselves are shared.
+func cloneBindings(bindings []Binding) []Binding {
+	if bindings == nil {
+		return nil
+	}
+
+	cloned := make([]Binding, len(bindings))
+	copy(cloned, bindings)
+	for i := range cloned {
+		cloned[i].Modifiers.Custom = maps.Clone(cloned[i].Modifiers.Custom)
+		cloned[i].Modifiers.Values = maps.Clone(cloned[i].Modifiers.Values)
+	}
+
+	return cloned
+}
+
+// PCManager manages parse chains for different destination struct types.
+//
+// It is responsible creating, caching, retrieving, and executing parse chains
+// for a single source type. The source type is defined by the generic type
+// parameter Source, which is the type of data that will be parsed into
+// destination structs.
+//
+// The PCManager is thread-safe and can be used concurrently
+// across multiple goroutines.
+//
+// The BindingHandlerFunc is used to retrieve values from the source
+// based on the bindings defined in the parse steps. This generally will be a
+// function pointer to the BindingHandlerFunc of the BindingManager, or a closure
+// of it that injects cached values (ex. BaseMBParser's BindingHandlerAdapter).
+type PCManager[S any] struct {
+	Chains   map[reflect.Type]*ParseChain[S] // Cache for chains. Keyed by Destination struct type.
+	CMutex   sync.RWMutex                    // Mutex for thread-safe access to chains
+	Opts     PCManagerOpts                   // Options for the parse chain manager
+	Handler  BindingHandlerFunc[S]           // Binding Handler for this source type
+	Compiler ChainCompiler[S]                // Compiles the chains into executors, see ChainCompiler. Nil interprets them.
+
+	lruEntries map[reflect.Type]*chainCacheEntry // Sizes and last uses of the cached chains. Only with a cache budget.
+	lruClock   atomic.Int64                      // Orders the uses of the cached chains
+	cacheBytes int64                             // Estimated size of the cached chains. Only with a cache budget.
+}
+
+type PCManagerOpts struct {
+	tagOpts ParseTagOpts
+	// ScopeFuncs enables scoped sub-chains per binding name. A recursive
+	// struct field that carries a binding with a registered ScopeFunc
+	// uses that binding's identifier as a prefix for the same binding
+	// on all of its nested fields. See [ScopeFunc].
+	ScopeFuncs map[string]ScopeFunc
+	// CustomTagHandler receives the custom tags of each field while its
+	// step is built. See [CustomTagHandler].
+	CustomTagHandler CustomTagHandler
+	// GroupByBinding orders the steps of each chain by the name of their
+	// first binding instead of by field, so that each part of the source
+	// (e.g. headers, query parameters, JSON body) is read in one pass.
+	// This improves locality for structs with many fields. Fields of a
+	// kind keep their order, and kinds are ordered by their first field.
+	// Errors and ParseReports follow the execution order. See
+	// ParseChain.Groups.
+	GroupByBinding bool
+	// MaxCacheBytes caps the estimated memory of the cached parse chains
+	// (see ParseChain.EstimatedSize). Once exceeded, the least recently
+	// used chains are evicted and rebuilt when needed again. Zero means
+	// no limit.
+	MaxCacheBytes int64
+	// CheckJSONTags reports fields of dual-use structs, which are both
+	// parsed and serialized with encoding/json, whose json tag and
+	// bindings drifted apart: fields with a json tag but no binding, and
+	// bound fields without a json tag. Fields tagged `json:"-"` are
+	// exempt. Mismatches fail the chain build with ErrJSONTagMismatch.
+	CheckJSONTags bool
+	// UnexportedFields selects how bound unexported fields are populated.
+	// They are skipped by default. See UnexportedFieldMode.
+	UnexportedFields UnexportedFieldMode
+	// DirectBindings maps binding names to the number of distinct
+	// bindings of that name a destination type may have for them to be
+	// marked Direct. BindingManagers read Direct bindings straight from
+	// the source rather than materializing a cache of all its values
+	// (e.g. every header of a request) for one or two of them.
+	DirectBindings map[string]int
+}
+
+// NewPCManagerOpts creates PCManagerOpts that decode field tags with
+// tagOpts.
+func NewPCManagerOpts(tagOpts ParseTagOpts) PCManagerOpts {
+	return PCManagerOpts{tagOpts: tagOpts}
+}
+
+func NewPCManager[S any](
+	handler BindingHandlerFunc[S],
+	opts PCManagerOpts,
+) *PCManager[S] {
+
+	return &PCManager[S]{
+		Chains:  make(map[reflect.Type]*ParseChain[S]),
+		CMutex:  sync.RWMutex{},
+		Opts:    opts,
+		Handler: handler,
+	}
+}
+
+// GetParseChain retrieves a parse chain for the given destination struct type.
+//
+// If not found, it will create a new parse chain for the type and cache it.
+func (cman *PCManager[S]) GetParseChain(
+	typ reflect.Type,
+) (*ParseChain[S], error) {
+
+	if chain, exists := cman.cachedChain(typ); exists {
+		return chain, nil
+	}
+
+	// DNE. Build the chain (cached inside)
+	chain, err := cman.NewParseChain(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	return chain, nil
+}
+
+// Len returns the number of cached parse chains, one per destination
+// struct type parsed so far.
+func (cman *PCManager[S]) Len() int {
+	cman.CMutex.RLock()
+	defer cman.CMutex.RUnlock()
+
+	return len(cman.Chains)
+}
+
+// UpdateParseChain replaces the cached parse chain for typ with a copy
+// derived by modify (see ParseChain.Derive), building the chain first if
+// needed. Executions that already retrieved the previous chain finish
+// with it; later calls to GetParseChain return the derived chain.
+//
+// Updates of the same manager are serialized, so concurrent updates are
+// never lost. With a cache budget (see PCManagerOpts.MaxCacheBytes), an
+// updated chain that is evicted is rebuilt from its tags without the
+// update.
+func (cman *PCManager[S]) UpdateParseChain(
+	typ reflect.Type, modify func(*ParseChain[S]) error,
+) (*ParseChain[S], error) {
+
+	chain, err := cman.GetParseChain(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	cman.CMutex.Lock()
+	defer cman.CMutex.Unlock()
+
+	// The chain may have been updated or evicted in the meantime
+	if cached, exists := cman.Chains[typ]; exists {
+		chain = cached
+	}
+
+	derived, err := chain.Derive(modify)
+	if err != nil {
+		return nil, err
+	}
+	if err := cman.compile(derived); err != nil {
+		return nil, err
+	}
+	cman.cacheChain(typ, derived)
+
+	return derived, nil
+}
+
+func (cman *PCManager[S]) NewParseChain(
+	typ reflect.Type,
+) (*ParseChain[S], error) {
+
+	return cman.newParseChain(typ, nil, nil)
+}
+
+// newParseChain builds the parse chain for typ with the given binding
+// scopes applied. Only unscoped chains are cached, as scoped chains are
+// specific to the parent field they were built for. ancestors are the
+// struct types whose chains are being built around this one.
+func (cman *PCManager[S]) newParseChain(
+	typ reflect.Type, scopes bindingScopes, ancestors []reflect.Type,
+) (*ParseChain[S], error) {
+
+	// Force a copy so that sibling sub-chains do not share the slice
+	ancestors = append(ancestors[:len(ancestors):len(ancestors)], typ)
+
+	var (
+		head, current *ParseStep[S]
+		defaults      []FieldDefault
+		issues        []TagIssue
+		bound         = make(map[string]string) // Field names by bound value, see boundValueKey
+	)
+
+	// Parse fields to build the execution chain. Invalid fields do not
+	// stop the build so that all of them are reported at once.
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		// Skip unexported fields, unless populated through setters or unsafe
+		unexported := !field.IsExported()
+		if unexported && cman.Opts.UnexportedFields == UnexportedFieldsSkip {
+			continue
+		}
+
+		step, err := cman.newParseStep(field, i, scopes, ancestors)
+		if err != nil {
+			// If no bindings, skip this field but keep its default
+			if errors.Is(err, ErrNoStepBindings) {
+				if def, ok := unboundDefault(field, i, cman.Opts.tagOpts); ok && !unexported {
+					defaults = append(defaults, def)
+				}
+				if cman.Opts.CheckJSONTags && !unexported {
+					if err := checkJSONTag(field, false); err != nil {
+						issues = append(issues, TagIssue{Field: field.Name, Err: err})
+					}
+				}
+				continue
+			}
+			issues = addTagIssues(issues, field.Name, err)
+			continue
+		}
+
+		if unexported {
+			step.Unexported = true
+			if cman.Opts.UnexportedFields == UnexportedFieldsSetter {
+				step.Setter, err = fieldSetter(typ, field)
+				if err != nil {
+					issues = append(issues, TagIssue{Field: field.Name, Err: err})
+					continue
+				}
+			}
+		}
+
+		// encoding/json does not serialize unexported fields either
+		if cman.Opts.CheckJSONTags && !unexported {
+			if err := checkJSONTag(field, true); err != nil {
+				issues = append(issues, TagIssue{Field: field.Name, Err: err})
+			}
+		}
+
+		// Fields bound to the same value are usually copy-paste mistakes
+		for _, binding := range step.Bindings {
+			if binding.Modifiers.AllowDup {
+				continue
+			}
+			key := boundValueKey(binding, step.Part)
+			if other, exists := bound[key]; exists {
+				issues = append(issues, TagIssue{Field: field.Name, Err: fmt.Errorf(
+					"%w: %s:%q is also bound by field %s, add the %s modifier if intended",
+					ErrDuplicateBinding, binding.Name, binding.Identifier, other, AllowDupBindingModifier,
+				)})
+				continue
+			}
+			bound[key] = field.Name
+		}
+
+		if head == nil {
+			head = step
+			current = step
+		} else {
+			current.Next = step
+			current = step
+		}
+	}
+
+	checksums, checksumIssues := fieldChecksums(typ, head)
+	issues = append(issues, checksumIssues...)
+
+	if len(issues) > 0 {
+		return nil, &TagReport{StructType: typ, Issues: issues}
+	}
+
+	if cman.Opts.GroupByBinding {
+		head = groupStepsByBinding(head)
+	}
+
+	chain := &ParseChain[S]{
+		StructType: typ,
+		Head:       head,
+		Handler:    cman.Handler,
+		Defaults:   defaults,
+		Checksums:  checksums,
+	}
+	markDirectBindings(chain, cman.Opts.DirectBindings)
+
+	if len(scopes) > 0 {
+		return chain, nil
+	}
+
+	if err := cman.compile(chain); err != nil {
+		return nil, err
+	}
+
+	// Cache the chain. If another goroutine cached (or updated) a chain
+	// for typ in the meantime, keep that one so no update is lost.
+	cman.CMutex.Lock()
+	cached, exists := cman.Chains[typ]
+	if exists {
+		chain = cached
+	} else {
+		cman.cacheChain(typ, chain)
+	}
+	cman.CMutex.Unlock()
+
+	// Sub-chains are part of the manifest of the type being built
+	if !exists && len(ancestors) == 1 {
+		runPIIHooks(chain)
+	}
+
+	return chain, nil
+}
+
+// boundValueKey identifies the value a binding gets for a field: fields
+// with the same binding, identifier, part and modifier values (e.g.
+// different bits of the same integer) get the same value.
+func boundValueKey(binding Binding, part string) string {
+	var key strings.Builder
+	key.WriteString(binding.Name + "\x00" + binding.Identifier + "\x00" + part)
+
+	names := slices.Sorted(maps.Keys(binding.Modifiers.Values))
+	for _, name := range names {
+		fmt.Fprintf(&key, "\x00%s=%v", name, binding.Modifiers.Values[name])
+	}
+	return key.String()
+}
+
+// checkJSONTag reports whether the json tag of field matches whether it
+// is bound, see PCManagerOpts.CheckJSONTags. Embedded structs are
+// flattened by encoding/json, so they need no json tag.
+func checkJSONTag(field reflect.StructField, bound bool) error {
+	fieldTag, _ := ProfiledTag(field)
+	tag, tagged := fieldTag.Lookup("json")
+	if tag == "-" {
+		return nil
+	}
+
+	switch {
+	case tagged && !bound:
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		return fmt.Errorf("%w: serialized as %q but not bound", ErrJSONTagMismatch, name)
+	case !tagged && bound && !field.Anonymous:
+		return fmt.Errorf("%w: bound but not serialized, tag it `json:\"-\"` if intended", ErrJSONTagMismatch)
+	}
+	return nil
+}
+
+// unboundDefault returns the default of field, which has no bindings, if
+// it has one. Struct fields without bindings are parsed recursively and
+// get the defaults of their own fields instead.
+func unboundDefault(field reflect.StructField, index int, opts ParseTagOpts) (FieldDefault, bool) {
+	if _, _, isStruct := recursiveStructType(field.Type); isStruct {
+		return FieldDefault{}, false
+	}
+
+	// Invalid tags were reported when the step was built
+	tag, err := resolvedTag(field, opts)
+	if err != nil {
+		return FieldDefault{}, false
+	}
+	field.Tag = tag
+
+	def, err := decodeDefaultTagV2(field)
+	if err != nil || !def.Set {
+		return FieldDefault{}, false
+	}
+	return FieldDefault{FieldIndex: index, FieldName: field.Name, Value: def.Value}, true
+}
+
+func (cman *PCManager[S]) NewParseStep(
+	field reflect.StructField, index int,
+) (*ParseStep[S], error) {
+
+	return cman.newParseStep(field, index, nil, nil)
+}
+
+// newParseStep builds the step for field. Struct and pointer to struct
+// fields are parsed recursively through a sub-chain, unless tagged
+// `recursive:"false"`: those are bound like other fields, and populated
+// from their sub-document in one shot (see setStructValue).
+//
+// Nil pointer fields are allocated before their sub-chain runs. Pointers
+// to structs without any bound field are skipped rather than allocated,
+// as are pointers back to one of the ancestors (e.g. Parent *Node within
+// Node), which would otherwise be allocated without end.
+func (cman *PCManager[S]) newParseStep(
+	field reflect.StructField, index int, scopes bindingScopes, ancestors []reflect.Type,
+) (*ParseStep[S], error) {
+
+	var (
+		subChain                    *ParseChain[S]
+		bindings                    []Binding
+		defaultTag                  DefaultTag
+		err                         error
+		structType, isPtr, isStruct = recursiveStructType(field.Type)
+		opts                        = cman.Opts.tagOpts
+	)
+
+	parseTag, err := DecodeParseTagV2(field, opts)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrFailedToParseTag, field.Name, err)
+	}
+
+	// Handle recursive parsing
+	if parseTag.recursiveTag.Enabled {
+		if isPtr && slices.Contains(ancestors, structType) {
+			return nil, ErrNoStepBindings
+		}
+
+		if isStruct {
+			childScopes := scopes.child(parseTag.bindingTags, cman.Opts.ScopeFuncs)
+			subChain, err = cman.newParseChain(structType, childScopes, ancestors)
+			if err != nil {
+				return nil, fmt.Errorf("%w %s: %w", ErrFailedToBuildSubChain, field.Name, err)
+			}
+			if subChain.empty() {
+				return nil, ErrNoStepBindings
+			}
+			// Struct fields don't need bindings since they use sub-chains
+			bindings = []Binding{}
+		}
+	} else {
+		// Handle nonrecursive parsing
+		bindings, err = makeBindings(parseTag, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(bindings) == 0 {
+			return nil, ErrNoStepBindings
+		}
+
+		for _, binding := range bindings {
+			if binding.Modifiers.Flatten {
+				return nil, fmt.Errorf("%w: %s:%q", ErrFlattenNotStruct, binding.Name, binding.Identifier)
+			}
+			if binding.Modifiers.Scope {
+				return nil, fmt.Errorf("%w: %s:%q", ErrScopeNotStruct, binding.Name, binding.Identifier)
+			}
+			if _, ok := binding.Modifiers.Value(MaxBindingModifier); ok && !honorsMaxModifier(binding, field.Type) {
+				return nil, fmt.Errorf("%w: %s:%q", ErrMaxNotRange, binding.Name, binding.Identifier)
+			}
+		}
+
+		scopes.apply(bindings, cman.Opts.ScopeFuncs)
+
+		if isMultiValueType(field.Type) {
+			for i := range bindings {
+				bindings[i].Multi = true
+			}
+		}
+
+		defaultTag = parseTag.defaultTag
+	}
+
+	pii, err := piiCategory(field, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata map[string]any
+	if handler := cman.Opts.CustomTagHandler; handler != nil && len(parseTag.customTags) > 0 {
+		tagField := &CustomTagField{
+			Field:    field,
+			Tags:     parseTag.customTags,
+			Bindings: bindings,
+			Metadata: make(map[string]any),
+		}
+		if err := handler.HandleCustomTags(tagField); err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrFailedToHandleCustomTags, field.Name, err)
+		}
+
+		bindings = tagField.Bindings
+		if len(tagField.Metadata) > 0 {
+			metadata = tagField.Metadata
+		}
+	}
+
+	return &ParseStep[S]{
+		FieldIndex:    index,
+		FieldName:     field.Name,
+		Bindings:      bindings,
+		DefaultValue:  defaultTag.Value,
+		HasDefault:    defaultTag.Set,
+		Part:          parseTag.partTag.Name,
+		IsStruct:      isStruct,
+		SubChain:      subChain,
+		ShouldRecurse: parseTag.recursiveTag.Enabled,
+		Metadata:      metadata,
+		PII:           pii,
+	}, nil
+}
@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Estimated sizes used by ParseChain.EstimatedSize
+const (
+	_chainSize        = int64(unsafe.Sizeof(ParseChain[struct{}]{}))
+	_stepSize         = int64(unsafe.Sizeof(ParseStep[struct{}]{}))
+	_bindingSize      = int64(unsafe.Sizeof(Binding{}))
+	_fieldDefaultSize = int64(unsafe.Sizeof(FieldDefault{}))
+	_checksumSize     = int64(unsafe.Sizeof(FieldChecksum{}))
+	_mapEntrySize     = 48 // Rough per entry overhead of small maps
+)
+
+// EstimatedSize returns an estimate of the memory held by the chain in
+// bytes, including its steps, their bindings and all sub-chains. Values
+// shared with other chains, such as parsed modifier values, are not
+// counted.
+func (chain *ParseChain[S]) EstimatedSize() int64 {
+	return chain.estimatedSize(nil)
+}
+
+// estimatedSize is EstimatedSize, leaving out the sub-chains for which
+// skip reports true.
+func (chain *ParseChain[S]) estimatedSize(skip func(*ParseChain[S]) bool) int64 {
+	size := _chainSize
+	size += int64(cap(chain.Defaults)) * _fieldDefaultSize
+	for _, def := range chain.Defaults {
+		size += int64(len(def.FieldName) + len(def.Value))
+	}
+	size += int64(cap(chain.Checksums)) * _checksumSize
+
+	for step := chain.Head; step != nil; step = step.Next {
+		size += _stepSize
+		size += int64(len(step.FieldName) + len(step.DefaultValue) + len(step.Part) + len(step.PII))
+		size += int64(cap(step.Bindings)) * _bindingSize
+
+		for _, binding := range step.Bindings {
+			size += int64(len(binding.Name) + len(binding.Identifier))
+			for name := range binding.Modifiers.Custom {
+				size += _mapEntrySize + int64(len(name))
+			}
+			for name := range binding.Modifiers.Values {
+				size += _mapEntrySize + int64(len(name))
+			}
+		}
+
+		for name := range step.Metadata {
+			size += _mapEntrySize + int64(len(name))
+		}
+
+		if step.SubChain != nil && (skip == nil || !skip(step.SubChain)) {
+			size += step.SubChain.estimatedSize(skip)
+		}
+	}
+
+	return size
+}
+
+// cachedSize returns the estimated size of chain in the cache: sub-chains
+// that are the cached chains of their own types are left out, as they
+// are counted with their own entries. Each chain is thus counted once. A
+// sub-chain evicted with its entry stays referenced by the chains holding
+// it until they are evicted as well.
+//
+// The caller must hold a lock of CMutex.
+func (cman *PCManager[S]) cachedSize(chain *ParseChain[S]) int64 {
+	return chain.estimatedSize(func(sub *ParseChain[S]) bool {
+		return cman.Chains[sub.StructType] == sub
+	})
+}
+
+// CacheBytes returns the estimated memory held by the cached chains in
+// bytes. See ParseChain.EstimatedSize.
+func (cman *PCManager[S]) CacheBytes() int64 {
+	cman.CMutex.RLock()
+	defer cman.CMutex.RUnlock()
+
+	if cman.Opts.MaxCacheBytes > 0 {
+		return cman.cacheBytes
+	}
+
+	var size int64
+	for _, chain := range cman.Chains {
+		size += cman.cachedSize(chain)
+	}
+	return size
+}
+
+// cachedChain returns the cached chain for typ, if any. With a cache
+// budget, the chain becomes the most recently used one. Cache hits only
+// take the read lock of CMutex: uses are recorded atomically, and only
+// ordered when chains are evicted, see cacheChain.
+func (cman *PCManager[S]) cachedChain(typ reflect.Type) (*ParseChain[S], bool) {
+	cman.CMutex.RLock()
+	defer cman.CMutex.RUnlock()
+
+	chain, exists := cman.Chains[typ]
+	if entry, ok := cman.lruEntries[typ]; ok {
+		entry.used.Store(cman.lruClock.Add(1))
+	}
+	return chain, exists
+}
+
+// chainCacheEntry records the size and last use of a cached chain.
+type chainCacheEntry struct {
+	typ  reflect.Type
+	size int64
+	used atomic.Int64 // Value of the lruClock of the PCManager at the last use
+}
+
+// cacheChain caches chain for typ and, with a cache budget, evicts the
+// least recently used chains until the cache fits the budget again. The
+// chain just cached is never evicted, even if it alone exceeds the budget.
+//
+// The caller must hold the write lock of CMutex.
+func (cman *PCManager[S]) cacheChain(typ reflect.Type, chain *ParseChain[S]) {
+	cman.Chains[typ] = chain
+
+	budget := cman.Opts.MaxCacheBytes
+	if budget <= 0 {
+		return
+	}
+
+	if cman.lruEntries == nil {
+		cman.lruEntries = make(map[reflect.Type]*chainCacheEntry)
+	}
+
+	size := cman.cachedSize(chain)
+	entry, ok := cman.lruEntries[typ]
+	if !ok {
+		entry = &chainCacheEntry{typ: typ}
+		cman.lruEntries[typ] = entry
+	}
+	cman.cacheBytes += size - entry.size
+	entry.size = size
+	entry.used.Store(cman.lruClock.Add(1))
+
+	if cman.cacheBytes <= budget {
+		return
+	}
+
+	entries := make([]*chainCacheEntry, 0, len(cman.lruEntries))
+	for _, other := range cman.lruEntries {
+		if other != entry {
+			entries = append(entries, other)
+		}
+	}
+	slices.SortFunc(entries, func(a, b *chainCacheEntry) int {
+		return cmp.Compare(a.used.Load(), b.used.Load())
+	})
+
+	for _, evicted := range entries {
+		if cman.cacheBytes <= budget {
+			break
+		}
+		delete(cman.lruEntries, evicted.typ)
+		delete(cman.Chains, evicted.typ)
+		cman.cacheBytes -= evicted.size
+	}
+}
@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBudgetPCManager(budget int64) *PCManager[map[string]string] {
+	opts := NewPCManagerOpts(ParseTagOpts{
+		BindingOpts: BindingOpts{AllowedBindingNames: []string{MapValueTagBinding}},
+	})
+	opts.MaxCacheBytes = budget
+
+	return NewPCManager(regionBindingManager{}.BindingHandler, opts)
+}
+
+func TestParseChain_EstimatedSize(t *testing.T) {
+	type Small struct {
+		A string `mapvalue:"a"`
+	}
+	type Large struct {
+		A string `mapvalue:"a"`
+		B string `mapvalue:"b,omitempty" default:"a rather long default value"`
+		C Small
+	}
+
+	pcm := newBudgetPCManager(0)
+
+	small, err := pcm.GetParseChain(reflect.TypeFor[Small]())
+	require.NoError(t, err)
+	large, err := pcm.GetParseChain(reflect.TypeFor[Large]())
+	require.NoError(t, err)
+
+	assert.Greater(t, small.EstimatedSize(), _chainSize+_stepSize)
+	assert.Greater(t, large.EstimatedSize(), small.EstimatedSize()*2)
+	assert.Equal(t, large.EstimatedSize(), pcm.CacheBytes(), "the sub-chain of Large is the cached chain of Small, counted once")
+}
+
+func TestPCManager_MaxCacheBytes(t *testing.T) {
+	type A struct {
+		Value string `mapvalue:"a"`
+	}
+	type B struct {
+		Value string `mapvalue:"b"`
+	}
+	type C struct {
+		Value string `mapvalue:"c"`
+	}
+
+	typA, typB, typC := reflect.TypeFor[A](), reflect.TypeFor[B](), reflect.TypeFor[C]()
+
+	size := func(typ reflect.Type) int64 {
+		chain, err := newBudgetPCManager(0).GetParseChain(typ)
+		require.NoError(t, err)
+		return chain.EstimatedSize()
+	}
+
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		pcm := newBudgetPCManager(size(typA) + size(typB))
+
+		for _, typ := range []reflect.Type{typA, typB, typA, typC} {
+			_, err := pcm.GetParseChain(typ)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 2, pcm.Len())
+		assert.Contains(t, pcm.Chains, typA)
+		assert.Contains(t, pcm.Chains, typC)
+		assert.NotContains(t, pcm.Chains, typB, "B was used least recently")
+		assert.LessOrEqual(t, pcm.CacheBytes(), size(typA)+size(typB))
+
+		// Evicted chains are rebuilt
+		source := map[string]string{"b": "value"}
+		chain, err := pcm.GetParseChain(typB)
+		require.NoError(t, err)
+
+		var b B
+		require.NoError(t, chain.Execute(&source, &b))
+		assert.Equal(t, "value", b.Value)
+		assert.Equal(t, 2, pcm.Len())
+	})
+
+	t.Run("KeepsChainLargerThanBudget", func(t *testing.T) {
+		pcm := newBudgetPCManager(1)
+
+		_, err := pcm.GetParseChain(typA)
+		require.NoError(t, err)
+		_, err = pcm.GetParseChain(typB)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, pcm.Len())
+		assert.Contains(t, pcm.Chains, typB)
+		assert.Equal(t, size(typB), pcm.CacheBytes())
+	})
+
+	t.Run("UpdateParseChain", func(t *testing.T) {
+		pcm := newBudgetPCManager(size(typA) + size(typB))
+
+		updated, err := pcm.UpdateParseChain(typA, func(chain *ParseChain[map[string]string]) error {
+			step, _ := chain.Step("Value")
+			step.DefaultValue = "a longer default value"
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, updated, pcm.Chains[typA])
+		assert.Equal(t, updated.EstimatedSize(), pcm.CacheBytes())
+	})
+
+	t.Run("CountsSubChainsOnce", func(t *testing.T) {
+		type Outer struct {
+			Inner A
+			Value string `mapvalue:"outer"`
+		}
+		typOuter := reflect.TypeFor[Outer]()
+
+		pcm := newBudgetPCManager(size(typOuter))
+		_, err := pcm.GetParseChain(typOuter)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, pcm.Len(), "the chain of A is cached both alone and as a sub-chain")
+		assert.Equal(t, size(typOuter), pcm.CacheBytes())
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		pcm := newBudgetPCManager(size(typA))
+		types := []reflect.Type{typA, typB, typC}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					_, err := pcm.GetParseChain(types[(i+j)%len(types)])
+					assert.NoError(t, err, fmt.Sprint(i, j))
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, pcm.Len())
+		assert.Len(t, pcm.lruEntries, 1)
+	})
+}
@@ -0,0 +1,647 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test ParseChain functionality
+func TestParseChain_SingleStepExecute(t *testing.T) {
+	t.Run("NilHead", func(t *testing.T) {
+		chain := &ParseChain[string]{
+			StructType: reflect.TypeOf(struct{}{}),
+			Head:       nil,
+			Handler: func(source *string, binding Binding) BindingResult {
+				return BindingResultValue("test")
+			},
+		}
+
+		source := "test"
+		dest := &struct{}{}
+
+		err := chain.Execute(&source, dest)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "parse chain is empty")
+	})
+
+	t.Run("SuccessfulExecution", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string
+		}
+
+		// Create a simple parse step
+		step := &ParseStep[string]{
+			Next: nil,
+			Bindings: []Binding{
+				{
+					Name:       "test",
+					Identifier: "field1",
+					Modifiers:  BindingModifiers{Required: true},
+				},
+			},
+			FieldName:  "Field1",
+			FieldIndex: 0,
+			IsStruct:   false,
+		}
+
+		chain := &ParseChain[string]{
+			StructType: reflect.TypeOf(TestStruct{}),
+			Head:       step,
+			Handler: func(source *string, binding Binding) BindingResult {
+				return BindingResultValue("test_value")
+			},
+		}
+
+		source := "test"
+		dest := &TestStruct{}
+
+		err := chain.Execute(&source, dest)
+		require.NoError(t, err)
+		assert.Equal(t, "test_value", dest.Field1)
+	})
+
+	t.Run("FailedBinding", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string
+		}
+
+		// Create a simple parse step
+		step := &ParseStep[string]{
+			Next: nil,
+			Bindings: []Binding{
+				{
+					Name:       "test",
+					Identifier: "field1",
+					Modifiers:  BindingModifiers{Required: true},
+				},
+			},
+			FieldName:  "Field1",
+			FieldIndex: 0,
+			IsStruct:   false,
+		}
+
+		chain := &ParseChain[string]{
+			StructType: reflect.TypeOf(TestStruct{}),
+			Head:       step,
+			Handler: func(source *string, binding Binding) BindingResult {
+				return BindingResultNotFound()
+			},
+		}
+
+		source := "test"
+		dest := &TestStruct{}
+
+		err := chain.Execute(&source, dest)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse field")
+	})
+}
+
+func TestParseChain_doStepRecursive(t *testing.T) {
+	// This test is merged with the main execution test above
+	// since doStepRecursive is tested through the Execute method
+}
+
+func TestPCManager(t *testing.T) {
+	handler := func(source *string, binding Binding) BindingResult {
+		return BindingResultValue("test")
+	}
+
+	t.Run("NewPCManager", func(t *testing.T) {
+		opts := PCManagerOpts{
+			tagOpts: ParseTagOpts{
+				BindingOpts: BindingOpts{
+					AllowedBindingNames: []string{"json"},
+				},
+			},
+		}
+
+		pcm := NewPCManager(handler, opts)
+		assert.NotNil(t, pcm)
+	})
+
+	t.Run("GetParseChain", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string `json:"field1"`
+		}
+
+		opts := PCManagerOpts{
+			tagOpts: ParseTagOpts{
+				BindingOpts: BindingOpts{
+					AllowedBindingNames: []string{"json"},
+				},
+			},
+		}
+
+		pcm := NewPCManager(handler, opts)
+
+		chain, err := pcm.GetParseChain(reflect.TypeOf(TestStruct{}))
+		require.NoError(t, err)
+		assert.NotNil(t, chain)
+		assert.Equal(t, reflect.TypeOf(TestStruct{}), chain.StructType)
+	})
+
+	t.Run("GetParseChain_Cached", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string `json:"field1"`
+		}
+
+		opts := PCManagerOpts{
+			tagOpts: ParseTagOpts{
+				BindingOpts: BindingOpts{
+					AllowedBindingNames: []string{"json"},
+				},
+			},
+		}
+
+		pcm := NewPCManager(handler, opts)
+
+		// First call should create and cache
+		chain1, err := pcm.GetParseChain(reflect.TypeOf(TestStruct{}))
+		require.NoError(t, err)
+
+		// Second call should return cached version
+		chain2, err := pcm.GetParseChain(reflect.TypeOf(TestStruct{}))
+		require.NoError(t, err)
+
+		// Should be the same instance
+		assert.Equal(t, chain1, chain2)
+	})
+
+	t.Run("NewParseChain", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string `json:"field1"`
+		}
+
+		opts := PCManagerOpts{
+			tagOpts: ParseTagOpts{
+				BindingOpts: BindingOpts{
+					AllowedBindingNames: []string{"json"},
+				},
+			},
+		}
+
+		pcm := NewPCManager(handler, opts)
+
+		chain, err := pcm.NewParseChain(reflect.TypeOf(TestStruct{}))
+		require.NoError(t, err)
+		assert.NotNil(t, chain)
+		assert.NotNil(t, chain.Head)
+		assert.Equal(t, "Field1", chain.Head.FieldName)
+	})
+
+	t.Run("NewParseStep", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string `json:"field1"`
+		}
+
+		opts := PCManagerOpts{
+			tagOpts: ParseTagOpts{
+				BindingOpts: BindingOpts{
+					AllowedBindingNames: []string{"json"},
+				},
+			},
+		}
+
+		pcm := NewPCManager(handler, opts)
+
+		field := reflect.TypeOf(TestStruct{}).Field(0)
+		step, err := pcm.NewParseStep(field, 0)
+		require.NoError(t, err)
+		assert.NotNil(t, step)
+		assert.Equal(t, "Field1", step.FieldName)
+		assert.Equal(t, 0, step.FieldIndex)
+	})
+}
+
+func TestParseChain_Derive(t *testing.T) {
+	type Address struct {
+		Street string `json:"street,omitempty" default:"unknown"`
+	}
+
+	type TestStruct struct {
+		Name    string  `json:"name,omitempty" default:"anonymous"`
+		Address Address `json:"address"`
+	}
+
+	pcm := NewPCManager(func(source *string, binding Binding) BindingResult {
+		return BindingResultNotFound()
+	}, PCManagerOpts{tagOpts: ParseTagOpts{
+		BindingOpts: BindingOpts{AllowedBindingNames: []string{"json"}},
+	}})
+
+	chain, err := pcm.GetParseChain(reflect.TypeOf(TestStruct{}))
+	require.NoError(t, err)
+
+	t.Run("Clone", func(t *testing.T) {
+		clone := chain.Clone()
+		assert.Equal(t, chain.StructType, clone.StructType)
+		assert.Equal(t, *chain.Head.Next.SubChain.Head, *clone.Head.Next.SubChain.Head)
+		assert.NotSame(t, chain.Head, clone.Head)
+		assert.NotSame(t, chain.Head.Next.SubChain, clone.Head.Next.SubChain)
+	})
+
+	t.Run("Step", func(t *testing.T) {
+		step, found := chain.Step("Address.Street")
+		require.True(t, found)
+		assert.Equal(t, "street", step.Bindings[0].Identifier)
+
+		_, found = chain.Step("Address.Missing")
+		assert.False(t, found)
+	})
+
+	t.Run("WithDefault", func(t *testing.T) {
+		derived, err := chain.WithDefault("Name", "guest")
+		require.NoError(t, err)
+
+		assert.Equal(t, "guest", derived.Head.DefaultValue)
+		assert.Equal(t, "anonymous", chain.Head.DefaultValue, "original chain must not change")
+		assert.Equal(t, chain.Version+1, derived.Version)
+
+		_, err = chain.WithDefault("Missing", "x")
+		assert.ErrorIs(t, err, ErrParseStepNotFound)
+	})
+
+	t.Run("UpdateParseChain", func(t *testing.T) {
+		typ := reflect.TypeOf(TestStruct{})
+
+		updated, err := pcm.UpdateParseChain(typ, func(derived *ParseChain[string]) error {
+			step, _ := derived.Step("Name")
+			step.DefaultValue = "updated"
+			return nil
+		})
+		require.NoError(t, err)
+
+		current, err := pcm.GetParseChain(typ)
+		require.NoError(t, err)
+		assert.Same(t, updated, current)
+		assert.Equal(t, "anonymous", chain.Head.DefaultValue)
+
+		var dest TestStruct
+		source := ""
+		require.NoError(t, current.Execute(&source, &dest))
+		assert.Equal(t, "updated", dest.Name)
+
+		_, err = pcm.UpdateParseChain(typ, func(*ParseChain[string]) error {
+			return errors.New("rejected")
+		})
+		assert.Error(t, err)
+
+		current, _ = pcm.GetParseChain(typ)
+		assert.Same(t, updated, current, "a failed update keeps the cached chain")
+	})
+}
+
+func TestPCManager_TagReport(t *testing.T) {
+	type Nested struct {
+		Valid   string `json:"valid"`
+		Invalid string `json:"invalid,bogus"`
+	}
+
+	type TestStruct struct {
+		First  string `json:"first,unknown"`
+		Valid  string `json:"valid"`
+		Nested Nested
+		Last   int `json:"last,omitempty" default:""`
+	}
+
+	pcm := NewPCManager(func(source *string, binding Binding) BindingResult {
+		return BindingResultNotFound()
+	}, PCManagerOpts{tagOpts: ParseTagOpts{
+		BindingOpts: BindingOpts{AllowedBindingNames: []string{"json"}},
+	}})
+
+	_, err := pcm.NewParseChain(reflect.TypeOf(TestStruct{}))
+	require.Error(t, err)
+
+	var report *TagReport
+	require.ErrorAs(t, err, &report)
+	assert.Equal(t, reflect.TypeOf(TestStruct{}), report.StructType)
+
+	fields := make([]string, len(report.Issues))
+	for i, issue := range report.Issues {
+		fields[i] = issue.Field
+	}
+	assert.Equal(t, []string{"First", "Nested.Invalid", "Last"}, fields)
+
+	assert.ErrorIs(t, err, ErrUnallowedBindingModifier)
+	assert.ErrorIs(t, err, ErrEmptyTagValue)
+	assert.Contains(t, err.Error(), "(3 issues)")
+
+	_, cached := pcm.Chains[reflect.TypeOf(TestStruct{})]
+	assert.False(t, cached, "invalid chains are not cached")
+}
+
+func TestPCManager_DuplicateBindings(t *testing.T) {
+	pcm := NewPCManager(func(source *string, binding Binding) BindingResult {
+		return BindingResultNotFound()
+	}, PCManagerOpts{tagOpts: ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{"field", "query"},
+			ValueModifiers:      map[string]ModifierValueParser{BitBindingModifier: parseBitModifier},
+		},
+	}})
+
+	type Duplicated struct {
+		Name    string `field:"name"`
+		Title   string `field:"title"`
+		Label   string `field:"name"`
+		Caption string `field:"title,omitempty" query:"title"`
+	}
+
+	_, err := pcm.NewParseChain(reflect.TypeFor[Duplicated]())
+	require.ErrorIs(t, err, ErrDuplicateBinding)
+
+	var report *TagReport
+	require.ErrorAs(t, err, &report)
+	require.Len(t, report.Issues, 2)
+	assert.Equal(t, "Label", report.Issues[0].Field)
+	assert.ErrorContains(t, report.Issues[0].Err, `field:"name" is also bound by field Name`)
+	assert.Equal(t, "Caption", report.Issues[1].Field)
+
+	type Distinct struct {
+		Name     string `field:"name"`
+		Alias    string `field:"name,allowdup"`
+		Query    string `query:"name"`
+		Beta     bool   `query:"flags,bit=0"`
+		Dark     bool   `query:"flags,bit=1"`
+		Username string `field:"auth" part:"username"`
+		Password string `field:"auth" part:"password"`
+		Nested   struct {
+			Name string `field:"name"`
+		}
+	}
+
+	_, err = pcm.NewParseChain(reflect.TypeFor[Distinct]())
+	assert.NoError(t, err, "allowdup, other bindings, modifier values, parts and nested structs are distinct")
+}
+
+func TestPCManager_CheckJSONTags(t *testing.T) {
+	newPCM := func(check bool) *PCManager[string] {
+		return NewPCManager(func(source *string, binding Binding) BindingResult {
+			return BindingResultNotFound()
+		}, PCManagerOpts{tagOpts: ParseTagOpts{
+			BindingOpts: BindingOpts{AllowedBindingNames: []string{"env"}},
+		}, CheckJSONTags: check})
+	}
+
+	type Config struct {
+		Host     string `env:"HOST" json:"host"`
+		Port     int    `env:"PORT" json:"port,omitempty"`
+		Secret   string `env:"SECRET" json:"-"`
+		Debug    bool   `json:"debug"`
+		Token    string `env:"TOKEN"`
+		Internal string
+		Limits   struct {
+			Max int `env:"MAX" json:"max"`
+		} `json:"limits"`
+	}
+
+	_, err := newPCM(false).NewParseChain(reflect.TypeFor[Config]())
+	require.NoError(t, err, "the check is opt-in")
+
+	_, err = newPCM(true).NewParseChain(reflect.TypeFor[Config]())
+	require.ErrorIs(t, err, ErrJSONTagMismatch)
+
+	var report *TagReport
+	require.ErrorAs(t, err, &report)
+	require.Len(t, report.Issues, 2)
+	assert.Equal(t, "Debug", report.Issues[0].Field)
+	assert.ErrorContains(t, report.Issues[0].Err, `serialized as "debug" but not bound`)
+	assert.Equal(t, "Token", report.Issues[1].Field)
+	assert.ErrorContains(t, report.Issues[1].Err, "bound but not serialized")
+
+	t.Run("HTTPRequestParser", func(t *testing.T) {
+		type Response struct {
+			ID        string `json:"id"`
+			RequestID string `header:"X-Request-ID" json:"-"`
+		}
+
+		req := newTestRequest(t, "POST", "http://example.com/", strings.NewReader(`{"id":"42","-":"body"}`))
+		req.Header.Set("X-Request-ID", "req-1")
+
+		var response Response
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{CheckJSONTags: true})
+		require.NoError(t, parser.Parse(req, &response))
+		assert.Equal(t, Response{ID: "42", RequestID: "req-1"}, response, `json:"-" is not a json binding`)
+	})
+}
+
+func TestParseChain_UnboundDefaults(t *testing.T) {
+	type Retry struct {
+		Max     int    `default:"3"`
+		Backoff string `default:"exponential"`
+	}
+	type Request struct {
+		Name     string `query:"name"`
+		Timeout  int    `default:"30"`
+		Retry    Retry
+		Fallback *Retry
+		Limits   struct {
+			Max   int `query:"max,omitempty" default:"10"`
+			Burst int `default:"20"`
+		}
+		Meta struct {
+			Note string
+		}
+	}
+
+	parser := NewHTTPRequestParser()
+	req := newTestRequest(t, "GET", "http://example.com/?name=a&max=5", nil)
+
+	var request Request
+	require.NoError(t, parser.Parse(req, &request), "structs without bindings or defaults are skipped")
+	assert.Equal(t, "a", request.Name)
+	assert.Equal(t, 30, request.Timeout)
+	assert.Equal(t, Retry{Max: 3, Backoff: "exponential"}, request.Retry)
+	require.NotNil(t, request.Fallback, "absent sub-structs get their defaults")
+	assert.Equal(t, Retry{Max: 3, Backoff: "exponential"}, *request.Fallback)
+	assert.Equal(t, 5, request.Limits.Max)
+	assert.Equal(t, 20, request.Limits.Burst)
+
+	request = Request{Timeout: 10, Fallback: &Retry{Max: 1}}
+	require.NoError(t, parser.Parse(req, &request))
+	assert.Equal(t, 10, request.Timeout, "defaults do not replace values set before parsing")
+	assert.Equal(t, Retry{Max: 1, Backoff: "exponential"}, *request.Fallback)
+}
+
+func TestParseChain_doStepRegular(t *testing.T) {
+	t.Run("SuccessfulBinding", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string
+		}
+
+		step := &ParseStep[string]{
+			Bindings: []Binding{
+				{
+					Name:       "test",
+					Identifier: "field1",
+					Modifiers:  BindingModifiers{Required: true},
+				},
+			},
+			FieldName:  "Field1",
+			FieldIndex: 0,
+			IsStruct:   false,
+		}
+
+		chain := &ParseChain[string]{
+			StructType: reflect.TypeOf(TestStruct{}),
+			Handler: func(source *string, binding Binding) BindingResult {
+				return BindingResultValue("test_value")
+			},
+		}
+
+		source := "test"
+		dest := &TestStruct{}
+		destValue := reflect.ValueOf(dest).Elem()
+		field := destValue.Field(0)
+
+		err := chain.doStepRegular(&source, field, step, execution[string]{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "test_value", dest.Field1)
+	})
+
+	t.Run("FailedBinding_NoDefault", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string
+		}
+
+		step := &ParseStep[string]{
+			Bindings: []Binding{
+				{Name: "test", Identifier: "field1", Modifiers: BindingModifiers{OmitEmpty: true}},
+			},
+			FieldName: "Field1",
+		}
+
+		chain := &ParseChain[string]{
+			StructType: reflect.TypeOf(TestStruct{}),
+			Handler: func(source *string, binding Binding) BindingResult {
+				return BindingResultNotFound()
+			},
+		}
+
+		source := "test"
+		field := reflect.ValueOf(&TestStruct{}).Elem().Field(0)
+
+		err := chain.doStepRegular(&source, field, step, execution[string]{}, nil)
+		require.ErrorIs(t, err, ErrAllBindingsFailedNoDefault)
+		assert.Equal(t, "All bindings failed with no default value for field Field1", err.Error())
+	})
+
+	t.Run("FailedBinding_WithDefault", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string
+		}
+
+		step := &ParseStep[string]{
+			Bindings: []Binding{
+				{
+					Name:       "test",
+					Identifier: "field1",
+					Modifiers:  BindingModifiers{Required: false, OmitEmpty: true},
+				},
+			},
+			FieldName:    "Field1",
+			FieldIndex:   0,
+			IsStruct:     false,
+			DefaultValue: "default_value",
+		}
+
+		chain := &ParseChain[string]{
+			StructType: reflect.TypeOf(TestStruct{}),
+			Handler: func(source *string, binding Binding) BindingResult {
+				return BindingResultNotFound()
+			},
+		}
+
+		source := "test"
+		dest := &TestStruct{}
+		destValue := reflect.ValueOf(dest).Elem()
+		field := destValue.Field(0)
+
+		err := chain.doStepRegular(&source, field, step, execution[string]{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "default_value", dest.Field1)
+	})
+
+	t.Run("FailedBinding_EmptyDefault", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string
+		}
+
+		step := &ParseStep[string]{
+			Bindings: []Binding{
+				{Name: "test", Identifier: "field1", Modifiers: BindingModifiers{OmitEmpty: true}},
+			},
+			FieldName:  "Field1",
+			HasDefault: true,
+		}
+
+		chain := &ParseChain[string]{
+			StructType: reflect.TypeOf(TestStruct{}),
+			Handler: func(source *string, binding Binding) BindingResult {
+				return BindingResultNotFound()
+			},
+		}
+
+		source := "test"
+		dest := &TestStruct{Field1: "stale"}
+		field := reflect.ValueOf(dest).Elem().Field(0)
+
+		err := chain.doStepRegular(&source, field, step, execution[string]{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "", dest.Field1)
+	})
+
+	t.Run("FailedBinding_NoDefault_Required", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string
+		}
+
+		step := &ParseStep[string]{
+			Bindings: []Binding{
+				{
+					Name:       "test",
+					Identifier: "field1",
+					Modifiers:  BindingModifiers{Required: true},
+				},
+			},
+			FieldName:  "Field1",
+			FieldIndex: 0,
+			IsStruct:   false,
+		}
+
+		chain := &ParseChain[string]{
+			StructType: reflect.TypeOf(TestStruct{}),
+			Handler: func(source *string, binding Binding) BindingResult {
+				return BindingResultNotFound()
+			},
+		}
+
+		source := "test"
+		dest := &TestStruct{}
+		destValue := reflect.ValueOf(dest).Elem()
+		field := destValue.Field(0)
+
+		err := chain.doStepRegular(&source, field, step, execution[string]{}, nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "required field field1 not found in source test")
+	})
+}
+
+func TestFieldPath(t *testing.T) {
+	cause := errors.New("boom")
+	err := &FieldError{Field: "Address", Err: &FieldError{Field: "Street", Err: cause}}
+
+	assert.Equal(t, "Address.Street", FieldPath(err))
+	assert.Equal(t, "Address.Street", FieldPath(fmt.Errorf("wrapped: %w", err)))
+	assert.Equal(t, "", FieldPath(cause))
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "failed to parse field Address: failed to parse field Street: boom", err.Error())
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+)
+
+var (
+	ErrNoFieldSetter      = fmt.Errorf("no setter method for unexported field")
+	ErrInvalidFieldSetter = fmt.Errorf("invalid setter method for unexported field")
+)
+
+// UnexportedFieldMode controls whether and how the bound unexported
+// fields of a struct are populated, for structs that keep their
+// invariants behind private fields. See PCManagerOpts.UnexportedFields.
+type UnexportedFieldMode int
+
+const (
+	// UnexportedFieldsSkip ignores unexported fields, like encoding/json.
+	UnexportedFieldsSkip UnexportedFieldMode = iota
+	// UnexportedFieldsSetter populates each bound unexported field
+	// through the setter method of the struct named after it, e.g.
+	// SetEmail for email. Setters have a pointer receiver, take a value
+	// of the field's type and may return an error, which fails the
+	// parse:
+	//
+	//	func (u *User) SetEmail(email string) error
+	//
+	// Setters are called with the value (or default) found for the
+	// field, unless parsing it failed. Fields without a valid setter
+	// fail the chain build with ErrNoFieldSetter or
+	// ErrInvalidFieldSetter.
+	UnexportedFieldsSetter
+	// UnexportedFieldsUnsafe writes bound unexported fields directly,
+	// bypassing the visibility rules of Go with package unsafe. Only use
+	// it for types whose invariants are checked after parsing.
+	UnexportedFieldsUnsafe
+)
+
+// fieldSetter returns the setter method of the unexported field of typ,
+// see UnexportedFieldsSetter.
+func fieldSetter(typ reflect.Type, field reflect.StructField) (reflect.Value, error) {
+	first, size := utf8.DecodeRuneInString(field.Name)
+	name := "Set" + string(unicode.ToUpper(first)) + field.Name[size:]
+
+	method, ok := reflect.PointerTo(typ).MethodByName(name)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%w: %s.%s", ErrNoFieldSetter, typ.Name(), name)
+	}
+
+	// The receiver is the first parameter of method.Type
+	fn := method.Type
+	errorType := reflect.TypeFor[error]()
+	if fn.NumIn() != 2 || fn.In(1) != field.Type || fn.IsVariadic() ||
+		fn.NumOut() > 1 || (fn.NumOut() == 1 && fn.Out(0) != errorType) {
+		return reflect.Value{}, fmt.Errorf(
+			"%w: %s.%s must take a %s and return nothing or an error",
+			ErrInvalidFieldSetter, typ.Name(), name, field.Type,
+		)
+	}
+
+	return method.Func, nil
+}
+
+// callFieldSetter calls setter on the struct dest points to with value.
+func callFieldSetter(setter reflect.Value, dest reflect.Value, value reflect.Value) error {
+	out := setter.Call([]reflect.Value{dest, value})
+	if len(out) == 1 && !out[0].IsNil() {
+		return out[0].Interface().(error)
+	}
+	return nil
+}
+
+// exposeField returns a settable view of the unexported field, which
+// must be addressable. See UnexportedFieldsUnsafe.
+func exposeField(field reflect.Value) reflect.Value {
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// account keeps its invariants behind unexported fields
+type account struct {
+	mu      sync.Mutex
+	email   string `query:"email"`
+	plan    string `query:"plan,omitempty" default:"free"`
+	seats   int    `query:"seats,omitempty" default:"1"`
+	address struct {
+		City string `query:"city"`
+	}
+	Name string `query:"name"`
+
+	setSeats int // Number of SetSeats calls
+}
+
+func (a *account) SetEmail(email string) error {
+	if !strings.Contains(email, "@") {
+		return errors.New("invalid email")
+	}
+	a.email = strings.ToLower(email)
+	return nil
+}
+
+func (a *account) SetPlan(plan string) { a.plan = plan }
+
+func (a *account) SetSeats(seats int) {
+	a.seats = seats
+	a.setSeats++
+}
+
+func (a *account) SetAddress(address struct {
+	City string `query:"city"`
+}) {
+	a.address = address
+}
+
+type badSetter struct {
+	email string `query:"email"`
+}
+
+func (b *badSetter) SetEmail(email []byte) { b.email = string(email) }
+
+func TestUnexportedFields(t *testing.T) {
+	t.Run("Skip", func(t *testing.T) {
+		var a account
+		require.NoError(t, NewHTTPRequestParser().Parse(newTestRequest(t, "GET", "http://example.com/?email=Ann@Example.com&name=Ann", nil), &a))
+		assert.Equal(t, "Ann", a.Name)
+		assert.Empty(t, a.email)
+	})
+
+	t.Run("Setter", func(t *testing.T) {
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{UnexportedFields: UnexportedFieldsSetter})
+
+		var a account
+		require.NoError(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/?email=Ann@Example.com&name=Ann&city=Oslo", nil), &a))
+		assert.Equal(t, "ann@example.com", a.email, "setters enforce invariants")
+		assert.Equal(t, "free", a.plan, "defaults are passed to setters")
+		assert.Equal(t, "Oslo", a.address.City)
+		assert.Equal(t, "Ann", a.Name)
+		assert.Equal(t, 1, a.seats)
+		assert.Equal(t, 1, a.setSeats)
+
+		err := parser.Parse(newTestRequest(t, "GET", "http://example.com/?email=ann&name=Ann&city=Oslo", nil), &account{})
+		assert.ErrorContains(t, err, "invalid email")
+		assert.Equal(t, "email", FieldPath(err))
+
+		err = parser.Parse(newTestRequest(t, "GET", "http://example.com/?email=ann@example.com", nil), &badSetter{})
+		assert.ErrorIs(t, err, ErrInvalidFieldSetter)
+
+		err = parser.Parse(newTestRequest(t, "GET", "http://example.com/?id=1", nil), &struct {
+			id int `query:"id"`
+		}{})
+		assert.ErrorIs(t, err, ErrNoFieldSetter)
+	})
+
+	t.Run("Unsafe", func(t *testing.T) {
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{UnexportedFields: UnexportedFieldsUnsafe})
+
+		var a account
+		require.NoError(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/?email=Ann@Example.com&seats=3&name=Ann&city=Oslo", nil), &a))
+		assert.Equal(t, "Ann@Example.com", a.email)
+		assert.Equal(t, "free", a.plan)
+		assert.Equal(t, 3, a.seats)
+		assert.Zero(t, a.setSeats, "setters are not called")
+		assert.Equal(t, "Oslo", a.address.City)
+	})
+}
@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ParseReport records where the time of a single parse went, step by
+// step. It is returned by ParseWithReport, which is meant for
+// development and profiling: timing every step has a cost of its own.
+//
+// Binding handlers usually cache what they read from a source, so the
+// cost of e.g. reading and decoding a JSON body shows up in the
+// BindingDuration of the first step that uses a json binding.
+type ParseReport struct {
+	Type          reflect.Type  // Type of the destination struct
+	Version       string        // Version of the source, see VersionOpts
+	Duration      time.Duration // Total duration of the parse
+	ChainDuration time.Duration // Time spent getting (or building) the parse chain
+	BatchDuration time.Duration // Time spent in the BindingBatchHandler, if any
+	Steps         []StepTiming  // Timing of every executed field, in execution order
+}
+
+// StepTiming is the timing of the step of a single field. Struct fields
+// parsed recursively have no timing of their own, their fields do.
+type StepTiming struct {
+	Field           string        // Dotted path of the field (e.g. "Address.Street")
+	Binding         Binding       // Binding the field was populated from. Zero if none.
+	Default         bool          // Whether the field was populated from its default value
+	Duration        time.Duration // Total time spent in the step
+	BindingDuration time.Duration // Part of Duration spent getting values from the source
+	Err             error         // Error of the step, if any
+	Reads           []BindingRead // Values read from the source, in binding order, see ParseReport.Snapshot
+}
+
+// ReportingParser is implemented by parsers that can report the timing
+// of a parse, such as those built on BaseMBParser.
+type ReportingParser interface {
+	Parser
+	// ParseWithReport is Parse, also returning a report of where the time
+	// of the parse went. The report is returned even if parsing fails.
+	ParseWithReport(source any, dest any) (*ParseReport, error)
+}
+
+// Share returns the fraction of the total duration of the report spent
+// in step, from 0 to 1.
+func (report *ParseReport) Share(step StepTiming) float64 {
+	if report.Duration <= 0 {
+		return 0
+	}
+	return float64(step.Duration) / float64(report.Duration)
+}
+
+// Slowest returns the n slowest steps of the report, slowest first. A
+// negative n returns all steps.
+func (report *ParseReport) Slowest(n int) []StepTiming {
+	steps := slices.Clone(report.Steps)
+	slices.SortStableFunc(steps, func(a, b StepTiming) int {
+		return cmp.Compare(b.Duration, a.Duration)
+	})
+
+	if n >= 0 && n < len(steps) {
+		steps = steps[:n]
+	}
+	return steps
+}
+
+// String formats the report as a table of its steps, slowest first.
+func (report *ParseReport) String() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "parse of %v took %v (parse chain %v", report.Type, report.Duration, report.ChainDuration)
+	if report.BatchDuration > 0 {
+		fmt.Fprintf(&sb, ", binding batch %v", report.BatchDuration)
+	}
+	sb.WriteString(")\n")
+
+	tw := tabwriter.NewWriter(&sb, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tSOURCE\tTIME\tSHARE\tBINDINGS\t")
+
+	for _, step := range report.Slowest(-1) {
+		source := "-"
+		switch {
+		case step.Err != nil:
+			source = "error"
+		case step.Default:
+			source = "default"
+		case step.Binding.Name != "":
+			source = fmt.Sprintf("%s:%q", step.Binding.Name, step.Binding.Identifier)
+		}
+
+		fmt.Fprintf(
+			tw, "%s\t%s\t%v\t%.1f%%\t%v\t\n",
+			step.Field, source, step.Duration, 100*report.Share(step), step.BindingDuration,
+		)
+	}
+
+	tw.Flush()
+
+	for _, dep := range report.Deprecations() {
+		fmt.Fprintf(&sb, "warning: %s was bound from deprecated %s:%q", dep.Field, dep.Binding.Name, dep.Binding.Identifier)
+		if dep.Message != "" {
+			fmt.Fprintf(&sb, ", %s", dep.Message)
+		}
+		if !dep.Sunset.IsZero() {
+			fmt.Fprintf(&sb, " (sunset %s)", dep.Sunset.Format(time.DateOnly))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
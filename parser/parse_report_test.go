@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowBindingManager is a regionBindingManager that takes a while to get
+// the value of the "slow" identifier.
+type slowBindingManager struct {
+	regionBindingManager
+}
+
+func (m slowBindingManager) BindingHandler(source *map[string]string, binding Binding) BindingResult {
+	if binding.Identifier == "slow" {
+		time.Sleep(20 * time.Millisecond)
+	}
+	return m.regionBindingManager.BindingHandler(source, binding)
+}
+
+func (m slowBindingManager) BindingHandlerCached(
+	source *map[string]string, _ *CacheEntry[struct{}], binding Binding,
+) BindingResult {
+	return m.BindingHandler(source, binding)
+}
+
+func newSlowParser() *BaseMBParser[map[string]string, struct{}] {
+	return NewBaseMBParser(slowBindingManager{}, BaseMBParserOpts{
+		PCMOpts: NewPCManagerOpts(ParseTagOpts{
+			BindingOpts: BindingOpts{AllowedBindingNames: []string{MapValueTagBinding}},
+		}),
+	})
+}
+
+func TestBaseMBParser_ParseWithReport(t *testing.T) {
+	type Inner struct {
+		Value int `mapvalue:"value"`
+	}
+	type Request struct {
+		Slow  string `mapvalue:"slow"`
+		Fast  string `mapvalue:"fast"`
+		Port  int    `mapvalue:"port,omitempty" default:"8080"`
+		Inner Inner
+	}
+
+	parser := newSlowParser()
+	var _ ReportingParser = &HTTPRequestParser{}
+
+	t.Run("Success", func(t *testing.T) {
+		source := map[string]string{"slow": "a", "fast": "b", "value": "3"}
+
+		var dest Request
+		report, err := parser.ParseWithReport(&source, &dest)
+		require.NoError(t, err)
+		assert.Equal(t, Request{Slow: "a", Fast: "b", Port: 8080, Inner: Inner{Value: 3}}, dest)
+
+		assert.Equal(t, "Request", report.Type.Name())
+		require.Len(t, report.Steps, 4)
+
+		fields := make([]string, len(report.Steps))
+		for i, step := range report.Steps {
+			fields[i] = step.Field
+			assert.NoError(t, step.Err)
+			assert.LessOrEqual(t, step.BindingDuration, step.Duration)
+		}
+		assert.Equal(t, []string{"Slow", "Fast", "Port", "Inner.Value"}, fields)
+
+		assert.Equal(t, MapValueTagBinding, report.Steps[3].Binding.Name)
+		assert.Equal(t, "value", report.Steps[3].Binding.Identifier)
+		assert.True(t, report.Steps[2].Default)
+		assert.Empty(t, report.Steps[2].Binding.Name)
+
+		slowest := report.Slowest(1)
+		require.Len(t, slowest, 1)
+		assert.Equal(t, "Slow", slowest[0].Field)
+		assert.GreaterOrEqual(t, slowest[0].BindingDuration, 20*time.Millisecond)
+		assert.Greater(t, report.Share(slowest[0]), 0.5)
+		assert.LessOrEqual(t, report.Share(slowest[0]), 1.0)
+		assert.Len(t, report.Slowest(-1), 4)
+
+		assert.Contains(t, report.String(), "Inner.Value")
+		assert.Contains(t, report.String(), `mapvalue:"slow"`)
+		assert.Contains(t, report.String(), "default")
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		source := map[string]string{"slow": "a", "fast": "b", "value": "three"}
+
+		report, err := parser.ParseWithReport(&source, &Request{})
+		require.Error(t, err)
+		require.NotNil(t, report)
+		require.Len(t, report.Steps, 4)
+		assert.Error(t, report.Steps[3].Err)
+		assert.Equal(t, "Inner.Value", report.Steps[3].Field)
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		source := map[string]string{}
+
+		_, err := parser.ParseWithReport(source, &Request{})
+		assert.Error(t, err)
+
+		_, err = parser.ParseWithReport(&source, Request{})
+		assert.Error(t, err)
+	})
+}
@@ -1,4 +1,4 @@
-package pave
+package parser
 
 import (
 	"reflect"
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	ErrDefaultParsersInUse = errors.New("default parsers are already in use, set them before the first parse or registry")
+)
+
+// ParserFactory constructs a Parser. Default parsers are given as
+// factories so that only the ones an application uses are constructed.
+type ParserFactory func() Parser
+
+var (
+	_defaultParsersMu        sync.Mutex
+	_defaultParserFactories  = _builtinDefaultParsers
+	_defaultSourceParsers    []Parser
+	_defaultParsersInstalled bool
+)
+
+// SetDefaultParsers replaces the parsers that NewParserRegistry registers
+// unless ParserRegistryOpts.ExcludeDefaults is set, and that the global
+// registry behind Parse, WithParser, etc. starts with.
+//
+// Default parsers are constructed on first use, e.g. by the first call to
+// Parse, and then shared. SetDefaultParsers must be called before that,
+// typically from main, or it returns ErrDefaultParsersInUse.
+//
+// Without SetDefaultParsers, the defaults are the HTTPRequestParser, or
+// none when building with the pave_nodefaults tag.
+func SetDefaultParsers(factories ...ParserFactory) error {
+	_defaultParsersMu.Lock()
+	defer _defaultParsersMu.Unlock()
+
+	if _defaultParsersInstalled {
+		return ErrDefaultParsersInUse
+	}
+
+	_defaultParserFactories = factories
+	return nil
+}
+
+// DefaultParsers returns the default parsers, constructing them on first
+// use. See SetDefaultParsers.
+func DefaultParsers() []Parser {
+	_defaultParsersMu.Lock()
+	defer _defaultParsersMu.Unlock()
+
+	if !_defaultParsersInstalled {
+		for _, factory := range _defaultParserFactories {
+			_defaultSourceParsers = append(_defaultSourceParsers, factory())
+		}
+		_defaultParsersInstalled = true
+	}
+
+	return _defaultSourceParsers
+}
+
+// newDefaultParsers constructs new instances of the default parsers,
+// which are not shared.
+func newDefaultParsers() []Parser {
+	_defaultParsersMu.Lock()
+	defer _defaultParsersMu.Unlock()
+
+	parsers := make([]Parser, 0, len(_defaultParserFactories))
+	for _, factory := range _defaultParserFactories {
+		parsers = append(parsers, factory())
+	}
+	return parsers
+}
@@ -0,0 +1,9 @@
+//go:build !pave_nodefaults
+
+package parser
+
+// _builtinDefaultParsers are the default parsers of the registries. Build
+// with the pave_nodefaults tag to start without any.
+var _builtinDefaultParsers = []ParserFactory{
+	func() Parser { return NewHTTPRequestParser() },
+}
@@ -0,0 +1,7 @@
+//go:build pave_nodefaults
+
+package parser
+
+// Built with pave_nodefaults: registries start without default parsers,
+// unless some are set with SetDefaultParsers.
+var _builtinDefaultParsers []ParserFactory
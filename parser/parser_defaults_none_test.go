@@ -0,0 +1,17 @@
+//go:build pave_nodefaults
+
+package parser
+
+import (
+	"os"
+	"testing"
+)
+
+// Built with pave_nodefaults, the tests of the package level functions use
+// the HTTPRequestParser as their only default parser.
+func TestMain(m *testing.M) {
+	if err := SetDefaultParsers(func() Parser { return NewHTTPRequestParser() }); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
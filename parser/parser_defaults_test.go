@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetDefaultParsers lets a test set the default parsers as if none had
+// been used yet, restoring the previous defaults when it ends.
+func resetDefaultParsers(t *testing.T) {
+	_defaultParsersMu.Lock()
+	factories, parsers, installed := _defaultParserFactories, _defaultSourceParsers, _defaultParsersInstalled
+	_defaultParserFactories, _defaultSourceParsers, _defaultParsersInstalled = _builtinDefaultParsers, nil, false
+	_defaultParsersMu.Unlock()
+
+	t.Cleanup(func() {
+		_defaultParsersMu.Lock()
+		_defaultParserFactories, _defaultSourceParsers, _defaultParsersInstalled = factories, parsers, installed
+		_defaultParsersMu.Unlock()
+	})
+}
+
+func TestSetDefaultParsers(t *testing.T) {
+	t.Run("ConstructedLazily", func(t *testing.T) {
+		resetDefaultParsers(t)
+
+		constructed := 0
+		require.NoError(t, SetDefaultParsers(func() Parser {
+			constructed++
+			return &MockParser{name: "mock", sourceType: StringType}
+		}))
+		assert.Zero(t, constructed)
+
+		for i := 0; i < 2; i++ {
+			registry, err := NewParserRegistry(ParserRegistryOpts{})
+			require.NoError(t, err)
+
+			parser, err := registry.tryGetDefaultParser("source")
+			require.NoError(t, err)
+			assert.Equal(t, "mock", parser.Name())
+		}
+		assert.Equal(t, 1, constructed, "default parsers are shared")
+
+		_, err := NewParserRegistry(ParserRegistryOpts{ExcludeDefaults: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("NotConstructedWhenExcluded", func(t *testing.T) {
+		resetDefaultParsers(t)
+
+		require.NoError(t, SetDefaultParsers(func() Parser {
+			t.Fatal("default parser constructed")
+			return nil
+		}))
+
+		_, err := NewParserRegistry(ParserRegistryOpts{ExcludeDefaults: true})
+		require.NoError(t, err)
+	})
+
+	t.Run("InUse", func(t *testing.T) {
+		resetDefaultParsers(t)
+
+		assert.Len(t, DefaultParsers(), len(_builtinDefaultParsers))
+		assert.ErrorIs(t, SetDefaultParsers(), ErrDefaultParsersInUse)
+	})
+
+	t.Run("None", func(t *testing.T) {
+		resetDefaultParsers(t)
+		require.NoError(t, SetDefaultParsers())
+
+		registry, err := NewParserRegistry(ParserRegistryOpts{})
+		require.NoError(t, err)
+		assert.Empty(t, registry.m)
+	})
+}
@@ -0,0 +1,443 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	ErrNoFieldSourcesInTag            = errors.New("no fields sources defined in tag but attempted to validate field")
+	ErrParserAlreadyRegistered        = errors.New("a parser with this name for this source-type is already registered")
+	ErrNoParser                       = errors.New("no built-in or registered parser found for this type")
+	ErrNoParserBuiltin                = errors.New("no built-in parser found for this type")
+	ErrNoParserRegistered             = errors.New("no registered parser found for this type")
+	ErrMultipleParsersAvailable       = errors.New("multiple parsers available for this source type, use WithParser() to specify which one")
+	ErrParserNotFound                 = errors.New("specified parser not found for this source type")
+	ErrNoParseExecutionChain          = errors.New("no parse execution chain found for this type")
+	ErrInvalidParseExecutionChainType = errors.New("improper type passed for this parse execution chain")
+	ErrNilDest                        = errors.New("dest cannot be nil")
+	ErrDestNotStructPtr               = errors.New("dest must be a non-nil pointer to a struct type")
+	ErrSourceTypeMismatch             = errors.New("source is not of the type the parser works with")
+)
+
+// ParseError is returned by the ParserRegistry when the selected parser
+// failed to populate the destination.
+type ParseError struct {
+	Parser string // Name of the parser that failed
+	Err    error  // Underlying cause
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse with %s: %s", e.Parser, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned by the ParserRegistry when the destination
+// was populated but its Validate method rejected the result.
+type ValidationError struct {
+	Parser string // Name of the parser that populated the destination
+	Err    error  // Error returned by Validate
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation failed after parsing with %s: %s", e.Parser, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+type Validatable interface {
+	// Validate checks the fields of the struct and returns an error
+	// if any of the fields are invalid.
+	Validate() error
+}
+
+// ParserRegistry is the main struct that handles validation
+// of Validatable types using registered SourceParsers.
+//
+// It provides methods to register parsers, validate data,
+// and invalidate partially or fully validated structs.
+//
+// Multiple SourceParsers can be registered for each SourceType.
+// If only one parser is registered for a type, it will be used
+// automatically. If multiple parsers are registered, you must
+// use WithParser() to specify which one to use.
+//
+// Each SourceParser will build and cache an execution chain
+// for each unique Validatable type it is used with.
+type ParserRegistry struct {
+	m              map[reflect.Type]map[string]Parser // source type -> parser name -> parser
+	invalidateOpts InvalidateOpts                     // used when a failed parse is invalidated
+	environment    string                             // selects environment variants of tags
+	errorValues    ErrorValueOpts                     // how errors echo the values of fields
+	maxErrors      int                                // validation errors collected at most, all if negative
+}
+
+// ParserRegistryContext provides a curried Registry with a specific parser selection
+type ParserRegistryContext struct {
+	registry   *ParserRegistry
+	parserName string
+}
+
+type ParserRegistryOpts struct {
+	Parsers         []Parser
+	ExcludeDefaults bool
+	Invalidate      InvalidateOpts // How dest is reset by Invalidate and after a failed parse
+
+	// Environment selects the environment variants of tags (e.g.
+	// `default.dev:"true" default.prod:"false"`) of the registered
+	// EnvironmentParsers and of the defaults applied by Invalidate, so
+	// that one struct serves all environments. With an environment, the
+	// registry constructs its own default parsers rather than sharing
+	// them, and parsers passed in Parsers or to Register are switched to
+	// it.
+	Environment string
+
+	// ErrorValues truncates or redacts the values of fields echoed in the
+	// messages of the ParseErrors and ValidationErrors of the registry.
+	ErrorValues ErrorValueOpts
+
+	// MaxErrors is the number of validation errors collected at most,
+	// the rest being summarized by a *MoreErrors. Zero selects
+	// DefaultMaxErrors, negative values collect every error.
+	MaxErrors int
+}
+
+func NewParserRegistry(opts ParserRegistryOpts) (*ParserRegistry, error) {
+	reg := &ParserRegistry{
+		m:              make(map[reflect.Type]map[string]Parser),
+		invalidateOpts: opts.Invalidate,
+		environment:    opts.Environment,
+		errorValues:    opts.ErrorValues,
+		maxErrors:      resolveMaxErrors(opts.MaxErrors),
+	}
+
+	if !opts.ExcludeDefaults {
+		defaults := DefaultParsers
+		if opts.Environment != "" {
+			// Shared default parsers are built for no environment
+			defaults = newDefaultParsers
+		}
+		for _, parser := range defaults() {
+			err := reg.Register(parser)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, parser := range opts.Parsers {
+		err := reg.Register(parser)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reg, nil
+}
+
+// Now your registration method can accept the non-generic interface
+func (reg *ParserRegistry) Register(parser Parser) error {
+	typ := parser.SourceType()
+	name := parser.Name()
+
+	if envParser, ok := parser.(EnvironmentParser); ok && reg.environment != "" {
+		envParser.SetEnvironment(reg.environment)
+	}
+
+	if reg.m[typ] == nil {
+		reg.m[typ] = make(map[string]Parser)
+	}
+
+	reg.m[typ][name] = parser
+	return nil
+}
+
+// WithParser returns a ValidatorContext that will use the specified parser
+// for validation. This is useful when multiple parsers are registered for
+// the same source type.
+func (reg *ParserRegistry) WithParser(parserName string) *ParserRegistryContext {
+	return &ParserRegistryContext{
+		registry:   reg,
+		parserName: parserName,
+	}
+}
+
+// Parse populates dest based on the specified parser's logic.
+// It expects the passed dest to be a pointer.
+func (regCtx *ParserRegistryContext) Parse(source any, dest any, validate bool) error {
+	parser, err := regCtx.registry.getParserByName(source, regCtx.parserName)
+	if err != nil {
+		return err
+	}
+
+	return regCtx.registry.parseWith(parser, source, dest, nil, validate)
+}
+
+// ParseMasked is Parse, populating and validating only the fields that
+// paths select. See ParserRegistry.ParseMasked.
+func (regCtx *ParserRegistryContext) ParseMasked(source any, dest any, paths []string, validate bool) error {
+	parser, err := regCtx.registry.getParserByName(source, regCtx.parserName)
+	if err != nil {
+		return err
+	}
+
+	return regCtx.registry.parseWith(parser, source, dest, paths, validate)
+}
+
+// Parse populates dest based on the implementation of source's
+// parsing logic.
+//
+// It only succeeds if there is exactly one parser registered
+// for data's type. To use a specific parser, you must
+// use the WithParser() method to specify which one to use.
+//
+// # It expects dest to be a pointer
+//
+// If validation fails, it will return the validation error
+// and zero all of dest's fields.
+func (reg *ParserRegistry) Parse(source any, dest any, validate bool) error {
+
+	if err := checkDest(dest); err != nil {
+		return err
+	}
+
+	parser, err := reg.tryGetDefaultParser(source)
+	if err != nil {
+		return err
+	}
+
+	return reg.parseWith(parser, source, dest, nil, validate)
+}
+
+// ParseMasked is Parse, populating only the fields of dest that paths
+// select, e.g. the paths of a google.protobuf.FieldMask or of
+// HeaderFieldMask. With validate set, only their `validate` tags are
+// checked (see validate.ValidateMasked) before the Validate method of
+// dest. The parser must be a MaskedParser, unless paths are empty.
+func (reg *ParserRegistry) ParseMasked(source any, dest any, paths []string, validate bool) error {
+
+	if err := checkDest(dest); err != nil {
+		return err
+	}
+
+	parser, err := reg.tryGetDefaultParser(source)
+	if err != nil {
+		return err
+	}
+
+	return reg.parseWith(parser, source, dest, paths, validate)
+}
+
+// parseWith populates the fields of dest that paths select (all of them
+// if paths are empty) with parser and, if validate is set, checks the
+// `validate` tags of those fields and then the Validate method of dest.
+func (reg *ParserRegistry) parseWith(parser Parser, source any, dest any, paths []string, validate bool) error {
+	var err error
+	if len(paths) == 0 {
+		err = parser.Parse(source, dest)
+	} else if masked, ok := parser.(MaskedParser); ok {
+		err = masked.ParseMasked(source, dest, paths)
+	} else {
+		err = fmt.Errorf("%w: %s", ErrMaskUnsupported, parser.Name())
+	}
+	if err != nil {
+		if dest, ok := dest.(Validatable); ok {
+			reg.Invalidate(dest)
+		}
+		return &ParseError{Parser: parser.Name(), Err: reg.errorValues.Sanitize(err)}
+	}
+
+	if !validate {
+		return nil
+	}
+
+	err = validateMasked(dest, paths, reg.maxErrors)
+	if err == nil {
+		if dest, ok := dest.(Validatable); ok {
+			err = dest.Validate()
+		}
+	}
+
+	if err != nil {
+		reg.InvalidateWithOpts(dest, reg.invalidateOpts)
+		return &ValidationError{Parser: parser.Name(), Err: reg.errorValues.Sanitize(err)}
+	}
+
+	return nil
+}
+
+// tryGetDefaultParser retrieves the appropriate SourceParser for the given data type.
+//
+// If multiple parsers are found for the same source type, it returns an error
+// indicating that WithParser() should be used to specify which one.
+//
+// If no parser is found, it returns ErrNoParser.
+func (reg *ParserRegistry) tryGetDefaultParser(source any) (Parser, error) {
+	parser, err := reg.getParserByName(source, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return parser, nil
+}
+
+// getParserByName retrieves a specific parser by name for the given data type.
+//
+// No name provided: If there is only one parser registered for the type,
+// it returns that parser. If multiple parsers are registered, it returns an error
+//
+// Parsers register the SourceType they parse, which is not a pointer for
+// the parsers of this package (e.g. http.Request), while sources are
+// passed by pointer (e.g. an *http.Request). A pointer source therefore
+// matches the parsers of its element type, unless parsers are registered
+// for the pointer type itself.
+func (reg *ParserRegistry) getParserByName(source any, parserName string) (Parser, error) {
+	t := reflect.TypeOf(source)
+
+	parsersForType, exists := reg.m[t]
+	if !exists && t != nil && t.Kind() == reflect.Ptr {
+		parsersForType, exists = reg.m[t.Elem()]
+	}
+
+	// Check registered parsers
+	if exists {
+
+		// If no parser name is specified, handle the case of multiple parsers
+		// registered for the same type.
+		// - 0 parsers: return ErrNoParserRegistered
+		// - 1 parser: return it
+		// - >1 parsers: return an error
+		if parserName == "" {
+			switch len(parsersForType) {
+			case 0:
+				return nil, ErrNoParserRegistered
+			case 1:
+				for _, parser := range parsersForType {
+					return parser, nil
+				}
+			default:
+				return nil, ErrMultipleParsersAvailable
+			}
+		}
+
+		if parser, found := parsersForType[parserName]; found {
+			return parser, nil
+		}
+	}
+
+	return nil, ErrParserNotFound
+}
+
+// InvalidateOpts configures how Invalidate resets the fields of a struct.
+//
+// Fields tagged `preserve:"true"` are never reset, regardless of options.
+type InvalidateOpts struct {
+	// UseDefaults resets fields with a `default` tag to the tag value
+	// instead of their zero value.
+	UseDefaults bool
+
+	// KeepAllocations resets non-nil pointers to structs in place,
+	// truncates slices to zero length and clears maps instead of setting
+	// them to nil, so that a reused struct does not reallocate.
+	KeepAllocations bool
+
+	environment string // Set by the registry, see ParserRegistryOpts.Environment
+}
+
+// Invalidate clears a partially or fully validated dest by
+// resetting each field according to the registry's InvalidateOpts.
+//
+// # It expects the passed v to be a pointer
+//
+// An error is returned if the argument is not reflect-able
+func (reg *ParserRegistry) Invalidate(dest Validatable) error {
+	return reg.InvalidateWithOpts(dest, reg.invalidateOpts)
+}
+
+// InvalidateWithOpts clears dest like Invalidate, using opts instead of
+// the registry's InvalidateOpts.
+//
+// An error is returned if dest is not a non-nil pointer or if a default
+// value cannot be applied.
+func (reg *ParserRegistry) InvalidateWithOpts(dest any, opts InvalidateOpts) error {
+	if dest == nil {
+		return ErrNilDest
+	}
+
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf("%w: cannot invalidate %T", ErrDestNotStructPtr, dest)
+	}
+
+	opts.environment = reg.environment
+	return resetStructFields(value.Elem(), opts)
+}
+
+// Environment returns the environment selected for the registry, see
+// ParserRegistryOpts.Environment.
+func (reg *ParserRegistry) Environment() string {
+	return reg.environment
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Global Singleton and Package Functions
+///////////////////////////////////////////////////////////////////////////////
+
+var (
+	_gParserRegistry     *ParserRegistry
+	_gParserRegistryOnce sync.Once
+)
+
+// globalRegistry returns the global ParserRegistry, creating it with the
+// default parsers on first use.
+func globalRegistry() *ParserRegistry {
+	_gParserRegistryOnce.Do(func() {
+		var err error
+		_gParserRegistry, err = NewParserRegistry(ParserRegistryOpts{ExcludeDefaults: false})
+		if err != nil {
+			panic(fmt.Sprintf("Failed to initialize global ParserRegistry: %v", err))
+		}
+	})
+	return _gParserRegistry
+}
+
+// Package-level functions that delegate to the global ParserRegistry instance
+
+func RegisterParser(parser Parser) error {
+	return globalRegistry().Register(parser)
+}
+
+func Parse(source any, dest any, validate bool) error {
+	return globalRegistry().Parse(source, dest, validate)
+}
+
+func ParseMasked(source any, dest any, paths []string, validate bool) error {
+	return globalRegistry().ParseMasked(source, dest, paths, validate)
+}
+
+func WithParser(parserName string) *ParserRegistryContext {
+	return globalRegistry().WithParser(parserName)
+}
+
+func Invalidate(dest Validatable) error {
+	return globalRegistry().Invalidate(dest)
+}
+
+func InvalidateWithOpts(dest any, opts InvalidateOpts) error {
+	return globalRegistry().InvalidateWithOpts(dest, opts)
+}
+
+func GetParser(source any) (Parser, error) {
+	return globalRegistry().tryGetDefaultParser(source)
+}
+
+func GetParserByName(source any, parserName string) (Parser, error) {
+	return globalRegistry().getParserByName(source, parserName)
+}
@@ -1,7 +1,8 @@
-package pave
+package parser
 
 import (
 	"errors"
+	"net/http"
 	"reflect"
 	"testing"
 
@@ -210,6 +211,7 @@ func TestParserRegistry(t *testing.T) {
 		err = registry.Parse(source, nil, false)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "dest cannot be nil")
+		assert.ErrorIs(t, err, ErrNilDest)
 	})
 
 	t.Run("Parse_NonPointerDest", func(t *testing.T) {
@@ -224,6 +226,7 @@ func TestParserRegistry(t *testing.T) {
 		err = registry.Parse(source, dest, false)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "dest must be a non-nil pointer to a struct type")
+		assert.ErrorIs(t, err, ErrDestNotStructPtr)
 	})
 
 	t.Run("Parse_NoParserFound", func(t *testing.T) {
@@ -292,6 +295,36 @@ func TestParserRegistry(t *testing.T) {
 		// Note: Invalidate should zero out the struct fields
 		assert.Equal(t, "", dest.Value)
 	})
+
+	t.Run("Invalidate_WithDefaults", func(t *testing.T) {
+		type Defaulted struct {
+			MockValidatable
+			Page int `default:"1"`
+		}
+
+		registry, err := NewParserRegistry(ParserRegistryOpts{
+			ExcludeDefaults: true,
+			Invalidate:      InvalidateOpts{UseDefaults: true},
+		})
+		require.NoError(t, err)
+
+		dest := &Defaulted{MockValidatable: MockValidatable{Value: "test"}, Page: 5}
+
+		err = registry.Invalidate(dest)
+		assert.NoError(t, err)
+		assert.Equal(t, "", dest.Value)
+		assert.Equal(t, 1, dest.Page)
+
+		err = registry.InvalidateWithOpts(dest, InvalidateOpts{})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, dest.Page)
+
+		err = registry.InvalidateWithOpts(*dest, InvalidateOpts{})
+		assert.ErrorIs(t, err, ErrDestNotStructPtr)
+
+		err = registry.InvalidateWithOpts(nil, InvalidateOpts{})
+		assert.ErrorIs(t, err, ErrNilDest)
+	})
 }
 
 func TestParserRegistryContext(t *testing.T) {
@@ -473,3 +506,100 @@ func TestPackageLevelFunctions(t *testing.T) {
 		_ = err
 	})
 }
+
+func TestParserRegistry_ErrorTypes(t *testing.T) {
+	registry, err := NewParserRegistry(ParserRegistryOpts{ExcludeDefaults: true})
+	require.NoError(t, err)
+
+	parseErr := errors.New("bad input")
+	err = registry.Register(&MockParser{
+		name:       "mock",
+		sourceType: reflect.TypeOf(""),
+		parseFunc: func(source any, dest any) error {
+			if *source.(*string) == "bad" {
+				return parseErr
+			}
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("ParseError", func(t *testing.T) {
+		source := "bad"
+		err := registry.Parse(&source, &MockValidatable{}, false)
+
+		var pe *ParseError
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, "mock", pe.Parser)
+		assert.ErrorIs(t, err, parseErr)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		source := "ok"
+		err := registry.Parse(&source, &MockValidatable{ShouldErr: true}, true)
+
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		assert.Equal(t, "mock", ve.Parser)
+		assert.Contains(t, err.Error(), "validation failed after parsing with mock")
+	})
+}
+
+func TestParserRegistry_PointerSources(t *testing.T) {
+	parsedBy := func(name string, sourceType reflect.Type, parsed *string) *MockParser {
+		return &MockParser{
+			name:       name,
+			sourceType: sourceType,
+			parseFunc: func(source any, dest any) error {
+				*parsed = name
+				return nil
+			},
+		}
+	}
+
+	t.Run("MatchesElemType", func(t *testing.T) {
+		registry, err := NewParserRegistry(ParserRegistryOpts{ExcludeDefaults: true})
+		require.NoError(t, err)
+
+		var parsed string
+		require.NoError(t, registry.Register(parsedBy("value", reflect.TypeOf(""), &parsed)))
+
+		source := "ok"
+		require.NoError(t, registry.Parse(&source, &MockValidatable{}, false))
+		assert.Equal(t, "value", parsed)
+
+		sourcePtr := &source
+		err = registry.Parse(&sourcePtr, &MockValidatable{}, false)
+		assert.Error(t, err, "only one pointer is dereferenced")
+	})
+
+	t.Run("PointerTypeFirst", func(t *testing.T) {
+		registry, err := NewParserRegistry(ParserRegistryOpts{ExcludeDefaults: true})
+		require.NoError(t, err)
+
+		var parsed string
+		require.NoError(t, registry.Register(parsedBy("value", reflect.TypeOf(""), &parsed)))
+		require.NoError(t, registry.Register(parsedBy("pointer", reflect.TypeOf(new(string)), &parsed)))
+
+		source := "ok"
+		require.NoError(t, registry.Parse(&source, &MockValidatable{}, false))
+		assert.Equal(t, "pointer", parsed)
+	})
+
+	t.Run("HTTPRequest", func(t *testing.T) {
+		registry, err := NewParserRegistry(ParserRegistryOpts{
+			Parsers:         []Parser{NewHTTPRequestParser()},
+			ExcludeDefaults: true,
+		})
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("GET", "http://example.com/?name=gopher", nil)
+		require.NoError(t, err)
+
+		var dest struct {
+			Name string `query:"name"`
+		}
+		require.NoError(t, registry.Parse(req, &dest, false))
+		assert.Equal(t, "gopher", dest.Name)
+	})
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"net/http"
+)
+
+// PathValueExtractor gets the path parameters of requests for the path
+// binding of the HTTPRequestParser, e.g. `path:"user_id"` for the route
+// /users/{user_id}. Routers store path parameters in their own way, so
+// the extractor adapts the router the request was routed by. See
+// HTTPRequestParserOpts.PathValues.
+type PathValueExtractor interface {
+	// PathValue returns the value of the path parameter name of req, or
+	// false if the route has no such parameter.
+	PathValue(req *http.Request, name string) (string, bool)
+}
+
+// PathValueExtractorFunc adapts a function to the PathValueExtractor
+// interface.
+type PathValueExtractorFunc func(req *http.Request, name string) (string, bool)
+
+func (f PathValueExtractorFunc) PathValue(req *http.Request, name string) (string, bool) {
+	return f(req, name)
+}
+
+// ServeMuxPathValues gets the path parameters set by http.ServeMux, or by
+// http.Request.SetPathValue. It is the default PathValueExtractor.
+var ServeMuxPathValues PathValueExtractor = PathValueExtractorFunc(
+	func(req *http.Request, name string) (string, bool) {
+		value := req.PathValue(name)
+		return value, value != ""
+	},
+)
+
+// ChiPathValues adapts the URLParam function of github.com/go-chi/chi,
+// e.g. ChiPathValues(chi.URLParam). Empty parameters are not found.
+func ChiPathValues(urlParam func(req *http.Request, name string) string) PathValueExtractor {
+	return PathValueExtractorFunc(func(req *http.Request, name string) (string, bool) {
+		value := urlParam(req, name)
+		return value, value != ""
+	})
+}
+
+// GorillaPathValues adapts the Vars function of github.com/gorilla/mux,
+// e.g. GorillaPathValues(mux.Vars). Empty parameters are not found.
+func GorillaPathValues(vars func(req *http.Request) map[string]string) PathValueExtractor {
+	return PathValueExtractorFunc(func(req *http.Request, name string) (string, bool) {
+		value := vars(req)[name]
+		return value, value != ""
+	})
+}
+
+// PathValue gets the value of the path parameter key with the
+// PathValueExtractor of the parser.
+func (mgr *HTTPBindingManager) PathValue(source *http.Request, key string) BindingResult {
+	extractor := mgr.opts.PathValues
+	if extractor == nil {
+		extractor = ServeMuxPathValues
+	}
+
+	value, ok := extractor.PathValue(source, key)
+	if !ok {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(value)
+}
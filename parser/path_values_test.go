@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type getUserRequest struct {
+	UserID int    `path:"user_id"`
+	Tab    string `path:"tab,omitempty" query:"tab,omitempty" default:"profile"`
+}
+
+func TestHTTPRequestParser_PathServeMux(t *testing.T) {
+	var (
+		user getUserRequest
+		err  error
+	)
+
+	parser := NewHTTPRequestParser()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{user_id}", func(w http.ResponseWriter, r *http.Request) {
+		err = parser.Parse(r, &user)
+	})
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/42?tab=posts", nil))
+	require.NoError(t, err)
+	assert.Equal(t, getUserRequest{UserID: 42, Tab: "posts"}, user)
+}
+
+func TestHTTPRequestParser_PathExtractors(t *testing.T) {
+	params := map[string]string{"user_id": "7", "tab": ""}
+
+	tests := []struct {
+		name      string
+		extractor PathValueExtractor
+	}{
+		{"Chi", ChiPathValues(func(_ *http.Request, name string) string { return params[name] })},
+		{"Gorilla", GorillaPathValues(func(*http.Request) map[string]string { return params })},
+		{"Func", PathValueExtractorFunc(func(_ *http.Request, name string) (string, bool) {
+			value, ok := params[name]
+			return value, ok && value != ""
+		})},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{PathValues: test.extractor})
+			req := httptest.NewRequest("GET", "/users/7", nil)
+
+			var user getUserRequest
+			require.NoError(t, parser.Parse(req, &user))
+			assert.Equal(t, getUserRequest{UserID: 7, Tab: "profile"}, user, "empty parameters are not found")
+		})
+	}
+}
+
+func TestHTTPRequestParser_PathMissing(t *testing.T) {
+	req := httptest.NewRequest("GET", "/users/7", nil)
+
+	var user getUserRequest
+	err := NewHTTPRequestParser().Parse(req, &user)
+	assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+}
+
+func TestNewHTTPRequest_Path(t *testing.T) {
+	req, err := NewHTTPRequest("GET", "http://example.com/users/9", &getUserRequest{UserID: 9, Tab: "posts"})
+	require.NoError(t, err)
+	assert.Equal(t, "9", req.PathValue("user_id"))
+
+	var user getUserRequest
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &user))
+	assert.Equal(t, getUserRequest{UserID: 9, Tab: "posts"}, user)
+}
@@ -0,0 +1,86 @@
+//go:build pave_phone
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+// Phone number support backed by github.com/nyaruka/phonenumbers (a port
+// of libphonenumber).
+//
+// It provides the `e164` value modifier, and the validate package the
+// `phone` validation rule:
+//
+//	type SignupRequest struct {
+//		Phone string `json:"phone,e164=US" validate:"required,phone"`
+//	}
+
+var (
+	ErrInvalidPhoneNumber = errors.New("invalid phone number")
+	ErrUnknownPhoneRegion = errors.New("unknown phone region")
+)
+
+// installPhone registers the phone integration. See installIntegrations.
+func installPhone() {
+	registerValueModifier(E164BindingModifier, ValueModifier{
+		Parse:     parsePhoneRegion,
+		Transform: normalizeE164,
+	})
+}
+
+// ParsePhoneNumber parses a phone number and returns it in E.164 format,
+// e.g. "+14155552671". region is the ISO 3166-1 alpha-2 code of the
+// country that numbers in national format (e.g. "(415) 555-2671") belong
+// to. With an empty region, only numbers in international format ("+"
+// followed by the country code) are accepted.
+func ParsePhoneNumber(value string, region string) (string, error) {
+	number, err := parsePhoneNumber(value, region)
+	if err != nil {
+		return "", err
+	}
+	return phonenumbers.Format(number, phonenumbers.E164), nil
+}
+
+// parsePhoneNumber parses and validates value, see ParsePhoneNumber.
+func parsePhoneNumber(value string, region string) (*phonenumbers.PhoneNumber, error) {
+	if region == "" {
+		region = phonenumbers.UNKNOWN_REGION
+	}
+
+	number, err := phonenumbers.Parse(value, region)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrInvalidPhoneNumber, value, err)
+	}
+	if !phonenumbers.IsValidNumber(number) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPhoneNumber, value)
+	}
+	return number, nil
+}
+
+// parsePhoneRegion is the ModifierValueParser of the e164 modifier.
+// Unknown regions fail when the parse chain is built. "ZZ" only accepts
+// numbers in international format.
+func parsePhoneRegion(value string) (any, error) {
+	region := strings.ToUpper(value)
+	if region != phonenumbers.UNKNOWN_REGION && !isPhoneRegion(region) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPhoneRegion, value)
+	}
+	return region, nil
+}
+
+// normalizeE164 is the ValueTransform of the e164 modifier, rewriting
+// bound phone numbers to E.164 format.
+func normalizeE164(value string, param any, _ Binding) (string, error) {
+	region, _ := param.(string)
+	return ParsePhoneNumber(value, region)
+}
+
+// isPhoneRegion reports whether region has a country calling code.
+func isPhoneRegion(region string) bool {
+	return phonenumbers.GetCountryCodeForRegion(region) != 0
+}
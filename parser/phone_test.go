@@ -0,0 +1,75 @@
+//go:build pave_phone
+
+package parser
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		region  string
+		want    string
+		wantErr error
+	}{
+		{"national_us", "(415) 555-2671", "US", "+14155552671", nil},
+		{"international", "+44 20 7946 0958", "", "+442079460958", nil},
+		{"international_other_region", "+44 20 7946 0958", "US", "+442079460958", nil},
+		{"national_without_region", "415 555 2671", "", "", ErrInvalidPhoneNumber},
+		{"invalid_number", "+1 000", "", "", ErrInvalidPhoneNumber},
+		{"garbage", "call me", "US", "", ErrInvalidPhoneNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePhoneNumber(tt.value, tt.region)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHTTPRequestParser_E164(t *testing.T) {
+	type request struct {
+		Phone string `json:"phone,e164=US"`
+		Intl  string `json:"intl,omitempty,e164=zz" default:""`
+	}
+
+	parse := func(body string) (request, error) {
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		var dest request
+		err = NewHTTPRequestParser().Parse(req, &dest)
+		return dest, err
+	}
+
+	dest, err := parse(`{"phone": "(415) 555-2671", "intl": "+44 20 7946 0958"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "+14155552671", dest.Phone)
+	assert.Equal(t, "+442079460958", dest.Intl)
+
+	_, err = parse(`{"phone": "555"}`)
+	assert.ErrorIs(t, err, ErrInvalidPhoneNumber)
+
+	t.Run("unknown_region", func(t *testing.T) {
+		type invalid struct {
+			Phone string `json:"phone,e164=XX"`
+		}
+		req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		assert.ErrorIs(t, NewHTTPRequestParser().Parse(req, &invalid{}), ErrUnknownPhoneRegion)
+	})
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	ErrEmptyPIICategory = errors.New("pii tag cannot be empty")
+)
+
+// PIIManifest lists the parsed fields of a struct type that hold personal
+// data, for data-governance tooling (records of processing, GDPR exports
+// and erasure). Fields are marked with the category of data they hold:
+//
+//	type SignUp struct {
+//		Email string `json:"email" pii:"email"`
+//		Name  string `json:"name" pii:"name"`
+//		Plan  string `json:"plan"`
+//	}
+//
+// Categories are free-form, e.g. "email", "phone" or "health".
+type PIIManifest struct {
+	Type   reflect.Type // The struct type
+	Fields []PIIField   // Fields holding personal data, in execution order
+}
+
+// PIIField is a parsed field holding personal data.
+type PIIField struct {
+	Field    string    // Dotted path of the field
+	Category string    // Value of the pii tag
+	Bindings []Binding // Bindings the field is parsed from
+}
+
+// PIIHook receives the PIIManifest of every struct type with personal
+// data that a parser builds a parse chain for, e.g. to register it with
+// data-governance tooling. See RegisterPIIHook.
+type PIIHook func(manifest PIIManifest)
+
+// piiHooks holds the registered PIIHooks keyed by name.
+var (
+	_piiHooks      = make(map[string]PIIHook)
+	_piiHooksMutex sync.RWMutex
+)
+
+// RegisterPIIHook registers hook as name. Hooks are called once per
+// parser and struct type, when the parse chain of a type with fields
+// tagged `pii` is built and cached, which is usually on its first parse
+// (and again if a cache budget evicted the chain, see MaxCacheBytes).
+// They must be safe for concurrent use.
+//
+// Registering a hook for an already registered name replaces it.
+func RegisterPIIHook(name string, hook PIIHook) {
+	_piiHooksMutex.Lock()
+	defer _piiHooksMutex.Unlock()
+
+	_piiHooks[name] = hook
+}
+
+// UnregisterPIIHook removes the hook registered as name, if any.
+func UnregisterPIIHook(name string) {
+	_piiHooksMutex.Lock()
+	defer _piiHooksMutex.Unlock()
+
+	delete(_piiHooks, name)
+}
+
+// runPIIHooks passes the PIIManifest of chain to the registered hooks,
+// unless it has no personal data.
+func runPIIHooks[S any](chain *ParseChain[S]) {
+	manifest := chain.PIIManifest()
+	if len(manifest.Fields) == 0 {
+		return
+	}
+
+	_piiHooksMutex.RLock()
+	defer _piiHooksMutex.RUnlock()
+
+	for _, hook := range _piiHooks {
+		hook(manifest)
+	}
+}
+
+// PIIManifest returns the fields of the chain, and of its sub-chains,
+// that hold personal data.
+func (chain *ParseChain[S]) PIIManifest() PIIManifest {
+	manifest := PIIManifest{Type: chain.StructType}
+	chain.collectPII(&manifest, "")
+	return manifest
+}
+
+func (chain *ParseChain[S]) collectPII(manifest *PIIManifest, prefix string) {
+	for step := chain.Head; step != nil; step = step.Next {
+		if step.PII != "" {
+			manifest.Fields = append(manifest.Fields, PIIField{
+				Field:    prefix + step.FieldName,
+				Category: step.PII,
+				Bindings: cloneBindings(step.Bindings),
+			})
+		}
+		if step.SubChain != nil {
+			step.SubChain.collectPII(manifest, prefix+step.FieldName+".")
+		}
+	}
+}
+
+// piiCategory returns the value of the pii tag of field, if any.
+func piiCategory(field reflect.StructField, opts ParseTagOpts) (string, error) {
+	tag, err := resolvedTag(field, opts)
+	if err != nil {
+		return "", err
+	}
+
+	category, ok := tag.Lookup(PIITagName)
+	if ok && category == "" {
+		return "", fmt.Errorf("%w in field %s", ErrEmptyPIICategory, field.Name)
+	}
+	return category, nil
+}
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPIIManifest(t *testing.T) {
+	type Address struct {
+		Street string `query:"street" pii:"address"`
+		City   string `query:"city"`
+	}
+	type SignUp struct {
+		Email   string  `query:"email" header:"X-Email,omitempty" pii:"email"`
+		Plan    string  `query:"plan"`
+		Address Address `query:"address_"`
+		Notes   string  `pii:"free_text"` // not parsed
+	}
+
+	var (
+		mu        sync.Mutex
+		manifests []PIIManifest
+	)
+	RegisterPIIHook("pii_test", func(manifest PIIManifest) {
+		mu.Lock()
+		defer mu.Unlock()
+		manifests = append(manifests, manifest)
+	})
+	t.Cleanup(func() { UnregisterPIIHook("pii_test") })
+
+	parser := NewHTTPRequestParser()
+	req := newTestRequest(t, "GET", "http://example.com/?email=ann@example.com&plan=pro&address_street=Main&address_city=Oslo", nil)
+
+	var signUp SignUp
+	require.NoError(t, parser.Parse(req, &signUp))
+	require.NoError(t, parser.Parse(req, &signUp))
+
+	require.Len(t, manifests, 1, "hooks run once per type")
+	manifest := manifests[0]
+	assert.Equal(t, reflect.TypeFor[SignUp](), manifest.Type)
+	require.Len(t, manifest.Fields, 2)
+
+	assert.Equal(t, "Email", manifest.Fields[0].Field)
+	assert.Equal(t, "email", manifest.Fields[0].Category)
+	require.Len(t, manifest.Fields[0].Bindings, 2)
+	assert.Equal(t, "X-Email", manifest.Fields[0].Bindings[0].Identifier)
+
+	assert.Equal(t, "Address.Street", manifest.Fields[1].Field)
+	assert.Equal(t, "address", manifest.Fields[1].Category)
+	assert.Equal(t, "address_street", manifest.Fields[1].Bindings[0].Identifier, "bindings are scoped")
+
+	t.Run("NoPersonalData", func(t *testing.T) {
+		var plan struct {
+			Plan string `query:"plan"`
+		}
+		require.NoError(t, parser.Parse(req, &plan))
+		assert.Len(t, manifests, 1)
+	})
+
+	t.Run("EmptyCategory", func(t *testing.T) {
+		var signUp struct {
+			Email string `query:"email" pii:""`
+		}
+		assert.ErrorIs(t, parser.Parse(req, &signUp), ErrEmptyPIICategory)
+	})
+}
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// _poolInvalidateOpts resets pooled values to the state of a freshly
+// acquired one while keeping their allocations for reuse.
+var _poolInvalidateOpts = InvalidateOpts{
+	UseDefaults:     true,
+	KeepAllocations: true,
+}
+
+// Pool returns a sync.Pool backed acquire/release pair for destination
+// structs of type T, so that hot handlers can reuse them:
+//
+//	acquire, release := pave.Pool[CreateUserRequest]()
+//
+//	func handle(w http.ResponseWriter, r *http.Request) {
+//		req := acquire()
+//		defer release(req)
+//		...
+//	}
+//
+// Acquired values always have their `default` tag values applied. Release
+// resets a value with Invalidate semantics (defaults applied, allocations
+// kept, `preserve:"true"` fields left untouched) before returning it to
+// the pool, so no data leaks from one request into the next. Values must
+// not be used after they are released; releasing nil is a no-op.
+//
+// Pool panics if T is not a struct or if its default tags cannot be
+// applied.
+func Pool[T any]() (acquire func() *T, release func(*T)) {
+	typ := reflect.TypeFor[T]()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("pave.Pool: %s is not a struct type", typ))
+	}
+
+	if err := resetStructFields(reflect.ValueOf(new(T)).Elem(), _poolInvalidateOpts); err != nil {
+		panic(fmt.Sprintf("pave.Pool: invalid defaults for %s: %v", typ, err))
+	}
+
+	pool := &sync.Pool{
+		New: func() any {
+			v := new(T)
+			// Applying the defaults was checked above and cannot fail here.
+			_ = resetStructFields(reflect.ValueOf(v).Elem(), _poolInvalidateOpts)
+			return v
+		},
+	}
+
+	acquire = func() *T {
+		return pool.Get().(*T)
+	}
+
+	release = func(v *T) {
+		if v == nil {
+			return
+		}
+		// A value that cannot be reset is dropped rather than reused.
+		if err := resetStructFields(reflect.ValueOf(v).Elem(), _poolInvalidateOpts); err != nil {
+			return
+		}
+		pool.Put(v)
+	}
+
+	return acquire, release
+}
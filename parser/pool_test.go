@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type poolTestRequest struct {
+	Page  int      `query:"page,omitempty" default:"1"`
+	Name  string   `query:"name,omitempty"`
+	Tags  []string `preserve:"true"`
+	Inner *struct {
+		Size int `default:"20"`
+	}
+}
+
+func TestPool(t *testing.T) {
+	acquire, release := Pool[poolTestRequest]()
+
+	t.Run("AcquireAppliesDefaults", func(t *testing.T) {
+		v := acquire()
+		assert.Equal(t, 1, v.Page)
+		assert.Empty(t, v.Name)
+		release(v)
+	})
+
+	t.Run("ReleaseResetsValue", func(t *testing.T) {
+		v := acquire()
+		req := newTestRequest(t, "GET", "http://example.com/?page=7&name=jane", nil)
+		require.NoError(t, NewHTTPRequestParser().Parse(req, v))
+		require.Equal(t, 7, v.Page)
+
+		v.Tags = append(v.Tags, "kept")
+		v.Inner = &struct {
+			Size int `default:"20"`
+		}{Size: 3}
+
+		release(v)
+
+		assert.Equal(t, 1, v.Page)
+		assert.Empty(t, v.Name)
+		assert.Equal(t, []string{"kept"}, v.Tags)
+		require.NotNil(t, v.Inner)
+		assert.Equal(t, 20, v.Inner.Size)
+	})
+
+	t.Run("ReleaseNil", func(t *testing.T) {
+		assert.NotPanics(t, func() { release(nil) })
+	})
+}
+
+func TestPool_Panics(t *testing.T) {
+	assert.Panics(t, func() { Pool[int]() })
+
+	type BadDefault struct {
+		Count int `default:"many"`
+	}
+	assert.Panics(t, func() { Pool[BadDefault]() })
+}
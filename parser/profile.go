@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrUnknownProfile = errors.New("unknown binding profile")
+)
+
+// profiles holds the registered binding profiles keyed by name.
+var (
+	_profiles      = make(map[string]reflect.StructTag)
+	_profilesMutex sync.RWMutex
+)
+
+// RegisterProfile makes the struct tag available to the use tag as name,
+// so that fields sharing long tag strings (audit fields, tenancy,
+// pagination, ...) across many structs declare them once, e.g.
+//
+//	pave.RegisterProfile("tenant", `header:"X-Tenant-ID,omitempty" query:"tenant" validate:"tenant"`)
+//
+//	type CreateOrder struct {
+//		Tenant string `use:"tenant"`
+//	}
+//
+// A field may use several profiles, separated by commas. Its own tags take
+// precedence over those of its profiles, and earlier profiles over later
+// ones, so `use:"tenant" header:"X-Org-ID,omitempty"` only replaces the
+// header binding.
+//
+// Registering a profile for an already registered name replaces it. Chains
+// that were already built keep the tags they were built with, so register
+// profiles before parsing, e.g. in an init function.
+func RegisterProfile(name string, tag reflect.StructTag) {
+	_profilesMutex.Lock()
+	defer _profilesMutex.Unlock()
+
+	_profiles[name] = tag
+}
+
+// UnregisterProfile removes the profile registered as name, if any.
+func UnregisterProfile(name string) {
+	_profilesMutex.Lock()
+	defer _profilesMutex.Unlock()
+
+	delete(_profiles, name)
+}
+
+// getProfile returns the profile registered as name, if any.
+func getProfile(name string) (reflect.StructTag, bool) {
+	_profilesMutex.RLock()
+	defer _profilesMutex.RUnlock()
+
+	tag, ok := _profiles[name]
+	return tag, ok
+}
+
+// ProfiledTag returns the tag of field with the tags of the profiles it
+// uses appended, so that lookups find its own tags first. If a profile is
+// unknown, it returns the tag of field as-is with ErrUnknownProfile.
+func ProfiledTag(field reflect.StructField) (reflect.StructTag, error) {
+	names, ok := field.Tag.Lookup(UseTagName)
+	if !ok {
+		return field.Tag, nil
+	}
+
+	var tag strings.Builder
+	tag.WriteString(string(field.Tag))
+	for _, name := range strings.Split(names, CommaDelimeter) {
+		name = strings.TrimSpace(name)
+		profile, ok := getProfile(name)
+		if !ok {
+			return field.Tag, fmt.Errorf("%w: %s", ErrUnknownProfile, name)
+		}
+		tag.WriteString(" ")
+		tag.WriteString(string(profile))
+	}
+
+	return reflect.StructTag(tag.String()), nil
+}
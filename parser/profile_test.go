@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	RegisterProfile("profile_test_tenant", `header:"X-Tenant-ID,omitempty" query:"tenant" validate:"tenant"`)
+	RegisterProfile("profile_test_actor", `header:"X-Actor,omitempty" query:"actor,omitempty" default:"system"`)
+	t.Cleanup(func() {
+		UnregisterProfile("profile_test_tenant")
+		UnregisterProfile("profile_test_actor")
+	})
+
+	type CreateOrder struct {
+		Tenant string `use:"profile_test_tenant"`
+		Actor  string `use:"profile_test_actor"`
+		Org    string `use:"profile_test_tenant" header:"X-Org-ID,omitempty" query:"org"`
+		Item   string `query:"item"`
+	}
+
+	req := newTestRequest(t, "GET", "http://example.com/?tenant=acme&item=book", nil)
+	req.Header.Set("X-Tenant-ID", "globex")
+	req.Header.Set("X-Org-ID", "initech")
+
+	var order CreateOrder
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &order))
+	assert.Equal(t, "globex", order.Tenant, "profiles bind in the order of their tags")
+	assert.Equal(t, "system", order.Actor, "profiles carry defaults")
+	assert.Equal(t, "initech", order.Org, "the tags of the field take precedence")
+	assert.Equal(t, "book", order.Item)
+
+	t.Run("UnknownProfile", func(t *testing.T) {
+		var order struct {
+			Tenant string `use:"profile_test_unregistered"`
+		}
+		err := NewHTTPRequestParser().Parse(req, &order)
+		assert.ErrorIs(t, err, ErrUnknownProfile)
+	})
+}
@@ -0,0 +1,205 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidRange        = errors.New("invalid range")
+	ErrRangeNotSatisfiable = errors.New("range not satisfiable")
+)
+
+// Part names produced by the range binding, for the first range of the
+// header
+const (
+	RangeUnitPart   string = "unit"
+	RangeStartPart  string = "start"
+	RangeEndPart    string = "end"
+	RangeSuffixPart string = "suffix"
+)
+
+// Range is a parsed Range header (RFC 9110), e.g. "bytes=0-499,1000-".
+type Range struct {
+	Unit  string      // Range unit, usually "bytes"
+	Specs []RangeSpec // Requested ranges, in header order
+}
+
+// RangeSpec is a single range of a Range header. Exactly one of its forms
+// is set:
+//   - "0-499": Start 0, End 499
+//   - "500-": Start 500, End -1 (to the end)
+//   - "-500": Start -1, End -1, Suffix 500 (the last 500 units)
+type RangeSpec struct {
+	Start  int64 // First position, or -1 for a suffix range
+	End    int64 // Last position (inclusive), or -1 if open-ended
+	Suffix int64 // Number of units at the end, for suffix ranges
+}
+
+// Resolve returns the first and last positions (inclusive) of the range
+// within content of the given size. ok is false if the range does not
+// overlap the content.
+func (spec RangeSpec) Resolve(size int64) (start, end int64, ok bool) {
+	if spec.Start < 0 {
+		if spec.Suffix <= 0 || size <= 0 {
+			return 0, 0, false
+		}
+		return max(size-spec.Suffix, 0), size - 1, true
+	}
+
+	if spec.Start >= size {
+		return 0, 0, false
+	}
+
+	end = size - 1
+	if spec.End >= 0 && spec.End < end {
+		end = spec.End
+	}
+	return spec.Start, end, true
+}
+
+// String formats the range as in a Range header, e.g. "500-".
+func (spec RangeSpec) String() string {
+	switch {
+	case spec.Start < 0:
+		return "-" + strconv.FormatInt(spec.Suffix, 10)
+	case spec.End < 0:
+		return strconv.FormatInt(spec.Start, 10) + "-"
+	default:
+		return strconv.FormatInt(spec.Start, 10) + "-" + strconv.FormatInt(spec.End, 10)
+	}
+}
+
+// String formats the range as a Range header value.
+func (r Range) String() string {
+	specs := make([]string, len(r.Specs))
+	for i, spec := range r.Specs {
+		specs[i] = spec.String()
+	}
+	return r.Unit + "=" + strings.Join(specs, CommaDelimeter)
+}
+
+// MarshalText formats the range as a Range header value.
+func (r Range) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// ParseRange parses the value of a Range header, e.g. "bytes=0-499". If
+// limit is positive, it is the size of the content the ranges select
+// from: ranges starting at or after it, and suffixes longer than it, fail
+// with ErrRangeNotSatisfiable.
+func ParseRange(value string, limit int64) (Range, error) {
+	unit, set, ok := strings.Cut(strings.TrimSpace(value), "=")
+	unit = strings.TrimSpace(unit)
+	if !ok || unit == "" {
+		return Range{}, fmt.Errorf("%w: %q has no unit", ErrInvalidRange, value)
+	}
+
+	result := Range{Unit: unit}
+	for _, part := range strings.Split(set, CommaDelimeter) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			// Empty list elements are allowed
+			continue
+		}
+
+		spec, err := parseRangeSpec(part)
+		if err != nil {
+			return Range{}, fmt.Errorf("%w: %q: %w", ErrInvalidRange, value, err)
+		}
+
+		if limit > 0 && (spec.Start >= limit || spec.Suffix > limit) {
+			return Range{}, fmt.Errorf("%w: %s exceeds %d", ErrRangeNotSatisfiable, part, limit)
+		}
+		result.Specs = append(result.Specs, spec)
+	}
+
+	if len(result.Specs) == 0 {
+		return Range{}, fmt.Errorf("%w: %q has no ranges", ErrInvalidRange, value)
+	}
+	return result, nil
+}
+
+// parseRangeSpec parses a single range, e.g. "0-499", "500-" or "-500".
+func parseRangeSpec(part string) (RangeSpec, error) {
+	first, last, ok := strings.Cut(part, "-")
+	if !ok || (first == "" && last == "") {
+		return RangeSpec{}, fmt.Errorf("invalid range %q", part)
+	}
+
+	parse := func(position string) (int64, error) {
+		if position == "" || strings.TrimLeft(position, "0123456789") != "" {
+			return 0, fmt.Errorf("invalid position %q", position)
+		}
+		return strconv.ParseInt(position, 10, 64)
+	}
+
+	if first == "" {
+		suffix, err := parse(last)
+		if err != nil {
+			return RangeSpec{}, err
+		}
+		return RangeSpec{Start: -1, End: -1, Suffix: suffix}, nil
+	}
+
+	start, err := parse(first)
+	if err != nil {
+		return RangeSpec{}, err
+	}
+	if last == "" {
+		return RangeSpec{Start: start, End: -1}, nil
+	}
+
+	end, err := parse(last)
+	if err != nil {
+		return RangeSpec{}, err
+	}
+	if end < start {
+		return RangeSpec{}, fmt.Errorf("range %q ends before it starts", part)
+	}
+	return RangeSpec{Start: start, End: end}, nil
+}
+
+// convertRange parses a Range header value, honoring the max modifier of
+// the binding.
+func convertRange(value string, modifiers BindingModifiers) (any, error) {
+	limit, _ := modifiers.Value(MaxBindingModifier)
+	n, _ := limit.(int64)
+	return ParseRange(value, n)
+}
+
+// honorsMaxModifier reports whether the max modifier of binding applies
+// to a field of type typ: range bindings apply it, and so does the
+// converter of Range fields (see convertRange), whatever their binding.
+func honorsMaxModifier(binding Binding, typ reflect.Type) bool {
+	if binding.Name == RangeTagBinding {
+		return true
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if IsOptType(typ) {
+		typ = typ.Field(0).Type
+	}
+	return typ == reflect.TypeFor[Range]()
+}
+
+// rangeParts returns the parts of the first range of r.
+func rangeParts(r Range) map[string]any {
+	spec := r.Specs[0]
+	parts := map[string]any{RangeUnitPart: r.Unit}
+
+	if spec.Start >= 0 {
+		parts[RangeStartPart] = spec.Start
+	} else {
+		parts[RangeSuffixPart] = spec.Suffix
+	}
+	if spec.End >= 0 {
+		parts[RangeEndPart] = spec.End
+	}
+
+	return parts
+}
@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRange(t *testing.T) {
+	r, err := ParseRange("bytes=0-499, 1000-,-500", 0)
+	require.NoError(t, err)
+	assert.Equal(t, Range{Unit: "bytes", Specs: []RangeSpec{
+		{Start: 0, End: 499},
+		{Start: 1000, End: -1},
+		{Start: -1, End: -1, Suffix: 500},
+	}}, r)
+	assert.Equal(t, "bytes=0-499,1000-,-500", r.String())
+
+	for _, value := range []string{"", "bytes", "=0-1", "bytes=", "bytes=-", "bytes=5-1", "bytes=a-1", "bytes=+1-2", "bytes=0-1-2"} {
+		_, err := ParseRange(value, 0)
+		assert.ErrorIs(t, err, ErrInvalidRange, value)
+	}
+
+	t.Run("Limit", func(t *testing.T) {
+		_, err := ParseRange("bytes=0-2048", 1024)
+		assert.NoError(t, err, "ranges may end beyond the content")
+
+		for _, value := range []string{"bytes=1024-", "bytes=0-1,2000-3000", "bytes=-1025"} {
+			_, err := ParseRange(value, 1024)
+			assert.ErrorIs(t, err, ErrRangeNotSatisfiable, value)
+		}
+	})
+}
+
+func TestRangeSpec_Resolve(t *testing.T) {
+	tests := []struct {
+		spec       RangeSpec
+		start, end int64
+		ok         bool
+	}{
+		{RangeSpec{Start: 0, End: 499}, 0, 499, true},
+		{RangeSpec{Start: 0, End: 5000}, 0, 999, true},
+		{RangeSpec{Start: 500, End: -1}, 500, 999, true},
+		{RangeSpec{Start: -1, End: -1, Suffix: 100}, 900, 999, true},
+		{RangeSpec{Start: -1, End: -1, Suffix: 5000}, 0, 999, true},
+		{RangeSpec{Start: 1000, End: -1}, 0, 0, false},
+		{RangeSpec{Start: -1, End: -1, Suffix: 0}, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		start, end, ok := tt.spec.Resolve(1000)
+		assert.Equal(t, tt.ok, ok, tt.spec.String())
+		if ok {
+			assert.Equal(t, tt.start, start, tt.spec.String())
+			assert.Equal(t, tt.end, end, tt.spec.String())
+		}
+	}
+}
+
+func TestHTTPRequestParser_Range(t *testing.T) {
+	type Download struct {
+		Range Range  `range:"Range,max=1000"`
+		Unit  string `range:"Range" part:"unit"`
+		Start int64  `range:"Range,omitempty" part:"start" default:"0"`
+		End   int64  `range:"Range,omitempty" part:"end" default:"-1"`
+	}
+
+	parser := NewHTTPRequestParser()
+
+	newRequest := func(t *testing.T, value string) *http.Request {
+		req := newTestRequest(t, "GET", "http://example.com/file", nil)
+		req.Header.Set("Range", value)
+		return req
+	}
+
+	var download Download
+	require.NoError(t, parser.Parse(newRequest(t, "bytes=100-"), &download))
+	assert.Equal(t, Download{
+		Range: Range{Unit: "bytes", Specs: []RangeSpec{{Start: 100, End: -1}}},
+		Unit:  "bytes",
+		Start: 100,
+		End:   -1,
+	}, download)
+
+	download = Download{}
+	require.NoError(t, parser.Parse(newRequest(t, "bytes=0-499,600-700"), &download))
+	assert.Equal(t, int64(0), download.Start)
+	assert.Equal(t, int64(499), download.End)
+	assert.Len(t, download.Range.Specs, 2)
+
+	err := parser.Parse(newRequest(t, "bytes=1000-"), &Download{})
+	assert.ErrorIs(t, err, ErrRangeNotSatisfiable)
+
+	err = parser.Parse(newRequest(t, "pages"), &Download{})
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	t.Run("Max", func(t *testing.T) {
+		type Resume struct {
+			Range Opt[Range] `header:"Range,max=1000"`
+		}
+
+		err := parser.Parse(newRequest(t, "bytes=1000-"), &Resume{})
+		assert.ErrorIs(t, err, ErrRangeNotSatisfiable, "Range fields honor max whatever their binding")
+
+		err = parser.Parse(newRequest(t, "bytes=0-"), &struct {
+			Limit int `query:"limit,max=5"`
+		}{})
+		assert.ErrorIs(t, err, ErrMaxNotRange)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		type Resume struct {
+			Range Range `range:"Range"`
+		}
+
+		want := Resume{Range: Range{Unit: "bytes", Specs: []RangeSpec{{Start: 4096, End: -1}}}}
+		req, err := NewHTTPRequest("GET", "http://example.com/file", want)
+		require.NoError(t, err)
+		assert.Equal(t, "bytes=4096-", req.Header.Get("Range"))
+
+		var got Resume
+		require.NoError(t, parser.Parse(req, &got))
+		assert.Equal(t, want, got)
+	})
+}
@@ -0,0 +1,226 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidRateLimit    = errors.New("invalid rate limit")
+	ErrUnknownRateLimiter  = errors.New("unknown rate limiter")
+	ErrInvalidRateLimitKey = errors.New("invalid rate limit key")
+)
+
+// Part names produced by the ratelimit binding
+const (
+	RateLimitLimitPart     string = "limit"
+	RateLimitRemainingPart string = "remaining"
+	RateLimitResetPart     string = "reset" // Seconds until the window resets
+)
+
+// RateLimitKeyClientIP is the key modifier keying rate limits by the
+// client IP, the default. See ClientIP.
+const RateLimitKeyClientIP string = "ip"
+
+// RateLimit is the state of a rate limit window after a request was
+// counted against it.
+type RateLimit struct {
+	Limit     int64         // Requests allowed per window
+	Remaining int64         // Requests left in the window
+	Reset     time.Duration // Time until the window resets
+}
+
+// ParseRateLimit parses a rate limit in the form of the RateLimit header
+// field drafts, e.g. "limit=100, remaining=42, reset=30" with the reset
+// in seconds.
+func ParseRateLimit(value string) (RateLimit, error) {
+	var (
+		limit RateLimit
+		seen  = make(map[string]bool)
+	)
+	for _, item := range strings.Split(value, CommaDelimeter) {
+		name, number, ok := strings.Cut(strings.TrimSpace(item), "=")
+		n, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+		if !ok || err != nil || n < 0 {
+			return RateLimit{}, fmt.Errorf("%w: %q", ErrInvalidRateLimit, value)
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case RateLimitLimitPart:
+			limit.Limit = n
+		case RateLimitRemainingPart:
+			limit.Remaining = n
+		case RateLimitResetPart:
+			limit.Reset = time.Duration(n) * time.Second
+		default:
+			return RateLimit{}, fmt.Errorf("%w: unknown item %q in %q", ErrInvalidRateLimit, name, value)
+		}
+		seen[name] = true
+	}
+
+	if !seen[RateLimitLimitPart] || !seen[RateLimitRemainingPart] || !seen[RateLimitResetPart] {
+		return RateLimit{}, fmt.Errorf("%w: %q lacks limit, remaining or reset", ErrInvalidRateLimit, value)
+	}
+	return limit, nil
+}
+
+// ResetSeconds returns the time until the window resets in whole seconds,
+// rounded up.
+func (rl RateLimit) ResetSeconds() int64 {
+	return int64((rl.Reset + time.Second - 1) / time.Second)
+}
+
+// String formats the rate limit like ParseRateLimit parses it.
+func (rl RateLimit) String() string {
+	return fmt.Sprintf("limit=%d, remaining=%d, reset=%d", rl.Limit, rl.Remaining, rl.ResetSeconds())
+}
+
+// MarshalText formats the rate limit like String.
+func (rl RateLimit) MarshalText() ([]byte, error) {
+	return []byte(rl.String()), nil
+}
+
+// SetHeaders echoes the rate limit to a client in the RateLimit-Limit,
+// RateLimit-Remaining and RateLimit-Reset response headers.
+func (rl RateLimit) SetHeaders(header http.Header) {
+	header.Set("RateLimit-Limit", strconv.FormatInt(rl.Limit, 10))
+	header.Set("RateLimit-Remaining", strconv.FormatInt(rl.Remaining, 10))
+	header.Set("RateLimit-Reset", strconv.FormatInt(rl.ResetSeconds(), 10))
+}
+
+// RateLimiter counts requests against rate limits, e.g. a token bucket
+// per key in memory or in Redis. See HTTPRequestParser.RegisterRateLimiter.
+type RateLimiter interface {
+	// Take counts req against the limit of key and returns the state of
+	// the window afterwards.
+	Take(req *http.Request, key string) (RateLimit, error)
+}
+
+// RateLimiterFunc adapts a function to the RateLimiter interface.
+type RateLimiterFunc func(req *http.Request, key string) (RateLimit, error)
+
+func (f RateLimiterFunc) Take(req *http.Request, key string) (RateLimit, error) {
+	return f(req, key)
+}
+
+// rateLimitTake is the cached result of counting a request against a
+// rate limit.
+type rateLimitTake struct {
+	limit RateLimit
+	err   error
+}
+
+// RegisterRateLimiter makes limiter available to ratelimit bindings as
+// name, e.g. `ratelimit:"<name>"`. Registering a limiter for an already
+// registered name replaces it.
+func (hp *HTTPRequestParser) RegisterRateLimiter(name string, limiter RateLimiter) {
+	mgr := hp.bindingManager()
+	mgr.rateLimitersMu.Lock()
+	defer mgr.rateLimitersMu.Unlock()
+
+	if mgr.rateLimiters == nil {
+		mgr.rateLimiters = make(map[string]RateLimiter)
+	}
+	mgr.rateLimiters[name] = limiter
+}
+
+// UnregisterRateLimiter removes the limiter registered as name, if any.
+func (hp *HTTPRequestParser) UnregisterRateLimiter(name string) {
+	mgr := hp.bindingManager()
+	mgr.rateLimitersMu.Lock()
+	defer mgr.rateLimitersMu.Unlock()
+
+	delete(mgr.rateLimiters, name)
+}
+
+// RateLimitValue counts the request against the limiter named by the
+// binding identifier, keyed by the value of the key modifier binding (the
+// client IP by default). A request is counted once per limiter and key,
+// whatever the number of fields bound to its parts. Requests without a
+// key are not counted and not found.
+func (mgr *HTTPBindingManager) RateLimitValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], binding Binding,
+) BindingResult {
+
+	mgr.rateLimitersMu.RLock()
+	limiter, ok := mgr.rateLimiters[binding.Identifier]
+	mgr.rateLimitersMu.RUnlock()
+	if !ok {
+		return BindingResultError(fmt.Errorf("%w: %s", ErrUnknownRateLimiter, binding.Identifier))
+	}
+
+	var key string
+	if keyBinding, ok := ModifierValue[Binding](binding, KeyBindingModifier); ok && keyBinding.Name != RateLimitKeyClientIP {
+		result := mgr.BindingHandlerCached(source, entry, keyBinding)
+		if !result.Found || result.Error != nil {
+			return result
+		}
+		key = fmt.Sprint(result.Value)
+	} else {
+		ip, err := ClientIP(source, mgr.opts.TrustedProxies)
+		if err != nil {
+			return BindingResultError(err)
+		}
+		key = ip.String()
+	}
+	if key == "" {
+		return BindingResultNotFound()
+	}
+
+	var take rateLimitTake
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		cacheKey := binding.Identifier + "\x00" + key
+		cached, ok := data.rateLimits[cacheKey]
+		if !ok {
+			cached.limit, cached.err = limiter.Take(source, key)
+			if data.rateLimits == nil {
+				data.rateLimits = make(map[string]rateLimitTake)
+			}
+			data.rateLimits[cacheKey] = cached
+		}
+		take = cached
+	})
+	if take.err != nil {
+		return BindingResultError(fmt.Errorf("rate limiter %s: %w", binding.Identifier, take.err))
+	}
+
+	return BindingResult{
+		Value: take.limit.String(),
+		Values: map[string]any{
+			RateLimitLimitPart:     take.limit.Limit,
+			RateLimitRemainingPart: take.limit.Remaining,
+			RateLimitResetPart:     take.limit.ResetSeconds(),
+		},
+		Found: true,
+	}
+}
+
+// parseRateLimitKeyModifier parses the key modifier of ratelimit
+// bindings, the binding keying the limit: "header:<name>",
+// "query:<name>", "cookie:<name>" or "ip".
+func parseRateLimitKeyModifier(value string) (any, error) {
+	if value == RateLimitKeyClientIP {
+		return Binding{Name: RateLimitKeyClientIP}, nil
+	}
+
+	name, identifier, ok := strings.Cut(value, ":")
+	if !ok || identifier == "" {
+		return nil, fmt.Errorf("%w: %q is not <binding>:<name>", ErrInvalidRateLimitKey, value)
+	}
+	switch name {
+	case HeaderTagBinding, QueryTagBinding, CookieTagBinding:
+		return Binding{Name: name, Identifier: identifier}, nil
+	default:
+		return nil, fmt.Errorf("%w: cannot key by %s bindings", ErrInvalidRateLimitKey, name)
+	}
+}
+
+// convertRateLimit parses a RateLimit field.
+func convertRateLimit(value string) (any, error) {
+	return ParseRateLimit(value)
+}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitBinding(t *testing.T) {
+	type Request struct {
+		APIKey    string    `header:"X-API-Key"`
+		Limit     RateLimit `ratelimit:"api,key=header:X-API-Key"`
+		Remaining int       `ratelimit:"api,key=header:X-API-Key" part:"remaining"`
+		PerIP     RateLimit `ratelimit:"anonymous"`
+	}
+
+	// A fixed window of 3 requests per key
+	counts := make(map[string]int64)
+	window := RateLimiterFunc(func(req *http.Request, key string) (RateLimit, error) {
+		counts[key]++
+		return RateLimit{Limit: 3, Remaining: max(3-counts[key], 0), Reset: 1500 * time.Millisecond}, nil
+	})
+
+	parser := NewHTTPRequestParser()
+	parser.RegisterRateLimiter("api", window)
+	parser.RegisterRateLimiter("anonymous", window)
+
+	newRequest := func(t *testing.T, apiKey string) *http.Request {
+		req := newTestRequest(t, "GET", "http://example.com/", nil)
+		req.RemoteAddr = "203.0.113.7:52100"
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		return req
+	}
+
+	var request Request
+	require.NoError(t, parser.Parse(newRequest(t, "k1"), &request))
+	assert.Equal(t, RateLimit{Limit: 3, Remaining: 2, Reset: 2 * time.Second}, request.Limit)
+	assert.Equal(t, 2, request.Remaining, "parts of a limit are counted once per request")
+	assert.Equal(t, int64(2), request.PerIP.Remaining)
+	assert.Equal(t, int64(1), counts["203.0.113.7"])
+
+	request = Request{}
+	require.NoError(t, parser.Parse(newRequest(t, "k1"), &request))
+	assert.Equal(t, 1, request.Remaining)
+
+	t.Run("Headers", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		request.Limit.SetHeaders(recorder.Header())
+		assert.Equal(t, "3", recorder.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", recorder.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "2", recorder.Header().Get("RateLimit-Reset"))
+	})
+
+	t.Run("MissingKey", func(t *testing.T) {
+		var optional struct {
+			Limit RateLimit `ratelimit:"api,key=query:api_key,omitempty" default:"limit=0, remaining=0, reset=0"`
+		}
+		require.NoError(t, parser.Parse(newRequest(t, ""), &optional))
+		assert.Equal(t, RateLimit{}, optional.Limit)
+
+		err := parser.Parse(newRequest(t, ""), &Request{})
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+	})
+
+	t.Run("LimiterError", func(t *testing.T) {
+		parser := NewHTTPRequestParser()
+		parser.RegisterRateLimiter("api", RateLimiterFunc(func(*http.Request, string) (RateLimit, error) {
+			return RateLimit{}, errors.New("redis unavailable")
+		}))
+
+		var request struct {
+			Remaining int `ratelimit:"api,key=ip,omiterror" part:"remaining" default:"-1"`
+		}
+		require.NoError(t, parser.Parse(newRequest(t, ""), &request))
+		assert.Equal(t, -1, request.Remaining)
+
+		parser.UnregisterRateLimiter("api")
+		err := parser.Parse(newRequest(t, ""), &struct {
+			Limit RateLimit `ratelimit:"api"`
+		}{})
+		assert.ErrorIs(t, err, ErrUnknownRateLimiter)
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		for _, dest := range []any{
+			&struct {
+				Limit RateLimit `ratelimit:"api,key=json:user.id"`
+			}{},
+			&struct {
+				Limit RateLimit `ratelimit:"api,key=header"`
+			}{},
+		} {
+			assert.ErrorIs(t, parser.Parse(newRequest(t, "k1"), dest), ErrInvalidRateLimitKey)
+		}
+	})
+
+	t.Run("ParseRateLimit", func(t *testing.T) {
+		limit, err := ParseRateLimit("limit=100, remaining=42, reset=30")
+		require.NoError(t, err)
+		assert.Equal(t, RateLimit{Limit: 100, Remaining: 42, Reset: 30 * time.Second}, limit)
+		assert.Equal(t, "limit=100, remaining=42, reset=30", limit.String())
+
+		for _, value := range []string{"", "limit=100, remaining=42", "limit=100, remaining=-1, reset=0", "limit=1, remaining=1, reset=1, burst=2"} {
+			_, err := ParseRateLimit(value)
+			assert.ErrorIs(t, err, ErrInvalidRateLimit, value)
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// SourceSnapshot is the subset of a source that a parse actually read:
+// the values found (or not) for each binding tried. Snapshots of failed
+// parses serialize to JSON, e.g. in logs, and are replayed in tests with
+// a Replayer, exactly as they were parsed.
+type SourceSnapshot struct {
+	Type    string        `json:"type"`              // Destination type of the parse
+	Version string        `json:"version,omitempty"` // Version of the source, see VersionOpts
+	Reads   []BindingRead `json:"reads"`
+}
+
+// BindingRead is the result of a binding tried during a parse.
+type BindingRead struct {
+	Binding    string  `json:"binding"`
+	Identifier string  `json:"identifier"`
+	Part       string  `json:"part,omitempty"`  // Part of the result the field selected, see BindingResult
+	Found      bool    `json:"found"`           // Whether the value (or part) was found
+	Value      *string `json:"value,omitempty"` // The value found as the field saw it, before modifiers transformed it. Nil if nil.
+	Error      string  `json:"error,omitempty"` // Error of the binding handler, if any
+	Fatal      bool    `json:"fatal,omitempty"`
+}
+
+// Replayer is implemented by parsers that can replay snapshots of the
+// sources they parsed, such as those built on BaseMBParser.
+type Replayer interface {
+	Parser
+	// Replay populates dest from snapshot the way the parser populated a
+	// value of the same type from the source of snapshot.
+	Replay(snapshot *SourceSnapshot, dest any) error
+}
+
+// newBindingRead records result, the result of binding for step.
+func newBindingRead[S any](binding Binding, step *ParseStep[S], result BindingResult) BindingRead {
+	read := BindingRead{
+		Binding:    binding.Name,
+		Identifier: binding.Identifier,
+		Part:       step.Part,
+		Fatal:      result.Fatal,
+	}
+
+	if result.Error != nil {
+		read.Error = result.Error.Error()
+		return read
+	}
+
+	value, found := result.Part(step.Part)
+	read.Found = found
+	if found && value != nil {
+		formatted := step.formatValue(value)
+		read.Value = &formatted
+	}
+	return read
+}
+
+// Snapshot returns the values the parse of report read from its source.
+func (report *ParseReport) Snapshot() *SourceSnapshot {
+	snapshot := &SourceSnapshot{Version: report.Version, Reads: []BindingRead{}}
+	if report.Type != nil {
+		snapshot.Type = report.Type.String()
+	}
+
+	for _, step := range report.Steps {
+		snapshot.Reads = append(snapshot.Reads, step.Reads...)
+	}
+	return snapshot
+}
+
+// replayKey identifies the reads of a binding in a snapshot.
+type replayKey struct {
+	binding    string
+	identifier string
+}
+
+// results returns the binding results of snapshot. Reads of different
+// parts of a binding are merged into one result.
+func (snapshot *SourceSnapshot) results() map[replayKey]BindingResult {
+	results := make(map[replayKey]BindingResult, len(snapshot.Reads))
+	for _, read := range snapshot.Reads {
+		key := replayKey{binding: read.Binding, identifier: read.Identifier}
+		result := results[key]
+
+		switch {
+		case read.Error != "":
+			result.Error = errors.New(read.Error)
+			result.Fatal = read.Fatal
+		case read.Found:
+			var value any
+			if read.Value != nil {
+				value = *read.Value
+			}
+			result.Found = true
+			if read.Part == "" {
+				result.Value = value
+			} else {
+				if result.Values == nil {
+					result.Values = make(map[string]any)
+				}
+				result.Values[read.Part] = value
+			}
+		}
+
+		results[key] = result
+	}
+	return results
+}
+
+// Replay populates dest from snapshot, see Replayer. Bindings the
+// snapshot has no read of are not found.
+func (base *BaseMBParser[S, C]) Replay(snapshot *SourceSnapshot, dest any) error {
+	if err := checkDest(dest); err != nil {
+		return err
+	}
+
+	pcm, err := base.versionManager(snapshot.Version)
+	if err != nil {
+		return err
+	}
+
+	chain, err := pcm.GetParseChain(reflect.TypeOf(dest).Elem())
+	if err != nil {
+		return err
+	}
+
+	results := snapshot.results()
+	replay := chain.withHandler(func(_ *S, binding Binding) BindingResult {
+		key := replayKey{binding: binding.Name, identifier: binding.Identifier}
+		if result, ok := results[key]; ok {
+			// The values of Multi bindings are recorded as a JSON array
+			if value, isString := result.Value.(string); isString && binding.Multi {
+				var values []string
+				if json.Unmarshal([]byte(value), &values) == nil {
+					result.Value = values
+				}
+			}
+			return result
+		}
+		return BindingResultNotFound()
+	})
+
+	return replay.Execute(new(S), dest)
+}
+
+// withHandler returns a copy of the chain and its sub-chains that get
+// values from handler.
+func (chain *ParseChain[S]) withHandler(handler BindingHandlerFunc[S]) *ParseChain[S] {
+	clone := chain.Clone()
+	clone.setHandler(handler)
+	return clone
+}
+
+// setHandler sets the handler of the chain and its sub-chains.
+func (chain *ParseChain[S]) setHandler(handler BindingHandlerFunc[S]) {
+	chain.Handler = handler
+	for step := chain.Head; step != nil; step = step.Next {
+		if step.SubChain != nil {
+			step.SubChain.setHandler(handler)
+		}
+	}
+}
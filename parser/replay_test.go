@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplay(t *testing.T) {
+	type Request struct {
+		Username string `basicauth:"Authorization" part:"username"`
+		Password string `basicauth:"Authorization" part:"password"`
+		Sort     string `query:"sort,omitempty" default:"name"`
+		Page     int    `query:"page,omitempty" default:"1"`
+		Body     struct {
+			Name  string `json:"name"`
+			Count int    `json:"count"`
+		}
+	}
+
+	parser := NewHTTPRequestParser()
+
+	req := newTestRequest(t, "POST", "http://example.com/?sort=-name&secret=unread",
+		strings.NewReader(`{"name": "Ann", "count": "many", "unread": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth("ann", "hunter2")
+
+	var parsed Request
+	report, parseErr := parser.ParseWithReport(req, &parsed)
+	require.Error(t, parseErr)
+
+	encoded, err := json.Marshal(report.Snapshot())
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "unread", "only values read are recorded")
+
+	var snapshot SourceSnapshot
+	require.NoError(t, json.Unmarshal(encoded, &snapshot))
+	assert.Contains(t, snapshot.Type, "Request")
+
+	var replayed Request
+	replayErr := parser.Replay(&snapshot, &replayed)
+	require.Error(t, replayErr)
+	assert.Equal(t, parseErr.Error(), replayErr.Error(), "failures are replayed exactly")
+	assert.Equal(t, parsed, replayed)
+	assert.Equal(t, "hunter2", replayed.Password)
+	assert.Equal(t, "-name", replayed.Sort)
+	assert.Equal(t, 1, replayed.Page)
+
+	t.Run("Versions", func(t *testing.T) {
+		type User struct {
+			UserID string `query:"user_id" query.v2:"userId"`
+		}
+
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{Versions: VersionOpts{
+			Bindings: []Binding{{Name: HeaderTagBinding, Identifier: "API-Version"}},
+			Versions: []string{"v2"},
+		}})
+
+		req := newTestRequest(t, "GET", "http://example.com/?userId=u2", nil)
+		req.Header.Set("API-Version", "v2")
+
+		var user User
+		report, err := parser.ParseWithReport(req, &user)
+		require.NoError(t, err)
+
+		snapshot := report.Snapshot()
+		assert.Equal(t, "v2", snapshot.Version)
+
+		var replayed User
+		require.NoError(t, parser.Replay(snapshot, &replayed))
+		assert.Equal(t, "u2", replayed.UserID)
+	})
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+)
+
+// TransientError is implemented by errors that know whether they are
+// transient, i.e. caused by a condition that may clear up on its own
+// (e.g. a binding backend timing out), or permanent (e.g. an invalid tag
+// or payload), which fails the same way however often it is retried.
+//
+// BindingManagers mark the errors of their backends with MarkTransient
+// and MarkPermanent. See IsTransient.
+type TransientError interface {
+	error
+	Transient() bool
+}
+
+// markedError is an error marked as transient or permanent.
+type markedError struct {
+	err       error
+	transient bool
+}
+
+func (e *markedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *markedError) Unwrap() error {
+	return e.err
+}
+
+func (e *markedError) Transient() bool {
+	return e.transient
+}
+
+// MarkTransient marks err as transient, so that IsTransient reports true
+// for err and every error wrapping it. A nil err returns nil.
+func MarkTransient(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &markedError{err: err, transient: true}
+}
+
+// MarkPermanent marks err as permanent, overriding whether the errors it
+// wraps are transient. A nil err returns nil.
+func MarkPermanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &markedError{err: err, transient: false}
+}
+
+// IsTransient reports whether retrying the operation that returned err
+// may succeed, so that e.g. a message queue consumer can decide between
+// retrying a message and dead-lettering it.
+//
+// The outermost TransientError in the tree of err decides. Without one,
+// context cancellation and deadlines, os.ErrDeadlineExceeded and network
+// timeouts are transient, and all other errors are permanent: invalid
+// tags, payloads and values fail the same way on every attempt.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var marked TransientError
+	if errors.As(err, &marked) {
+		return marked.Transient()
+	}
+
+	if errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsPermanent reports whether err is a non-nil error that is not
+// transient. See IsTransient.
+func IsPermanent(err error) bool {
+	return err != nil && !IsTransient(err)
+}
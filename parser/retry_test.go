@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestBackend = errors.New("backend unavailable")
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain", errors.New("x"), false},
+		{"marked_transient", MarkTransient(errTestBackend), true},
+		{"wrapped_transient", fmt.Errorf("get: %w", MarkTransient(errTestBackend)), true},
+		{"joined_transient", errors.Join(errors.New("x"), MarkTransient(errTestBackend)), true},
+		{"permanent_overrides", MarkPermanent(fmt.Errorf("get: %w", MarkTransient(errTestBackend))), false},
+		{"transient_overrides", MarkTransient(MarkPermanent(errTestBackend)), true},
+		{"deadline", fmt.Errorf("get: %w", context.DeadlineExceeded), true},
+		{"canceled", context.Canceled, true},
+		{"os_deadline", os.ErrDeadlineExceeded, true},
+		{"net_timeout", &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, true},
+		{"marked_permanent_deadline", MarkPermanent(context.DeadlineExceeded), false},
+		{"required", &RequiredFieldError{Identifier: "id", Binding: "query"}, false},
+		{"tag_report", &TagReport{StructType: reflect.TypeFor[struct{}](), Issues: []TagIssue{{Field: "A", Err: context.DeadlineExceeded}}}, false},
+		{"field_error", &FieldError{Field: "A", Err: MarkTransient(errTestBackend)}, true},
+		{"parse_error", &ParseError{Parser: "p", Err: &FieldError{Field: "A", Err: ErrInvalidMoney}}, false},
+		{"validation_error", &ValidationError{Parser: "p", Err: &FieldError{Field: "A", Err: ErrRequiredFieldNotFound}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransient(tt.err))
+			assert.Equal(t, !tt.want && tt.err != nil, IsPermanent(tt.err))
+		})
+	}
+
+	assert.Nil(t, MarkTransient(nil))
+	assert.Nil(t, MarkPermanent(nil))
+	assert.ErrorIs(t, MarkTransient(errTestBackend), errTestBackend)
+	assert.Equal(t, errTestBackend.Error(), MarkTransient(errTestBackend).Error())
+}
+
+func TestIsTransient_Parse(t *testing.T) {
+	type Config struct {
+		Host string `mapvalue:"host"`
+	}
+
+	handler := func(source *map[string]string, binding Binding) BindingResult {
+		if (*source)["down"] != "" {
+			return BindingResultError(MarkTransient(errTestBackend))
+		}
+		return regionBindingManager{}.BindingHandler(source, binding)
+	}
+
+	pcm := NewPCManager(handler, NewPCManagerOpts(ParseTagOpts{
+		BindingOpts: BindingOpts{AllowedBindingNames: []string{MapValueTagBinding}},
+	}))
+	chain, err := pcm.GetParseChain(reflect.TypeFor[Config]())
+	require.NoError(t, err)
+
+	source := map[string]string{"down": "true"}
+	err = chain.Execute(&source, &Config{})
+	require.ErrorIs(t, err, errTestBackend)
+	assert.True(t, IsTransient(err))
+
+	source = map[string]string{}
+	err = chain.Execute(&source, &Config{})
+	require.Error(t, err)
+	assert.True(t, IsPermanent(err))
+}
@@ -0,0 +1,475 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCron  = errors.New("invalid cron expression")
+	ErrInvalidRRule = errors.New("invalid recurrence rule")
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Cron
+///////////////////////////////////////////////////////////////////////////////
+
+// CronSchedule is a parsed standard 5 field cron expression
+// ("minute hour day-of-month month day-of-week"). CronSchedule fields are
+// populated from cron expressions, e.g. "*/15 9-17 * * MON-FRI" or
+// "@daily".
+//
+// Fields accept "*", "?", numbers, names (JAN-DEC, SUN-SAT), ranges
+// ("1-5"), steps ("*/15", "10-30/5") and lists of those ("1,15"). Day of
+// week 7 is Sunday, like 0. The @yearly, @annually, @monthly, @weekly,
+// @daily, @midnight and @hourly macros are accepted.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64 // Bit n is set if value n matches
+	domAny, dowAny                bool   // Field was "*" or "?"
+	expr                          string
+}
+
+// _cronMacros are the cron macros and their expressions.
+var _cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField describes the range and names of a cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // Names of values from min, if any
+}
+
+var (
+	_cronMinute = cronField{"minute", 0, 59, nil}
+	_cronHour   = cronField{"hour", 0, 23, nil}
+	_cronDom    = cronField{"day of month", 1, 31, nil}
+	_cronMonth  = cronField{"month", 1, 12, []string{
+		"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC",
+	}}
+	_cronDow = cronField{"day of week", 0, 7, []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"}}
+)
+
+// ParseCronSchedule parses a cron expression, see CronSchedule.
+func ParseCronSchedule(expr string) (CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+
+	fields := strings.Fields(expr)
+	if macro, ok := _cronMacros[strings.ToLower(expr)]; ok {
+		fields = strings.Fields(macro)
+	}
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("%w: %q: expected 5 fields, got %d", ErrInvalidCron, expr, len(fields))
+	}
+
+	schedule := CronSchedule{expr: expr}
+	for i, target := range []struct {
+		field cronField
+		bits  *uint64
+	}{
+		{_cronMinute, &schedule.minute},
+		{_cronHour, &schedule.hour},
+		{_cronDom, &schedule.dom},
+		{_cronMonth, &schedule.month},
+		{_cronDow, &schedule.dow},
+	} {
+		bits, err := parseCronField(fields[i], target.field)
+		if err != nil {
+			return CronSchedule{}, fmt.Errorf("%w: %q: %w", ErrInvalidCron, expr, err)
+		}
+		*target.bits = bits
+	}
+
+	// Sunday may be written as 0 or 7
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domAny = fields[2] == "*" || fields[2] == "?"
+	schedule.dowAny = fields[4] == "*" || fields[4] == "?"
+
+	return schedule, nil
+}
+
+// parseCronField parses one field of a cron expression into a bit set.
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(value, CommaDelimeter) {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, fmt.Errorf("%s: invalid step %q", field.name, stepPart)
+			}
+		}
+
+		low, high := field.min, field.max
+		if rangePart != "*" && rangePart != "?" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			if low, err = parseCronValue(lowPart, field); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = parseCronValue(highPart, field); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				high = field.max
+			}
+			if low > high {
+				return 0, fmt.Errorf("%s: range %q is reversed", field.name, rangePart)
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+// parseCronValue parses a number or name of field.
+func parseCronValue(value string, field cronField) (int, error) {
+	if i := slices.Index(field.names, strings.ToUpper(value)); i >= 0 {
+		return field.min + i, nil
+	}
+
+	v, err := strconv.Atoi(value)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("%s: %q is not between %d and %d", field.name, value, field.min, field.max)
+	}
+	return v, nil
+}
+
+// Matches reports whether the schedule fires at the minute of t.
+func (s CronSchedule) Matches(t time.Time) bool {
+	return s.minute&(1<<t.Minute()) != 0 &&
+		s.hour&(1<<t.Hour()) != 0 &&
+		s.month&(1<<int(t.Month())) != 0 &&
+		s.matchesDay(t)
+}
+
+// matchesDay applies the cron rule that a day matches either day field
+// when both are restricted.
+func (s CronSchedule) matchesDay(t time.Time) bool {
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<int(t.Weekday())) != 0
+
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Next returns the first time after t, truncated to the minute, at which
+// the schedule fires, in the location of t. It returns the zero time if
+// the schedule never fires within the next five years (e.g. "0 0 30 2 *").
+func (s CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<int(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<t.Hour()) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<t.Minute()) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// String returns the expression s was parsed from.
+func (s CronSchedule) String() string {
+	return s.expr
+}
+
+// MarshalText implements encoding.TextMarshaler using CronSchedule.String.
+func (s CronSchedule) MarshalText() ([]byte, error) {
+	return []byte(s.expr), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseCronSchedule.
+func (s *CronSchedule) UnmarshalText(text []byte) error {
+	schedule, err := ParseCronSchedule(string(text))
+	if err != nil {
+		return err
+	}
+	*s = schedule
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// RRULE
+///////////////////////////////////////////////////////////////////////////////
+
+// RRule is a parsed iCalendar recurrence rule (RFC 5545, section 3.3.10).
+// RRule fields are populated from rules such as
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", with or without the
+// "RRULE:" prefix.
+//
+// Rules are validated (known parts, value ranges, COUNT and UNTIL not
+// combined) but not expanded into occurrences.
+type RRule struct {
+	Freq       string    // SECONDLY, MINUTELY, HOURLY, DAILY, WEEKLY, MONTHLY or YEARLY
+	Interval   int       // 1 if not set
+	Count      int       // 0 if not set
+	Until      time.Time // Zero if not set
+	BySecond   []int
+	ByMinute   []int
+	ByHour     []int
+	ByDay      []string // Weekdays with an optional ordinal, e.g. "MO" or "-1FR"
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByMonth    []int
+	BySetPos   []int
+	WeekStart  string // WKST, empty if not set
+}
+
+var (
+	_rruleFreqs    = []string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+	_rruleWeekdays = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+)
+
+// _rruleIntLists are the BYxxx parts holding numbers, with their ranges.
+// Parts that allow negative values accept -max to -min as well.
+var _rruleIntLists = map[string]struct {
+	min, max int
+	negative bool
+	field    func(*RRule) *[]int
+}{
+	"BYSECOND":   {0, 60, false, func(r *RRule) *[]int { return &r.BySecond }},
+	"BYMINUTE":   {0, 59, false, func(r *RRule) *[]int { return &r.ByMinute }},
+	"BYHOUR":     {0, 23, false, func(r *RRule) *[]int { return &r.ByHour }},
+	"BYMONTHDAY": {1, 31, true, func(r *RRule) *[]int { return &r.ByMonthDay }},
+	"BYYEARDAY":  {1, 366, true, func(r *RRule) *[]int { return &r.ByYearDay }},
+	"BYWEEKNO":   {1, 53, true, func(r *RRule) *[]int { return &r.ByWeekNo }},
+	"BYMONTH":    {1, 12, false, func(r *RRule) *[]int { return &r.ByMonth }},
+	"BYSETPOS":   {1, 366, true, func(r *RRule) *[]int { return &r.BySetPos }},
+}
+
+// ParseRRule parses a recurrence rule, see RRule.
+func ParseRRule(value string) (RRule, error) {
+	rule := RRule{Interval: 1}
+
+	text := strings.TrimSpace(value)
+	if len(text) >= len("RRULE:") && strings.EqualFold(text[:len("RRULE:")], "RRULE:") {
+		text = text[len("RRULE:"):]
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(text, ";") {
+		name, partValue, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || partValue == "" {
+			return RRule{}, fmt.Errorf("%w: %q: malformed part %q", ErrInvalidRRule, value, part)
+		}
+		if seen[name] {
+			return RRule{}, fmt.Errorf("%w: %q: duplicate part %s", ErrInvalidRRule, value, name)
+		}
+		seen[name] = true
+
+		if err := rule.setPart(name, strings.ToUpper(partValue)); err != nil {
+			return RRule{}, fmt.Errorf("%w: %q: %s: %w", ErrInvalidRRule, value, name, err)
+		}
+	}
+
+	switch {
+	case rule.Freq == "":
+		return RRule{}, fmt.Errorf("%w: %q: FREQ is required", ErrInvalidRRule, value)
+	case rule.Count > 0 && !rule.Until.IsZero():
+		return RRule{}, fmt.Errorf("%w: %q: COUNT and UNTIL cannot be combined", ErrInvalidRRule, value)
+	}
+
+	return rule, nil
+}
+
+// setPart parses one NAME=VALUE part of a rule into r.
+func (r *RRule) setPart(name, value string) error {
+	var err error
+
+	switch name {
+	case "FREQ":
+		if !slices.Contains(_rruleFreqs, value) {
+			return fmt.Errorf("unknown frequency %q", value)
+		}
+		r.Freq = value
+	case "INTERVAL":
+		r.Interval, err = strconv.Atoi(value)
+		if err == nil && r.Interval < 1 {
+			err = errors.New("must be positive")
+		}
+	case "COUNT":
+		r.Count, err = strconv.Atoi(value)
+		if err == nil && r.Count < 1 {
+			err = errors.New("must be positive")
+		}
+	case "UNTIL":
+		r.Until, err = parseRRuleUntil(value)
+	case "WKST":
+		if !slices.Contains(_rruleWeekdays, value) {
+			return fmt.Errorf("unknown weekday %q", value)
+		}
+		r.WeekStart = value
+	case "BYDAY":
+		for _, day := range strings.Split(value, CommaDelimeter) {
+			if err := checkRRuleWeekday(day); err != nil {
+				return err
+			}
+			r.ByDay = append(r.ByDay, day)
+		}
+	default:
+		list, ok := _rruleIntLists[name]
+		if !ok {
+			return errors.New("unknown part")
+		}
+		for _, item := range strings.Split(value, CommaDelimeter) {
+			n, err := strconv.Atoi(item)
+			if err != nil {
+				return err
+			}
+			abs := n
+			if list.negative && n < 0 {
+				abs = -n
+			}
+			if abs < list.min || abs > list.max {
+				return fmt.Errorf("%d is out of range", n)
+			}
+			*list.field(r) = append(*list.field(r), n)
+		}
+	}
+
+	return err
+}
+
+// checkRRuleWeekday checks a BYDAY value such as "MO", "2TU" or "-1FR".
+func checkRRuleWeekday(day string) error {
+	if len(day) < 2 || !slices.Contains(_rruleWeekdays, day[len(day)-2:]) {
+		return fmt.Errorf("unknown weekday %q", day)
+	}
+
+	if ordinal := day[:len(day)-2]; ordinal != "" {
+		n, err := strconv.Atoi(ordinal)
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return fmt.Errorf("invalid weekday ordinal %q", day)
+		}
+	}
+	return nil
+}
+
+// parseRRuleUntil parses an UNTIL value, a date or a UTC or floating
+// date-time.
+func parseRRuleUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// String formats r as a rule without the "RRULE:" prefix.
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	ints := func(name string, values []int) {
+		if len(values) == 0 {
+			return
+		}
+		items := make([]string, len(values))
+		for i, v := range values {
+			items[i] = strconv.Itoa(v)
+		}
+		parts = append(parts, name+"="+strings.Join(items, CommaDelimeter))
+	}
+	ints("BYSECOND", r.BySecond)
+	ints("BYMINUTE", r.ByMinute)
+	ints("BYHOUR", r.ByHour)
+	if len(r.ByDay) > 0 {
+		parts = append(parts, "BYDAY="+strings.Join(r.ByDay, CommaDelimeter))
+	}
+	ints("BYMONTHDAY", r.ByMonthDay)
+	ints("BYYEARDAY", r.ByYearDay)
+	ints("BYWEEKNO", r.ByWeekNo)
+	ints("BYMONTH", r.ByMonth)
+	ints("BYSETPOS", r.BySetPos)
+	if r.WeekStart != "" {
+		parts = append(parts, "WKST="+r.WeekStart)
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// MarshalText implements encoding.TextMarshaler using RRule.String.
+func (r RRule) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseRRule.
+func (r *RRule) UnmarshalText(text []byte) error {
+	rule, err := ParseRRule(string(text))
+	if err != nil {
+		return err
+	}
+	*r = rule
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Converters and Validation Rules
+///////////////////////////////////////////////////////////////////////////////
+
+// convertCronSchedule is the TypeConverter for CronSchedule fields.
+func convertCronSchedule(value string) (any, error) {
+	return ParseCronSchedule(value)
+}
+
+// convertRRule is the TypeConverter for RRule fields.
+func convertRRule(value string) (any, error) {
+	return ParseRRule(value)
+}
@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"*/15 9-17 * * MON-FRI",
+		"0 0 1,15 * ?",
+		"30 4 1 jan 7",
+		"10-30/5 * * * *",
+		"@daily",
+		"@HOURLY",
+	}
+	for _, expr := range valid {
+		schedule, err := ParseCronSchedule(expr)
+		assert.NoError(t, err, expr)
+		assert.Equal(t, expr, schedule.String())
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * FOO *",
+		"@often",
+	}
+	for _, expr := range invalid {
+		_, err := ParseCronSchedule(expr)
+		assert.ErrorIs(t, err, ErrInvalidCron, expr)
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	from := time.Date(2024, time.March, 15, 17, 50, 30, 0, time.UTC) // a Friday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.March, 15, 17, 51, 0, 0, time.UTC)},
+		{"*/15 9-17 * * MON-FRI", time.Date(2024, time.March, 18, 9, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 2 *", time.Date(2028, time.February, 29, 12, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches
+		{"0 0 20 * SUN", time.Date(2024, time.March, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		schedule, err := ParseCronSchedule(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, schedule.Next(from), tt.expr)
+		if !tt.want.IsZero() {
+			assert.True(t, schedule.Matches(tt.want), tt.expr)
+		}
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	rule, err := ParseRRule("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,-1FR;UNTIL=20241231T235959Z;WKST=su")
+	require.NoError(t, err)
+	assert.Equal(t, RRule{
+		Freq:      "WEEKLY",
+		Interval:  2,
+		Until:     time.Date(2024, time.December, 31, 23, 59, 59, 0, time.UTC),
+		ByDay:     []string{"MO", "-1FR"},
+		WeekStart: "SU",
+	}, rule)
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2;UNTIL=20241231T235959Z;BYDAY=MO,-1FR;WKST=SU", rule.String())
+
+	rule, err = ParseRRule("FREQ=MONTHLY;COUNT=10;BYMONTHDAY=1,-1;BYSETPOS=-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, rule.Interval)
+	assert.Equal(t, []int{1, -1}, rule.ByMonthDay)
+
+	invalid := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=FORTNIGHTLY",
+		"FREQ=DAILY;COUNT=3;UNTIL=20240101",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;BYDAY=XX",
+		"FREQ=DAILY;BYDAY=0MO",
+		"FREQ=DAILY;BYHOUR=24",
+		"FREQ=DAILY;BYMONTH=-1",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;COLOR=RED",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ=DAILY;",
+	}
+	for _, value := range invalid {
+		_, err := ParseRRule(value)
+		assert.ErrorIs(t, err, ErrInvalidRRule, value)
+	}
+}
+
+func TestHTTPRequestParser_Schedule(t *testing.T) {
+	type request struct {
+		Cron       CronSchedule `query:"cron"`
+		Recurrence *RRule       `query:"rrule"`
+	}
+
+	query := url.Values{
+		"cron":  {"0 9 * * MON"},
+		"rrule": {"FREQ=DAILY;COUNT=5"},
+	}
+	req, err := http.NewRequest(http.MethodGet, "/?"+query.Encode(), nil)
+	require.NoError(t, err)
+
+	var dest request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &dest))
+	assert.Equal(t, "0 9 * * MON", dest.Cron.String())
+	require.NotNil(t, dest.Recurrence)
+	assert.Equal(t, 5, dest.Recurrence.Count)
+
+	query.Set("cron", "0 25 * * *")
+	req, err = http.NewRequest(http.MethodGet, "/?"+query.Encode(), nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, NewHTTPRequestParser().Parse(req, &request{}), ErrInvalidCron)
+}
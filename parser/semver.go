@@ -0,0 +1,49 @@
+//go:build pave_semver
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Support for github.com/Masterminds/semver/v3 destination fields, whose
+// version rules the validate package provides.
+//
+// semver.Version and semver.Constraints fields (and pointers to them) are
+// populated from version strings ("1.2.3", "v2.0.0-rc.1") and constraint
+// strings (">= 1.2, < 2.0", "^1.4") respectively.
+
+var (
+	ErrInvalidSemver           = errors.New("invalid semantic version")
+	ErrInvalidSemverConstraint = errors.New("invalid semantic version constraint")
+	ErrSemverNotSatisfied      = errors.New("version does not satisfy the constraint")
+)
+
+// installSemver registers the semver integration. See installIntegrations.
+func installSemver() {
+	_builtinTypeConverters[reflect.TypeFor[semver.Version]()] = ignoreModifiers(convertSemver)
+	_builtinTypeConverters[reflect.TypeFor[semver.Constraints]()] = ignoreModifiers(convertSemverConstraints)
+}
+
+// convertSemver parses a semantic version, allowing a "v" prefix and
+// missing minor or patch numbers.
+func convertSemver(value string) (any, error) {
+	version, err := semver.NewVersion(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrInvalidSemver, value, err)
+	}
+	return *version, nil
+}
+
+// convertSemverConstraints parses a version constraint.
+func convertSemverConstraints(value string) (any, error) {
+	constraints, err := semver.NewConstraint(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrInvalidSemverConstraint, value, err)
+	}
+	return *constraints, nil
+}
@@ -0,0 +1,38 @@
+//go:build pave_semver
+
+package parser
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPRequestParser_Semver(t *testing.T) {
+	type request struct {
+		Version    semver.Version     `query:"version"`
+		MinVersion *semver.Version    `query:"min"`
+		Requires   semver.Constraints `query:"requires"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/?version=v1.4.2&min=1.2&requires=%5E1.4", nil)
+	require.NoError(t, err)
+
+	var dest request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &dest))
+	assert.Equal(t, "1.4.2", dest.Version.String())
+	require.NotNil(t, dest.MinVersion)
+	assert.Equal(t, "1.2.0", dest.MinVersion.String())
+	assert.True(t, dest.Requires.Check(&dest.Version))
+
+	req, err = http.NewRequest(http.MethodGet, "/?version=one&min=1&requires=*", nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, NewHTTPRequestParser().Parse(req, &request{}), ErrInvalidSemver)
+
+	req, err = http.NewRequest(http.MethodGet, "/?version=1.0.0&min=1&requires=%3E%3E1", nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, NewHTTPRequestParser().Parse(req, &request{}), ErrInvalidSemverConstraint)
+}
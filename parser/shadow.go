@@ -0,0 +1,180 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ShadowOpts configures a ShadowParser.
+type ShadowOpts struct {
+	// Types maps destination types to the types the shadow parser
+	// populates instead, for migrations to a new version of a struct.
+	// Types without an entry are shadowed with the same type, e.g. for
+	// migrations of the tag grammar or of parser options.
+	Types map[reflect.Type]reflect.Type
+	// Report receives the report of every shadowed parse, e.g. to log or
+	// count divergences. It is called synchronously, after the primary
+	// parse, and must be safe for concurrent use.
+	Report func(report *ShadowReport)
+}
+
+// ShadowParser parses sources with a primary parser and then, in its
+// shadow, with a candidate parser, and reports where the candidate
+// diverges from the primary without affecting the primary result. This
+// de-risks migrations in production: the candidate (a new tag grammar,
+// new parser options, a new struct version) runs on real traffic before
+// it replaces the primary.
+//
+// The shadow parse populates a separate value, its errors and panics are
+// reported rather than returned, and it runs after the primary parse, on
+// the same source. Sources must therefore be readable twice, as
+// *http.Request bodies read by the HTTPRequestParser are. Shadowing
+// doubles the cost of parsing.
+type ShadowParser struct {
+	primary Parser
+	shadow  Parser
+	opts    ShadowOpts
+}
+
+// ShadowReport compares a primary and a shadow parse of the same source.
+type ShadowReport struct {
+	Type        reflect.Type // Destination type of the primary parse
+	ShadowType  reflect.Type // Destination type of the shadow parse
+	Err         error        // Error of the primary parse, if any
+	ShadowErr   error        // Error (or recovered panic) of the shadow parse, if any
+	Divergences []Divergence // Fields whose values differ, if both parses succeeded
+}
+
+// Divergence is a field whose shadow value differs from its primary value.
+// Fields missing from one of the types have a nil value on that side.
+type Divergence struct {
+	Field   string // Dotted path of the field
+	Primary any    // Value of the primary parse
+	Shadow  any    // Value of the shadow parse
+}
+
+// NewShadowParser returns a parser that parses with primary and shadows
+// it with shadow, see ShadowParser. Both parsers must work with the same
+// source type. The ShadowParser takes over the name of primary, so that
+// it can replace it in a ParserRegistry.
+func NewShadowParser(primary Parser, shadow Parser, opts ShadowOpts) (*ShadowParser, error) {
+	if primary.SourceType() != shadow.SourceType() {
+		return nil, fmt.Errorf(
+			"%w: shadow parser works with %v, not %v",
+			ErrSourceTypeMismatch, shadow.SourceType(), primary.SourceType(),
+		)
+	}
+	return &ShadowParser{primary: primary, shadow: shadow, opts: opts}, nil
+}
+
+func (sp *ShadowParser) SourceType() reflect.Type {
+	return sp.primary.SourceType()
+}
+
+func (sp *ShadowParser) Name() string {
+	return sp.primary.Name()
+}
+
+// Parse parses source into dest with the primary parser, whose error it
+// returns, then shadows the parse and reports the result.
+func (sp *ShadowParser) Parse(source any, dest any) error {
+	err := sp.primary.Parse(source, dest)
+	if sp.opts.Report == nil {
+		return err
+	}
+
+	if checkDest(dest) != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(dest).Elem()
+	shadowType := value.Type()
+	if typ, ok := sp.opts.Types[shadowType]; ok {
+		shadowType = typ
+	}
+
+	report := &ShadowReport{Type: value.Type(), ShadowType: shadowType, Err: err}
+	shadowValue := reflect.New(shadowType)
+	report.ShadowErr = sp.shadowParse(source, shadowValue.Interface())
+
+	if err == nil && report.ShadowErr == nil {
+		report.Divergences = compareShadow(value, shadowValue.Elem(), "", nil)
+	}
+
+	sp.opts.Report(report)
+	return err
+}
+
+// shadowParse parses source into dest with the shadow parser, recovering
+// from its panics.
+func (sp *ShadowParser) shadowParse(source any, dest any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("shadow parser panicked: %v", r)
+		}
+	}()
+	return sp.shadow.Parse(source, dest)
+}
+
+// Diverged reports whether the shadow parse diverged from the primary
+// parse: only one of them failed, or fields differ.
+func (report *ShadowReport) Diverged() bool {
+	return (report.Err == nil) != (report.ShadowErr == nil) || len(report.Divergences) > 0
+}
+
+// compareShadow appends the divergences between the fields of the
+// primary and shadow structs, matched by name, to divergences.
+func compareShadow(primary, shadow reflect.Value, prefix string, divergences []Divergence) []Divergence {
+	primaryType, shadowType := primary.Type(), shadow.Type()
+
+	compared := make(map[string]bool, primaryType.NumField())
+	for i := 0; i < primaryType.NumField(); i++ {
+		field := primaryType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		compared[field.Name] = true
+
+		path := prefix + field.Name
+		shadowField, ok := shadowType.FieldByName(field.Name)
+		if !ok || len(shadowField.Index) > 1 {
+			divergences = append(divergences, Divergence{Field: path, Primary: primary.Field(i).Interface()})
+			continue
+		}
+
+		divergences = compareShadowValues(
+			primary.Field(i), shadow.FieldByIndex(shadowField.Index), path, divergences,
+		)
+	}
+
+	for i := 0; i < shadowType.NumField(); i++ {
+		field := shadowType.Field(i)
+		if field.IsExported() && !compared[field.Name] {
+			divergences = append(divergences, Divergence{Field: prefix + field.Name, Shadow: shadow.Field(i).Interface()})
+		}
+	}
+
+	return divergences
+}
+
+// compareShadowValues compares the values of a field, recursing into
+// nested structs of both parses.
+func compareShadowValues(primary, shadow reflect.Value, path string, divergences []Divergence) []Divergence {
+	if primary.Kind() == reflect.Ptr && shadow.Kind() == reflect.Ptr && !primary.IsNil() && !shadow.IsNil() {
+		primary, shadow = primary.Elem(), shadow.Elem()
+	}
+
+	if primary.Kind() == reflect.Struct && shadow.Kind() == reflect.Struct &&
+		!IsSpecialStructType(primary.Type()) && !IsSpecialStructType(shadow.Type()) {
+		return compareShadow(primary, shadow, path+".", divergences)
+	}
+
+	if primary.Type() != shadow.Type() || !reflect.DeepEqual(primary.Interface(), shadow.Interface()) {
+		divergences = append(divergences, Divergence{
+			Field:   path,
+			Primary: primary.Interface(),
+			Shadow:  shadow.Interface(),
+		})
+	}
+	return divergences
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// panickingParser is an HTTP request parser that always panics.
+type panickingParser struct{}
+
+func (panickingParser) Parse(source any, dest any) error { panic("boom") }
+func (panickingParser) SourceType() reflect.Type         { return reflect.TypeFor[http.Request]() }
+func (panickingParser) Name() string                     { return "panicking" }
+
+func TestShadowParser(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+	type UserV1 struct {
+		Name    string  `json:"name"`
+		Email   string  `json:"email"`
+		Address Address `json:"address,scope"`
+	}
+	type UserV2 struct {
+		Name    string `json:"name"`
+		Email   string `json:"email_address,omitempty" default:""`
+		Address struct {
+			City string `json:"town,omitempty" default:""`
+		} `json:"address,scope"`
+		Plan string `json:"plan,omitempty" default:"free"`
+	}
+
+	newRequest := func(t *testing.T) *http.Request {
+		body := `{"name": "Ann", "email": "ann@example.com", "address": {"city": "Oslo"}}`
+		req := newTestRequest(t, "POST", "http://example.com/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	var (
+		mu      sync.Mutex
+		reports []*ShadowReport
+	)
+	record := func(report *ShadowReport) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, report)
+	}
+
+	t.Run("Divergences", func(t *testing.T) {
+		reports = nil
+		parser, err := NewShadowParser(NewHTTPRequestParser(), NewHTTPRequestParser(), ShadowOpts{
+			Types:  map[reflect.Type]reflect.Type{reflect.TypeFor[UserV1](): reflect.TypeFor[UserV2]()},
+			Report: record,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, HTTPRequestParserName, parser.Name())
+
+		var user UserV1
+		require.NoError(t, parser.Parse(newRequest(t), &user))
+		assert.Equal(t, UserV1{Name: "Ann", Email: "ann@example.com", Address: Address{City: "Oslo"}}, user,
+			"the primary result is unaffected")
+
+		require.Len(t, reports, 1)
+		report := reports[0]
+		require.NoError(t, report.ShadowErr)
+		assert.True(t, report.Diverged())
+		assert.Equal(t, reflect.TypeFor[UserV2](), report.ShadowType)
+		assert.Equal(t, []Divergence{
+			{Field: "Email", Primary: "ann@example.com", Shadow: ""},
+			{Field: "Address.City", Primary: "Oslo", Shadow: ""},
+			{Field: "Plan", Shadow: "free"},
+		}, report.Divergences)
+	})
+
+	t.Run("SameType", func(t *testing.T) {
+		reports = nil
+		parser, err := NewShadowParser(NewHTTPRequestParser(), NewHTTPRequestParser(), ShadowOpts{Report: record})
+		require.NoError(t, err)
+
+		require.NoError(t, parser.Parse(newRequest(t), &UserV1{}))
+		require.Len(t, reports, 1)
+		assert.False(t, reports[0].Diverged(), "the shadow parse reads the body again")
+	})
+
+	t.Run("ShadowPanics", func(t *testing.T) {
+		reports = nil
+		parser, err := NewShadowParser(NewHTTPRequestParser(), panickingParser{}, ShadowOpts{Report: record})
+		require.NoError(t, err)
+
+		var user UserV1
+		require.NoError(t, parser.Parse(newRequest(t), &user))
+		assert.Equal(t, "Ann", user.Name)
+		require.Len(t, reports, 1)
+		assert.ErrorContains(t, reports[0].ShadowErr, "boom")
+		assert.True(t, reports[0].Diverged())
+	})
+
+	t.Run("SourceTypeMismatch", func(t *testing.T) {
+		_, err := NewShadowParser(NewHTTPRequestParser(), NewJSONStringSourceParser(), ShadowOpts{})
+		assert.ErrorIs(t, err, ErrSourceTypeMismatch)
+	})
+}
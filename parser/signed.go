@@ -0,0 +1,219 @@
+package parser
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid request signature")
+	ErrUnknownVerifier  = errors.New("unknown signature verifier")
+)
+
+// SignatureVerifier verifies the signature of a request over its raw
+// body, e.g. that of a webhook delivery. See
+// HTTPRequestParser.RegisterVerifier.
+type SignatureVerifier interface {
+	// VerifySignature returns an error wrapping ErrInvalidSignature if
+	// body, the raw body of req, is not signed as expected.
+	VerifySignature(req *http.Request, body []byte) error
+}
+
+// SignedPayloadFunc returns the payload a request signature is computed
+// over, for schemes that sign more than the body.
+type SignedPayloadFunc func(req *http.Request, body []byte) []byte
+
+// TimestampPayload returns a SignedPayloadFunc for schemes signing the
+// value of a timestamp header, then sep, then the body (e.g. Discord
+// interactions, with the X-Signature-Timestamp header and no separator).
+func TimestampPayload(header string, sep string) SignedPayloadFunc {
+	return func(req *http.Request, body []byte) []byte {
+		payload := []byte(req.Header.Get(header) + sep)
+		return append(payload, body...)
+	}
+}
+
+// HMACVerifier verifies HMAC signatures carried by a header, e.g. the
+// X-Hub-Signature-256 header of GitHub webhooks:
+//
+//	pave.HMACVerifier{Header: "X-Hub-Signature-256", Prefix: "sha256=", Secret: secret}
+type HMACVerifier struct {
+	Header  string            // Header holding the signature
+	Prefix  string            // Prefix of the header value before the signature, e.g. "sha256="
+	Secret  []byte            // Shared secret
+	Hash    func() hash.Hash  // Hash of the HMAC. Nil uses SHA-256.
+	Base64  bool              // Whether the signature is base64 rather than hex encoded
+	Payload SignedPayloadFunc // Signed payload. Nil signs the body.
+}
+
+func (v HMACVerifier) VerifySignature(req *http.Request, body []byte) error {
+	signature, err := decodeSignature(req, v.Header, v.Prefix, v.Base64)
+	if err != nil {
+		return err
+	}
+
+	newHash := v.Hash
+	if newHash == nil {
+		newHash = sha256.New
+	}
+
+	mac := hmac.New(newHash, v.Secret)
+	mac.Write(signedPayload(v.Payload, req, body))
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// Ed25519Verifier verifies Ed25519 signatures carried by a header, e.g.
+// the X-Signature-Ed25519 header of Discord interactions:
+//
+//	pave.Ed25519Verifier{
+//		Header:    "X-Signature-Ed25519",
+//		PublicKey: key,
+//		Payload:   pave.TimestampPayload("X-Signature-Timestamp", ""),
+//	}
+type Ed25519Verifier struct {
+	Header    string            // Header holding the signature
+	Prefix    string            // Prefix of the header value before the signature
+	PublicKey ed25519.PublicKey // Key of the signer
+	Base64    bool              // Whether the signature is base64 rather than hex encoded
+	Payload   SignedPayloadFunc // Signed payload. Nil signs the body.
+}
+
+func (v Ed25519Verifier) VerifySignature(req *http.Request, body []byte) error {
+	signature, err := decodeSignature(req, v.Header, v.Prefix, v.Base64)
+	if err != nil {
+		return err
+	}
+
+	if len(v.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: invalid public key", ErrInvalidSignature)
+	}
+	if !ed25519.Verify(v.PublicKey, signedPayload(v.Payload, req, body), signature) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// decodeSignature returns the signature carried by header, after prefix.
+func decodeSignature(req *http.Request, header string, prefix string, isBase64 bool) ([]byte, error) {
+	value := req.Header.Get(header)
+	if value == "" {
+		return nil, fmt.Errorf("%w: missing %s header", ErrInvalidSignature, header)
+	}
+
+	encoded, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s header does not start with %q", ErrInvalidSignature, header, prefix)
+	}
+
+	var (
+		signature []byte
+		err       error
+	)
+	if isBase64 {
+		signature, err = base64.StdEncoding.DecodeString(encoded)
+	} else {
+		signature, err = hex.DecodeString(encoded)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s header: %w", ErrInvalidSignature, header, err)
+	}
+	return signature, nil
+}
+
+// signedPayload returns the payload signed for req, body unless payload
+// is set.
+func signedPayload(payload SignedPayloadFunc, req *http.Request, body []byte) []byte {
+	if payload == nil {
+		return body
+	}
+	return payload(req, body)
+}
+
+// RegisterVerifier makes verifier available to the signed modifier of the
+// parser as name: the bindings of `json:"action,signed=github"` only read
+// the body once verifier accepted its signature. Every other binding of
+// a field may carry the modifier too, e.g. for headers sent along with
+// the signed body.
+//
+// A failed verification fails the field even if it is optional or has a
+// default. Registering a verifier for an already registered name replaces
+// it.
+func (hp *HTTPRequestParser) RegisterVerifier(name string, verifier SignatureVerifier) {
+	hp.bindingManager().registerVerifier(name, verifier)
+}
+
+// UnregisterVerifier removes the verifier registered as name, if any.
+func (hp *HTTPRequestParser) UnregisterVerifier(name string) {
+	hp.bindingManager().unregisterVerifier(name)
+}
+
+// bindingManager returns the HTTPBindingManager of the parser.
+func (hp *HTTPRequestParser) bindingManager() *HTTPBindingManager {
+	if faults, ok := hp.BMgr.(*FaultInjectingBindingManager[http.Request, HTTPRequestOnce]); ok {
+		return faults.Unwrap().(*HTTPBindingManager)
+	}
+	return hp.BMgr.(*HTTPBindingManager)
+}
+
+func (mgr *HTTPBindingManager) registerVerifier(name string, verifier SignatureVerifier) {
+	mgr.verifiersMu.Lock()
+	defer mgr.verifiersMu.Unlock()
+
+	if mgr.verifiers == nil {
+		mgr.verifiers = make(map[string]SignatureVerifier)
+	}
+	mgr.verifiers[name] = verifier
+}
+
+func (mgr *HTTPBindingManager) unregisterVerifier(name string) {
+	mgr.verifiersMu.Lock()
+	defer mgr.verifiersMu.Unlock()
+
+	delete(mgr.verifiers, name)
+}
+
+// verifySignature verifies the body of source with the verifier
+// registered as name, once per request and verifier.
+func (mgr *HTTPBindingManager) verifySignature(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], name string,
+) error {
+
+	mgr.verifiersMu.RLock()
+	verifier, ok := mgr.verifiers[name]
+	mgr.verifiersMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownVerifier, name)
+	}
+
+	var err error
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		if verified, ok := data.signatures[name]; ok {
+			err = verified
+			return
+		}
+
+		body, readErr := mgr.readBodyOnce(source, data)
+		if readErr != nil {
+			err = readErr
+		} else {
+			err = verifier.VerifySignature(source, body)
+		}
+
+		if data.signatures == nil {
+			data.signatures = make(map[string]error)
+		}
+		data.signatures[name] = err
+	})
+	return err
+}
@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingVerifier counts its verifications.
+type countingVerifier struct {
+	SignatureVerifier
+	calls int
+}
+
+func (v *countingVerifier) VerifySignature(req *http.Request, body []byte) error {
+	v.calls++
+	return v.SignatureVerifier.VerifySignature(req, body)
+}
+
+func TestHTTPRequestParser_Signed(t *testing.T) {
+	type PushEvent struct {
+		Event  string `header:"X-GitHub-Event"`
+		Ref    string `json:"ref,signed=github"`
+		Pusher string `json:"pusher.name,signed=github,omitempty" default:"unknown"`
+	}
+
+	secret := []byte("webhook-secret")
+	body := []byte(`{"ref":"refs/heads/main","pusher":{"name":"octocat"}}`)
+
+	sign := func(body []byte) string {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	newRequest := func(t *testing.T, body []byte, signature string) *http.Request {
+		req := newTestRequest(t, "POST", "http://example.com/webhook", bytes.NewReader(body))
+		req.Header.Set("X-GitHub-Event", "push")
+		req.Header.Set("X-Hub-Signature-256", signature)
+		return req
+	}
+
+	verifier := &countingVerifier{
+		SignatureVerifier: HMACVerifier{Header: "X-Hub-Signature-256", Prefix: "sha256=", Secret: secret},
+	}
+	parser := NewHTTPRequestParser()
+	parser.RegisterVerifier("github", verifier)
+
+	var event PushEvent
+	require.NoError(t, parser.Parse(newRequest(t, body, sign(body)), &event))
+	assert.Equal(t, PushEvent{Event: "push", Ref: "refs/heads/main", Pusher: "octocat"}, event)
+	assert.Equal(t, 1, verifier.calls, "the body is verified once per request")
+
+	tampered := bytes.Replace(body, []byte("main"), []byte("evil"), 1)
+	err := parser.Parse(newRequest(t, tampered, sign(body)), &PushEvent{})
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	for _, signature := range []string{"", "sha1=abc", "sha256=zz", sign([]byte("other"))} {
+		err := parser.Parse(newRequest(t, body, signature), &PushEvent{})
+		assert.ErrorIs(t, err, ErrInvalidSignature, signature)
+	}
+
+	t.Run("NotRecoverable", func(t *testing.T) {
+		var event struct {
+			Pusher string `json:"pusher.name,signed=github,omitempty,omiterror" default:"unknown"`
+		}
+		err := parser.Parse(newRequest(t, tampered, sign(body)), &event)
+		assert.ErrorIs(t, err, ErrInvalidSignature, "defaults do not hide a forged body")
+	})
+
+	t.Run("UnknownVerifier", func(t *testing.T) {
+		var event struct {
+			Ref string `json:"ref,signed=gitlab"`
+		}
+		err := parser.Parse(newRequest(t, body, sign(body)), &event)
+		assert.ErrorIs(t, err, ErrUnknownVerifier)
+
+		parser.UnregisterVerifier("github")
+		err = parser.Parse(newRequest(t, body, sign(body)), &PushEvent{})
+		assert.ErrorIs(t, err, ErrUnknownVerifier)
+	})
+}
+
+func TestHMACVerifier(t *testing.T) {
+	secret := []byte("key")
+	body := []byte("payload")
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write([]byte("123.payload"))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req := newTestRequest(t, "POST", "http://example.com/hook", nil)
+	req.Header.Set("X-Signature", signature)
+	req.Header.Set("X-Timestamp", "123")
+
+	verifier := HMACVerifier{
+		Header:  "X-Signature",
+		Secret:  secret,
+		Hash:    sha1.New,
+		Base64:  true,
+		Payload: TimestampPayload("X-Timestamp", "."),
+	}
+	assert.NoError(t, verifier.VerifySignature(req, body))
+
+	verifier.Secret = []byte("other")
+	assert.ErrorIs(t, verifier.VerifySignature(req, body), ErrInvalidSignature)
+}
+
+func TestEd25519Verifier(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	body := []byte(`{"type":1}`)
+	signature := ed25519.Sign(private, append([]byte("1700000000"), body...))
+
+	type Interaction struct {
+		Type int `json:"type,signed=discord"`
+	}
+
+	parser := NewHTTPRequestParser()
+	parser.RegisterVerifier("discord", Ed25519Verifier{
+		Header:    "X-Signature-Ed25519",
+		PublicKey: public,
+		Payload:   TimestampPayload("X-Signature-Timestamp", ""),
+	})
+
+	newRequest := func(t *testing.T, timestamp string) *http.Request {
+		req := newTestRequest(t, "POST", "http://example.com/interactions", bytes.NewReader(body))
+		req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(signature))
+		req.Header.Set("X-Signature-Timestamp", timestamp)
+		return req
+	}
+
+	var interaction Interaction
+	require.NoError(t, parser.Parse(newRequest(t, "1700000000"), &interaction))
+	assert.Equal(t, 1, interaction.Type)
+
+	err = parser.Parse(newRequest(t, "1700000001"), &Interaction{})
+	assert.ErrorIs(t, err, ErrInvalidSignature, "the timestamp is signed")
+
+	err = Ed25519Verifier{Header: "X-Signature-Ed25519"}.VerifySignature(newRequest(t, "1700000000"), body)
+	assert.ErrorIs(t, err, ErrInvalidSignature, "missing public key")
+}
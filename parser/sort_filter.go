@@ -0,0 +1,287 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidSort        = errors.New("invalid sort expression")
+	ErrInvalidFilter      = errors.New("invalid filter expression")
+	ErrUnknownQueryFields = errors.New("unknown query fields")
+	ErrFieldNotSortable   = errors.New("field is not sortable")
+	ErrFieldNotFilterable = errors.New("field is not filterable")
+	ErrFilterOpNotAllowed = errors.New("filter operator is not allowed")
+)
+
+// FilterOp is the operator of a FilterSpec.
+type FilterOp string
+
+// Operators of filter expressions
+const (
+	FilterEq   FilterOp = "eq"
+	FilterNe   FilterOp = "ne"
+	FilterLt   FilterOp = "lt"
+	FilterLe   FilterOp = "le"
+	FilterGt   FilterOp = "gt"
+	FilterGe   FilterOp = "ge"
+	FilterIn   FilterOp = "in"   // Value lists alternatives separated by "|"
+	FilterLike FilterOp = "like" // Value is a pattern, e.g. with * wildcards
+)
+
+var _filterOps = []FilterOp{FilterEq, FilterNe, FilterLt, FilterLe, FilterGt, FilterGe, FilterIn, FilterLike}
+
+// SortSpec is a single element of a sort expression, e.g. "-created_at".
+type SortSpec struct {
+	Field string
+	Desc  bool
+}
+
+// String formats the element as in a sort expression.
+func (spec SortSpec) String() string {
+	if spec.Desc {
+		return "-" + spec.Field
+	}
+	return spec.Field
+}
+
+// SortSpecs is a parsed sort expression, e.g. "-created_at,+name", in
+// order of precedence.
+type SortSpecs []SortSpec
+
+// String formats the specs as a sort expression.
+func (specs SortSpecs) String() string {
+	elements := make([]string, len(specs))
+	for i, spec := range specs {
+		elements[i] = spec.String()
+	}
+	return strings.Join(elements, CommaDelimeter)
+}
+
+// MarshalText formats the specs as a sort expression.
+func (specs SortSpecs) MarshalText() ([]byte, error) {
+	return []byte(specs.String()), nil
+}
+
+// FilterSpec is a single element of a filter expression, e.g.
+// "status:eq:open".
+type FilterSpec struct {
+	Field string
+	Op    FilterOp
+	Value string
+}
+
+// Values returns the alternatives of an in filter, or the value of other
+// filters.
+func (spec FilterSpec) Values() []string {
+	if spec.Op == FilterIn {
+		return strings.Split(spec.Value, "|")
+	}
+	return []string{spec.Value}
+}
+
+// String formats the element as in a filter expression.
+func (spec FilterSpec) String() string {
+	return spec.Field + ":" + string(spec.Op) + ":" + spec.Value
+}
+
+// FilterSpecs is a parsed filter expression, e.g.
+// "status:eq:open,age:gt:30". All filters apply.
+type FilterSpecs []FilterSpec
+
+// String formats the specs as a filter expression.
+func (specs FilterSpecs) String() string {
+	elements := make([]string, len(specs))
+	for i, spec := range specs {
+		elements[i] = spec.String()
+	}
+	return strings.Join(elements, CommaDelimeter)
+}
+
+// MarshalText formats the specs as a filter expression.
+func (specs FilterSpecs) MarshalText() ([]byte, error) {
+	return []byte(specs.String()), nil
+}
+
+// QueryFields lists the fields of a resource that sort and filter
+// expressions may refer to. See RegisterQueryFields.
+type QueryFields struct {
+	Sortable   []string              // Fields that may be sorted by
+	Filterable map[string][]FilterOp // Fields that may be filtered, with their operators. Nil allows all operators.
+}
+
+// queryFields holds the registered QueryFields keyed by name.
+var (
+	_queryFields      = make(map[string]QueryFields)
+	_queryFieldsMutex sync.RWMutex
+)
+
+// RegisterQueryFields makes fields available to the allow modifier as
+// name, so that SortSpecs and FilterSpecs fields bound with
+// `query:"sort,allow=<name>"` only accept the fields it lists, e.g.
+//
+//	pave.RegisterQueryFields("users", pave.QueryFields{
+//		Sortable:   []string{"created_at", "name"},
+//		Filterable: map[string][]pave.FilterOp{"status": {pave.FilterEq, pave.FilterIn}},
+//	})
+//
+// Registering fields for an already registered name replaces them. Chains
+// that were already built keep the fields they were built with.
+func RegisterQueryFields(name string, fields QueryFields) {
+	_queryFieldsMutex.Lock()
+	defer _queryFieldsMutex.Unlock()
+
+	_queryFields[name] = fields
+}
+
+// UnregisterQueryFields removes the fields registered as name, if any.
+func UnregisterQueryFields(name string) {
+	_queryFieldsMutex.Lock()
+	defer _queryFieldsMutex.Unlock()
+
+	delete(_queryFields, name)
+}
+
+// getQueryFields returns the fields registered as name, if any.
+func getQueryFields(name string) (QueryFields, bool) {
+	_queryFieldsMutex.RLock()
+	defer _queryFieldsMutex.RUnlock()
+
+	fields, ok := _queryFields[name]
+	return fields, ok
+}
+
+// parseAllowModifier is the ModifierValueParser of the allow modifier,
+// which names registered QueryFields.
+func parseAllowModifier(value string) (any, error) {
+	fields, ok := getQueryFields(value)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownQueryFields, value)
+	}
+	return fields, nil
+}
+
+// ParseSort parses a sort expression: fields separated by commas, each
+// prefixed with - for descending order and optionally + for ascending
+// order (query strings decode an unescaped + to a space, which is
+// trimmed). If fields is not nil, only its Sortable fields are accepted.
+func ParseSort(value string, fields *QueryFields) (SortSpecs, error) {
+	elements, err := splitQueryExpression(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSort, err)
+	}
+
+	var specs SortSpecs
+	for _, element := range elements {
+		element = strings.TrimSpace(element)
+
+		var spec SortSpec
+		switch {
+		case strings.HasPrefix(element, "-"):
+			spec = SortSpec{Field: element[1:], Desc: true}
+		case strings.HasPrefix(element, "+"):
+			spec = SortSpec{Field: element[1:]}
+		default:
+			spec = SortSpec{Field: element}
+		}
+
+		if spec.Field == "" || spec.Field[0] == '-' || strings.ContainsAny(spec.Field, "+ ") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSort, element)
+		}
+		if fields != nil && !slices.Contains(fields.Sortable, spec.Field) {
+			return nil, fmt.Errorf("%w: %s", ErrFieldNotSortable, spec.Field)
+		}
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+// ParseFilter parses a filter expression: filters separated by commas,
+// each of the form field:op:value, e.g. "status:eq:open". The value may
+// contain colons but no commas. If fields is not nil, only its Filterable
+// fields and their operators are accepted.
+func ParseFilter(value string, fields *QueryFields) (FilterSpecs, error) {
+	elements, err := splitQueryExpression(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+
+	var specs FilterSpecs
+	for _, element := range elements {
+		parts := strings.SplitN(element, ":", 3)
+		if len(parts) != 3 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("%w: %q is not field:op:value", ErrInvalidFilter, element)
+		}
+
+		spec := FilterSpec{
+			Field: strings.TrimSpace(parts[0]),
+			Op:    FilterOp(strings.ToLower(strings.TrimSpace(parts[1]))),
+			Value: parts[2],
+		}
+		if !slices.Contains(_filterOps, spec.Op) {
+			return nil, fmt.Errorf("%w: unknown operator %q", ErrInvalidFilter, parts[1])
+		}
+
+		if fields != nil {
+			ops, ok := fields.Filterable[spec.Field]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrFieldNotFilterable, spec.Field)
+			}
+			if ops != nil && !slices.Contains(ops, spec.Op) {
+				return nil, fmt.Errorf("%w: %s:%s", ErrFilterOpNotAllowed, spec.Field, spec.Op)
+			}
+		}
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+// splitQueryExpression splits a sort or filter expression into its
+// elements. Expressions are either separated by commas, or a JSON array of
+// elements, as bound from repeated bracketed query parameters
+// (sort[]=-created_at&sort[]=name).
+func splitQueryExpression(value string) ([]string, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "[") {
+		var elements []string
+		if err := json.Unmarshal([]byte(value), &elements); err != nil {
+			return nil, err
+		}
+		return elements, nil
+	}
+
+	var elements []string
+	for _, element := range strings.Split(value, CommaDelimeter) {
+		if strings.TrimSpace(element) != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements, nil
+}
+
+// allowedQueryFields returns the QueryFields of the allow modifier, if
+// set.
+func allowedQueryFields(modifiers BindingModifiers) *QueryFields {
+	value, _ := modifiers.Value(AllowBindingModifier)
+	if fields, ok := value.(QueryFields); ok {
+		return &fields
+	}
+	return nil
+}
+
+// convertSortSpecs parses a SortSpecs field, honoring the allow modifier
+// of the binding.
+func convertSortSpecs(value string, modifiers BindingModifiers) (any, error) {
+	return ParseSort(value, allowedQueryFields(modifiers))
+}
+
+// convertFilterSpecs parses a FilterSpecs field, honoring the allow
+// modifier of the binding.
+func convertFilterSpecs(value string, modifiers BindingModifiers) (any, error) {
+	return ParseFilter(value, allowedQueryFields(modifiers))
+}
@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	specs, err := ParseSort("-created_at, +name,email, updated-at", nil)
+	require.NoError(t, err)
+	assert.Equal(t, SortSpecs{
+		{Field: "created_at", Desc: true},
+		{Field: "name"},
+		{Field: "email"},
+		{Field: "updated-at"},
+	}, specs)
+	assert.Equal(t, "-created_at,name,email,updated-at", specs.String())
+
+	specs, err = ParseSort(`["-created_at"," name"]`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, SortSpecs{{Field: "created_at", Desc: true}, {Field: "name"}}, specs)
+
+	for _, value := range []string{"-", "+", "--name", "+-name", "first name", `["name"`} {
+		_, err := ParseSort(value, nil)
+		assert.ErrorIs(t, err, ErrInvalidSort, value)
+	}
+
+	fields := &QueryFields{Sortable: []string{"name"}}
+	_, err = ParseSort("-name", fields)
+	assert.NoError(t, err)
+	_, err = ParseSort("name,password", fields)
+	assert.ErrorIs(t, err, ErrFieldNotSortable)
+}
+
+func TestParseFilter(t *testing.T) {
+	specs, err := ParseFilter("status:eq:open, age:GT:30,tag:in:a|b,at:lt:12:30", nil)
+	require.NoError(t, err)
+	assert.Equal(t, FilterSpecs{
+		{Field: "status", Op: FilterEq, Value: "open"},
+		{Field: "age", Op: FilterGt, Value: "30"},
+		{Field: "tag", Op: FilterIn, Value: "a|b"},
+		{Field: "at", Op: FilterLt, Value: "12:30"},
+	}, specs)
+	assert.Equal(t, []string{"a", "b"}, specs[2].Values())
+	assert.Equal(t, []string{"open"}, specs[0].Values())
+	assert.Equal(t, "status:eq:open,age:gt:30,tag:in:a|b,at:lt:12:30", specs.String())
+
+	for _, value := range []string{"status", "status:eq", ":eq:open", "status:is:open", `[1]`} {
+		_, err := ParseFilter(value, nil)
+		assert.ErrorIs(t, err, ErrInvalidFilter, value)
+	}
+
+	fields := &QueryFields{Filterable: map[string][]FilterOp{
+		"status": {FilterEq, FilterIn},
+		"name":   nil,
+	}}
+	_, err = ParseFilter("status:in:open|closed,name:like:a*", fields)
+	assert.NoError(t, err)
+	_, err = ParseFilter("status:ne:open", fields)
+	assert.ErrorIs(t, err, ErrFilterOpNotAllowed)
+	_, err = ParseFilter("owner:eq:me", fields)
+	assert.ErrorIs(t, err, ErrFieldNotFilterable)
+}
+
+func TestHTTPRequestParser_SortFilter(t *testing.T) {
+	RegisterQueryFields("sort_filter_test", QueryFields{
+		Sortable:   []string{"created_at", "name"},
+		Filterable: map[string][]FilterOp{"status": {FilterEq}},
+	})
+	t.Cleanup(func() { UnregisterQueryFields("sort_filter_test") })
+
+	type ListIssues struct {
+		Sort   SortSpecs   `query:"sort,allow=sort_filter_test,omitempty" default:"-created_at"`
+		Filter FilterSpecs `query:"filter,allow=sort_filter_test,omitempty" default:"status:eq:open"`
+	}
+
+	parser := NewHTTPRequestParser()
+
+	var list ListIssues
+	require.NoError(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/issues?sort=+name,-created_at&filter=status:eq:closed", nil), &list))
+	assert.Equal(t, ListIssues{
+		Sort:   SortSpecs{{Field: "name"}, {Field: "created_at", Desc: true}},
+		Filter: FilterSpecs{{Field: "status", Op: FilterEq, Value: "closed"}},
+	}, list)
+
+	list = ListIssues{}
+	require.NoError(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/issues", nil), &list))
+	assert.Equal(t, SortSpecs{{Field: "created_at", Desc: true}}, list.Sort)
+	assert.Equal(t, FilterSpecs{{Field: "status", Op: FilterEq, Value: "open"}}, list.Filter)
+
+	err := parser.Parse(newTestRequest(t, "GET", "http://example.com/issues?sort=password", nil), &ListIssues{})
+	assert.ErrorIs(t, err, ErrFieldNotSortable)
+
+	err = parser.Parse(newTestRequest(t, "GET", "http://example.com/issues?filter=status:ne:open", nil), &ListIssues{})
+	assert.ErrorIs(t, err, ErrFilterOpNotAllowed)
+
+	t.Run("EmptyDefault", func(t *testing.T) {
+		var list struct {
+			Filter FilterSpecs `query:"filter,allow=sort_filter_test,omitempty" default:""`
+		}
+		require.NoError(t, parser.Parse(newTestRequest(t, "GET", "http://example.com/issues", nil), &list))
+		assert.Nil(t, list.Filter)
+	})
+
+	t.Run("UnknownFields", func(t *testing.T) {
+		var list struct {
+			Sort SortSpecs `query:"sort,allow=unregistered"`
+		}
+		err := parser.Parse(newTestRequest(t, "GET", "http://example.com/issues?sort=name", nil), &list)
+		assert.ErrorIs(t, err, ErrUnknownQueryFields)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		want := ListIssues{
+			Sort:   SortSpecs{{Field: "name"}},
+			Filter: FilterSpecs{{Field: "status", Op: FilterEq, Value: "open"}},
+		}
+		req, err := NewHTTPRequest("GET", "http://example.com/issues", want)
+		require.NoError(t, err)
+
+		var got ListIssues
+		require.NoError(t, parser.Parse(req, &got))
+		assert.Equal(t, want, got)
+	})
+}
@@ -0,0 +1,75 @@
+package parser
+
+// StepGroup is a run of consecutive steps of a chain whose highest
+// priority bindings share the same name, and thus the same part of the
+// source (e.g. the headers or the JSON body of a request).
+type StepGroup[S any] struct {
+	Binding string          // Name of the first binding of the steps. Empty for struct fields parsed recursively.
+	Steps   []*ParseStep[S] // Steps of the group, in execution order
+}
+
+// Groups returns the steps of the chain as runs of steps with the same
+// binding name, in execution order. Sub-chains are not descended into.
+//
+// Chains built with PCManagerOpts.GroupByBinding have a single group per
+// binding name.
+func (chain *ParseChain[S]) Groups() []StepGroup[S] {
+	var groups []StepGroup[S]
+
+	for current := chain.Head; current != nil; current = current.Next {
+		kind := current.bindingKind()
+
+		if n := len(groups); n > 0 && groups[n-1].Binding == kind {
+			groups[n-1].Steps = append(groups[n-1].Steps, current)
+			continue
+		}
+		groups = append(groups, StepGroup[S]{Binding: kind, Steps: []*ParseStep[S]{current}})
+	}
+
+	return groups
+}
+
+// bindingKind returns the name of the binding the step tries first, or ""
+// if it has none.
+func (step *ParseStep[S]) bindingKind() string {
+	if len(step.Bindings) == 0 {
+		return ""
+	}
+	return step.Bindings[0].Name
+}
+
+// groupStepsByBinding relinks the steps starting at head so that steps
+// with the same binding kind follow each other. Kinds keep the order of
+// their first field, and steps keep their field order within a kind. It
+// returns the new head.
+func groupStepsByBinding[S any](head *ParseStep[S]) *ParseStep[S] {
+	var (
+		kinds []string
+		steps = make(map[string][]*ParseStep[S])
+	)
+
+	for current := head; current != nil; current = current.Next {
+		kind := current.bindingKind()
+		if _, seen := steps[kind]; !seen {
+			kinds = append(kinds, kind)
+		}
+		steps[kind] = append(steps[kind], current)
+	}
+
+	var prev *ParseStep[S]
+	for _, kind := range kinds {
+		for _, step := range steps[kind] {
+			if prev == nil {
+				head = step
+			} else {
+				prev.Next = step
+			}
+			prev = step
+		}
+	}
+	if prev != nil {
+		prev.Next = nil
+	}
+
+	return head
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type groupedRequest struct {
+	ID      string `json:"id"`
+	Page    int    `query:"page"`
+	Agent   string `header:"User-Agent"`
+	Name    string `json:"name"`
+	Limit   int    `query:"limit"`
+	Session string `cookie:"session_id" header:"X-Session,omitempty"`
+	Paging  struct {
+		Page int `query:"page"`
+	}
+	Age int `json:"age"`
+}
+
+func TestParseChain_Groups(t *testing.T) {
+	typ := reflect.TypeFor[groupedRequest]()
+
+	groupNames := func(parser *HTTPRequestParser) ([]string, [][]string) {
+		chain, err := parser.PCMgr.GetParseChain(typ)
+		require.NoError(t, err)
+
+		var (
+			kinds  []string
+			fields [][]string
+		)
+		for _, group := range chain.Groups() {
+			kinds = append(kinds, group.Binding)
+
+			var names []string
+			for _, step := range group.Steps {
+				names = append(names, step.FieldName)
+			}
+			fields = append(fields, names)
+		}
+		return kinds, fields
+	}
+
+	t.Run("FieldOrder", func(t *testing.T) {
+		kinds, _ := groupNames(NewHTTPRequestParser())
+		assert.Equal(t, []string{
+			JsonTagBinding, QueryTagBinding, HeaderTagBinding, JsonTagBinding,
+			QueryTagBinding, CookieTagBinding, "", JsonTagBinding,
+		}, kinds)
+	})
+
+	t.Run("GroupByBinding", func(t *testing.T) {
+		kinds, fields := groupNames(NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{GroupByBinding: true}))
+		assert.Equal(t, []string{JsonTagBinding, QueryTagBinding, HeaderTagBinding, CookieTagBinding, ""}, kinds)
+		assert.Equal(t, [][]string{
+			{"ID", "Name", "Age"},
+			{"Page", "Limit"},
+			{"Agent"},
+			{"Session"},
+			{"Paging"},
+		}, fields)
+	})
+
+	t.Run("SameResult", func(t *testing.T) {
+		var ordered, grouped groupedRequest
+
+		req := createTestRequest(t)
+		req.Header.Set("X-Session", "ignored")
+		require.NoError(t, NewHTTPRequestParser().Parse(req, &ordered))
+		require.NoError(t, NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{GroupByBinding: true}).Parse(req, &grouped))
+
+		assert.Equal(t, ordered, grouped)
+		assert.Equal(t, "abc123", grouped.Session)
+		assert.Equal(t, 1, grouped.Paging.Page)
+		assert.Equal(t, 30, grouped.Age)
+	})
+
+	t.Run("Derive", func(t *testing.T) {
+		parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{GroupByBinding: true})
+		chain, err := parser.PCMgr.GetParseChain(typ)
+		require.NoError(t, err)
+
+		derived, err := chain.WithDefault("Age", "40")
+		require.NoError(t, err)
+		assert.Len(t, derived.Groups(), len(chain.Groups()))
+	})
+}
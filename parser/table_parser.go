@@ -0,0 +1,215 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrEmptyTable = errors.New("table has no header row")
+)
+
+var (
+	// Default TableRowParser Binding Options
+	_tableTagOpts = ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				ColumnTagBinding,
+			},
+			CustomBindingModifiers: []string{},
+			ValueModifiers: map[string]ModifierValueParser{
+				CurrencyBindingModifier: parseCurrencyModifier,
+			},
+		},
+		AllowedTagOptionals: []string{},
+	}
+
+	// Default TableRowParser Options
+	_tableParserOpts = BaseMBParserOpts{
+		UseCache: false,
+		PCMOpts: PCManagerOpts{
+			tagOpts: _tableTagOpts,
+		},
+	}
+)
+
+// TableRow is a row of a table whose first row names its columns, such as
+// a CSV file or a spreadsheet.
+type TableRow struct {
+	Header []string // Names of the columns
+	Cells  []string // Cells of the row, in the order of Header
+}
+
+// Column returns the index of the column named name, matched ignoring
+// case and surrounding spaces, or -1.
+func (row *TableRow) Column(name string) int {
+	name = strings.TrimSpace(name)
+	for i, header := range row.Header {
+		if strings.EqualFold(strings.TrimSpace(header), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// TableRowParser parses a TableRow:
+//   - column:'<name,[modifiers]>'`: Parses the cell of the column named
+//     name. Empty cells, like missing columns, are not found.
+//
+// Use ParseTable to parse all rows of a table.
+type TableRowParser struct {
+	*BaseMBParser[TableRow, struct{}]
+}
+
+func NewTableRowParser() *TableRowParser {
+	return &TableRowParser{
+		BaseMBParser: NewBaseMBParser(&TableBindingManager{}, _tableParserOpts),
+	}
+}
+
+func (tp *TableRowParser) Name() string {
+	return TableRowParserName
+}
+
+// TableBindingManager gets the values of column bindings from a TableRow.
+// See TableRowParser.
+type TableBindingManager struct{}
+
+func (mgr *TableBindingManager) BindingHandler(source *TableRow, binding Binding) BindingResult {
+	column := source.Column(binding.Identifier)
+	if column < 0 || column >= len(source.Cells) || source.Cells[column] == "" {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(source.Cells[column])
+}
+
+func (mgr *TableBindingManager) BindingHandlerCached(
+	source *TableRow, _ *CacheEntry[struct{}], binding Binding,
+) BindingResult {
+	return mgr.BindingHandler(source, binding)
+}
+
+func (mgr *TableBindingManager) NewCached() struct{} {
+	return struct{}{}
+}
+
+// CellError is returned by ParseTable for every row that failed to parse
+// or validate, addressed like a spreadsheet cell.
+type CellError struct {
+	Row    int    // Number of the row, counting the header row as 1
+	Column int    // Index of the column, or -1 if the error is not about a single column
+	Header string // Name of the column
+	Err    error  // Underlying cause
+}
+
+func (e *CellError) Error() string {
+	if e.Column < 0 {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %d, column %s (%s): %s", e.Row, columnLetters(e.Column), e.Header, e.Err)
+}
+
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// _tableRowParser parses the rows of ParseTable.
+var _tableRowParser = sync.OnceValue(NewTableRowParser)
+
+// ParseTable parses every row of rows but the first, which names the
+// columns, into a T with the column bindings of its fields. Rows are
+// validated like by the ParserRegistry: their validate tags are checked
+// (see SetTagValidator), then their Validate method if *T is
+// Validatable. The rows of excelize (File.GetRows) and encoding/csv
+// (Reader.ReadAll) are tables:
+//
+//	type Contact struct {
+//		Name  string `column:"Name"`
+//		Email string `column:"E-mail" validate:"required"`
+//		Age   int    `column:"Age,omitempty" default:"0"`
+//	}
+//
+//	contacts, err := pave.ParseTable[Contact](rows)
+//
+// It returns one T per row, in order, and the CellErrors of the rows that
+// failed joined together, so that imports can report every invalid row at
+// once. Past DefaultMaxErrors failed rows, the rest are summarized by a
+// *MoreErrors.
+func ParseTable[T any](rows [][]string) ([]T, error) {
+	if len(rows) == 0 {
+		return nil, ErrEmptyTable
+	}
+
+	var (
+		header = rows[0]
+		parser = _tableRowParser()
+		parsed = make([]T, len(rows)-1)
+		errs   []error
+		limit  = &ErrorLimit{Max: DefaultMaxErrors}
+	)
+	for i, cells := range rows[1:] {
+		row := &TableRow{Header: header, Cells: cells}
+
+		err := parser.Parse(row, &parsed[i])
+		if err == nil {
+			err = validateTags(&parsed[i], nil, DefaultMaxErrors)
+		}
+		if err == nil {
+			if validatable, ok := any(&parsed[i]).(Validatable); ok {
+				err = validatable.Validate()
+			}
+		}
+		if err != nil && limit.Collect() {
+			errs = append(errs, tableCellError(reflect.TypeFor[T](), row, i+2, err))
+		}
+	}
+	return parsed, errors.Join(append(errs, limit.More())...)
+}
+
+// tableCellError returns the CellError of err, the error of the row with
+// number number, addressed at the column of the field it is about.
+func tableCellError(typ reflect.Type, row *TableRow, number int, err error) *CellError {
+	cellErr := &CellError{Row: number, Column: -1, Err: err}
+
+	path := FieldPath(err)
+	if path == "" {
+		return cellErr
+	}
+
+	var field reflect.StructField
+	for _, name := range strings.Split(path, ".") {
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return cellErr
+		}
+
+		var ok bool
+		if field, ok = typ.FieldByName(name); !ok {
+			return cellErr
+		}
+		typ = field.Type
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get(ColumnTagBinding), CommaDelimeter)
+	if column := row.Column(name); name != "" && column >= 0 {
+		cellErr.Column = column
+		cellErr.Header = row.Header[column]
+	}
+	return cellErr
+}
+
+// columnLetters returns the spreadsheet name of the column at index, e.g.
+// "A" for 0 and "AB" for 27.
+func columnLetters(index int) string {
+	var letters []byte
+	for index >= 0 {
+		letters = append([]byte{byte('A' + index%26)}, letters...)
+		index = index/26 - 1
+	}
+	return string(letters)
+}
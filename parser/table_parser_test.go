@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tableContact struct {
+	Name  string `column:"Name"`
+	Email string `column:"E-mail"`
+	Age   int    `column:"Age,omitempty" default:"0"`
+}
+
+func (c *tableContact) Validate() error {
+	if c.Age > 150 {
+		return errors.New("implausible age")
+	}
+	return nil
+}
+
+func TestParseTable(t *testing.T) {
+	rows, err := csv.NewReader(strings.NewReader(strings.Join([]string{
+		" name ,E-MAIL,Age",
+		"Ada,ada@example.com,36",
+		"Bob,bob@example.com,",
+		"Eve,eve@example.com,old",
+		"Mallory,,40",
+		"Trent,trent@example.com,200",
+	}, "\n"))).ReadAll()
+	require.NoError(t, err)
+
+	contacts, err := ParseTable[tableContact](rows)
+	require.Len(t, contacts, 5)
+	assert.Equal(t, tableContact{Name: "Ada", Email: "ada@example.com", Age: 36}, contacts[0])
+	assert.Equal(t, tableContact{Name: "Bob", Email: "bob@example.com"}, contacts[1])
+
+	var cellErrs []*CellError
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var cellErr *CellError
+		require.ErrorAs(t, err, &cellErr)
+		cellErrs = append(cellErrs, cellErr)
+	}
+	require.Len(t, cellErrs, 3)
+
+	assert.Equal(t, 4, cellErrs[0].Row)
+	assert.Equal(t, 2, cellErrs[0].Column)
+	assert.Equal(t, "Age", cellErrs[0].Header)
+	assert.Contains(t, cellErrs[0].Error(), "row 4, column C (Age)")
+
+	assert.Equal(t, 5, cellErrs[1].Row)
+	assert.Equal(t, 1, cellErrs[1].Column)
+	assert.Equal(t, "E-MAIL", cellErrs[1].Header)
+
+	assert.Equal(t, 6, cellErrs[2].Row)
+	assert.Equal(t, -1, cellErrs[2].Column)
+	assert.EqualError(t, cellErrs[2], "row 6: implausible age")
+
+	_, err = ParseTable[tableContact](nil)
+	assert.ErrorIs(t, err, ErrEmptyTable)
+
+	contacts, err = ParseTable[tableContact]([][]string{{"Name", "E-mail"}})
+	assert.NoError(t, err)
+	assert.Empty(t, contacts)
+}
+
+func TestTableRowParser(t *testing.T) {
+	row := &TableRow{Header: []string{"Name", "E-mail"}, Cells: []string{"Ada"}}
+
+	var contact tableContact
+	err := NewTableRowParser().Parse(row, &contact)
+	assert.ErrorIs(t, err, ErrRequiredFieldNotFound, "short rows miss their last cells")
+}
+
+func TestColumnLetters(t *testing.T) {
+	for index, letters := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, letters, columnLetters(index))
+	}
+}
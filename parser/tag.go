@@ -1,4 +1,4 @@
-package pave
+package parser
 
 import (
 	"errors"
@@ -16,9 +16,70 @@ var (
 	ErrInvalidBindingTagFormat  = errors.New("invalid binding tag format")
 	ErrInvalidBindingInfoFormat = errors.New("invalid binding info format")
 	ErrUnallowedBindingModifier = errors.New("binding modifier is not allowed")
-	ErrEmptyTagValue            = errors.New("tag value cannot be empty for non-string types")
+	ErrEmptyTagValue            = errors.New("tag value cannot be empty for types without an empty value")
+	ErrEmptyPartTag             = errors.New("part tag cannot be empty")
+	ErrInvalidModifierValue     = errors.New("invalid binding modifier value")
+	ErrDuplicateBinding         = errors.New("binding is duplicated")
+	ErrFlattenNotStruct         = errors.New("flatten modifier only applies to nested struct fields")
+	ErrScopeNotStruct           = errors.New("scope modifier only applies to nested struct fields")
+	ErrMaxNotRange              = errors.New("max modifier only applies to range bindings and Range fields")
 )
 
+// TagIssue is a single invalid tag found while building a parse chain.
+type TagIssue struct {
+	Field string // Dotted path of the struct field with the invalid tag
+	Err   error  // What is wrong with the tag
+}
+
+// TagReport is returned when a parse chain cannot be built because of
+// invalid tags. It lists every invalid field, tag and modifier of the
+// struct type and its nested structs, so that all of them can be fixed in
+// one pass. errors.Is and errors.As match against each issue.
+type TagReport struct {
+	StructType reflect.Type // The struct type the chain was built for
+	Issues     []TagIssue   // All invalid tags, in field order
+}
+
+func (r *TagReport) Error() string {
+	issues := make([]string, len(r.Issues))
+	for i, issue := range r.Issues {
+		issues[i] = issue.Field + ": " + issue.Err.Error()
+	}
+
+	return fmt.Sprintf(
+		"invalid tags for %s (%d issues): %s",
+		r.StructType, len(r.Issues), strings.Join(issues, "; "),
+	)
+}
+
+func (r *TagReport) Unwrap() []error {
+	errs := make([]error, len(r.Issues))
+	for i, issue := range r.Issues {
+		errs[i] = issue.Err
+	}
+	return errs
+}
+
+// Transient reports false: invalid tags fail every parse into the struct
+// type. See IsTransient.
+func (r *TagReport) Transient() bool {
+	return false
+}
+
+// addTagIssues records err as an issue of field. Issues of a nested
+// TagReport are flattened into issues with a path below field.
+func addTagIssues(issues []TagIssue, field string, err error) []TagIssue {
+	var report *TagReport
+	if !errors.As(err, &report) {
+		return append(issues, TagIssue{Field: field, Err: err})
+	}
+
+	for _, issue := range report.Issues {
+		issues = append(issues, TagIssue{Field: field + "." + issue.Field, Err: issue.Err})
+	}
+	return issues
+}
+
 // This file contains the tag parser for the pave package. It is responsible
 // for parsing the tags associated with fields in structs that are used for
 // parsing and validation. The tag parser interprets the tags and generates
@@ -48,14 +109,16 @@ var (
 // binding_modifier_list:
 //     [<binding_modifier>]^* // Delimited with "," end-delim optional
 // binding_modifier:
-//     omitempty | omiterror | omitnil | <modifier_custom>
+//     omitempty | omiterror | omitnil | allowdup | flatten | <modifier_custom> | <modifier_value>
 // modifier_custom:
 //    <parser_specific>
+// modifier_value:
+//    <parser_specific>=<string> // Value may not contain ","
 //
 // optional_tag_list:
 //    [<optional_tag>]^*
 // optional_tag:
-//     <default_tag> | <recursive_tag> | <custom_tag>
+//     <default_tag> | <recursive_tag> | <part_tag> | <use_tag> | <custom_tag>
 // custom_tag:
 //     <parser_specific>
 //
@@ -65,6 +128,12 @@ var (
 // recursive_tag:
 //     recursive:"<bool>"
 //
+// part_tag:
+//     part:"<string>"
+//
+// use_tag:
+//     use:"<profile_name>[,<profile_name>]^*" // See RegisterProfile
+//
 // validate_tag
 //     validate:"<...>" | nil
 
@@ -74,17 +143,23 @@ Grammar Notes:
     1. Require explicit delineation for non-recursive parsing
     2. Recursive parsing happens by default, non-recursive parsing is indicated
 	   by the tag as `recursive:"false"`
+    3. Non-recursive struct fields are bound as a whole: the sub-document
+       found by their bindings (a JSON subtree, a nested map, ...) is
+       unmarshaled into them with encoding/json in one shot
 */
 
 type ParseTagOpts struct {
 	BindingOpts
 	AllowedTagOptionals []string // List of allowed optional tags
+	Environment         string   // Selects environment variants of tags, see EnvironmentParser
+	Version             string   // Selects version variants of tags, see VersionOpts
 }
 
 type ParseTag struct {
 	bindingTags  []BindingTag
 	defaultTag   DefaultTag
 	recursiveTag RecursiveTag
+	partTag      PartTag
 	customTags   []CustomTag
 }
 
@@ -92,6 +167,7 @@ type ParseTag struct {
 // Example: default:"5"
 type DefaultTag struct {
 	Value string
+	Set   bool // Whether the tag is present, as Value may be empty for strings
 }
 
 // Corresponds to <recursive_tag>
@@ -99,6 +175,12 @@ type RecursiveTag struct {
 	Enabled bool // If true, the field should be recursively parsed
 }
 
+// Corresponds to <part_tag>
+// Example: part:"username"
+type PartTag struct {
+	Name string // The name of the sub-value selected from a multi-value binding
+}
+
 type CustomTag struct {
 	Name  string // The name of the optional tag
 	Value string // The value of the optional tag
@@ -113,6 +195,12 @@ type BindingTag struct {
 }
 
 func DecodeParseTagV2(field reflect.StructField, opts ParseTagOpts) (ParseTag, error) {
+	tag, err := resolvedTag(field, opts)
+	if err != nil {
+		return ParseTag{}, err
+	}
+	field.Tag = tag
+
 	// Get binding list
 	bindingTags, err := decodeBindingTagsV2(field, opts)
 	if err != nil {
@@ -137,21 +225,42 @@ func DecodeParseTagV2(field reflect.StructField, opts ParseTagOpts) (ParseTag, e
 		return ParseTag{}, err
 	}
 
+	// Get part tag
+	partTag, err := decodePartTagV2(field)
+	if err != nil {
+		return ParseTag{}, err
+	}
+
 	return ParseTag{
 		customTags:   customTags,
 		bindingTags:  bindingTags,
 		defaultTag:   defTag,
 		recursiveTag: recTag,
+		partTag:      partTag,
 	}, nil
 }
 
+// resolvedTag returns the tag of field with the tags of the profiles it
+// uses and its variants for the environment and version of opts, see
+// RegisterProfile, EnvironmentParser and VersionOpts.
+func resolvedTag(field reflect.StructField, opts ParseTagOpts) (reflect.StructTag, error) {
+	tag, err := ProfiledTag(field)
+	if err != nil {
+		return "", err
+	}
+	tag = qualifiedTag(tag, opts.Environment)
+	return qualifiedTag(tag, opts.Version), nil
+}
+
 func decodeBindingTagsV2(field reflect.StructField, opts ParseTagOpts) ([]BindingTag, error) {
 	var bindingTags []BindingTag
 
 	for _, name := range opts.AllowedBindingNames {
 		value, ok := field.Tag.Lookup(name)
 
-		if ok {
+		// Like encoding/json, a "-" tag leaves the field unbound, so that
+		// json tags can exclude fields from both parsing and serialization
+		if ok && value != "-" {
 			bindingTag, err := decodeBindingTagV2(name, value, opts.BindingOpts)
 			if err != nil {
 				return []BindingTag{}, fmt.Errorf("error getting binding tag %s for field %s: %w", name, field.Name, err)
@@ -188,10 +297,17 @@ func decodeBindingTagV2(key string, value string, opts BindingOpts) (BindingTag,
 
 	for _, modifier := range modifiers {
 		switch modifier {
-		case OmitEmptyBindingModifier, OmitErrorBindingModifier, OmitNilBindingModifier:
+		case OmitEmptyBindingModifier, OmitErrorBindingModifier, OmitNilBindingModifier, AllowDupBindingModifier,
+			FlattenBindingModifier, ScopeBindingModifier:
 			// These are standard modifiers, no action needed
 			continue
 		default:
+			if name, _, isValue := strings.Cut(modifier, ModifierKeyValueDelimiter); isValue {
+				if _, ok := opts.valueModifierParser(name); !ok {
+					return BindingTag{}, fmt.Errorf("%w: %s", ErrUnallowedBindingModifier, name)
+				}
+				continue
+			}
 			if !slices.Contains(opts.CustomBindingModifiers, modifier) {
 				return BindingTag{}, fmt.Errorf("%w: %s", ErrUnallowedBindingModifier, modifier)
 			}
@@ -222,10 +338,10 @@ func decodeDefaultTagV2(field reflect.StructField) (DefaultTag, error) {
 	if defaultTag, ok := field.Tag.Lookup("default"); ok {
 		// Parse the default tag
 		value := strings.TrimSpace(defaultTag)
-		if value == "" && field.Type.Kind() != reflect.String {
+		if value == "" && !hasEmptyValue(field.Type) {
 			return DefaultTag{}, fmt.Errorf("default %w", ErrEmptyTagValue)
 		}
-		return DefaultTag{Value: value}, nil
+		return DefaultTag{Value: value, Set: true}, nil
 	} else {
 		// If no default tag is found, return an empty DefaultTag
 		return DefaultTag{}, nil
@@ -235,8 +351,9 @@ func decodeDefaultTagV2(field reflect.StructField) (DefaultTag, error) {
 func decodeRecursiveTagV2(field reflect.StructField) (RecursiveTag, error) {
 	var enabled bool
 
-	// Check if the field has a `recursive` tag
-	if field.Type.Kind() == reflect.Struct {
+	// Check if the field has a `recursive` tag. Special struct types
+	// (time.Time, uuid.UUID, ...) are populated as primitives.
+	if _, _, isStruct := recursiveStructType(field.Type); isStruct {
 		if recursiveTag, ok := field.Tag.Lookup("recursive"); ok {
 			// Parse the recursive tag
 			enabled = strings.TrimSpace(recursiveTag) == "true"
@@ -248,6 +365,18 @@ func decodeRecursiveTagV2(field reflect.StructField) (RecursiveTag, error) {
 	return RecursiveTag{Enabled: enabled}, nil
 }
 
+func decodePartTagV2(field reflect.StructField) (PartTag, error) {
+	if partTag, ok := field.Tag.Lookup(PartSubTagPrefix); ok {
+		name := strings.TrimSpace(partTag)
+		if name == "" {
+			return PartTag{}, ErrEmptyPartTag
+		}
+		return PartTag{Name: name}, nil
+	}
+
+	return PartTag{}, nil
+}
+
 // // GetBindings parses the tag string and returns a structured representation of the tag.
 // func GetBindings(field reflect.StructField, opts ParseTagOpts) ([]Binding, string, error) {
 
@@ -389,7 +518,7 @@ func makeBindings(parseTag ParseTag, opts ParseTagOpts) ([]Binding, error) {
 
 	for _, bindingTag := range parseTag.bindingTags {
 
-		binding, err := bindingTag.toBinding(opts.CustomBindingModifiers)
+		binding, err := bindingTag.toBindingWithOpts(opts.BindingOpts)
 		if err != nil {
 			return nil, fmt.Errorf("error creating field binding from tag %s: %w", bindingTag.Name, err)
 		}
@@ -400,6 +529,12 @@ func makeBindings(parseTag ParseTag, opts ParseTagOpts) ([]Binding, error) {
 }
 
 func (t BindingTag) toBinding(customModifiers []string) (Binding, error) {
+	return t.toBindingWithOpts(BindingOpts{CustomBindingModifiers: customModifiers})
+}
+
+// toBindingWithOpts converts the tag to a Binding, parsing key=value
+// modifiers with the ModifierValueParsers of opts.
+func (t BindingTag) toBindingWithOpts(opts BindingOpts) (Binding, error) {
 
 	modifiers := BindingModifiers{}
 	omit := false
@@ -414,10 +549,34 @@ func (t BindingTag) toBinding(customModifiers []string) (Binding, error) {
 		case OmitNilBindingModifier:
 			modifiers.OmitNil = true
 			omit = true
+		case AllowDupBindingModifier:
+			modifiers.AllowDup = true
+		case FlattenBindingModifier:
+			modifiers.Flatten = true
+		case ScopeBindingModifier:
+			modifiers.Scope = true
 		default:
-			if !slices.Contains(customModifiers, modifier) {
+			if name, raw, isValue := strings.Cut(modifier, ModifierKeyValueDelimiter); isValue {
+				parse, ok := opts.valueModifierParser(name)
+				if !ok {
+					return Binding{}, fmt.Errorf("%w: %s", ErrUnallowedBindingModifier, name)
+				}
+
+				value, err := parse(raw)
+				if err != nil {
+					return Binding{}, fmt.Errorf("%w %s: %w", ErrInvalidModifierValue, name, err)
+				}
+
+				if modifiers.Values == nil {
+					modifiers.Values = make(map[string]any)
+				}
+				modifiers.Values[name] = value
+			} else if !slices.Contains(opts.CustomBindingModifiers, modifier) {
 				return Binding{}, fmt.Errorf("%w: %s", ErrUnallowedBindingModifier, modifier)
 			} else {
+				if modifiers.Custom == nil {
+					modifiers.Custom = make(map[string]bool)
+				}
 				modifiers.Custom[modifier] = true
 			}
 		}
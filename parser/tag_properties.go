@@ -0,0 +1,503 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// DefaultPropertyRuns is the number of sources CheckTagProperties
+// generates, see PropertyOpts.
+const DefaultPropertyRuns = 200
+
+var (
+	ErrPropertyViolation = errors.New("tag property violated")
+	ErrChecksumProperty  = errors.New("tag properties cannot be checked for types with checksums")
+)
+
+// BindingState is the state of a binding in a source generated by
+// CheckTagProperties.
+type BindingState int
+
+const (
+	BindingPresent BindingState = iota // Found with a value of the type of the field
+	BindingMissing                     // Not found
+	BindingEmpty                       // Found with an empty value
+	BindingNil                         // Found with a nil value
+	BindingFailed                      // Failed with an error that is not fatal
+)
+
+func (state BindingState) String() string {
+	switch state {
+	case BindingPresent:
+		return "present"
+	case BindingMissing:
+		return "missing"
+	case BindingEmpty:
+		return "empty"
+	case BindingNil:
+		return "nil"
+	case BindingFailed:
+		return "failed"
+	}
+	return fmt.Sprintf("BindingState(%d)", int(state))
+}
+
+// PropertyOpts configures CheckTagProperties. The zero value checks
+// DefaultPropertyRuns sources generated from seed 0.
+type PropertyOpts struct {
+	// Runs is the number of sources generated. Defaults to
+	// DefaultPropertyRuns.
+	Runs int
+	// Seed seeds the generator. Sources, and so violations, are the same
+	// for the same seed.
+	Seed uint64
+	// Values are the values of present bindings by the dotted path of
+	// their field (e.g. "Address.Zip"), for the fields whose type needs a
+	// value the generator does not produce, e.g. an email address.
+	Values map[string]string
+	// MaxErrors caps the violations returned, see DefaultMaxErrors.
+	// Negative values return all of them.
+	MaxErrors int
+}
+
+// PropertyViolation is a field of a generated source that was not parsed
+// the way the modifiers and default of its bindings prescribe.
+type PropertyViolation struct {
+	Run    int                     // Index of the generated source
+	Field  string                  // Dotted path of the field
+	States map[string]BindingState // States of the bindings of the source, keyed by "name:identifier"
+	Want   string                  // Expected outcome
+	Got    string                  // Actual outcome
+}
+
+func (v *PropertyViolation) Error() string {
+	states := make([]string, 0, len(v.States))
+	for _, key := range slices.Sorted(maps.Keys(v.States)) {
+		states = append(states, key+"="+v.States[key].String())
+	}
+	return fmt.Sprintf("run %d: field %s: want %s, got %s (%s)", v.Run, v.Field, v.Want, v.Got, strings.Join(states, " "))
+}
+
+func (v *PropertyViolation) Unwrap() error {
+	return ErrPropertyViolation
+}
+
+// CheckTagProperties parses randomized sources into dest, a pointer to a
+// struct, with the parse chain of parser, and checks that every field is
+// parsed the way the modifiers and default of its bindings prescribe:
+//   - fields whose required bindings are missing, or whose bindings all
+//     failed without a default, fail the parse
+//   - defaults are applied iff the bindings of the field are all omitted
+//     (e.g. omitempty and missing)
+//   - otherwise the field holds the value of its first usable binding
+//
+// Each source sets every binding of the chain to a random BindingState,
+// through the handler of the chain, so that any parser is checked without
+// building actual sources. The violations found are returned joined,
+// ordered by run. Use it in tests to catch regressions of the semantics of
+// modifiers, e.g.
+//
+//	require.NoError(t, pave.CheckTagProperties(parser.BaseMBParser, &Request{}, pave.PropertyOpts{}))
+//
+// Types with checksums (see ChecksumBindingModifier) fail with
+// ErrChecksumProperty, as random values do not match their digests.
+func CheckTagProperties[S, C any](parser *BaseMBParser[S, C], dest any, opts PropertyOpts) error {
+	if err := checkDest(dest); err != nil {
+		return err
+	}
+
+	typ := reflect.TypeOf(dest).Elem()
+	chain, err := parser.PCMgr.GetParseChain(typ)
+	if err != nil {
+		return err
+	}
+
+	checker := &propertyChecker[S]{opts: opts}
+	if err := checker.collect(chain, nil, ""); err != nil {
+		return err
+	}
+
+	runs := opts.Runs
+	if runs == 0 {
+		runs = DefaultPropertyRuns
+	}
+
+	limit := &ErrorLimit{Max: resolveMaxErrors(opts.MaxErrors)}
+	var errs []error
+	for run := range runs {
+		for _, violation := range checker.check(chain, typ, run) {
+			if limit.Collect() {
+				errs = append(errs, violation)
+			}
+		}
+	}
+	if more := limit.More(); more != nil {
+		errs = append(errs, more)
+	}
+	return errors.Join(errs...)
+}
+
+// propertyKey identifies the binding of a part of a value, so that steps
+// sharing it are handed the same value.
+type propertyKey struct {
+	binding    string
+	identifier string
+	part       string
+}
+
+// propertyStep is a step populating a field, in execution order.
+type propertyStep[S any] struct {
+	step    *ParseStep[S]
+	path    string       // Dotted path of the field
+	index   []int        // Field indexes from the destination struct down to the field
+	typ     reflect.Type // Type of the field
+	checked bool         // Whether the value of the field is compared
+}
+
+// propertyChecker generates the sources of CheckTagProperties and predicts
+// how their fields are parsed.
+type propertyChecker[S any] struct {
+	opts   PropertyOpts
+	steps  []propertyStep[S]
+	keys   []propertyKey          // Keys of the bindings, in order of appearance
+	values map[propertyKey]string // Values of present bindings
+}
+
+// collect adds the steps of chain and its sub-chains, whose fields are at
+// index and path below the destination struct.
+func (checker *propertyChecker[S]) collect(chain *ParseChain[S], index []int, path string) error {
+	if len(chain.Checksums) > 0 {
+		return fmt.Errorf("%w: %s", ErrChecksumProperty, chain.StructType)
+	}
+	if checker.values == nil {
+		checker.values = make(map[propertyKey]string)
+	}
+
+	for step := chain.Head; step != nil; step = step.Next {
+		stepIndex := append(slices.Clip(index), step.FieldIndex)
+		stepPath := path + step.FieldName
+
+		if step.IsStruct && step.ShouldRecurse {
+			if step.SubChain == nil {
+				continue
+			}
+			if err := checker.collect(step.SubChain, stepIndex, stepPath+"."); err != nil {
+				return err
+			}
+			continue
+		}
+
+		field := chain.StructType.Field(step.FieldIndex)
+		if !field.IsExported() && !step.Unexported && !step.Setter.IsValid() {
+			// Left alone by the executor
+			continue
+		}
+
+		checker.steps = append(checker.steps, propertyStep[S]{
+			step:    step,
+			path:    stepPath,
+			index:   stepIndex,
+			typ:     field.Type,
+			checked: field.IsExported(),
+		})
+		for _, binding := range step.Bindings {
+			key := propertyKey{binding: binding.Name, identifier: binding.Identifier, part: step.Part}
+			if _, exists := checker.values[key]; exists {
+				continue
+			}
+
+			value, ok := checker.opts.Values[stepPath]
+			if !ok {
+				value = samplePropertyValue(field.Type)
+			}
+			checker.keys = append(checker.keys, key)
+			checker.values[key] = value
+		}
+	}
+	return nil
+}
+
+// check parses the source of run into a new value of typ and returns the
+// violations found.
+func (checker *propertyChecker[S]) check(chain *ParseChain[S], typ reflect.Type, run int) []error {
+	random := rand.New(rand.NewPCG(checker.opts.Seed, uint64(run)))
+
+	states := make(map[propertyKey]BindingState, len(checker.keys))
+	for _, key := range checker.keys {
+		states[key] = BindingState(random.IntN(int(BindingFailed) + 1))
+	}
+
+	handler := func(_ *S, binding Binding) BindingResult {
+		return checker.result(states, binding)
+	}
+
+	dest := reflect.New(typ)
+	err := chain.execute(new(S), dest.Interface(), execution[S]{handler: handler}, "")
+
+	// Fields are populated in order until the first one that fails
+	var violations []error
+	violation := func(path, want, got string) {
+		violations = append(violations, &PropertyViolation{
+			Run: run, Field: path, States: stateNames(states), Want: want, Got: got,
+		})
+	}
+
+	for _, pstep := range checker.steps {
+		outcome := checker.predict(pstep, states)
+
+		if outcome.err {
+			switch {
+			case err == nil:
+				violation(pstep.path, "an error", "none")
+			case FieldPath(err) != pstep.path:
+				violation(pstep.path, "an error", fmt.Sprintf("an error of field %s: %v", FieldPath(err), err))
+			}
+			return violations
+		}
+
+		if FieldPath(err) == pstep.path {
+			violation(pstep.path, "no error", err.Error())
+			return violations
+		}
+
+		if pstep.checked {
+			actual, ok := propertyField(dest.Elem(), pstep.index)
+			if ok && !reflect.DeepEqual(actual.Interface(), outcome.value.Interface()) {
+				violation(pstep.path, outcome.describe(), formatPropertyValue(actual))
+			}
+		}
+	}
+
+	if err != nil {
+		violation(FieldPath(err), "no error", err.Error())
+	}
+	return violations
+}
+
+// result returns the result of binding in a source with the given states.
+func (checker *propertyChecker[S]) result(states map[propertyKey]BindingState, binding Binding) BindingResult {
+	result := BindingResultNotFound()
+
+	for _, key := range checker.keys {
+		if key.binding != binding.Name || key.identifier != binding.Identifier {
+			continue
+		}
+
+		var value any
+		switch states[key] {
+		case BindingMissing:
+			continue
+		case BindingFailed:
+			return BindingResultError(fmt.Errorf("%w: generated failure", ErrPropertyViolation))
+		case BindingPresent:
+			value = checker.values[key]
+		case BindingEmpty:
+			value = ""
+		}
+
+		result.Found = true
+		if key.part == "" {
+			result.Value = value
+			continue
+		}
+		if result.Values == nil {
+			result.Values = make(map[string]any)
+		}
+		result.Values[key.part] = value
+	}
+	return result
+}
+
+// propertyOutcome is the predicted outcome of a step.
+type propertyOutcome struct {
+	err     bool          // Whether the step fails
+	value   reflect.Value // Value of the field
+	binding string        // Binding the value is from, empty for defaults and zero values
+}
+
+// describe describes the expected value of the field.
+func (outcome propertyOutcome) describe() string {
+	switch outcome.binding {
+	case "":
+		return formatPropertyValue(outcome.value)
+	default:
+		return fmt.Sprintf("%s from %s", formatPropertyValue(outcome.value), outcome.binding)
+	}
+}
+
+// predict returns the outcome of step in a source with the given states,
+// following the rules of ParseChain.doStepRegular.
+func (checker *propertyChecker[S]) predict(pstep propertyStep[S], states map[propertyKey]BindingState) propertyOutcome {
+	var (
+		step         = pstep.step
+		failed       bool
+		allOmitEmpty = true
+		allOmitError = true
+		allOmitNil   = true
+	)
+
+	for _, binding := range step.Bindings {
+		modifiers := binding.Modifiers
+		allOmitEmpty = allOmitEmpty && modifiers.OmitEmpty
+		allOmitError = allOmitError && modifiers.OmitError
+		allOmitNil = allOmitNil && modifiers.OmitNil
+
+		key := propertyKey{binding: binding.Name, identifier: binding.Identifier, part: step.Part}
+		switch states[key] {
+		case BindingFailed:
+			if modifiers.OmitError {
+				continue
+			}
+			failed = true
+			if modifiers.Required {
+				return propertyOutcome{err: true}
+			}
+			continue
+
+		case BindingPresent, BindingEmpty:
+			value := ""
+			if states[key] == BindingPresent {
+				value = checker.values[key]
+			}
+			field, err := propertyBindingValue(pstep.typ, step.formatValue(value), binding)
+			if err != nil {
+				return propertyOutcome{err: true}
+			}
+			return propertyOutcome{value: field, binding: binding.Name + ":" + binding.Identifier}
+
+		case BindingNil:
+			if modifiers.OmitNil {
+				continue
+			}
+		}
+
+		if modifiers.Required {
+			return propertyOutcome{err: true}
+		}
+	}
+
+	if allOmitEmpty || allOmitError || allOmitNil {
+		if step.DefaultValue != "" || step.HasDefault {
+			field := reflect.New(pstep.typ).Elem()
+			if err := setFieldValueWithModifiers(field, step.DefaultValue, step.defaultModifiers()); err != nil {
+				return propertyOutcome{err: true}
+			}
+			return propertyOutcome{value: field}
+		}
+		if !IsOptType(pstep.typ) {
+			return propertyOutcome{err: true}
+		}
+	}
+
+	if failed {
+		return propertyOutcome{err: true}
+	}
+	return propertyOutcome{value: reflect.New(pstep.typ).Elem()}
+}
+
+// propertyBindingValue returns a value of typ set from value the way
+// binding populates its field.
+func propertyBindingValue(typ reflect.Type, value string, binding Binding) (reflect.Value, error) {
+	field := reflect.New(typ).Elem()
+
+	raw, err := transformValue(value, binding)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if err := setFieldValueWithModifiers(field, raw, binding.Modifiers); err != nil {
+		return reflect.Value{}, err
+	}
+	markOptSet(field)
+	return field, nil
+}
+
+// propertyField returns the field of dest at index, following pointers to
+// structs. It reports false if a pointer on the way is nil.
+func propertyField(dest reflect.Value, index []int) (reflect.Value, bool) {
+	for i, fieldIndex := range index {
+		if i > 0 && dest.Kind() == reflect.Ptr {
+			if dest.IsNil() {
+				return reflect.Value{}, false
+			}
+			dest = dest.Elem()
+		}
+		dest = dest.Field(fieldIndex)
+	}
+	return dest, true
+}
+
+// samplePropertyValue returns a value that fields of typ are set from.
+func samplePropertyValue(typ reflect.Type) string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if IsOptType(typ) {
+		return samplePropertyValue(typ.Field(0).Type)
+	}
+
+	switch typ {
+	case UUIDType:
+		return "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	case TimeType:
+		return "2001-02-03T04:05:06Z"
+	case DurationType:
+		return "1m30s"
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return "true"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "42"
+	case reflect.Float32, reflect.Float64:
+		return "2.5"
+	case reflect.Complex64, reflect.Complex128:
+		return "1+2i"
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "pave"
+		}
+		return samplePropertyValue(typ.Elem())
+	case reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return strings.Repeat("ab", typ.Len())
+		}
+		elems := make([]string, typ.Len())
+		for i := range elems {
+			elems[i] = samplePropertyValue(typ.Elem())
+		}
+		return strings.Join(elems, CommaDelimeter)
+	}
+	return "pave"
+}
+
+// formatPropertyValue formats the value of a field for a violation.
+func formatPropertyValue(value reflect.Value) string {
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.IsZero() {
+		return fmt.Sprintf("zero %s", value.Type())
+	}
+	return fmt.Sprintf("%#v", value.Interface())
+}
+
+// stateNames returns the states of the bindings keyed by "name:identifier",
+// with the part if any.
+func stateNames(states map[propertyKey]BindingState) map[string]BindingState {
+	names := make(map[string]BindingState, len(states))
+	for key, state := range states {
+		name := key.binding + ":" + key.identifier
+		if key.part != "" {
+			name += "#" + key.part
+		}
+		names[name] = state
+	}
+	return names
+}
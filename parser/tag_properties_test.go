@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type propertyAddress struct {
+	City string `query:"city,omitempty" default:"Oslo"`
+	Zip  *int   `query:"zip,omitnil"`
+}
+
+type propertyRequest struct {
+	Name     string           `query:"name" header:"X-Name,omitempty"`
+	Age      int              `query:"age,omitempty" default:"18"`
+	Admin    bool             `query:"admin,omiterror"`
+	Score    float64          `query:"score,omitempty,omiterror" header:"X-Score,omitempty" default:"0.5"`
+	ID       uuid.UUID        `header:"X-ID,omitempty"`
+	Since    time.Time        `query:"since,omitnil" default:"2000-01-01T00:00:00Z"`
+	Tags     []string         `query:"tags,omitempty"`
+	Limit    Opt[int]         `query:"limit,omitempty"`
+	Title    Opt[string]      `query:"title,omitempty" default:"none"`
+	Email    string           `query:"email,omitempty"`
+	Address  propertyAddress  `query:"address_"`
+	Location *propertyAddress `query:"location_"`
+}
+
+// breakingCompiler compiles chains into executors that reset the Age
+// field after interpreting them, so that its default is never applied.
+func breakingCompiler() ChainCompiler[http.Request] {
+	return ChainCompilerFunc[http.Request](func(chain *ParseChain[http.Request]) (ChainExecutor[http.Request], error) {
+		return ChainExecutorFunc[http.Request](func(source *http.Request, dest any, handler BindingHandlerFunc[http.Request]) error {
+			err := chain.Interpret(source, dest, handler)
+			if req, ok := dest.(*propertyRequest); ok {
+				req.Age = 0
+			}
+			return err
+		}), nil
+	})
+}
+
+func TestCheckTagProperties(t *testing.T) {
+	t.Run("Holds", func(t *testing.T) {
+		parser := NewHTTPRequestParser()
+
+		err := CheckTagProperties(parser.BaseMBParser, &propertyRequest{}, PropertyOpts{
+			Values: map[string]string{"Email": "jane@example.com"},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Seeds", func(t *testing.T) {
+		parser := NewHTTPRequestParser()
+
+		for seed := range uint64(5) {
+			err := CheckTagProperties(parser.BaseMBParser, &propertyRequest{}, PropertyOpts{Seed: seed, Runs: 50})
+			assert.NoError(t, err, "seed %d", seed)
+		}
+	})
+
+	t.Run("Violated", func(t *testing.T) {
+		parser := NewHTTPRequestParser()
+		parser.SetChainCompiler(breakingCompiler())
+
+		err := CheckTagProperties(parser.BaseMBParser, &propertyRequest{}, PropertyOpts{MaxErrors: -1})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrPropertyViolation)
+
+		var violation *PropertyViolation
+		require.ErrorAs(t, err, &violation)
+		assert.Equal(t, "Age", violation.Field)
+		assert.Contains(t, violation.Error(), "query:age=")
+
+		again := CheckTagProperties(parser.BaseMBParser, &propertyRequest{}, PropertyOpts{MaxErrors: -1})
+		assert.Equal(t, err.Error(), again.Error(), "same seed, same sources")
+	})
+
+	t.Run("MaxErrors", func(t *testing.T) {
+		parser := NewHTTPRequestParser()
+		parser.SetChainCompiler(breakingCompiler())
+
+		err := CheckTagProperties(parser.BaseMBParser, &propertyRequest{}, PropertyOpts{MaxErrors: 2})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrTooManyErrors)
+		assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+	})
+
+	t.Run("Checksum", func(t *testing.T) {
+		type checksummed struct {
+			File   []byte `query:"file,checksum=crc32:Digest"`
+			Digest string `query:"digest"`
+		}
+		parser := NewHTTPRequestParser()
+
+		err := CheckTagProperties(parser.BaseMBParser, &checksummed{}, PropertyOpts{})
+		assert.ErrorIs(t, err, ErrChecksumProperty)
+	})
+
+	t.Run("InvalidDest", func(t *testing.T) {
+		parser := NewHTTPRequestParser()
+
+		err := CheckTagProperties(parser.BaseMBParser, propertyRequest{}, PropertyOpts{})
+		assert.Error(t, err)
+	})
+}
+
+func TestBindingState_String(t *testing.T) {
+	assert.Equal(t, "present", BindingPresent.String())
+	assert.Equal(t, "failed", BindingFailed.String())
+	assert.Equal(t, "BindingState(9)", BindingState(9).String())
+}
+
+func TestSamplePropertyValue(t *testing.T) {
+	tests := []struct {
+		typ  reflect.Type
+		want string
+	}{
+		{reflect.TypeFor[int](), "42"},
+		{reflect.TypeFor[*Opt[bool]](), "true"},
+		{reflect.TypeFor[[]float64](), "2.5"},
+		{reflect.TypeFor[[2]uint](), "42,42"},
+		{reflect.TypeFor[[2]byte](), "abab"},
+		{reflect.TypeFor[time.Time](), "2001-02-03T04:05:06Z"},
+		{reflect.TypeFor[string](), "pave"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typ.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, samplePropertyValue(tt.typ))
+		})
+	}
+}
@@ -1,4 +1,4 @@
-package pave
+package parser
 
 import (
 	"reflect"
@@ -160,6 +160,29 @@ func TestDecodeRecursiveTagV2(t *testing.T) {
 	})
 }
 
+func TestDecodePartTagV2(t *testing.T) {
+	t.Run("WithPartTag", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string `basicauth:"Authorization" part:"username"`
+		}
+
+		field := reflect.TypeOf(TestStruct{}).Field(0)
+		tag, err := decodePartTagV2(field)
+		require.NoError(t, err)
+		assert.Equal(t, "username", tag.Name)
+	})
+
+	t.Run("EmptyPartTag", func(t *testing.T) {
+		type TestStruct struct {
+			Field1 string `part:""`
+		}
+
+		field := reflect.TypeOf(TestStruct{}).Field(0)
+		_, err := decodePartTagV2(field)
+		assert.ErrorIs(t, err, ErrEmptyPartTag)
+	})
+}
+
 func TestMakeBindings(t *testing.T) {
 	t.Run("BasicBinding", func(t *testing.T) {
 		parseTag := ParseTag{
@@ -248,4 +271,68 @@ func TestBindingTag_toBinding(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, binding.Modifiers.Required)
 	})
+
+	t.Run("CustomModifier", func(t *testing.T) {
+		tag := BindingTag{
+			Name:       "json",
+			Identifier: "field1",
+			Modifiers:  []string{"trim"},
+		}
+
+		binding, err := tag.toBinding([]string{"trim"})
+		require.NoError(t, err)
+		assert.True(t, binding.Modifiers.Custom["trim"])
+	})
+}
+
+func TestBindingTag_toBindingWithOpts(t *testing.T) {
+	opts := BindingOpts{
+		ValueModifiers: map[string]ModifierValueParser{
+			"layout": TypedModifier[string](),
+			"max":    TypedModifier[int](),
+		},
+	}
+
+	t.Run("ValueModifiers", func(t *testing.T) {
+		tag := BindingTag{
+			Name:       "query",
+			Identifier: "since",
+			Modifiers:  []string{"layout=2006-01-02", "max=10", OmitEmptyBindingModifier},
+		}
+
+		binding, err := tag.toBindingWithOpts(opts)
+		require.NoError(t, err)
+		assert.False(t, binding.Modifiers.Required)
+
+		layout, ok := ModifierValue[string](binding, "layout")
+		assert.True(t, ok)
+		assert.Equal(t, "2006-01-02", layout)
+
+		maxValue, ok := ModifierValue[int](binding, "max")
+		assert.True(t, ok)
+		assert.Equal(t, 10, maxValue)
+
+		_, ok = ModifierValue[string](binding, "max")
+		assert.False(t, ok, "wrong type")
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		tag := BindingTag{Name: "query", Identifier: "n", Modifiers: []string{"max=ten"}}
+
+		_, err := tag.toBindingWithOpts(opts)
+		assert.ErrorIs(t, err, ErrInvalidModifierValue)
+	})
+
+	t.Run("UndeclaredValueModifier", func(t *testing.T) {
+		tag := BindingTag{Name: "query", Identifier: "n", Modifiers: []string{"sep=;"}}
+
+		_, err := tag.toBindingWithOpts(opts)
+		assert.ErrorIs(t, err, ErrUnallowedBindingModifier)
+
+		_, err = decodeBindingTagV2("query", "n,sep=;", opts)
+		assert.ErrorIs(t, err, ErrUnallowedBindingModifier)
+
+		_, err = decodeBindingTagV2("query", "n,layout=2006", opts)
+		assert.NoError(t, err)
+	})
 }
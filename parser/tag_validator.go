@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var ErrNoTagValidator = errors.New("no validator of validate tags, call validate.InstallTagValidator")
+
+// TagValidator checks the `validate` tags of the fields of v, a pointer to
+// a struct, that the mask paths select (see MaskedParser), collecting
+// maxErrors errors at most (all if negative). Nil paths select every
+// field.
+//
+// The validate package installs its validator on first use (see
+// validate.InstallTagValidator), so that programs that only parse do not
+// depend on it. See SetTagValidator.
+type TagValidator func(v any, paths [][]string, maxErrors int) error
+
+var (
+	_tagValidator      TagValidator
+	_tagValidatorMutex sync.RWMutex
+)
+
+// SetTagValidator sets the validator of the `validate` tags checked by
+// ParserRegistry parses with validate set and by ParseTable. The
+// functions of the validate package (and of the pave package, which
+// combines it) set it on first use, see validate.InstallTagValidator. A
+// nil validator unsets it.
+func SetTagValidator(validator TagValidator) {
+	_tagValidatorMutex.Lock()
+	defer _tagValidatorMutex.Unlock()
+
+	_tagValidator = validator
+}
+
+// validateTags checks the `validate` tags of v with the TagValidator set
+// by SetTagValidator. Without one, it fails with ErrNoTagValidator if the
+// type of v has validate tags, rather than leaving them unchecked.
+func validateTags(v any, paths [][]string, maxErrors int) error {
+	_tagValidatorMutex.RLock()
+	validator := _tagValidator
+	_tagValidatorMutex.RUnlock()
+
+	if validator != nil {
+		return validator(v, paths, maxErrors)
+	}
+
+	typ := reflect.TypeOf(v)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ != nil && typ.Kind() == reflect.Struct && hasValidateTags(typ, make(map[reflect.Type]bool)) {
+		return fmt.Errorf("%w: %s", ErrNoTagValidator, typ)
+	}
+	return nil
+}
+
+// hasValidateTags reports whether a field of the struct type typ, or of
+// its nested structs, has a validate tag. visited holds the types being
+// checked, for recursive types.
+func hasValidateTags(typ reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[typ] {
+		return false
+	}
+	visited[typ] = true
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if tag, _ := ProfiledTag(field); tag.Get(ValidateTagName) != "" {
+			return true
+		}
+
+		nested := field.Type
+		if nested.Kind() == reflect.Ptr {
+			nested = nested.Elem()
+		}
+		if nested.Kind() == reflect.Struct && !IsSpecialStructType(nested) && hasValidateTags(nested, visited) {
+			return true
+		}
+	}
+	return false
+}
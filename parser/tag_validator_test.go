@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTags(t *testing.T) {
+	type Address struct {
+		Zip string `validate:"required"`
+	}
+	type Tagged struct {
+		Address *Address
+	}
+	type Untagged struct {
+		Name string
+		Next *Untagged
+	}
+
+	assert.ErrorIs(t, validateTags(&Tagged{}, nil, DefaultMaxErrors), ErrNoTagValidator, "nested tags need a validator")
+	assert.NoError(t, validateTags(&Untagged{}, nil, DefaultMaxErrors))
+	assert.NoError(t, validateTags("not a struct", nil, DefaultMaxErrors))
+
+	errInvalid := errors.New("invalid")
+	var gotPaths [][]string
+	var gotMax int
+	SetTagValidator(func(v any, paths [][]string, maxErrors int) error {
+		gotPaths, gotMax = paths, maxErrors
+		return errInvalid
+	})
+	t.Cleanup(func() { SetTagValidator(nil) })
+
+	assert.ErrorIs(t, validateTags(&Untagged{}, [][]string{{"Name"}}, 3), errInvalid)
+	assert.Equal(t, [][]string{{"Name"}}, gotPaths)
+	assert.Equal(t, 3, gotMax)
+
+	t.Run("Registry", func(t *testing.T) {
+		type Query struct {
+			Name string `query:"name"`
+		}
+
+		registry, err := NewParserRegistry(ParserRegistryOpts{MaxErrors: -1, Parsers: []Parser{NewHTTPRequestParser()}})
+		require.NoError(t, err)
+
+		req := newTestRequest(t, "GET", "http://example.com/?name=jane", nil)
+		err = registry.ParseMasked(req, &Query{}, []string{" Name ", ""}, true)
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.ErrorIs(t, err, errInvalid)
+		assert.Equal(t, [][]string{{"Name"}}, gotPaths, "masks are split")
+		assert.Equal(t, -1, gotMax)
+	})
+}
@@ -0,0 +1,205 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrInvalidTenantID       = errors.New("invalid tenant id")
+	ErrUnknownTenantResolver = errors.New("unknown tenant resolver")
+)
+
+// TenantID identifies the tenant of a multi-tenant service. Tenant IDs are
+// DNS labels, so that they can name subdomains: 1 to 63 lower case
+// letters, digits and hyphens, not starting or ending with a hyphen.
+type TenantID string
+
+// ParseTenantID parses a tenant ID, failing with ErrInvalidTenantID if it
+// is not a DNS label.
+func ParseTenantID(value string) (TenantID, error) {
+	if !isTenantID(value) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTenantID, value)
+	}
+	return TenantID(value), nil
+}
+
+// isTenantID reports whether value is a valid TenantID.
+func isTenantID(value string) bool {
+	if len(value) == 0 || len(value) > 63 || value[0] == '-' || value[len(value)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// TenantResolver finds the tenant a request is made for. See
+// HTTPRequestParser.RegisterTenantResolver.
+type TenantResolver interface {
+	// ResolveTenant returns the tenant of req, or "" if req does not name
+	// one.
+	ResolveTenant(req *http.Request) (string, error)
+}
+
+// TenantResolverFunc adapts a function to the TenantResolver interface.
+type TenantResolverFunc func(req *http.Request) (string, error)
+
+func (f TenantResolverFunc) ResolveTenant(req *http.Request) (string, error) {
+	return f(req)
+}
+
+// TenantResolvers tries its resolvers in order, the first tenant found
+// wins, so that the order sets the priority of the tenant sources.
+type TenantResolvers []TenantResolver
+
+func (resolvers TenantResolvers) ResolveTenant(req *http.Request) (string, error) {
+	for _, resolver := range resolvers {
+		tenant, err := resolver.ResolveTenant(req)
+		if err != nil || tenant != "" {
+			return tenant, err
+		}
+	}
+	return "", nil
+}
+
+// SubdomainTenant resolves the tenant from the subdomain of domain the
+// request is made to, e.g. "acme" for "acme.example.com" with domain
+// "example.com". Hosts with more or other labels name no tenant.
+func SubdomainTenant(domain string) TenantResolver {
+	suffix := "." + strings.ToLower(strings.Trim(domain, "."))
+	return TenantResolverFunc(func(req *http.Request) (string, error) {
+		host := req.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+		tenant, ok := strings.CutSuffix(host, suffix)
+		if !ok || strings.Contains(tenant, ".") {
+			return "", nil
+		}
+		return tenant, nil
+	})
+}
+
+// HeaderTenant resolves the tenant from the value of a request header,
+// e.g. "X-Tenant-ID".
+func HeaderTenant(header string) TenantResolver {
+	return TenantResolverFunc(func(req *http.Request) (string, error) {
+		return strings.TrimSpace(req.Header.Get(header)), nil
+	})
+}
+
+// JWTClaimTenant resolves the tenant from a claim of the bearer token of
+// the Authorization header. verify must check the signature of the token
+// before returning its claims: tenants taken from unverified tokens could
+// be forged. Dots in claim select nested claims, e.g. "org.id".
+func JWTClaimTenant(claim string, verify func(token string) (map[string]any, error)) TenantResolver {
+	path := strings.Split(claim, ".")
+	return TenantResolverFunc(func(req *http.Request) (string, error) {
+		scheme, token, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return "", nil
+		}
+
+		claims, err := verify(strings.TrimSpace(token))
+		if err != nil {
+			return "", err
+		}
+
+		var value any = claims
+		for _, name := range path {
+			object, ok := value.(map[string]any)
+			if !ok {
+				return "", nil
+			}
+			value = object[name]
+		}
+
+		tenant, ok := value.(string)
+		if value != nil && !ok {
+			return "", fmt.Errorf("%w: claim %s is a %T", ErrInvalidTenantID, claim, value)
+		}
+		return tenant, nil
+	})
+}
+
+// RegisterTenantResolver makes resolver available to tenant bindings as
+// name, e.g. `tenant:"<name>"`. Registering a resolver for an already
+// registered name replaces it.
+func (hp *HTTPRequestParser) RegisterTenantResolver(name string, resolver TenantResolver) {
+	mgr := hp.bindingManager()
+	mgr.tenantResolversMu.Lock()
+	defer mgr.tenantResolversMu.Unlock()
+
+	if mgr.tenantResolvers == nil {
+		mgr.tenantResolvers = make(map[string]TenantResolver)
+	}
+	mgr.tenantResolvers[name] = resolver
+}
+
+// UnregisterTenantResolver removes the resolver registered as name, if
+// any.
+func (hp *HTTPRequestParser) UnregisterTenantResolver(name string) {
+	mgr := hp.bindingManager()
+	mgr.tenantResolversMu.Lock()
+	defer mgr.tenantResolversMu.Unlock()
+
+	delete(mgr.tenantResolvers, name)
+}
+
+// TenantValue returns the tenant of the request, as resolved by the
+// TenantResolver registered as the binding identifier. Each resolver runs
+// once per request.
+func (mgr *HTTPBindingManager) TenantValue(
+	source *http.Request, entry *CacheEntry[HTTPRequestOnce], binding Binding,
+) BindingResult {
+
+	mgr.tenantResolversMu.RLock()
+	resolver, ok := mgr.tenantResolvers[binding.Identifier]
+	mgr.tenantResolversMu.RUnlock()
+	if !ok {
+		return BindingResultError(fmt.Errorf("%w: %s", ErrUnknownTenantResolver, binding.Identifier))
+	}
+
+	var resolved tenantResolution
+	entry.WriteData(func(data *HTTPRequestOnce) {
+		cached, ok := data.tenants[binding.Identifier]
+		if !ok {
+			cached.tenant, cached.err = resolver.ResolveTenant(source)
+			if data.tenants == nil {
+				data.tenants = make(map[string]tenantResolution)
+			}
+			data.tenants[binding.Identifier] = cached
+		}
+		resolved = cached
+	})
+
+	if resolved.err != nil {
+		return BindingResultError(fmt.Errorf("tenant resolver %s: %w", binding.Identifier, resolved.err))
+	}
+	if resolved.tenant == "" {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(resolved.tenant)
+}
+
+// tenantResolution is the cached result of resolving the tenant of a
+// request.
+type tenantResolution struct {
+	tenant string
+	err    error
+}
+
+// convertTenantID parses a TenantID field.
+func convertTenantID(value string) (any, error) {
+	return ParseTenantID(value)
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantBinding(t *testing.T) {
+	type Request struct {
+		Tenant TenantID `tenant:"default"`
+	}
+
+	var verified int
+	verify := func(token string) (map[string]any, error) {
+		verified++
+		switch token {
+		case "globex-token":
+			return map[string]any{"org": map[string]any{"id": "globex"}}, nil
+		case "numeric-token":
+			return map[string]any{"org": map[string]any{"id": 42.0}}, nil
+		}
+		return nil, errors.New("invalid signature")
+	}
+
+	parser := NewHTTPRequestParser()
+	parser.RegisterTenantResolver("default", TenantResolvers{
+		JWTClaimTenant("org.id", verify),
+		HeaderTenant("X-Tenant-ID"),
+		SubdomainTenant("example.com"),
+	})
+
+	newRequest := func(t *testing.T, host string, headers ...string) *http.Request {
+		req := newTestRequest(t, "GET", "http://"+host+"/", nil)
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		return req
+	}
+
+	for _, test := range []struct {
+		req  *http.Request
+		want TenantID
+	}{
+		{newRequest(t, "acme.example.com"), "acme"},
+		{newRequest(t, "ACME.example.com:8443"), "acme"},
+		{newRequest(t, "acme.example.com", "X-Tenant-ID", "initech"), "initech"},
+		{newRequest(t, "acme.example.com", "X-Tenant-ID", "initech", "Authorization", "Bearer globex-token"), "globex"},
+	} {
+		var request Request
+		require.NoError(t, parser.Parse(test.req, &request), test.req.Host)
+		assert.Equal(t, test.want, request.Tenant, test.req.Host)
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		for _, host := range []string{"example.com", "a.b.example.com", "acme.example.org"} {
+			err := parser.Parse(newRequest(t, host), &Request{})
+			assert.ErrorIs(t, err, ErrRequiredFieldNotFound, host)
+		}
+	})
+
+	t.Run("InvalidTenantID", func(t *testing.T) {
+		err := parser.Parse(newRequest(t, "example.com", "X-Tenant-ID", "Acme Corp"), &Request{})
+		assert.ErrorIs(t, err, ErrInvalidTenantID)
+
+		err = parser.Parse(newRequest(t, "example.com", "Authorization", "Bearer numeric-token"), &Request{})
+		assert.ErrorIs(t, err, ErrInvalidTenantID)
+	})
+
+	t.Run("ResolvedOncePerRequest", func(t *testing.T) {
+		verified = 0
+		var request struct {
+			Tenant TenantID `tenant:"default"`
+			Raw    string   `tenant:"default,allowdup"`
+		}
+		require.NoError(t, parser.Parse(newRequest(t, "example.com", "Authorization", "Bearer globex-token"), &request))
+		assert.Equal(t, TenantID("globex"), request.Tenant)
+		assert.Equal(t, "globex", request.Raw)
+		assert.Equal(t, 1, verified)
+	})
+
+	t.Run("UnverifiedToken", func(t *testing.T) {
+		err := parser.Parse(newRequest(t, "acme.example.com", "Authorization", "Bearer forged"), &Request{})
+		assert.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("UnknownResolver", func(t *testing.T) {
+		err := parser.Parse(newRequest(t, "acme.example.com"), &struct {
+			Tenant TenantID `tenant:"other"`
+		}{})
+		assert.ErrorIs(t, err, ErrUnknownTenantResolver)
+	})
+
+	t.Run("ParseTenantID", func(t *testing.T) {
+		for _, valid := range []string{"a", "acme", "acme-eu-1", "0"} {
+			_, err := ParseTenantID(valid)
+			assert.NoError(t, err, valid)
+		}
+		for _, invalid := range []string{"", "-acme", "acme-", "ACME", "acme.eu", "acme_eu", string(make([]byte, 64))} {
+			_, err := ParseTenantID(invalid)
+			assert.ErrorIs(t, err, ErrInvalidTenantID, invalid)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidDuration = errors.New("invalid duration")
+	ErrInvalidTime     = errors.New("invalid time")
+)
+
+// _namedLayouts are the layouts of the time package that the layout
+// modifier accepts by name, e.g. `layout=RFC1123` for a layout holding a
+// comma, which cannot be spelled out in a binding tag.
+var _namedLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// parseLayoutModifier parses the layout of the layout modifier: the name
+// of a layout of the time package or a layout, which may be quoted, e.g.
+// `layout=DateOnly`, `layout=2006-01-02` or `layout="02 Jan 06 15:04"`.
+func parseLayoutModifier(value string) (any, error) {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	if value == "" {
+		return nil, fmt.Errorf("layout must not be empty")
+	}
+	if layout, ok := _namedLayouts[value]; ok {
+		return layout, nil
+	}
+	return value, nil
+}
+
+// timeLayout returns the layout of the layout modifier of modifiers, if
+// any.
+func timeLayout(modifiers BindingModifiers) (string, bool) {
+	layout, ok := modifiers.Values[LayoutBindingModifier].(string)
+	return layout, ok
+}
+
+// setTimeLayoutValue sets a time.Time field from value in layout.
+func setTimeLayoutValue(field reflect.Value, value string, layout string) error {
+	timeValue, err := time.Parse(layout, value)
+	if err != nil {
+		return fmt.Errorf("%w %q for layout %q: %w", ErrInvalidTime, value, layout, err)
+	}
+	field.Set(reflect.ValueOf(timeValue))
+	return nil
+}
+
+// convertDuration parses a duration like "1h30m" or "250ms", see
+// time.ParseDuration. Integers are nanoseconds, as they were before
+// durations had a converter.
+func convertDuration(value string) (any, error) {
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return duration, nil
+	}
+	if nanos, intErr := strconv.ParseInt(value, 10, 64); intErr == nil {
+		return time.Duration(nanos), nil
+	}
+	return nil, fmt.Errorf("%w %q: %w", ErrInvalidDuration, value, err)
+}
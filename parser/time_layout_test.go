@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type layoutRequest struct {
+	Timeout  time.Duration      `query:"timeout,omitempty" default:"30s"`
+	Retry    Opt[time.Duration] `query:"retry,omitempty"`
+	Date     time.Time          `query:"date,layout=2006-01-02"`
+	Stamp    time.Time          `query:"stamp,omitempty,layout=RFC1123" default:"Mon, 02 Jan 2006 15:04:05 MST"`
+	Clock    Opt[time.Time]     `query:"clock,omitempty,layout=\"15:04\""`
+	Holidays []time.Time        `query:"holidays,omitempty,layout=DateOnly" default:""`
+	Windows  []time.Duration    `query:"windows,omitempty" default:""`
+	Since    *time.Time         `query:"since,omitempty" default:""`
+}
+
+func TestTimeLayout_HTTP(t *testing.T) {
+	parser := NewHTTPRequestParser()
+
+	t.Run("Parsed", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/?timeout=1m30s&retry=250ms&date=2024-05-06&clock=09:30&holidays=2024-12-25&holidays=2024-12-26&windows=1s&windows=2h&since=2024-01-02T03:04:05Z", nil)
+
+		var dest layoutRequest
+		require.NoError(t, parser.Parse(req, &dest))
+
+		assert.Equal(t, 90*time.Second, dest.Timeout)
+		assert.Equal(t, Opt[time.Duration]{Value: 250 * time.Millisecond, Set: true}, dest.Retry)
+		assert.Equal(t, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), dest.Date)
+		assert.Equal(t, "Mon, 02 Jan 2006 15:04:05 MST", dest.Stamp.Format(time.RFC1123), "default parsed in the layout")
+		assert.True(t, dest.Clock.Set)
+		assert.Equal(t, 9, dest.Clock.Value.Hour())
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 12, 26, 0, 0, 0, 0, time.UTC),
+		}, dest.Holidays)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Hour}, dest.Windows)
+		require.NotNil(t, dest.Since)
+		assert.Equal(t, 2024, dest.Since.Year())
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/?date=2024-05-06", nil)
+
+		var dest layoutRequest
+		require.NoError(t, parser.Parse(req, &dest))
+		assert.Equal(t, 30*time.Second, dest.Timeout)
+		assert.False(t, dest.Retry.Set)
+	})
+
+	t.Run("WrongLayout", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/?date=2024-05-06T00:00:00Z", nil)
+
+		var dest layoutRequest
+		err := parser.Parse(req, &dest)
+		assert.ErrorIs(t, err, ErrInvalidTime)
+		assert.Equal(t, "Date", FieldPath(err))
+	})
+
+	t.Run("InvalidDuration", func(t *testing.T) {
+		req := newTestRequest(t, "GET", "http://example.com/?date=2024-05-06&timeout=soon", nil)
+
+		var dest layoutRequest
+		err := parser.Parse(req, &dest)
+		assert.ErrorIs(t, err, ErrInvalidDuration)
+		assert.Equal(t, "Timeout", FieldPath(err))
+	})
+
+	t.Run("EmptyLayout", func(t *testing.T) {
+		type emptyLayout struct {
+			Date time.Time `query:"date,layout="`
+		}
+		req := newTestRequest(t, "GET", "http://example.com/?date=2024-05-06", nil)
+
+		assert.Error(t, parser.Parse(req, &emptyLayout{}))
+	})
+}
+
+func TestConvertDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"1h30m", 90 * time.Minute, false},
+		{"-5s", -5 * time.Second, false},
+		{"1500", 1500, false},
+		{"0", 0, false},
+		{"5 minutes", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := convertDuration(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidDuration)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseLayoutModifier(t *testing.T) {
+	tests := map[string]string{
+		"2006-01-02":     "2006-01-02",
+		"DateOnly":       time.DateOnly,
+		"RFC1123":        time.RFC1123,
+		`"02 Jan 15:04"`: "02 Jan 15:04",
+		`'15:04'`:        "15:04",
+	}
+
+	for value, want := range tests {
+		t.Run(value, func(t *testing.T) {
+			got, err := parseLayoutModifier(value)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	_, err := parseLayoutModifier(`""`)
+	assert.Error(t, err)
+}
+
+func TestFormatFieldValue_TimeLayout(t *testing.T) {
+	type encoded struct {
+		Timeout time.Duration `query:"timeout"`
+		Date    time.Time     `query:"date,layout=DateOnly"`
+		Since   time.Time     `query:"since"`
+	}
+	value := encoded{
+		Timeout: 90 * time.Second,
+		Date:    time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+		Since:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	query, err := EncodeQuery(value)
+	require.NoError(t, err)
+	assert.Equal(t, "1m30s", query.Get("timeout"))
+	assert.Equal(t, "2024-05-06", query.Get("date"))
+	assert.Equal(t, "2024-01-02T03:04:05Z", query.Get("since"))
+
+	req := newTestRequest(t, "GET", "http://example.com/?"+query.Encode(), nil)
+	var parsed encoded
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &parsed))
+	assert.True(t, reflect.DeepEqual(value, parsed))
+}
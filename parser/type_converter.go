@@ -0,0 +1,191 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/mail"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrTypeConverterResult = errors.New("type converter returned a value of the wrong type")
+	ErrInvalidBigNumber    = errors.New("invalid big number")
+)
+
+// TypeConverter converts the string value of a binding into a value of
+// the destination field type. modifiers are those of the binding that
+// produced the value, so that converters can honor key=value modifiers
+// (e.g. `currency=USD`).
+type TypeConverter func(value string, modifiers BindingModifiers) (any, error)
+
+// typeConverters holds the registered converters keyed by the
+// reflect.Type of the values they produce.
+var (
+	_typeConverters      = make(map[reflect.Type]TypeConverter)
+	_typeConvertersMutex sync.RWMutex
+)
+
+// _builtinTypeConverters are used for their types unless a converter was
+// registered for the same type.
+var _builtinTypeConverters = map[reflect.Type]TypeConverter{
+	reflect.TypeFor[big.Int]():         ignoreModifiers(convertBigInt),
+	reflect.TypeFor[big.Float]():       ignoreModifiers(convertBigFloat),
+	reflect.TypeFor[big.Rat]():         ignoreModifiers(convertBigRat),
+	reflect.TypeFor[Money]():           convertMoney,
+	reflect.TypeFor[Range]():           convertRange,
+	reflect.TypeFor[RateLimit]():       ignoreModifiers(convertRateLimit),
+	reflect.TypeFor[TenantID]():        ignoreModifiers(convertTenantID),
+	reflect.TypeFor[AcceptList]():      ignoreModifiers(convertAcceptList),
+	reflect.TypeFor[ETag]():            ignoreModifiers(convertETag),
+	reflect.TypeFor[ETagList]():        ignoreModifiers(convertETagList),
+	reflect.TypeFor[SortSpecs]():       convertSortSpecs,
+	reflect.TypeFor[FilterSpecs]():     convertFilterSpecs,
+	reflect.TypeFor[Point]():           ignoreModifiers(convertPoint),
+	reflect.TypeFor[BoundingBox]():     ignoreModifiers(convertBoundingBox),
+	reflect.TypeFor[Color]():           ignoreModifiers(convertColor),
+	reflect.TypeFor[CronSchedule]():    ignoreModifiers(convertCronSchedule),
+	reflect.TypeFor[RRule]():           ignoreModifiers(convertRRule),
+	reflect.TypeFor[*time.Location]():  ignoreModifiers(convertTimezone),
+	DurationType:                       ignoreModifiers(convertDuration),
+	reflect.TypeFor[mail.Address]():    ignoreModifiers(convertMailAddress),
+	reflect.TypeFor[[]*mail.Address](): ignoreModifiers(convertMailAddressList),
+}
+
+// RegisterTypeConverter registers a converter that is used whenever a
+// destination field of type T (or *T) is populated. It takes precedence
+// over the built-in conversions, including encoding.TextUnmarshaler. T may
+// itself be a pointer type, for values that are shared rather than copied
+// (e.g. *time.Location).
+//
+// Registering a converter for an already registered type replaces it.
+func RegisterTypeConverter[T any](converter func(value string) (T, error)) {
+	typ := reflect.TypeFor[T]()
+
+	_typeConvertersMutex.Lock()
+	defer _typeConvertersMutex.Unlock()
+
+	_typeConverters[typ] = func(value string, _ BindingModifiers) (any, error) {
+		return converter(value)
+	}
+}
+
+// RegisterModifierTypeConverter is RegisterTypeConverter for converters
+// that depend on the modifiers of the binding the value was produced by.
+// Use ModifierValue or BindingModifiers.Value to read key=value modifiers.
+func RegisterModifierTypeConverter[T any](
+	converter func(value string, modifiers BindingModifiers) (T, error),
+) {
+	typ := reflect.TypeFor[T]()
+
+	_typeConvertersMutex.Lock()
+	defer _typeConvertersMutex.Unlock()
+
+	_typeConverters[typ] = func(value string, modifiers BindingModifiers) (any, error) {
+		return converter(value, modifiers)
+	}
+}
+
+// UnregisterTypeConverter removes the converter registered for type T, if
+// any. Built-in converters cannot be removed.
+func UnregisterTypeConverter[T any]() {
+	typ := reflect.TypeFor[T]()
+
+	_typeConvertersMutex.Lock()
+	defer _typeConvertersMutex.Unlock()
+
+	delete(_typeConverters, typ)
+}
+
+// getTypeConverter returns the converter for typ, if any.
+func getTypeConverter(typ reflect.Type) (TypeConverter, bool) {
+	installIntegrations()
+
+	_typeConvertersMutex.RLock()
+	converter, ok := _typeConverters[typ]
+	_typeConvertersMutex.RUnlock()
+
+	if !ok {
+		converter, ok = _builtinTypeConverters[typ]
+	}
+	return converter, ok
+}
+
+// setConvertedValue sets field to the result of converter.
+func setConvertedValue(
+	field reflect.Value, converter TypeConverter, value string, modifiers BindingModifiers,
+) error {
+	result, err := converter(value, modifiers)
+	if err != nil {
+		return err
+	}
+
+	resultValue := reflect.ValueOf(result)
+	if !resultValue.IsValid() || resultValue.Type() != field.Type() {
+		return fmt.Errorf("%w: expected %s, got %T", ErrTypeConverterResult, field.Type(), result)
+	}
+
+	field.Set(resultValue)
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Built-in Converters
+///////////////////////////////////////////////////////////////////////////////
+
+// ignoreModifiers adapts a converter that does not use binding modifiers.
+func ignoreModifiers(converter func(value string) (any, error)) TypeConverter {
+	return func(value string, _ BindingModifiers) (any, error) {
+		return converter(value)
+	}
+}
+
+// convertBigInt parses an integer of arbitrary size. Base prefixes (0x,
+// 0o, 0b) and underscores are accepted like in Go literals.
+func convertBigInt(value string) (any, error) {
+	var result big.Int
+	if _, ok := result.SetString(value, 0); !ok {
+		return nil, fmt.Errorf("%w: cannot convert %q to big.Int", ErrInvalidBigNumber, value)
+	}
+	return result, nil
+}
+
+// convertBigFloat parses a decimal number with enough precision to hold
+// every digit of value, and at least float64 precision.
+func convertBigFloat(value string) (any, error) {
+	result, _, err := big.ParseFloat(value, 10, bigFloatPrec(value), big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot convert %q to big.Float: %w", ErrInvalidBigNumber, value, err)
+	}
+	return *result, nil
+}
+
+// convertBigRat parses a decimal ("12.34", "1e-3") or fraction ("1/3")
+// exactly.
+func convertBigRat(value string) (any, error) {
+	var result big.Rat
+	if _, ok := result.SetString(value); !ok {
+		return nil, fmt.Errorf("%w: cannot convert %q to big.Rat", ErrInvalidBigNumber, value)
+	}
+	return result, nil
+}
+
+// bigFloatPrec returns the mantissa precision in bits needed to represent
+// the significant digits of a decimal number.
+func bigFloatPrec(value string) uint {
+	mantissa, _, _ := strings.Cut(strings.ToLower(value), "e")
+
+	digits := 0
+	for _, r := range mantissa {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+
+	prec := uint(math.Ceil(float64(digits) * math.Log2(10)))
+	return max(prec, 64)
+}
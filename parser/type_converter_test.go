@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPRequestParser_BigNumbers(t *testing.T) {
+	type Transfer struct {
+		Amount  big.Int   `json:"amount"`
+		Balance *big.Int  `json:"balance"`
+		Rate    big.Float `json:"rate"`
+		Ratio   big.Rat   `query:"ratio"`
+	}
+
+	req := newTestRequest(t, "POST", "http://example.com/?ratio=1/3",
+		bytes.NewBufferString(`{
+			"amount": 123456789012345678901234567890,
+			"balance": "-98765432109876543210",
+			"rate": 0.1234567890123456789012345
+		}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var result Transfer
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &result))
+
+	assert.Equal(t, "123456789012345678901234567890", result.Amount.String())
+	require.NotNil(t, result.Balance)
+	assert.Equal(t, "-98765432109876543210", result.Balance.String())
+	assert.Equal(t, "0.1234567890123456789012345", result.Rate.Text('f', 25))
+	assert.Equal(t, "1/3", result.Ratio.String())
+}
+
+func TestBigNumberConverters(t *testing.T) {
+	t.Run("BigInt", func(t *testing.T) {
+		value, err := convertBigInt("0xff")
+		require.NoError(t, err)
+		i := value.(big.Int)
+		assert.Equal(t, int64(255), i.Int64())
+
+		_, err = convertBigInt("12.5")
+		assert.ErrorIs(t, err, ErrInvalidBigNumber)
+	})
+
+	t.Run("BigFloat", func(t *testing.T) {
+		value, err := convertBigFloat("1e3")
+		require.NoError(t, err)
+		f := value.(big.Float)
+		assert.Equal(t, "1000", f.Text('f', -1))
+
+		_, err = convertBigFloat("abc")
+		assert.ErrorIs(t, err, ErrInvalidBigNumber)
+	})
+
+	t.Run("BigRat", func(t *testing.T) {
+		value, err := convertBigRat("12.34")
+		require.NoError(t, err)
+		r := value.(big.Rat)
+		assert.Equal(t, "617/50", r.String())
+
+		_, err = convertBigRat("1/0")
+		assert.ErrorIs(t, err, ErrInvalidBigNumber)
+	})
+
+	assert.Equal(t, uint(64), bigFloatPrec("1.5"))
+	assert.Equal(t, uint(100), bigFloatPrec("123456789012345678901234567890e-5"))
+}
+
+type converterTestCode string
+
+func TestRegisterTypeConverter(t *testing.T) {
+	RegisterTypeConverter(func(value string) (converterTestCode, error) {
+		if len(value) != 3 {
+			return "", errors.New("code must have 3 letters")
+		}
+		return converterTestCode(strings.ToUpper(value)), nil
+	})
+	defer UnregisterTypeConverter[converterTestCode]()
+
+	type Order struct {
+		Currency converterTestCode  `query:"currency"`
+		Fallback *converterTestCode `query:"fallback"`
+	}
+
+	req := newTestRequest(t, "GET", "http://example.com/?currency=usd&fallback=eur", nil)
+
+	var result Order
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &result))
+	assert.Equal(t, converterTestCode("USD"), result.Currency)
+	require.NotNil(t, result.Fallback)
+	assert.Equal(t, converterTestCode("EUR"), *result.Fallback)
+
+	req = newTestRequest(t, "GET", "http://example.com/?currency=dollar&fallback=eur", nil)
+	assert.Error(t, NewHTTPRequestParser().Parse(req, &Order{}))
+
+	t.Run("WrongResultType", func(t *testing.T) {
+		var code converterTestCode
+		err := setConvertedValue(reflect.ValueOf(&code).Elem(), func(string, BindingModifiers) (any, error) {
+			return 42, nil
+		}, "usd", BindingModifiers{})
+		assert.ErrorIs(t, err, ErrTypeConverterResult)
+	})
+}
@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrNoUnionAlternative = errors.New("value matches no alternative of union")
+)
+
+// Union2 holds a value of one of two types, for fields that sloppy APIs
+// send in more than one form, such as a string or a number:
+//
+//	type Product struct {
+//		Price pave.Union2[float64, string] `json:"price"`
+//	}
+//
+// Parsers try the alternatives in order and keep the first that the value
+// converts to, so the most specific type goes first. Bindings hand values
+// over as text, so a JSON 12 and "12" both populate a float64 First, while
+// "N/A" falls back to a string Second. Validation rules and encoders see
+// the value held.
+//
+// In JSON, a Union2 marshals to the value held, or null if it holds none.
+// Unmarshaling tries the alternatives in order, so unlike bindings, it
+// tells the string "12" from the number 12.
+type Union2[A, B any] struct {
+	First  A
+	Second B
+	Which  int // 1 if First holds the value, 2 if Second does, 0 if neither
+}
+
+// UnionFirst returns a Union2 holding value as its first alternative.
+func UnionFirst[A, B any](value A) Union2[A, B] {
+	return Union2[A, B]{First: value, Which: 1}
+}
+
+// UnionSecond returns a Union2 holding value as its second alternative.
+func UnionSecond[A, B any](value B) Union2[A, B] {
+	return Union2[A, B]{Second: value, Which: 2}
+}
+
+// Value returns the value held, or nil if there is none.
+func (u Union2[A, B]) Value() any {
+	switch u.Which {
+	case 1:
+		return u.First
+	case 2:
+		return u.Second
+	}
+	return nil
+}
+
+func (u Union2[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.Value())
+}
+
+func (u *Union2[A, B]) UnmarshalJSON(data []byte) error {
+	*u = Union2[A, B]{}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+
+	firstErr := json.Unmarshal(data, &u.First)
+	if firstErr == nil {
+		u.Which = 1
+		return nil
+	}
+	u.First = *new(A)
+
+	secondErr := json.Unmarshal(data, &u.Second)
+	if secondErr == nil {
+		u.Which = 2
+		return nil
+	}
+	u.Second = *new(B)
+
+	return fmt.Errorf("%w: %w", ErrNoUnionAlternative, errors.Join(firstErr, secondErr))
+}
+
+// isUnion marks the instances of Union2, see IsUnionType.
+func (Union2[A, B]) isUnion() {}
+
+// union is implemented by every instance of Union2.
+type union interface {
+	isUnion()
+}
+
+var _unionType = reflect.TypeFor[union]()
+
+// IsUnionType reports whether typ is an instance of Union2.
+func IsUnionType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && typ.Implements(_unionType)
+}
+
+// UnionValue returns the alternative of the union value u that holds its
+// value, and false if it holds none.
+func UnionValue(u reflect.Value) (reflect.Value, bool) {
+	which := int(u.Field(u.NumField() - 1).Int())
+	if which == 0 {
+		return reflect.Value{}, false
+	}
+	return u.Field(which - 1), true
+}
+
+// setUnionValue populates the first alternative of the union field that
+// value converts to, see Union2.
+func setUnionValue(field reflect.Value, value string, modifiers BindingModifiers) error {
+	field.SetZero()
+
+	alternatives := field.NumField() - 1
+	errs := make([]error, 0, alternatives)
+	for i := 0; i < alternatives; i++ {
+		alternative := field.Field(i)
+		err := setFieldValueWithModifiers(alternative, value, modifiers)
+		if err == nil {
+			field.Field(alternatives).SetInt(int64(i + 1))
+			return nil
+		}
+		alternative.SetZero()
+		errs = append(errs, err)
+	}
+
+	return fmt.Errorf("%w %s: %w", ErrNoUnionAlternative, field.Type(), errors.Join(errs...))
+}
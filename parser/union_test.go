@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type unionProduct struct {
+	Price    Union2[float64, string]  `json:"price"`
+	Quantity Union2[int, bool]        `query:"quantity,omitempty" default:"true"`
+	Discount Opt[Union2[int, string]] `query:"discount,omitempty"`
+}
+
+func TestUnion2_Parse(t *testing.T) {
+	parser := NewHTTPRequestParser()
+
+	tests := []struct {
+		name     string
+		url      string
+		body     string
+		expected unionProduct
+	}{
+		{
+			name: "Number",
+			url:  "http://example.com/?quantity=3",
+			body: `{"price": 12.5}`,
+			expected: unionProduct{
+				Price:    UnionFirst[float64, string](12.5),
+				Quantity: UnionFirst[int, bool](3),
+			},
+		},
+		{
+			name: "NumericString",
+			url:  "http://example.com/?discount=10",
+			body: `{"price": "12"}`,
+			expected: unionProduct{
+				Price:    UnionFirst[float64, string](12),
+				Quantity: UnionSecond[int](true),
+				Discount: Some(UnionFirst[int, string](10)),
+			},
+		},
+		{
+			name: "String",
+			url:  "http://example.com/?quantity=false&discount=",
+			body: `{"price": "N/A"}`,
+			expected: unionProduct{
+				Price:    UnionSecond[float64]("N/A"),
+				Quantity: UnionSecond[int](false),
+				Discount: Some(UnionSecond[int]("")),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := newTestRequest(t, "POST", test.url, strings.NewReader(test.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			var product unionProduct
+			require.NoError(t, parser.Parse(req, &product))
+			assert.Equal(t, test.expected, product)
+		})
+	}
+
+	t.Run("NoAlternative", func(t *testing.T) {
+		req := newTestRequest(t, "POST", "http://example.com/?quantity=many", strings.NewReader(`{"price": 1}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		var product unionProduct
+		err := parser.Parse(req, &product)
+		assert.ErrorIs(t, err, ErrNoUnionAlternative)
+		assert.Equal(t, 0, product.Quantity.Which)
+	})
+}
+
+func TestUnion2_JSON(t *testing.T) {
+	data, err := json.Marshal([]Union2[int, string]{UnionFirst[int, string](1), UnionSecond[int]("12"), {}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[1, "12", null]`, string(data))
+
+	var values []Union2[int, string]
+	require.NoError(t, json.Unmarshal(data, &values))
+	assert.Equal(t, []Union2[int, string]{UnionFirst[int, string](1), UnionSecond[int]("12"), {}}, values)
+	assert.Equal(t, "12", values[1].Value())
+	assert.Nil(t, values[2].Value())
+
+	var value Union2[int, string]
+	assert.ErrorIs(t, json.Unmarshal([]byte(`true`), &value), ErrNoUnionAlternative)
+}
+
+func TestUnion2_Encode(t *testing.T) {
+	type Query struct {
+		Lat Union2[float64, string] `query:"lat"`
+	}
+
+	values, err := EncodeQuery(&Query{Lat: UnionFirst[float64, string](48.5)})
+	require.NoError(t, err)
+	assert.Equal(t, "48.5", values.Get("lat"))
+}
@@ -0,0 +1,247 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalidURLTemplate  = errors.New("invalid url template")
+	ErrURLTemplateMismatch = errors.New("url does not match template")
+)
+
+var (
+	// Default URLTemplateParser Binding Options
+	_urlTemplateTagOpts = ParseTagOpts{
+		BindingOpts: BindingOpts{
+			AllowedBindingNames: []string{
+				URLSegmentTagBinding,
+			},
+			CustomBindingModifiers: []string{},
+			ValueModifiers: map[string]ModifierValueParser{
+				CurrencyBindingModifier: parseCurrencyModifier,
+			},
+		},
+		AllowedTagOptionals: []string{},
+	}
+
+	// Default URLTemplateParser Options
+	_urlTemplateParserOpts = BaseMBParserOpts{
+		UseCache: true,
+		PCMOpts: PCManagerOpts{
+			tagOpts: _urlTemplateTagOpts,
+		},
+	}
+)
+
+// URLTemplateMatch is the source of the URLTemplateParser: a URL, or any
+// slash separated path such as an S3 object key, and the template it is
+// matched against.
+type URLTemplateMatch struct {
+	Template string // e.g. "/orgs/{org}/repos/{repo}"
+	URL      string // e.g. "https://example.com/orgs/acme/repos/pave?tab=code"
+}
+
+// URLTemplateParser binds the segments a URL captures in a template,
+// outside of HTTP servers, e.g. to parse webhook callback URLs or object
+// keys:
+//   - urlseg:'<name,[modifiers]>'`: Parses the value captured by the
+//     wildcard {name} of the template.
+//
+// A wildcard {name} matches the text up to the next slash, possibly next
+// to literal text in the same segment (e.g. "{file}.json"), and {name...}
+// matches the rest of the URL, slashes included. Captured values are path
+// unescaped. URLs with a scheme and host are matched by their path, other
+// URLs as a whole; query strings and fragments are ignored.
+//
+//	type Callback struct {
+//		Org  string `urlseg:"org"`
+//		Repo string `urlseg:"repo"`
+//	}
+//
+//	match := pave.URLTemplateMatch{Template: "/orgs/{org}/repos/{repo}", URL: callbackURL}
+//	err := pave.NewURLTemplateParser().Parse(&match, &callback)
+//
+// URLs that do not match their template fail with ErrURLTemplateMismatch.
+type URLTemplateParser struct {
+	*BaseMBParser[URLTemplateMatch, URLTemplateOnce]
+}
+
+func NewURLTemplateParser() *URLTemplateParser {
+	return &URLTemplateParser{
+		BaseMBParser: NewBaseMBParser(&URLTemplateBindingManager{}, _urlTemplateParserOpts),
+	}
+}
+
+func (up *URLTemplateParser) Name() string {
+	return URLTemplateParserName
+}
+
+// URLTemplateOnce caches the segments a URL captures in its template.
+type URLTemplateOnce struct {
+	matchOnce  sync.Once
+	segments   map[string]string
+	matchError error
+}
+
+// URLTemplateBindingManager gets the values of urlseg bindings from a
+// URLTemplateMatch. See URLTemplateParser.
+type URLTemplateBindingManager struct{}
+
+func (mgr *URLTemplateBindingManager) BindingHandlerCached(
+	source *URLTemplateMatch,
+	entry *CacheEntry[URLTemplateOnce],
+	binding Binding,
+) BindingResult {
+
+	if entry == nil {
+		return BindingResultError(ErrBindingCacheNilEntry)
+	}
+
+	var (
+		segments map[string]string
+		err      error
+	)
+	entry.WriteData(func(data *URLTemplateOnce) {
+		data.matchOnce.Do(func() {
+			data.segments, data.matchError = MatchURLTemplate(source.Template, source.URL)
+		})
+		segments, err = data.segments, data.matchError
+	})
+	if err != nil {
+		return BindingResultError(err)
+	}
+
+	value, ok := segments[binding.Identifier]
+	if !ok {
+		return BindingResultError(fmt.Errorf("%w: no wildcard {%s} in %s", ErrInvalidURLTemplate, binding.Identifier, source.Template))
+	}
+	if value == "" {
+		return BindingResultNotFound()
+	}
+	return BindingResultValue(value)
+}
+
+func (mgr *URLTemplateBindingManager) BindingHandler(source *URLTemplateMatch, binding Binding) BindingResult {
+	return mgr.BindingHandlerCached(source, &CacheEntry[URLTemplateOnce]{}, binding)
+}
+
+func (mgr *URLTemplateBindingManager) NewCached() URLTemplateOnce {
+	return URLTemplateOnce{}
+}
+
+// MatchURLTemplate returns the segments rawURL captures in template, keyed
+// by wildcard name. See URLTemplateParser.
+func MatchURLTemplate(template string, rawURL string) (map[string]string, error) {
+	pattern, err := compileURLTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+
+	target, _, _ := strings.Cut(rawURL, "#")
+	target, _, _ = strings.Cut(target, "?")
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		target = parsed.EscapedPath()
+	}
+
+	match := pattern.FindStringSubmatch(target)
+	if match == nil {
+		return nil, fmt.Errorf("%w: %s does not match %s", ErrURLTemplateMismatch, rawURL, template)
+	}
+
+	segments := make(map[string]string, len(match)-1)
+	for i, name := range pattern.SubexpNames() {
+		if i == 0 {
+			continue
+		}
+		value, err := url.PathUnescape(match[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: segment %s: %w", ErrURLTemplateMismatch, name, err)
+		}
+		segments[name] = value
+	}
+	return segments, nil
+}
+
+// _urlTemplates caches the compiled URL templates.
+var _urlTemplates sync.Map // string -> *regexp.Regexp
+
+// _urlTemplateWildcard matches the {name} and {name...} wildcards of a
+// URL template.
+var _urlTemplateWildcard = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// compileURLTemplate returns the regular expression of a URL template.
+func compileURLTemplate(template string) (*regexp.Regexp, error) {
+	if cached, ok := _urlTemplates.Load(template); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	var (
+		expr  strings.Builder
+		names = make(map[string]bool)
+		last  int
+	)
+	literal := func(text string) error {
+		if strings.ContainsAny(text, "{}") {
+			return fmt.Errorf("%w: unbalanced braces in %s", ErrInvalidURLTemplate, template)
+		}
+		expr.WriteString(regexp.QuoteMeta(text))
+		return nil
+	}
+
+	expr.WriteString("^")
+	for _, loc := range _urlTemplateWildcard.FindAllStringSubmatchIndex(template, -1) {
+		if err := literal(template[last:loc[0]]); err != nil {
+			return nil, err
+		}
+		last = loc[1]
+
+		name, rest := template[loc[2]:loc[3]], false
+		if trimmed, ok := strings.CutSuffix(name, "..."); ok {
+			name, rest = trimmed, true
+		}
+		if !isWildcardName(name) {
+			return nil, fmt.Errorf("%w: invalid wildcard %q in %s", ErrInvalidURLTemplate, template[loc[0]:loc[1]], template)
+		}
+		if names[name] {
+			return nil, fmt.Errorf("%w: duplicate wildcard {%s} in %s", ErrInvalidURLTemplate, name, template)
+		}
+		names[name] = true
+
+		if rest {
+			fmt.Fprintf(&expr, "(?P<%s>.*)", name)
+		} else {
+			fmt.Fprintf(&expr, "(?P<%s>[^/]+)", name)
+		}
+	}
+	if err := literal(template[last:]); err != nil {
+		return nil, err
+	}
+	expr.WriteString("$")
+
+	pattern, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidURLTemplate, err)
+	}
+
+	_urlTemplates.Store(template, pattern)
+	return pattern, nil
+}
+
+// isWildcardName reports whether name is a valid wildcard name, which is
+// also a valid regexp group name.
+func isWildcardName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r != '_' && !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(i > 0 && r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
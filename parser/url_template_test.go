@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLTemplateParser(t *testing.T) {
+	type Repo struct {
+		Org  string `urlseg:"org"`
+		Repo string `urlseg:"repo"`
+		Tab  string `urlseg:"tab"`
+	}
+
+	parser := NewURLTemplateParser()
+
+	var repo Repo
+	match := URLTemplateMatch{
+		Template: "/orgs/{org}/repos/{repo}",
+		URL:      "https://example.com/orgs/acme/repos/go%20pave?tab=issues#readme",
+	}
+	err := parser.Parse(&match, &repo)
+	assert.ErrorIs(t, err, ErrInvalidURLTemplate, "the template has no {tab} wildcard")
+
+	type Callback struct {
+		Org  string `urlseg:"org"`
+		Repo string `urlseg:"repo"`
+	}
+	var callback Callback
+	require.NoError(t, parser.Parse(&URLTemplateMatch{Template: match.Template, URL: match.URL}, &callback))
+	assert.Equal(t, Callback{Org: "acme", Repo: "go pave"}, callback)
+
+	t.Run("ObjectKey", func(t *testing.T) {
+		var object struct {
+			Tenant string `urlseg:"tenant"`
+			Day    string `urlseg:"day"`
+			Name   string `urlseg:"name"`
+			Part   int    `urlseg:"part"`
+		}
+		key := URLTemplateMatch{
+			Template: "exports/{tenant}/{day}/{name}-{part}.json",
+			URL:      "exports/acme/2024-01-02/events-7.json",
+		}
+		require.NoError(t, parser.Parse(&key, &object))
+		assert.Equal(t, "acme", object.Tenant)
+		assert.Equal(t, "2024-01-02", object.Day)
+		assert.Equal(t, "events", object.Name)
+		assert.Equal(t, 7, object.Part)
+	})
+
+	t.Run("Rest", func(t *testing.T) {
+		segments, err := MatchURLTemplate("/files/{path...}", "/files/a/b/c.txt")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"path": "a/b/c.txt"}, segments)
+
+		segments, err = MatchURLTemplate("/files/{path...}", "/files/")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"path": ""}, segments)
+
+		var file struct {
+			Path string `urlseg:"path"`
+		}
+		err = parser.Parse(&URLTemplateMatch{Template: "/files/{path...}", URL: "/files/"}, &file)
+		assert.ErrorIs(t, err, ErrRequiredFieldNotFound)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		for _, url := range []string{
+			"https://example.com/orgs/acme",
+			"https://example.com/orgs/acme/repos/pave/issues",
+			"/users/acme/repos/pave",
+		} {
+			err := parser.Parse(&URLTemplateMatch{Template: match.Template, URL: url}, &Callback{})
+			assert.ErrorIs(t, err, ErrURLTemplateMismatch, url)
+		}
+	})
+
+	t.Run("InvalidTemplates", func(t *testing.T) {
+		for _, template := range []string{
+			"/orgs/{}",
+			"/orgs/{org name}",
+			"/orgs/{org}/repos/{org}",
+			"/orgs/{org",
+			"/orgs/org}",
+			"/a{/{b}",
+		} {
+			_, err := MatchURLTemplate(template, "/orgs/acme")
+			assert.ErrorIs(t, err, ErrInvalidURLTemplate, template)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+//go:build !pave_minimal
+
+package parser
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+// Support for github.com/google/uuid destination fields. Build with the
+// pave_minimal tag to leave it out, uuid.UUID fields are then populated
+// through their encoding.TextUnmarshaler implementation instead.
+
+// UUIDType is the type of uuid.UUID fields. It is nil in pave_minimal
+// builds.
+var UUIDType = reflect.TypeFor[uuid.UUID]()
+
+// installUUID registers the uuid integration. See installIntegrations.
+func installUUID() {
+	_builtinTypeConverters[UUIDType] = ignoreModifiers(convertUUID)
+}
+
+// convertUUID parses a UUID in any of the formats of uuid.Parse.
+func convertUUID(value string) (any, error) {
+	uuidValue, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("error converting value to UUID: %w", err)
+	}
+	return uuidValue, nil
+}
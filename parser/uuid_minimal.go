@@ -0,0 +1,12 @@
+//go:build pave_minimal
+
+package parser
+
+import "reflect"
+
+// UUIDType is the type of uuid.UUID fields. It is nil in pave_minimal
+// builds.
+var UUIDType reflect.Type
+
+// installUUID is a no-op with the pave_minimal build tag.
+func installUUID() {}
@@ -0,0 +1,27 @@
+//go:build !pave_minimal
+
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertUUID(t *testing.T) {
+	id := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	converter, ok := getTypeConverter(UUIDType)
+	require.True(t, ok)
+	assert.True(t, IsSpecialStructType(UUIDType))
+
+	var dest uuid.UUID
+	require.NoError(t, setFieldValueWithModifiers(reflect.ValueOf(&dest).Elem(), id.String(), BindingModifiers{}))
+	assert.Equal(t, id, dest)
+
+	_, err := converter("invalid-uuid", BindingModifiers{})
+	assert.ErrorContains(t, err, "error converting value to UUID")
+}
@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ValueTransform rewrites a bound value before it is converted to the
+// field type. param is the parsed value of the modifier it belongs to,
+// and binding the binding the value is bound with, e.g. to tie encrypted
+// values to their binding.
+type ValueTransform func(value string, param any, binding Binding) (string, error)
+
+// ValueModifier is a key=value binding modifier that every parser
+// accepts, in addition to the ValueModifiers of its BindingOpts.
+type ValueModifier struct {
+	Parse     ModifierValueParser // Parses the modifier value at chain build time
+	Transform ValueTransform      // Rewrites bound values before conversion. May be nil.
+	Encode    ValueTransform      // Reverts Transform on formatted values, for Encoders. May be nil.
+}
+
+// valueModifiers holds the registered ValueModifiers keyed by name.
+var (
+	_valueModifiers = map[string]ValueModifier{
+		AllowBindingModifier:      {Parse: parseAllowModifier},
+		BitBindingModifier:        {Parse: parseBitModifier, Transform: transformBit},
+		ChecksumBindingModifier:   {Parse: parseChecksumModifier},
+		DeprecatedBindingModifier: {Parse: parseDeprecatedModifier},
+		DelimBindingModifier:      {Parse: parseDelimModifier},
+		EncryptedBindingModifier:  {Parse: parseEncryptedModifier, Transform: transformDecrypt, Encode: transformEncrypt},
+		EnumBindingModifier:       {Parse: parseEnumModifier},
+		LayoutBindingModifier:     {Parse: parseLayoutModifier},
+		SunsetBindingModifier:     {Parse: parseSunsetModifier},
+	}
+	_valueModifiersMutex sync.RWMutex
+)
+
+// RegisterValueModifier makes the key=value modifier name available to
+// the bindings of every parser, e.g. RegisterValueModifier("e164", ...)
+// enables `query:"phone,e164=US"`. Modifiers declared in the BindingOpts
+// of a parser take precedence for that parser.
+//
+// Registering a modifier for an already registered name replaces it.
+// Chains that were already built keep the modifier they were built with.
+func RegisterValueModifier(name string, modifier ValueModifier) {
+	installIntegrations()
+	registerValueModifier(name, modifier)
+}
+
+// registerValueModifier is RegisterValueModifier, without installing the
+// integrations first.
+func registerValueModifier(name string, modifier ValueModifier) {
+	_valueModifiersMutex.Lock()
+	defer _valueModifiersMutex.Unlock()
+
+	_valueModifiers[name] = modifier
+}
+
+// UnregisterValueModifier removes the modifier registered as name, if any.
+func UnregisterValueModifier(name string) {
+	installIntegrations()
+
+	_valueModifiersMutex.Lock()
+	defer _valueModifiersMutex.Unlock()
+
+	delete(_valueModifiers, name)
+}
+
+// getValueModifier returns the modifier registered as name, if any.
+func getValueModifier(name string) (ValueModifier, bool) {
+	installIntegrations()
+
+	_valueModifiersMutex.RLock()
+	defer _valueModifiersMutex.RUnlock()
+
+	modifier, ok := _valueModifiers[name]
+	return modifier, ok
+}
+
+// valueModifierParser returns the parser for the key=value modifier name,
+// looking at opts before the registered ValueModifiers.
+func (opts BindingOpts) valueModifierParser(name string) (ModifierValueParser, bool) {
+	if parse, ok := opts.ValueModifiers[name]; ok {
+		return parse, true
+	}
+
+	modifier, ok := getValueModifier(name)
+	return modifier.Parse, ok && modifier.Parse != nil
+}
+
+// transformValue applies the ValueTransforms of the registered modifiers
+// set on binding to value, in modifier name order.
+func transformValue(value string, binding Binding) (string, error) {
+	modifiers := binding.Modifiers
+	if len(modifiers.Values) == 0 {
+		return value, nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(modifiers.Values)) {
+		modifier, ok := getValueModifier(name)
+		if !ok || modifier.Transform == nil {
+			continue
+		}
+
+		var err error
+		value, err = modifier.Transform(value, modifiers.Values[name], binding)
+		if err != nil {
+			return "", fmt.Errorf("%w %s: %w", ErrInvalidModifierValue, name, err)
+		}
+	}
+
+	return value, nil
+}
+
+// encodeValue applies the encode ValueTransforms of the registered
+// modifiers set on binding to value, in reverse modifier name order,
+// reverting transformValue.
+func encodeValue(value string, binding Binding) (string, error) {
+	modifiers := binding.Modifiers
+	if len(modifiers.Values) == 0 {
+		return value, nil
+	}
+
+	names := slices.Sorted(maps.Keys(modifiers.Values))
+	slices.Reverse(names)
+	for _, name := range names {
+		modifier, ok := getValueModifier(name)
+		if !ok || modifier.Encode == nil {
+			continue
+		}
+
+		var err error
+		value, err = modifier.Encode(value, modifiers.Values[name], binding)
+		if err != nil {
+			return "", fmt.Errorf("%w %s: %w", ErrInvalidModifierValue, name, err)
+		}
+	}
+
+	return value, nil
+}
+
+// hasEncodeTransform reports whether a registered modifier set on a
+// binding rewrites encoded values.
+func hasEncodeTransform(modifiers BindingModifiers) bool {
+	for name := range modifiers.Values {
+		if modifier, ok := getValueModifier(name); ok && modifier.Encode != nil {
+			return true
+		}
+	}
+	return false
+}
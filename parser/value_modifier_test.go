@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registerUpperModifier(t *testing.T) {
+	RegisterValueModifier("upper", ValueModifier{
+		Parse: TypedModifier[bool](),
+		Transform: func(value string, param any, _ Binding) (string, error) {
+			if param.(bool) {
+				return strings.ToUpper(value), nil
+			}
+			return value, nil
+		},
+	})
+	t.Cleanup(func() { UnregisterValueModifier("upper") })
+}
+
+func TestRegisterValueModifier(t *testing.T) {
+	registerUpperModifier(t)
+
+	tag := BindingTag{Name: "query", Identifier: "code", Modifiers: []string{"upper=true"}}
+	binding, err := tag.toBindingWithOpts(BindingOpts{})
+	require.NoError(t, err)
+
+	upper, ok := ModifierValue[bool](binding, "upper")
+	assert.True(t, ok)
+	assert.True(t, upper)
+
+	got, err := transformValue("abc", binding)
+	require.NoError(t, err)
+	assert.Equal(t, "ABC", got)
+
+	tag.Modifiers = []string{"upper=maybe"}
+	_, err = tag.toBindingWithOpts(BindingOpts{})
+	assert.ErrorIs(t, err, ErrInvalidModifierValue)
+
+	UnregisterValueModifier("upper")
+	tag.Modifiers = []string{"upper=true"}
+	_, err = tag.toBindingWithOpts(BindingOpts{})
+	assert.ErrorIs(t, err, ErrUnallowedBindingModifier)
+}
+
+func TestHTTPRequestParser_ValueModifierTransform(t *testing.T) {
+	registerUpperModifier(t)
+
+	type request struct {
+		Code string `query:"code,upper=true"`
+		Raw  string `query:"raw,upper=false"`
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/?code=abc&raw=abc", nil)
+	require.NoError(t, err)
+
+	var dest request
+	require.NoError(t, NewHTTPRequestParser().Parse(req, &dest))
+	assert.Equal(t, "ABC", dest.Code)
+	assert.Equal(t, "abc", dest.Raw)
+}
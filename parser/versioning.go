@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported payload version")
+)
+
+// VersionOpts enables versioned bindings, so that one struct parses the
+// payloads of several API versions while clients migrate. Tags qualified
+// with the version of a source take precedence over unqualified tags,
+// e.g. with versions "v1" and "v2",
+//
+//	UserID string `json.v1:"user_id" json.v2:"userId"`
+//
+// binds user_id for v1 sources and userId for v2 sources. Any tag may be
+// qualified, like environment variants (see EnvironmentParser), which
+// versions take precedence over.
+//
+// The version of a source is the value of the first of Bindings found in
+// it, e.g. the API-Version header. Each version has its own cached parse
+// chains.
+type VersionOpts struct {
+	// Bindings the version is read from, in priority order, e.g.
+	// Binding{Name: HeaderTagBinding, Identifier: "API-Version"}.
+	Bindings []Binding
+	// Versions are the supported versions. Sources of other versions fail
+	// with ErrUnsupportedVersion.
+	Versions []string
+	// Default is the version of sources without one. If empty, they are
+	// parsed with unqualified tags only.
+	Default string
+}
+
+// newVersionedPCManagers creates the PCManagers of the versions of opts,
+// keyed by version.
+func newVersionedPCManagers[S any](
+	handler BindingHandlerFunc[S], pcmOpts PCManagerOpts, opts VersionOpts,
+) map[string]*PCManager[S] {
+
+	if len(opts.Versions) == 0 {
+		return nil
+	}
+
+	managers := make(map[string]*PCManager[S], len(opts.Versions))
+	for _, version := range opts.Versions {
+		versionOpts := pcmOpts
+		versionOpts.tagOpts.Version = version
+		managers[version] = NewPCManager(handler, versionOpts)
+	}
+	return managers
+}
+
+// chainManager returns the PCManager that builds the chains for the
+// version of source, and that version, see VersionOpts. Unversioned
+// parsers and sources use PCMgr.
+func (base *BaseMBParser[S, C]) chainManager(source *S) (*PCManager[S], string, error) {
+	if len(base.versioned) == 0 {
+		return base.PCMgr, "", nil
+	}
+
+	version := base.versionOpts.Default
+	for _, binding := range base.versionOpts.Bindings {
+		result := base.bindingHandlerAdapter(source, binding)
+		if result.Error != nil {
+			return nil, "", fmt.Errorf("%w: %w", ErrUnsupportedVersion, result.Error)
+		}
+		if result.Found && result.Value != nil {
+			version = fmt.Sprintf("%v", result.Value)
+			break
+		}
+	}
+
+	pcm, err := base.versionManager(version)
+	return pcm, version, err
+}
+
+// versionManager returns the PCManager of version, or PCMgr if version
+// is empty.
+func (base *BaseMBParser[S, C]) versionManager(version string) (*PCManager[S], error) {
+	if version == "" {
+		return base.PCMgr, nil
+	}
+
+	pcm, ok := base.versioned[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedVersion, version)
+	}
+	return pcm, nil
+}
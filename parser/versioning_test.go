@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPRequestParser_Versions(t *testing.T) {
+	type User struct {
+		UserID string `json:"user_id" json.v2:"userId"`
+		Name   string `json:"name"`
+		Plan   string `json:"plan,omitempty" default:"free" default.v2:"trial"`
+	}
+
+	parser := NewHTTPRequestParserWithOpts(HTTPRequestParserOpts{
+		Versions: VersionOpts{
+			Bindings: []Binding{
+				{Name: HeaderTagBinding, Identifier: "API-Version"},
+				{Name: QueryTagBinding, Identifier: "version"},
+			},
+			Versions: []string{"v1", "v2"},
+			Default:  "v1",
+		},
+	})
+
+	newRequest := func(t *testing.T, url, body string) *http.Request {
+		req := newTestRequest(t, "POST", url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	var user User
+	require.NoError(t, parser.Parse(newRequest(t, "http://example.com/", `{"user_id": "u1", "name": "Ann"}`), &user))
+	assert.Equal(t, User{UserID: "u1", Name: "Ann", Plan: "free"}, user, "sources without a version use the default")
+
+	req := newRequest(t, "http://example.com/", `{"userId": "u2", "name": "Bob"}`)
+	req.Header.Set("API-Version", "v2")
+	user = User{}
+	require.NoError(t, parser.Parse(req, &user))
+	assert.Equal(t, User{UserID: "u2", Name: "Bob", Plan: "trial"}, user)
+
+	user = User{}
+	require.NoError(t, parser.Parse(newRequest(t, "http://example.com/?version=v2", `{"userId": "u3", "name": "Cy"}`), &user))
+	assert.Equal(t, "u3", user.UserID, "later bindings are tried in order")
+
+	t.Run("Report", func(t *testing.T) {
+		req := newRequest(t, "http://example.com/", `{"userId": "u2", "name": "Bob"}`)
+		req.Header.Set("API-Version", "v2")
+
+		var user User
+		_, err := parser.ParseWithReport(req, &user)
+		require.NoError(t, err)
+		assert.Equal(t, "u2", user.UserID)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		req := newRequest(t, "http://example.com/", `{"user_id": "u1", "name": "Ann"}`)
+		req.Header.Set("API-Version", "v3")
+		err := parser.Parse(req, &User{})
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+}
@@ -0,0 +1,1595 @@
+// Code generated by go run ./internal/genapi; DO NOT EDIT.
+
+package pave
+
+import (
+	"net/http"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+// AcceptValue is parser.AcceptValue.
+type AcceptValue = parser.AcceptValue
+
+// AcceptList is parser.AcceptList.
+type AcceptList = parser.AcceptList
+
+// BindingCacheOpts is parser.BindingCacheOpts.
+type BindingCacheOpts = parser.BindingCacheOpts
+
+// AzureHTTPRequest is parser.AzureHTTPRequest.
+type AzureHTTPRequest = parser.AzureHTTPRequest
+
+// AzureHTTPRequestParser is parser.AzureHTTPRequestParser.
+type AzureHTTPRequestParser = parser.AzureHTTPRequestParser
+
+// BinaryParser is parser.BinaryParser.
+type BinaryParser = parser.BinaryParser
+
+// BinaryBindingManager is parser.BinaryBindingManager.
+type BinaryBindingManager = parser.BinaryBindingManager
+
+// Binding is parser.Binding.
+type Binding = parser.Binding
+
+// BindingModifiers is parser.BindingModifiers.
+type BindingModifiers = parser.BindingModifiers
+
+// ModifierValueParser is parser.ModifierValueParser.
+type ModifierValueParser = parser.ModifierValueParser
+
+// BindingOpts is parser.BindingOpts.
+type BindingOpts = parser.BindingOpts
+
+// BindingResult is parser.BindingResult.
+type BindingResult = parser.BindingResult
+
+// BindingHandlerFunc is parser.BindingHandlerFunc.
+type BindingHandlerFunc[S any] = parser.BindingHandlerFunc[S]
+
+// BindingHandlerCachedFunc is parser.BindingHandlerCachedFunc.
+type BindingHandlerCachedFunc[S any, C any] = parser.BindingHandlerCachedFunc[S, C]
+
+// BindingManager is parser.BindingManager.
+type BindingManager[Source any, Cached any] = parser.BindingManager[Source, Cached]
+
+// CustomTagField is parser.CustomTagField.
+type CustomTagField = parser.CustomTagField
+
+// CustomTagHandler is parser.CustomTagHandler.
+type CustomTagHandler = parser.CustomTagHandler
+
+// BindingBatchHandler is parser.BindingBatchHandler.
+type BindingBatchHandler[S any] = parser.BindingBatchHandler[S]
+
+// BindingCache is parser.BindingCache.
+type BindingCache[S any, C any] = parser.BindingCache[S, C]
+
+// CacheEntry is parser.CacheEntry.
+type CacheEntry[C any] = parser.CacheEntry[C]
+
+// ScopeFunc is parser.ScopeFunc.
+type ScopeFunc = parser.ScopeFunc
+
+// BodySchema is parser.BodySchema.
+type BodySchema = parser.BodySchema
+
+// BodySchemaFunc is parser.BodySchemaFunc.
+type BodySchemaFunc = parser.BodySchemaFunc
+
+// ChainCompiler is parser.ChainCompiler.
+type ChainCompiler[S any] = parser.ChainCompiler[S]
+
+// ChainCompilerFunc is parser.ChainCompilerFunc.
+type ChainCompilerFunc[S any] = parser.ChainCompilerFunc[S]
+
+// ChainExecutor is parser.ChainExecutor.
+type ChainExecutor[S any] = parser.ChainExecutor[S]
+
+// ChainExecutorFunc is parser.ChainExecutorFunc.
+type ChainExecutorFunc[S any] = parser.ChainExecutorFunc[S]
+
+// FieldChecksum is parser.FieldChecksum.
+type FieldChecksum = parser.FieldChecksum
+
+// CloudEvent is parser.CloudEvent.
+type CloudEvent = parser.CloudEvent
+
+// CloudEventParser is parser.CloudEventParser.
+type CloudEventParser = parser.CloudEventParser
+
+// CloudEventBindingManager is parser.CloudEventBindingManager.
+type CloudEventBindingManager = parser.CloudEventBindingManager
+
+// Color is parser.Color.
+type Color = parser.Color
+
+// ETag is parser.ETag.
+type ETag = parser.ETag
+
+// ETagList is parser.ETagList.
+type ETagList = parser.ETagList
+
+// Preconditions is parser.Preconditions.
+type Preconditions = parser.Preconditions
+
+// ConfigFileOnce is parser.ConfigFileOnce.
+type ConfigFileOnce = parser.ConfigFileOnce
+
+// Deprecation is parser.Deprecation.
+type Deprecation = parser.Deprecation
+
+// FieldChange is parser.FieldChange.
+type FieldChange = parser.FieldChange
+
+// DotEnvData is parser.DotEnvData.
+type DotEnvData = parser.DotEnvData
+
+// DotEnvParser is parser.DotEnvParser.
+type DotEnvParser = parser.DotEnvParser
+
+// EncodedField is parser.EncodedField.
+type EncodedField = parser.EncodedField
+
+// Encoder is parser.Encoder.
+type Encoder = parser.Encoder
+
+// KeyProvider is parser.KeyProvider.
+type KeyProvider = parser.KeyProvider
+
+// KeyProviderFunc is parser.KeyProviderFunc.
+type KeyProviderFunc = parser.KeyProviderFunc
+
+// EnvBlock is parser.EnvBlock.
+type EnvBlock = parser.EnvBlock
+
+// EnvBlockParserOpts is parser.EnvBlockParserOpts.
+type EnvBlockParserOpts = parser.EnvBlockParserOpts
+
+// EnvBlockParser is parser.EnvBlockParser.
+type EnvBlockParser = parser.EnvBlockParser
+
+// EnvironmentParser is parser.EnvironmentParser.
+type EnvironmentParser = parser.EnvironmentParser
+
+// ValueError is parser.ValueError.
+type ValueError = parser.ValueError
+
+// ErrorValueOpts is parser.ErrorValueOpts.
+type ErrorValueOpts = parser.ErrorValueOpts
+
+// FaultOpts is parser.FaultOpts.
+type FaultOpts = parser.FaultOpts
+
+// FaultCounts is parser.FaultCounts.
+type FaultCounts = parser.FaultCounts
+
+// FaultInjectingBindingManager is parser.FaultInjectingBindingManager.
+type FaultInjectingBindingManager[S, C any] = parser.FaultInjectingBindingManager[S, C]
+
+// FlagProvider is parser.FlagProvider.
+type FlagProvider = parser.FlagProvider
+
+// FlagProviderFunc is parser.FlagProviderFunc.
+type FlagProviderFunc = parser.FlagProviderFunc
+
+// FieldMask is parser.FieldMask.
+type FieldMask[T any] = parser.FieldMask[T]
+
+// Frozen is parser.Frozen.
+type Frozen[T any] = parser.Frozen[T]
+
+// Point is parser.Point.
+type Point = parser.Point
+
+// BoundingBox is parser.BoundingBox.
+type BoundingBox = parser.BoundingBox
+
+// IPInfo is parser.IPInfo.
+type IPInfo = parser.IPInfo
+
+// IPEnricher is parser.IPEnricher.
+type IPEnricher = parser.IPEnricher
+
+// IPEnricherFunc is parser.IPEnricherFunc.
+type IPEnricherFunc = parser.IPEnricherFunc
+
+// HTTPAdapterOnce is parser.HTTPAdapterOnce.
+type HTTPAdapterOnce = parser.HTTPAdapterOnce
+
+// HTTPAdapterBindingManager is parser.HTTPAdapterBindingManager.
+type HTTPAdapterBindingManager[S any] = parser.HTTPAdapterBindingManager[S]
+
+// JSONBodyOnce is parser.JSONBodyOnce.
+type JSONBodyOnce = parser.JSONBodyOnce
+
+// HTTPRequestParser is parser.HTTPRequestParser.
+type HTTPRequestParser = parser.HTTPRequestParser
+
+// HTTPRequestParserOpts is parser.HTTPRequestParserOpts.
+type HTTPRequestParserOpts = parser.HTTPRequestParserOpts
+
+// HTTPBindingManager is parser.HTTPBindingManager.
+type HTTPBindingManager = parser.HTTPBindingManager
+
+// HTTPRequestOnce is parser.HTTPRequestOnce.
+type HTTPRequestOnce = parser.HTTPRequestOnce
+
+// QueryDecodingMode is parser.QueryDecodingMode.
+type QueryDecodingMode = parser.QueryDecodingMode
+
+// INIData is parser.INIData.
+type INIData = parser.INIData
+
+// INIParser is parser.INIParser.
+type INIParser = parser.INIParser
+
+// InterfaceFactory is parser.InterfaceFactory.
+type InterfaceFactory = parser.InterfaceFactory
+
+// JSONByteSliceSourceParser is parser.JSONByteSliceSourceParser.
+type JSONByteSliceSourceParser = parser.JSONByteSliceSourceParser
+
+// JSONStringSourceParser is parser.JSONStringSourceParser.
+type JSONStringSourceParser = parser.JSONStringSourceParser
+
+// MailMessageParser is parser.MailMessageParser.
+type MailMessageParser = parser.MailMessageParser
+
+// MailMessageOnce is parser.MailMessageOnce.
+type MailMessageOnce = parser.MailMessageOnce
+
+// MailBindingManager is parser.MailBindingManager.
+type MailBindingManager = parser.MailBindingManager
+
+// TemplateDataParser is parser.TemplateDataParser.
+type TemplateDataParser = parser.TemplateDataParser
+
+// TemplateDataBindingManager is parser.TemplateDataBindingManager.
+type TemplateDataBindingManager = parser.TemplateDataBindingManager
+
+// MaskedParser is parser.MaskedParser.
+type MaskedParser = parser.MaskedParser
+
+// MoreErrors is parser.MoreErrors.
+type MoreErrors = parser.MoreErrors
+
+// ErrorLimit is parser.ErrorLimit.
+type ErrorLimit = parser.ErrorLimit
+
+// Money is parser.Money.
+type Money = parser.Money
+
+// BaseMBParser is parser.BaseMBParser.
+type BaseMBParser[S any, C any] = parser.BaseMBParser[S, C]
+
+// BaseMBParserOpts is parser.BaseMBParserOpts.
+type BaseMBParserOpts = parser.BaseMBParserOpts
+
+// ObjectKey is parser.ObjectKey.
+type ObjectKey = parser.ObjectKey
+
+// ObjectKeyParser is parser.ObjectKeyParser.
+type ObjectKeyParser = parser.ObjectKeyParser
+
+// Opt is parser.Opt.
+type Opt[T any] = parser.Opt[T]
+
+// Page is parser.Page.
+type Page = parser.Page
+
+// Cursor is parser.Cursor.
+type Cursor = parser.Cursor
+
+// RequiredFieldError is parser.RequiredFieldError.
+type RequiredFieldError = parser.RequiredFieldError
+
+// FieldError is parser.FieldError.
+type FieldError = parser.FieldError
+
+// ParseChain is parser.ParseChain.
+type ParseChain[S any] = parser.ParseChain[S]
+
+// FieldDefault is parser.FieldDefault.
+type FieldDefault = parser.FieldDefault
+
+// ParseStep is parser.ParseStep.
+type ParseStep[S any] = parser.ParseStep[S]
+
+// PCManager is parser.PCManager.
+type PCManager[S any] = parser.PCManager[S]
+
+// PCManagerOpts is parser.PCManagerOpts.
+type PCManagerOpts = parser.PCManagerOpts
+
+// UnexportedFieldMode is parser.UnexportedFieldMode.
+type UnexportedFieldMode = parser.UnexportedFieldMode
+
+// ParseReport is parser.ParseReport.
+type ParseReport = parser.ParseReport
+
+// StepTiming is parser.StepTiming.
+type StepTiming = parser.StepTiming
+
+// ReportingParser is parser.ReportingParser.
+type ReportingParser = parser.ReportingParser
+
+// Parser is parser.Parser.
+type Parser = parser.Parser
+
+// ParserFactory is parser.ParserFactory.
+type ParserFactory = parser.ParserFactory
+
+// ParseError is parser.ParseError.
+type ParseError = parser.ParseError
+
+// ValidationError is parser.ValidationError.
+type ValidationError = parser.ValidationError
+
+// Validatable is parser.Validatable.
+type Validatable = parser.Validatable
+
+// ParserRegistry is parser.ParserRegistry.
+type ParserRegistry = parser.ParserRegistry
+
+// ParserRegistryContext is parser.ParserRegistryContext.
+type ParserRegistryContext = parser.ParserRegistryContext
+
+// ParserRegistryOpts is parser.ParserRegistryOpts.
+type ParserRegistryOpts = parser.ParserRegistryOpts
+
+// InvalidateOpts is parser.InvalidateOpts.
+type InvalidateOpts = parser.InvalidateOpts
+
+// PathValueExtractor is parser.PathValueExtractor.
+type PathValueExtractor = parser.PathValueExtractor
+
+// PathValueExtractorFunc is parser.PathValueExtractorFunc.
+type PathValueExtractorFunc = parser.PathValueExtractorFunc
+
+// PIIManifest is parser.PIIManifest.
+type PIIManifest = parser.PIIManifest
+
+// PIIField is parser.PIIField.
+type PIIField = parser.PIIField
+
+// PIIHook is parser.PIIHook.
+type PIIHook = parser.PIIHook
+
+// Range is parser.Range.
+type Range = parser.Range
+
+// RangeSpec is parser.RangeSpec.
+type RangeSpec = parser.RangeSpec
+
+// RateLimit is parser.RateLimit.
+type RateLimit = parser.RateLimit
+
+// RateLimiter is parser.RateLimiter.
+type RateLimiter = parser.RateLimiter
+
+// RateLimiterFunc is parser.RateLimiterFunc.
+type RateLimiterFunc = parser.RateLimiterFunc
+
+// SourceSnapshot is parser.SourceSnapshot.
+type SourceSnapshot = parser.SourceSnapshot
+
+// BindingRead is parser.BindingRead.
+type BindingRead = parser.BindingRead
+
+// Replayer is parser.Replayer.
+type Replayer = parser.Replayer
+
+// TransientError is parser.TransientError.
+type TransientError = parser.TransientError
+
+// CronSchedule is parser.CronSchedule.
+type CronSchedule = parser.CronSchedule
+
+// RRule is parser.RRule.
+type RRule = parser.RRule
+
+// ShadowOpts is parser.ShadowOpts.
+type ShadowOpts = parser.ShadowOpts
+
+// ShadowParser is parser.ShadowParser.
+type ShadowParser = parser.ShadowParser
+
+// ShadowReport is parser.ShadowReport.
+type ShadowReport = parser.ShadowReport
+
+// Divergence is parser.Divergence.
+type Divergence = parser.Divergence
+
+// SignatureVerifier is parser.SignatureVerifier.
+type SignatureVerifier = parser.SignatureVerifier
+
+// SignedPayloadFunc is parser.SignedPayloadFunc.
+type SignedPayloadFunc = parser.SignedPayloadFunc
+
+// HMACVerifier is parser.HMACVerifier.
+type HMACVerifier = parser.HMACVerifier
+
+// Ed25519Verifier is parser.Ed25519Verifier.
+type Ed25519Verifier = parser.Ed25519Verifier
+
+// FilterOp is parser.FilterOp.
+type FilterOp = parser.FilterOp
+
+// SortSpec is parser.SortSpec.
+type SortSpec = parser.SortSpec
+
+// SortSpecs is parser.SortSpecs.
+type SortSpecs = parser.SortSpecs
+
+// FilterSpec is parser.FilterSpec.
+type FilterSpec = parser.FilterSpec
+
+// FilterSpecs is parser.FilterSpecs.
+type FilterSpecs = parser.FilterSpecs
+
+// QueryFields is parser.QueryFields.
+type QueryFields = parser.QueryFields
+
+// StepGroup is parser.StepGroup.
+type StepGroup[S any] = parser.StepGroup[S]
+
+// TableRow is parser.TableRow.
+type TableRow = parser.TableRow
+
+// TableRowParser is parser.TableRowParser.
+type TableRowParser = parser.TableRowParser
+
+// TableBindingManager is parser.TableBindingManager.
+type TableBindingManager = parser.TableBindingManager
+
+// CellError is parser.CellError.
+type CellError = parser.CellError
+
+// TagIssue is parser.TagIssue.
+type TagIssue = parser.TagIssue
+
+// TagReport is parser.TagReport.
+type TagReport = parser.TagReport
+
+// ParseTagOpts is parser.ParseTagOpts.
+type ParseTagOpts = parser.ParseTagOpts
+
+// ParseTag is parser.ParseTag.
+type ParseTag = parser.ParseTag
+
+// DefaultTag is parser.DefaultTag.
+type DefaultTag = parser.DefaultTag
+
+// RecursiveTag is parser.RecursiveTag.
+type RecursiveTag = parser.RecursiveTag
+
+// PartTag is parser.PartTag.
+type PartTag = parser.PartTag
+
+// CustomTag is parser.CustomTag.
+type CustomTag = parser.CustomTag
+
+// BindingTag is parser.BindingTag.
+type BindingTag = parser.BindingTag
+
+// BindingState is parser.BindingState.
+type BindingState = parser.BindingState
+
+// PropertyOpts is parser.PropertyOpts.
+type PropertyOpts = parser.PropertyOpts
+
+// PropertyViolation is parser.PropertyViolation.
+type PropertyViolation = parser.PropertyViolation
+
+// TagValidator is parser.TagValidator.
+type TagValidator = parser.TagValidator
+
+// TenantID is parser.TenantID.
+type TenantID = parser.TenantID
+
+// TenantResolver is parser.TenantResolver.
+type TenantResolver = parser.TenantResolver
+
+// TenantResolverFunc is parser.TenantResolverFunc.
+type TenantResolverFunc = parser.TenantResolverFunc
+
+// TenantResolvers is parser.TenantResolvers.
+type TenantResolvers = parser.TenantResolvers
+
+// TypeConverter is parser.TypeConverter.
+type TypeConverter = parser.TypeConverter
+
+// Union2 is parser.Union2.
+type Union2[A, B any] = parser.Union2[A, B]
+
+// URLTemplateMatch is parser.URLTemplateMatch.
+type URLTemplateMatch = parser.URLTemplateMatch
+
+// URLTemplateParser is parser.URLTemplateParser.
+type URLTemplateParser = parser.URLTemplateParser
+
+// URLTemplateOnce is parser.URLTemplateOnce.
+type URLTemplateOnce = parser.URLTemplateOnce
+
+// URLTemplateBindingManager is parser.URLTemplateBindingManager.
+type URLTemplateBindingManager = parser.URLTemplateBindingManager
+
+// ValueTransform is parser.ValueTransform.
+type ValueTransform = parser.ValueTransform
+
+// ValueModifier is parser.ValueModifier.
+type ValueModifier = parser.ValueModifier
+
+// VersionOpts is parser.VersionOpts.
+type VersionOpts = parser.VersionOpts
+
+const (
+	DefaultMaxUncachedBindings              = parser.DefaultMaxUncachedBindings
+	BigEndian                               = parser.BigEndian
+	LittleEndian                            = parser.LittleEndian
+	CRC32Checksum                           = parser.CRC32Checksum
+	SHA256Checksum                          = parser.SHA256Checksum
+	CountryCodeAlpha2                       = parser.CountryCodeAlpha2
+	CountryCodeAlpha3                       = parser.CountryCodeAlpha3
+	CountryCodeAny                          = parser.CountryCodeAny
+	IfMatchHeader                           = parser.IfMatchHeader
+	IfNoneMatchHeader                       = parser.IfNoneMatchHeader
+	IfModifiedSinceHeader                   = parser.IfModifiedSinceHeader
+	IfUnmodifiedSinceHeader                 = parser.IfUnmodifiedSinceHeader
+	ParseTagPrefix                          = parser.ParseTagPrefix
+	DefaultValueSubTagPrefix                = parser.DefaultValueSubTagPrefix
+	DefaultValueSubTagPrefixWithKVDelimiter = parser.DefaultValueSubTagPrefixWithKVDelimiter
+	PartSubTagPrefix                        = parser.PartSubTagPrefix
+	DefaultKeyValueTagDelimiter             = parser.DefaultKeyValueTagDelimiter
+	CommaDelimeter                          = parser.CommaDelimeter
+	ModifierKeyValueDelimiter               = parser.ModifierKeyValueDelimiter
+	PreserveTagName                         = parser.PreserveTagName
+	ValidateTagName                         = parser.ValidateTagName
+	UseTagName                              = parser.UseTagName
+	PIITagName                              = parser.PIITagName
+	JsonTagBinding                          = parser.JsonTagBinding
+	CookieTagBinding                        = parser.CookieTagBinding
+	HeaderTagBinding                        = parser.HeaderTagBinding
+	QueryTagBinding                         = parser.QueryTagBinding
+	MapValueTagBinding                      = parser.MapValueTagBinding
+	BasicAuthTagBinding                     = parser.BasicAuthTagBinding
+	TrailerTagBinding                       = parser.TrailerTagBinding
+	MultipartTagBinding                     = parser.MultipartTagBinding
+	RangeTagBinding                         = parser.RangeTagBinding
+	AcceptTagBinding                        = parser.AcceptTagBinding
+	LocaleTagBinding                        = parser.LocaleTagBinding
+	GeoIPTagBinding                         = parser.GeoIPTagBinding
+	FlagTagBinding                          = parser.FlagTagBinding
+	RateLimitTagBinding                     = parser.RateLimitTagBinding
+	TenantTagBinding                        = parser.TenantTagBinding
+	ConditionalTagBinding                   = parser.ConditionalTagBinding
+	EnvTagBinding                           = parser.EnvTagBinding
+	FormTagBinding                          = parser.FormTagBinding
+	DataTagBinding                          = parser.DataTagBinding
+	INITagBinding                           = parser.INITagBinding
+	PathTagBinding                          = parser.PathTagBinding
+	BinaryTagBinding                        = parser.BinaryTagBinding
+	ColumnTagBinding                        = parser.ColumnTagBinding
+	MailHeaderTagBinding                    = parser.MailHeaderTagBinding
+	MailBodyTagBinding                      = parser.MailBodyTagBinding
+	CloudEventTagBinding                    = parser.CloudEventTagBinding
+	URLSegmentTagBinding                    = parser.URLSegmentTagBinding
+	KeyTagBinding                           = parser.KeyTagBinding
+	AttributeTagBinding                     = parser.AttributeTagBinding
+	SQSTagBinding                           = parser.SQSTagBinding
+	SNSTagBinding                           = parser.SNSTagBinding
+	MetadataTagBinding                      = parser.MetadataTagBinding
+	MessageTagBinding                       = parser.MessageTagBinding
+	OmitEmptyBindingModifier                = parser.OmitEmptyBindingModifier
+	OmitNilBindingModifier                  = parser.OmitNilBindingModifier
+	OmitErrorBindingModifier                = parser.OmitErrorBindingModifier
+	AllowDupBindingModifier                 = parser.AllowDupBindingModifier
+	FlattenBindingModifier                  = parser.FlattenBindingModifier
+	ScopeBindingModifier                    = parser.ScopeBindingModifier
+	LiteralBindingModifier                  = parser.LiteralBindingModifier
+	CurrencyBindingModifier                 = parser.CurrencyBindingModifier
+	MaxBindingModifier                      = parser.MaxBindingModifier
+	OffersBindingModifier                   = parser.OffersBindingModifier
+	SupportedBindingModifier                = parser.SupportedBindingModifier
+	KeyBindingModifier                      = parser.KeyBindingModifier
+	AllowBindingModifier                    = parser.AllowBindingModifier
+	BitBindingModifier                      = parser.BitBindingModifier
+	SignedBindingModifier                   = parser.SignedBindingModifier
+	LenBindingModifier                      = parser.LenBindingModifier
+	EndianBindingModifier                   = parser.EndianBindingModifier
+	OffsetBindingModifier                   = parser.OffsetBindingModifier
+	DeprecatedBindingModifier               = parser.DeprecatedBindingModifier
+	SunsetBindingModifier                   = parser.SunsetBindingModifier
+	EncryptedBindingModifier                = parser.EncryptedBindingModifier
+	ChecksumBindingModifier                 = parser.ChecksumBindingModifier
+	ExplodeBindingModifier                  = parser.ExplodeBindingModifier
+	DelimBindingModifier                    = parser.DelimBindingModifier
+	LayoutBindingModifier                   = parser.LayoutBindingModifier
+	EnumBindingModifier                     = parser.EnumBindingModifier
+	E164BindingModifier                     = parser.E164BindingModifier
+	HTTPRequestParserName                   = parser.HTTPRequestParserName
+	JSONByteSliceParserName                 = parser.JSONByteSliceParserName
+	JSONStringParserName                    = parser.JSONStringParserName
+	StringMapParserName                     = parser.StringMapParserName
+	StringAnyMapParserName                  = parser.StringAnyMapParserName
+	TemplateDataParserName                  = parser.TemplateDataParserName
+	INIParserName                           = parser.INIParserName
+	DotEnvParserName                        = parser.DotEnvParserName
+	EnvBlockParserName                      = parser.EnvBlockParserName
+	JSValueParserName                       = parser.JSValueParserName
+	MailMessageParserName                   = parser.MailMessageParserName
+	TableRowParserName                      = parser.TableRowParserName
+	BinaryParserName                        = parser.BinaryParserName
+	AzureHTTPRequestParserName              = parser.AzureHTTPRequestParserName
+	CloudEventParserName                    = parser.CloudEventParserName
+	URLTemplateParserName                   = parser.URLTemplateParserName
+	ObjectKeyParserName                     = parser.ObjectKeyParserName
+	APIGatewayProxyParserName               = parser.APIGatewayProxyParserName
+	SQSMessageParserName                    = parser.SQSMessageParserName
+	SNSEntityParserName                     = parser.SNSEntityParserName
+	PubSubMessageParserName                 = parser.PubSubMessageParserName
+	ContentEncodingUTF8                     = parser.ContentEncodingUTF8
+	ContentTypeApplicationJSON              = parser.ContentTypeApplicationJSON
+	ContentTypeDelimiter                    = parser.ContentTypeDelimiter
+	DefaultDirectBindingLimit               = parser.DefaultDirectBindingLimit
+	AESGCMCipher                            = parser.AESGCMCipher
+	RedactedErrorValue                      = parser.RedactedErrorValue
+	GeoIPCountry                            = parser.GeoIPCountry
+	GeoIPASN                                = parser.GeoIPASN
+	GeoIPOrganization                       = parser.GeoIPOrganization
+	BasicAuthUsernamePart                   = parser.BasicAuthUsernamePart
+	BasicAuthPasswordPart                   = parser.BasicAuthPasswordPart
+	QueryDecodingFlat                       = parser.QueryDecodingFlat
+	QueryDecodingBracket                    = parser.QueryDecodingBracket
+	MailBodyTextPart                        = parser.MailBodyTextPart
+	MailBodyHTMLPart                        = parser.MailBodyHTMLPart
+	DefaultMaxErrors                        = parser.DefaultMaxErrors
+	DefaultMultipartMaxMemory               = parser.DefaultMultipartMaxMemory
+	HiveDefaultPartition                    = parser.HiveDefaultPartition
+	DefaultPageSize                         = parser.DefaultPageSize
+	MaxPageSize                             = parser.MaxPageSize
+	UnexportedFieldsSkip                    = parser.UnexportedFieldsSkip
+	UnexportedFieldsSetter                  = parser.UnexportedFieldsSetter
+	UnexportedFieldsUnsafe                  = parser.UnexportedFieldsUnsafe
+	RangeUnitPart                           = parser.RangeUnitPart
+	RangeStartPart                          = parser.RangeStartPart
+	RangeEndPart                            = parser.RangeEndPart
+	RangeSuffixPart                         = parser.RangeSuffixPart
+	RateLimitLimitPart                      = parser.RateLimitLimitPart
+	RateLimitRemainingPart                  = parser.RateLimitRemainingPart
+	RateLimitResetPart                      = parser.RateLimitResetPart
+	RateLimitKeyClientIP                    = parser.RateLimitKeyClientIP
+	FilterEq                                = parser.FilterEq
+	FilterNe                                = parser.FilterNe
+	FilterLt                                = parser.FilterLt
+	FilterLe                                = parser.FilterLe
+	FilterGt                                = parser.FilterGt
+	FilterGe                                = parser.FilterGe
+	FilterIn                                = parser.FilterIn
+	FilterLike                              = parser.FilterLike
+	DefaultPropertyRuns                     = parser.DefaultPropertyRuns
+	BindingPresent                          = parser.BindingPresent
+	BindingMissing                          = parser.BindingMissing
+	BindingEmpty                            = parser.BindingEmpty
+	BindingNil                              = parser.BindingNil
+	BindingFailed                           = parser.BindingFailed
+)
+
+var (
+	ErrInvalidAccept                  = parser.ErrInvalidAccept
+	ErrNotAcceptable                  = parser.ErrNotAcceptable
+	ErrInvalidAzureRequest            = parser.ErrInvalidAzureRequest
+	ErrInvalidBinaryBinding           = parser.ErrInvalidBinaryBinding
+	ErrBindingBatchResults            = parser.ErrBindingBatchResults
+	ErrBindingCacheNotInitialized     = parser.ErrBindingCacheNotInitialized
+	ErrBindingCacheNilEntry           = parser.ErrBindingCacheNilEntry
+	ErrBodySchema                     = parser.ErrBodySchema
+	ErrFailedToCompileChain           = parser.ErrFailedToCompileChain
+	ErrUnsupportedChecksum            = parser.ErrUnsupportedChecksum
+	ErrUnknownChecksumField           = parser.ErrUnknownChecksumField
+	ErrChecksumMismatch               = parser.ErrChecksumMismatch
+	ErrInvalidCloudEvent              = parser.ErrInvalidCloudEvent
+	ErrInvalidCountryCode             = parser.ErrInvalidCountryCode
+	ErrInvalidLanguageTag             = parser.ErrInvalidLanguageTag
+	ErrInvalidTimezone                = parser.ErrInvalidTimezone
+	ErrInvalidColor                   = parser.ErrInvalidColor
+	ErrInvalidETag                    = parser.ErrInvalidETag
+	ErrInvalidPrecondition            = parser.ErrInvalidPrecondition
+	HTTPRequestType                   = parser.HTTPRequestType
+	JSONByteSliceType                 = parser.JSONByteSliceType
+	StringType                        = parser.StringType
+	StringMapType                     = parser.StringMapType
+	StringMapAnyType                  = parser.StringMapAnyType
+	DurationType                      = parser.DurationType
+	TimeType                          = parser.TimeType
+	FileHeaderType                    = parser.FileHeaderType
+	ErrDiffTypeMismatch               = parser.ErrDiffTypeMismatch
+	ErrInvalidDotEnv                  = parser.ErrInvalidDotEnv
+	ErrEncodeNotStruct                = parser.ErrEncodeNotStruct
+	ErrUnsupportedEncodeType          = parser.ErrUnsupportedEncodeType
+	ErrJSONEncodePathConflict         = parser.ErrJSONEncodePathConflict
+	ErrNoKeyProvider                  = parser.ErrNoKeyProvider
+	ErrUnsupportedCipher              = parser.ErrUnsupportedCipher
+	ErrDecryptionFailed               = parser.ErrDecryptionFailed
+	ErrInvalidEnumValue               = parser.ErrInvalidEnumValue
+	ErrInvalidEnvBlock                = parser.ErrInvalidEnvBlock
+	ErrInjectedFault                  = parser.ErrInjectedFault
+	ErrInvalidFaultOpt                = parser.ErrInvalidFaultOpt
+	ErrNoFlagProvider                 = parser.ErrNoFlagProvider
+	ErrFlagEvaluationFailed           = parser.ErrFlagEvaluationFailed
+	ErrUnknownMaskField               = parser.ErrUnknownMaskField
+	ErrInvalidLatitude                = parser.ErrInvalidLatitude
+	ErrInvalidLongitude               = parser.ErrInvalidLongitude
+	ErrInvalidPoint                   = parser.ErrInvalidPoint
+	ErrInvalidBoundingBox             = parser.ErrInvalidBoundingBox
+	ErrOutsideBoundingBox             = parser.ErrOutsideBoundingBox
+	ErrInvalidCoordinate              = parser.ErrInvalidCoordinate
+	ErrNoIPEnricher                   = parser.ErrNoIPEnricher
+	ErrInvalidClientIP                = parser.ErrInvalidClientIP
+	ErrArrayLength                    = parser.ErrArrayLength
+	ErrUnsupportedFieldType           = parser.ErrUnsupportedFieldType
+	ErrValueOverflow                  = parser.ErrValueOverflow
+	ErrEmptyValue                     = parser.ErrEmptyValue
+	ErrInvalidSubDocument             = parser.ErrInvalidSubDocument
+	ErrInvalidBasicAuth               = parser.ErrInvalidBasicAuth
+	ErrBodyTooLarge                   = parser.ErrBodyTooLarge
+	ErrUnsupportedMediaType           = parser.ErrUnsupportedMediaType
+	ErrInvalidBracketQueryKey         = parser.ErrInvalidBracketQueryKey
+	ErrBracketQueryConflict           = parser.ErrBracketQueryConflict
+	ErrInvalidINI                     = parser.ErrInvalidINI
+	ErrNoInterfaceFactory             = parser.ErrNoInterfaceFactory
+	ErrInterfaceFactoryResult         = parser.ErrInterfaceFactoryResult
+	ErrUnknownDiscriminator           = parser.ErrUnknownDiscriminator
+	ErrMissingDiscriminator           = parser.ErrMissingDiscriminator
+	ErrFactoryTypeNotInterface        = parser.ErrFactoryTypeNotInterface
+	ErrInvalidMailMessage             = parser.ErrInvalidMailMessage
+	ErrInvalidDataPath                = parser.ErrInvalidDataPath
+	ErrMaskUnsupported                = parser.ErrMaskUnsupported
+	ErrTooManyErrors                  = parser.ErrTooManyErrors
+	ErrInvalidMoney                   = parser.ErrInvalidMoney
+	ErrUnknownCurrency                = parser.ErrUnknownCurrency
+	ErrMissingCurrency                = parser.ErrMissingCurrency
+	ErrCurrencyMismatch               = parser.ErrCurrencyMismatch
+	ErrMoneyPrecision                 = parser.ErrMoneyPrecision
+	ErrInvalidObjectKey               = parser.ErrInvalidObjectKey
+	ErrInvalidPage                    = parser.ErrInvalidPage
+	ErrInvalidCursor                  = parser.ErrInvalidCursor
+	ErrNoStepBindings                 = parser.ErrNoStepBindings
+	ErrFailedToParseTag               = parser.ErrFailedToParseTag
+	ErrAllBindingsFailedNoDefault     = parser.ErrAllBindingsFailedNoDefault
+	ErrFailedToBuildSubChain          = parser.ErrFailedToBuildSubChain
+	ErrNilParseChain                  = parser.ErrNilParseChain
+	ErrRequiredFieldNotFound          = parser.ErrRequiredFieldNotFound
+	ErrParseStepNotFound              = parser.ErrParseStepNotFound
+	ErrFailedToHandleCustomTags       = parser.ErrFailedToHandleCustomTags
+	ErrNoSubChain                     = parser.ErrNoSubChain
+	ErrFieldNotAddressable            = parser.ErrFieldNotAddressable
+	ErrJSONTagMismatch                = parser.ErrJSONTagMismatch
+	ErrNoFieldSetter                  = parser.ErrNoFieldSetter
+	ErrInvalidFieldSetter             = parser.ErrInvalidFieldSetter
+	ErrDefaultParsersInUse            = parser.ErrDefaultParsersInUse
+	ErrNoFieldSourcesInTag            = parser.ErrNoFieldSourcesInTag
+	ErrParserAlreadyRegistered        = parser.ErrParserAlreadyRegistered
+	ErrNoParser                       = parser.ErrNoParser
+	ErrNoParserBuiltin                = parser.ErrNoParserBuiltin
+	ErrNoParserRegistered             = parser.ErrNoParserRegistered
+	ErrMultipleParsersAvailable       = parser.ErrMultipleParsersAvailable
+	ErrParserNotFound                 = parser.ErrParserNotFound
+	ErrNoParseExecutionChain          = parser.ErrNoParseExecutionChain
+	ErrInvalidParseExecutionChainType = parser.ErrInvalidParseExecutionChainType
+	ErrNilDest                        = parser.ErrNilDest
+	ErrDestNotStructPtr               = parser.ErrDestNotStructPtr
+	ErrSourceTypeMismatch             = parser.ErrSourceTypeMismatch
+	ServeMuxPathValues                = parser.ServeMuxPathValues
+	ErrEmptyPIICategory               = parser.ErrEmptyPIICategory
+	ErrUnknownProfile                 = parser.ErrUnknownProfile
+	ErrInvalidRange                   = parser.ErrInvalidRange
+	ErrRangeNotSatisfiable            = parser.ErrRangeNotSatisfiable
+	ErrInvalidRateLimit               = parser.ErrInvalidRateLimit
+	ErrUnknownRateLimiter             = parser.ErrUnknownRateLimiter
+	ErrInvalidRateLimitKey            = parser.ErrInvalidRateLimitKey
+	ErrInvalidCron                    = parser.ErrInvalidCron
+	ErrInvalidRRule                   = parser.ErrInvalidRRule
+	ErrInvalidSignature               = parser.ErrInvalidSignature
+	ErrUnknownVerifier                = parser.ErrUnknownVerifier
+	ErrInvalidSort                    = parser.ErrInvalidSort
+	ErrInvalidFilter                  = parser.ErrInvalidFilter
+	ErrUnknownQueryFields             = parser.ErrUnknownQueryFields
+	ErrFieldNotSortable               = parser.ErrFieldNotSortable
+	ErrFieldNotFilterable             = parser.ErrFieldNotFilterable
+	ErrFilterOpNotAllowed             = parser.ErrFilterOpNotAllowed
+	ErrEmptyTable                     = parser.ErrEmptyTable
+	ErrNoParseTagInField              = parser.ErrNoParseTagInField
+	ErrUnallowedBindingName           = parser.ErrUnallowedBindingName
+	ErrEmptyBindingIdentifier         = parser.ErrEmptyBindingIdentifier
+	ErrInvalidBindingTagFormat        = parser.ErrInvalidBindingTagFormat
+	ErrInvalidBindingInfoFormat       = parser.ErrInvalidBindingInfoFormat
+	ErrUnallowedBindingModifier       = parser.ErrUnallowedBindingModifier
+	ErrEmptyTagValue                  = parser.ErrEmptyTagValue
+	ErrEmptyPartTag                   = parser.ErrEmptyPartTag
+	ErrInvalidModifierValue           = parser.ErrInvalidModifierValue
+	ErrDuplicateBinding               = parser.ErrDuplicateBinding
+	ErrFlattenNotStruct               = parser.ErrFlattenNotStruct
+	ErrScopeNotStruct                 = parser.ErrScopeNotStruct
+	ErrMaxNotRange                    = parser.ErrMaxNotRange
+	ErrPropertyViolation              = parser.ErrPropertyViolation
+	ErrChecksumProperty               = parser.ErrChecksumProperty
+	ErrNoTagValidator                 = parser.ErrNoTagValidator
+	ErrInvalidTenantID                = parser.ErrInvalidTenantID
+	ErrUnknownTenantResolver          = parser.ErrUnknownTenantResolver
+	ErrInvalidDuration                = parser.ErrInvalidDuration
+	ErrInvalidTime                    = parser.ErrInvalidTime
+	ErrTypeConverterResult            = parser.ErrTypeConverterResult
+	ErrInvalidBigNumber               = parser.ErrInvalidBigNumber
+	ErrNoUnionAlternative             = parser.ErrNoUnionAlternative
+	ErrInvalidURLTemplate             = parser.ErrInvalidURLTemplate
+	ErrURLTemplateMismatch            = parser.ErrURLTemplateMismatch
+	ErrUnsupportedVersion             = parser.ErrUnsupportedVersion
+)
+
+// ParseAccept calls parser.ParseAccept.
+func ParseAccept(value string) (AcceptList, error) {
+	installTagValidator()
+	return parser.ParseAccept(value)
+}
+
+// NewAzureHTTPRequestParser calls parser.NewAzureHTTPRequestParser.
+func NewAzureHTTPRequestParser() *AzureHTTPRequestParser {
+	installTagValidator()
+	return parser.NewAzureHTTPRequestParser()
+}
+
+// NewBinaryParser calls parser.NewBinaryParser.
+func NewBinaryParser() *BinaryParser {
+	installTagValidator()
+	return parser.NewBinaryParser()
+}
+
+// ModifierValue calls parser.ModifierValue.
+func ModifierValue[T any](binding Binding, name string) (T, bool) {
+	installTagValidator()
+	return parser.ModifierValue[T](binding, name)
+}
+
+// TypedModifier calls parser.TypedModifier.
+func TypedModifier[T any]() ModifierValueParser {
+	installTagValidator()
+	return parser.TypedModifier[T]()
+}
+
+// BindingResultNotFound calls parser.BindingResultNotFound.
+func BindingResultNotFound() BindingResult {
+	installTagValidator()
+	return parser.BindingResultNotFound()
+}
+
+// BindingResultError calls parser.BindingResultError.
+func BindingResultError(err error) BindingResult {
+	installTagValidator()
+	return parser.BindingResultError(err)
+}
+
+// BindingResultFatal calls parser.BindingResultFatal.
+func BindingResultFatal(err error) BindingResult {
+	installTagValidator()
+	return parser.BindingResultFatal(err)
+}
+
+// BindingResultValue calls parser.BindingResultValue.
+func BindingResultValue(value any) BindingResult {
+	installTagValidator()
+	return parser.BindingResultValue(value)
+}
+
+// BindingResultValues calls parser.BindingResultValues.
+func BindingResultValues(values map[string]any) BindingResult {
+	installTagValidator()
+	return parser.BindingResultValues(values)
+}
+
+// NewBindingCache calls parser.NewBindingCache.
+func NewBindingCache[S any, C any]() *BindingCache[S, C] {
+	installTagValidator()
+	return parser.NewBindingCache[S, C]()
+}
+
+// ConcatScope calls parser.ConcatScope.
+func ConcatScope(prefix string, identifier string) string {
+	installTagValidator()
+	return parser.ConcatScope(prefix, identifier)
+}
+
+// DotScope calls parser.DotScope.
+func DotScope(prefix string, identifier string) string {
+	installTagValidator()
+	return parser.DotScope(prefix, identifier)
+}
+
+// NewCloudEventParser calls parser.NewCloudEventParser.
+func NewCloudEventParser() *CloudEventParser {
+	installTagValidator()
+	return parser.NewCloudEventParser()
+}
+
+// LoadTimezone calls parser.LoadTimezone.
+func LoadTimezone(name string) (*time.Location, error) {
+	installTagValidator()
+	return parser.LoadTimezone(name)
+}
+
+// ParseColor calls parser.ParseColor.
+func ParseColor(value string) (Color, error) {
+	installTagValidator()
+	return parser.ParseColor(value)
+}
+
+// ParseETag calls parser.ParseETag.
+func ParseETag(value string) (ETag, error) {
+	installTagValidator()
+	return parser.ParseETag(value)
+}
+
+// ParseETagList calls parser.ParseETagList.
+func ParseETagList(value string) (ETagList, error) {
+	installTagValidator()
+	return parser.ParseETagList(value)
+}
+
+// SetDeprecationHeaders calls parser.SetDeprecationHeaders.
+func SetDeprecationHeaders(header http.Header, report *ParseReport) {
+	installTagValidator()
+	parser.SetDeprecationHeaders(header, report)
+}
+
+// Diff calls parser.Diff.
+func Diff[T any](a T, b T) ([]FieldChange, error) {
+	installTagValidator()
+	return parser.Diff[T](a, b)
+}
+
+// NewDotEnvParser calls parser.NewDotEnvParser.
+func NewDotEnvParser() *DotEnvParser {
+	installTagValidator()
+	return parser.NewDotEnvParser()
+}
+
+// NewEncoder calls parser.NewEncoder.
+func NewEncoder(tagOpts ParseTagOpts, scopeFuncs map[string]ScopeFunc) *Encoder {
+	installTagValidator()
+	return parser.NewEncoder(tagOpts, scopeFuncs)
+}
+
+// EncodeQuery calls parser.EncodeQuery.
+func EncodeQuery(v any) (url.Values, error) {
+	installTagValidator()
+	return parser.EncodeQuery(v)
+}
+
+// EncodeHeader calls parser.EncodeHeader.
+func EncodeHeader(v any) (http.Header, error) {
+	installTagValidator()
+	return parser.EncodeHeader(v)
+}
+
+// EncodeJSON calls parser.EncodeJSON.
+func EncodeJSON(v any) ([]byte, error) {
+	installTagValidator()
+	return parser.EncodeJSON(v)
+}
+
+// EncodeEnv calls parser.EncodeEnv.
+func EncodeEnv(v any) ([]byte, error) {
+	installTagValidator()
+	return parser.EncodeEnv(v)
+}
+
+// NewHTTPRequest calls parser.NewHTTPRequest.
+func NewHTTPRequest(method string, target string, v any) (*http.Request, error) {
+	installTagValidator()
+	return parser.NewHTTPRequest(method, target, v)
+}
+
+// RegisterKeyProvider calls parser.RegisterKeyProvider.
+func RegisterKeyProvider(provider KeyProvider) {
+	installTagValidator()
+	parser.RegisterKeyProvider(provider)
+}
+
+// UnregisterKeyProvider calls parser.UnregisterKeyProvider.
+func UnregisterKeyProvider() {
+	installTagValidator()
+	parser.UnregisterKeyProvider()
+}
+
+// RegisterEnum calls parser.RegisterEnum.
+func RegisterEnum[T comparable](constants map[string]T) {
+	installTagValidator()
+	parser.RegisterEnum[T](constants)
+}
+
+// UnregisterEnum calls parser.UnregisterEnum.
+func UnregisterEnum[T comparable]() {
+	installTagValidator()
+	parser.UnregisterEnum[T]()
+}
+
+// NewEnvBlockParser calls parser.NewEnvBlockParser.
+func NewEnvBlockParser() *EnvBlockParser {
+	installTagValidator()
+	return parser.NewEnvBlockParser()
+}
+
+// NewEnvBlockParserWithOpts calls parser.NewEnvBlockParserWithOpts.
+func NewEnvBlockParserWithOpts(opts EnvBlockParserOpts) *EnvBlockParser {
+	installTagValidator()
+	return parser.NewEnvBlockParserWithOpts(opts)
+}
+
+// NewFaultInjectingBindingManager calls parser.NewFaultInjectingBindingManager.
+func NewFaultInjectingBindingManager[S, C any](inner BindingManager[S, C], opts FaultOpts) (*FaultInjectingBindingManager[S, C], error) {
+	installTagValidator()
+	return parser.NewFaultInjectingBindingManager[S, C](inner, opts)
+}
+
+// ParseFieldMask calls parser.ParseFieldMask.
+func ParseFieldMask[T any](value string) (FieldMask[T], error) {
+	installTagValidator()
+	return parser.ParseFieldMask[T](value)
+}
+
+// Freeze calls parser.Freeze.
+func Freeze[T any](value T) Frozen[T] {
+	installTagValidator()
+	return parser.Freeze[T](value)
+}
+
+// ParseFrozen calls parser.ParseFrozen.
+func ParseFrozen[T any](source any) (Frozen[T], error) {
+	installTagValidator()
+	return parser.ParseFrozen[T](source)
+}
+
+// ParsePoint calls parser.ParsePoint.
+func ParsePoint(value string) (Point, error) {
+	installTagValidator()
+	return parser.ParsePoint(value)
+}
+
+// ParseBoundingBox calls parser.ParseBoundingBox.
+func ParseBoundingBox(value string) (BoundingBox, error) {
+	installTagValidator()
+	return parser.ParseBoundingBox(value)
+}
+
+// CheckLatitude calls parser.CheckLatitude.
+func CheckLatitude(lat float64) error {
+	installTagValidator()
+	return parser.CheckLatitude(lat)
+}
+
+// CheckLongitude calls parser.CheckLongitude.
+func CheckLongitude(lon float64) error {
+	installTagValidator()
+	return parser.CheckLongitude(lon)
+}
+
+// ClientIP calls parser.ClientIP.
+func ClientIP(req *http.Request, trustedProxies []netip.Prefix) (netip.Addr, error) {
+	installTagValidator()
+	return parser.ClientIP(req, trustedProxies)
+}
+
+// IsSpecialStructType calls parser.IsSpecialStructType.
+func IsSpecialStructType(t reflect.Type) bool {
+	installTagValidator()
+	return parser.IsSpecialStructType(t)
+}
+
+// ParseTypeErasedPointer calls parser.ParseTypeErasedPointer.
+func ParseTypeErasedPointer[S any](source any, dest any, parse func(source *S, dest any) error) error {
+	installTagValidator()
+	return parser.ParseTypeErasedPointer[S](source, dest, parse)
+}
+
+// ParseTypeErasedSlice calls parser.ParseTypeErasedSlice.
+func ParseTypeErasedSlice[S any](source any, dest any, parse func(source []S, dest any) error) error {
+	installTagValidator()
+	return parser.ParseTypeErasedSlice[S](source, dest, parse)
+}
+
+// NewHTTPAdapterBindingManager calls parser.NewHTTPAdapterBindingManager.
+func NewHTTPAdapterBindingManager[S any](convert func(source *S) (*http.Request, error), pathParams func(source *S) map[string]string) *HTTPAdapterBindingManager[S] {
+	installTagValidator()
+	return parser.NewHTTPAdapterBindingManager[S](convert, pathParams)
+}
+
+// NewHTTPRequestParser calls parser.NewHTTPRequestParser.
+func NewHTTPRequestParser() *HTTPRequestParser {
+	installTagValidator()
+	return parser.NewHTTPRequestParser()
+}
+
+// NewHTTPRequestParserWithOpts calls parser.NewHTTPRequestParserWithOpts.
+func NewHTTPRequestParserWithOpts(opts HTTPRequestParserOpts) *HTTPRequestParser {
+	installTagValidator()
+	return parser.NewHTTPRequestParserWithOpts(opts)
+}
+
+// NewHTTPBindingManager calls parser.NewHTTPBindingManager.
+func NewHTTPBindingManager() *HTTPBindingManager {
+	installTagValidator()
+	return parser.NewHTTPBindingManager()
+}
+
+// NewHTTPBindingManagerWithOpts calls parser.NewHTTPBindingManagerWithOpts.
+func NewHTTPBindingManagerWithOpts(opts HTTPRequestParserOpts) *HTTPBindingManager {
+	installTagValidator()
+	return parser.NewHTTPBindingManagerWithOpts(opts)
+}
+
+// NewHTTPRequestOnce calls parser.NewHTTPRequestOnce.
+func NewHTTPRequestOnce() HTTPRequestOnce {
+	installTagValidator()
+	return parser.NewHTTPRequestOnce()
+}
+
+// NewINIParser calls parser.NewINIParser.
+func NewINIParser() *INIParser {
+	installTagValidator()
+	return parser.NewINIParser()
+}
+
+// RegisterInterfaceFactory calls parser.RegisterInterfaceFactory.
+func RegisterInterfaceFactory[I any](factory func(value string) (I, error)) error {
+	installTagValidator()
+	return parser.RegisterInterfaceFactory[I](factory)
+}
+
+// UnregisterInterfaceFactory calls parser.UnregisterInterfaceFactory.
+func UnregisterInterfaceFactory[I any]() {
+	installTagValidator()
+	parser.UnregisterInterfaceFactory[I]()
+}
+
+// DiscriminatorFactory calls parser.DiscriminatorFactory.
+func DiscriminatorFactory[I any](key string, variants map[string]func() I) func(value string) (I, error) {
+	installTagValidator()
+	return parser.DiscriminatorFactory[I](key, variants)
+}
+
+// NewJsonByteSliceSourceParser calls parser.NewJsonByteSliceSourceParser.
+func NewJsonByteSliceSourceParser() *JSONByteSliceSourceParser {
+	installTagValidator()
+	return parser.NewJsonByteSliceSourceParser()
+}
+
+// NewJSONStringSourceParser calls parser.NewJSONStringSourceParser.
+func NewJSONStringSourceParser() *JSONStringSourceParser {
+	installTagValidator()
+	return parser.NewJSONStringSourceParser()
+}
+
+// NewMailMessageParser calls parser.NewMailMessageParser.
+func NewMailMessageParser() *MailMessageParser {
+	installTagValidator()
+	return parser.NewMailMessageParser()
+}
+
+// NewTemplateDataParser calls parser.NewTemplateDataParser.
+func NewTemplateDataParser() *TemplateDataParser {
+	installTagValidator()
+	return parser.NewTemplateDataParser()
+}
+
+// SelectMaskPaths calls parser.SelectMaskPaths.
+func SelectMaskPaths(paths [][]string, field reflect.StructField) (bool, [][]string) {
+	installTagValidator()
+	return parser.SelectMaskPaths(paths, field)
+}
+
+// SplitMaskPaths calls parser.SplitMaskPaths.
+func SplitMaskPaths(paths []string) [][]string {
+	installTagValidator()
+	return parser.SplitMaskPaths(paths)
+}
+
+// HeaderFieldMask calls parser.HeaderFieldMask.
+func HeaderFieldMask(req *http.Request, header string) []string {
+	installTagValidator()
+	return parser.HeaderFieldMask(req, header)
+}
+
+// ParseMoney calls parser.ParseMoney.
+func ParseMoney(value string, currency string) (Money, error) {
+	installTagValidator()
+	return parser.ParseMoney(value, currency)
+}
+
+// IsCurrencyCode calls parser.IsCurrencyCode.
+func IsCurrencyCode(code string) bool {
+	installTagValidator()
+	return parser.IsCurrencyCode(code)
+}
+
+// CurrencyMinorUnits calls parser.CurrencyMinorUnits.
+func CurrencyMinorUnits(code string) (int, bool) {
+	installTagValidator()
+	return parser.CurrencyMinorUnits(code)
+}
+
+// NewBaseMBParser calls parser.NewBaseMBParser.
+func NewBaseMBParser[S any, C any](bMgr BindingManager[S, C], opts BaseMBParserOpts) *BaseMBParser[S, C] {
+	installTagValidator()
+	return parser.NewBaseMBParser[S, C](bMgr, opts)
+}
+
+// NewObjectKeyParser calls parser.NewObjectKeyParser.
+func NewObjectKeyParser() *ObjectKeyParser {
+	installTagValidator()
+	return parser.NewObjectKeyParser()
+}
+
+// Some calls parser.Some.
+func Some[T any](value T) Opt[T] {
+	installTagValidator()
+	return parser.Some[T](value)
+}
+
+// IsOptType calls parser.IsOptType.
+func IsOptType(typ reflect.Type) bool {
+	installTagValidator()
+	return parser.IsOptType(typ)
+}
+
+// OptValue calls parser.OptValue.
+func OptValue(opt reflect.Value) reflect.Value {
+	installTagValidator()
+	return parser.OptValue(opt)
+}
+
+// EncodeCursor calls parser.EncodeCursor.
+func EncodeCursor(position any) (string, error) {
+	installTagValidator()
+	return parser.EncodeCursor(position)
+}
+
+// FieldPath calls parser.FieldPath.
+func FieldPath(err error) string {
+	installTagValidator()
+	return parser.FieldPath(err)
+}
+
+// NewPCManagerOpts calls parser.NewPCManagerOpts.
+func NewPCManagerOpts(tagOpts ParseTagOpts) PCManagerOpts {
+	installTagValidator()
+	return parser.NewPCManagerOpts(tagOpts)
+}
+
+// NewPCManager calls parser.NewPCManager.
+func NewPCManager[S any](handler BindingHandlerFunc[S], opts PCManagerOpts) *PCManager[S] {
+	installTagValidator()
+	return parser.NewPCManager[S](handler, opts)
+}
+
+// SetDefaultParsers calls parser.SetDefaultParsers.
+func SetDefaultParsers(factories ...ParserFactory) error {
+	installTagValidator()
+	return parser.SetDefaultParsers(factories...)
+}
+
+// DefaultParsers calls parser.DefaultParsers.
+func DefaultParsers() []Parser {
+	installTagValidator()
+	return parser.DefaultParsers()
+}
+
+// NewParserRegistry calls parser.NewParserRegistry.
+func NewParserRegistry(opts ParserRegistryOpts) (*ParserRegistry, error) {
+	installTagValidator()
+	return parser.NewParserRegistry(opts)
+}
+
+// RegisterParser calls parser.RegisterParser.
+func RegisterParser(p0 Parser) error {
+	installTagValidator()
+	return parser.RegisterParser(p0)
+}
+
+// Parse calls parser.Parse.
+func Parse(source any, dest any, p2 bool) error {
+	installTagValidator()
+	return parser.Parse(source, dest, p2)
+}
+
+// ParseMasked calls parser.ParseMasked.
+func ParseMasked(source any, dest any, paths []string, p3 bool) error {
+	installTagValidator()
+	return parser.ParseMasked(source, dest, paths, p3)
+}
+
+// WithParser calls parser.WithParser.
+func WithParser(parserName string) *ParserRegistryContext {
+	installTagValidator()
+	return parser.WithParser(parserName)
+}
+
+// Invalidate calls parser.Invalidate.
+func Invalidate(dest Validatable) error {
+	installTagValidator()
+	return parser.Invalidate(dest)
+}
+
+// InvalidateWithOpts calls parser.InvalidateWithOpts.
+func InvalidateWithOpts(dest any, opts InvalidateOpts) error {
+	installTagValidator()
+	return parser.InvalidateWithOpts(dest, opts)
+}
+
+// GetParser calls parser.GetParser.
+func GetParser(source any) (Parser, error) {
+	installTagValidator()
+	return parser.GetParser(source)
+}
+
+// GetParserByName calls parser.GetParserByName.
+func GetParserByName(source any, parserName string) (Parser, error) {
+	installTagValidator()
+	return parser.GetParserByName(source, parserName)
+}
+
+// ChiPathValues calls parser.ChiPathValues.
+func ChiPathValues(urlParam func(req *http.Request, name string) string) PathValueExtractor {
+	installTagValidator()
+	return parser.ChiPathValues(urlParam)
+}
+
+// GorillaPathValues calls parser.GorillaPathValues.
+func GorillaPathValues(vars func(req *http.Request) map[string]string) PathValueExtractor {
+	installTagValidator()
+	return parser.GorillaPathValues(vars)
+}
+
+// RegisterPIIHook calls parser.RegisterPIIHook.
+func RegisterPIIHook(name string, hook PIIHook) {
+	installTagValidator()
+	parser.RegisterPIIHook(name, hook)
+}
+
+// UnregisterPIIHook calls parser.UnregisterPIIHook.
+func UnregisterPIIHook(name string) {
+	installTagValidator()
+	parser.UnregisterPIIHook(name)
+}
+
+// Pool calls parser.Pool.
+func Pool[T any]() (func() *T, func(*T)) {
+	installTagValidator()
+	return parser.Pool[T]()
+}
+
+// RegisterProfile calls parser.RegisterProfile.
+func RegisterProfile(name string, tag reflect.StructTag) {
+	installTagValidator()
+	parser.RegisterProfile(name, tag)
+}
+
+// UnregisterProfile calls parser.UnregisterProfile.
+func UnregisterProfile(name string) {
+	installTagValidator()
+	parser.UnregisterProfile(name)
+}
+
+// ProfiledTag calls parser.ProfiledTag.
+func ProfiledTag(field reflect.StructField) (reflect.StructTag, error) {
+	installTagValidator()
+	return parser.ProfiledTag(field)
+}
+
+// ParseRange calls parser.ParseRange.
+func ParseRange(value string, limit int64) (Range, error) {
+	installTagValidator()
+	return parser.ParseRange(value, limit)
+}
+
+// ParseRateLimit calls parser.ParseRateLimit.
+func ParseRateLimit(value string) (RateLimit, error) {
+	installTagValidator()
+	return parser.ParseRateLimit(value)
+}
+
+// MarkTransient calls parser.MarkTransient.
+func MarkTransient(err error) error {
+	installTagValidator()
+	return parser.MarkTransient(err)
+}
+
+// MarkPermanent calls parser.MarkPermanent.
+func MarkPermanent(err error) error {
+	installTagValidator()
+	return parser.MarkPermanent(err)
+}
+
+// IsTransient calls parser.IsTransient.
+func IsTransient(err error) bool {
+	installTagValidator()
+	return parser.IsTransient(err)
+}
+
+// IsPermanent calls parser.IsPermanent.
+func IsPermanent(err error) bool {
+	installTagValidator()
+	return parser.IsPermanent(err)
+}
+
+// ParseCronSchedule calls parser.ParseCronSchedule.
+func ParseCronSchedule(expr string) (CronSchedule, error) {
+	installTagValidator()
+	return parser.ParseCronSchedule(expr)
+}
+
+// ParseRRule calls parser.ParseRRule.
+func ParseRRule(value string) (RRule, error) {
+	installTagValidator()
+	return parser.ParseRRule(value)
+}
+
+// NewShadowParser calls parser.NewShadowParser.
+func NewShadowParser(primary Parser, shadow Parser, opts ShadowOpts) (*ShadowParser, error) {
+	installTagValidator()
+	return parser.NewShadowParser(primary, shadow, opts)
+}
+
+// TimestampPayload calls parser.TimestampPayload.
+func TimestampPayload(header string, sep string) SignedPayloadFunc {
+	installTagValidator()
+	return parser.TimestampPayload(header, sep)
+}
+
+// RegisterQueryFields calls parser.RegisterQueryFields.
+func RegisterQueryFields(name string, fields QueryFields) {
+	installTagValidator()
+	parser.RegisterQueryFields(name, fields)
+}
+
+// UnregisterQueryFields calls parser.UnregisterQueryFields.
+func UnregisterQueryFields(name string) {
+	installTagValidator()
+	parser.UnregisterQueryFields(name)
+}
+
+// ParseSort calls parser.ParseSort.
+func ParseSort(value string, fields *QueryFields) (SortSpecs, error) {
+	installTagValidator()
+	return parser.ParseSort(value, fields)
+}
+
+// ParseFilter calls parser.ParseFilter.
+func ParseFilter(value string, fields *QueryFields) (FilterSpecs, error) {
+	installTagValidator()
+	return parser.ParseFilter(value, fields)
+}
+
+// NewTableRowParser calls parser.NewTableRowParser.
+func NewTableRowParser() *TableRowParser {
+	installTagValidator()
+	return parser.NewTableRowParser()
+}
+
+// ParseTable calls parser.ParseTable.
+func ParseTable[T any](rows [][]string) ([]T, error) {
+	installTagValidator()
+	return parser.ParseTable[T](rows)
+}
+
+// DecodeParseTagV2 calls parser.DecodeParseTagV2.
+func DecodeParseTagV2(field reflect.StructField, opts ParseTagOpts) (ParseTag, error) {
+	installTagValidator()
+	return parser.DecodeParseTagV2(field, opts)
+}
+
+// CheckTagProperties calls parser.CheckTagProperties.
+func CheckTagProperties[S, C any](p0 *BaseMBParser[S, C], dest any, opts PropertyOpts) error {
+	installTagValidator()
+	return parser.CheckTagProperties[S, C](p0, dest, opts)
+}
+
+// SetTagValidator calls parser.SetTagValidator.
+func SetTagValidator(validator TagValidator) {
+	installTagValidator()
+	parser.SetTagValidator(validator)
+}
+
+// ParseTenantID calls parser.ParseTenantID.
+func ParseTenantID(value string) (TenantID, error) {
+	installTagValidator()
+	return parser.ParseTenantID(value)
+}
+
+// SubdomainTenant calls parser.SubdomainTenant.
+func SubdomainTenant(domain string) TenantResolver {
+	installTagValidator()
+	return parser.SubdomainTenant(domain)
+}
+
+// HeaderTenant calls parser.HeaderTenant.
+func HeaderTenant(header string) TenantResolver {
+	installTagValidator()
+	return parser.HeaderTenant(header)
+}
+
+// JWTClaimTenant calls parser.JWTClaimTenant.
+func JWTClaimTenant(claim string, verify func(token string) (map[string]any, error)) TenantResolver {
+	installTagValidator()
+	return parser.JWTClaimTenant(claim, verify)
+}
+
+// RegisterTypeConverter calls parser.RegisterTypeConverter.
+func RegisterTypeConverter[T any](converter func(value string) (T, error)) {
+	installTagValidator()
+	parser.RegisterTypeConverter[T](converter)
+}
+
+// RegisterModifierTypeConverter calls parser.RegisterModifierTypeConverter.
+func RegisterModifierTypeConverter[T any](converter func(value string, modifiers BindingModifiers) (T, error)) {
+	installTagValidator()
+	parser.RegisterModifierTypeConverter[T](converter)
+}
+
+// UnregisterTypeConverter calls parser.UnregisterTypeConverter.
+func UnregisterTypeConverter[T any]() {
+	installTagValidator()
+	parser.UnregisterTypeConverter[T]()
+}
+
+// UnionFirst calls parser.UnionFirst.
+func UnionFirst[A, B any](value A) Union2[A, B] {
+	installTagValidator()
+	return parser.UnionFirst[A, B](value)
+}
+
+// UnionSecond calls parser.UnionSecond.
+func UnionSecond[A, B any](value B) Union2[A, B] {
+	installTagValidator()
+	return parser.UnionSecond[A, B](value)
+}
+
+// IsUnionType calls parser.IsUnionType.
+func IsUnionType(typ reflect.Type) bool {
+	installTagValidator()
+	return parser.IsUnionType(typ)
+}
+
+// UnionValue calls parser.UnionValue.
+func UnionValue(u reflect.Value) (reflect.Value, bool) {
+	installTagValidator()
+	return parser.UnionValue(u)
+}
+
+// NewURLTemplateParser calls parser.NewURLTemplateParser.
+func NewURLTemplateParser() *URLTemplateParser {
+	installTagValidator()
+	return parser.NewURLTemplateParser()
+}
+
+// MatchURLTemplate calls parser.MatchURLTemplate.
+func MatchURLTemplate(template string, rawURL string) (map[string]string, error) {
+	installTagValidator()
+	return parser.MatchURLTemplate(template, rawURL)
+}
+
+// RegisterValueModifier calls parser.RegisterValueModifier.
+func RegisterValueModifier(name string, modifier ValueModifier) {
+	installTagValidator()
+	parser.RegisterValueModifier(name, modifier)
+}
+
+// UnregisterValueModifier calls parser.UnregisterValueModifier.
+func UnregisterValueModifier(name string) {
+	installTagValidator()
+	parser.UnregisterValueModifier(name)
+}
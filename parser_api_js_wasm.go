@@ -0,0 +1,25 @@
+// Code generated by go run ./internal/genapi; DO NOT EDIT.
+
+//go:build js && wasm
+
+package pave
+
+import (
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+// JSValueParser is parser.JSValueParser.
+type JSValueParser = parser.JSValueParser
+
+// JSBindingManager is parser.JSBindingManager.
+type JSBindingManager = parser.JSBindingManager
+
+var (
+	ErrUnsupportedJSValue = parser.ErrUnsupportedJSValue
+)
+
+// NewJSValueParser calls parser.NewJSValueParser.
+func NewJSValueParser() *JSValueParser {
+	installTagValidator()
+	return parser.NewJSValueParser()
+}
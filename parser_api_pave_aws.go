@@ -0,0 +1,55 @@
+// Code generated by go run ./internal/genapi; DO NOT EDIT.
+
+//go:build pave_aws
+
+package pave
+
+import (
+	"net/http"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// APIGatewayProxyParser is parser.APIGatewayProxyParser.
+type APIGatewayProxyParser = parser.APIGatewayProxyParser
+
+// SQSMessageParser is parser.SQSMessageParser.
+type SQSMessageParser = parser.SQSMessageParser
+
+// SQSBindingManager is parser.SQSBindingManager.
+type SQSBindingManager = parser.SQSBindingManager
+
+// SNSEntityParser is parser.SNSEntityParser.
+type SNSEntityParser = parser.SNSEntityParser
+
+// SNSBindingManager is parser.SNSBindingManager.
+type SNSBindingManager = parser.SNSBindingManager
+
+var (
+	ErrInvalidLambdaEvent = parser.ErrInvalidLambdaEvent
+)
+
+// NewAPIGatewayProxyParser calls parser.NewAPIGatewayProxyParser.
+func NewAPIGatewayProxyParser() *APIGatewayProxyParser {
+	installTagValidator()
+	return parser.NewAPIGatewayProxyParser()
+}
+
+// APIGatewayProxyHTTPRequest calls parser.APIGatewayProxyHTTPRequest.
+func APIGatewayProxyHTTPRequest(event *events.APIGatewayProxyRequest) (*http.Request, error) {
+	installTagValidator()
+	return parser.APIGatewayProxyHTTPRequest(event)
+}
+
+// NewSQSMessageParser calls parser.NewSQSMessageParser.
+func NewSQSMessageParser() *SQSMessageParser {
+	installTagValidator()
+	return parser.NewSQSMessageParser()
+}
+
+// NewSNSEntityParser calls parser.NewSNSEntityParser.
+func NewSNSEntityParser() *SNSEntityParser {
+	installTagValidator()
+	return parser.NewSNSEntityParser()
+}
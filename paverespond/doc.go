@@ -0,0 +1,15 @@
+// Package paverespond converts errors returned by pave into consistent
+// HTTP error payloads.
+//
+// Two payload formats are supported:
+//   - RFC 7807 problem details (application/problem+json), see WriteProblem
+//   - JSON:API error objects (application/vnd.api+json), see WriteJSONAPI
+//
+// Errors are classified into kinds (missing required field, validation,
+// body too large, ...) which are mapped to status codes by a StatusMap.
+// The package-level functions use DefaultStatusMap; applications that need
+// different codes or kinds create their own Responder. Errors that are not
+// produced by pave are reported as 500 Internal Server Error without
+// echoing their message to the client, as are those of the kinds of
+// KindMatchers unless they set ExposeDetail.
+package paverespond
@@ -0,0 +1,223 @@
+package paverespond
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	pave "github.com/SimonDaKappa/go-pave"
+)
+
+// Content type constants for the supported error payloads.
+const (
+	ContentTypeProblemJSON string = "application/problem+json"
+	ContentTypeJSONAPI     string = "application/vnd.api+json"
+)
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam describes a single destination field that failed to parse
+// or validate. It is the "invalid-params" extension member of RFC 7807.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// JSONAPIErrors is the top-level JSON:API error document.
+type JSONAPIErrors struct {
+	Errors []JSONAPIError `json:"errors"`
+}
+
+// JSONAPIError is a single JSON:API error object.
+type JSONAPIError struct {
+	Status string         `json:"status"`
+	Code   string         `json:"code,omitempty"`
+	Title  string         `json:"title"`
+	Detail string         `json:"detail,omitempty"`
+	Meta   map[string]any `json:"meta,omitempty"`
+}
+
+// Responder writes error payloads with a configurable status code
+// mapping. The zero value is not usable, create one with NewResponder.
+type Responder struct {
+	// Statuses maps error kinds to HTTP status codes.
+	Statuses StatusMap
+	// Matchers classify application specific errors. They are consulted
+	// in order before the built-in classification.
+	Matchers []KindMatcher
+}
+
+// NewResponder creates a Responder using statuses. A nil map uses
+// DefaultStatusMap.
+func NewResponder(statuses StatusMap, matchers ...KindMatcher) *Responder {
+	if statuses == nil {
+		statuses = DefaultStatusMap()
+	}
+
+	return &Responder{
+		Statuses: statuses,
+		Matchers: matchers,
+	}
+}
+
+// _defaultResponder is used by the package-level functions.
+var _defaultResponder = NewResponder(nil)
+
+// classify determines the kind and HTTP status for err, and whether its
+// message may be returned to the client: for the kinds of Matchers, if
+// they expose it, and for the built-in kinds, unless err is internal.
+func (res *Responder) classify(err error) (kind string, status int, exposed bool) {
+	for _, matcher := range res.Matchers {
+		if matcher.Match(err) {
+			return matcher.Kind, res.Statuses.Status(matcher.Kind), matcher.ExposeDetail
+		}
+	}
+
+	kind = Classify(err)
+	return kind, res.Statuses.Status(kind), kind != KindInternal
+}
+
+// NewProblem converts err into an RFC 7807 problem details object.
+//
+// The detail and invalid-params members are only populated for parse and
+// validation errors, and for the kinds of Matchers that expose them.
+// Internal errors never expose their message.
+func (res *Responder) NewProblem(err error) Problem {
+	kind, status, exposed := res.classify(err)
+
+	problem := Problem{
+		Type:   "about:blank#" + kind,
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	if !exposed {
+		return problem
+	}
+
+	problem.Detail = err.Error()
+	problem.InvalidParams = invalidParams(err)
+
+	return problem
+}
+
+// NewJSONAPIErrors converts err into a JSON:API error document.
+func (res *Responder) NewJSONAPIErrors(err error) JSONAPIErrors {
+	kind, status, exposed := res.classify(err)
+
+	apiErr := JSONAPIError{
+		Status: strconv.Itoa(status),
+		Code:   kind,
+		Title:  http.StatusText(status),
+	}
+
+	if !exposed {
+		return JSONAPIErrors{Errors: []JSONAPIError{apiErr}}
+	}
+
+	params := invalidParams(err)
+	if len(params) == 0 {
+		apiErr.Detail = err.Error()
+		return JSONAPIErrors{Errors: []JSONAPIError{apiErr}}
+	}
+
+	// One error object per invalid field
+	doc := JSONAPIErrors{Errors: make([]JSONAPIError, len(params))}
+	for i, param := range params {
+		fieldErr := apiErr
+		fieldErr.Detail = param.Reason
+		fieldErr.Meta = map[string]any{"field": param.Name}
+		doc.Errors[i] = fieldErr
+	}
+
+	return doc
+}
+
+// WriteProblem writes err as an application/problem+json response.
+// If r is not nil, its request URI is used as the problem instance.
+func (res *Responder) WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	problem := res.NewProblem(err)
+	if r != nil {
+		problem.Instance = r.URL.RequestURI()
+	}
+
+	writeJSON(w, ContentTypeProblemJSON, problem.Status, problem)
+}
+
+// WriteJSONAPI writes err as an application/vnd.api+json response.
+func (res *Responder) WriteJSONAPI(w http.ResponseWriter, err error) {
+	doc := res.NewJSONAPIErrors(err)
+	status, _ := strconv.Atoi(doc.Errors[0].Status)
+
+	writeJSON(w, ContentTypeJSONAPI, status, doc)
+}
+
+// NewProblem converts err into a problem using the default status mapping.
+func NewProblem(err error) Problem {
+	return _defaultResponder.NewProblem(err)
+}
+
+// NewJSONAPIErrors converts err into a JSON:API error document using the
+// default status mapping.
+func NewJSONAPIErrors(err error) JSONAPIErrors {
+	return _defaultResponder.NewJSONAPIErrors(err)
+}
+
+// WriteProblem writes err as problem+json using the default status mapping.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	_defaultResponder.WriteProblem(w, r, err)
+}
+
+// WriteJSONAPI writes err as JSON:API errors using the default status mapping.
+func WriteJSONAPI(w http.ResponseWriter, err error) {
+	_defaultResponder.WriteJSONAPI(w, err)
+}
+
+func writeJSON(w http.ResponseWriter, contentType string, status int, body any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// invalidParams returns an InvalidParam for every field that failed in
+// err. Validation errors may contain several failed fields.
+func invalidParams(err error) []InvalidParam {
+	var params []InvalidParam
+	collectInvalidParams(err, "", &params)
+	return params
+}
+
+func collectInvalidParams(err error, path string, params *[]InvalidParam) {
+	switch e := err.(type) {
+	case nil:
+		return
+	case *pave.FieldError:
+		name := e.Field
+		if path != "" {
+			name = path + "." + e.Field
+		}
+
+		var nested *pave.FieldError
+		if errors.As(e.Err, &nested) {
+			collectInvalidParams(e.Err, name, params)
+			return
+		}
+
+		*params = append(*params, InvalidParam{Name: name, Reason: e.Err.Error()})
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			collectInvalidParams(inner, path, params)
+		}
+	default:
+		collectInvalidParams(errors.Unwrap(err), path, params)
+	}
+}
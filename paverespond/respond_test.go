@@ -0,0 +1,118 @@
+package paverespond
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pave "github.com/SimonDaKappa/go-pave"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	Paging struct {
+		Page int `query:"page"`
+	}
+}
+
+func (r *testRequest) Validate() error {
+	if r.Paging.Page > 100 {
+		return errors.New("page must be at most 100")
+	}
+	return nil
+}
+
+// parse parses target into dest with the HTTPRequestParser, which is not
+// a default parser of pave_nodefaults builds.
+func parse(t *testing.T, target string, dest any) error {
+	t.Helper()
+	registry, err := pave.NewParserRegistry(pave.ParserRegistryOpts{Parsers: []pave.Parser{pave.NewHTTPRequestParser()}})
+	require.NoError(t, err)
+	return registry.Parse(httptest.NewRequest("GET", target, nil), dest, true)
+}
+
+func TestWriteProblem(t *testing.T) {
+	t.Run("ParseError", func(t *testing.T) {
+		err := parse(t, "/items?page=abc", &testRequest{})
+		require.Error(t, err)
+
+		rec := httptest.NewRecorder()
+		WriteProblem(rec, httptest.NewRequest("GET", "/items?page=abc", nil), err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, ContentTypeProblemJSON, rec.Header().Get("Content-Type"))
+
+		var problem Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, "about:blank#"+KindParse, problem.Type)
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, "/items?page=abc", problem.Instance)
+		require.Len(t, problem.InvalidParams, 1)
+		assert.Equal(t, "Paging.Page", problem.InvalidParams[0].Name)
+		assert.Contains(t, problem.InvalidParams[0].Reason, "error converting value to int")
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		err := parse(t, "/items?page=101", &testRequest{})
+		require.Error(t, err)
+
+		rec := httptest.NewRecorder()
+		WriteProblem(rec, nil, err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+		var problem Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Contains(t, problem.Detail, "page must be at most 100")
+		assert.Empty(t, problem.InvalidParams)
+	})
+
+	t.Run("ValidateTags", func(t *testing.T) {
+		type tagged struct {
+			Name  string `query:"name" validate:"required"`
+			Email string `query:"email" validate:"required"`
+		}
+
+		err := parse(t, "/items?name=&email=", &tagged{})
+		require.Error(t, err)
+
+		problem := NewProblem(err)
+		assert.Equal(t, http.StatusUnprocessableEntity, problem.Status)
+		require.Len(t, problem.InvalidParams, 2)
+		assert.Equal(t, "Name", problem.InvalidParams[0].Name)
+		assert.Equal(t, "Email", problem.InvalidParams[1].Name)
+		assert.Contains(t, problem.InvalidParams[0].Reason, "value is required")
+
+		doc := NewJSONAPIErrors(err)
+		require.Len(t, doc.Errors, 2)
+		assert.Equal(t, "Email", doc.Errors[1].Meta["field"])
+	})
+
+	t.Run("InternalErrorHidesDetail", func(t *testing.T) {
+		problem := NewProblem(errors.New("database password is hunter2"))
+
+		assert.Equal(t, http.StatusInternalServerError, problem.Status)
+		assert.Empty(t, problem.Detail)
+	})
+}
+
+func TestWriteJSONAPI(t *testing.T) {
+	err := parse(t, "/items?page=abc", &testRequest{})
+	require.Error(t, err)
+
+	rec := httptest.NewRecorder()
+	WriteJSONAPI(rec, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, ContentTypeJSONAPI, rec.Header().Get("Content-Type"))
+
+	var doc JSONAPIErrors
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	require.Len(t, doc.Errors, 1)
+	assert.Equal(t, "400", doc.Errors[0].Status)
+	assert.Equal(t, KindParse, doc.Errors[0].Code)
+	assert.Equal(t, "Paging.Page", doc.Errors[0].Meta["field"])
+}
@@ -0,0 +1,111 @@
+package paverespond
+
+import (
+	"errors"
+	"net/http"
+
+	pave "github.com/SimonDaKappa/go-pave"
+)
+
+// Error kinds reported as the problem type and JSON:API code.
+const (
+	KindParse                string = "parse-error"
+	KindValidation           string = "validation-error"
+	KindMissingRequired      string = "missing-required"
+	KindInvalidSignature     string = "invalid-signature"
+	KindBodyTooLarge         string = "body-too-large"
+	KindUnsupportedMediaType string = "unsupported-media-type"
+	KindNotAcceptable        string = "not-acceptable"
+	KindRangeNotSatisfiable  string = "range-not-satisfiable"
+	KindInternal             string = "internal-error"
+)
+
+// StatusMap maps error kinds to the HTTP status code they are reported with.
+type StatusMap map[string]int
+
+// DefaultStatusMap returns a new StatusMap with the default status codes:
+//   - missing required field  → 400 Bad Request
+//   - other parse errors      → 400 Bad Request
+//   - invalid signature       → 401 Unauthorized
+//   - validation errors       → 422 Unprocessable Entity
+//   - body too large          → 413 Request Entity Too Large
+//   - unsupported media type  → 415 Unsupported Media Type
+//   - not acceptable          → 406 Not Acceptable
+//   - range not satisfiable   → 416 Range Not Satisfiable
+//   - anything else           → 500 Internal Server Error
+func DefaultStatusMap() StatusMap {
+	return StatusMap{
+		KindParse:                http.StatusBadRequest,
+		KindMissingRequired:      http.StatusBadRequest,
+		KindInvalidSignature:     http.StatusUnauthorized,
+		KindValidation:           http.StatusUnprocessableEntity,
+		KindBodyTooLarge:         http.StatusRequestEntityTooLarge,
+		KindUnsupportedMediaType: http.StatusUnsupportedMediaType,
+		KindNotAcceptable:        http.StatusNotAcceptable,
+		KindRangeNotSatisfiable:  http.StatusRequestedRangeNotSatisfiable,
+		KindInternal:             http.StatusInternalServerError,
+	}
+}
+
+// Status returns the status code for kind. Kinds without a mapping are
+// reported as 500 Internal Server Error.
+func (m StatusMap) Status(kind string) int {
+	if status, ok := m[kind]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// KindMatcher classifies application specific errors. Match reports
+// whether err is of the matcher's Kind.
+//
+// The messages of matched errors are not returned to the client unless
+// ExposeDetail is set, as they may hold internal details. Only set it for
+// kinds whose messages are written for clients.
+type KindMatcher struct {
+	Kind         string
+	Match        func(err error) bool
+	ExposeDetail bool
+}
+
+// Classify returns the built-in kind of err.
+func Classify(err error) string {
+	var (
+		parseErr      *pave.ParseError
+		validationErr *pave.ValidationError
+	)
+
+	switch {
+	case errors.As(err, &validationErr):
+		return KindValidation
+	case errors.Is(err, pave.ErrInvalidSignature):
+		return KindInvalidSignature
+	case errors.Is(err, pave.ErrBodyTooLarge):
+		return KindBodyTooLarge
+	case errors.Is(err, pave.ErrUnsupportedMediaType):
+		return KindUnsupportedMediaType
+	case errors.Is(err, pave.ErrNotAcceptable):
+		return KindNotAcceptable
+	case errors.Is(err, pave.ErrRangeNotSatisfiable):
+		return KindRangeNotSatisfiable
+	case errors.Is(err, pave.ErrRequiredFieldNotFound):
+		return KindMissingRequired
+	case isCallerError(err):
+		return KindInternal
+	case errors.As(err, &parseErr):
+		return KindParse
+	default:
+		return KindInternal
+	}
+}
+
+// isCallerError reports whether err is caused by how pave was called
+// (e.g. a destination that is not a pointer to a struct) rather than by
+// the request, so that it is not reported as the client's fault.
+func isCallerError(err error) bool {
+	return errors.Is(err, pave.ErrNilDest) ||
+		errors.Is(err, pave.ErrDestNotStructPtr) ||
+		errors.Is(err, pave.ErrSourceTypeMismatch) ||
+		errors.Is(err, pave.ErrUnsupportedFieldType) ||
+		errors.Is(err, pave.ErrUnknownVerifier)
+}
@@ -0,0 +1,114 @@
+package paverespond
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pave "github.com/SimonDaKappa/go-pave"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestRateLimited = errors.New("rate limited")
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"validation", &pave.ValidationError{Parser: "p", Err: errors.New("x")}, KindValidation},
+		{"parse", &pave.ParseError{Parser: "p", Err: errors.New("x")}, KindParse},
+		{"missing_required", &pave.ParseError{Parser: "p", Err: &pave.RequiredFieldError{Identifier: "id", Binding: "query"}}, KindMissingRequired},
+		{"body_too_large", &pave.ParseError{Parser: "p", Err: fmt.Errorf("read: %w", pave.ErrBodyTooLarge)}, KindBodyTooLarge},
+		{"unsupported_media_type", &pave.ParseError{Parser: "p", Err: pave.ErrUnsupportedMediaType}, KindUnsupportedMediaType},
+		{"invalid_signature", &pave.ParseError{Parser: "p", Err: fmt.Errorf("field Ref: %w", pave.ErrInvalidSignature)}, KindInvalidSignature},
+		{"unknown_verifier", &pave.ParseError{Parser: "p", Err: fmt.Errorf("field Ref: %w", pave.ErrUnknownVerifier)}, KindInternal},
+		{"not_acceptable", &pave.ParseError{Parser: "p", Err: fmt.Errorf("header Accept: %w", pave.ErrNotAcceptable)}, KindNotAcceptable},
+		{"range_not_satisfiable", &pave.ParseError{Parser: "p", Err: fmt.Errorf("header Range: %w", pave.ErrRangeNotSatisfiable)}, KindRangeNotSatisfiable},
+		{"internal", errors.New("x"), KindInternal},
+		{"unsupported_field", &pave.ParseError{Parser: "p", Err: fmt.Errorf("field: %w", pave.ErrUnsupportedFieldType)}, KindInternal},
+		{"dest_not_struct_ptr", &pave.ParseError{Parser: "p", Err: pave.ErrDestNotStructPtr}, KindInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Classify(tt.err))
+		})
+	}
+}
+
+func TestDefaultStatusMap(t *testing.T) {
+	statuses := DefaultStatusMap()
+
+	assert.Equal(t, http.StatusBadRequest, statuses.Status(KindMissingRequired))
+	assert.Equal(t, http.StatusUnprocessableEntity, statuses.Status(KindValidation))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, statuses.Status(KindBodyTooLarge))
+	assert.Equal(t, http.StatusUnsupportedMediaType, statuses.Status(KindUnsupportedMediaType))
+	assert.Equal(t, http.StatusNotAcceptable, statuses.Status(KindNotAcceptable))
+	assert.Equal(t, http.StatusUnauthorized, statuses.Status(KindInvalidSignature))
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, statuses.Status(KindRangeNotSatisfiable))
+	assert.Equal(t, http.StatusInternalServerError, statuses.Status("unknown-kind"))
+}
+
+func TestResponder_CustomMapping(t *testing.T) {
+	statuses := DefaultStatusMap()
+	statuses[KindValidation] = http.StatusBadRequest
+	statuses["rate-limited"] = http.StatusTooManyRequests
+
+	res := NewResponder(statuses, KindMatcher{
+		Kind:  "rate-limited",
+		Match: func(err error) bool { return errors.Is(err, errTestRateLimited) },
+	})
+
+	t.Run("OverriddenStatus", func(t *testing.T) {
+		problem := res.NewProblem(&pave.ValidationError{Parser: "p", Err: errors.New("x")})
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+	})
+
+	t.Run("CustomKind", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		res.WriteJSONAPI(rec, fmt.Errorf("wrapped: %w", errTestRateLimited))
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"rate-limited"`)
+		assert.NotContains(t, rec.Body.String(), "wrapped", "custom kinds do not expose their message by default")
+	})
+
+	t.Run("ExposedDetail", func(t *testing.T) {
+		res := NewResponder(statuses, KindMatcher{
+			Kind:         "rate-limited",
+			Match:        func(err error) bool { return errors.Is(err, errTestRateLimited) },
+			ExposeDetail: true,
+		})
+
+		problem := res.NewProblem(fmt.Errorf("wrapped: %w", errTestRateLimited))
+		assert.Equal(t, "wrapped: rate limited", problem.Detail)
+	})
+}
+
+func TestWriteProblem_BodyTooLarge(t *testing.T) {
+	registry, err := pave.NewParserRegistry(pave.ParserRegistryOpts{
+		ExcludeDefaults: true,
+		Parsers: []pave.Parser{
+			pave.NewHTTPRequestParserWithOpts(pave.HTTPRequestParserOpts{MaxBodyBytes: 8}),
+		},
+	})
+	require.NoError(t, err)
+
+	type Body struct {
+		Name string `json:"name"`
+	}
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name": "far too long"}`))
+	err = registry.Parse(req, &Body{}, false)
+	require.Error(t, err)
+
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, req, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
@@ -12,7 +12,7 @@ echo "Creating output directory..."
 mkdir -p $OUTPUT_DIR
 
 echo "Running benchmarks with profiling..."
-go test -bench=$BENCHMARK_PATTERN -cpuprofile=$OUTPUT_DIR/cpu.prof -memprofile=$OUTPUT_DIR/mem.prof -benchtime=10s
+go test ./parser -bench=$BENCHMARK_PATTERN -cpuprofile=$OUTPUT_DIR/cpu.prof -memprofile=$OUTPUT_DIR/mem.prof -benchtime=10s
 
 echo "Starting pprof web server for CPU profile..."
 echo "Open your browser to http://localhost:8080 to view the profile"
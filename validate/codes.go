@@ -0,0 +1,17 @@
+package validate
+
+import (
+	"reflect"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+// validateTimezone is the timezone validation rule for string fields.
+func validateTimezone(value reflect.Value, _ string) error {
+	name, err := stringRuleValue(value, TimezoneValidationRule)
+	if err != nil {
+		return err
+	}
+	_, err = parser.LoadTimezone(name)
+	return err
+}
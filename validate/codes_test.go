@@ -0,0 +1,20 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStruct_Codes(t *testing.T) {
+	type profile struct {
+		Timezone string `validate:"timezone"`
+	}
+
+	assert.NoError(t, ValidateStruct(&profile{Timezone: "UTC"}))
+
+	err := ValidateStruct(&profile{Timezone: "Local"})
+	assert.ErrorIs(t, err, parser.ErrInvalidTimezone)
+	assert.Equal(t, "Timezone", parser.FieldPath(err.(interface{ Unwrap() []error }).Unwrap()[0]))
+}
@@ -0,0 +1,23 @@
+package validate
+
+// constants for builtin rules of the validate tag
+const (
+	RequiredValidationRule         string = "required"
+	OmitEmptyValidationRule        string = "omitempty"
+	PasswordValidationRule         string = "password"
+	MaxFileSizeValidationRule      string = "maxfilesize"
+	MIMETypeValidationRule         string = "mimetype"
+	MaxFilesValidationRule         string = "maxfiles"
+	LatitudeValidationRule         string = "lat"
+	LongitudeValidationRule        string = "lon"
+	InBoundingBoxValidationRule    string = "inbbox"
+	CronValidationRule             string = "cron"
+	RRuleValidationRule            string = "rrule"
+	CountryValidationRule          string = "country"  // left out by the pave_minimal build tag
+	LanguageValidationRule         string = "language" // left out by the pave_minimal build tag
+	TimezoneValidationRule         string = "timezone"
+	TenantValidationRule           string = "tenant"
+	PhoneValidationRule            string = "phone"             // requires the pave_phone build tag
+	SemverValidationRule           string = "semver"            // requires the pave_semver build tag
+	SemverConstraintValidationRule string = "semver_constraint" // requires the pave_semver build tag
+)
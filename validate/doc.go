@@ -0,0 +1,8 @@
+// Package validate holds the validation rules of the `validate` tag and
+// ValidateStruct, which checks them.
+//
+// Its functions install its validator in the parser package on first use
+// (see InstallTagValidator), so that parses asking for validation check
+// the validate tags of their destinations. The pave package combines both
+// packages.
+package validate
@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+// validateLatitude is the lat validation rule, for numeric and string fields.
+func validateLatitude(value reflect.Value, _ string) error {
+	lat, err := coordinateRuleValue(value, LatitudeValidationRule)
+	if err != nil {
+		return err
+	}
+	return parser.CheckLatitude(lat)
+}
+
+// validateLongitude is the lon validation rule, for numeric and string fields.
+func validateLongitude(value reflect.Value, _ string) error {
+	lon, err := coordinateRuleValue(value, LongitudeValidationRule)
+	if err != nil {
+		return err
+	}
+	return parser.CheckLongitude(lon)
+}
+
+// validateInBoundingBox is the inbbox validation rule for parser.Point
+// fields. param is either the name of a parser.BoundingBox (or pointer)
+// field of the same struct, e.g. `validate:"inbbox=Area"`, or a literal
+// box, e.g. `validate:"inbbox=47.2,5.8,55.1,15.1"`. A nil *BoundingBox
+// field accepts any point.
+func validateInBoundingBox(value reflect.Value, parent reflect.Value, param string) error {
+	point, ok := value.Interface().(parser.Point)
+	if !ok {
+		return fmt.Errorf("%w: rule %s only applies to pave.Point, got %s", ErrInvalidValidateTag, InBoundingBoxValidationRule, value.Type())
+	}
+
+	box, err := parser.ParseBoundingBox(param)
+	if err != nil {
+		field, fieldErr := siblingField(parent, param, InBoundingBoxValidationRule)
+		if fieldErr != nil {
+			return fieldErr
+		}
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				return nil
+			}
+			field = field.Elem()
+		}
+
+		box, ok = field.Interface().(parser.BoundingBox)
+		if !ok {
+			return fmt.Errorf("%w: rule %s requires a pave.BoundingBox field, %s is %s", ErrInvalidValidateTag, InBoundingBoxValidationRule, param, field.Type())
+		}
+	}
+
+	if !box.Contains(point) {
+		return fmt.Errorf("%w: %s is not within %s", parser.ErrOutsideBoundingBox, point, box)
+	}
+	return nil
+}
+
+// coordinateRuleValue returns the number held by value for coordinate rules.
+func coordinateRuleValue(value reflect.Value, rule string) (float64, error) {
+	switch value.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), nil
+	case reflect.String:
+		coord, err := strconv.ParseFloat(strings.TrimSpace(value.String()), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q is not a number", parser.ErrInvalidCoordinate, value.String())
+		}
+		return coord, nil
+	default:
+		return 0, fmt.Errorf("%w: rule %s only applies to numbers and strings, got %s", ErrInvalidValidateTag, rule, value.Type())
+	}
+}
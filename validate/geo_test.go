@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStruct_Geo(t *testing.T) {
+	type search struct {
+		Lat      float64             `validate:"lat"`
+		Lon      string              `validate:"lon"`
+		Center   parser.Point        `validate:"inbbox=Area"`
+		Fallback parser.Point        `validate:"inbbox=-90,-180,0,0"`
+		Area     *parser.BoundingBox `validate:"omitempty"`
+	}
+
+	germany := &parser.BoundingBox{MinLat: 47.2, MinLon: 5.8, MaxLat: 55.1, MaxLon: 15.1}
+
+	assert.NoError(t, ValidateStruct(&search{Lat: 52.5, Lon: "13.4", Center: parser.Point{Lat: 52.5, Lon: 13.4}, Area: germany}))
+	assert.NoError(t, ValidateStruct(&search{Lon: "0", Center: parser.Point{Lat: 48.85, Lon: 2.35}}), "nil area accepts any point")
+
+	err := ValidateStruct(&search{Lat: 95, Lon: "200", Center: parser.Point{Lat: 48.85, Lon: 2.35}, Fallback: parser.Point{Lat: 10, Lon: 10}, Area: germany})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, parser.ErrInvalidLatitude)
+	assert.ErrorIs(t, err, parser.ErrInvalidLongitude)
+	assert.ErrorIs(t, err, parser.ErrOutsideBoundingBox)
+	assert.Equal(t, "Lat", parser.FieldPath(err))
+
+	t.Run("unknown_field", func(t *testing.T) {
+		invalid := struct {
+			Center parser.Point `validate:"inbbox=Nope"`
+		}{}
+		assert.ErrorIs(t, ValidateStruct(&invalid), ErrInvalidValidateTag)
+	})
+
+	t.Run("not_a_number", func(t *testing.T) {
+		invalid := struct {
+			Lat string `validate:"lat"`
+		}{Lat: "north"}
+		assert.ErrorIs(t, ValidateStruct(&invalid), parser.ErrInvalidCoordinate)
+	})
+}
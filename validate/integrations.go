@@ -0,0 +1,21 @@
+package validate
+
+import "sync"
+
+// Integrations with third-party modules, such as phone numbers (pave_phone),
+// semantic versions (pave_semver) and language tags (left out by
+// pave_minimal), register their validation rules on first use of the rule
+// registry rather than in init(), like those of the parser package.
+var _integrationsOnce sync.Once
+
+// installIntegrations registers the integrations enabled by build tags,
+// once. Every Register, Unregister and lookup function of the validation
+// rule registry calls it first, so that integrations never override what
+// the application registered.
+func installIntegrations() {
+	_integrationsOnce.Do(func() {
+		installLanguage()
+		installPhone()
+		installSemver()
+	})
+}
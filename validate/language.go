@@ -0,0 +1,57 @@
+//go:build !pave_minimal
+
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+// The country and language rules check codes with golang.org/x/text. Build
+// with the pave_minimal tag to leave them out.
+
+// installLanguage registers the country and language rules. See
+// installIntegrations.
+func installLanguage() {
+	registerValidationRule(CountryValidationRule, ignoreParent(validateCountry))
+	registerValidationRule(LanguageValidationRule, ignoreParent(validateLanguage))
+}
+
+// validateCountry is the country validation rule for string fields. The
+// param selects the accepted format, parser.CountryCodeAlpha2 by default.
+func validateCountry(value reflect.Value, param string) error {
+	code, err := stringRuleValue(value, CountryValidationRule)
+	if err != nil {
+		return err
+	}
+
+	var length int
+	switch strings.ToLower(param) {
+	case "", parser.CountryCodeAlpha2:
+		length = 2
+	case parser.CountryCodeAlpha3:
+		length = 3
+	case parser.CountryCodeAny:
+		length = len(code)
+	default:
+		return fmt.Errorf("%w: rule %s: unknown format %q", ErrInvalidValidateTag, CountryValidationRule, param)
+	}
+
+	if len(code) != length || !parser.IsCountryCode(code) {
+		return fmt.Errorf("%w: %q", parser.ErrInvalidCountryCode, code)
+	}
+	return nil
+}
+
+// validateLanguage is the language validation rule for string fields.
+func validateLanguage(value reflect.Value, _ string) error {
+	tag, err := stringRuleValue(value, LanguageValidationRule)
+	if err != nil {
+		return err
+	}
+	_, err = parser.ParseLanguageTag(tag)
+	return err
+}
@@ -0,0 +1,6 @@
+//go:build pave_minimal
+
+package validate
+
+// installLanguage is a no-op with the pave_minimal build tag.
+func installLanguage() {}
@@ -0,0 +1,22 @@
+//go:build pave_minimal
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStruct_LanguageMinimal(t *testing.T) {
+	for _, v := range []any{
+		&struct {
+			Country string `validate:"country"`
+		}{Country: "DE"},
+		&struct {
+			Language string `validate:"language"`
+		}{Language: "en"},
+	} {
+		assert.ErrorIs(t, ValidateStruct(v), ErrUnknownValidationRule)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build !pave_minimal
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStruct_Language(t *testing.T) {
+	type profile struct {
+		Country  string `validate:"country"`
+		Country3 string `validate:"omitempty,country=alpha3"`
+		Origin   string `validate:"omitempty,country=any"`
+		Language string `validate:"language"`
+		Timezone string `validate:"timezone"`
+	}
+
+	assert.NoError(t, ValidateStruct(&profile{
+		Country: "DE", Country3: "DEU", Origin: "fr", Language: "zh-Hant-TW", Timezone: "UTC",
+	}))
+
+	err := ValidateStruct(&profile{
+		Country: "DEU", Country3: "DE", Origin: "EU", Language: "english", Timezone: "Local",
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, parser.ErrInvalidCountryCode)
+	assert.ErrorIs(t, err, parser.ErrInvalidLanguageTag)
+	assert.ErrorIs(t, err, parser.ErrInvalidTimezone)
+
+	paths := []string{}
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		paths = append(paths, parser.FieldPath(err))
+	}
+	assert.Equal(t, []string{"Country", "Country3", "Origin", "Language", "Timezone"}, paths)
+
+	invalid := struct {
+		Country string `validate:"country=alpha4"`
+	}{Country: "DE"}
+	assert.ErrorIs(t, ValidateStruct(&invalid), ErrInvalidValidateTag)
+}
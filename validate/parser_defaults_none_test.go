@@ -0,0 +1,19 @@
+//go:build pave_nodefaults
+
+package validate
+
+import (
+	"os"
+	"testing"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+// Built with pave_nodefaults, the tests of the package level functions use
+// the HTTPRequestParser as their only default parser.
+func TestMain(m *testing.M) {
+	if err := parser.SetDefaultParsers(func() parser.Parser { return parser.NewHTTPRequestParser() }); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
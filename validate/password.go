@@ -0,0 +1,253 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+var (
+	ErrWeakPassword   = errors.New("password does not meet the password policy")
+	ErrDeniedPassword = errors.New("password is not allowed")
+)
+
+// DefaultPasswordMinLength is the minimum password length of a `password`
+// rule without a minN option.
+const DefaultPasswordMinLength = 8
+
+// PasswordPolicy is the parsed parameter of a `password` rule. Its options
+// are separated by commas and can be combined freely:
+//
+//	minN      at least N characters (default DefaultPasswordMinLength)
+//	maxN      at most N characters
+//	upper     at least one upper case letter
+//	lower     at least one lower case letter
+//	digit     at least one digit
+//	symbol    at least one character that is not a letter or digit
+//	entropyN  an estimated entropy of at least N bits, see PasswordEntropy
+//
+// e.g. `validate:"password=min12,upper,lower,digit,symbol"`. Passwords are
+// always checked against the deny list set with SetPasswordDenyList.
+type PasswordPolicy struct {
+	MinLength  int
+	MaxLength  int // 0 for no limit
+	Upper      bool
+	Lower      bool
+	Digit      bool
+	Symbol     bool
+	MinEntropy float64 // In bits, 0 for no minimum
+}
+
+// ParsePasswordPolicy parses the parameter of a `password` rule, see
+// PasswordPolicy.
+func ParsePasswordPolicy(param string) (PasswordPolicy, error) {
+	policy := PasswordPolicy{MinLength: DefaultPasswordMinLength}
+	if param == "" {
+		return policy, nil
+	}
+
+	for _, option := range strings.Split(param, parser.CommaDelimeter) {
+		option = strings.TrimSpace(option)
+
+		switch option {
+		case "upper":
+			policy.Upper = true
+		case "lower":
+			policy.Lower = true
+		case "digit":
+			policy.Digit = true
+		case "symbol":
+			policy.Symbol = true
+		default:
+			var err error
+			switch {
+			case strings.HasPrefix(option, "min"):
+				policy.MinLength, err = strconv.Atoi(option[len("min"):])
+			case strings.HasPrefix(option, "max"):
+				policy.MaxLength, err = strconv.Atoi(option[len("max"):])
+			case strings.HasPrefix(option, "entropy"):
+				policy.MinEntropy, err = strconv.ParseFloat(option[len("entropy"):], 64)
+			default:
+				err = errors.New("unknown option")
+			}
+			if err != nil {
+				return PasswordPolicy{}, fmt.Errorf("%w: password option %q: %w", ErrInvalidValidateTag, option, err)
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+// Check returns a wrapped ErrWeakPassword listing every requirement of p
+// that password does not meet, or ErrDeniedPassword if it is on the deny
+// list.
+func (p PasswordPolicy) Check(password string) error {
+	var unmet []string
+
+	length := len([]rune(password))
+	if length < p.MinLength {
+		unmet = append(unmet, fmt.Sprintf("at least %d characters", p.MinLength))
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		unmet = append(unmet, fmt.Sprintf("at most %d characters", p.MaxLength))
+	}
+
+	classes := passwordClasses(password)
+	if p.Upper && !classes.upper {
+		unmet = append(unmet, "an upper case letter")
+	}
+	if p.Lower && !classes.lower {
+		unmet = append(unmet, "a lower case letter")
+	}
+	if p.Digit && !classes.digit {
+		unmet = append(unmet, "a digit")
+	}
+	if p.Symbol && !classes.symbol {
+		unmet = append(unmet, "a symbol")
+	}
+	if p.MinEntropy > 0 && PasswordEntropy(password) < p.MinEntropy {
+		unmet = append(unmet, fmt.Sprintf("at least %g bits of entropy", p.MinEntropy))
+	}
+
+	if len(unmet) > 0 {
+		return fmt.Errorf("%w: requires %s", ErrWeakPassword, strings.Join(unmet, ", "))
+	}
+
+	if isDeniedPassword(password) {
+		return ErrDeniedPassword
+	}
+	return nil
+}
+
+// PasswordEntropy estimates the entropy of password in bits as its length
+// times log2 of the size of the character classes it uses, not counting
+// repeated characters beyond their first two occurrences. It is a rough
+// upper bound meant to reject short or repetitive passwords, not a
+// strength meter.
+func PasswordEntropy(password string) float64 {
+	classes := passwordClasses(password)
+
+	pool := 0
+	if classes.lower {
+		pool += 26
+	}
+	if classes.upper {
+		pool += 26
+	}
+	if classes.digit {
+		pool += 10
+	}
+	if classes.symbol {
+		pool += 33
+	}
+	if classes.other {
+		pool += 100
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	seen := make(map[rune]int)
+	length := 0
+	for _, r := range password {
+		seen[r]++
+		if seen[r] <= 2 {
+			length++
+		}
+	}
+
+	return float64(length) * math.Log2(float64(pool))
+}
+
+// passwordCharClasses are the character classes used by a password.
+type passwordCharClasses struct {
+	upper, lower, digit, symbol, other bool
+}
+
+// passwordClasses returns the character classes used by password. other
+// is set for letters and digits outside of ASCII.
+func passwordClasses(password string) passwordCharClasses {
+	var classes passwordCharClasses
+	for _, r := range password {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			classes.upper = true
+		case r >= 'a' && r <= 'z':
+			classes.lower = true
+		case r >= '0' && r <= '9':
+			classes.digit = true
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			classes.other = true
+			classes.upper = classes.upper || unicode.IsUpper(r)
+			classes.lower = classes.lower || unicode.IsLower(r)
+		default:
+			classes.symbol = true
+		}
+	}
+	return classes
+}
+
+// passwordDenyList is the hook set with SetPasswordDenyList.
+var (
+	_passwordDenyList      func(password string) bool
+	_passwordDenyListMutex sync.RWMutex
+)
+
+// SetPasswordDenyList sets the hook that `password` rules use to reject
+// passwords that meet their policy but are known to be compromised or too
+// common (e.g. a breached-password lookup). denied reports whether a
+// password is not allowed. Passing nil removes the hook.
+func SetPasswordDenyList(denied func(password string) bool) {
+	InstallTagValidator()
+
+	_passwordDenyListMutex.Lock()
+	defer _passwordDenyListMutex.Unlock()
+
+	_passwordDenyList = denied
+}
+
+// PasswordDenySet returns a deny list hook for SetPasswordDenyList that
+// rejects the given passwords, ignoring case.
+func PasswordDenySet(passwords ...string) func(password string) bool {
+	set := make(map[string]struct{}, len(passwords))
+	for _, password := range passwords {
+		set[strings.ToLower(password)] = struct{}{}
+	}
+
+	return func(password string) bool {
+		_, ok := set[strings.ToLower(password)]
+		return ok
+	}
+}
+
+// isDeniedPassword reports whether the deny list hook rejects password.
+func isDeniedPassword(password string) bool {
+	_passwordDenyListMutex.RLock()
+	denied := _passwordDenyList
+	_passwordDenyListMutex.RUnlock()
+
+	return denied != nil && denied(password)
+}
+
+// validatePassword is the `password` validation rule, see PasswordPolicy.
+func validatePassword(value reflect.Value, param string) error {
+	password, err := stringRuleValue(value, PasswordValidationRule)
+	if err != nil {
+		return err
+	}
+
+	policy, err := ParsePasswordPolicy(param)
+	if err != nil {
+		return err
+	}
+
+	return policy.Check(password)
+}
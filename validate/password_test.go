@@ -0,0 +1,100 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+func TestParsePasswordPolicy(t *testing.T) {
+	policy, err := ParsePasswordPolicy("min12,max64,upper,lower,digit,symbol,entropy60")
+	require.NoError(t, err)
+	assert.Equal(t, PasswordPolicy{
+		MinLength:  12,
+		MaxLength:  64,
+		Upper:      true,
+		Lower:      true,
+		Digit:      true,
+		Symbol:     true,
+		MinEntropy: 60,
+	}, policy)
+
+	policy, err = ParsePasswordPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, PasswordPolicy{MinLength: DefaultPasswordMinLength}, policy)
+
+	_, err = ParsePasswordPolicy("min12,uppercase")
+	assert.ErrorIs(t, err, ErrInvalidValidateTag)
+
+	_, err = ParsePasswordPolicy("minX")
+	assert.ErrorIs(t, err, ErrInvalidValidateTag)
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 12, Upper: true, Lower: true, Digit: true, Symbol: true}
+
+	assert.NoError(t, policy.Check("Correct-Horse-9"))
+
+	err := policy.Check("short")
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	assert.Contains(t, err.Error(), "at least 12 characters, an upper case letter, a digit, a symbol")
+
+	assert.ErrorIs(t, PasswordPolicy{MaxLength: 4}.Check("toolong"), ErrWeakPassword)
+	assert.ErrorIs(t, PasswordPolicy{MinEntropy: 40}.Check("aaaaaaaaaaaaaaaa"), ErrWeakPassword)
+	assert.NoError(t, PasswordPolicy{MinEntropy: 40}.Check("tr0ub4dor&3x"))
+}
+
+func TestPasswordEntropy(t *testing.T) {
+	assert.Zero(t, PasswordEntropy(""))
+	assert.InDelta(t, 8*4.7004, PasswordEntropy("abcdefgh"), 0.01)
+	// Repeats beyond the second occurrence do not add entropy
+	assert.Equal(t, PasswordEntropy("aa"), PasswordEntropy("aaaaaaaa"))
+	assert.Greater(t, PasswordEntropy("Abcdefg1!"), PasswordEntropy("abcdefghi"))
+}
+
+func TestValidateStruct_Password(t *testing.T) {
+	type signup struct {
+		Password string `validate:"required,password=min12,upper,lower,digit,symbol"`
+		PIN      string `validate:"omitempty,password=min4,max6,digit"`
+	}
+
+	assert.NoError(t, ValidateStruct(&signup{Password: "Correct-Horse-9", PIN: "1234"}))
+
+	err := ValidateStruct(&signup{Password: "correct-horse-9"})
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	assert.Equal(t, "Password", parser.FieldPath(err))
+
+	var ruleErr *RuleError
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, PasswordValidationRule, ruleErr.Rule)
+	assert.Equal(t, "min12,upper,lower,digit,symbol", ruleErr.Param)
+
+	err = ValidateStruct(&signup{Password: "Correct-Horse-9", PIN: "1234567"})
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	assert.Equal(t, "PIN", parser.FieldPath(err))
+
+	t.Run("deny_list", func(t *testing.T) {
+		SetPasswordDenyList(PasswordDenySet("Correct-Horse-9"))
+		t.Cleanup(func() { SetPasswordDenyList(nil) })
+
+		err := ValidateStruct(&signup{Password: "CORRECT-HORSE-9"})
+		assert.ErrorIs(t, err, ErrWeakPassword, "policy is checked first")
+
+		err = ValidateStruct(&signup{Password: "correct-HORSE-9"})
+		assert.ErrorIs(t, err, ErrDeniedPassword)
+	})
+
+	t.Run("rules_after_list_param", func(t *testing.T) {
+		type login struct {
+			Password string `validate:"password=min4,digit,required"`
+		}
+		err := ValidateStruct(&login{})
+		var ruleErr *RuleError
+		require.ErrorAs(t, err, &ruleErr)
+		assert.Equal(t, PasswordValidationRule, ruleErr.Rule)
+		assert.Equal(t, "min4,digit", ruleErr.Param)
+	})
+}
@@ -0,0 +1,54 @@
+//go:build pave_phone
+
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+	"github.com/nyaruka/phonenumbers"
+)
+
+// The `phone` validation rule, backed by github.com/nyaruka/phonenumbers.
+// Build with the pave_phone tag to enable it, see parser.ParsePhoneNumber.
+
+// installPhone registers the phone integration. See installIntegrations.
+func installPhone() {
+	registerValidationRule(PhoneValidationRule, ignoreParent(validatePhone))
+}
+
+// validatePhone is the `phone` validation rule. `validate:"phone"` accepts
+// any valid number in international format, `validate:"phone=US"` any
+// valid number of that region, in national or international format.
+func validatePhone(value reflect.Value, param string) error {
+	s, err := stringRuleValue(value, PhoneValidationRule)
+	if err != nil {
+		return err
+	}
+
+	region := strings.ToUpper(param)
+	if region != "" && !isPhoneRegion(region) {
+		return fmt.Errorf("%w: %s", parser.ErrUnknownPhoneRegion, param)
+	}
+
+	e164, err := parser.ParsePhoneNumber(s, region)
+	if err != nil {
+		return err
+	}
+	if region == "" {
+		return nil
+	}
+
+	number, err := phonenumbers.Parse(e164, region)
+	if err != nil || !phonenumbers.IsValidNumberForRegion(number, region) {
+		return fmt.Errorf("%w: %q is not a %s number", parser.ErrInvalidPhoneNumber, s, region)
+	}
+	return nil
+}
+
+// isPhoneRegion reports whether region has a country calling code.
+func isPhoneRegion(region string) bool {
+	return phonenumbers.GetCountryCodeForRegion(region) != 0
+}
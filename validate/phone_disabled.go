@@ -0,0 +1,6 @@
+//go:build !pave_phone
+
+package validate
+
+// installPhone is a no-op without the pave_phone build tag.
+func installPhone() {}
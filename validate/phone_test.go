@@ -0,0 +1,31 @@
+//go:build pave_phone
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStruct_Phone(t *testing.T) {
+	type contact struct {
+		Any    string `validate:"omitempty,phone"`
+		US     string `validate:"omitempty,phone=us"`
+		Broken string `validate:"omitempty,phone=XX"`
+	}
+
+	assert.NoError(t, ValidateStruct(&contact{Any: "+442079460958", US: "415-555-2671"}))
+
+	err := ValidateStruct(&contact{Any: "415-555-2671"})
+	assert.ErrorIs(t, err, parser.ErrInvalidPhoneNumber)
+	assert.Equal(t, "Any", parser.FieldPath(err))
+
+	err = ValidateStruct(&contact{US: "+442079460958"})
+	assert.ErrorIs(t, err, parser.ErrInvalidPhoneNumber)
+	assert.Equal(t, "US", parser.FieldPath(err))
+
+	err = ValidateStruct(&contact{Broken: "+442079460958"})
+	assert.ErrorIs(t, err, parser.ErrUnknownPhoneRegion)
+}
@@ -0,0 +1,27 @@
+package validate
+
+import (
+	"reflect"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+// validateCron is the cron validation rule for string fields.
+func validateCron(value reflect.Value, _ string) error {
+	s, err := stringRuleValue(value, CronValidationRule)
+	if err != nil {
+		return err
+	}
+	_, err = parser.ParseCronSchedule(s)
+	return err
+}
+
+// validateRRule is the rrule validation rule for string fields.
+func validateRRule(value reflect.Value, _ string) error {
+	s, err := stringRuleValue(value, RRuleValidationRule)
+	if err != nil {
+		return err
+	}
+	_, err = parser.ParseRRule(s)
+	return err
+}
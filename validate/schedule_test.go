@@ -0,0 +1,22 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+func TestValidateStruct_Schedule(t *testing.T) {
+	type request struct {
+		Raw     string `validate:"cron"`
+		RawRule string `validate:"rrule"`
+	}
+
+	assert.NoError(t, ValidateStruct(&request{Raw: "@weekly", RawRule: "FREQ=YEARLY"}))
+
+	err := ValidateStruct(&request{Raw: "every day", RawRule: "FREQ=HOURLY;BYDAY=1XX"})
+	assert.ErrorIs(t, err, parser.ErrInvalidCron)
+	assert.ErrorIs(t, err, parser.ErrInvalidRRule)
+}
@@ -0,0 +1,72 @@
+//go:build pave_semver
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+// The `semver` and `semver_constraint` validation rules, backed by
+// github.com/Masterminds/semver/v3. Build with the pave_semver tag to
+// enable them.
+
+// installSemver registers the semver integration. See installIntegrations.
+func installSemver() {
+	registerValidationRule(SemverValidationRule, ignoreParent(validateSemver))
+	registerValidationRule(SemverConstraintValidationRule, ignoreParent(validateSemverConstraint))
+}
+
+// validateSemver is the semver validation rule. It applies to strings,
+// which must be semantic versions, and semver.Version fields. With a
+// param, the version must satisfy it: `validate:"semver=^1.2"`. Join
+// constraints with spaces rather than commas when they contain "=", which
+// would otherwise start a new rule: `validate:"semver=>=1.2 <2"`.
+func validateSemver(value reflect.Value, param string) error {
+	var version *semver.Version
+
+	switch v := value.Interface().(type) {
+	case semver.Version:
+		version = &v
+	case string:
+		parsed, err := semver.NewVersion(v)
+		if err != nil {
+			return fmt.Errorf("%w: %q: %w", parser.ErrInvalidSemver, v, err)
+		}
+		version = parsed
+	default:
+		return fmt.Errorf("%w: rule %s only applies to strings and semver.Version, got %s", ErrInvalidValidateTag, SemverValidationRule, value.Type())
+	}
+
+	if param == "" {
+		return nil
+	}
+
+	constraints, err := semver.NewConstraint(param)
+	if err != nil {
+		return fmt.Errorf("%w: rule %s: %q: %w", ErrInvalidValidateTag, SemverValidationRule, param, err)
+	}
+	if ok, reasons := constraints.Validate(version); !ok {
+		return fmt.Errorf("%w: %s: %w", parser.ErrSemverNotSatisfied, version, errors.Join(reasons...))
+	}
+	return nil
+}
+
+// validateSemverConstraint is the semver_constraint validation rule. It
+// applies to strings, which must be valid version constraints.
+func validateSemverConstraint(value reflect.Value, _ string) error {
+	s, err := stringRuleValue(value, SemverConstraintValidationRule)
+	if err != nil {
+		return err
+	}
+
+	if _, err := semver.NewConstraint(strings.TrimSpace(s)); err != nil {
+		return fmt.Errorf("%w: %q: %w", parser.ErrInvalidSemverConstraint, s, err)
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+//go:build !pave_semver
+
+package validate
+
+// installSemver is a no-op without the pave_semver build tag.
+func installSemver() {}
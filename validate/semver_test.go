@@ -0,0 +1,47 @@
+//go:build pave_semver
+
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/SimonDaKappa/go-pave/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStruct_Semver(t *testing.T) {
+	type plugin struct {
+		Version    string         `validate:"semver"`
+		APIVersion semver.Version `validate:"semver=>=1.2 <2"`
+		Compatible string         `validate:"omitempty,semver_constraint"`
+		Range      string         `validate:"omitempty,semver=^2.0,<2.5"`
+	}
+
+	api := *semver.MustParse("1.4.0")
+
+	assert.NoError(t, ValidateStruct(&plugin{Version: "1.0.0", APIVersion: api, Compatible: ">= 1.2, < 2", Range: "2.1.0"}))
+
+	err := ValidateStruct(&plugin{Version: "latest", APIVersion: *semver.MustParse("2.0.0"), Compatible: "is latest", Range: "2.6.0"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, parser.ErrInvalidSemver)
+	assert.ErrorIs(t, err, parser.ErrSemverNotSatisfied)
+	assert.ErrorIs(t, err, parser.ErrInvalidSemverConstraint)
+	assert.Equal(t, "Version", parser.FieldPath(err))
+}
+
+func TestValidateStruct_SemverOverridden(t *testing.T) {
+	type plugin struct {
+		Version string `validate:"semver"`
+	}
+
+	// The integration is installed before the rule is replaced, not after
+	RegisterValidationRule(SemverValidationRule, func(value reflect.Value, _ string) error {
+		return nil
+	})
+	t.Cleanup(func() { registerValidationRule(SemverValidationRule, ignoreParent(validateSemver)) })
+
+	assert.NoError(t, ValidateStruct(&plugin{Version: "latest"}))
+}
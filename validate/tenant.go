@@ -0,0 +1,17 @@
+package validate
+
+import (
+	"reflect"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+// validateTenant is the tenant validation rule for string fields.
+func validateTenant(value reflect.Value, _ string) error {
+	tenant, err := stringRuleValue(value, TenantValidationRule)
+	if err != nil {
+		return err
+	}
+	_, err = parser.ParseTenantID(tenant)
+	return err
+}
@@ -0,0 +1,18 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+func TestValidateStruct_Tenant(t *testing.T) {
+	type request struct {
+		Tenant string `validate:"tenant"`
+	}
+
+	assert.NoError(t, ValidateStruct(&request{Tenant: "acme"}))
+	assert.ErrorIs(t, ValidateStruct(&request{Tenant: "-acme"}), parser.ErrInvalidTenantID)
+}
@@ -0,0 +1,384 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+var (
+	ErrUnknownValidationRule = errors.New("unknown validation rule")
+	ErrInvalidValidateTag    = errors.New("invalid validate tag")
+	ErrValueRequired         = errors.New("value is required")
+)
+
+// ValidationRule checks the value of a field against one rule of its
+// `validate` tag. param is the text after "=" (e.g. "US" for
+// `validate:"phone=US"`), or empty if the rule has none.
+//
+// Pointers are dereferenced before a rule is called, rules never see nil
+// pointers: nil fields only fail the required rule.
+type ValidationRule func(value reflect.Value, param string) error
+
+// CrossFieldValidationRule is a ValidationRule that also receives parent,
+// the struct holding the field, so that it can compare the field with its
+// siblings (e.g. `validate:"inbbox=Area"`).
+type CrossFieldValidationRule func(value reflect.Value, parent reflect.Value, param string) error
+
+// RuleError is returned for a field that failed a validation rule.
+type RuleError struct {
+	Rule  string // Name of the rule that failed
+	Param string // Parameter of the rule, if any
+	Err   error  // Why the value failed the rule
+}
+
+func (e *RuleError) Error() string {
+	if e.Param != "" {
+		return fmt.Sprintf("rule %s=%s: %s", e.Rule, e.Param, e.Err)
+	}
+	return fmt.Sprintf("rule %s: %s", e.Rule, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+var _tagValidatorOnce sync.Once
+
+// InstallTagValidator sets the validator of the `validate` tags of parses
+// that ask for it to ValidateStruct, see parser.SetTagValidator. It does
+// so once: the functions of this package and of the pave package call it
+// on first use, so that importing this package has no side effects.
+// Programs that parse through the parser package without calling them
+// call it themselves.
+//
+// Validators set with parser.SetTagValidator before it runs are replaced.
+func InstallTagValidator() {
+	_tagValidatorOnce.Do(func() {
+		parser.SetTagValidator(validateParsed)
+	})
+}
+
+// validationRules holds the rules available to `validate` tags, keyed by
+// rule name.
+var (
+	_validationRules = map[string]CrossFieldValidationRule{
+		RequiredValidationRule:      ignoreParent(validateRequired),
+		PasswordValidationRule:      ignoreParent(validatePassword),
+		MaxFileSizeValidationRule:   ignoreParent(validateMaxFileSize),
+		MIMETypeValidationRule:      ignoreParent(validateMIMEType),
+		MaxFilesValidationRule:      ignoreParent(validateMaxFiles),
+		LatitudeValidationRule:      ignoreParent(validateLatitude),
+		LongitudeValidationRule:     ignoreParent(validateLongitude),
+		InBoundingBoxValidationRule: validateInBoundingBox,
+		CronValidationRule:          ignoreParent(validateCron),
+		RRuleValidationRule:         ignoreParent(validateRRule),
+		TimezoneValidationRule:      ignoreParent(validateTimezone),
+		TenantValidationRule:        ignoreParent(validateTenant),
+	}
+	_validationRulesMutex sync.RWMutex
+)
+
+// RegisterValidationRule makes rule available to `validate` tags as name.
+// Registering a rule for an already registered name replaces it.
+func RegisterValidationRule(name string, rule ValidationRule) {
+	RegisterCrossFieldValidationRule(name, ignoreParent(rule))
+}
+
+// RegisterCrossFieldValidationRule is RegisterValidationRule for rules
+// that depend on the other fields of the struct.
+func RegisterCrossFieldValidationRule(name string, rule CrossFieldValidationRule) {
+	InstallTagValidator()
+	installIntegrations()
+	registerValidationRule(name, rule)
+}
+
+// registerValidationRule is RegisterCrossFieldValidationRule, without
+// installing the integrations first.
+func registerValidationRule(name string, rule CrossFieldValidationRule) {
+	_validationRulesMutex.Lock()
+	defer _validationRulesMutex.Unlock()
+
+	_validationRules[name] = rule
+}
+
+// ignoreParent adapts a rule that does not depend on other fields.
+func ignoreParent(rule ValidationRule) CrossFieldValidationRule {
+	return func(value reflect.Value, _ reflect.Value, param string) error {
+		return rule(value, param)
+	}
+}
+
+// UnregisterValidationRule removes the rule registered as name, if any.
+func UnregisterValidationRule(name string) {
+	InstallTagValidator()
+	installIntegrations()
+
+	_validationRulesMutex.Lock()
+	defer _validationRulesMutex.Unlock()
+
+	delete(_validationRules, name)
+}
+
+// getValidationRule returns the rule registered as name, if any.
+func getValidationRule(name string) (CrossFieldValidationRule, bool) {
+	installIntegrations()
+
+	_validationRulesMutex.RLock()
+	defer _validationRulesMutex.RUnlock()
+
+	rule, ok := _validationRules[name]
+	return rule, ok
+}
+
+// ValidateStruct checks the `validate` tags of every field of v, which
+// must be a struct or a pointer to one, including nested structs.
+//
+// A tag lists rules separated by commas, each either a name or
+// name=param: `validate:"required,phone=US"`. Elements following a param
+// that are not rule names extend it, so parameters can be lists:
+// `validate:"password=min12,upper,digit"`. The omitempty rule skips all
+// other rules of a field holding its zero value.
+//
+// All fields are checked. The returned error joins a *parser.FieldError
+// per failed field (nested fields wrapped in the FieldError of their
+// parent, see parser.FieldPath) around the *RuleError of the first rule
+// that failed. Past parser.DefaultMaxErrors failed fields, the rest are
+// summarized by a *parser.MoreErrors.
+func ValidateStruct(v any) error {
+	InstallTagValidator()
+	return validateStruct(v, nil, parser.DefaultMaxErrors, false)
+}
+
+// ValidateMasked is ValidateStruct, checking only the fields that paths
+// select, see parser.MaskedParser, so that the fields a masked parse left
+// alone are not validated.
+func ValidateMasked(v any, paths []string) error {
+	InstallTagValidator()
+	return validateStruct(v, parser.SplitMaskPaths(paths), parser.DefaultMaxErrors, false)
+}
+
+// validateParsed is the TagValidator of parses, see InstallTagValidator.
+// It skips rules that are not registered rather than failing, so that
+// tags shared with other validators (e.g. `validate:"required,alphanum"`
+// for github.com/go-playground/validator, run by a Validate method) keep
+// working.
+func validateParsed(v any, paths [][]string, maxErrors int) error {
+	return validateStruct(v, paths, maxErrors, true)
+}
+
+// validateStruct is ValidateStruct, checking the fields that the mask
+// paths select (see parser.MaskedParser) and collecting maxErrors errors
+// at most (all if negative). Rules that are not registered are skipped if
+// skipUnknown is set, and fail with ErrUnknownValidationRule otherwise.
+func validateStruct(v any, paths [][]string, maxErrors int, skipUnknown bool) error {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: cannot validate %T", ErrInvalidValidateTag, v)
+	}
+
+	limit := &parser.ErrorLimit{Max: maxErrors, Partial: true}
+	errs := validateStructValue(value, paths, limit, skipUnknown)
+	return errors.Join(append(errs, limit.More())...)
+}
+
+// validateStructValue returns the FieldErrors of the invalid fields that
+// the mask paths select, see parser.MaskedParser, as many as limit
+// collects. Nil paths select every field.
+func validateStructValue(value reflect.Value, paths [][]string, limit *parser.ErrorLimit, skipUnknown bool) []error {
+	var errs []error
+
+	// Validation stops at the first error that is not collected, so that
+	// adversarial payloads do not pay for the rules of every field
+	typ := value.Type()
+	for i := 0; i < typ.NumField() && !limit.Done(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		// Nested fields are masked by the paths below their field
+		var below [][]string
+		if paths != nil {
+			var whole bool
+			if whole, below = parser.SelectMaskPaths(paths, field); !whole && len(below) == 0 {
+				continue
+			}
+		}
+
+		fieldValue := value.Field(i)
+
+		// Unknown profiles fail the chain build, see parser.RegisterProfile
+		fieldTag, _ := parser.ProfiledTag(field)
+		if tag, ok := fieldTag.Lookup(parser.ValidateTagName); ok {
+			if err := validateField(fieldValue, value, tag, skipUnknown); err != nil {
+				if limit.Collect() {
+					// Rules echo values, which ErrorValueOpts truncates or redacts
+					err = &parser.ValueError{Value: errorValue(fieldValue), PII: fieldTag.Get(parser.PIITagName), Err: err}
+					errs = append(errs, &parser.FieldError{Field: field.Name, Err: err, Validation: true})
+				}
+				continue
+			}
+		}
+
+		nested := fieldValue
+		if nested.Kind() == reflect.Ptr && !nested.IsNil() {
+			nested = nested.Elem()
+		}
+		if nested.Kind() == reflect.Struct && !parser.IsSpecialStructType(nested.Type()) {
+			if nestedErrs := validateStructValue(nested, below, limit, skipUnknown); len(nestedErrs) > 0 {
+				errs = append(errs, &parser.FieldError{Field: field.Name, Err: errors.Join(nestedErrs...), Validation: true})
+			}
+		}
+	}
+
+	return errs
+}
+
+// errorValue returns the value of a field the way rules echo it in their
+// errors.
+func errorValue(value reflect.Value) string {
+	if parser.IsOptType(value.Type()) {
+		value = parser.OptValue(value)
+	}
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Ptr || !value.CanInterface() {
+		return ""
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// splitValidateTag splits tag into its rules. An element that is neither
+// name=param nor a registered rule continues the parameter of the rule
+// before it, so that parameters can be lists: "password=min12,upper,digit"
+// is the single rule password with the parameter "min12,upper,digit".
+func splitValidateTag(tag string) []string {
+	var rules []string
+	for _, rule := range strings.Split(tag, parser.CommaDelimeter) {
+		rule = strings.TrimSpace(rule)
+
+		if n := len(rules); n > 0 && rule != "" && !strings.Contains(rule, parser.ModifierKeyValueDelimiter) &&
+			rule != OmitEmptyValidationRule && strings.Contains(rules[n-1], parser.ModifierKeyValueDelimiter) {
+			if _, ok := getValidationRule(rule); !ok {
+				rules[n-1] += parser.CommaDelimeter + rule
+				continue
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// validateField applies the rules of tag to value, a field of parent,
+// stopping at the first rule that fails. Rules that are not registered
+// are skipped if skipUnknown is set.
+func validateField(value reflect.Value, parent reflect.Value, tag string, skipUnknown bool) error {
+	rules := splitValidateTag(tag)
+
+	if slices.Contains(rules, OmitEmptyValidationRule) && value.IsZero() {
+		return nil
+	}
+
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, parser.ModifierKeyValueDelimiter)
+		if name == "" {
+			return fmt.Errorf("%w: empty rule in %q", ErrInvalidValidateTag, tag)
+		}
+		if name == OmitEmptyValidationRule {
+			continue
+		}
+
+		validate, ok := getValidationRule(name)
+		if !ok {
+			if skipUnknown {
+				continue
+			}
+			return fmt.Errorf("%w: %s", ErrUnknownValidationRule, name)
+		}
+
+		target := value
+		for target.Kind() == reflect.Ptr && !target.IsNil() {
+			target = target.Elem()
+		}
+		if target.Kind() == reflect.Ptr && name != RequiredValidationRule {
+			// nil pointers only fail the required rule
+			continue
+		}
+		if parser.IsOptType(target.Type()) {
+			// Like nil pointers, unset Opt values only fail the required
+			// rule, which set values always pass
+			if !target.Field(1).Bool() {
+				if name == RequiredValidationRule {
+					return &RuleError{Rule: name, Param: param, Err: ErrValueRequired}
+				}
+				continue
+			}
+			if name == RequiredValidationRule {
+				continue
+			}
+			target = parser.OptValue(target)
+		}
+		if parser.IsUnionType(target.Type()) {
+			// Rules check the value a union holds, empty unions are unset
+			held, ok := parser.UnionValue(target)
+			if !ok {
+				if name == RequiredValidationRule {
+					return &RuleError{Rule: name, Param: param, Err: ErrValueRequired}
+				}
+				continue
+			}
+			target = held
+		}
+
+		if err := validate(target, parent, param); err != nil {
+			return &RuleError{Rule: name, Param: param, Err: err}
+		}
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Built-in Rules
+///////////////////////////////////////////////////////////////////////////////
+
+// validateRequired fails for zero values, including nil pointers.
+func validateRequired(value reflect.Value, _ string) error {
+	if value.IsZero() {
+		return ErrValueRequired
+	}
+	return nil
+}
+
+// siblingField returns the field name of parent for cross-field rules.
+func siblingField(parent reflect.Value, name string, rule string) (reflect.Value, error) {
+	field := parent.FieldByName(name)
+	if !field.IsValid() {
+		return reflect.Value{}, fmt.Errorf("%w: rule %s refers to unknown field %s", ErrInvalidValidateTag, rule, name)
+	}
+	return field, nil
+}
+
+// stringRuleValue returns the string held by value for rules that only
+// apply to strings.
+func stringRuleValue(value reflect.Value, rule string) (string, error) {
+	if value.Kind() != reflect.String {
+		return "", fmt.Errorf("%w: rule %s only applies to strings, got %s", ErrInvalidValidateTag, rule, value.Type())
+	}
+	return value.String(), nil
+}
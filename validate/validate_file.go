@@ -0,0 +1,193 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+var (
+	ErrFileTooLarge        = errors.New("file is too large")
+	ErrFileTypeNotAllowed  = errors.New("file type is not allowed")
+	ErrTooManyFiles        = errors.New("too many files")
+	ErrFileRuleTarget      = errors.New("file rule applied to a field that holds no files")
+	ErrInvalidFileSizeRule = errors.New("invalid file size")
+)
+
+// _sniffLen is the number of bytes http.DetectContentType considers.
+const _sniffLen = 512
+
+// File validation rules apply to *multipart.FileHeader and
+// []*multipart.FileHeader fields, and check every file of the field:
+//
+//	maxfilesize=<size>   every file is at most size bytes, with an optional
+//	                     B, KB, MB or GB suffix (multiples of 1024)
+//	mimetype=<types>     the sniffed content type of every file is one of
+//	                     types, e.g. mimetype=image/png,image/jpeg or image/*
+//	maxfiles=<n>         the field holds at most n files
+//
+// The content type is detected from the first 512 bytes of the file with
+// http.DetectContentType; the Content-Type declared by the client is
+// ignored. As rules run when the destination is validated, invalid files
+// are rejected before the handler reads them.
+
+// validateMaxFileSize is the maxfilesize validation rule.
+func validateMaxFileSize(value reflect.Value, param string) error {
+	limit, err := parseByteSize(param)
+	if err != nil {
+		return err
+	}
+
+	files, err := ruleFileHeaders(value, MaxFileSizeValidationRule)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if file.Size > limit {
+			return fmt.Errorf("%w: %s has %d bytes, at most %d allowed", ErrFileTooLarge, file.Filename, file.Size, limit)
+		}
+	}
+	return nil
+}
+
+// validateMIMEType is the mimetype validation rule.
+func validateMIMEType(value reflect.Value, param string) error {
+	if param == "" {
+		return fmt.Errorf("%w: rule %s requires a list of types", ErrInvalidValidateTag, MIMETypeValidationRule)
+	}
+	allowed := strings.Split(param, parser.CommaDelimeter)
+
+	files, err := ruleFileHeaders(value, MIMETypeValidationRule)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		detected, err := sniffFileType(file)
+		if err != nil {
+			return err
+		}
+		if !matchesMIMEType(detected, allowed) {
+			return fmt.Errorf("%w: %s is %s", ErrFileTypeNotAllowed, file.Filename, detected)
+		}
+	}
+	return nil
+}
+
+// validateMaxFiles is the maxfiles validation rule.
+func validateMaxFiles(value reflect.Value, param string) error {
+	limit, err := strconv.Atoi(param)
+	if err != nil || limit < 0 {
+		return fmt.Errorf("%w: rule %s requires a count, got %q", ErrInvalidValidateTag, MaxFilesValidationRule, param)
+	}
+
+	files, err := ruleFileHeaders(value, MaxFilesValidationRule)
+	if err != nil {
+		return err
+	}
+
+	if len(files) > limit {
+		return fmt.Errorf("%w: got %d, at most %d allowed", ErrTooManyFiles, len(files), limit)
+	}
+	return nil
+}
+
+// ruleFileHeaders returns the files held by value, which is a dereferenced
+// *multipart.FileHeader or a []*multipart.FileHeader. nil entries are skipped.
+func ruleFileHeaders(value reflect.Value, rule string) ([]*multipart.FileHeader, error) {
+	if !value.CanAddr() {
+		addressable := reflect.New(value.Type()).Elem()
+		addressable.Set(value)
+		value = addressable
+	}
+
+	switch files := value.Addr().Interface().(type) {
+	case *multipart.FileHeader:
+		return []*multipart.FileHeader{files}, nil
+	case *[]*multipart.FileHeader:
+		result := make([]*multipart.FileHeader, 0, len(*files))
+		for _, file := range *files {
+			if file != nil {
+				result = append(result, file)
+			}
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%w: rule %s, got %s", ErrFileRuleTarget, rule, value.Type())
+	}
+}
+
+// sniffFileType detects the content type of file from its first bytes.
+func sniffFileType(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("cannot open %s: %w", file.Filename, err)
+	}
+	defer f.Close()
+
+	head := make([]byte, _sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("cannot read %s: %w", file.Filename, err)
+	}
+
+	return http.DetectContentType(head[:n]), nil
+}
+
+// matchesMIMEType reports whether the media type of contentType is one of
+// allowed, which may hold wildcards of the form "type/*".
+func matchesMIMEType(contentType string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if mediaType == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// parseByteSize parses a size in bytes with an optional B, KB, MB or GB
+// suffix, in multiples of 1024.
+func parseByteSize(s string) (int64, error) {
+	number := strings.ToUpper(strings.TrimSpace(s))
+
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix     string
+		multiplier int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	} {
+		if trimmed, ok := strings.CutSuffix(number, unit.suffix); ok {
+			number, multiplier = strings.TrimSpace(trimmed), unit.multiplier
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidFileSizeRule, s)
+	}
+	return size * multiplier, nil
+}
@@ -0,0 +1,111 @@
+package validate
+
+import (
+	"bytes"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+)
+
+var _pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// testFileHeaders builds the file headers of a multipart form with one
+// file per content, all under the form field "files".
+func testFileHeaders(t *testing.T, contents ...[]byte) []*multipart.FileHeader {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for i, content := range contents {
+		// Declared as text/plain to check that the type is sniffed
+		part, err := writer.CreateFormFile("files", string(rune('a'+i))+".txt")
+		require.NoError(t, err)
+		_, err = part.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = form.RemoveAll() })
+
+	return form.File["files"]
+}
+
+func TestValidateStruct_Files(t *testing.T) {
+	type upload struct {
+		Avatar      *multipart.FileHeader   `validate:"required,maxfilesize=1KB,mimetype=image/png,image/jpeg"`
+		Attachments []*multipart.FileHeader `validate:"maxfiles=2,maxfilesize=16B,mimetype=text/*"`
+	}
+
+	png := testFileHeaders(t, _pngHeader)[0]
+	texts := testFileHeaders(t, []byte("hello"), []byte("world"), []byte("again"))
+
+	assert.NoError(t, ValidateStruct(upload{Avatar: png, Attachments: texts[:2]}))
+
+	t.Run("required", func(t *testing.T) {
+		err := ValidateStruct(&upload{})
+		assert.ErrorIs(t, err, ErrValueRequired)
+		assert.Equal(t, "Avatar", parser.FieldPath(err))
+	})
+
+	t.Run("sniffed_type", func(t *testing.T) {
+		err := ValidateStruct(&upload{Avatar: texts[0]})
+		assert.ErrorIs(t, err, ErrFileTypeNotAllowed)
+		assert.Contains(t, err.Error(), "text/plain")
+
+		err = ValidateStruct(&upload{Avatar: png, Attachments: []*multipart.FileHeader{png}})
+		assert.ErrorIs(t, err, ErrFileTypeNotAllowed)
+		assert.Equal(t, "Attachments", parser.FieldPath(err))
+	})
+
+	t.Run("max_size", func(t *testing.T) {
+		large := testFileHeaders(t, bytes.Repeat([]byte("a"), 17))
+		err := ValidateStruct(&upload{Avatar: png, Attachments: large})
+		assert.ErrorIs(t, err, ErrFileTooLarge)
+	})
+
+	t.Run("max_files", func(t *testing.T) {
+		err := ValidateStruct(&upload{Avatar: png, Attachments: texts})
+		assert.ErrorIs(t, err, ErrTooManyFiles)
+	})
+
+	t.Run("wrong_field_type", func(t *testing.T) {
+		invalid := struct {
+			Name string `validate:"maxfiles=1"`
+		}{}
+		assert.ErrorIs(t, ValidateStruct(&invalid), ErrFileRuleTarget)
+	})
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := map[string]int64{
+		"512":   512,
+		"512B":  512,
+		"10kb":  10 << 10,
+		"10 MB": 10 << 20,
+		"1GB":   1 << 30,
+		"0":     0,
+	}
+	for input, want := range tests {
+		got, err := parseByteSize(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "MB", "-1", "1TB", "1.5MB"} {
+		_, err := parseByteSize(input)
+		assert.ErrorIs(t, err, ErrInvalidFileSizeRule, input)
+	}
+}
+
+func TestMatchesMIMEType(t *testing.T) {
+	assert.True(t, matchesMIMEType("text/plain; charset=utf-8", []string{"text/plain"}))
+	assert.True(t, matchesMIMEType("image/png", []string{"image/jpeg", "image/*"}))
+	assert.False(t, matchesMIMEType("application/octet-stream", []string{"image/*"}))
+	assert.False(t, matchesMIMEType("imagery/png", []string{"image/*"}))
+}
@@ -0,0 +1,464 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SimonDaKappa/go-pave/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validateAddress struct {
+	Street string `validate:"required"`
+}
+
+type validateTestStruct struct {
+	Name     string           `validate:"required"`
+	Nickname *string          `validate:"required"`
+	Email    string           `validate:"omitempty,lowercase"`
+	Age      *int             `validate:"lowercase"`
+	Address  validateAddress  // nested, no tag of its own
+	Billing  *validateAddress // nil nested pointers are not validated
+}
+
+func registerLowercaseRule(t *testing.T) {
+	RegisterValidationRule("lowercase", func(value reflect.Value, _ string) error {
+		s, err := stringRuleValue(value, "lowercase")
+		if err != nil {
+			return err
+		}
+		if s != strings.ToLower(s) {
+			return errors.New("must be lower case")
+		}
+		return nil
+	})
+	t.Cleanup(func() { UnregisterValidationRule("lowercase") })
+}
+
+func TestValidateStruct(t *testing.T) {
+	registerLowercaseRule(t)
+
+	nickname := "bob"
+
+	t.Run("valid", func(t *testing.T) {
+		v := validateTestStruct{
+			Name:     "Bob",
+			Nickname: &nickname,
+			Address:  validateAddress{Street: "Main St"},
+		}
+		assert.NoError(t, ValidateStruct(&v))
+	})
+
+	t.Run("all_fields_reported", func(t *testing.T) {
+		v := validateTestStruct{Email: "Bob@Example.com"}
+		err := ValidateStruct(v)
+		require.Error(t, err)
+
+		var paths []string
+		for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+			paths = append(paths, parser.FieldPath(err))
+		}
+		assert.Equal(t, []string{"Name", "Nickname", "Email", "Address.Street"}, paths)
+		assert.ErrorIs(t, err, ErrValueRequired)
+
+		var ruleErr *RuleError
+		require.ErrorAs(t, err, &ruleErr)
+		assert.Equal(t, RequiredValidationRule, ruleErr.Rule)
+	})
+
+	t.Run("nil_pointer_skips_other_rules", func(t *testing.T) {
+		v := validateTestStruct{Name: "Bob", Nickname: &nickname, Address: validateAddress{"x"}}
+		assert.NoError(t, ValidateStruct(&v))
+	})
+
+	t.Run("nested_pointer", func(t *testing.T) {
+		v := validateTestStruct{
+			Name:     "Bob",
+			Nickname: &nickname,
+			Address:  validateAddress{"x"},
+			Billing:  &validateAddress{},
+		}
+		err := ValidateStruct(&v)
+		require.Error(t, err)
+		assert.Equal(t, "Billing.Street", parser.FieldPath(err))
+	})
+
+	t.Run("rule_on_wrong_kind", func(t *testing.T) {
+		age := 3
+		v := validateTestStruct{Name: "Bob", Nickname: &nickname, Address: validateAddress{"x"}, Age: &age}
+		err := ValidateStruct(&v)
+		assert.ErrorIs(t, err, ErrInvalidValidateTag)
+		assert.Equal(t, "Age", parser.FieldPath(err))
+	})
+
+	t.Run("unknown_rule", func(t *testing.T) {
+		v := struct {
+			Name string `validate:"nosuchrule"`
+		}{}
+		assert.ErrorIs(t, ValidateStruct(&v), ErrUnknownValidationRule)
+	})
+
+	t.Run("not_a_struct", func(t *testing.T) {
+		assert.ErrorIs(t, ValidateStruct("nope"), ErrInvalidValidateTag)
+	})
+}
+
+func TestValidateStruct_RuleParam(t *testing.T) {
+	RegisterValidationRule("prefix", func(value reflect.Value, param string) error {
+		s, err := stringRuleValue(value, "prefix")
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(s, param) {
+			return fmt.Errorf("must start with %q", param)
+		}
+		return nil
+	})
+	t.Cleanup(func() { UnregisterValidationRule("prefix") })
+
+	v := struct {
+		ID string `validate:"required, prefix=usr_"`
+	}{ID: "grp_1"}
+
+	err := ValidateStruct(&v)
+	var ruleErr *RuleError
+	require.ErrorAs(t, err, &ruleErr)
+	assert.Equal(t, "prefix", ruleErr.Rule)
+	assert.Equal(t, "usr_", ruleErr.Param)
+	assert.Contains(t, err.Error(), `rule prefix=usr_: must start with "usr_"`)
+
+	v.ID = "usr_1"
+	assert.NoError(t, ValidateStruct(&v))
+}
+
+// funcParser parses sources of sourceType with parseFunc.
+type funcParser struct {
+	name       string
+	sourceType reflect.Type
+	parseFunc  func(source any, dest any) error
+}
+
+func (p *funcParser) Name() string {
+	return p.name
+}
+
+func (p *funcParser) SourceType() reflect.Type {
+	return p.sourceType
+}
+
+func (p *funcParser) Parse(source any, dest any) error {
+	return p.parseFunc(source, dest)
+}
+
+func TestInstallTagValidator(t *testing.T) {
+	parser.SetTagValidator(nil)
+	_tagValidatorOnce = sync.Once{}
+	t.Cleanup(InstallTagValidator)
+
+	type request struct {
+		Name string `query:"name" validate:"required"`
+	}
+
+	registry, err := parser.NewParserRegistry(parser.ParserRegistryOpts{Parsers: []parser.Parser{parser.NewHTTPRequestParser()}})
+	require.NoError(t, err)
+	req, err := http.NewRequest("GET", "http://example.com/?name=", nil)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, registry.Parse(req, &request{}, true), parser.ErrNoTagValidator, "not installed when imported")
+
+	require.Error(t, ValidateStruct(&request{}))
+	assert.ErrorIs(t, registry.Parse(req, &request{}, true), ErrValueRequired, "installed on first use")
+}
+
+func TestParserRegistry_ValidateTags(t *testing.T) {
+	InstallTagValidator()
+	registry, err := parser.NewParserRegistry(parser.ParserRegistryOpts{ExcludeDefaults: true})
+	require.NoError(t, err)
+
+	type request struct {
+		Name string `validate:"required"`
+	}
+
+	require.NoError(t, registry.Register(&funcParser{
+		name:       "test_parser",
+		sourceType: reflect.TypeOf(""),
+		parseFunc: func(source any, dest any) error {
+			dest.(*request).Name = source.(string)
+			return nil
+		},
+	}))
+
+	dest := &request{}
+	require.NoError(t, registry.Parse("bob", dest, true))
+	assert.Equal(t, "bob", dest.Name)
+
+	// Tags are only checked when validating
+	require.NoError(t, registry.Parse("", dest, false))
+
+	err = registry.Parse("", dest, true)
+	var validationErr *parser.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.ErrorIs(t, err, ErrValueRequired)
+	assert.Equal(t, "Name", parser.FieldPath(err))
+}
+
+func TestParserRegistry_ValidateUnknownRules(t *testing.T) {
+	InstallTagValidator()
+	registry, err := parser.NewParserRegistry(parser.ParserRegistryOpts{ExcludeDefaults: true})
+	require.NoError(t, err)
+
+	type request struct {
+		Name string `validate:"required,alphanum,gte=3"`
+	}
+
+	require.NoError(t, registry.Register(&funcParser{
+		name:       "test_parser",
+		sourceType: reflect.TypeOf(""),
+		parseFunc: func(source any, dest any) error {
+			dest.(*request).Name = source.(string)
+			return nil
+		},
+	}))
+
+	require.NoError(t, registry.Parse("bob", &request{}, true), "unknown rules are skipped")
+
+	err = registry.Parse("", &request{}, true)
+	assert.ErrorIs(t, err, ErrValueRequired)
+	assert.Contains(t, err.Error(), "invalid field Name")
+	assert.NotContains(t, err.Error(), "failed to parse field")
+
+	assert.ErrorIs(t, ValidateStruct(&request{Name: "bob"}), ErrUnknownValidationRule)
+}
+
+func TestParserRegistry_ValidateErrorValues(t *testing.T) {
+	InstallTagValidator()
+	errNotEven := errors.New("not even")
+	RegisterValidationRule("even", func(value reflect.Value, _ string) error {
+		if value.Len()%2 != 0 {
+			return fmt.Errorf("%w: %q", errNotEven, value.String())
+		}
+		return nil
+	})
+	t.Cleanup(func() { UnregisterValidationRule("even") })
+
+	type request struct {
+		Phone string `query:"phone" validate:"even" pii:"contact"`
+		Code  string `query:"code" validate:"even"`
+	}
+
+	registry, err := parser.NewParserRegistry(parser.ParserRegistryOpts{
+		Parsers:     []parser.Parser{parser.NewHTTPRequestParser()},
+		ErrorValues: parser.ErrorValueOpts{RedactPII: true},
+	})
+	require.NoError(t, err)
+	req, err := http.NewRequest("GET", "http://example.com/?phone=5551234&code=abc", nil)
+	require.NoError(t, err)
+
+	err = registry.Parse(req, &request{}, true)
+	var validationErr *parser.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.ErrorIs(t, err, errNotEven)
+	assert.NotContains(t, err.Error(), "5551234", "values of pii fields are redacted")
+	assert.Contains(t, err.Error(), parser.RedactedErrorValue)
+	assert.Contains(t, err.Error(), `"abc"`)
+
+	var valueErr *parser.ValueError
+	require.ErrorAs(t, err, &valueErr)
+	assert.Equal(t, "5551234", valueErr.Value)
+	assert.Equal(t, "contact", valueErr.PII)
+}
+
+func TestValidateStruct_MaxErrors(t *testing.T) {
+	err := ValidateStruct(maxErrorsStruct(15))
+
+	var more *parser.MoreErrors
+	require.ErrorAs(t, err, &more)
+	assert.True(t, more.Partial, "validation stops at the first error not collected")
+	assert.Equal(t, 1, more.Count)
+	assert.ErrorIs(t, err, parser.ErrTooManyErrors)
+	assert.True(t, strings.HasSuffix(err.Error(), "and more errors"))
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 17, "15 fields, the nested struct and the marker")
+
+	err = ValidateStruct(maxErrorsStruct(10))
+	assert.NotErrorIs(t, err, parser.ErrTooManyErrors)
+}
+
+func TestValidateStruct_Opt(t *testing.T) {
+	type Update struct {
+		Age parser.Opt[int] `validate:"lat"`
+	}
+	assert.NoError(t, ValidateStruct(&Update{}), "unset values skip rules")
+	assert.NoError(t, ValidateStruct(&Update{Age: parser.Some(18)}))
+	assert.Error(t, ValidateStruct(&Update{Age: parser.Some(120)}))
+
+	type Required struct {
+		Name parser.Opt[string] `validate:"required"`
+	}
+	assert.ErrorIs(t, ValidateStruct(&Required{Name: parser.Opt[string]{Value: "jane"}}), ErrValueRequired)
+	assert.NoError(t, ValidateStruct(&Required{Name: parser.Some("")}))
+}
+
+func TestValidateStruct_Profiles(t *testing.T) {
+	parser.RegisterProfile("validate_test_tenant", `header:"X-Tenant-ID,omitempty" query:"tenant" validate:"tenant"`)
+	t.Cleanup(func() { parser.UnregisterProfile("validate_test_tenant") })
+
+	type CreateOrder struct {
+		Tenant string `use:"validate_test_tenant"`
+	}
+
+	assert.NoError(t, ValidateStruct(&CreateOrder{Tenant: "acme"}))
+
+	var ruleErr *RuleError
+	assert.ErrorAs(t, ValidateStruct(&CreateOrder{Tenant: "ACME"}), &ruleErr, "profiles carry validation rules")
+}
+
+func TestValidateStruct_Union(t *testing.T) {
+	type Query struct {
+		Lat parser.Union2[float64, string] `query:"lat" validate:"required"`
+	}
+
+	assert.ErrorIs(t, ValidateStruct(&Query{}), ErrValueRequired)
+	assert.NoError(t, ValidateStruct(&Query{Lat: parser.UnionSecond[float64]("north")}))
+}
+
+// maxErrorsStruct returns a struct value with count required fields, all
+// empty, and a nested struct with count more.
+func maxErrorsStruct(count int) any {
+	fields := make([]reflect.StructField, count)
+	for i := range fields {
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("F%d", i),
+			Type: reflect.TypeFor[string](),
+			Tag:  `validate:"required"`,
+		}
+	}
+	nested := reflect.StructOf(fields)
+
+	fields = append(fields, reflect.StructField{Name: "Nested", Type: nested})
+	return reflect.New(reflect.StructOf(fields)).Interface()
+}
+
+func TestParserRegistry_MaxErrors(t *testing.T) {
+	InstallTagValidator()
+
+	type Query struct {
+		A string `query:"a,omitempty" default:"" validate:"required"`
+		B string `query:"b,omitempty" default:"" validate:"required"`
+		C string `query:"c,omitempty" default:"" validate:"required"`
+	}
+
+	tests := []struct {
+		max  int
+		more int
+	}{
+		{max: 1, more: 1},
+		{max: 0, more: 0},
+		{max: -1, more: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.max), func(t *testing.T) {
+			registry, err := parser.NewParserRegistry(parser.ParserRegistryOpts{
+				MaxErrors: test.max,
+				Parsers:   []parser.Parser{parser.NewHTTPRequestParser()},
+			})
+			require.NoError(t, err)
+
+			req, err := http.NewRequest("GET", "http://example.com/", nil)
+			require.NoError(t, err)
+			err = registry.Parse(req, &Query{}, true)
+
+			var validationErr *parser.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+
+			var more *parser.MoreErrors
+			if test.more == 0 {
+				assert.False(t, errors.As(err, &more))
+				return
+			}
+			require.ErrorAs(t, err, &more)
+			assert.Equal(t, test.more, more.Count)
+		})
+	}
+}
+
+func TestParserRegistry_ParseMasked(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip" validate:"required"`
+	}
+	type Update struct {
+		Name    string  `json:"name" validate:"required"`
+		Email   string  `json:"email"`
+		Address Address `json:"address,scope"`
+	}
+
+	InstallTagValidator()
+	registry, err := parser.NewParserRegistry(parser.ParserRegistryOpts{Parsers: []parser.Parser{parser.NewHTTPRequestParser()}})
+	require.NoError(t, err)
+
+	newRequest := func(t *testing.T, body string) *http.Request {
+		req, err := http.NewRequest("PATCH", "http://example.com/users/1", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	t.Run("ValidatesMaskedFields", func(t *testing.T) {
+		update := Update{Email: "jane@example.com"}
+		req := newRequest(t, `{"email": "j@example.com", "address": {"city": "Lyon"}}`)
+		req.Header.Set("X-Fields", "email, address.city")
+
+		err := registry.ParseMasked(req, &update, parser.HeaderFieldMask(req, "X-Fields"), true)
+		require.NoError(t, err, "the required name and zip are not masked")
+		assert.Equal(t, "j@example.com", update.Email)
+	})
+
+	t.Run("InvalidMaskedField", func(t *testing.T) {
+		var update Update
+		req := newRequest(t, `{"name": "", "address": {"zip": "1"}}`)
+
+		err := registry.ParseMasked(req, &update, []string{"name"}, true)
+		var validationErr *parser.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "Name", parser.FieldPath(validationErr.Err))
+	})
+}
+
+func TestValidateMasked(t *testing.T) {
+	type Address struct {
+		City string `validate:"required"`
+		Zip  string `validate:"required"`
+	}
+	type Update struct {
+		Name    string `validate:"required"`
+		Address Address
+	}
+
+	assert.NoError(t, ValidateMasked(&Update{Address: Address{City: "Lyon"}}, []string{"address.city"}))
+
+	err := ValidateMasked(&Update{}, []string{" Name ", "address.zip"})
+	assert.ErrorIs(t, err, ErrValueRequired)
+	assert.NotContains(t, err.Error(), "City")
+}
+
+func TestParseFrozen_Validate(t *testing.T) {
+	InstallTagValidator()
+
+	type Request struct {
+		Tenant string `query:"tenant" validate:"tenant"`
+	}
+
+	req, err := http.NewRequest("GET", "http://example.com/?tenant=ACME", nil)
+	require.NoError(t, err)
+	_, err = parser.ParseFrozen[Request](req)
+	var validationErr *parser.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.ErrorIs(t, err, parser.ErrInvalidTenantID)
+}
@@ -0,0 +1,12 @@
+package pave
+
+import "github.com/SimonDaKappa/go-pave/validate"
+
+// installTagValidator installs the validator of the validate package in the
+// parser package. The generated functions calling those of the parser
+// package call it first (see internal/genapi), so that parses through the
+// pave package check validate tags without the validate package installing
+// its validator when imported.
+func installTagValidator() {
+	validate.InstallTagValidator()
+}